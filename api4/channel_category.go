@@ -5,6 +5,7 @@ package api4
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/mattermost/mattermost-server/v6/audit"
@@ -209,6 +210,98 @@ func updateCategoriesForTeamForUser(c *Context, w http.ResponseWriter, r *http.R
 	w.Write(categoriesJSON)
 }
 
+func updateCategoriesAndOrderForTeamForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateCategoriesAndOrderForTeamForUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	var categories *model.OrderedSidebarCategories
+	if err := json.NewDecoder(r.Body).Decode(&categories); err != nil || categories == nil {
+		c.SetInvalidParam("category")
+		return
+	}
+
+	if len(categories.Categories) != len(categories.Order) {
+		c.SetInvalidParam("order")
+		return
+	}
+
+	inOrder := make(map[string]bool, len(categories.Order))
+	for _, categoryId := range categories.Order {
+		inOrder[categoryId] = true
+	}
+
+	for _, category := range categories.Categories {
+		if !inOrder[category.Id] {
+			c.SetInvalidParam("order")
+			return
+		}
+		delete(inOrder, category.Id)
+
+		if !c.App.SessionHasPermissionToCategory(*c.AppContext.Session(), c.Params.UserId, c.Params.TeamId, category.Id) {
+			c.SetInvalidParam("category")
+			return
+		}
+	}
+
+	channels, appErr := c.App.GetChannelsForTeamForUser(c.Params.TeamId, c.Params.UserId, &model.ChannelSearchOpts{
+		IncludeDeleted: true,
+		LastDeleteAt:   0,
+	})
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	for _, category := range categories.Categories {
+		if invalid := invalidSidebarCategoryChannels(category.Channels, channels); len(invalid) > 0 {
+			c.Err = model.NewAppError("updateCategoriesAndOrderForTeamForUser", "api.channel.update_sidebar_categories.invalid_channel.app_error", nil, fmt.Sprintf("category_id=%s channel_ids=%v", category.Id, invalid), http.StatusBadRequest)
+			return
+		}
+	}
+
+	updated, appErr := c.App.UpdateSidebarCategoriesAndOrder(c.Params.UserId, c.Params.TeamId, categories)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	categoriesJSON, jsonErr := json.Marshal(updated)
+	if jsonErr != nil {
+		c.Err = model.NewAppError("updateCategoriesAndOrderForTeamForUser", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	auditRec.Success()
+	w.Write(categoriesJSON)
+}
+
+// invalidSidebarCategoryChannels returns the IDs of channelIds that aren't among the user's channels.
+func invalidSidebarCategoryChannels(channelIds []string, channels model.ChannelList) []string {
+	memberOf := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		memberOf[channel.Id] = true
+	}
+
+	var invalid []string
+	for _, channelId := range channelIds {
+		if !memberOf[channelId] {
+			invalid = append(invalid, channelId)
+		}
+	}
+
+	return invalid
+}
+
 func validateSidebarCategory(c *Context, teamId, userId string, category *model.SidebarCategoryWithChannels) *model.AppError {
 	channels, err := c.App.GetChannelsForTeamForUser(teamId, userId, &model.ChannelSearchOpts{
 		IncludeDeleted: true,
@@ -546,6 +546,130 @@ func TestUpdateCategoriesForTeamForUser(t *testing.T) {
 	})
 }
 
+func TestUpdateCategoriesAndOrderForTeamForUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("should reorder categories and move channels in a single request", func(t *testing.T) {
+		user, client := setupUserForSubtest(t, th)
+
+		categories, _, err := client.GetSidebarCategoriesForTeamForUser(user.Id, th.BasicTeam.Id, "")
+		require.NoError(t, err)
+		require.Len(t, categories.Categories, 3)
+		require.Len(t, categories.Order, 3)
+
+		favoritesCategory := categories.Categories[0]
+		require.Equal(t, model.SidebarCategoryFavorites, favoritesCategory.Type)
+		channelsCategory := categories.Categories[1]
+		require.Equal(t, model.SidebarCategoryChannels, channelsCategory.Type)
+		require.Contains(t, channelsCategory.Channels, th.BasicChannel.Id)
+
+		// Move BasicChannel into favorites and put favorites after channels
+		favoritesCategory.Channels = []string{th.BasicChannel.Id}
+		var remaining []string
+		for _, channelID := range channelsCategory.Channels {
+			if channelID != th.BasicChannel.Id {
+				remaining = append(remaining, channelID)
+			}
+		}
+		channelsCategory.Channels = remaining
+		categories.Order = []string{categories.Order[1], categories.Order[0], categories.Order[2]}
+
+		received, _, err := client.UpdateSidebarCategoriesAndOrderForTeamForUser(user.Id, th.BasicTeam.Id, categories)
+		require.NoError(t, err)
+		assert.Equal(t, categories.Order, received.Order)
+
+		updated, _, err := client.GetSidebarCategoriesForTeamForUser(user.Id, th.BasicTeam.Id, "")
+		require.NoError(t, err)
+		require.Len(t, updated.Categories, 3)
+		assert.Equal(t, categories.Order, updated.Order)
+
+		assert.Equal(t, model.SidebarCategoryChannels, updated.Categories[0].Type)
+		assert.NotContains(t, updated.Categories[0].Channels, th.BasicChannel.Id)
+		assert.Equal(t, model.SidebarCategoryFavorites, updated.Categories[1].Type)
+		assert.Equal(t, []string{th.BasicChannel.Id}, updated.Categories[1].Channels)
+	})
+
+	t.Run("should reject a channel that the user isn't a member of without changing anything", func(t *testing.T) {
+		user, client := setupUserForSubtest(t, th)
+
+		categories, _, err := client.GetSidebarCategoriesForTeamForUser(user.Id, th.BasicTeam.Id, "")
+		require.NoError(t, err)
+		require.Len(t, categories.Categories, 3)
+
+		channel, _, err := th.SystemAdminClient.CreateChannel(&model.Channel{
+			TeamId: th.BasicTeam.Id,
+			Type:   model.ChannelTypeOpen,
+			Name:   "testchannel" + model.NewId()[:8],
+		})
+		require.NoError(t, err)
+
+		originalOrder := append([]string{}, categories.Order...)
+		categories.Categories[1].Channels = append(categories.Categories[1].Channels, channel.Id)
+		categories.Order = []string{categories.Order[2], categories.Order[1], categories.Order[0]}
+
+		_, resp, err := client.UpdateSidebarCategoriesAndOrderForTeamForUser(user.Id, th.BasicTeam.Id, categories)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "api.channel.update_sidebar_categories.invalid_channel.app_error")
+
+		updated, _, err := client.GetSidebarCategoriesForTeamForUser(user.Id, th.BasicTeam.Id, "")
+		require.NoError(t, err)
+		assert.Equal(t, originalOrder, []string(updated.Order))
+		assert.NotContains(t, updated.Categories[1].Channels, channel.Id)
+	})
+
+	t.Run("should reject an invalid channel ID", func(t *testing.T) {
+		user, client := setupUserForSubtest(t, th)
+
+		categories, _, err := client.GetSidebarCategoriesForTeamForUser(user.Id, th.BasicTeam.Id, "")
+		require.NoError(t, err)
+
+		categories.Categories[1].Channels = append(categories.Categories[1].Channels, "notachannel")
+
+		_, resp, err := client.UpdateSidebarCategoriesAndOrderForTeamForUser(user.Id, th.BasicTeam.Id, categories)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should reject an incomplete structure", func(t *testing.T) {
+		user, client := setupUserForSubtest(t, th)
+
+		categories, _, err := client.GetSidebarCategoriesForTeamForUser(user.Id, th.BasicTeam.Id, "")
+		require.NoError(t, err)
+
+		// Missing from both the categories and the order
+		missing := &model.OrderedSidebarCategories{
+			Categories: categories.Categories[:2],
+			Order:      categories.Order[:2],
+		}
+		_, resp, err := client.UpdateSidebarCategoriesAndOrderForTeamForUser(user.Id, th.BasicTeam.Id, missing)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		// Categories and order not matching
+		mismatched := &model.OrderedSidebarCategories{
+			Categories: categories.Categories[:2],
+			Order:      categories.Order[1:],
+		}
+		_, resp, err = client.UpdateSidebarCategoriesAndOrderForTeamForUser(user.Id, th.BasicTeam.Id, mismatched)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should not update another user's categories", func(t *testing.T) {
+		user, _ := setupUserForSubtest(t, th)
+		_, client := setupUserForSubtest(t, th)
+
+		categories, _, err := th.SystemAdminClient.GetSidebarCategoriesForTeamForUser(user.Id, th.BasicTeam.Id, "")
+		require.NoError(t, err)
+
+		_, resp, err := client.UpdateSidebarCategoriesAndOrderForTeamForUser(user.Id, th.BasicTeam.Id, categories)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func setupUserForSubtest(t *testing.T, th *TestHelper) (*model.User, *model.Client4) {
 	password := "password"
 	user, appErr := th.App.CreateUser(th.Context, &model.User{
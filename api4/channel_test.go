@@ -1104,6 +1104,86 @@ func TestGetChannelsForUser(t *testing.T) {
 	assert.Len(t, channels, 100)
 }
 
+func TestSearchChannelMembersForUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.BasicUser2
+	prefix := "search" + model.NewId()
+
+	team2 := th.CreateTeam()
+	th.LinkUserToTeam(user, team2)
+
+	createChannel := func(teamId, displayName string, channelType model.ChannelType) *model.Channel {
+		channel, _, err := th.SystemAdminClient.CreateChannel(&model.Channel{
+			DisplayName: displayName,
+			Name:        GenerateTestChannelName(),
+			Type:        channelType,
+			TeamId:      teamId,
+		})
+		require.NoError(t, err)
+		th.AddUserToChannel(user, channel)
+		return channel
+	}
+
+	c1 := createChannel(th.BasicTeam.Id, prefix+" a", model.ChannelTypeOpen)
+	c2 := createChannel(team2.Id, prefix+" b", model.ChannelTypePrivate)
+	c3 := createChannel(team2.Id, prefix+" c", model.ChannelTypeOpen)
+
+	channelIds := func(members model.ChannelMembersWithTeamData) []string {
+		ids := make([]string, 0, len(members))
+		for _, member := range members {
+			ids = append(ids, member.ChannelId)
+		}
+		return ids
+	}
+
+	t.Run("requires permission", func(t *testing.T) {
+		_, resp, err := th.Client.SearchChannelMembersForUser(user.Id, prefix, 0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("system admin sees private channels across teams", func(t *testing.T) {
+		members, resp, err := th.SystemAdminClient.SearchChannelMembersForUser(user.Id, prefix, 0, 10)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Equal(t, []string{c1.Id, c2.Id, c3.Id}, channelIds(members))
+		assert.Equal(t, th.BasicTeam.Name, members[0].TeamName)
+		assert.Equal(t, team2.DisplayName, members[1].TeamDisplayName)
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		members, _, err := th.SystemAdminClient.SearchChannelMembersForUser(user.Id, prefix, 0, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{c1.Id, c2.Id}, channelIds(members))
+
+		members, _, err = th.SystemAdminClient.SearchChannelMembersForUser(user.Id, prefix, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{c3.Id}, channelIds(members))
+
+		members, _, err = th.SystemAdminClient.SearchChannelMembersForUser(user.Id, prefix, 2, 2)
+		require.NoError(t, err)
+		assert.Empty(t, members)
+	})
+
+	t.Run("private channels require permission to read channels", func(t *testing.T) {
+		th.AddPermissionToRole(model.PermissionSysconsoleReadUserManagementUsers.Id, model.SystemUserRoleId)
+		defer th.RemovePermissionFromRole(model.PermissionSysconsoleReadUserManagementUsers.Id, model.SystemUserRoleId)
+
+		members, resp, err := th.Client.SearchChannelMembersForUser(user.Id, prefix, 0, 10)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		assert.Equal(t, []string{c1.Id, c3.Id}, channelIds(members))
+	})
+
+	t.Run("without search keeps returning channels", func(t *testing.T) {
+		channels, _, err := th.SystemAdminClient.GetChannelsForUserWithLastDeleteAt(user.Id, 0)
+		require.NoError(t, err)
+		assert.NotEmpty(t, channels)
+	})
+}
+
 func TestGetAllChannels(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -2272,6 +2352,87 @@ func TestGetChannelMembers(t *testing.T) {
 	CheckForbiddenStatus(t, resp)
 }
 
+func TestGetChannelMembersSince(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	channel := th.CreatePublicChannel()
+	th.AddUserToChannel(th.BasicUser2, channel)
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+
+	time.Sleep(2 * time.Millisecond)
+	since := model.GetMillis()
+
+	_, _, err := client.AddChannelMember(channel.Id, user.Id)
+	require.NoError(t, err)
+	_, err = client.RemoveUserFromChannel(channel.Id, th.BasicUser2.Id)
+	require.NoError(t, err)
+
+	delta, _, err := client.GetChannelMembersSince(channel.Id, since, 0, 60)
+	require.NoError(t, err)
+
+	memberIds := []string{}
+	for _, member := range delta.Members {
+		memberIds = append(memberIds, member.UserId)
+	}
+	assert.Contains(t, memberIds, user.Id)
+	assert.NotContains(t, memberIds, th.BasicUser2.Id)
+
+	require.Len(t, delta.Removed, 1)
+	assert.Equal(t, channel.Id, delta.Removed[0].ChannelId)
+	assert.Equal(t, th.BasicUser2.Id, delta.Removed[0].UserId)
+	assert.GreaterOrEqual(t, delta.Removed[0].DeleteAt, since)
+
+	t.Run("rejoining removes the tombstone", func(t *testing.T) {
+		_, _, err := client.AddChannelMember(channel.Id, th.BasicUser2.Id)
+		require.NoError(t, err)
+
+		delta, _, err := client.GetChannelMembersSince(channel.Id, since, 0, 60)
+		require.NoError(t, err)
+		assert.Empty(t, delta.Removed)
+
+		memberIds := []string{}
+		for _, member := range delta.Members {
+			memberIds = append(memberIds, member.UserId)
+		}
+		assert.Contains(t, memberIds, th.BasicUser2.Id)
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		delta, _, err := client.GetChannelMembersSince(channel.Id, since, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, delta.Members, 1)
+
+		next, _, err := client.GetChannelMembersSince(channel.Id, since, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, next.Members, 1)
+		assert.NotEqual(t, delta.Members[0].UserId, next.Members[0].UserId)
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		r, err := client.DoAPIGet("/channels/"+channel.Id+"/members?since=junk", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, model.BuildResponse(r))
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		other := th.CreateUser()
+		th.LinkUserToTeam(other, th.BasicTeam)
+		privateChannel := th.CreatePrivateChannel()
+
+		otherClient := th.CreateClient()
+		_, _, err := otherClient.Login(other.Email, other.Password)
+		require.NoError(t, err)
+
+		_, resp, err := otherClient.GetChannelMembersSince(privateChannel.Id, since, 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestGetChannelMembersByIds(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -4158,6 +4319,42 @@ func TestGetChannelModerations(t *testing.T) {
 	})
 }
 
+func TestPatchChannelRestrictions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreatePublicChannel()
+	th.AddUserToChannel(th.BasicUser2, channel)
+
+	memberClient := th.CreateClient()
+	_, _, err := memberClient.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+	require.NoError(t, err)
+
+	t.Run("a member can patch the header", func(t *testing.T) {
+		_, _, err := memberClient.PatchChannel(channel.Id, &model.ChannelPatch{Header: model.NewString("header")})
+		require.NoError(t, err)
+	})
+
+	for name, patch := range map[string]*model.ChannelPatch{
+		"slow mode":               {SlowModeSeconds: model.NewInt(0)},
+		"default notify level":    {DefaultNotifyLevel: model.NewString(model.ChannelNotifyAll)},
+		"allowed reactions":       {AllowedReactions: &model.StringArray{}},
+		"allowed file extensions": {AllowedFileExtensions: &model.StringArray{}},
+		"blocked file extensions": {BlockedFileExtensions: &model.StringArray{}},
+	} {
+		t.Run("a member can't patch the "+name, func(t *testing.T) {
+			_, resp, err := memberClient.PatchChannel(channel.Id, patch)
+			require.Error(t, err)
+			CheckForbiddenStatus(t, resp)
+		})
+
+		t.Run("an admin can patch the "+name, func(t *testing.T) {
+			_, _, err := th.SystemAdminClient.PatchChannel(channel.Id, patch)
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestPatchChannelModerations(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -4535,6 +4732,39 @@ func TestMoveChannel(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, team2.Id, newChannel.TeamId)
 	}, "Should be able to (force) move private channel by a member that is not member of target team")
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		publicChannel := th.CreatePublicChannel()
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, team1)
+		th.AddUserToChannel(user, publicChannel)
+
+		stayingUser := th.CreateUser()
+		th.LinkUserToTeam(stayingUser, team1)
+		th.LinkUserToTeam(stayingUser, team2)
+		th.AddUserToChannel(stayingUser, publicChannel)
+
+		newChannel, _, err := client.MoveChannel(publicChannel.Id, team2.Id, true)
+		require.NoError(t, err)
+		require.Equal(t, team2.Id, newChannel.TeamId)
+
+		_, appErr := th.App.GetChannelMember(context.Background(), publicChannel.Id, user.Id)
+		require.NotNil(t, appErr)
+		_, appErr = th.App.GetChannelMember(context.Background(), publicChannel.Id, stayingUser.Id)
+		require.Nil(t, appErr)
+
+		systemBot, appErr := th.App.GetSystemBot()
+		require.Nil(t, appErr)
+		dm, appErr := th.App.GetOrCreateDirectChannel(th.Context, systemBot.UserId, user.Id)
+		require.Nil(t, appErr)
+		posts, appErr := th.App.GetPosts(dm.Id, 0, 1)
+		require.Nil(t, appErr)
+		require.Len(t, posts.Order, 1)
+		post := posts.Posts[posts.Order[0]]
+		assert.Equal(t, systemBot.UserId, post.UserId)
+		assert.Contains(t, post.Message, publicChannel.DisplayName)
+		assert.Contains(t, post.Message, team2.DisplayName)
+	}, "Should remove and notify members that are not members of the target team when forced")
 }
 
 func TestRootMentionsCount(t *testing.T) {
@@ -18,6 +18,7 @@ import (
 
 const (
 	EmojiMaxAutocompleteItems = 100
+	GetEmojisByNamesMax       = 200
 )
 
 func (api *API) InitEmoji() {
@@ -25,6 +26,7 @@ func (api *API) InitEmoji() {
 	api.BaseRoutes.Emojis.Handle("", api.APISessionRequired(getEmojiList)).Methods("GET")
 	api.BaseRoutes.Emojis.Handle("/search", api.APISessionRequired(searchEmojis)).Methods("POST")
 	api.BaseRoutes.Emojis.Handle("/autocomplete", api.APISessionRequired(autocompleteEmojis)).Methods("GET")
+	api.BaseRoutes.Emojis.Handle("/names", api.APISessionRequired(getEmojisByNames)).Methods("POST")
 	api.BaseRoutes.Emoji.Handle("", api.APISessionRequired(deleteEmoji)).Methods("DELETE")
 	api.BaseRoutes.Emoji.Handle("", api.APISessionRequired(getEmoji)).Methods("GET")
 	api.BaseRoutes.EmojiByName.Handle("", api.APISessionRequired(getEmojiByName)).Methods("GET")
@@ -231,6 +233,29 @@ func getEmojiByName(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getEmojisByNames(c *Context, w http.ResponseWriter, r *http.Request) {
+	names := model.ArrayFromJSON(r.Body)
+	if len(names) == 0 {
+		c.SetInvalidParam("names")
+		return
+	}
+
+	if len(names) > GetEmojisByNamesMax {
+		c.Err = model.NewAppError("getEmojisByNames", "api.emoji.get_multiple_by_name_too_many.request_error", map[string]interface{}{"MaxNames": GetEmojisByNamesMax}, "", http.StatusBadRequest)
+		return
+	}
+
+	emojis, err := c.App.GetMultipleEmojiByName(names)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(emojis); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func getEmojiImage(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireEmojiId()
 	if c.Err != nil {
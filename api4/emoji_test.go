@@ -485,6 +485,76 @@ func TestGetEmojiByName(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestGetEmojisByNames(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	emoji1, _, err := client.CreateEmoji(&model.Emoji{
+		CreatorId: th.BasicUser.Id,
+		Name:      model.NewId(),
+	}, utils.CreateTestGif(t, 10, 10), "image.gif")
+	require.NoError(t, err)
+
+	emoji2, _, err := client.CreateEmoji(&model.Emoji{
+		CreatorId: th.BasicUser.Id,
+		Name:      model.NewId(),
+	}, utils.CreateTestGif(t, 10, 10), "image.gif")
+	require.NoError(t, err)
+
+	t.Run("known and unknown names", func(t *testing.T) {
+		emojis, _, err := client.GetEmojisByNames([]string{emoji1.Name, model.NewId(), emoji2.Name, "smile"})
+		require.NoError(t, err)
+		require.Len(t, emojis, 2)
+
+		names := []string{emojis[0].Name, emojis[1].Name}
+		assert.ElementsMatch(t, []string{emoji1.Name, emoji2.Name}, names)
+	})
+
+	t.Run("only unknown names", func(t *testing.T) {
+		emojis, _, err := client.GetEmojisByNames([]string{model.NewId(), model.NewId()})
+		require.NoError(t, err)
+		assert.Empty(t, emojis)
+	})
+
+	t.Run("no names", func(t *testing.T) {
+		_, resp, err := client.GetEmojisByNames([]string{})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("too many names", func(t *testing.T) {
+		names := make([]string, GetEmojisByNamesMax+1)
+		for i := range names {
+			names[i] = model.NewId()
+		}
+
+		_, resp, err := client.GetEmojisByNames(names)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("custom emoji disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+		_, resp, err := client.GetEmojisByNames([]string{emoji1.Name})
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("logged out", func(t *testing.T) {
+		client.Logout()
+		defer th.LoginBasic()
+
+		_, resp, err := client.GetEmojisByNames([]string{emoji1.Name})
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
 func TestGetEmojiImage(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -62,6 +62,7 @@ func (api *API) InitFile() {
 	api.BaseRoutes.File.Handle("/link", api.APISessionRequired(getFileLink)).Methods("GET")
 	api.BaseRoutes.File.Handle("/preview", api.APISessionRequiredTrustRequester(getFilePreview)).Methods("GET")
 	api.BaseRoutes.File.Handle("/info", api.APISessionRequired(getFileInfo)).Methods("GET")
+	api.BaseRoutes.File.Handle("/quarantine", api.APISessionRequired(clearFileQuarantine)).Methods("DELETE")
 
 	api.BaseRoutes.Team.Handle("/files/search", api.APISessionRequiredDisableWhenBusy(searchFilesInTeam)).Methods("POST")
 
@@ -491,6 +492,11 @@ func getFile(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if info.Quarantined {
+		c.Err = model.NewAppError("getFile", "api.file.get_file.quarantined.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		return
+	}
+
 	fileReader, err := c.App.FileReader(info.Path)
 	if err != nil {
 		c.Err = err
@@ -522,6 +528,11 @@ func getFileThumbnail(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if info.Quarantined {
+		c.Err = model.NewAppError("getFileThumbnail", "api.file.get_file.quarantined.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		return
+	}
+
 	if info.ThumbnailPath == "" {
 		c.Err = model.NewAppError("getFileThumbnail", "api.file.get_file_thumbnail.no_thumbnail.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
 		return
@@ -564,6 +575,11 @@ func getFileLink(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if info.Quarantined {
+		c.Err = model.NewAppError("getPublicLink", "api.file.get_file.quarantined.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		return
+	}
+
 	if info.PostId == "" {
 		c.Err = model.NewAppError("getPublicLink", "api.file.get_public_link.no_post.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
 		return
@@ -597,6 +613,11 @@ func getFilePreview(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if info.Quarantined {
+		c.Err = model.NewAppError("getFilePreview", "api.file.get_file.quarantined.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		return
+	}
+
 	if info.PreviewPath == "" {
 		c.Err = model.NewAppError("getFilePreview", "api.file.get_file_preview.no_preview.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
 		return
@@ -636,6 +657,31 @@ func getFileInfo(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func clearFileQuarantine(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireFileId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("clearFileQuarantine", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("file_id", c.Params.FileId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	if err := c.App.ClearFileQuarantine(c.Params.FileId); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
 func getPublicFile(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireFileId()
 	if c.Err != nil {
@@ -667,6 +713,12 @@ func getPublicFile(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if info.Quarantined {
+		c.Err = model.NewAppError("getPublicFile", "api.file.get_file.quarantined.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		utils.RenderWebAppError(c.App.Config(), w, r, c.Err, c.App.AsymmetricSigningKey())
+		return
+	}
+
 	fileReader, err := c.App.FileReader(info.Path)
 	if err != nil {
 		c.Err = err
@@ -765,6 +765,52 @@ func TestGetFile(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetFileQuarantined(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	if *th.App.Config().FileSettings.DriverName == "" {
+		t.Skip("skipping because no file driver is enabled")
+	}
+
+	sent, err := testutils.ReadTestFile("test.png")
+	require.NoError(t, err)
+
+	fileResp, _, err := client.UploadFile(sent, th.BasicChannel.Id, "test.png")
+	require.NoError(t, err)
+	fileId := fileResp.FileInfos[0].Id
+
+	require.NoError(t, th.App.Srv().Store.FileInfo().SetQuarantined(fileId, true))
+
+	_, resp, err := client.GetFile(fileId)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = client.GetFileThumbnail(fileId)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = client.GetFilePreview(fileId)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	info, _, err := client.GetFileInfo(fileId)
+	require.NoError(t, err)
+	require.True(t, info.Quarantined)
+
+	resp, err = client.ClearFileQuarantine(fileId)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, err = th.SystemAdminClient.ClearFileQuarantine(fileId)
+	require.NoError(t, err)
+
+	data, _, err := client.GetFile(fileId)
+	require.NoError(t, err)
+	require.Equal(t, sent, data)
+}
+
 func TestGetFileHeaders(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
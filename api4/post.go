@@ -45,8 +45,9 @@ func createPost(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Strip away delete_at if passed
+	// Strip away delete_at and any props reserved for the server if passed
 	post.DeleteAt = 0
+	post.SanitizeProps()
 
 	post.UserId = c.AppContext.Session().UserId
 
@@ -244,6 +245,11 @@ func getPostsForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.Err = err
 		return
 	}
+	if err = c.App.RemoveIgnoredUsersPosts(clientPostList, c.AppContext.Session().UserId); err != nil {
+		c.Err = err
+		return
+	}
+	c.App.LocalizePostListForUser(clientPostList, c.AppContext.Session().UserId)
 
 	if err := clientPostList.EncodeJSON(w); err != nil {
 		mlog.Warn("Error while writing response", mlog.Err(err))
@@ -307,6 +313,11 @@ func getPostsForChannelAroundLastUnread(c *Context, w http.ResponseWriter, r *ht
 		c.Err = err
 		return
 	}
+	if err = c.App.RemoveIgnoredUsersPosts(clientPostList, c.AppContext.Session().UserId); err != nil {
+		c.Err = err
+		return
+	}
+	c.App.LocalizePostListForUser(clientPostList, c.AppContext.Session().UserId)
 
 	if etag != "" {
 		w.Header().Set(model.HeaderEtagServer, etag)
@@ -376,6 +387,7 @@ func getFlaggedPostsForUser(c *Context, w http.ResponseWriter, r *http.Request)
 		c.Err = err
 		return
 	}
+	c.App.LocalizePostListForUser(clientPostList, c.AppContext.Session().UserId)
 	if err := clientPostList.EncodeJSON(w); err != nil {
 		mlog.Warn("Error while writing response", mlog.Err(err))
 	}
@@ -405,6 +417,7 @@ func getPost(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.Err = err
 		return
 	}
+	c.App.LocalizePostForUser(post, c.AppContext.Session().UserId)
 
 	if c.HandleEtag(post.Etag(), "Get Post", w, r) {
 		return
@@ -459,6 +472,7 @@ func getPostsByIds(c *Context, w http.ResponseWriter, r *http.Request) {
 
 		post = c.App.PreparePostForClient(post, false, false)
 		post.StripActionIntegrations()
+		c.App.LocalizePostForUser(post, c.AppContext.Session().UserId)
 		posts = append(posts, post)
 	}
 
@@ -584,6 +598,7 @@ func getPostThread(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.Err = err
 		return
 	}
+	c.App.LocalizePostListForUser(clientPostList, c.AppContext.Session().UserId)
 
 	w.Header().Set(model.HeaderEtagServer, clientPostList.Etag())
 
@@ -679,6 +694,7 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request, teamId stri
 		c.Err = err
 		return
 	}
+	c.App.LocalizePostListForUser(clientPostList, c.AppContext.Session().UserId)
 
 	results = model.MakePostSearchResults(clientPostList, results.Matches)
 
@@ -847,10 +863,12 @@ func saveIsPinnedPost(c *Context, w http.ResponseWriter, isPinned bool) {
 	}
 	auditRec.AddMeta("post", post)
 
-	patch := &model.PostPatch{}
-	patch.IsPinned = model.NewBool(isPinned)
-
-	patchedPost, err := c.App.PatchPost(c.AppContext, c.Params.PostId, patch)
+	var patchedPost *model.Post
+	if isPinned {
+		patchedPost, err = c.App.PinPost(c.AppContext, c.Params.PostId, c.AppContext.Session().UserId)
+	} else {
+		patchedPost, err = c.App.UnpinPost(c.AppContext, c.Params.PostId)
+	}
 	if err != nil {
 		c.Err = err
 		return
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitPostReminder() {
+	api.BaseRoutes.PostForUser.Handle("/reminder", api.APISessionRequired(setPostReminder)).Methods("POST")
+	api.BaseRoutes.PostForUser.Handle("/reminder", api.APISessionRequired(clearPostReminder)).Methods("DELETE")
+	api.BaseRoutes.User.Handle("/post_reminders", api.APISessionRequired(getPostReminders)).Methods("GET")
+}
+
+func setPostReminder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId().RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var props struct {
+		RemindAt int64 `json:"remind_at"`
+	}
+	if jsonErr := json.NewDecoder(r.Body).Decode(&props); jsonErr != nil || props.RemindAt == 0 {
+		c.SetInvalidParam("remind_at")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("setPostReminder", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("post_id", c.Params.PostId)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if c.AppContext.Session().UserId != c.Params.UserId && !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannel) {
+		c.SetPermissionError(model.PermissionReadChannel)
+		return
+	}
+
+	reminder, err := c.App.SetPostReminder(c.Params.UserId, c.Params.PostId, props.RemindAt)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(reminder); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func clearPostReminder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId().RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("clearPostReminder", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("post_id", c.Params.PostId)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if c.AppContext.Session().UserId != c.Params.UserId && !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	if err := c.App.ClearPostReminder(c.Params.UserId, c.Params.PostId); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+func getPostReminders(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.AppContext.Session().UserId != c.Params.UserId && !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	reminders, err := c.App.GetPostReminders(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(reminders); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestPostReminders(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	remindAt := model.GetMillis() + 60*60*1000
+
+	reminder, resp, err := client.SetPostReminder(th.BasicUser.Id, th.BasicPost.Id, remindAt)
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+	require.Equal(t, th.BasicPost.Id, reminder.PostId)
+	require.Equal(t, th.BasicUser.Id, reminder.UserId)
+	require.Equal(t, remindAt, reminder.RemindAt)
+
+	reminders, resp, err := client.GetPostReminders(th.BasicUser.Id)
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+	require.Len(t, reminders, 1)
+	require.Equal(t, th.BasicPost.Id, reminders[0].PostId)
+
+	t.Run("time in the past", func(t *testing.T) {
+		_, resp, err := client.SetPostReminder(th.BasicUser.Id, th.BasicPost.Id, model.GetMillis()-1000)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "app.post_reminder.remind_at.app_error")
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		_, resp, err := client.SetPostReminder(th.BasicUser2.Id, th.BasicPost.Id, remindAt)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.GetPostReminders(th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = client.ClearPostReminder(th.BasicUser2.Id, th.BasicPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("post in a channel the user cannot read", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel()
+		post := th.CreatePostWithClient(th.Client, privateChannel)
+		_, err := th.Client.RemoveUserFromChannel(privateChannel.Id, th.BasicUser.Id)
+		require.NoError(t, err)
+
+		_, resp, err := client.SetPostReminder(th.BasicUser.Id, post.Id, remindAt)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		resp, err := client.ClearPostReminder(th.BasicUser.Id, th.BasicPost.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		reminders, _, err := client.GetPostReminders(th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Empty(t, reminders)
+
+		resp, err = client.ClearPostReminder(th.BasicUser.Id, th.BasicPost.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		client.Logout()
+		defer th.LoginBasic()
+
+		_, resp, err := client.SetPostReminder(th.BasicUser.Id, th.BasicPost.Id, remindAt)
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
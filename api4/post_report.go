@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app"
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitPostReport() {
+	api.BaseRoutes.Post.Handle("/report", api.APISessionRequired(reportPost)).Methods("POST")
+	api.BaseRoutes.PostReports.Handle("", api.APISessionRequired(getPostReports)).Methods("GET")
+	api.BaseRoutes.PostReports.Handle("/{report_id:[A-Za-z0-9]+}/status", api.APISessionRequired(updatePostReportStatus)).Methods("PUT")
+}
+
+func reportPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.MapFromJSON(r.Body)
+
+	auditRec := c.MakeAuditRecord("reportPost", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	auditRec.AddMeta("post_id", c.Params.PostId)
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannel) {
+		c.SetPermissionError(model.PermissionReadChannel)
+		return
+	}
+
+	report, err := c.App.ReportPost(c.AppContext, c.AppContext.Session().UserId, c.Params.PostId, props["reason"])
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("report_id", report.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getPostReports(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	reports, err := c.App.GetPostReports(model.PostReportGetOptions{
+		Status:  r.URL.Query().Get("status"),
+		Page:    c.Params.Page,
+		PerPage: c.Params.PerPage,
+	})
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(reports); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updatePostReportStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReportId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.MapFromJSON(r.Body)
+	status, ok := props["status"]
+	if !ok {
+		c.SetInvalidParam("status")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updatePostReportStatus", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("report_id", c.Params.ReportId)
+	auditRec.AddMeta("status", status)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	report, err := c.App.UpdatePostReportStatus(c.Params.ReportId, status)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
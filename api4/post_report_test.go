@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestReportPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	report, resp, err := client.ReportPost(th.BasicPost.Id, "spam")
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	require.Equal(t, th.BasicPost.Id, report.PostId)
+	require.Equal(t, th.BasicChannel.Id, report.ChannelId)
+	require.Equal(t, th.BasicUser.Id, report.ReporterId)
+	require.Equal(t, "spam", report.Reason)
+	require.Equal(t, model.PostReportStatusOpen, report.Status)
+
+	t.Run("duplicate report", func(t *testing.T) {
+		_, resp, err := client.ReportPost(th.BasicPost.Id, "harassment")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "app.post_report.save.exists.app_error")
+	})
+
+	t.Run("invalid post id", func(t *testing.T) {
+		_, resp, err := client.ReportPost("junk", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("post in a channel the user cannot read", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel()
+		post := th.CreatePostWithClient(th.Client, privateChannel)
+		_, err := th.Client.RemoveUserFromChannel(privateChannel.Id, th.BasicUser.Id)
+		require.NoError(t, err)
+
+		_, resp, err := client.ReportPost(post.Id, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		client.Logout()
+		defer th.LoginBasic()
+
+		_, resp, err := client.ReportPost(th.BasicPost.Id, "")
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
+func TestGetPostReports(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	report1, _, err := th.Client.ReportPost(th.BasicPost.Id, "spam")
+	require.NoError(t, err)
+
+	th.LoginBasic2()
+	report2, _, err := th.Client.ReportPost(th.BasicPost.Id, "harassment")
+	require.NoError(t, err)
+
+	// Other tests may have reported posts too, so only the reports on this test's post are considered.
+	reportsForPost := func(all []*model.PostReportWithContext) []*model.PostReportWithContext {
+		reports := []*model.PostReportWithContext{}
+		for _, report := range all {
+			if report.PostId == th.BasicPost.Id {
+				reports = append(reports, report)
+			}
+		}
+		return reports
+	}
+
+	t.Run("requires system admin", func(t *testing.T) {
+		_, resp, err := th.Client.GetPostReports("", 0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.UpdatePostReportStatus(report1.Id, model.PostReportStatusResolved)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("list includes reporter and post", func(t *testing.T) {
+		all, _, err := th.SystemAdminClient.GetPostReports("", 0, 200)
+		require.NoError(t, err)
+		reports := reportsForPost(all)
+		require.Len(t, reports, 2)
+
+		require.Equal(t, report2.Id, reports[0].Id)
+		require.Equal(t, "harassment", reports[0].Reason)
+		require.Equal(t, th.BasicUser2.Id, reports[0].Reporter.Id)
+		require.Empty(t, reports[0].Reporter.Password)
+		require.Equal(t, th.BasicPost.Id, reports[0].Post.Id)
+		require.Equal(t, th.BasicPost.Message, reports[0].Post.Message)
+
+		require.Equal(t, report1.Id, reports[1].Id)
+		require.Equal(t, th.BasicUser.Id, reports[1].Reporter.Id)
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		page1, _, err := th.SystemAdminClient.GetPostReports("", 0, 1)
+		require.NoError(t, err)
+		require.Len(t, page1, 1)
+
+		page2, _, err := th.SystemAdminClient.GetPostReports("", 1, 1)
+		require.NoError(t, err)
+		require.Len(t, page2, 1)
+		require.NotEqual(t, page1[0].Id, page2[0].Id)
+	})
+
+	t.Run("filtered by status", func(t *testing.T) {
+		updated, _, err := th.SystemAdminClient.UpdatePostReportStatus(report1.Id, model.PostReportStatusResolved)
+		require.NoError(t, err)
+		require.Equal(t, model.PostReportStatusResolved, updated.Status)
+
+		all, _, err := th.SystemAdminClient.GetPostReports(model.PostReportStatusOpen, 0, 200)
+		require.NoError(t, err)
+		reports := reportsForPost(all)
+		require.Len(t, reports, 1)
+		require.Equal(t, report2.Id, reports[0].Id)
+
+		all, _, err = th.SystemAdminClient.GetPostReports(model.PostReportStatusResolved, 0, 200)
+		require.NoError(t, err)
+		reports = reportsForPost(all)
+		require.Len(t, reports, 1)
+		require.Equal(t, report1.Id, reports[0].Id)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetPostReports("escalated", 0, 10)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.UpdatePostReportStatus(report1.Id, "escalated")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("missing report", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.UpdatePostReportStatus(model.NewId(), model.PostReportStatusResolved)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
@@ -48,6 +48,26 @@ func TestCreatePost(t *testing.T) {
 	require.Nil(t, rpost.GetProp(model.PropsAddChannelMember), "newly created post shouldn't have Props['add_channel_member'] set")
 	require.Equal(t, 0, int(rpost.DeleteAt), "newly created post shouldn't have DeleteAt set")
 
+	t.Run("reserved props are stripped", func(t *testing.T) {
+		reservedPost := &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			Message:   "reserved props",
+			Props: model.StringInterface{
+				model.PostPropsFromWebhook:     "true",
+				model.PostPropsFromPlugin:      "true",
+				model.PostPropsSystemMessageId: "api.channel.join_channel.post_and_forget",
+				"custom":                       "kept",
+			},
+		}
+
+		rpost, _, err := client.CreatePost(reservedPost)
+		require.NoError(t, err)
+		assert.Nil(t, rpost.GetProp(model.PostPropsFromWebhook))
+		assert.Nil(t, rpost.GetProp(model.PostPropsFromPlugin))
+		assert.Nil(t, rpost.GetProp(model.PostPropsSystemMessageId))
+		assert.Equal(t, "kept", rpost.GetProp("custom"))
+	})
+
 	post.RootId = rpost.Id
 	_, _, err2 = client.CreatePost(post)
 	require.NoError(t, err2)
@@ -740,6 +760,21 @@ func TestUpdatePost(t *testing.T) {
 		assert.Nil(t, actual.GetProp(model.PropsAddChannelMember), "failed to sanitize Props['add_channel_member'], should be nil")
 	})
 
+	t.Run("reserved props", func(t *testing.T) {
+		rpost.Message = "update with reserved props " + model.NewId()
+		rpost.AddProp(model.PostPropsFromWebhook, "true")
+		rpost.AddProp(model.PostPropsFromBot, "true")
+		rupost, _, err := client.UpdatePost(rpost.Id, rpost)
+		require.NoError(t, err)
+		assert.Nil(t, rupost.GetProp(model.PostPropsFromWebhook), "failed to sanitize Props['from_webhook'], should be nil")
+		assert.Nil(t, rupost.GetProp(model.PostPropsFromBot), "failed to sanitize Props['from_bot'], should be nil")
+
+		actual, _, err := client.GetPost(rpost.Id, "")
+		require.NoError(t, err)
+		assert.Nil(t, actual.GetProp(model.PostPropsFromWebhook), "failed to sanitize Props['from_webhook'], should be nil")
+		assert.Nil(t, actual.GetProp(model.PostPropsFromBot), "failed to sanitize Props['from_bot'], should be nil")
+	})
+
 	t.Run("join/leave post", func(t *testing.T) {
 		var rpost2 *model.Post
 		rpost2, appErr = th.App.CreatePost(th.Context, &model.Post{
@@ -922,6 +957,26 @@ func TestPatchPost(t *testing.T) {
 		assert.NotEqual(t, rpost.EditAt, rpost2.EditAt)
 	})
 
+	t.Run("reserved props", func(t *testing.T) {
+		patch := &model.PostPatch{}
+		patch.Props = &model.StringInterface{
+			"channel_header":           "reserved_header",
+			model.PostPropsFromWebhook: "true",
+			model.PostPropsFromPlugin:  "true",
+		}
+
+		rpost3, _, err := client.PatchPost(post.Id, patch)
+		require.NoError(t, err)
+		assert.Equal(t, "reserved_header", rpost3.GetProp("channel_header"))
+		assert.Nil(t, rpost3.GetProp(model.PostPropsFromWebhook), "failed to sanitize Props['from_webhook'], should be nil")
+		assert.Nil(t, rpost3.GetProp(model.PostPropsFromPlugin), "failed to sanitize Props['from_plugin'], should be nil")
+
+		actual, _, err := client.GetPost(post.Id, "")
+		require.NoError(t, err)
+		assert.Nil(t, actual.GetProp(model.PostPropsFromWebhook), "failed to sanitize Props['from_webhook'], should be nil")
+		assert.Nil(t, actual.GetProp(model.PostPropsFromPlugin), "failed to sanitize Props['from_plugin'], should be nil")
+	})
+
 	t.Run("invalid requests", func(t *testing.T) {
 		r, err := client.DoAPIPut("/posts/"+post.Id+"/patch", "garbage")
 		require.EqualError(t, err, ": Invalid or missing post in request body., ")
@@ -1015,6 +1070,23 @@ func TestPinPost(t *testing.T) {
 
 	_, err = th.SystemAdminClient.PinPost(post.Id)
 	require.NoError(t, err)
+
+	t.Run("pinned posts limit", func(t *testing.T) {
+		th.LoginBasic()
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxPinnedPostsPerChannel = 1 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxPinnedPostsPerChannel = 0 })
+
+		resp, err := client.PinPost(th.CreatePost().Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "app.post.pin_post.limit_reached.app_error")
+
+		_, err = client.UnpinPost(post.Id)
+		require.NoError(t, err)
+
+		_, err = client.PinPost(th.CreatePost().Id)
+		require.NoError(t, err)
+	})
 }
 
 func TestUnpinPost(t *testing.T) {
@@ -2232,6 +2304,34 @@ func TestGetPostThread(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetPostThreadWithIgnoredRoot(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+	root := th.CreateMessagePostWithClient(client2, th.BasicChannel, "root")
+	reply, _, err := th.Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "reply", RootId: root.Id})
+	require.NoError(t, err)
+
+	appErr := th.App.IgnoreUser(th.BasicUser.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+
+	t.Run("the thread keeps its root", func(t *testing.T) {
+		list, _, err := th.Client.GetPostThread(root.Id, "", false)
+		require.NoError(t, err)
+		assert.Contains(t, list.Posts, root.Id)
+		assert.Contains(t, list.Posts, reply.Id)
+	})
+
+	t.Run("the channel feed hides the thread", func(t *testing.T) {
+		list, _, err := th.Client.GetPostsForChannel(th.BasicChannel.Id, 0, 60, "", false)
+		require.NoError(t, err)
+		assert.NotContains(t, list.Posts, root.Id)
+		assert.NotContains(t, list.Posts, reply.Id)
+	})
+}
+
 func TestSearchPosts(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -272,6 +272,85 @@ func TestGetReactions(t *testing.T) {
 	})
 }
 
+func TestGetReactionDetails(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+	postId := th.BasicPost.Id
+
+	user3 := th.CreateUser()
+	createAt := model.GetMillis()
+
+	for i, reaction := range []*model.Reaction{
+		{UserId: th.BasicUser2.Id, PostId: postId, EmojiName: "smile"},
+		{UserId: th.BasicUser.Id, PostId: postId, EmojiName: "sad"},
+		{UserId: user3.Id, PostId: postId, EmojiName: "smile"},
+		{UserId: th.BasicUser.Id, PostId: postId, EmojiName: "smile"},
+	} {
+		reaction.CreateAt = createAt + int64(i)
+		_, err := th.App.Srv().Store.Reaction().Save(reaction)
+		require.NoError(t, err)
+	}
+
+	userIds := func(users []*model.ReactionUser) []string {
+		ids := make([]string, 0, len(users))
+		for _, user := range users {
+			ids = append(ids, user.UserId)
+		}
+		return ids
+	}
+
+	t.Run("ordered by reaction time", func(t *testing.T) {
+		details, _, err := client.GetReactionDetails(postId, 0, 60)
+		require.NoError(t, err)
+		require.Len(t, details, 2)
+
+		assert.Equal(t, "smile", details[0].EmojiName)
+		assert.Equal(t, int64(3), details[0].Count)
+		assert.Equal(t, []string{th.BasicUser2.Id, user3.Id, th.BasicUser.Id}, userIds(details[0].Users))
+		assert.Equal(t, createAt, details[0].Users[0].CreateAt)
+
+		assert.Equal(t, "sad", details[1].EmojiName)
+		assert.Equal(t, int64(1), details[1].Count)
+		assert.Equal(t, []string{th.BasicUser.Id}, userIds(details[1].Users))
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		details, _, err := client.GetReactionDetails(postId, 0, 2)
+		require.NoError(t, err)
+		require.Len(t, details, 2)
+		assert.Equal(t, []string{th.BasicUser2.Id, user3.Id}, userIds(details[0].Users))
+
+		details, _, err = client.GetReactionDetails(postId, 1, 2)
+		require.NoError(t, err)
+		require.Len(t, details, 2)
+		assert.Equal(t, int64(3), details[0].Count)
+		assert.Equal(t, []string{th.BasicUser.Id}, userIds(details[0].Users))
+		assert.Empty(t, details[1].Users)
+	})
+
+	t.Run("invalid post id", func(t *testing.T) {
+		_, resp, err := client.GetReactionDetails("junk", 0, 60)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("no access to the post", func(t *testing.T) {
+		_, resp, err := client.GetReactionDetails(GenerateTestId(), 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("anonymous user", func(t *testing.T) {
+		th.Client.Logout()
+		defer th.LoginBasic()
+
+		_, resp, err := client.GetReactionDetails(postId, 0, 60)
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
 func TestDeleteReaction(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
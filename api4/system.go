@@ -39,6 +39,7 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.System.Handle("/ping", api.APIHandler(getSystemPing)).Methods("GET")
 
 	api.BaseRoutes.System.Handle("/timezones", api.APISessionRequired(getSupportedTimezones)).Methods("GET")
+	api.BaseRoutes.System.Handle("/time", api.APISessionRequired(getServerTime)).Methods("GET")
 
 	api.BaseRoutes.APIRoot.Handle("/audits", api.APISessionRequired(getAudits)).Methods("GET")
 	api.BaseRoutes.APIRoot.Handle("/email/test", api.APISessionRequired(testEmail)).Methods("POST")
@@ -451,6 +452,30 @@ func getSupportedTimezones(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write(b)
 }
 
+func getServerTime(c *Context, w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	zone, offset := now.Zone()
+
+	// The location is named "Local" unless the server was given an IANA timezone through TZ.
+	if name := now.Location().String(); name != "Local" {
+		zone = name
+	}
+
+	serverTime := &model.ServerTime{
+		CurrentTime: model.GetMillisForTime(now),
+		Timezone:    zone,
+		UTCOffset:   offset,
+	}
+
+	js, err := json.Marshal(serverTime)
+	if err != nil {
+		c.Err = model.NewAppError("getServerTime", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(js)
+}
+
 func testS3(c *Context, w http.ResponseWriter, r *http.Request) {
 	cfg := model.ConfigFromJSON(r.Body)
 	if cfg == nil {
@@ -615,6 +615,35 @@ func TestSupportedTimezones(t *testing.T) {
 	assert.Equal(t, supportedTimezonesFromConfig, supportedTimezones)
 }
 
+func TestGetServerTime(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("returns the current time and timezone", func(t *testing.T) {
+		before := model.GetMillis()
+		serverTime, resp, err := th.Client.GetServerTime()
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		assert.GreaterOrEqual(t, serverTime.CurrentTime, before)
+		assert.LessOrEqual(t, serverTime.CurrentTime, model.GetMillis())
+
+		zone, offset := time.Now().Zone()
+		if name := time.Local.String(); name != "Local" {
+			zone = name
+		}
+		assert.Equal(t, zone, serverTime.Timezone)
+		assert.Equal(t, offset, serverTime.UTCOffset)
+	})
+
+	t.Run("requires a session", func(t *testing.T) {
+		client := th.CreateClient()
+		_, resp, err := client.GetServerTime()
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
 func TestRedirectLocation(t *testing.T) {
 	expected := "https://mattermost.com/wp-content/themes/mattermostv2/img/logo-light.svg"
 
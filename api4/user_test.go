@@ -5520,6 +5520,57 @@ func TestGetChannelMembersWithTeamData(t *testing.T) {
 	}
 }
 
+func TestGetResolvedNotifyProps(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user, _, err := th.Client.GetMe("")
+	require.NoError(t, err)
+
+	_, err = th.Client.UpdateChannelNotifyProps(th.BasicChannel.Id, th.BasicUser.Id, map[string]string{
+		model.DesktopNotifyProp:    model.ChannelNotifyNone,
+		model.EmailNotifyProp:      "false",
+		model.MarkUnreadNotifyProp: model.ChannelMarkUnreadMention,
+	})
+	require.NoError(t, err)
+
+	resolved, resp, err := th.Client.GetResolvedNotifyProps("me", 0, 100)
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+	require.Contains(t, resolved, th.BasicChannel.Id)
+	require.Contains(t, resolved, th.BasicChannel2.Id)
+
+	t.Run("channel overrides", func(t *testing.T) {
+		props := resolved[th.BasicChannel.Id]
+		assert.Equal(t, model.ChannelNotifyNone, props[model.DesktopNotifyProp])
+		assert.Equal(t, user.NotifyProps[model.PushNotifyProp], props[model.PushNotifyProp])
+		assert.Equal(t, "false", props[model.EmailNotifyProp])
+		assert.Equal(t, model.ChannelMarkUnreadMention, props[model.MarkUnreadNotifyProp])
+		assert.Equal(t, model.IgnoreChannelMentionsOn, props[model.IgnoreChannelMentionsNotifyProp])
+	})
+
+	t.Run("account settings", func(t *testing.T) {
+		props := resolved[th.BasicChannel2.Id]
+		assert.Equal(t, user.NotifyProps[model.DesktopNotifyProp], props[model.DesktopNotifyProp])
+		assert.Equal(t, user.NotifyProps[model.PushNotifyProp], props[model.PushNotifyProp])
+		assert.Equal(t, user.NotifyProps[model.EmailNotifyProp], props[model.EmailNotifyProp])
+		assert.Equal(t, model.ChannelMarkUnreadAll, props[model.MarkUnreadNotifyProp])
+		assert.Equal(t, model.IgnoreChannelMentionsOff, props[model.IgnoreChannelMentionsNotifyProp])
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		_, resp, err := th.Client.GetResolvedNotifyProps(th.BasicUser2.Id, 0, 100)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("system admin", func(t *testing.T) {
+		resolved, _, err := th.SystemAdminClient.GetResolvedNotifyProps(th.BasicUser.Id, 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, model.ChannelNotifyNone, resolved[th.BasicChannel.Id][model.DesktopNotifyProp])
+	})
+}
+
 func TestMigrateAuthToLDAP(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -5888,6 +5939,43 @@ func TestGetThreadsForUser(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, uss.TotalUnreadThreads, int64(0))
 	})
+
+	t.Run("only followed threads with their last reply and unread replies", func(t *testing.T) {
+		th.App.Srv().Store.Post().PermanentDeleteByUser(th.BasicUser.Id)
+		th.App.Srv().Store.Post().PermanentDeleteByUser(th.SystemAdminUser.Id)
+		defer th.App.Srv().Store.Post().PermanentDeleteByUser(th.BasicUser.Id)
+		defer th.App.Srv().Store.Post().PermanentDeleteByUser(th.SystemAdminUser.Id)
+
+		followedRoot, _ := postAndCheck(t, th.Client, &model.Post{ChannelId: th.BasicChannel.Id, Message: "followed"})
+		postAndCheck(t, th.SystemAdminClient, &model.Post{ChannelId: th.BasicChannel.Id, Message: "reply 1", RootId: followedRoot.Id})
+		lastReply, _ := postAndCheck(t, th.SystemAdminClient, &model.Post{ChannelId: th.BasicChannel.Id, Message: "reply 2", RootId: followedRoot.Id})
+
+		unfollowedRoot, _ := postAndCheck(t, th.Client, &model.Post{ChannelId: th.BasicChannel.Id, Message: "unfollowed"})
+		postAndCheck(t, th.SystemAdminClient, &model.Post{ChannelId: th.BasicChannel.Id, Message: "reply", RootId: unfollowedRoot.Id})
+		_, err := th.Client.UpdateThreadFollowForUser(th.BasicUser.Id, th.BasicTeam.Id, unfollowedRoot.Id, false)
+		require.NoError(t, err)
+
+		uss, _, err := th.Client.GetUserThreads(th.BasicUser.Id, th.BasicTeam.Id, model.GetUserThreadsOpts{})
+		require.NoError(t, err)
+		require.Len(t, uss.Threads, 1)
+		require.Equal(t, followedRoot.Id, uss.Threads[0].PostId)
+		require.Equal(t, lastReply.CreateAt, uss.Threads[0].LastReplyAt)
+		require.Equal(t, int64(2), uss.Threads[0].UnreadReplies)
+
+		uss, _, err = th.Client.GetUserThreads(th.BasicUser.Id, th.BasicTeam.Id, model.GetUserThreadsOpts{
+			Before:   followedRoot.Id,
+			PageSize: 30,
+		})
+		require.NoError(t, err)
+		require.Empty(t, uss.Threads)
+
+		uss, _, err = th.Client.GetUserThreads(th.BasicUser.Id, th.BasicTeam.Id, model.GetUserThreadsOpts{
+			After:    followedRoot.Id,
+			PageSize: 30,
+		})
+		require.NoError(t, err)
+		require.Empty(t, uss.Threads)
+	})
 }
 
 func TestThreadSocketEvents(t *testing.T) {
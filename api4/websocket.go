@@ -28,6 +28,8 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 		ReadBufferSize:  model.SocketMaxMessageSizeKb,
 		WriteBufferSize: model.SocketMaxMessageSizeKb,
 		CheckOrigin:     c.App.OriginChecker(),
+		// Compression is only used with the clients that ask for it during the handshake.
+		EnableCompression: *c.App.Config().ServiceSettings.EnableWebSocketCompression,
 	}
 
 	ws, err := upgrader.Upgrade(w, r, nil)
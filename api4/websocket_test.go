@@ -4,14 +4,18 @@
 package api4
 
 import (
+	"bytes"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -200,6 +204,103 @@ func TestWebsocketOriginSecurity(t *testing.T) {
 	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.AllowCorsFrom = "" })
 }
 
+// countingConn counts the bytes read from the network.
+type countingConn struct {
+	net.Conn
+	read int64
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	atomic.AddInt64(&c.read, int64(n))
+	return n, err
+}
+
+func TestWebSocketCompression(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	url := fmt.Sprintf("ws://localhost:%v", th.App.Srv().ListenAddr.Port) + model.APIURLSuffix + "/websocket"
+	header := http.Header{"Authorization": []string{model.HeaderBearer + " " + th.Client.AuthToken}}
+	payload := strings.Repeat("a large and very compressible payload ", 8*1024)
+
+	connect := func(t *testing.T, enableCompression bool) (*websocket.Conn, *http.Response, *countingConn) {
+		t.Helper()
+
+		var wire *countingConn
+		dialer := websocket.Dialer{
+			EnableCompression: enableCompression,
+			NetDial: func(network, addr string) (net.Conn, error) {
+				conn, err := net.Dial(network, addr)
+				if err != nil {
+					return nil, err
+				}
+				wire = &countingConn{Conn: conn}
+				return wire, nil
+			},
+		}
+		conn, resp, err := dialer.Dial(url, header)
+		require.NoError(t, err)
+		return conn, resp, wire
+	}
+
+	// receivePayload publishes the payload to the user and reads it back from the connection,
+	// returning the number of bytes that were read from the network to receive it.
+	receivePayload := func(t *testing.T, conn *websocket.Conn, wire *countingConn) int64 {
+		t.Helper()
+
+		_, data, err := conn.ReadMessage()
+		require.NoError(t, err)
+		hello, err := model.WebSocketEventFromJSON(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, model.WebsocketEventHello, hello.EventType())
+
+		before := atomic.LoadInt64(&wire.read)
+		event := model.NewWebSocketEvent(model.WebsocketEventPreferencesChanged, "", "", th.BasicUser.Id, nil)
+		event.Add("payload", payload)
+		th.App.Publish(event)
+
+		conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+		for {
+			_, data, err := conn.ReadMessage()
+			require.NoError(t, err)
+			received, err := model.WebSocketEventFromJSON(bytes.NewReader(data))
+			require.NoError(t, err)
+			if received.EventType() == model.WebsocketEventPreferencesChanged {
+				require.Equal(t, payload, received.GetData()["payload"])
+				return atomic.LoadInt64(&wire.read) - before
+			}
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		conn, resp, wire := connect(t, true)
+		defer conn.Close()
+
+		assert.NotContains(t, resp.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+		assert.Greater(t, receivePayload(t, conn, wire), int64(len(payload)))
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebSocketCompression = true })
+	defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebSocketCompression = false })
+
+	t.Run("large payload round-trips compressed", func(t *testing.T) {
+		conn, resp, wire := connect(t, true)
+		defer conn.Close()
+
+		assert.Contains(t, resp.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+		assert.Less(t, receivePayload(t, conn, wire), int64(len(payload)/10))
+	})
+
+	t.Run("client without compression", func(t *testing.T) {
+		conn, resp, wire := connect(t, false)
+		defer conn.Close()
+
+		assert.NotContains(t, resp.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+		assert.Greater(t, receivePayload(t, conn, wire), int64(len(payload)))
+	})
+}
+
 func TestWebSocketReconnectRace(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
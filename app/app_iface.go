@@ -203,7 +203,7 @@ type AppIface interface {
 	// GetSchemeRolesForChannel Checks if a channel or its team has an override scheme for channel roles and returns the scheme roles or default channel roles.
 	GetSchemeRolesForChannel(channelID string) (guestRoleName string, userRoleName string, adminRoleName string, err *model.AppError)
 	// GetSessionLengthInMillis returns the session length, in milliseconds,
-	// based on the type of session (Mobile, SSO, Web/LDAP).
+	// based on the type of session (Mobile, SAML, OAuth, LDAP, Web).
 	GetSessionLengthInMillis(session *model.Session) int64
 	// GetStorageUsage returns the sum of files' sizes stored on this instance
 	GetStorageUsage() (int64, *model.AppError)
@@ -376,6 +376,122 @@ type AppIface interface {
 	VerifyPlugin(plugin, signature io.ReadSeeker) *model.AppError
 	//GetUserStatusesByIds used by apiV4
 	GetUserStatusesByIds(userIDs []string) ([]*model.Status, *model.AppError)
+	// PinPost pins the post on behalf of the given user. It fails once the channel holds
+	// ServiceSettings.MaxPinnedPostsPerChannel pinned posts and, if enabled, announces the pin
+	// with a system message.
+	PinPost(c *request.Context, postID, userID string) (*model.Post, *model.AppError)
+	// UnpinPost unpins the post, freeing up a slot towards the channel's pinned posts limit.
+	UnpinPost(c *request.Context, postID string) (*model.Post, *model.AppError)
+	// UpdateSidebarCategoriesAndOrder replaces the user's sidebar categories on the team and their order in one
+	// go, publishing a single websocket event for the whole change.
+	UpdateSidebarCategoriesAndOrder(userID, teamID string, categories *model.OrderedSidebarCategories) (*model.OrderedSidebarCategories, *model.AppError)
+	// ReportPost records a report by the given user that the post needs to be reviewed for moderation
+	// and notifies the admins of the post's channel and team. A user may only report a post once.
+	ReportPost(c *request.Context, userID, postID, reason string) (*model.PostReport, *model.AppError)
+	// GetPostReports returns a page of post reports along with the reported posts and the reporting users.
+	GetPostReports(options model.PostReportGetOptions) ([]*model.PostReportWithContext, *model.AppError)
+	// UpdatePostReportStatus sets the moderation status of the report.
+	UpdatePostReportStatus(reportID, status string) (*model.PostReport, *model.AppError)
+	// LocalizePostListForUser renders the system messages in the post list in the locale of the given
+	// user. The posts are modified in place, so the list must already have been prepared for the client.
+	LocalizePostListForUser(postList *model.PostList, userID string)
+	// LocalizePostForUser renders the system message of the post in the locale of the given user. The
+	// post is modified in place, so it must already have been prepared for the client.
+	LocalizePostForUser(post *model.Post, userID string)
+	// SearchChannelMembersForUser returns a page of the user's memberships in public, and optionally private,
+	// channels across all teams whose name or display name matches the term.
+	SearchChannelMembersForUser(userID, term string, includePrivate bool, page, perPage int) (model.ChannelMembersWithTeamData, *model.AppError)
+	// GetReactionDetailsForPost returns the post's reactions grouped by emoji, each with a page of the
+	// users who reacted with it in the order that they did so.
+	GetReactionDetailsForPost(postID string, page, perPage int) ([]*model.ReactionDetails, *model.AppError)
+	// PasswordValidator returns the registered password validator, or the built-in rules when
+	// none has been registered.
+	PasswordValidator() einterfaces.PasswordValidatorInterface
+	// MergeDuplicateDirectChannels consolidates the direct channels between the two users into a single
+	// canonical channel, moving the posts of every other channel into it and archiving them. The
+	// canonical channel is the one found by name when looking up the direct channel, or the oldest one
+	// if there is no such channel. Merging users that have no duplicated channels is a no-op.
+	MergeDuplicateDirectChannels(userA, userB string) (*model.Channel, *model.AppError)
+	// SetPostReminder schedules a direct message to the user about the post at the given time,
+	// replacing any reminder the user already had for the post.
+	SetPostReminder(userID, postID string, remindAt int64) (*model.PostReminder, *model.AppError)
+	// GetPostReminders returns the user's pending post reminders in the order that they are due.
+	GetPostReminders(userID string) ([]*model.PostReminder, *model.AppError)
+	// ClearPostReminder removes the user's pending reminder for the post.
+	ClearPostReminder(userID, postID string) *model.AppError
+	// SendPostReminder sends the user a direct message from the system bot linking to the post of a due
+	// reminder, then removes the reminder. Reminders for posts that have been deleted, or whose channel
+	// the user can no longer read, are removed without sending anything, as are reminders that failed to
+	// send MaxPostReminderAttempts times.
+	SendPostReminder(c *request.Context, reminder *model.PostReminder) *model.AppError
+	// FileScanner returns the registered virus scanner, or one that passes every file when none has
+	// been registered.
+	FileScanner() einterfaces.FileScannerInterface
+	// ClearFileQuarantine allows the file to be downloaded again after it was quarantined by the virus
+	// scanner.
+	ClearFileQuarantine(fileID string) *model.AppError
+	// GetChannelMembersSince returns a page of the memberships of the channel that were created or updated
+	// at or after the given time, along with a page of tombstones for the users that have left the channel
+	// since. Both lists are paged with the same page and perPage.
+	GetChannelMembersSince(channelID string, since int64, page, perPage int) (*model.ChannelMembersDelta, *model.AppError)
+	// FocusChannel records that the websocket connection of the user has the channel focused in place
+	// of the channel it had focused before, and returns the IDs of the users currently viewing the
+	// channel on this server.
+	FocusChannel(connectionID, userID, channelID string) []string
+	// BlurChannel records that the websocket connection no longer has any channel focused.
+	BlurChannel(connectionID string)
+	// GetChannelViewers returns the IDs of the users currently viewing the channel on this server.
+	GetChannelViewers(channelID string) []string
+	// SnoozeChannel silences the email and push notifications of the channel for the user until the
+	// given time, after which they resume on their own.
+	SnoozeChannel(channelID, userID string, until int64) (*model.ChannelMember, *model.AppError)
+	// ClearChannelSnooze resumes the notifications of the channel for the user before the snooze expires.
+	ClearChannelSnooze(channelID, userID string) (*model.ChannelMember, *model.AppError)
+	// CreatePresignedUpload creates an upload session along with a URL through which the client can upload
+	// the file directly to the file store. If the file store doesn't support direct uploads, as is the
+	// case for local storage, the URL is left empty and the data must be sent through UploadData instead.
+	CreatePresignedUpload(us *model.UploadSession) (*model.PresignedUpload, *model.AppError)
+	// CompletePresignedUpload verifies that the file of an upload session created by CreatePresignedUpload
+	// has been uploaded to the file store with the expected size and creates its FileInfo.
+	CompletePresignedUpload(c *request.Context, us *model.UploadSession) (*model.FileInfo, *model.AppError)
+	// CreateChannelTemplate saves a new channel template for the template's team.
+	CreateChannelTemplate(template *model.ChannelTemplate) (*model.ChannelTemplate, *model.AppError)
+	// GetChannelTemplate returns the channel template with the given id.
+	GetChannelTemplate(templateID string) (*model.ChannelTemplate, *model.AppError)
+	// GetChannelTemplatesForTeam returns the channel templates of the team ordered by name.
+	GetChannelTemplatesForTeam(teamID string) ([]*model.ChannelTemplate, *model.AppError)
+	// DeleteChannelTemplate deletes the channel template. The channels already created from it are left as they are.
+	DeleteChannelTemplate(templateID string) *model.AppError
+	// CreateChannelFromTemplate creates a channel with the given name in the team using the type, purpose and
+	// header of the template, then posts and pins each of the template's pinned messages in it as the user.
+	CreateChannelFromTemplate(c *request.Context, teamID, templateID, name, userID string) (*model.Channel, *model.AppError)
+	// SaveReactions validates the reactions and saves them in a single transaction, updating each post
+	// they're on once. Saving a reaction that already exists isn't an error. No events are sent and no
+	// hooks are run, so this is meant for imports.
+	SaveReactions(reactions []*model.Reaction) *model.AppError
+	// ScheduleChannelDeletion schedules the channel to be archived at the given time, replacing any
+	// deletion already scheduled for it, and posts a system message counting down to the deletion.
+	ScheduleChannelDeletion(c *request.Context, channelID string, deleteAt int64) (*model.ScheduledChannelDeletion, *model.AppError)
+	// CancelChannelDeletion cancels the deletion scheduled for the channel and lets its members know.
+	CancelChannelDeletion(c *request.Context, channelID string) *model.AppError
+	// ExecuteScheduledChannelDeletion archives the channel of a due deletion as the system bot, then
+	// removes the deletion. Deletions that have since been cancelled or moved to a later time are left
+	// alone, and those for channels that no longer exist or are already archived are just removed.
+	ExecuteScheduledChannelDeletion(c *request.Context, deletion *model.ScheduledChannelDeletion) *model.AppError
+	// GetResolvedChannelNotifyPropsForUser returns, keyed by channel id, the notification settings in
+	// effect for the user in a page of their channels, merging the user's account settings with the
+	// overrides of each channel.
+	GetResolvedChannelNotifyPropsForUser(userID string, page, perPage int) (map[string]model.StringMap, *model.AppError)
+	// IgnoreUser hides the posts of the ignored user from the user's channel feeds, and stops their
+	// mentions of the user from notifying them.
+	IgnoreUser(userID, ignoredUserID string) *model.AppError
+	// UnignoreUser shows the posts of a user the user had ignored again.
+	UnignoreUser(userID, ignoredUserID string) *model.AppError
+	// GetIgnoredUserIds returns the ids of the users the user has ignored.
+	GetIgnoredUserIds(userID string) ([]string, *model.AppError)
+	// RemoveIgnoredUsersPosts removes the posts of the users the user has ignored from the post list,
+	// which is modified in place.
+	RemoveIgnoredUsersPosts(postList *model.PostList, userID string) *model.AppError
 	AccountMigration() einterfaces.AccountMigrationInterface
 	ActivateMfa(userID, token string) *model.AppError
 	AddChannelsToRetentionPolicy(policyID string, channelIDs []string) *model.AppError
@@ -7,13 +7,21 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v6/app/request"
 	"github.com/mattermost/mattermost-server/v6/app/users"
+	"github.com/mattermost/mattermost-server/v6/einterfaces"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mfa"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/utils"
 )
 
+// LoginAttemptsCacheSize is the number of client IP addresses for which failed login attempts are
+// tracked when the login lockout is per IP address.
+const LoginAttemptsCacheSize = 10000
+
 type TokenLocation int
 
 const (
@@ -44,23 +52,53 @@ func (tl TokenLocation) String() string {
 	}
 }
 
+// defaultPasswordValidator enforces the built-in password rules from the PasswordSettings.
+type defaultPasswordValidator struct {
+	app *App
+}
+
+func (v *defaultPasswordValidator) ValidatePassword(password string) []model.PasswordFailureReason {
+	settings := v.app.Config().PasswordSettings
+	if err := users.IsPasswordValidWithSettings(password, &settings); err != nil {
+		var invErr *users.ErrInvalidPassword
+		if errors.As(err, &invErr) {
+			return []model.PasswordFailureReason{{
+				Id:     invErr.Id(),
+				Params: map[string]interface{}{"Min": *settings.MinimumLength},
+			}}
+		}
+		return []model.PasswordFailureReason{{Id: "app.valid_password_generic.app_error"}}
+	}
+
+	return nil
+}
+
+// PasswordValidator returns the registered password validator, or the built-in rules when
+// none has been registered.
+func (a *App) PasswordValidator() einterfaces.PasswordValidatorInterface {
+	if a.ch.PasswordValidator != nil {
+		return a.ch.PasswordValidator
+	}
+	return &defaultPasswordValidator{app: a}
+}
+
 func (a *App) IsPasswordValid(password string) *model.AppError {
 
 	if *a.Config().ServiceSettings.EnableDeveloper {
 		return nil
 	}
 
-	if err := users.IsPasswordValidWithSettings(password, &a.Config().PasswordSettings); err != nil {
-		var invErr *users.ErrInvalidPassword
-		switch {
-		case errors.As(err, &invErr):
-			return model.NewAppError("User.IsValid", invErr.Id(), map[string]interface{}{"Min": *a.Config().PasswordSettings.MinimumLength}, "", http.StatusBadRequest)
-		default:
-			return model.NewAppError("User.IsValid", "app.valid_password_generic.app_error", nil, err.Error(), http.StatusInternalServerError)
-		}
+	reasons := a.PasswordValidator().ValidatePassword(password)
+	if len(reasons) == 0 {
+		return nil
 	}
 
-	return nil
+	ids := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		ids = append(ids, reason.Id)
+	}
+
+	return model.NewAppError("User.IsValid", reasons[0].Id, reasons[0].Params, "reasons="+strings.Join(ids, ","), http.StatusBadRequest)
 }
 
 func (a *App) CheckPasswordAndAllCriteria(user *model.User, password string, mfaToken string) *model.AppError {
@@ -69,8 +107,8 @@ func (a *App) CheckPasswordAndAllCriteria(user *model.User, password string, mfa
 	}
 
 	if err := users.CheckUserPassword(user, password); err != nil {
-		if passErr := a.Srv().Store.User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); passErr != nil {
-			return model.NewAppError("CheckPasswordAndAllCriteria", "app.user.update_failed_pwd_attempts.app_error", nil, passErr.Error(), http.StatusInternalServerError)
+		if passErr := a.incrementFailedLoginAttempts("CheckPasswordAndAllCriteria", user); passErr != nil {
+			return passErr
 		}
 
 		a.InvalidateCacheForUser(user.Id)
@@ -88,8 +126,8 @@ func (a *App) CheckPasswordAndAllCriteria(user *model.User, password string, mfa
 		// If the mfaToken is not set, we assume the client used this as a pre-flight request to query the server
 		// about the MFA state of the user in question
 		if mfaToken != "" {
-			if passErr := a.Srv().Store.User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); passErr != nil {
-				return model.NewAppError("CheckPasswordAndAllCriteria", "app.user.update_failed_pwd_attempts.app_error", nil, passErr.Error(), http.StatusInternalServerError)
+			if passErr := a.incrementFailedLoginAttempts("CheckPasswordAndAllCriteria", user); passErr != nil {
+				return passErr
 			}
 		}
 
@@ -113,13 +151,13 @@ func (a *App) CheckPasswordAndAllCriteria(user *model.User, password string, mfa
 
 // This to be used for places we check the users password when they are already logged in
 func (a *App) DoubleCheckPassword(user *model.User, password string) *model.AppError {
-	if err := checkUserLoginAttempts(user, *a.Config().ServiceSettings.MaximumLoginAttempts); err != nil {
+	if err := a.checkUserLoginAttempts(user); err != nil {
 		return err
 	}
 
 	if err := users.CheckUserPassword(user, password); err != nil {
-		if passErr := a.Srv().Store.User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); passErr != nil {
-			return model.NewAppError("DoubleCheckPassword", "app.user.update_failed_pwd_attempts.app_error", nil, passErr.Error(), http.StatusInternalServerError)
+		if passErr := a.incrementFailedLoginAttempts("DoubleCheckPassword", user); passErr != nil {
+			return passErr
 		}
 
 		a.InvalidateCacheForUser(user.Id)
@@ -187,7 +225,7 @@ func (a *App) CheckUserPreflightAuthenticationCriteria(user *model.User, mfaToke
 		return err
 	}
 
-	if err := checkUserLoginAttempts(user, *a.Config().ServiceSettings.MaximumLoginAttempts); err != nil {
+	if err := a.checkUserLoginAttempts(user); err != nil {
 		return err
 	}
 
@@ -223,14 +261,153 @@ func (a *App) CheckUserMfa(user *model.User, token string) *model.AppError {
 	return nil
 }
 
-func checkUserLoginAttempts(user *model.User, max int) *model.AppError {
-	if user.FailedAttempts >= max {
+func loginLockoutDuration(settings model.ServiceSettings) time.Duration {
+	return time.Duration(*settings.LoginLockoutDurationInMinutes) * time.Minute
+}
+
+// checkUserLoginAttempts returns an error if the user's account is locked because of too many
+// failed password checks. It does nothing unless the login lockout is per account. Once the
+// configured lockout duration has passed since the attempt that locked the account, the failed
+// attempts are cleared and the user may try again.
+func (a *App) checkUserLoginAttempts(user *model.User) *model.AppError {
+	settings := a.Config().ServiceSettings
+	if *settings.LoginLockoutScope != model.LoginLockoutScopeAccount || user.FailedAttempts < *settings.MaximumLoginAttempts {
+		return nil
+	}
+
+	duration := loginLockoutDuration(settings)
+	if duration == 0 {
 		return model.NewAppError("checkUserLoginAttempts", "api.user.check_user_login_attempts.too_many.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
 	}
 
+	if model.GetMillis() < user.LastFailedAttemptAt+duration.Milliseconds() {
+		return model.NewAppError("checkUserLoginAttempts", "api.user.check_user_login_attempts.locked.app_error", map[string]interface{}{"Minutes": *settings.LoginLockoutDurationInMinutes}, "user_id="+user.Id, http.StatusUnauthorized)
+	}
+
+	if err := a.Srv().Store.User().UpdateFailedPasswordAttempts(user.Id, 0); err != nil {
+		return model.NewAppError("checkUserLoginAttempts", "app.user.update_failed_pwd_attempts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	user.FailedAttempts = 0
+	user.LastFailedAttemptAt = 0
+	a.InvalidateCacheForUser(user.Id)
+
+	mlog.Info("Login lockout expired", mlog.String("user_id", user.Id))
+
+	return nil
+}
+
+// incrementFailedLoginAttempts records a failed password or MFA attempt by the user when the login
+// lockout is per account, logging when it locks their account.
+func (a *App) incrementFailedLoginAttempts(where string, user *model.User) *model.AppError {
+	settings := a.Config().ServiceSettings
+	if *settings.LoginLockoutScope != model.LoginLockoutScopeAccount {
+		return nil
+	}
+
+	attempts := user.FailedAttempts + 1
+	if err := a.Srv().Store.User().UpdateFailedPasswordAttempts(user.Id, attempts); err != nil {
+		return model.NewAppError(where, "app.user.update_failed_pwd_attempts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if attempts == *settings.MaximumLoginAttempts {
+		mlog.Warn("Locking account because of too many failed login attempts",
+			mlog.String("user_id", user.Id),
+			mlog.Int("failed_attempts", attempts),
+			mlog.Int("lockout_duration_minutes", *settings.LoginLockoutDurationInMinutes),
+		)
+	}
+
 	return nil
 }
 
+// loginAttemptsFromIP is the record of the failed login attempts from a client IP address.
+type loginAttemptsFromIP struct {
+	Attempts int
+	// UserIds are the ids of the existing users the attempts tried to log in as.
+	UserIds []string
+}
+
+// checkLoginAttemptsFromIP returns an error if logins from the client IP address are locked because
+// of too many failed login attempts. It does nothing unless the login lockout is per IP address. Only
+// the attempts made against this server count, so in a cluster an address may fail
+// ServiceSettings.MaximumLoginAttempts logins on each server before it is locked out of all of them.
+func (a *App) checkLoginAttemptsFromIP(ipAddress string) *model.AppError {
+	settings := a.Config().ServiceSettings
+	if *settings.LoginLockoutScope != model.LoginLockoutScopeIP || ipAddress == "" {
+		return nil
+	}
+
+	var attempts loginAttemptsFromIP
+	if err := a.Srv().loginAttemptsCache.Get(ipAddress, &attempts); err != nil || attempts.Attempts < *settings.MaximumLoginAttempts {
+		return nil
+	}
+
+	return model.NewAppError("checkLoginAttemptsFromIP", "api.user.check_user_login_attempts.locked.app_error", map[string]interface{}{"Minutes": *settings.LoginLockoutDurationInMinutes}, "ip_address="+ipAddress, http.StatusUnauthorized)
+}
+
+// incrementFailedLoginAttemptsFromIP records a failed login attempt from the client IP address as
+// the given user, if they exist, when the login lockout is per IP address, logging when it locks the
+// address. The attempts are tracked by each server independently and expire after the lockout
+// duration without further failures.
+func (a *App) incrementFailedLoginAttemptsFromIP(ipAddress, userID string) {
+	settings := a.Config().ServiceSettings
+	if *settings.LoginLockoutScope != model.LoginLockoutScopeIP || ipAddress == "" {
+		return
+	}
+
+	var attempts loginAttemptsFromIP
+	if err := a.Srv().loginAttemptsCache.Get(ipAddress, &attempts); err != nil {
+		attempts = loginAttemptsFromIP{}
+	}
+	attempts.Attempts++
+	if userID != "" && !utils.StringInSlice(userID, attempts.UserIds) {
+		attempts.UserIds = append(attempts.UserIds, userID)
+	}
+
+	if err := a.Srv().loginAttemptsCache.SetWithExpiry(ipAddress, attempts, loginLockoutDuration(settings)); err != nil {
+		mlog.Warn("Failed to record failed login attempt", mlog.String("ip_address", ipAddress), mlog.Err(err))
+		return
+	}
+
+	if attempts.Attempts == *settings.MaximumLoginAttempts {
+		mlog.Warn("Locking IP address because of too many failed login attempts",
+			mlog.String("ip_address", ipAddress),
+			mlog.Int("failed_attempts", attempts.Attempts),
+			mlog.Int("lockout_duration_minutes", *settings.LoginLockoutDurationInMinutes),
+		)
+	}
+}
+
+// clearFailedLoginAttemptsFromIP forgets the failed login attempts from the client IP address after
+// a successful login.
+func (a *App) clearFailedLoginAttemptsFromIP(ipAddress string) {
+	if ipAddress == "" {
+		return
+	}
+	a.Srv().loginAttemptsCache.Remove(ipAddress)
+}
+
+// clearFailedLoginAttemptsForUser forgets the failed login attempts from every client IP address
+// which tried to log in as the user, so that resetting their password also lifts the lockout of
+// those addresses.
+func (a *App) clearFailedLoginAttemptsForUser(userID string) {
+	ipAddresses, err := a.Srv().loginAttemptsCache.Keys()
+	if err != nil {
+		mlog.Warn("Failed to get the IP addresses with failed login attempts", mlog.String("user_id", userID), mlog.Err(err))
+		return
+	}
+
+	for _, ipAddress := range ipAddresses {
+		var attempts loginAttemptsFromIP
+		if err := a.Srv().loginAttemptsCache.Get(ipAddress, &attempts); err != nil {
+			continue
+		}
+		if utils.StringInSlice(userID, attempts.UserIds) {
+			a.Srv().loginAttemptsCache.Remove(ipAddress)
+		}
+	}
+}
+
 func checkUserNotDisabled(user *model.User) *model.AppError {
 	if user.DeleteAt > 0 {
 		return model.NewAppError("Login", "api.user.login.inactive.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -55,3 +57,72 @@ func TestParseAuthTokenFromRequest(t *testing.T) {
 		require.Equal(t, tc.expectedLocation, location, "Wrong location on test "+strconv.Itoa(testnum))
 	}
 }
+
+type testPasswordValidator struct {
+	banned string
+}
+
+func (v *testPasswordValidator) ValidatePassword(password string) []model.PasswordFailureReason {
+	if strings.Contains(strings.ToLower(password), v.banned) {
+		return []model.PasswordFailureReason{
+			{Id: "test.password.banned_word", Params: map[string]interface{}{"Word": v.banned}},
+			{Id: "test.password.policy"},
+		}
+	}
+	return nil
+}
+
+func TestIsPasswordValidWithCustomValidator(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableDeveloper = false
+		*cfg.PasswordSettings.MinimumLength = 5
+	})
+
+	t.Run("built-in rules by default", func(t *testing.T) {
+		require.Nil(t, th.App.IsPasswordValid("company1"))
+
+		appErr := th.App.IsPasswordValid("abc")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.user.is_valid.pwd.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	th.App.ch.PasswordValidator = &testPasswordValidator{banned: "company"}
+	defer func() { th.App.ch.PasswordValidator = nil }()
+
+	t.Run("custom validator rejects an otherwise valid password", func(t *testing.T) {
+		appErr := th.App.IsPasswordValid("Company1")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "test.password.banned_word", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		assert.Contains(t, appErr.DetailedError, "test.password.banned_word")
+		assert.Contains(t, appErr.DetailedError, "test.password.policy")
+
+		require.Nil(t, th.App.IsPasswordValid("abc"))
+	})
+
+	t.Run("custom validator applies when updating a password", func(t *testing.T) {
+		appErr := th.App.UpdatePassword(th.BasicUser, "mycompany")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "test.password.banned_word", appErr.Id)
+	})
+
+	t.Run("custom validator applies when creating a user", func(t *testing.T) {
+		user := &model.User{
+			Email:    "success+" + model.NewId() + "@simulator.amazonses.com",
+			Username: "un_" + model.NewId(),
+			Password: "company",
+		}
+		_, appErr := th.App.CreateUser(th.Context, user)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "test.password.banned_word", appErr.Id)
+
+		user.Password = "abc"
+		ruser, appErr := th.App.CreateUser(th.Context, user)
+		require.Nil(t, appErr)
+		defer th.App.PermanentDeleteUser(th.Context, ruser)
+	})
+}
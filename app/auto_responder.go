@@ -5,6 +5,7 @@ package app
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/mattermost/mattermost-server/v6/app/request"
@@ -15,12 +16,25 @@ import (
 func (a *App) checkIfRespondedToday(createdAt int64, channelId, userId string) (bool, error) {
 	y, m, d := model.GetTimeForMillis(createdAt).Date()
 	since := model.GetMillisForTime(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	return a.checkIfRespondedSince(since, channelId, userId)
+}
+
+// check if there is any auto_response type post in channel by the user since the given time
+func (a *App) checkIfRespondedSince(since int64, channelId, userId string) (bool, error) {
 	return a.Srv().Store.Post().HasAutoResponsePostByUserSince(
 		model.GetPostsSinceOptions{ChannelId: channelId, Time: since},
 		userId,
 	)
 }
 
+// getAutoResponderWindow returns the start and end of the user's auto-responder active window,
+// as set in their notify props. A zero value means that side of the window is unbounded.
+func getAutoResponderWindow(notifyProps model.StringMap) (start, end int64) {
+	start, _ = strconv.ParseInt(notifyProps[model.AutoResponderStartNotifyProp], 10, 64)
+	end, _ = strconv.ParseInt(notifyProps[model.AutoResponderEndNotifyProp], 10, 64)
+	return start, end
+}
+
 func (a *App) SendAutoResponseIfNecessary(c *request.Context, channel *model.Channel, sender *model.User, post *model.Post) (bool, *model.AppError) {
 	if channel.Type != model.ChannelTypeDirect {
 		return false, nil
@@ -30,6 +44,11 @@ func (a *App) SendAutoResponseIfNecessary(c *request.Context, channel *model.Cha
 		return false, nil
 	}
 
+	// Never respond to an auto-responder, otherwise two users with auto-responders would reply to each other.
+	if post.Type == model.PostTypeAutoResponder {
+		return false, nil
+	}
+
 	receiverId := channel.GetOtherUserIdForDM(sender.Id)
 	if receiverId == "" {
 		// User direct messaged themself, let them test their auto-responder.
@@ -41,7 +60,15 @@ func (a *App) SendAutoResponseIfNecessary(c *request.Context, channel *model.Cha
 		return false, aErr
 	}
 
-	autoResponded, err := a.checkIfRespondedToday(post.CreateAt, post.ChannelId, receiverId)
+	// Within an active window the sender only gets a single reply for the whole window,
+	// otherwise the auto-responder replies once per day.
+	var autoResponded bool
+	var err error
+	if start, _ := getAutoResponderWindow(receiver.NotifyProps); start > 0 {
+		autoResponded, err = a.checkIfRespondedSince(start, post.ChannelId, receiverId)
+	} else {
+		autoResponded, err = a.checkIfRespondedToday(post.CreateAt, post.ChannelId, receiverId)
+	}
 	if err != nil {
 		return false, model.NewAppError("SendAutoResponseIfNecessary", "app.user.send_auto_response.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -64,8 +91,12 @@ func (a *App) SendAutoResponse(c *request.Context, channel *model.Channel, recei
 		return false, nil
 	}
 
+	if start, end := getAutoResponderWindow(receiver.NotifyProps); (start > 0 && post.CreateAt < start) || (end > 0 && post.CreateAt > end) {
+		return false, nil
+	}
+
 	rootID := post.Id
-	if post.RootId != "" {
+	if post.IsReply() {
 		rootID = post.RootId
 	}
 
@@ -4,6 +4,7 @@
 package app
 
 import (
+	"strconv"
 	"testing"
 	"time"
 
@@ -240,6 +241,99 @@ func TestSendAutoResponseIfNecessary(t *testing.T) {
 	})
 }
 
+func TestSendAutoResponseIfNecessaryWithActiveWindow(t *testing.T) {
+	setupReceiver := func(th *TestHelper, start, end int64) *model.User {
+		receiver := th.CreateUser()
+
+		patch := &model.UserPatch{
+			NotifyProps: map[string]string{
+				model.AutoResponderActiveNotifyProp:  "true",
+				model.AutoResponderMessageNotifyProp: "Hello, I'm on leave.",
+				model.AutoResponderStartNotifyProp:   strconv.FormatInt(start, 10),
+				model.AutoResponderEndNotifyProp:     strconv.FormatInt(end, 10),
+			},
+		}
+		receiver, err := th.App.PatchUser(receiver.Id, patch, true)
+		require.Nil(t, err)
+
+		return receiver
+	}
+
+	createPost := func(th *TestHelper, channel *model.Channel, sender *model.User) *model.Post {
+		// The post is created against BasicChannel so CreatePost doesn't trigger the auto-responder itself.
+		savedPost, err := th.App.CreatePost(th.Context, &model.Post{
+			ChannelId: channel.Id,
+			Message:   NewTestId(),
+			UserId:    sender.Id},
+			th.BasicChannel,
+			false, true)
+		require.Nil(t, err)
+
+		return savedPost
+	}
+
+	t.Run("should send a single auto response per sender within the window", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		now := model.GetMillis()
+		receiver := setupReceiver(th, now-time.Hour.Milliseconds(), now+time.Hour.Milliseconds())
+		channel := th.CreateDmChannel(receiver)
+
+		sent, err := th.App.SendAutoResponseIfNecessary(th.Context, channel, th.BasicUser, createPost(th, channel, th.BasicUser))
+		require.Nil(t, err)
+		assert.True(t, sent)
+
+		sent, err = th.App.SendAutoResponseIfNecessary(th.Context, channel, th.BasicUser, createPost(th, channel, th.BasicUser))
+		require.Nil(t, err)
+		assert.False(t, sent)
+
+		otherChannel, err := th.App.GetOrCreateDirectChannel(th.Context, th.BasicUser2.Id, receiver.Id)
+		require.Nil(t, err)
+
+		sent, err = th.App.SendAutoResponseIfNecessary(th.Context, otherChannel, th.BasicUser2, createPost(th, otherChannel, th.BasicUser2))
+		require.Nil(t, err)
+		assert.True(t, sent, "another sender should get their own reply")
+	})
+
+	t.Run("should not send auto response outside the window", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		now := model.GetMillis()
+		receiver := setupReceiver(th, now+time.Hour.Milliseconds(), now+2*time.Hour.Milliseconds())
+		channel := th.CreateDmChannel(receiver)
+
+		sent, err := th.App.SendAutoResponseIfNecessary(th.Context, channel, th.BasicUser, createPost(th, channel, th.BasicUser))
+		require.Nil(t, err)
+		assert.False(t, sent)
+	})
+
+	t.Run("should not reply to another auto-responder", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		now := model.GetMillis()
+		receiver := setupReceiver(th, now-time.Hour.Milliseconds(), now+time.Hour.Milliseconds())
+		sender := setupReceiver(th, now-time.Hour.Milliseconds(), now+time.Hour.Milliseconds())
+
+		channel, err := th.App.GetOrCreateDirectChannel(th.Context, sender.Id, receiver.Id)
+		require.Nil(t, err)
+
+		autoResponse, err := th.App.CreatePost(th.Context, &model.Post{
+			ChannelId: channel.Id,
+			Message:   "Hello, I'm on leave.",
+			UserId:    sender.Id,
+			Type:      model.PostTypeAutoResponder,
+		}, th.BasicChannel, false, true)
+		require.Nil(t, err)
+
+		sent, err := th.App.SendAutoResponseIfNecessary(th.Context, channel, sender, autoResponse)
+		require.Nil(t, err)
+		assert.False(t, sent)
+	})
+}
+
 func TestSendAutoResponseSuccess(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -66,6 +67,50 @@ func (a *App) DefaultChannelNames() []string {
 	return names
 }
 
+// teamDefaultChannelNames returns the system-wide default channel names followed by
+// any additional default channels configured on the team, without duplicates.
+func (a *App) teamDefaultChannelNames(teamID string) []string {
+	names := a.DefaultChannelNames()
+
+	team, err := a.Srv().Store.Team().Get(teamID)
+	if err != nil {
+		mlog.Warn("Failed to get team default channels", mlog.String("team_id", teamID), mlog.Err(err))
+		return names
+	}
+
+	seenChannels := make(map[string]bool, len(names))
+	for _, channelName := range names {
+		seenChannels[channelName] = true
+	}
+	for _, channelName := range team.DefaultChannels {
+		if !seenChannels[channelName] {
+			names = append(names, channelName)
+			seenChannels[channelName] = true
+		}
+	}
+
+	return names
+}
+
+// checkMaxChannelsPerUser returns an error if the user is already a member of
+// TeamSettings.MaxChannelsPerUserPerTeam channels on the team. System admins are exempt.
+func (a *App) checkMaxChannelsPerUser(user *model.User, teamID string) *model.AppError {
+	maxChannels := *a.Config().TeamSettings.MaxChannelsPerUserPerTeam
+	if maxChannels <= 0 || user.IsSystemAdmin() {
+		return nil
+	}
+
+	count, err := a.Srv().Store.Channel().GetTeamChannelCountForUser(teamID, user.Id)
+	if err != nil {
+		return model.NewAppError("checkMaxChannelsPerUser", "app.channel.get_team_channel_count_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if count >= maxChannels {
+		return model.NewAppError("checkMaxChannelsPerUser", "api.channel.add_user_to_channel.max_channels_per_user.app_error", map[string]interface{}{"MaxChannelsPerUserPerTeam": maxChannels}, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (a *App) JoinDefaultChannels(c *request.Context, teamID string, user *model.User, shouldBeAdmin bool, userRequestorId string) *model.AppError {
 	var requestor *model.User
 	var nErr error
@@ -83,9 +128,11 @@ func (a *App) JoinDefaultChannels(c *request.Context, teamID string, user *model
 	}
 
 	var err *model.AppError
-	for _, channelName := range a.DefaultChannelNames() {
+	for _, channelName := range a.teamDefaultChannelNames(teamID) {
 		channel, channelErr := a.Srv().Store.Channel().GetByName(teamID, channelName, true)
 		if channelErr != nil {
+			// Archived or deleted channels are not returned, so they are skipped here.
+			mlog.Debug("Skipping default channel", mlog.String("team_id", teamID), mlog.String("channel_name", channelName), mlog.Err(channelErr))
 			var nfErr *store.ErrNotFound
 			switch {
 			case errors.As(err, &nfErr):
@@ -100,13 +147,18 @@ func (a *App) JoinDefaultChannels(c *request.Context, teamID string, user *model
 			continue
 		}
 
+		if appErr := a.checkMaxChannelsPerUser(user, teamID); appErr != nil {
+			mlog.Warn("Skipping default channel", mlog.String("team_id", teamID), mlog.String("channel_name", channelName), mlog.String("user_id", user.Id), mlog.Err(appErr))
+			continue
+		}
+
 		cm := &model.ChannelMember{
 			ChannelId:   channel.Id,
 			UserId:      user.Id,
 			SchemeGuest: user.IsGuest(),
 			SchemeUser:  !user.IsGuest(),
 			SchemeAdmin: shouldBeAdmin,
-			NotifyProps: model.GetDefaultChannelNotifyProps(),
+			NotifyProps: model.GetDefaultChannelMemberNotifyProps(channel),
 		}
 
 		_, nErr = a.Srv().Store.Channel().SaveMember(cm)
@@ -238,6 +290,27 @@ func (a *App) RenameChannel(channel *model.Channel, newChannelName string, newDi
 
 func (a *App) CreateChannel(c *request.Context, channel *model.Channel, addMember bool) (*model.Channel, *model.AppError) {
 	channel.DisplayName = strings.TrimSpace(channel.DisplayName)
+
+	var user *model.User
+	if addMember {
+		var nErr error
+		user, nErr = a.Srv().Store.User().Get(context.Background(), channel.CreatorId)
+		if nErr != nil {
+			var nfErr *store.ErrNotFound
+			switch {
+			case errors.As(nErr, &nfErr):
+				return nil, model.NewAppError("CreateChannel", MissingAccountError, nil, nfErr.Error(), http.StatusNotFound)
+			default:
+				return nil, model.NewAppError("CreateChannel", "app.user.get.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+			}
+		}
+
+		// Checked before saving so a creator at the limit doesn't leave behind a channel they aren't a member of.
+		if appErr := a.checkMaxChannelsPerUser(user, channel.TeamId); appErr != nil {
+			return nil, appErr
+		}
+	}
+
 	sc, nErr := a.Srv().Store.Channel().Save(channel, *a.Config().TeamSettings.MaxChannelsPerTeam)
 	if nErr != nil {
 		var invErr *store.ErrInvalidInput
@@ -266,24 +339,13 @@ func (a *App) CreateChannel(c *request.Context, channel *model.Channel, addMembe
 	}
 
 	if addMember {
-		user, nErr := a.Srv().Store.User().Get(context.Background(), channel.CreatorId)
-		if nErr != nil {
-			var nfErr *store.ErrNotFound
-			switch {
-			case errors.As(nErr, &nfErr):
-				return nil, model.NewAppError("CreateChannel", MissingAccountError, nil, nfErr.Error(), http.StatusNotFound)
-			default:
-				return nil, model.NewAppError("CreateChannel", "app.user.get.app_error", nil, nErr.Error(), http.StatusInternalServerError)
-			}
-		}
-
 		cm := &model.ChannelMember{
 			ChannelId:   sc.Id,
 			UserId:      user.Id,
 			SchemeGuest: user.IsGuest(),
 			SchemeUser:  !user.IsGuest(),
 			SchemeAdmin: true,
-			NotifyProps: model.GetDefaultChannelNotifyProps(),
+			NotifyProps: model.GetDefaultChannelMemberNotifyProps(sc),
 		}
 
 		if _, nErr := a.Srv().Store.Channel().SaveMember(cm); nErr != nil {
@@ -581,7 +643,7 @@ func (a *App) createGroupChannel(userIDs []string) (*model.Channel, *model.AppEr
 		cm := &model.ChannelMember{
 			UserId:      user.Id,
 			ChannelId:   group.Id,
-			NotifyProps: model.GetDefaultChannelNotifyProps(),
+			NotifyProps: model.GetDefaultChannelMemberNotifyProps(group),
 			SchemeGuest: user.IsGuest(),
 			SchemeUser:  !user.IsGuest(),
 		}
@@ -743,19 +805,19 @@ func (a *App) postChannelPrivacyMessage(c *request.Context, user *model.User, ch
 		authorUsername = systemBot.Username
 	}
 
-	message := (map[model.ChannelType]string{
-		model.ChannelTypeOpen:    i18n.T("api.channel.change_channel_privacy.private_to_public"),
-		model.ChannelTypePrivate: i18n.T("api.channel.change_channel_privacy.public_to_private"),
+	translationID := (map[model.ChannelType]string{
+		model.ChannelTypeOpen:    "api.channel.change_channel_privacy.private_to_public",
+		model.ChannelTypePrivate: "api.channel.change_channel_privacy.public_to_private",
 	})[channel.Type]
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message:   message,
 		Type:      model.PostTypeChangeChannelPrivacy,
 		UserId:    authorId,
 		Props: model.StringInterface{
 			"username": authorUsername,
 		},
 	}
+	post.SetSystemMessage(i18n.T, translationID)
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("postChannelPrivacyMessage", "api.channel.post_channel_privacy_message.error", nil, err.Error(), http.StatusInternalServerError)
@@ -1198,7 +1260,7 @@ func (a *App) UpdateChannelMemberRoles(channelID string, userID string, newRoles
 
 	member.ExplicitRoles = strings.Join(newExplicitRoles, " ")
 
-	return a.updateChannelMember(member)
+	return a.updateChannelMemberRoles(member)
 }
 
 func (a *App) UpdateChannelMemberSchemeRoles(channelID string, userID string, isSchemeGuest bool, isSchemeUser bool, isSchemeAdmin bool) (*model.ChannelMember, *model.AppError) {
@@ -1220,7 +1282,7 @@ func (a *App) UpdateChannelMemberSchemeRoles(channelID string, userID string, is
 		member.ExplicitRoles = RemoveRoles([]string{model.ChannelGuestRoleId, model.ChannelUserRoleId, model.ChannelAdminRoleId}, member.ExplicitRoles)
 	}
 
-	return a.updateChannelMember(member)
+	return a.updateChannelMemberRoles(member)
 }
 
 func (a *App) UpdateChannelMemberNotifyProps(data map[string]string, channelID string, userID string) (*model.ChannelMember, *model.AppError) {
@@ -1255,6 +1317,10 @@ func (a *App) UpdateChannelMemberNotifyProps(data map[string]string, channelID s
 		filteredProps[model.IgnoreChannelMentionsNotifyProp] = ignoreChannelMentions
 	}
 
+	if snoozeUntil, exists := data[model.SnoozeUntilNotifyProp]; exists {
+		filteredProps[model.SnoozeUntilNotifyProp] = snoozeUntil
+	}
+
 	member, err := a.Srv().Store.Channel().UpdateMemberNotifyProps(channelID, userID, filteredProps)
 	if err != nil {
 		var appErr *model.AppError
@@ -1284,6 +1350,35 @@ func (a *App) UpdateChannelMemberNotifyProps(data map[string]string, channelID s
 	return member, nil
 }
 
+// SnoozeChannel silences the email and push notifications of the channel for the user until the
+// given time, after which they resume on their own.
+func (a *App) SnoozeChannel(channelID, userID string, until int64) (*model.ChannelMember, *model.AppError) {
+	if until <= model.GetMillis() {
+		return nil, model.NewAppError("SnoozeChannel", "app.channel.snooze.invalid_until.app_error", nil, "until="+strconv.FormatInt(until, 10), http.StatusBadRequest)
+	}
+
+	return a.updateChannelSnooze(channelID, userID, strconv.FormatInt(until, 10))
+}
+
+// ClearChannelSnooze resumes the notifications of the channel for the user before the snooze expires.
+func (a *App) ClearChannelSnooze(channelID, userID string) (*model.ChannelMember, *model.AppError) {
+	return a.updateChannelSnooze(channelID, userID, "")
+}
+
+func (a *App) updateChannelSnooze(channelID, userID, snoozeUntil string) (*model.ChannelMember, *model.AppError) {
+	member, appErr := a.UpdateChannelMemberNotifyProps(map[string]string{model.SnoozeUntilNotifyProp: snoozeUntil}, channelID, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	message := model.NewWebSocketEvent(model.WebsocketEventChannelSnoozeUpdated, "", "", userID, nil)
+	message.Add("channel_id", channelID)
+	message.Add("snooze_until", snoozeUntil)
+	a.Publish(message)
+
+	return member, nil
+}
+
 func (a *App) updateChannelMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
 	member, nErr := a.Srv().Store.Channel().UpdateMember(member)
 	if nErr != nil {
@@ -1313,6 +1408,36 @@ func (a *App) updateChannelMember(member *model.ChannelMember) (*model.ChannelMe
 	return member, nil
 }
 
+// updateChannelMemberRoles saves the member after a change to its roles and, on top of notifying the
+// member themselves, lets the rest of the channel know so that their view of who moderates it stays current.
+// The other members receive the same channel_member_updated event, carrying only the member's roles and
+// not its notify props or counts, so clients must check the member's user id before applying it as their own.
+func (a *App) updateChannelMemberRoles(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+	member, appErr := a.updateChannelMember(member)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rolesJSON, jsonErr := json.Marshal(&model.ChannelMember{
+		ChannelId:     member.ChannelId,
+		UserId:        member.UserId,
+		Roles:         member.Roles,
+		ExplicitRoles: member.ExplicitRoles,
+		SchemeGuest:   member.SchemeGuest,
+		SchemeUser:    member.SchemeUser,
+		SchemeAdmin:   member.SchemeAdmin,
+	})
+	if jsonErr != nil {
+		mlog.Warn("Failed to encode channel member to JSON", mlog.Err(jsonErr))
+	}
+
+	evt := model.NewWebSocketEvent(model.WebsocketEventChannelMemberUpdated, "", member.ChannelId, "", map[string]bool{member.UserId: true})
+	evt.Add("channelMember", string(rolesJSON))
+	a.Publish(evt)
+
+	return member, nil
+}
+
 func (a *App) DeleteChannel(c *request.Context, channel *model.Channel, userID string) *model.AppError {
 	ihc := make(chan store.StoreResult, 1)
 	ohc := make(chan store.StoreResult, 1)
@@ -1372,13 +1497,13 @@ func (a *App) DeleteChannel(c *request.Context, channel *model.Channel, userID s
 
 		post := &model.Post{
 			ChannelId: channel.Id,
-			Message:   fmt.Sprintf(T("api.channel.delete_channel.archived"), user.Username),
 			Type:      model.PostTypeChannelDeleted,
 			UserId:    userID,
 			Props: model.StringInterface{
 				"username": user.Username,
 			},
 		}
+		post.SetSystemMessage(T, "api.channel.delete_channel.archived", user.Username)
 
 		if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 			mlog.Warn("Failed to post archive message", mlog.Err(err))
@@ -1393,13 +1518,13 @@ func (a *App) DeleteChannel(c *request.Context, channel *model.Channel, userID s
 
 			post := &model.Post{
 				ChannelId: channel.Id,
-				Message:   fmt.Sprintf(i18n.T("api.channel.delete_channel.archived"), systemBot.Username),
 				Type:      model.PostTypeChannelDeleted,
 				UserId:    systemBot.UserId,
 				Props: model.StringInterface{
 					"username": systemBot.Username,
 				},
 			}
+			post.SetSystemMessage(i18n.T, "api.channel.delete_channel.archived", systemBot.Username)
 
 			if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 				mlog.Error("Failed to post archive message", mlog.Err(err))
@@ -1451,6 +1576,14 @@ func (a *App) addUserToChannel(user *model.User, channel *model.Channel) (*model
 		return channelMember, nil
 	}
 
+	if appErr := a.checkGuestChannelInvite(user, channel); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.checkMaxChannelsPerUser(user, channel.TeamId); appErr != nil {
+		return nil, appErr
+	}
+
 	if channel.IsGroupConstrained() {
 		nonMembers, err := a.FilterNonGroupChannelMembers([]string{user.Id}, channel)
 		if err != nil {
@@ -1464,7 +1597,7 @@ func (a *App) addUserToChannel(user *model.User, channel *model.Channel) (*model
 	newMember := &model.ChannelMember{
 		ChannelId:   channel.Id,
 		UserId:      user.Id,
-		NotifyProps: model.GetDefaultChannelNotifyProps(),
+		NotifyProps: model.GetDefaultChannelMemberNotifyProps(channel),
 		SchemeGuest: user.IsGuest(),
 		SchemeUser:  !user.IsGuest(),
 	}
@@ -1488,6 +1621,10 @@ func (a *App) addUserToChannel(user *model.User, channel *model.Channel) (*model
 		return nil, model.NewAppError("AddUserToChannel", "app.channel_member_history.log_join_event.internal_error", nil, nErr.Error(), http.StatusInternalServerError)
 	}
 
+	if user.IsGuest() {
+		a.useGuestChannelInvite(user.Id, channel.Id)
+	}
+
 	a.InvalidateCacheForUser(user.Id)
 	a.invalidateCacheForChannelMembers(channel.Id)
 
@@ -1561,6 +1698,11 @@ func (a *App) AddChannelMember(c *request.Context, userID string, channel *model
 		}
 	}
 
+	// A guest added by someone allowed to manage the channel's members is invited by them.
+	if user.IsGuest() && userRequestor != nil && userRequestor.Id != user.Id {
+		a.recordGuestChannelInvites(user.Id, []*model.Channel{channel})
+	}
+
 	cm, err := a.AddUserToChannel(user, channel, opts.SkipTeamMemberIntegrityCheck)
 	if err != nil {
 		return nil, err
@@ -1631,18 +1773,8 @@ func (a *App) PostUpdateChannelHeaderMessage(c *request.Context, userID string,
 		return model.NewAppError("PostUpdateChannelHeaderMessage", "api.channel.post_update_channel_header_message_and_forget.retrieve_user.error", nil, err.Error(), http.StatusBadRequest)
 	}
 
-	var message string
-	if oldChannelHeader == "" {
-		message = fmt.Sprintf(i18n.T("api.channel.post_update_channel_header_message_and_forget.updated_to"), user.Username, newChannelHeader)
-	} else if newChannelHeader == "" {
-		message = fmt.Sprintf(i18n.T("api.channel.post_update_channel_header_message_and_forget.removed"), user.Username, oldChannelHeader)
-	} else {
-		message = fmt.Sprintf(i18n.T("api.channel.post_update_channel_header_message_and_forget.updated_from"), user.Username, oldChannelHeader, newChannelHeader)
-	}
-
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message:   message,
 		Type:      model.PostTypeHeaderChange,
 		UserId:    userID,
 		Props: model.StringInterface{
@@ -1651,6 +1783,13 @@ func (a *App) PostUpdateChannelHeaderMessage(c *request.Context, userID string,
 			"new_header": newChannelHeader,
 		},
 	}
+	if oldChannelHeader == "" {
+		post.SetSystemMessage(i18n.T, "api.channel.post_update_channel_header_message_and_forget.updated_to", user.Username, newChannelHeader)
+	} else if newChannelHeader == "" {
+		post.SetSystemMessage(i18n.T, "api.channel.post_update_channel_header_message_and_forget.removed", user.Username, oldChannelHeader)
+	} else {
+		post.SetSystemMessage(i18n.T, "api.channel.post_update_channel_header_message_and_forget.updated_from", user.Username, oldChannelHeader, newChannelHeader)
+	}
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("", "api.channel.post_update_channel_header_message_and_forget.post.error", nil, err.Error(), http.StatusInternalServerError)
@@ -1665,18 +1804,8 @@ func (a *App) PostUpdateChannelPurposeMessage(c *request.Context, userID string,
 		return model.NewAppError("PostUpdateChannelPurposeMessage", "app.channel.post_update_channel_purpose_message.retrieve_user.error", nil, err.Error(), http.StatusBadRequest)
 	}
 
-	var message string
-	if oldChannelPurpose == "" {
-		message = fmt.Sprintf(i18n.T("app.channel.post_update_channel_purpose_message.updated_to"), user.Username, newChannelPurpose)
-	} else if newChannelPurpose == "" {
-		message = fmt.Sprintf(i18n.T("app.channel.post_update_channel_purpose_message.removed"), user.Username, oldChannelPurpose)
-	} else {
-		message = fmt.Sprintf(i18n.T("app.channel.post_update_channel_purpose_message.updated_from"), user.Username, oldChannelPurpose, newChannelPurpose)
-	}
-
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message:   message,
 		Type:      model.PostTypePurposeChange,
 		UserId:    userID,
 		Props: model.StringInterface{
@@ -1685,6 +1814,13 @@ func (a *App) PostUpdateChannelPurposeMessage(c *request.Context, userID string,
 			"new_purpose": newChannelPurpose,
 		},
 	}
+	if oldChannelPurpose == "" {
+		post.SetSystemMessage(i18n.T, "app.channel.post_update_channel_purpose_message.updated_to", user.Username, newChannelPurpose)
+	} else if newChannelPurpose == "" {
+		post.SetSystemMessage(i18n.T, "app.channel.post_update_channel_purpose_message.removed", user.Username, oldChannelPurpose)
+	} else {
+		post.SetSystemMessage(i18n.T, "app.channel.post_update_channel_purpose_message.updated_from", user.Username, oldChannelPurpose, newChannelPurpose)
+	}
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("", "app.channel.post_update_channel_purpose_message.post.error", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -1698,11 +1834,8 @@ func (a *App) PostUpdateChannelDisplayNameMessage(c *request.Context, userID str
 		return model.NewAppError("PostUpdateChannelDisplayNameMessage", "api.channel.post_update_channel_displayname_message_and_forget.retrieve_user.error", nil, err.Error(), http.StatusBadRequest)
 	}
 
-	message := fmt.Sprintf(i18n.T("api.channel.post_update_channel_displayname_message_and_forget.updated_from"), user.Username, oldChannelDisplayName, newChannelDisplayName)
-
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message:   message,
 		Type:      model.PostTypeDisplaynameChange,
 		UserId:    userID,
 		Props: model.StringInterface{
@@ -1711,6 +1844,7 @@ func (a *App) PostUpdateChannelDisplayNameMessage(c *request.Context, userID str
 			"new_displayname": newChannelDisplayName,
 		},
 	}
+	post.SetSystemMessage(i18n.T, "api.channel.post_update_channel_displayname_message_and_forget.updated_from", user.Username, oldChannelDisplayName, newChannelDisplayName)
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("PostUpdateChannelDisplayNameMessage", "api.channel.post_update_channel_displayname_message_and_forget.create_post.error", nil, err.Error(), http.StatusInternalServerError)
@@ -1983,6 +2117,35 @@ func (a *App) GetChannelMembersPage(channelID string, page, perPage int) (model.
 	return channelMembers, nil
 }
 
+// GetChannelMembersSince returns a page of the memberships of the channel that were created or updated
+// at or after the given time, along with a page of tombstones for the users that have left the channel
+// since. Both lists are paged with the same page and perPage.
+func (a *App) GetChannelMembersSince(channelID string, since int64, page, perPage int) (*model.ChannelMembersDelta, *model.AppError) {
+	members, err := a.Srv().Store.Channel().GetMembersUpdatedSince(channelID, since, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelMembersSince", "app.channel.get_members.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	left, err := a.Srv().Store.ChannelMemberHistory().GetUsersLeftSince(channelID, since, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelMembersSince", "app.channel_member_history.get_users_left_since.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	removed := make([]*model.ChannelMemberTombstone, 0, len(left))
+	for _, history := range left {
+		removed = append(removed, &model.ChannelMemberTombstone{
+			ChannelId: history.ChannelId,
+			UserId:    history.UserId,
+			DeleteAt:  *history.LeaveTime,
+		})
+	}
+
+	return &model.ChannelMembersDelta{
+		Members: members,
+		Removed: removed,
+	}, nil
+}
+
 func (a *App) GetChannelMembersTimezones(channelID string) ([]string, *model.AppError) {
 	membersTimezones, err := a.Srv().Store.Channel().GetChannelMembersTimezones(channelID)
 	if err != nil {
@@ -2032,6 +2195,28 @@ func (a *App) GetChannelMembersForUserWithPagination(userID string, page, perPag
 	return members, nil
 }
 
+// GetResolvedChannelNotifyPropsForUser returns, keyed by channel id, the notification settings in
+// effect for the user in a page of their channels, merging the user's account settings with the
+// overrides of each channel.
+func (a *App) GetResolvedChannelNotifyPropsForUser(userID string, page, perPage int) (map[string]model.StringMap, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	members, appErr := a.GetChannelMembersForUserWithPagination(userID, page, perPage)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	resolved := make(map[string]model.StringMap, len(members))
+	for _, member := range members {
+		resolved[member.ChannelId] = model.ResolveChannelNotifyProps(user.NotifyProps, member.NotifyProps)
+	}
+
+	return resolved, nil
+}
+
 func (a *App) GetChannelMembersWithTeamDataForUserWithPagination(userID string, page, perPage int) (model.ChannelMembersWithTeamData, *model.AppError) {
 	m, err := a.Srv().Store.Channel().GetMembersForUserWithPagination(userID, page, perPage)
 	if err != nil {
@@ -2041,6 +2226,17 @@ func (a *App) GetChannelMembersWithTeamDataForUserWithPagination(userID string,
 	return m, nil
 }
 
+// SearchChannelMembersForUser returns a page of the user's memberships in public, and optionally private,
+// channels across all teams whose name or display name matches the term.
+func (a *App) SearchChannelMembersForUser(userID, term string, includePrivate bool, page, perPage int) (model.ChannelMembersWithTeamData, *model.AppError) {
+	members, err := a.Srv().Store.Channel().SearchMembersForUser(userID, term, includePrivate, page, perPage)
+	if err != nil {
+		return nil, model.NewAppError("SearchChannelMembersForUser", "app.channel.get_members.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return members, nil
+}
+
 func (a *App) GetChannelMemberCount(channelID string) (int64, *model.AppError) {
 	count, err := a.Srv().Store.Channel().GetMemberCount(channelID, true)
 	if err != nil {
@@ -2165,23 +2361,23 @@ func (a *App) JoinChannel(c *request.Context, channel *model.Channel, userID str
 }
 
 func (a *App) postJoinChannelMessage(c *request.Context, user *model.User, channel *model.Channel) *model.AppError {
-	message := fmt.Sprintf(i18n.T("api.channel.join_channel.post_and_forget"), user.Username)
+	translationID := "api.channel.join_channel.post_and_forget"
 	postType := model.PostTypeJoinChannel
 
 	if user.IsGuest() {
-		message = fmt.Sprintf(i18n.T("api.channel.guest_join_channel.post_and_forget"), user.Username)
+		translationID = "api.channel.guest_join_channel.post_and_forget"
 		postType = model.PostTypeGuestJoinChannel
 	}
 
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message:   message,
 		Type:      postType,
 		UserId:    user.Id,
 		Props: model.StringInterface{
 			"username": user.Username,
 		},
 	}
+	post.SetSystemMessage(i18n.T, translationID, user.Username)
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("postJoinChannelMessage", "api.channel.post_user_add_remove_message_and_forget.error", nil, err.Error(), http.StatusInternalServerError)
@@ -2193,13 +2389,13 @@ func (a *App) postJoinChannelMessage(c *request.Context, user *model.User, chann
 func (a *App) postJoinTeamMessage(c *request.Context, user *model.User, channel *model.Channel) *model.AppError {
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message:   fmt.Sprintf(i18n.T("api.team.join_team.post_and_forget"), user.Username),
 		Type:      model.PostTypeJoinTeam,
 		UserId:    user.Id,
 		Props: model.StringInterface{
 			"username": user.Username,
 		},
 	}
+	post.SetSystemMessage(i18n.T, "api.team.join_team.post_and_forget", user.Username)
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("postJoinTeamMessage", "api.channel.post_user_add_remove_message_and_forget.error", nil, err.Error(), http.StatusInternalServerError)
@@ -2287,16 +2483,16 @@ func (a *App) LeaveChannel(c *request.Context, channelID string, userID string)
 func (a *App) postLeaveChannelMessage(c *request.Context, user *model.User, channel *model.Channel) *model.AppError {
 	post := &model.Post{
 		ChannelId: channel.Id,
-		// Message here embeds `@username`, not just `username`, to ensure that mentions
-		// treat this as a username mention even though the user has now left the channel.
-		// The client renders its own system message, ignoring this value altogether.
-		Message: fmt.Sprintf(i18n.T("api.channel.leave.left"), fmt.Sprintf("@%s", user.Username)),
-		Type:    model.PostTypeLeaveChannel,
-		UserId:  user.Id,
+		Type:      model.PostTypeLeaveChannel,
+		UserId:    user.Id,
 		Props: model.StringInterface{
 			"username": user.Username,
 		},
 	}
+	// Message here embeds `@username`, not just `username`, to ensure that mentions
+	// treat this as a username mention even though the user has now left the channel.
+	// The client renders its own system message, ignoring this value altogether.
+	post.SetSystemMessage(i18n.T, "api.channel.leave.left", fmt.Sprintf("@%s", user.Username))
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("postLeaveChannelMessage", "api.channel.post_user_add_remove_message_and_forget.error", nil, err.Error(), http.StatusInternalServerError)
@@ -2306,17 +2502,16 @@ func (a *App) postLeaveChannelMessage(c *request.Context, user *model.User, chan
 }
 
 func (a *App) PostAddToChannelMessage(c *request.Context, user *model.User, addedUser *model.User, channel *model.Channel, postRootId string) *model.AppError {
-	message := fmt.Sprintf(i18n.T("api.channel.add_member.added"), addedUser.Username, user.Username)
+	translationID := "api.channel.add_member.added"
 	postType := model.PostTypeAddToChannel
 
 	if addedUser.IsGuest() {
-		message = fmt.Sprintf(i18n.T("api.channel.add_guest.added"), addedUser.Username, user.Username)
+		translationID = "api.channel.add_guest.added"
 		postType = model.PostTypeAddGuestToChannel
 	}
 
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message:   message,
 		Type:      postType,
 		UserId:    user.Id,
 		RootId:    postRootId,
@@ -2327,6 +2522,7 @@ func (a *App) PostAddToChannelMessage(c *request.Context, user *model.User, adde
 			"addedUsername":            addedUser.Username,
 		},
 	}
+	post.SetSystemMessage(i18n.T, translationID, addedUser.Username, user.Username)
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("postAddToChannelMessage", "api.channel.post_user_add_remove_message_and_forget.error", nil, err.Error(), http.StatusInternalServerError)
@@ -2338,7 +2534,6 @@ func (a *App) PostAddToChannelMessage(c *request.Context, user *model.User, adde
 func (a *App) postAddToTeamMessage(c *request.Context, user *model.User, addedUser *model.User, channel *model.Channel, postRootId string) *model.AppError {
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message:   fmt.Sprintf(i18n.T("api.team.add_user_to_team.added"), addedUser.Username, user.Username),
 		Type:      model.PostTypeAddToTeam,
 		UserId:    user.Id,
 		RootId:    postRootId,
@@ -2349,6 +2544,7 @@ func (a *App) postAddToTeamMessage(c *request.Context, user *model.User, addedUs
 			"addedUsername":            addedUser.Username,
 		},
 	}
+	post.SetSystemMessage(i18n.T, "api.team.add_user_to_team.added", addedUser.Username, user.Username)
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("postAddToTeamMessage", "api.channel.post_user_add_remove_message_and_forget.error", nil, err.Error(), http.StatusInternalServerError)
@@ -2370,17 +2566,17 @@ func (a *App) postRemoveFromChannelMessage(c *request.Context, removerUserId str
 
 	post := &model.Post{
 		ChannelId: channel.Id,
-		// Message here embeds `@username`, not just `username`, to ensure that mentions
-		// treat this as a username mention even though the user has now left the channel.
-		// The client renders its own system message, ignoring this value altogether.
-		Message: fmt.Sprintf(i18n.T("api.channel.remove_member.removed"), fmt.Sprintf("@%s", removedUser.Username)),
-		Type:    model.PostTypeRemoveFromChannel,
-		UserId:  messageUserId,
+		Type:      model.PostTypeRemoveFromChannel,
+		UserId:    messageUserId,
 		Props: model.StringInterface{
 			"removedUserId":   removedUser.Id,
 			"removedUsername": removedUser.Username,
 		},
 	}
+	// Message here embeds `@username`, not just `username`, to ensure that mentions
+	// treat this as a username mention even though the user has now left the channel.
+	// The client renders its own system message, ignoring this value altogether.
+	post.SetSystemMessage(i18n.T, "api.channel.remove_member.removed", fmt.Sprintf("@%s", removedUser.Username))
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("postRemoveFromChannelMessage", "api.channel.post_user_add_remove_message_and_forget.error", nil, err.Error(), http.StatusInternalServerError)
@@ -2566,6 +2762,19 @@ func (a *App) IsCRTEnabledForUser(userID string) bool {
 	return threadsEnabled
 }
 
+// isThreadAutoFollowEnabledForUser returns whether replying to or being mentioned in a thread makes the
+// user follow it. Users can opt out through their display settings when ServiceSettings.ThreadAutoFollow
+// is enabled.
+func (a *App) isThreadAutoFollowEnabledForUser(userID string) bool {
+	if !*a.Config().ServiceSettings.ThreadAutoFollow {
+		return false
+	}
+	if preference, err := a.Srv().Store.Preference().Get(userID, model.PreferenceCategoryDisplaySettings, model.PreferenceNameThreadAutoFollow); err == nil {
+		return preference.Value != "off"
+	}
+	return true
+}
+
 // MarkChanelAsUnreadFromPost will take a post and set the channel as unread from that one.
 func (a *App) MarkChannelAsUnreadFromPost(postID string, userID string, collapsedThreadsSupported bool) (*model.ChannelUnreadAt, *model.AppError) {
 	if !collapsedThreadsSupported || !a.IsCRTEnabledForUser(userID) {
@@ -2609,7 +2818,7 @@ func (a *App) markChannelAsUnreadFromPostCRTUnsupported(postID string, userID st
 	}
 
 	threadId := post.RootId
-	if post.RootId == "" {
+	if post.IsRoot() {
 		threadId = post.Id
 	}
 
@@ -2621,7 +2830,7 @@ func (a *App) markChannelAsUnreadFromPostCRTUnsupported(postID string, userID st
 	// if root post,
 	// In CRT Supported Client: badge on channel only sums mentions in root posts including and below the post that was marked.
 	// In CRT Unsupported Client: badge on channel sums mentions in all posts (root & replies) including and below the post that was marked unread.
-	if post.RootId == "" {
+	if post.IsRoot() {
 		channelUnread, nErr := a.Srv().Store.Channel().UpdateLastViewedAtPost(post, userID, unreadMentions, unreadMentionsRoot, true)
 		if nErr != nil {
 			return channelUnread, model.NewAppError("MarkChannelAsUnreadFromPost", "app.channel.update_last_viewed_at_post.app_error", nil, nErr.Error(), http.StatusInternalServerError)
@@ -2647,7 +2856,7 @@ func (a *App) markChannelAsUnreadFromPostCRTUnsupported(postID string, userID st
 		return nil, model.NewAppError("MarkChannelAsUnreadFromPost", "app.channel.update_last_viewed_at_post.app_error", nil, nErr.Error(), http.StatusInternalServerError)
 	}
 
-	if *a.Config().ServiceSettings.ThreadAutoFollow {
+	if a.isThreadAutoFollowEnabledForUser(user.Id) {
 		threadMembership, sErr := a.Srv().Store.Thread().GetMembershipForUser(user.Id, threadId)
 		var errNotFound *store.ErrNotFound
 		if sErr != nil && !errors.As(sErr, &errNotFound) {
@@ -2683,7 +2892,7 @@ func (a *App) markChannelAsUnreadFromPostCRTUnsupported(postID string, userID st
 			return nil, model.NewAppError("MarkChannelAsUnreadFromPost", "app.channel.update_last_viewed_at_post.app_error", nil, sErr.Error(), http.StatusInternalServerError)
 		}
 		a.sanitizeProfiles(thread.Participants, false)
-		thread.Post.SanitizeProps()
+		thread.Post.SanitizeParticipants()
 
 		if a.IsCRTEnabledForUser(userID) {
 			payload, jsonErr := json.Marshal(thread)
@@ -3159,6 +3368,10 @@ func (a *App) RemoveUsersFromChannelNotMemberOfTeam(c *request.Context, remover
 				if err := a.removeUserFromChannel(c, userID, removerId, channel); err != nil {
 					return err
 				}
+
+				if err := a.postRemovedOnChannelMoveMessage(c, userID, channel, team); err != nil {
+					mlog.Warn("Failed to notify user removed from moved channel", mlog.String("user_id", userID), mlog.String("channel_id", channel.Id), mlog.Err(err))
+				}
 			}
 		}
 	}
@@ -3166,6 +3379,41 @@ func (a *App) RemoveUsersFromChannelNotMemberOfTeam(c *request.Context, remover
 	return nil
 }
 
+// postRemovedOnChannelMoveMessage lets a user removed from a channel that moved to a team they
+// aren't a member of know why, with a direct message from the system bot.
+func (a *App) postRemovedOnChannelMoveMessage(c *request.Context, userID string, channel *model.Channel, team *model.Team) *model.AppError {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	systemBot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		return appErr
+	}
+
+	dm, appErr := a.GetOrCreateDirectChannel(c, systemBot.UserId, user.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	post := &model.Post{
+		UserId:    systemBot.UserId,
+		ChannelId: dm.Id,
+		Message: T("app.channel.move_channel.removed_member", map[string]interface{}{
+			"Channel": channel.DisplayName,
+			"Team":    team.DisplayName,
+		}),
+	}
+
+	if _, appErr := a.CreatePost(c, post, dm, false, true); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
 func (a *App) GetPinnedPosts(channelID string) (*model.PostList, *model.AppError) {
 	posts, err := a.Srv().Store.Channel().GetPinnedPosts(channelID)
 	if err != nil {
@@ -3396,6 +3644,81 @@ func (s *Server) getDirectChannel(userID, otherUserID string) (*model.Channel, *
 	return channel, nil
 }
 
+// MergeDuplicateDirectChannels consolidates the direct channels between the two users into a single
+// canonical channel, moving the posts of every other channel into it and archiving them. The
+// canonical channel is the one found by name when looking up the direct channel, or the oldest one
+// if there is no such channel. Merging users that have no duplicated channels is a no-op.
+func (a *App) MergeDuplicateDirectChannels(userA, userB string) (*model.Channel, *model.AppError) {
+	channels, err := a.Srv().Store.Channel().GetDirectChannelsForUsers(userA, userB)
+	if err != nil {
+		return nil, model.NewAppError("MergeDuplicateDirectChannels", "app.channel.get_direct_channels_for_users.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(channels) < 2 {
+		return a.getDirectChannel(userA, userB)
+	}
+
+	members := model.RemoveDuplicateStrings([]string{userA, userB})
+
+	name := model.GetDMNameFromIds(userA, userB)
+	canonical := channels[0]
+	for _, channel := range channels {
+		if channel.Name == name {
+			canonical = channel
+			break
+		}
+	}
+
+	var duplicateIDs []string
+	for _, channel := range channels {
+		if channel.Id != canonical.Id {
+			duplicateIDs = append(duplicateIDs, channel.Id)
+		}
+	}
+
+	deleteAt := model.GetMillis()
+	mergedIDs, err := a.Srv().Store.Channel().MergeDirectChannels(canonical.Id, duplicateIDs, deleteAt)
+	if err != nil {
+		return nil, model.NewAppError("MergeDuplicateDirectChannels", "app.channel.merge_direct_channels.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	merged := make(map[string]bool, len(mergedIDs))
+	for _, channelID := range mergedIDs {
+		merged[channelID] = true
+	}
+	for _, channel := range channels {
+		if !merged[channel.Id] {
+			continue
+		}
+
+		a.invalidateCacheForChannel(channel)
+		a.invalidateCacheForChannelPosts(channel.Id)
+
+		for _, userID := range members {
+			message := model.NewWebSocketEvent(model.WebsocketEventChannelDeleted, "", "", userID, nil)
+			message.Add("channel_id", channel.Id)
+			message.Add("delete_at", deleteAt)
+			a.Publish(message)
+		}
+	}
+
+	// Make sure looking up the direct channel by name finds the canonical one from now on.
+	if canonical.Name != name {
+		a.invalidateCacheForChannel(canonical)
+		canonical.Name = name
+		if _, err := a.Srv().Store.Channel().Update(canonical); err != nil {
+			return nil, model.NewAppError("MergeDuplicateDirectChannels", "app.channel.update_channel.internal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.invalidateCacheForChannel(canonical)
+	a.invalidateCacheForChannelPosts(canonical.Id)
+	for _, userID := range members {
+		a.Srv().Store.Channel().InvalidateAllChannelMembersForUser(userID)
+	}
+
+	return a.GetChannel(canonical.Id)
+}
+
 func (a *App) GetTopChannelsForTeamSince(teamID, userID string, opts *model.InsightsOpts) (*model.TopChannelList, *model.AppError) {
 	if !a.Config().FeatureFlags.InsightsEnabled {
 		return nil, model.NewAppError("GetTopChannelsForTeamSince", "api.insights.feature_disabled", nil, "", http.StatusNotImplemented)
@@ -135,6 +135,43 @@ func (a *App) UpdateSidebarCategories(userID, teamID string, categories []*model
 	return updatedCategories, nil
 }
 
+// UpdateSidebarCategoriesAndOrder replaces the user's sidebar categories on the team and their order in one
+// go, publishing a single websocket event for the whole change.
+func (a *App) UpdateSidebarCategoriesAndOrder(userID, teamID string, categories *model.OrderedSidebarCategories) (*model.OrderedSidebarCategories, *model.AppError) {
+	updatedCategories, originalCategories, err := a.Srv().Store.Channel().UpdateSidebarCategoriesAndOrder(userID, teamID, categories.Categories, categories.Order)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		var invErr *store.ErrInvalidInput
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateSidebarCategoriesAndOrder", "app.channel.sidebar_categories.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		case errors.As(err, &invErr):
+			return nil, model.NewAppError("UpdateSidebarCategoriesAndOrder", "app.channel.sidebar_categories.app_error", nil, invErr.Error(), http.StatusBadRequest)
+		default:
+			return nil, model.NewAppError("UpdateSidebarCategoriesAndOrder", "app.channel.sidebar_categories.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	message := model.NewWebSocketEvent(model.WebsocketEventSidebarCategoryUpdated, teamID, "", userID, nil)
+
+	updatedCategoriesJSON, jsonErr := json.Marshal(updatedCategories)
+	if jsonErr != nil {
+		mlog.Warn("Failed to encode original categories to JSON", mlog.Err(jsonErr))
+	}
+
+	message.Add("updatedCategories", string(updatedCategoriesJSON))
+	message.Add("order", categories.Order)
+
+	a.Publish(message)
+
+	a.muteChannelsForUpdatedCategories(userID, updatedCategories, originalCategories)
+
+	return &model.OrderedSidebarCategories{
+		Categories: updatedCategories,
+		Order:      categories.Order,
+	}, nil
+}
+
 func (a *App) muteChannelsForUpdatedCategories(userID string, updatedCategories []*model.SidebarCategoryWithChannels, originalCategories []*model.SidebarCategoryWithChannels) {
 	var channelsToMute []string
 	var channelsToUnmute []string
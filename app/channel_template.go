@@ -0,0 +1,122 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// CreateChannelTemplate saves a new channel template for the template's team.
+func (a *App) CreateChannelTemplate(template *model.ChannelTemplate) (*model.ChannelTemplate, *model.AppError) {
+	template, err := a.Srv().Store.ChannelTemplate().Save(template)
+	if err != nil {
+		var appErr *model.AppError
+		var invErr *store.ErrInvalidInput
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &invErr):
+			return nil, model.NewAppError("CreateChannelTemplate", "app.channel_template.save.existing.app_error", nil, invErr.Error(), http.StatusBadRequest)
+		default:
+			return nil, model.NewAppError("CreateChannelTemplate", "app.channel_template.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return template, nil
+}
+
+// GetChannelTemplate returns the channel template with the given id.
+func (a *App) GetChannelTemplate(templateID string) (*model.ChannelTemplate, *model.AppError) {
+	template, err := a.Srv().Store.ChannelTemplate().Get(templateID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetChannelTemplate", "app.channel_template.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetChannelTemplate", "app.channel_template.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return template, nil
+}
+
+// GetChannelTemplatesForTeam returns the channel templates of the team ordered by name.
+func (a *App) GetChannelTemplatesForTeam(teamID string) ([]*model.ChannelTemplate, *model.AppError) {
+	templates, err := a.Srv().Store.ChannelTemplate().GetForTeam(teamID)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelTemplatesForTeam", "app.channel_template.get_for_team.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return templates, nil
+}
+
+// DeleteChannelTemplate deletes the channel template. The channels already created from it are left as they are.
+func (a *App) DeleteChannelTemplate(templateID string) *model.AppError {
+	if err := a.Srv().Store.ChannelTemplate().Delete(templateID); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteChannelTemplate", "app.channel_template.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("DeleteChannelTemplate", "app.channel_template.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// CreateChannelFromTemplate creates a channel with the given name in the team using the type, purpose and
+// header of the template, then posts and pins each of the template's pinned messages in it as the user. Templates
+// with more pinned messages than ServiceSettings.MaxPinnedPostsPerChannel allows are rejected.
+func (a *App) CreateChannelFromTemplate(c *request.Context, teamID, templateID, name, userID string) (*model.Channel, *model.AppError) {
+	template, appErr := a.GetChannelTemplate(templateID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Templates are only visible within their own team.
+	if template.TeamId != teamID {
+		return nil, model.NewAppError("CreateChannelFromTemplate", "app.channel_template.get.not_found.app_error", nil, "template_id="+templateID+", team_id="+teamID, http.StatusNotFound)
+	}
+
+	if limit := *a.Config().ServiceSettings.MaxPinnedPostsPerChannel; limit > 0 && len(template.PinnedMessages) > limit {
+		return nil, model.NewAppError("CreateChannelFromTemplate", "app.channel_template.pinned_messages_limit.app_error", map[string]interface{}{"Count": len(template.PinnedMessages), "Limit": limit}, "template_id="+templateID, http.StatusBadRequest)
+	}
+
+	channel := &model.Channel{
+		TeamId:      teamID,
+		Name:        name,
+		DisplayName: name,
+		Type:        template.Type,
+		Purpose:     template.Purpose,
+		Header:      template.Header,
+		CreatorId:   userID,
+	}
+
+	channel, appErr = a.CreateChannel(c, channel, true)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	for _, message := range template.PinnedMessages {
+		post := &model.Post{
+			UserId:    userID,
+			ChannelId: channel.Id,
+			Message:   message,
+			IsPinned:  true,
+		}
+
+		if _, appErr := a.CreatePost(c, post, channel, false, false); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return channel, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestCreateChannelFromTemplate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	template, appErr := th.App.CreateChannelTemplate(&model.ChannelTemplate{
+		TeamId:         th.BasicTeam.Id,
+		Name:           "Incident",
+		Type:           model.ChannelTypePrivate,
+		Purpose:        "Coordinate the response to an incident",
+		Header:         "Runbook: https://example.com/runbook",
+		PinnedMessages: model.StringArray{"Status: investigating", "Owner: TBD"},
+		CreatorId:      th.BasicUser.Id,
+	})
+	require.Nil(t, appErr)
+
+	t.Run("applies the template", func(t *testing.T) {
+		channel, appErr := th.App.CreateChannelFromTemplate(th.Context, th.BasicTeam.Id, template.Id, "incident-1", th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "incident-1", channel.Name)
+		assert.Equal(t, th.BasicTeam.Id, channel.TeamId)
+		assert.Equal(t, model.ChannelTypePrivate, channel.Type)
+		assert.Equal(t, template.Purpose, channel.Purpose)
+		assert.Equal(t, template.Header, channel.Header)
+		assert.Equal(t, th.BasicUser.Id, channel.CreatorId)
+
+		_, appErr = th.App.GetChannelMember(context.Background(), channel.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		pinned, appErr := th.App.GetPinnedPosts(channel.Id)
+		require.Nil(t, appErr)
+
+		messages := []string{}
+		for _, post := range pinned.ToSlice() {
+			assert.Equal(t, th.BasicUser.Id, post.UserId)
+			messages = append(messages, post.Message)
+		}
+		assert.ElementsMatch(t, []string(template.PinnedMessages), messages)
+	})
+
+	t.Run("template from another team", func(t *testing.T) {
+		otherTeam := th.CreateTeam()
+
+		_, appErr := th.App.CreateChannelFromTemplate(th.Context, otherTeam.Id, template.Id, "incident-2", th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("missing template", func(t *testing.T) {
+		_, appErr := th.App.CreateChannelFromTemplate(th.Context, th.BasicTeam.Id, model.NewId(), "incident-3", th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel_template.get.not_found.app_error", appErr.Id)
+	})
+
+	t.Run("more pinned messages than the limit", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.MaxPinnedPostsPerChannel = 1
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.MaxPinnedPostsPerChannel = 0
+		})
+
+		_, appErr := th.App.CreateChannelFromTemplate(th.Context, th.BasicTeam.Id, template.Id, "incident-4", th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel_template.pinned_messages_limit.app_error", appErr.Id)
+
+		_, appErr = th.App.GetChannelByName("incident-4", th.BasicTeam.Id, false)
+		require.NotNil(t, appErr)
+	})
+}
+
+func TestChannelTemplates(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	template, appErr := th.App.CreateChannelTemplate(&model.ChannelTemplate{
+		TeamId:    th.BasicTeam.Id,
+		Name:      "Project",
+		CreatorId: th.BasicUser.Id,
+	})
+	require.Nil(t, appErr)
+	assert.Equal(t, model.ChannelTypeOpen, template.Type)
+
+	templates, appErr := th.App.GetChannelTemplatesForTeam(th.BasicTeam.Id)
+	require.Nil(t, appErr)
+	require.Len(t, templates, 1)
+	assert.Equal(t, template.Id, templates[0].Id)
+
+	_, appErr = th.App.CreateChannelTemplate(&model.ChannelTemplate{TeamId: th.BasicTeam.Id, CreatorId: th.BasicUser.Id})
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.channel_template.is_valid.name.app_error", appErr.Id)
+
+	appErr = th.App.DeleteChannelTemplate(template.Id)
+	require.Nil(t, appErr)
+
+	templates, appErr = th.App.GetChannelTemplatesForTeam(th.BasicTeam.Id)
+	require.Nil(t, appErr)
+	assert.Empty(t, templates)
+
+	appErr = th.App.DeleteChannelTemplate(template.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/v6/app/users"
 	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
 	"github.com/mattermost/mattermost-server/v6/store/storetest/mocks"
 )
 
@@ -347,6 +349,36 @@ func TestJoinDefaultChannelsExperimentalDefaultChannels(t *testing.T) {
 	}
 }
 
+func TestJoinDefaultChannelsTeamDefaultChannels(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	teamChannel := th.CreateChannel(th.BasicTeam)
+	archivedChannel := th.CreateChannel(th.BasicTeam)
+	appErr := th.App.DeleteChannel(th.Context, archivedChannel, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	defaultChannels := model.StringArray{teamChannel.Name, archivedChannel.Name, "missing-channel"}
+	_, appErr = th.App.PatchTeam(th.BasicTeam.Id, &model.TeamPatch{DefaultChannels: &defaultChannels})
+	require.Nil(t, appErr)
+
+	user := th.CreateUser()
+	appErr = th.App.JoinDefaultChannels(th.Context, th.BasicTeam.Id, user, false, "")
+	require.Nil(t, appErr)
+
+	for _, channelName := range []string{model.DefaultChannelName, "off-topic", teamChannel.Name} {
+		channel, err := th.App.GetChannelByName(channelName, th.BasicTeam.Id, false)
+		require.Nil(t, err)
+
+		member, err := th.App.GetChannelMember(context.Background(), channel.Id, user.Id)
+		require.Nil(t, err)
+		require.NotNil(t, member)
+	}
+
+	_, err := th.App.GetChannelMember(context.Background(), archivedChannel.Id, user.Id)
+	require.NotNil(t, err)
+}
+
 func TestCreateChannelPublicCreatesChannelMemberHistoryRecord(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -443,6 +475,85 @@ func TestGetOrCreateDirectChannel(t *testing.T) {
 	})
 }
 
+func TestMergeDuplicateDirectChannels(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user1 := th.BasicUser
+	user2 := th.BasicUser2
+
+	canonical, appErr := th.App.GetOrCreateDirectChannel(th.Context, user1.Id, user2.Id)
+	require.Nil(t, appErr)
+
+	// Seed a duplicate the way older servers could create them, under a different name.
+	duplicate, err := th.App.Srv().Store.Channel().SaveDirectChannel(
+		&model.Channel{Name: model.NewId(), DisplayName: "duplicate", Type: model.ChannelTypeDirect},
+		&model.ChannelMember{UserId: user1.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+		&model.ChannelMember{UserId: user2.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+	)
+	require.NoError(t, err)
+
+	canonicalPost := th.CreatePost(canonical)
+	duplicatePost := th.CreatePost(duplicate)
+
+	_, err = th.App.Srv().Store.Channel().UpdateLastViewedAt([]string{duplicate.Id}, user2.Id)
+	require.NoError(t, err)
+	duplicateMember, appErr := th.App.GetChannelMember(context.Background(), duplicate.Id, user2.Id)
+	require.Nil(t, appErr)
+
+	merged, appErr := th.App.MergeDuplicateDirectChannels(user1.Id, user2.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, canonical.Id, merged.Id)
+	assert.Equal(t, int64(2), merged.TotalMsgCount)
+
+	assertMerged := func(t *testing.T) {
+		t.Helper()
+
+		posts, appErr := th.App.GetPosts(canonical.Id, 0, 10)
+		require.Nil(t, appErr)
+		assert.ElementsMatch(t, []string{canonicalPost.Id, duplicatePost.Id}, posts.Order)
+
+		posts, appErr = th.App.GetPosts(duplicate.Id, 0, 10)
+		require.Nil(t, appErr)
+		assert.Empty(t, posts.Order)
+
+		archived, appErr := th.App.GetChannel(duplicate.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, archived.DeleteAt)
+
+		found, appErr := th.App.GetOrCreateDirectChannel(th.Context, user2.Id, user1.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, canonical.Id, found.Id)
+
+		member, appErr := th.App.GetChannelMember(context.Background(), canonical.Id, user2.Id)
+		require.Nil(t, appErr)
+		assert.GreaterOrEqual(t, member.LastViewedAt, duplicateMember.LastViewedAt)
+		assert.Equal(t, int64(1), merged.TotalMsgCount-member.MsgCount)
+	}
+
+	assertMerged(t)
+
+	t.Run("merging again is a no-op", func(t *testing.T) {
+		merged, appErr = th.App.MergeDuplicateDirectChannels(user2.Id, user1.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, canonical.Id, merged.Id)
+		assert.Equal(t, int64(2), merged.TotalMsgCount)
+
+		assertMerged(t)
+	})
+
+	t.Run("users without duplicates", func(t *testing.T) {
+		user3 := th.CreateUser()
+
+		channel, appErr := th.App.GetOrCreateDirectChannel(th.Context, user1.Id, user3.Id)
+		require.Nil(t, appErr)
+
+		merged, appErr := th.App.MergeDuplicateDirectChannels(user1.Id, user3.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, channel.Id, merged.Id)
+	})
+}
+
 func TestCreateGroupChannelCreatesChannelMemberHistoryRecord(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -614,6 +725,228 @@ func TestLeaveLastChannel(t *testing.T) {
 	})
 }
 
+func TestAddUserToChannelDefaultNotifyLevel(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	_, appErr := th.App.PatchChannel(th.Context, channel, &model.ChannelPatch{DefaultNotifyLevel: model.NewString(model.ChannelNotifyMention)}, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	channel, appErr = th.App.GetChannel(channel.Id)
+	require.Nil(t, appErr)
+	require.Equal(t, model.ChannelNotifyMention, channel.DefaultNotifyLevel)
+
+	t.Run("new member inherits the channel default", func(t *testing.T) {
+		member, appErr := th.App.AddChannelMember(th.Context, th.BasicUser2.Id, channel, ChannelMemberOpts{})
+		require.Nil(t, appErr)
+		assert.Equal(t, model.ChannelNotifyMention, member.NotifyProps[model.DesktopNotifyProp])
+		assert.Equal(t, model.ChannelNotifyMention, member.NotifyProps[model.PushNotifyProp])
+
+		member, appErr = th.App.GetChannelMember(context.Background(), channel.Id, th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.ChannelNotifyMention, member.NotifyProps[model.DesktopNotifyProp])
+		assert.Equal(t, model.ChannelNotifyMention, member.NotifyProps[model.PushNotifyProp])
+	})
+
+	t.Run("existing members are unaffected", func(t *testing.T) {
+		member, appErr := th.App.GetChannelMember(context.Background(), channel.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.ChannelNotifyDefault, member.NotifyProps[model.DesktopNotifyProp])
+		assert.Equal(t, model.ChannelNotifyDefault, member.NotifyProps[model.PushNotifyProp])
+	})
+
+	t.Run("channel creator", func(t *testing.T) {
+		created, appErr := th.App.CreateChannel(th.Context, &model.Channel{
+			DisplayName:        "Announcements",
+			Name:               "announcements-" + model.NewId(),
+			Type:               model.ChannelTypeOpen,
+			TeamId:             th.BasicTeam.Id,
+			CreatorId:          th.BasicUser.Id,
+			DefaultNotifyLevel: model.ChannelNotifyNone,
+		}, true)
+		require.Nil(t, appErr)
+
+		member, appErr := th.App.GetChannelMember(context.Background(), created.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.ChannelNotifyNone, member.NotifyProps[model.DesktopNotifyProp])
+		assert.Equal(t, model.ChannelNotifyNone, member.NotifyProps[model.PushNotifyProp])
+	})
+
+	t.Run("channel without a default", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+
+		member, appErr := th.App.AddChannelMember(th.Context, user.Id, th.BasicChannel, ChannelMemberOpts{})
+		require.Nil(t, appErr)
+		assert.Equal(t, model.ChannelNotifyDefault, member.NotifyProps[model.DesktopNotifyProp])
+		assert.Equal(t, model.ChannelNotifyDefault, member.NotifyProps[model.PushNotifyProp])
+	})
+}
+
+func TestAddUserToChannelMaxChannelsPerUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+
+	count, err := th.App.Srv().Store.Channel().GetTeamChannelCountForUser(th.BasicTeam.Id, user.Id)
+	require.NoError(t, err)
+
+	// Channels are created up front as the creator is subject to the limit too.
+	channel1 := th.CreateChannel(th.BasicTeam)
+	channel2 := th.CreateChannel(th.BasicTeam)
+	adminChannels := make([]*model.Channel, count+2)
+	for i := range adminChannels {
+		adminChannels[i] = th.CreateChannel(th.BasicTeam)
+	}
+	channel3 := th.CreateChannel(th.BasicTeam)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.MaxChannelsPerUserPerTeam = count + 1
+	})
+	defer th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.MaxChannelsPerUserPerTeam = 0
+	})
+
+	_, appErr := th.App.AddUserToChannel(user, channel1, false)
+	require.Nil(t, appErr)
+
+	t.Run("joining past the limit fails", func(t *testing.T) {
+		_, appErr := th.App.AddUserToChannel(user, channel2, false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.channel.add_user_to_channel.max_channels_per_user.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		appErr = th.App.JoinChannel(th.Context, channel2, user.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.channel.add_user_to_channel.max_channels_per_user.app_error", appErr.Id)
+	})
+
+	t.Run("existing memberships are unaffected", func(t *testing.T) {
+		_, appErr := th.App.AddUserToChannel(user, channel1, false)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("archived channels don't count", func(t *testing.T) {
+		appErr := th.App.DeleteChannel(th.Context, channel1, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.AddUserToChannel(user, channel2, false)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("admins are exempt", func(t *testing.T) {
+		admin := th.CreateUser()
+		th.LinkUserToTeam(admin, th.BasicTeam)
+		_, appErr := th.App.UpdateUserRoles(admin.Id, model.SystemUserRoleId+" "+model.SystemAdminRoleId, false)
+		require.Nil(t, appErr)
+		admin, appErr = th.App.GetUser(admin.Id)
+		require.Nil(t, appErr)
+
+		for _, channel := range adminChannels {
+			_, appErr := th.App.AddUserToChannel(admin, channel, false)
+			require.Nil(t, appErr)
+		}
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.MaxChannelsPerUserPerTeam = 0
+		})
+
+		_, appErr := th.App.AddUserToChannel(user, channel3, false)
+		require.Nil(t, appErr)
+	})
+}
+
+func TestCreateChannelMaxChannelsPerUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	count, err := th.App.Srv().Store.Channel().GetTeamChannelCountForUser(th.BasicTeam.Id, th.BasicUser.Id)
+	require.NoError(t, err)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.MaxChannelsPerUserPerTeam = count
+	})
+	defer th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.MaxChannelsPerUserPerTeam = 0
+	})
+
+	channel := &model.Channel{
+		DisplayName: "Over the limit",
+		Name:        "over-the-limit-" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+		TeamId:      th.BasicTeam.Id,
+	}
+
+	_, appErr := th.App.CreateChannelWithUser(th.Context, channel, th.BasicUser.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "api.channel.add_user_to_channel.max_channels_per_user.app_error", appErr.Id)
+
+	_, nErr := th.App.Srv().Store.Channel().GetByName(th.BasicTeam.Id, channel.Name, true)
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(nErr, &nfErr), "the channel should not have been saved")
+
+	t.Run("default channels past the limit are skipped", func(t *testing.T) {
+		user := th.CreateUser()
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.MaxChannelsPerUserPerTeam = 1
+		})
+
+		th.LinkUserToTeam(user, th.BasicTeam)
+
+		userCount, err := th.App.Srv().Store.Channel().GetTeamChannelCountForUser(th.BasicTeam.Id, user.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), userCount)
+	})
+}
+
+func TestSnoozeChannel(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("snoozes the channel until the given time", func(t *testing.T) {
+		until := model.GetMillis() + 60*60*1000
+
+		member, appErr := th.App.SnoozeChannel(th.BasicChannel.Id, th.BasicUser.Id, until)
+		require.Nil(t, appErr)
+		assert.Equal(t, strconv.FormatInt(until, 10), member.NotifyProps[model.SnoozeUntilNotifyProp])
+		assert.True(t, member.IsChannelSnoozed(model.GetMillis()))
+
+		member, appErr = th.App.GetChannelMember(context.Background(), th.BasicChannel.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.True(t, member.IsChannelSnoozed(model.GetMillis()))
+	})
+
+	t.Run("rejects a time in the past", func(t *testing.T) {
+		_, appErr := th.App.SnoozeChannel(th.BasicChannel.Id, th.BasicUser.Id, model.GetMillis()-1000)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel.snooze.invalid_until.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("clears the snooze", func(t *testing.T) {
+		_, appErr := th.App.SnoozeChannel(th.BasicChannel.Id, th.BasicUser.Id, model.GetMillis()+60*60*1000)
+		require.Nil(t, appErr)
+
+		member, appErr := th.App.ClearChannelSnooze(th.BasicChannel.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, member.NotifyProps[model.SnoozeUntilNotifyProp])
+		assert.False(t, member.IsChannelSnoozed(model.GetMillis()))
+	})
+
+	t.Run("user not in the channel", func(t *testing.T) {
+		user := th.CreateUser()
+
+		_, appErr := th.App.SnoozeChannel(th.BasicChannel.Id, user.Id, model.GetMillis()+60*60*1000)
+		require.NotNil(t, appErr)
+		assert.Equal(t, MissingChannelMemberError, appErr.Id)
+	})
+}
+
 func TestAddChannelMemberNoUserRequestor(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
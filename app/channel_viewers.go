@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sync"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// channelViewerChange describes a user starting or stopping to view a channel.
+type channelViewerChange struct {
+	channelID string
+	userID    string
+	viewing   bool
+}
+
+type focusedChannel struct {
+	channelID string
+	userID    string
+}
+
+// channelViewerIndex tracks the channel that each websocket connection has focused. Connections are
+// spread over the hubs by user, so the index is shared by all of them and guarded by a mutex.
+// A user is viewing a channel for as long as any of their connections has it focused.
+//
+// The index only covers the connections of this server. Changes are published to the rest of the
+// cluster, but a node that goes away without its hubs unregistering their connections never
+// publishes that its users stopped viewing, so clients should drop the viewers they learnt of
+// through a node once they reconnect.
+type channelViewerIndex struct {
+	mut sync.Mutex
+	// byConnection stores the channel focused by a given connection ID.
+	byConnection map[string]focusedChannel
+	// byChannel stores, for a given channel, the number of connections of each user that have
+	// it focused.
+	byChannel map[string]map[string]int
+}
+
+func newChannelViewerIndex() *channelViewerIndex {
+	return &channelViewerIndex{
+		byConnection: make(map[string]focusedChannel),
+		byChannel:    make(map[string]map[string]int),
+	}
+}
+
+// Focus records that the connection has the channel focused in place of the one it had focused
+// before, and returns the resulting changes to the viewers of both channels.
+func (i *channelViewerIndex) Focus(connectionID, userID, channelID string) []channelViewerChange {
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
+	if focused, ok := i.byConnection[connectionID]; ok && focused.channelID == channelID && focused.userID == userID {
+		return nil
+	}
+
+	changes := i.blur(connectionID)
+
+	i.byConnection[connectionID] = focusedChannel{channelID: channelID, userID: userID}
+	viewers, ok := i.byChannel[channelID]
+	if !ok {
+		viewers = make(map[string]int)
+		i.byChannel[channelID] = viewers
+	}
+	viewers[userID]++
+	if viewers[userID] == 1 {
+		changes = append(changes, channelViewerChange{channelID: channelID, userID: userID, viewing: true})
+	}
+
+	return changes
+}
+
+// Blur records that the connection no longer has any channel focused, and returns the resulting
+// changes to the viewers of the channel it had focused.
+func (i *channelViewerIndex) Blur(connectionID string) []channelViewerChange {
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
+	return i.blur(connectionID)
+}
+
+func (i *channelViewerIndex) blur(connectionID string) []channelViewerChange {
+	focused, ok := i.byConnection[connectionID]
+	if !ok {
+		return nil
+	}
+	delete(i.byConnection, connectionID)
+
+	viewers := i.byChannel[focused.channelID]
+	viewers[focused.userID]--
+	if viewers[focused.userID] > 0 {
+		return nil
+	}
+
+	delete(viewers, focused.userID)
+	if len(viewers) == 0 {
+		delete(i.byChannel, focused.channelID)
+	}
+	return []channelViewerChange{{channelID: focused.channelID, userID: focused.userID, viewing: false}}
+}
+
+// Viewers returns the IDs of the users that have the channel focused.
+func (i *channelViewerIndex) Viewers(channelID string) []string {
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
+	userIDs := make([]string, 0, len(i.byChannel[channelID]))
+	for userID := range i.byChannel[channelID] {
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs
+}
+
+// publishChannelViewerChanges lets the members of each channel know which users started or
+// stopped viewing it.
+func (s *Server) publishChannelViewerChanges(changes []channelViewerChange) {
+	for _, change := range changes {
+		message := model.NewWebSocketEvent(model.WebsocketEventChannelViewerChanged, "", change.channelID, "", nil)
+		message.Add("channel_id", change.channelID)
+		message.Add("user_id", change.userID)
+		message.Add("viewing", change.viewing)
+		s.Publish(message)
+	}
+}
+
+// FocusChannel records that the websocket connection of the user has the channel focused in place
+// of the channel it had focused before, and returns the IDs of the users currently viewing the
+// channel on this server.
+func (a *App) FocusChannel(connectionID, userID, channelID string) []string {
+	changes := a.Srv().channelViewers.Focus(connectionID, userID, channelID)
+	a.Srv().publishChannelViewerChanges(changes)
+
+	return a.Srv().channelViewers.Viewers(channelID)
+}
+
+// BlurChannel records that the websocket connection no longer has any channel focused.
+func (a *App) BlurChannel(connectionID string) {
+	changes := a.Srv().channelViewers.Blur(connectionID)
+	a.Srv().publishChannelViewerChanges(changes)
+}
+
+// GetChannelViewers returns the IDs of the users currently viewing the channel on this server.
+func (a *App) GetChannelViewers(channelID string) []string {
+	return a.Srv().channelViewers.Viewers(channelID)
+}
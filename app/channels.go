@@ -68,6 +68,8 @@ type Channels struct {
 	clientConfig         atomic.Value
 	clientConfigHash     atomic.Value
 	limitedClientConfig  atomic.Value
+	// outgoingWebhookPayloadTemplate holds the *outgoingWebhookPayloadTemplate parsed from the config.
+	outgoingWebhookPayloadTemplate atomic.Value
 
 	// cached counts that are used during notice condition validation
 	cachedPostCount   int64
@@ -76,13 +78,15 @@ type Channels struct {
 	// previously fetched notices
 	cachedNotices model.ProductNotices
 
-	AccountMigration einterfaces.AccountMigrationInterface
-	Compliance       einterfaces.ComplianceInterface
-	DataRetention    einterfaces.DataRetentionInterface
-	MessageExport    einterfaces.MessageExportInterface
-	Saml             einterfaces.SamlInterface
-	Notification     einterfaces.NotificationInterface
-	Ldap             einterfaces.LdapInterface
+	AccountMigration  einterfaces.AccountMigrationInterface
+	Compliance        einterfaces.ComplianceInterface
+	DataRetention     einterfaces.DataRetentionInterface
+	MessageExport     einterfaces.MessageExportInterface
+	Saml              einterfaces.SamlInterface
+	Notification      einterfaces.NotificationInterface
+	Ldap              einterfaces.LdapInterface
+	PasswordValidator einterfaces.PasswordValidatorInterface
+	FileScanner       einterfaces.FileScannerInterface
 
 	// These are used to prevent concurrent upload requests
 	// for a given upload session which could cause inconsistencies
@@ -90,6 +94,9 @@ type Channels struct {
 	uploadLockMapMut sync.Mutex
 	uploadLockMap    map[string]bool
 
+	searchRateLimiter       *keyedRateLimiter
+	announcementRateLimiter *keyedRateLimiter
+
 	imgDecoder *imaging.Decoder
 	imgEncoder *imaging.Encoder
 
@@ -111,10 +118,22 @@ func init() {
 }
 
 func NewChannels(s *Server, services map[ServiceKey]interface{}) (*Channels, error) {
+	searchRateLimiter, err := newKeyedRateLimiter()
+	if err != nil {
+		return nil, err
+	}
+
+	announcementRateLimiter, err := newKeyedRateLimiter()
+	if err != nil {
+		return nil, err
+	}
+
 	ch := &Channels{
-		srv:           s,
-		imageProxy:    imageproxy.MakeImageProxy(s, s.httpService, s.Log),
-		uploadLockMap: map[string]bool{},
+		srv:                     s,
+		imageProxy:              imageproxy.MakeImageProxy(s, s.httpService, s.Log),
+		uploadLockMap:           map[string]bool{},
+		searchRateLimiter:       searchRateLimiter,
+		announcementRateLimiter: announcementRateLimiter,
 	}
 
 	// To get another service:
@@ -184,6 +203,12 @@ func NewChannels(s *Server, services map[ServiceKey]interface{}) (*Channels, err
 	if notificationInterface != nil {
 		ch.Notification = notificationInterface(New(ServerConnector(ch)))
 	}
+	if passwordValidatorInterface != nil {
+		ch.PasswordValidator = passwordValidatorInterface(New(ServerConnector(ch)))
+	}
+	if fileScannerInterface != nil {
+		ch.FileScanner = fileScannerInterface(New(ServerConnector(ch)))
+	}
 	if samlInterfaceNew != nil {
 		ch.Saml = samlInterfaceNew(New(ServerConnector(ch)))
 		if err := ch.Saml.ConfigureSP(); err != nil {
@@ -197,6 +222,11 @@ func NewChannels(s *Server, services map[ServiceKey]interface{}) (*Channels, err
 		})
 	}
 
+	ch.loadOutgoingWebhookPayloadTemplate(ch.cfgSvc.Config())
+	ch.AddConfigListener(func(_, cfg *model.Config) {
+		ch.loadOutgoingWebhookPayloadTemplate(cfg)
+	})
+
 	var imgErr error
 	decoderConcurrency := int(*ch.cfgSvc.Config().FileSettings.MaxImageDecoderConcurrency)
 	if decoderConcurrency == -1 {
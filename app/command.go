@@ -70,11 +70,19 @@ func (a *App) CreateCommandPost(c *request.Context, post *model.Post, teamID str
 		model.ParseSlackAttachment(post, response.Attachments)
 	}
 
+	// Attachments may also have been returned through the response props.
+	attachments := post.Attachments()
+	for _, attachment := range attachments {
+		if err := attachment.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	if response.ResponseType == model.CommandResponseTypeInChannel {
 		return a.CreatePostMissingChannel(c, post, true)
 	}
 
-	if (response.ResponseType == "" || response.ResponseType == model.CommandResponseTypeEphemeral) && (response.Text != "" || response.Attachments != nil) {
+	if (response.ResponseType == "" || response.ResponseType == model.CommandResponseTypeEphemeral) && (response.Text != "" || response.Attachments != nil || len(attachments) > 0) {
 		a.SendEphemeralPost(post.UserId, post)
 	}
 
@@ -577,6 +585,7 @@ func (a *App) HandleCommandResponsePost(c *request.Context, command *model.Comma
 	post.UserId = args.UserId
 	post.Type = response.Type
 	post.SetProps(response.Props)
+	post.SanitizeProps()
 
 	if response.ChannelId != "" {
 		_, err := a.GetChannelMember(context.Background(), response.ChannelId, args.UserId)
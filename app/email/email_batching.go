@@ -285,13 +285,13 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 				"TimeZone": timezone,
 			})
 
-			MessageURL := siteURL + "/" + notification.teamName + "/pl/" + notification.post.Id
+			MessageURL := model.MakePermalink(siteURL, notification.teamName, notification.post.Id)
 
 			channelDisplayName := channel.DisplayName
 			showChannelIcon := true
 			otherChannelMembersCount := 0
 
-			if threadsEnabled && notification.post.RootId != "" {
+			if threadsEnabled && notification.post.IsReply() {
 				props := map[string]interface{}{"channelName": channelDisplayName}
 				channelDisplayName = translateFunc("api.push_notification.title.collapsed_threads", props)
 				if channel.Type == model.ChannelTypeDirect {
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"image/color"
 	"image/color/palette"
 	"image/draw"
 	"image/gif"
@@ -88,6 +89,42 @@ func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartIma
 	return emoji, nil
 }
 
+// createPlaceholderEmoji creates a custom emoji with the given name and a plain grey image, for
+// importers to stand in for emojis that don't exist in Mattermost.
+func (a *App) createPlaceholderEmoji(name, creatorID string) (*model.Emoji, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableCustomEmoji {
+		return nil, model.NewAppError("createPlaceholderEmoji", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	emoji := &model.Emoji{
+		Name:      name,
+		CreatorId: creatorID,
+	}
+	emoji.PreSave()
+	if err := emoji.IsValid(); err != nil {
+		return nil, err
+	}
+
+	img := image.NewGray(image.Rect(0, 0, MaxEmojiWidth, MaxEmojiHeight))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Gray{Y: 0xa0}), image.Point{}, draw.Src)
+
+	buf := bytes.NewBuffer(nil)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, model.NewAppError("createPlaceholderEmoji", "api.emoji.upload.image.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, err := a.WriteFile(buf, getEmojiImagePath(emoji.Id)); err != nil {
+		return nil, err
+	}
+
+	emoji, err := a.Srv().Store.Emoji().Save(emoji)
+	if err != nil {
+		return nil, model.NewAppError("createPlaceholderEmoji", "app.emoji.create.internal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return emoji, nil
+}
+
 func (a *App) GetEmojiList(page, perPage int, sort string) ([]*model.Emoji, *model.AppError) {
 	list, err := a.Srv().Store.Emoji().GetList(page*perPage, perPage, sort)
 	if err != nil {
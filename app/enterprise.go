@@ -105,6 +105,18 @@ func RegisterNotificationInterface(f func(*App) einterfaces.NotificationInterfac
 	notificationInterface = f
 }
 
+var passwordValidatorInterface func(*App) einterfaces.PasswordValidatorInterface
+
+func RegisterPasswordValidatorInterface(f func(*App) einterfaces.PasswordValidatorInterface) {
+	passwordValidatorInterface = f
+}
+
+var fileScannerInterface func(*App) einterfaces.FileScannerInterface
+
+func RegisterFileScannerInterface(f func(*App) einterfaces.FileScannerInterface) {
+	fileScannerInterface = f
+}
+
 var licenseInterface func(*Server) einterfaces.LicenseInterface
 
 func RegisterLicenseInterface(f func(*Server) einterfaces.LicenseInterface) {
@@ -25,6 +25,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/v6/app/imaging"
 	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/einterfaces"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/plugin"
 	"github.com/mattermost/mattermost-server/v6/services/docextractor"
@@ -682,6 +683,11 @@ func (a *App) UploadFileX(c *request.Context, channelID, name string, input io.R
 	if t.ContentLength > t.maxFileSize {
 		return nil, t.newAppError("api.file.upload_file.too_large_detailed.app_error", http.StatusRequestEntityTooLarge, "Length", t.ContentLength, "Limit", t.maxFileSize)
 	}
+	if t.ChannelId != "" {
+		if appErr := a.checkChannelFileExtension(t.ChannelId, t.Name); appErr != nil {
+			return nil, appErr
+		}
+	}
 
 	t.init(a)
 
@@ -718,6 +724,10 @@ func (a *App) UploadFileX(c *request.Context, channelID, name string, input io.R
 		return nil, aerr
 	}
 
+	if aerr = a.scanFileAtPath(t.fileinfo); aerr != nil {
+		return nil, aerr
+	}
+
 	if !t.Raw && t.fileinfo.IsImage() {
 		file, aerr = a.FileReader(t.fileinfo.Path)
 		if aerr != nil {
@@ -910,12 +920,36 @@ func (t UploadFileTask) newAppError(id string, httpStatus int, extra ...interfac
 	return model.NewAppError("uploadFileTask", id, params, "", httpStatus)
 }
 
+// checkChannelFileExtension returns an error if the channel doesn't exist, or if its allowed and blocked
+// file extensions don't let the file be uploaded to it. This costs an extra channel lookup per upload, and
+// means uploads to a channel that doesn't exist now fail with a not found error instead of being stored.
+// Callers should skip it for uploads that aren't tied to a channel.
+func (a *App) checkChannelFileExtension(channelID, filename string) *model.AppError {
+	channel, appErr := a.GetChannel(channelID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !channel.IsFileExtensionAllowed(filename) {
+		return model.NewAppError("uploadFile", "api.file.upload_file.extension_not_allowed.app_error",
+			map[string]interface{}{"Name": filename}, "channel_id="+channelID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (a *App) DoUploadFileExpectModification(c *request.Context, now time.Time, rawTeamId string, rawChannelId string, rawUserId string, rawFilename string, data []byte) (*model.FileInfo, []byte, *model.AppError) {
 	filename := filepath.Base(rawFilename)
 	teamID := filepath.Base(rawTeamId)
 	channelID := filepath.Base(rawChannelId)
 	userID := filepath.Base(rawUserId)
 
+	if rawChannelId != "" {
+		if appErr := a.checkChannelFileExtension(channelID, filename); appErr != nil {
+			return nil, data, appErr
+		}
+	}
+
 	info, err := model.GetInfoForBytes(filename, bytes.NewReader(data), len(data))
 	if err != nil {
 		err.StatusCode = http.StatusBadRequest
@@ -977,6 +1011,8 @@ func (a *App) DoUploadFileExpectModification(c *request.Context, now time.Time,
 		return nil, data, err
 	}
 
+	a.scanFile(info, bytes.NewReader(data))
+
 	if _, err := a.Srv().Store.FileInfo().Save(info); err != nil {
 		var appErr *model.AppError
 		switch {
@@ -1166,6 +1202,10 @@ func (a *App) GetFile(fileID string) ([]byte, *model.AppError) {
 		return nil, err
 	}
 
+	if info.Quarantined {
+		return nil, model.NewAppError("GetFile", "app.file.quarantined.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+	}
+
 	data, err := a.ReadFile(info.Path)
 	if err != nil {
 		return nil, err
@@ -1174,6 +1214,66 @@ func (a *App) GetFile(fileID string) ([]byte, *model.AppError) {
 	return data, nil
 }
 
+// defaultFileScanner passes every file, for servers without a registered virus scanner.
+type defaultFileScanner struct{}
+
+func (defaultFileScanner) ScanFile(info *model.FileInfo, file io.Reader) (bool, error) {
+	return true, nil
+}
+
+// FileScanner returns the registered virus scanner, or one that passes every file when none has
+// been registered.
+func (a *App) FileScanner() einterfaces.FileScannerInterface {
+	if a.ch.FileScanner != nil {
+		return a.ch.FileScanner
+	}
+	return defaultFileScanner{}
+}
+
+// scanFile quarantines the uploaded file unless the virus scanner reports it to be clean. Files
+// that can't be scanned are quarantined as well, since they may not be safe to serve.
+func (a *App) scanFile(info *model.FileInfo, file io.Reader) {
+	clean, err := a.FileScanner().ScanFile(info, file)
+	if err != nil {
+		mlog.Warn("Failed to scan file, quarantining it", mlog.String("file_id", info.Id), mlog.String("path", info.Path), mlog.Err(err))
+	} else if !clean {
+		mlog.Info("Quarantining file flagged by the virus scanner", mlog.String("file_id", info.Id), mlog.String("path", info.Path))
+	}
+
+	info.Quarantined = err != nil || !clean
+}
+
+// scanFileAtPath is scanFile for a file that has already been written to the file store.
+func (a *App) scanFileAtPath(info *model.FileInfo) *model.AppError {
+	file, appErr := a.FileReader(info.Path)
+	if appErr != nil {
+		return appErr
+	}
+	defer file.Close()
+
+	a.scanFile(info, file)
+	return nil
+}
+
+// ClearFileQuarantine allows the file to be downloaded again after it was quarantined by the virus
+// scanner.
+func (a *App) ClearFileQuarantine(fileID string) *model.AppError {
+	info, appErr := a.GetFileInfo(fileID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if err := a.Srv().Store.FileInfo().SetQuarantined(info.Id, false); err != nil {
+		return model.NewAppError("ClearFileQuarantine", "app.file_info.set_quarantined.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if info.PostId != "" {
+		a.Srv().Store.FileInfo().InvalidateFileInfosForPostCache(info.PostId, false)
+	}
+
+	return nil
+}
+
 func (a *App) CopyFileInfos(userID string, fileIDs []string) ([]string, *model.AppError) {
 	var newFileIds []string
 
@@ -1267,6 +1367,10 @@ func (a *App) SearchFilesInTeamForUser(c *request.Context, terms string, userId
 		return nil, model.NewAppError("SearchFilesInTeamForUser", "store.sql_file_info.search.disabled", nil, fmt.Sprintf("teamId=%v userId=%v", teamId, userId), http.StatusNotImplemented)
 	}
 
+	if appErr := a.checkSearchRateLimit(userId); appErr != nil {
+		return nil, appErr
+	}
+
 	finalParamsList := []*model.SearchParams{}
 
 	for _, params := range paramsList {
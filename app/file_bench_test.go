@@ -60,7 +60,7 @@ func BenchmarkUploadFile(b *testing.B) {
 	defer th.TearDown()
 
 	teamID := model.NewId()
-	channelID := model.NewId()
+	channelID := th.BasicChannel.Id
 	userID := model.NewId()
 
 	mb := func(i int) int {
@@ -4,9 +4,12 @@
 package app
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"image"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"testing"
@@ -41,11 +44,11 @@ func TestGeneratePublicLinkHash(t *testing.T) {
 }
 
 func TestDoUploadFile(t *testing.T) {
-	th := Setup(t)
+	th := Setup(t).InitBasic()
 	defer th.TearDown()
 
 	teamID := model.NewId()
-	channelID := model.NewId()
+	channelID := th.BasicChannel.Id
 	userID := model.NewId()
 	filename := "test"
 	data := []byte("abcd")
@@ -119,6 +122,166 @@ func TestUploadFile(t *testing.T) {
 	assert.Equal(t, value, info1.Path, "Stored file at incorrect path")
 }
 
+type testFileScanner struct {
+	infected string
+	err      error
+}
+
+func (s *testFileScanner) ScanFile(info *model.FileInfo, file io.Reader) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return false, err
+	}
+	return string(data) != s.infected, nil
+}
+
+func TestUploadFileQuarantine(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	upload := func(t *testing.T, data string) *model.FileInfo {
+		t.Helper()
+
+		info, appErr := th.App.UploadFile(th.Context, []byte(data), th.BasicChannel.Id, "test.txt")
+		require.Nil(t, appErr)
+		t.Cleanup(func() {
+			th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+			th.App.RemoveFile(info.Path)
+		})
+		return info
+	}
+
+	t.Run("files pass by default", func(t *testing.T) {
+		info := upload(t, "infected")
+		assert.False(t, info.Quarantined)
+
+		_, appErr := th.App.GetFile(info.Id)
+		require.Nil(t, appErr)
+	})
+
+	th.App.ch.FileScanner = &testFileScanner{infected: "infected"}
+	defer func() { th.App.ch.FileScanner = nil }()
+
+	t.Run("clean file is not quarantined", func(t *testing.T) {
+		info := upload(t, "clean")
+		assert.False(t, info.Quarantined)
+
+		data, appErr := th.App.GetFile(info.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "clean", string(data))
+	})
+
+	t.Run("flagged file is quarantined until cleared", func(t *testing.T) {
+		info := upload(t, "infected")
+		assert.True(t, info.Quarantined)
+
+		stored, appErr := th.App.GetFileInfo(info.Id)
+		require.Nil(t, appErr)
+		assert.True(t, stored.Quarantined)
+
+		_, appErr = th.App.GetFile(info.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.quarantined.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+
+		require.Nil(t, th.App.ClearFileQuarantine(info.Id))
+
+		data, appErr := th.App.GetFile(info.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "infected", string(data))
+	})
+
+	t.Run("file is quarantined when the scan fails", func(t *testing.T) {
+		th.App.ch.FileScanner = &testFileScanner{err: errors.New("scanner unavailable")}
+		defer func() { th.App.ch.FileScanner = &testFileScanner{infected: "infected"} }()
+
+		info := upload(t, "clean")
+		assert.True(t, info.Quarantined)
+	})
+}
+
+func TestUploadFileXChannelFileExtensions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	channel.AllowedFileExtensions = model.StringArray{"txt", "pdf"}
+	channel.BlockedFileExtensions = model.StringArray{"pdf"}
+	channel, appErr := th.App.UpdateChannel(channel)
+	require.Nil(t, appErr)
+
+	upload := func(channelID, name string) (*model.FileInfo, *model.AppError) {
+		data := []byte("data")
+		return th.App.UploadFileX(th.Context, channelID, name, bytes.NewReader(data),
+			UploadFileSetTeamId(th.BasicTeam.Id),
+			UploadFileSetUserId(th.BasicUser.Id),
+			UploadFileSetTimestamp(time.Now()),
+			UploadFileSetContentLength(int64(len(data))))
+	}
+
+	requireNotAllowed := func(t *testing.T, appErr *model.AppError) {
+		t.Helper()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.file.upload_file.extension_not_allowed.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	}
+
+	t.Run("allowed extension", func(t *testing.T) {
+		info, appErr := upload(channel.Id, "notes.TXT")
+		require.Nil(t, appErr)
+		assert.Equal(t, "notes.TXT", info.Name)
+	})
+
+	t.Run("extension missing from the allowlist", func(t *testing.T) {
+		_, appErr := upload(channel.Id, "image.png")
+		requireNotAllowed(t, appErr)
+	})
+
+	t.Run("blocked extension", func(t *testing.T) {
+		_, appErr := upload(channel.Id, "report.pdf")
+		requireNotAllowed(t, appErr)
+	})
+
+	t.Run("upload sessions", func(t *testing.T) {
+		_, appErr := th.App.CreateUploadSession(&model.UploadSession{
+			Id:        model.NewId(),
+			Type:      model.UploadTypeAttachment,
+			UserId:    th.BasicUser.Id,
+			ChannelId: channel.Id,
+			Filename:  "report.pdf",
+			FileSize:  1024,
+		})
+		requireNotAllowed(t, appErr)
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		_, appErr := upload(model.NewId(), "notes.txt")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("uploading a file without a stream", func(t *testing.T) {
+		_, appErr := th.App.DoUploadFile(th.Context, time.Now(), th.BasicTeam.Id, channel.Id, th.BasicUser.Id, "report.pdf", []byte("data"))
+		requireNotAllowed(t, appErr)
+
+		_, appErr = th.App.UploadFile(th.Context, []byte("data"), channel.Id, "image.png")
+		requireNotAllowed(t, appErr)
+
+		info, appErr := th.App.DoUploadFile(th.Context, time.Now(), th.BasicTeam.Id, channel.Id, th.BasicUser.Id, "notes.txt", []byte("data"))
+		require.Nil(t, appErr)
+		assert.Equal(t, "notes.txt", info.Name)
+	})
+
+	t.Run("channels without restrictions", func(t *testing.T) {
+		_, appErr := upload(th.BasicChannel.Id, "report.pdf")
+		require.Nil(t, appErr)
+	})
+}
+
 func TestParseOldFilenames(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -294,11 +457,11 @@ func TestCreateZipFileAndAddFiles(t *testing.T) {
 }
 
 func TestCopyFileInfos(t *testing.T) {
-	th := Setup(t)
+	th := Setup(t).InitBasic()
 	defer th.TearDown()
 
 	teamID := model.NewId()
-	channelID := model.NewId()
+	channelID := th.BasicChannel.Id
 	userID := model.NewId()
 	filename := "test"
 	data := []byte("abcd")
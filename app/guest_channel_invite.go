@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// recordGuestChannelInvites remembers that the guest was invited to the channels so that they can be
+// added to them once GuestAccountsSettings.EnforceChannelInvites is enabled. Invites are recorded
+// whether or not the setting is enabled, so that enabling it later doesn't lock out existing guests.
+func (a *App) recordGuestChannelInvites(userID string, channels []*model.Channel) {
+	for _, channel := range channels {
+		invite := &model.GuestChannelInvite{
+			UserId:    userID,
+			ChannelId: channel.Id,
+		}
+		if err := a.Srv().Store.GuestChannelInvite().Save(invite); err != nil {
+			mlog.Warn("Failed to record guest channel invite", mlog.String("user_id", userID), mlog.String("channel_id", channel.Id), mlog.Err(err))
+		}
+	}
+}
+
+// useGuestChannelInvite deletes the guest's invite to the channel once they have joined it, so that
+// they can't rejoin on their own after leaving.
+func (a *App) useGuestChannelInvite(userID, channelID string) {
+	if err := a.Srv().Store.GuestChannelInvite().Delete(userID, channelID); err != nil {
+		mlog.Warn("Failed to delete guest channel invite", mlog.String("user_id", userID), mlog.String("channel_id", channelID), mlog.Err(err))
+	}
+}
+
+// checkGuestChannelInvite returns an error if the user is a guest who wasn't invited to the channel and
+// GuestAccountsSettings.EnforceChannelInvites is enabled.
+func (a *App) checkGuestChannelInvite(user *model.User, channel *model.Channel) *model.AppError {
+	if !user.IsGuest() || !*a.Config().GuestAccountsSettings.EnforceChannelInvites {
+		return nil
+	}
+
+	invited, err := a.Srv().Store.GuestChannelInvite().Exists(user.Id, channel.Id)
+	if err != nil {
+		return model.NewAppError("checkGuestChannelInvite", "app.guest_channel_invite.exists.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if !invited {
+		return model.NewAppError("checkGuestChannelInvite", "api.channel.add_guest.not_invited.app_error", nil, "user_id="+user.Id+", channel_id="+channel.Id, http.StatusForbidden)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestEnforceGuestChannelInvites(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.GuestAccountsSettings.EnforceChannelInvites = true
+	})
+
+	guest := th.CreateGuest()
+	th.LinkUserToTeam(guest, th.BasicTeam)
+
+	invitedChannel := th.CreateChannel(th.BasicTeam)
+	otherChannel := th.CreateChannel(th.BasicTeam)
+
+	th.App.recordGuestChannelInvites(guest.Id, []*model.Channel{invitedChannel})
+
+	t.Run("guest added to a channel they were invited to", func(t *testing.T) {
+		_, appErr := th.App.AddUserToChannel(guest, invitedChannel, false)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetChannelMember(context.Background(), invitedChannel.Id, guest.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("guest added to a channel they weren't invited to", func(t *testing.T) {
+		_, appErr := th.App.AddUserToChannel(guest, otherChannel, false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.channel.add_guest.not_invited.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+
+		_, appErr = th.App.GetChannelMember(context.Background(), otherChannel.Id, guest.Id)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("invite is used up once the guest joins", func(t *testing.T) {
+		invited, err := th.App.Srv().Store.GuestChannelInvite().Exists(guest.Id, invitedChannel.Id)
+		require.NoError(t, err)
+		assert.False(t, invited)
+
+		appErr := th.App.RemoveUserFromChannel(th.Context, guest.Id, th.SystemAdminUser.Id, invitedChannel)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.AddUserToChannel(guest, invitedChannel, false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.channel.add_guest.not_invited.app_error", appErr.Id)
+	})
+
+	t.Run("guest added by a member of the channel", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+
+		_, appErr := th.App.AddChannelMember(th.Context, guest.Id, channel, ChannelMemberOpts{UserRequestorID: th.BasicUser.Id})
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetChannelMember(context.Background(), channel.Id, guest.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("regular users aren't restricted", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+
+		_, appErr := th.App.AddUserToChannel(user, otherChannel, false)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("guest joining from a guest invitation", func(t *testing.T) {
+		token := model.NewToken(
+			TokenTypeGuestInvitation,
+			model.MapToJSON(map[string]string{"teamId": th.BasicTeam.Id, "channels": otherChannel.Id}),
+		)
+		require.NoError(t, th.App.Srv().Store.Token().Save(token))
+
+		_, _, appErr := th.App.AddUserToTeamByToken(th.Context, guest.Id, token.Token)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetChannelMember(context.Background(), otherChannel.Id, guest.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("enforcement disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.GuestAccountsSettings.EnforceChannelInvites = false
+		})
+
+		_, appErr := th.App.AddUserToChannel(guest, th.BasicChannel, false)
+		require.Nil(t, appErr)
+	})
+}
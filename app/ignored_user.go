@@ -0,0 +1,136 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// IgnoreUser hides the posts of the ignored user from the user's channel feeds, and stops their
+// mentions of the user from notifying them.
+func (a *App) IgnoreUser(userID, ignoredUserID string) *model.AppError {
+	if userID == ignoredUserID {
+		return model.NewAppError("IgnoreUser", "app.user.ignore.self.app_error", nil, "user_id="+userID, http.StatusBadRequest)
+	}
+
+	if _, appErr := a.GetUser(ignoredUserID); appErr != nil {
+		return appErr
+	}
+
+	return a.UpdatePreferences(userID, model.Preferences{{
+		UserId:   userID,
+		Category: model.PreferenceCategoryIgnoredUser,
+		Name:     ignoredUserID,
+		Value:    "true",
+	}})
+}
+
+// UnignoreUser shows the posts of a user the user had ignored again.
+func (a *App) UnignoreUser(userID, ignoredUserID string) *model.AppError {
+	return a.DeletePreferences(userID, model.Preferences{{
+		UserId:   userID,
+		Category: model.PreferenceCategoryIgnoredUser,
+		Name:     ignoredUserID,
+	}})
+}
+
+// GetIgnoredUserIds returns the ids of the users the user has ignored.
+func (a *App) GetIgnoredUserIds(userID string) ([]string, *model.AppError) {
+	preferences, err := a.Srv().Store.Preference().GetCategory(userID, model.PreferenceCategoryIgnoredUser)
+	if err != nil {
+		return nil, model.NewAppError("GetIgnoredUserIds", "app.preference.get_category.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	ignoredUserIDs := []string{}
+	for _, preference := range preferences {
+		if preference.Value == "true" {
+			ignoredUserIDs = append(ignoredUserIDs, preference.Name)
+		}
+	}
+
+	return ignoredUserIDs, nil
+}
+
+// RemoveIgnoredUsersPosts removes the posts of the users the user has ignored from the post list,
+// which is modified in place. The replies to their posts are removed too, so that no reply is left
+// without its root.
+func (a *App) RemoveIgnoredUsersPosts(postList *model.PostList, userID string) *model.AppError {
+	ignoredUserIDs, appErr := a.GetIgnoredUserIds(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if len(ignoredUserIDs) == 0 {
+		return nil
+	}
+
+	ignored := make(map[string]bool, len(ignoredUserIDs))
+	for _, ignoredUserID := range ignoredUserIDs {
+		ignored[ignoredUserID] = true
+	}
+
+	for id, post := range postList.Posts {
+		if ignored[post.UserId] {
+			delete(postList.Posts, id)
+			continue
+		}
+
+		// The root of a reply is included in the list along with it.
+		if root, ok := postList.Posts[post.RootId]; ok && ignored[root.UserId] {
+			delete(postList.Posts, id)
+		}
+	}
+
+	order := make([]string, 0, len(postList.Order))
+	for _, id := range postList.Order {
+		if _, ok := postList.Posts[id]; ok {
+			order = append(order, id)
+		}
+	}
+	postList.Order = order
+
+	return nil
+}
+
+// getUsersIgnoring returns the ids of the users who have ignored the given user.
+func (a *App) getUsersIgnoring(userID string) map[string]bool {
+	preferences, err := a.Srv().Store.Preference().GetCategoryAndName(model.PreferenceCategoryIgnoredUser, userID)
+	if err != nil {
+		mlog.Warn("Failed to get the users ignoring a user", mlog.String("user_id", userID), mlog.Err(err))
+		return nil
+	}
+
+	ignoring := make(map[string]bool, len(preferences))
+	for _, preference := range preferences {
+		if preference.Value == "true" {
+			ignoring[preference.UserId] = true
+		}
+	}
+
+	return ignoring
+}
+
+// removeMentionsOfUsersIgnoring removes the mentions of the users who have ignored the sender, along
+// with their notifications of all activity, so that the post doesn't notify them.
+func removeMentionsOfUsersIgnoring(ignoring map[string]bool, mentions *ExplicitMentions, allActivityPushUserIDs []string) []string {
+	if len(ignoring) == 0 {
+		return allActivityPushUserIDs
+	}
+
+	userIDs := make([]string, 0, len(allActivityPushUserIDs))
+	for _, userID := range allActivityPushUserIDs {
+		if !ignoring[userID] {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	for userID := range ignoring {
+		mentions.removeMention(userID)
+	}
+
+	return userIDs
+}
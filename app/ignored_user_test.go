@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestIgnoreUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	appErr := th.App.IgnoreUser(th.BasicUser.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+
+	ignoredUserIDs, appErr := th.App.GetIgnoredUserIds(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, []string{th.BasicUser2.Id}, ignoredUserIDs)
+
+	ignoredUserIDs, appErr = th.App.GetIgnoredUserIds(th.BasicUser2.Id)
+	require.Nil(t, appErr)
+	assert.Empty(t, ignoredUserIDs)
+
+	t.Run("unignore", func(t *testing.T) {
+		appErr := th.App.UnignoreUser(th.BasicUser.Id, th.BasicUser2.Id)
+		require.Nil(t, appErr)
+
+		ignoredUserIDs, appErr := th.App.GetIgnoredUserIds(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, ignoredUserIDs)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		appErr := th.App.IgnoreUser(th.BasicUser.Id, th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.user.ignore.self.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		appErr := th.App.IgnoreUser(th.BasicUser.Id, model.NewId())
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
+
+func TestRemoveIgnoredUsersPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post1 := th.CreatePost(th.BasicChannel)
+	post2, appErr := th.App.CreatePost(th.Context, &model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "from user2",
+	}, th.BasicChannel, false, true)
+	require.Nil(t, appErr)
+
+	getPosts := func(t *testing.T, userID string) *model.PostList {
+		t.Helper()
+
+		list, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, PerPage: 60})
+		require.Nil(t, appErr)
+		appErr = th.App.RemoveIgnoredUsersPosts(list, userID)
+		require.Nil(t, appErr)
+		return list
+	}
+
+	appErr = th.App.IgnoreUser(th.BasicUser.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+
+	list := getPosts(t, th.BasicUser.Id)
+	assert.Contains(t, list.Order, post1.Id)
+	assert.NotContains(t, list.Order, post2.Id)
+	assert.NotContains(t, list.Posts, post2.Id)
+
+	t.Run("replies to ignored posts", func(t *testing.T) {
+		reply, appErr := th.App.CreatePost(th.Context, &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			RootId:    post2.Id,
+			Message:   "reply to user2",
+		}, th.BasicChannel, false, true)
+		require.Nil(t, appErr)
+
+		list := getPosts(t, th.BasicUser.Id)
+		assert.NotContains(t, list.Order, reply.Id)
+		assert.NotContains(t, list.Posts, reply.Id)
+		assert.NotContains(t, list.Posts, post2.Id)
+	})
+
+	t.Run("other users still see the posts", func(t *testing.T) {
+		list := getPosts(t, th.BasicUser2.Id)
+		assert.Contains(t, list.Order, post1.Id)
+		assert.Contains(t, list.Order, post2.Id)
+	})
+
+	t.Run("unignored", func(t *testing.T) {
+		appErr := th.App.UnignoreUser(th.BasicUser.Id, th.BasicUser2.Id)
+		require.Nil(t, appErr)
+
+		list := getPosts(t, th.BasicUser.Id)
+		assert.Contains(t, list.Order, post2.Id)
+	})
+}
+
+func TestSendNotificationsFromIgnoredUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.AddUserToChannel(th.BasicUser2, th.BasicChannel)
+
+	post := &model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "@" + th.BasicUser2.Username,
+		CreateAt:  model.GetMillis(),
+	}
+
+	mentions, err := th.App.SendNotifications(post, th.BasicTeam, th.BasicChannel, th.BasicUser, nil, true)
+	require.NoError(t, err)
+	assert.Contains(t, mentions, th.BasicUser2.Id)
+
+	appErr := th.App.IgnoreUser(th.BasicUser2.Id, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	mentions, err = th.App.SendNotifications(post, th.BasicTeam, th.BasicChannel, th.BasicUser, nil, true)
+	require.NoError(t, err)
+	assert.NotContains(t, mentions, th.BasicUser2.Id)
+
+	t.Run("direct message", func(t *testing.T) {
+		dm := th.CreateDmChannel(th.BasicUser2)
+		post := &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: dm.Id,
+			Message:   "hello",
+			CreateAt:  model.GetMillis(),
+		}
+
+		mentions, err := th.App.SendNotifications(post, th.BasicTeam, dm, th.BasicUser, nil, true)
+		require.NoError(t, err)
+		assert.NotContains(t, mentions, th.BasicUser2.Id)
+	})
+}
@@ -976,6 +976,7 @@ func (a *App) importUserChannels(user *model.User, team *model.Team, data *[]Use
 				member.NotifyProps[model.MarkUnreadNotifyProp] = *cdata.NotifyProps.MarkUnread
 			}
 		}
+		model.ApplyChannelDefaultNotifyLevel(member.NotifyProps, channel)
 
 		channelsByID[channel.Id] = channel
 		channelMemberByChannelID[channel.Id] = member
@@ -1042,34 +1043,27 @@ func (a *App) importUserChannels(user *model.User, team *model.Team, data *[]Use
 	return nil
 }
 
-func (a *App) importReaction(data *ReactionImportData, post *model.Post) *model.AppError {
-	if err := validateReactionImportData(data, post.CreateAt); err != nil {
-		return err
-	}
-
-	var user *model.User
-	var nErr error
-	if user, nErr = a.Srv().Store.User().GetByUsername(*data.User); nErr != nil {
-		return model.NewAppError("BulkImport", "app.import.import_post.user_not_found.error", map[string]interface{}{"Username": data.User}, nErr.Error(), http.StatusBadRequest)
-	}
+func (a *App) importReactions(data []ReactionImportData, post *model.Post) *model.AppError {
+	reactions := make([]*model.Reaction, 0, len(data))
+	for i := range data {
+		if err := validateReactionImportData(&data[i], post.CreateAt); err != nil {
+			return err
+		}
 
-	reaction := &model.Reaction{
-		UserId:    user.Id,
-		PostId:    post.Id,
-		EmojiName: *data.EmojiName,
-		CreateAt:  *data.CreateAt,
-	}
-	if _, nErr = a.Srv().Store.Reaction().Save(reaction); nErr != nil {
-		var appErr *model.AppError
-		switch {
-		case errors.As(nErr, &appErr):
-			return appErr
-		default:
-			return model.NewAppError("importReaction", "app.reaction.save.save.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		user, nErr := a.Srv().Store.User().GetByUsername(*data[i].User)
+		if nErr != nil {
+			return model.NewAppError("BulkImport", "app.import.import_post.user_not_found.error", map[string]interface{}{"Username": data[i].User}, nErr.Error(), http.StatusBadRequest)
 		}
+
+		reactions = append(reactions, &model.Reaction{
+			UserId:    user.Id,
+			PostId:    post.Id,
+			EmojiName: *data[i].EmojiName,
+			CreateAt:  *data[i].CreateAt,
+		})
 	}
 
-	return nil
+	return a.SaveReactions(reactions)
 }
 
 func (a *App) importReplies(c *request.Context, data []ReplyImportData, post *model.Post, teamID string) *model.AppError {
@@ -1494,11 +1488,8 @@ func (a *App) importMultiplePostLines(c *request.Context, lines []LineImportWork
 		}
 
 		if postWithData.postData.Reactions != nil {
-			for _, reaction := range *postWithData.postData.Reactions {
-				reaction := reaction
-				if err := a.importReaction(&reaction, postWithData.post); err != nil {
-					return postWithData.lineNumber, err
-				}
+			if err := a.importReactions(*postWithData.postData.Reactions, postWithData.post); err != nil {
+				return postWithData.lineNumber, err
 			}
 		}
 
@@ -1803,11 +1794,8 @@ func (a *App) importMultipleDirectPostLines(c *request.Context, lines []LineImpo
 		}
 
 		if postWithData.directPostData.Reactions != nil {
-			for _, reaction := range *postWithData.directPostData.Reactions {
-				reaction := reaction
-				if err := a.importReaction(&reaction, postWithData.post); err != nil {
-					return postWithData.lineNumber, err
-				}
+			if err := a.importReactions(*postWithData.directPostData.Reactions, postWithData.post); err != nil {
+				return postWithData.lineNumber, err
 			}
 		}
 
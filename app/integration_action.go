@@ -167,7 +167,7 @@ func (a *App) DoPostActionWithCookie(c *request.Context, postID, actionId, userI
 		originalIsPinned = post.IsPinned
 		originalHasReactions = post.HasReactions
 
-		if post.RootId == "" {
+		if post.IsRoot() {
 			rootPostId = post.Id
 		} else {
 			rootPostId = post.RootId
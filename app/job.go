@@ -97,7 +97,11 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
-		model.JobTypeExtractContent:
+		model.JobTypeExtractContent,
+		model.JobTypeArchivedChannelPurge,
+		model.JobTypePostReminders,
+		model.JobTypeEditHistoryPurge,
+		model.JobTypeScheduledChannelDeletions:
 		return a.SessionHasPermissionTo(session, model.PermissionManageJobs), model.PermissionManageJobs
 	}
 
@@ -128,7 +132,11 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
-		model.JobTypeExtractContent:
+		model.JobTypeExtractContent,
+		model.JobTypeArchivedChannelPurge,
+		model.JobTypePostReminders,
+		model.JobTypeEditHistoryPurge,
+		model.JobTypeScheduledChannelDeletions:
 		return a.SessionHasPermissionTo(session, model.PermissionReadJobs), model.PermissionReadJobs
 	}
 
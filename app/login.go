@@ -59,8 +59,15 @@ func (a *App) AuthenticateUserForLogin(c *request.Context, id, loginId, password
 		return nil, model.NewAppError("AuthenticateUserForLogin", "api.user.login.blank_pwd.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if err = a.checkLoginAttemptsFromIP(c.IPAddress()); err != nil {
+		return nil, err
+	}
+
 	// Get the MM user we are trying to login
 	if user, err = a.GetUserForLogin(id, loginId); err != nil {
+		if isFailedLoginAttempt(err, mfaToken) {
+			a.incrementFailedLoginAttemptsFromIP(c.IPAddress(), "")
+		}
 		return nil, err
 	}
 
@@ -113,13 +120,36 @@ func (a *App) AuthenticateUserForLogin(c *request.Context, id, loginId, password
 	}
 
 	// and then authenticate them
+	userID := user.Id
 	if user, err = a.authenticateUser(c, user, password, mfaToken); err != nil {
+		if isFailedLoginAttempt(err, mfaToken) {
+			a.incrementFailedLoginAttemptsFromIP(c.IPAddress(), userID)
+		}
 		return nil, err
 	}
 
+	a.clearFailedLoginAttemptsFromIP(c.IPAddress())
+
 	return user, nil
 }
 
+// isFailedLoginAttempt returns whether the error returned when logging in means that the credentials
+// were wrong, as opposed to the login not being allowed or failing for another reason.
+func isFailedLoginAttempt(err *model.AppError, mfaToken string) bool {
+	switch err.Id {
+	case MissingAccountError,
+		"store.sql_user.get_for_login.app_error",
+		"api.user.check_user_password.invalid.app_error",
+		"ent.ldap.do_login.invalid_password.app_error",
+		"ent.ldap.do_login.user_not_registered.app_error":
+		return true
+	case "api.user.check_user_mfa.bad_code.app_error":
+		// Logging in without a token is how clients find out that the user needs to provide one.
+		return mfaToken != ""
+	}
+	return false
+}
+
 func (a *App) GetUserForLogin(id, loginId string) (*model.User, *model.AppError) {
 	enableUsername := *a.Config().EmailSettings.EnableSignInWithUsername
 	enableEmail := *a.Config().EmailSettings.EnableSignInWithEmail
@@ -174,6 +204,7 @@ func (a *App) DoLogin(c *request.Context, w http.ResponseWriter, r *http.Request
 		model.UserAuthServiceIsMobile: strconv.FormatBool(isMobile),
 		model.UserAuthServiceIsSaml:   strconv.FormatBool(isSaml),
 		model.UserAuthServiceIsOAuth:  strconv.FormatBool(isOAuthUser),
+		model.UserAuthServiceIsLdap:   strconv.FormatBool(user.AuthService == model.UserAuthServiceLdap),
 	}}
 	session.GenerateCSRF()
 
@@ -187,10 +218,8 @@ func (a *App) DoLogin(c *request.Context, w http.ResponseWriter, r *http.Request
 		}
 	} else if isMobile {
 		a.ch.srv.userService.SetSessionExpireInHours(session, *a.Config().ServiceSettings.SessionLengthMobileInHours)
-	} else if isOAuthUser || isSaml {
-		a.ch.srv.userService.SetSessionExpireInHours(session, *a.Config().ServiceSettings.SessionLengthSSOInHours)
 	} else {
-		a.ch.srv.userService.SetSessionExpireInHours(session, *a.Config().ServiceSettings.SessionLengthWebInHours)
+		a.ch.srv.userService.SetSessionExpireInHours(session, a.webSessionLengthInHours(session))
 	}
 
 	ua := uasurfer.Parse(r.UserAgent())
@@ -218,6 +247,9 @@ func (a *App) DoLogin(c *request.Context, w http.ResponseWriter, r *http.Request
 	w.Header().Set(model.HeaderToken, session.Token)
 
 	c.SetSession(session)
+
+	a.sendWelcomeMessageAsync(c, user)
+
 	if a.Srv().License() != nil && *a.Srv().License().Features.LDAP && a.Ldap() != nil {
 		userVal := *user
 		sessionVal := *session
@@ -5,11 +5,14 @@ package app
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/mattermost/mattermost-server/v6/app/request"
 	"github.com/mattermost/mattermost-server/v6/model"
 )
 
@@ -70,3 +73,226 @@ func TestCWSLogin(t *testing.T) {
 		require.Nil(t, user)
 	})
 }
+
+func TestLoginLockout(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	contextFrom := func(ipAddress string) *request.Context {
+		c := request.EmptyContext()
+		c.SetIPAddress(ipAddress)
+		return c
+	}
+
+	login := func(c *request.Context, user *model.User, password string) *model.AppError {
+		_, err := th.App.AuthenticateUserForLogin(c, "", user.Username, password, "", "", false)
+		return err
+	}
+
+	failLogins := func(t *testing.T, c *request.Context, user *model.User, attempts int) {
+		t.Helper()
+		for i := 0; i < attempts; i++ {
+			err := login(c, user, "wrongpassword")
+			require.NotNil(t, err)
+			require.Equal(t, "api.user.check_user_password.invalid.app_error", err.Id)
+		}
+	}
+
+	setLockout := func(scope string, durationInMinutes int) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.MaximumLoginAttempts = 3
+			*cfg.ServiceSettings.LoginLockoutScope = scope
+			*cfg.ServiceSettings.LoginLockoutDurationInMinutes = durationInMinutes
+		})
+	}
+
+	t.Run("account is locked after the threshold until the password is reset", func(t *testing.T) {
+		setLockout(model.LoginLockoutScopeAccount, 0)
+		user := th.CreateUser()
+		c := contextFrom("10.0.0.1")
+
+		failLogins(t, c, user, 2)
+		require.Nil(t, login(c, user, "Password1"))
+
+		failLogins(t, c, user, 3)
+		err := login(c, user, "Password1")
+		require.NotNil(t, err)
+		require.Equal(t, "api.user.check_user_login_attempts.too_many.app_error", err.Id)
+
+		err = login(contextFrom("10.0.0.2"), user, "Password1")
+		require.NotNil(t, err)
+		require.Equal(t, "api.user.check_user_login_attempts.too_many.app_error", err.Id)
+	})
+
+	t.Run("account is unlocked after the lockout duration", func(t *testing.T) {
+		setLockout(model.LoginLockoutScopeAccount, 10)
+		user := th.CreateUser()
+		c := contextFrom("10.0.0.1")
+
+		failLogins(t, c, user, 3)
+		err := login(c, user, "Password1")
+		require.NotNil(t, err)
+		require.Equal(t, "api.user.check_user_login_attempts.locked.app_error", err.Id)
+
+		_, nErr := th.GetSqlStore().GetMasterX().Exec("UPDATE Users SET LastFailedAttemptAt = ? WHERE Id = ?", model.GetMillis()-(10*time.Minute).Milliseconds(), user.Id)
+		require.NoError(t, nErr)
+		th.App.InvalidateCacheForUser(user.Id)
+
+		require.Nil(t, login(c, user, "Password1"))
+
+		user, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		require.Zero(t, user.FailedAttempts)
+		require.Zero(t, user.LastFailedAttemptAt)
+	})
+
+	t.Run("ip address is locked after the threshold across accounts", func(t *testing.T) {
+		setLockout(model.LoginLockoutScopeIP, 10)
+		user := th.CreateUser()
+		otherUser := th.CreateUser()
+		c := contextFrom("10.0.1.1")
+
+		failLogins(t, c, user, 2)
+		failLogins(t, c, otherUser, 1)
+		err := login(c, user, "Password1")
+		require.NotNil(t, err)
+		require.Equal(t, "api.user.check_user_login_attempts.locked.app_error", err.Id)
+
+		err = login(c, otherUser, "Password1")
+		require.NotNil(t, err)
+		require.Equal(t, "api.user.check_user_login_attempts.locked.app_error", err.Id)
+
+		require.Nil(t, login(contextFrom("10.0.1.2"), user, "Password1"))
+	})
+
+	t.Run("account isn't locked when locking ip addresses", func(t *testing.T) {
+		setLockout(model.LoginLockoutScopeIP, 10)
+		user := th.CreateUser()
+
+		failLogins(t, contextFrom("10.0.4.1"), user, 1)
+		failLogins(t, contextFrom("10.0.4.2"), user, 1)
+		failLogins(t, contextFrom("10.0.4.3"), user, 1)
+
+		require.Nil(t, login(contextFrom("10.0.4.4"), user, "Password1"))
+
+		user, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		require.Zero(t, user.FailedAttempts)
+	})
+
+	t.Run("password checks of a logged in user don't lock the account when locking ip addresses", func(t *testing.T) {
+		setLockout(model.LoginLockoutScopeIP, 10)
+		user := th.CreateUser()
+
+		for i := 0; i < 3; i++ {
+			err := th.App.DoubleCheckPassword(user, "wrongpassword")
+			require.NotNil(t, err)
+			require.Equal(t, "api.user.check_user_password.invalid.app_error", err.Id)
+		}
+
+		require.Nil(t, th.App.DoubleCheckPassword(user, "Password1"))
+	})
+
+	t.Run("ip address attempts are cleared by a successful login", func(t *testing.T) {
+		setLockout(model.LoginLockoutScopeIP, 10)
+		user := th.CreateUser()
+		c := contextFrom("10.0.2.1")
+
+		failLogins(t, c, user, 2)
+		require.Nil(t, login(c, user, "Password1"))
+		failLogins(t, c, user, 2)
+		require.Nil(t, login(c, user, "Password1"))
+	})
+
+	t.Run("ip address is unlocked after the lockout duration", func(t *testing.T) {
+		setLockout(model.LoginLockoutScopeIP, 10)
+		user := th.CreateUser()
+		otherUser := th.CreateUser()
+		c := contextFrom("10.0.3.1")
+
+		failLogins(t, c, user, 2)
+		failLogins(t, c, otherUser, 1)
+		require.NotNil(t, login(c, user, "Password1"))
+
+		// Stand in for the lockout duration passing by expiring the tracked attempts.
+		require.NoError(t, th.App.Srv().loginAttemptsCache.SetWithExpiry(c.IPAddress(), loginAttemptsFromIP{Attempts: 3}, time.Millisecond))
+		time.Sleep(10 * time.Millisecond)
+
+		require.Nil(t, login(c, user, "Password1"))
+	})
+
+	t.Run("ip address is unlocked when the password of a user it tried is reset", func(t *testing.T) {
+		setLockout(model.LoginLockoutScopeIP, 10)
+		user := th.CreateUser()
+		otherUser := th.CreateUser()
+		c := contextFrom("10.0.5.1")
+
+		failLogins(t, c, user, 2)
+		failLogins(t, c, otherUser, 1)
+		require.NotNil(t, login(c, user, "Password1"))
+
+		require.Nil(t, th.App.UpdatePassword(otherUser, "Password2"))
+
+		require.Nil(t, login(c, otherUser, "Password2"))
+	})
+}
+
+func TestDoLoginSessionLengthByAuthMethod(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SessionLengthWebInHours = 1
+		*cfg.ServiceSettings.SessionLengthSSOInHours = 2
+		*cfg.ServiceSettings.SessionLengthSAMLInHours = 3
+		*cfg.ServiceSettings.SessionLengthOAuthInHours = 4
+		*cfg.ServiceSettings.SessionLengthLDAPInHours = 5
+	})
+
+	ldapUser := *th.BasicUser
+	ldapUser.AuthService = model.UserAuthServiceLdap
+
+	login := func(t *testing.T, user *model.User, isOAuthUser, isSaml bool) time.Duration {
+		t.Helper()
+
+		c := request.EmptyContext()
+		before := model.GetMillis()
+		appErr := th.App.DoLogin(c, httptest.NewRecorder(), &http.Request{}, user, "", false, isOAuthUser, isSaml)
+		require.Nil(t, appErr)
+
+		return time.Duration(c.Session().ExpiresAt-before) * time.Millisecond
+	}
+
+	requireLength := func(t *testing.T, expected, actual time.Duration) {
+		t.Helper()
+		require.InDelta(t, float64(expected), float64(actual), float64(time.Minute))
+	}
+
+	t.Run("password", func(t *testing.T) {
+		requireLength(t, time.Hour, login(t, th.BasicUser, false, false))
+	})
+
+	t.Run("SAML", func(t *testing.T) {
+		requireLength(t, 3*time.Hour, login(t, th.BasicUser, false, true))
+	})
+
+	t.Run("OAuth", func(t *testing.T) {
+		requireLength(t, 4*time.Hour, login(t, th.BasicUser, true, false))
+	})
+
+	t.Run("LDAP", func(t *testing.T) {
+		requireLength(t, 5*time.Hour, login(t, &ldapUser, false, false))
+	})
+
+	t.Run("falls back to the SSO and web lengths", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.SessionLengthSAMLInHours = 0
+			*cfg.ServiceSettings.SessionLengthOAuthInHours = 0
+			*cfg.ServiceSettings.SessionLengthLDAPInHours = 0
+		})
+
+		requireLength(t, 2*time.Hour, login(t, th.BasicUser, false, true))
+		requireLength(t, 2*time.Hour, login(t, th.BasicUser, true, false))
+		requireLength(t, time.Hour, login(t, &ldapUser, false, false))
+	})
+}
@@ -10,14 +10,12 @@ import (
 	"sort"
 	"strings"
 	"sync"
-	"unicode"
 	"unicode/utf8"
 
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/i18n"
-	"github.com/mattermost/mattermost-server/v6/shared/markdown"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 	"github.com/mattermost/mattermost-server/v6/store"
 )
@@ -79,7 +77,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 	}
 
 	var tchan chan store.StoreResult
-	if isCRTAllowed && post.RootId != "" {
+	if isCRTAllowed && post.IsReply() {
 		tchan = make(chan store.StoreResult, 1)
 		go func() {
 			followers, err := a.Srv().Store.Thread().GetThreadFollowers(post.RootId, true)
@@ -121,6 +119,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 	mentions := &ExplicitMentions{}
 	allActivityPushUserIds := []string{}
 	var allowChannelMentions bool
+	var expandedMentionsSuppressed bool
 	var keywords map[string][]string
 	if channel.Type == model.ChannelTypeDirect {
 		otherUserId := channel.GetOtherUserIdForDM(post.UserId)
@@ -138,6 +137,13 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		keywords = a.getMentionKeywordsInChannel(profileMap, allowChannelMentions, channelMemberNotifyPropsMap)
 
 		mentions = getExplicitMentions(post, keywords, groups)
+		if allowChannelMentions && a.exceedsMaxExpandedMentions(post, mentions) {
+			// Only the users mentioned by name are notified.
+			allowChannelMentions = false
+			expandedMentionsSuppressed = true
+			keywords = a.getMentionKeywordsInChannel(profileMap, false, channelMemberNotifyPropsMap)
+			mentions = getExplicitMentions(post, keywords, groups)
+		}
 		// Add an implicit mention when a user is added to a channel
 		// even if the user has set 'username mentions' to false in account settings.
 		if post.Type == model.PostTypeAddToChannel {
@@ -160,7 +166,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		}
 
 		// get users that have comment thread mentions enabled
-		if post.RootId != "" && parentPostList != nil {
+		if post.IsReply() && parentPostList != nil {
 			for _, threadPost := range parentPostList.Posts {
 				profile := profileMap[threadPost.UserId]
 				if profile == nil {
@@ -203,12 +209,16 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 				channelMemberNotifyPropsMap[profile.Id][model.PushNotifyProp] == model.ChannelNotifyAll) &&
 				(post.UserId != profile.Id || post.GetProp("from_webhook") == "true") &&
 				!post.IsSystemMessage() &&
-				!(a.IsCRTEnabledForUser(profile.Id) && post.RootId != "") {
+				!(a.IsCRTEnabledForUser(profile.Id) && post.IsReply()) {
 				allActivityPushUserIds = append(allActivityPushUserIds, profile.Id)
 			}
 		}
 	}
 
+	// users who have ignored the sender aren't notified of their posts, nor sent them over the websocket
+	ignoringUserIDs := a.getUsersIgnoring(post.UserId)
+	allActivityPushUserIds = removeMentionsOfUsersIgnoring(ignoringUserIDs, mentions, allActivityPushUserIds)
+
 	mentionedUsersList := make(model.StringArray, 0, len(mentions.Mentions))
 	mentionAutofollowChans := []chan *model.AppError{}
 	threadParticipants := map[string]bool{post.UserId: true}
@@ -216,7 +226,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 	participantMemberships := map[string]*model.ThreadMembership{}
 	membershipsMutex := &sync.Mutex{}
 	followersMutex := &sync.Mutex{}
-	if *a.Config().ServiceSettings.ThreadAutoFollow && post.RootId != "" {
+	if *a.Config().ServiceSettings.ThreadAutoFollow && post.IsReply() {
 		var rootMentions *ExplicitMentions
 		if parentPostList != nil {
 			rootPost := parentPostList.Posts[parentPostList.Order[0]]
@@ -250,7 +260,9 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 					<-sema
 				}()
 				mentionType, incrementMentions := mentions.Mentions[userID]
+				autoFollow := a.isThreadAutoFollowEnabledForUser(userID)
 				// if the user was not explicitly mentioned, check if they explicitly unfollowed the thread
+				// or opted out of following threads automatically
 				if !incrementMentions {
 					membership, err := a.Srv().Store.Thread().GetMembershipForUser(userID, post.RootId)
 					var nfErr *store.ErrNotFound
@@ -263,15 +275,19 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 					if membership != nil && !membership.Following {
 						return
 					}
+
+					if membership == nil && !autoFollow {
+						return
+					}
 				}
 
-				updateFollowing := *a.Config().ServiceSettings.ThreadAutoFollow
+				updateFollowing := autoFollow
 				if mentionType == ThreadMention || mentionType == CommentMention {
 					incrementMentions = false
 					updateFollowing = false
 				}
 				opts := store.ThreadMembershipOpts{
-					Following:             true,
+					Following:             autoFollow,
 					IncrementMentions:     incrementMentions,
 					UpdateFollowing:       updateFollowing,
 					UpdateViewedTimestamp: false,
@@ -304,7 +320,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		mentionedUsersList = append(mentionedUsersList, id)
 	}
 
-	nErr := a.Srv().Store.Channel().IncrementMentionCount(post.ChannelId, mentionedUsersList, post.RootId == "")
+	nErr := a.Srv().Store.Channel().IncrementMentionCount(post.ChannelId, mentionedUsersList, post.IsRoot())
 	if nErr != nil {
 		mlog.Warn(
 			"Failed to update mention count",
@@ -327,7 +343,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 	}
 
 	notificationsForCRT := &CRTNotifiers{}
-	if isCRTAllowed && post.RootId != "" {
+	if isCRTAllowed && post.IsReply() {
 		for _, uid := range followers {
 			profile := profileMap[uid]
 			if profile == nil || !a.IsCRTEnabledForUser(uid) {
@@ -354,6 +370,11 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		emailRecipients := append(mentionedUsersList, notificationsForCRT.Email...)
 		emailRecipients = model.RemoveDuplicateStrings(emailRecipients)
 
+		// Announcements are emailed to everyone in the channel regardless of their preferences.
+		if post.IsAnnouncement() {
+			emailRecipients = announcementEmailRecipients(post, profileMap)
+		}
+
 		for _, id := range emailRecipients {
 			if profileMap[id] == nil {
 				continue
@@ -365,7 +386,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 				continue
 			}
 
-			if a.userAllowsEmail(profileMap[id], channelMemberNotifyPropsMap[id], post) {
+			if post.IsAnnouncement() || a.userAllowsEmail(profileMap[id], channelMemberNotifyPropsMap[id], post) {
 				senderProfileImage, _, err := a.GetProfileImage(sender)
 				if err != nil {
 					a.Log().Warn("Unable to get the sender user profile image.", mlog.String("user_id", sender.Id), mlog.Err(err))
@@ -377,6 +398,19 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		}
 	}
 
+	if expandedMentionsSuppressed {
+		T := i18n.GetUserTranslations(sender.Locale)
+
+		a.SendEphemeralPost(
+			post.UserId,
+			&model.Post{
+				ChannelId: post.ChannelId,
+				Message:   T("api.post.expanded_mentions_suppressed", map[string]interface{}{"Mentions": *a.Config().TeamSettings.MaxExpandedMentionsPerPost}),
+				CreateAt:  post.CreateAt + 1,
+			},
+		)
+	}
+
 	// Check for channel-wide mentions in channels that have too many members for those to work
 	if int64(len(profileMap)) > *a.Config().TeamSettings.MaxNotificationsPerChannel {
 		T := i18n.GetUserTranslations(sender.Locale)
@@ -500,7 +534,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 				status = &model.Status{UserId: id, Status: model.StatusOffline, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
 			}
 
-			if DoesStatusAllowPushNotification(profileMap[id].NotifyProps, status, post.ChannelId) {
+			if DoesStatusAllowPushNotification(profileMap[id].NotifyProps, status, post.ChannelId) && !model.IsChannelSnoozed(channelMemberNotifyPropsMap[id], model.GetMillis()) {
 				a.sendPushNotification(
 					notification,
 					profileMap[id],
@@ -521,7 +555,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		}
 	}
 
-	message := model.NewWebSocketEvent(model.WebsocketEventPosted, "", post.ChannelId, "", nil)
+	message := model.NewWebSocketEvent(model.WebsocketEventPosted, "", post.ChannelId, "", ignoringUserIDs)
 
 	// Note that PreparePostForClient should've already been called by this point
 	postJSON, jsonErr := post.ToJSON()
@@ -572,7 +606,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 	}
 
 	// If this is a reply in a thread, notify participants
-	if isCRTAllowed && post.RootId != "" {
+	if isCRTAllowed && post.IsReply() {
 		for _, uid := range followers {
 			// A user following a thread but had left the channel won't get a notification
 			// https://mattermost.atlassian.net/browse/MM-36769
@@ -624,7 +658,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 						userThread.UnreadReplies = 0
 					}
 					a.sanitizeProfiles(userThread.Participants, false)
-					userThread.Post.SanitizeProps()
+					userThread.Post.SanitizeParticipants()
 
 					sanitizedPost, err := a.SanitizePostMetadataForUser(userThread.Post, uid)
 					if err != nil {
@@ -664,7 +698,7 @@ func (a *App) userAllowsEmail(user *model.User, channelMemberNotificationProps m
 	userAllowsEmails := user.NotifyProps[model.EmailNotifyProp] != "false"
 
 	// if CRT is ON for user and the post is a reply disregard the channelEmail setting
-	if channelEmail, ok := channelMemberNotificationProps[model.EmailNotifyProp]; ok && !(a.IsCRTEnabledForUser(user.Id) && post.RootId != "") {
+	if channelEmail, ok := channelMemberNotificationProps[model.EmailNotifyProp]; ok && !(a.IsCRTEnabledForUser(user.Id) && post.IsReply()) {
 		if channelEmail != model.ChannelNotifyDefault {
 			userAllowsEmails = channelEmail != "false"
 		}
@@ -678,6 +712,12 @@ func (a *App) userAllowsEmail(user *model.User, channelMemberNotificationProps m
 		}
 	}
 
+	// Remove the user as recipient while the user has snoozed the channel.
+	if model.IsChannelSnoozed(channelMemberNotificationProps, model.GetMillis()) {
+		mlog.Debug("Channel snoozed for user", mlog.String("user_id", user.Id), mlog.String("snooze_until", channelMemberNotificationProps[model.SnoozeUntilNotifyProp]))
+		userAllowsEmails = false
+	}
+
 	var status *model.Status
 	var err *model.AppError
 	if status, err = a.GetStatus(user.Id); err != nil {
@@ -983,40 +1023,12 @@ func (m *ExplicitMentions) removeMention(userID string) {
 func getExplicitMentions(post *model.Post, keywords map[string][]string, groups map[string]*model.Group) *ExplicitMentions {
 	ret := &ExplicitMentions{}
 
-	buf := ""
-	mentionsEnabledFields := getMentionsEnabledFields(post)
-	for _, message := range mentionsEnabledFields {
-		markdown.Inspect(message, func(node interface{}) bool {
-			text, ok := node.(*markdown.Text)
-			if !ok {
-				ret.processText(buf, keywords, groups)
-				buf = ""
-				return true
-			}
-			buf += text.Text
-			return false
-		})
-	}
-	ret.processText(buf, keywords, groups)
-
-	return ret
-}
-
-// Given a post returns the values of the fields in which mentions are possible.
-// post.message, preText and text in the attachment are enabled.
-func getMentionsEnabledFields(post *model.Post) model.StringArray {
-	ret := []string{}
+	ret.OtherPotentialMentions = model.ParsePostMentions(post, func(word string) bool {
+		return ret.checkForMention(word, keywords, groups)
+	}, func(word string) {
+		ret.processMultibyteWord(word, keywords)
+	})
 
-	ret = append(ret, post.Message)
-	for _, attachment := range post.Attachments() {
-
-		if attachment.Pretext != "" {
-			ret = append(ret, attachment.Pretext)
-		}
-		if attachment.Text != "" {
-			ret = append(ret, attachment.Text)
-		}
-	}
 	return ret
 }
 
@@ -1037,6 +1049,32 @@ func (a *App) allowChannelMentions(post *model.Post, numProfiles int) bool {
 	return true
 }
 
+// exceedsMaxExpandedMentions returns whether the channel-wide mentions of the post notify more users than
+// TeamSettings.MaxExpandedMentionsPerPost allows. Channel admins may exceed the limit when
+// TeamSettings.AllowAdminsToExceedExpandedMentions is enabled.
+func (a *App) exceedsMaxExpandedMentions(post *model.Post, mentions *ExplicitMentions) bool {
+	maxMentions := *a.Config().TeamSettings.MaxExpandedMentionsPerPost
+	if maxMentions == 0 || !(mentions.HereMentioned || mentions.ChannelMentioned || mentions.AllMentioned) {
+		return false
+	}
+
+	// The author isn't notified of their own mention.
+	expanded := len(mentions.Mentions)
+	if _, ok := mentions.Mentions[post.UserId]; ok {
+		expanded--
+	}
+
+	if int64(expanded) <= maxMentions {
+		return false
+	}
+
+	if *a.Config().TeamSettings.AllowAdminsToExceedExpandedMentions && a.HasPermissionToChannel(post.UserId, post.ChannelId, model.PermissionManageChannelRoles) {
+		return false
+	}
+
+	return true
+}
+
 // allowGroupMentions returns whether or not the group mentions are allowed for the given post.
 func (a *App) allowGroupMentions(post *model.Post) bool {
 	if license := a.Srv().License(); license == nil || (license.SkuShortName != model.LicenseShortSkuProfessional && license.SkuShortName != model.LicenseShortSkuEnterprise) {
@@ -1296,67 +1334,19 @@ func isKeywordMultibyte(keywords map[string][]string, word string) ([]string, bo
 
 // Processes text to filter mentioned users and other potential mentions
 func (m *ExplicitMentions) processText(text string, keywords map[string][]string, groups map[string]*model.Group) {
-	systemMentions := map[string]bool{"@here": true, "@channel": true, "@all": true}
-
-	for _, word := range strings.FieldsFunc(text, func(c rune) bool {
-		// Split on any whitespace or punctuation that can't be part of an at mention or emoji pattern
-		return !(c == ':' || c == '.' || c == '-' || c == '_' || c == '@' || unicode.IsLetter(c) || unicode.IsNumber(c))
-	}) {
-		// skip word with format ':word:' with an assumption that it is an emoji format only
-		if word[0] == ':' && word[len(word)-1] == ':' {
-			continue
-		}
-
-		word = strings.TrimLeft(word, ":.-_")
-
-		if m.checkForMention(word, keywords, groups) {
-			continue
-		}
-
-		foundWithoutSuffix := false
-		wordWithoutSuffix := word
-
-		for wordWithoutSuffix != "" && strings.LastIndexAny(wordWithoutSuffix, ".-:_") == (len(wordWithoutSuffix)-1) {
-			wordWithoutSuffix = wordWithoutSuffix[0 : len(wordWithoutSuffix)-1]
-
-			if m.checkForMention(wordWithoutSuffix, keywords, groups) {
-				foundWithoutSuffix = true
-				break
-			}
-		}
-
-		if foundWithoutSuffix {
-			continue
-		}
-
-		if _, ok := systemMentions[word]; !ok && strings.HasPrefix(word, "@") {
-			// No need to bother about unicode as we are looking for ASCII characters.
-			last := word[len(word)-1]
-			switch last {
-			// If the word is possibly at the end of a sentence, remove that character.
-			case '.', '-', ':':
-				word = word[:len(word)-1]
-			}
-			m.OtherPotentialMentions = append(m.OtherPotentialMentions, word[1:])
-		} else if strings.ContainsAny(word, ".-:") {
-			// This word contains a character that may be the end of a sentence, so split further
-			splitWords := strings.FieldsFunc(word, func(c rune) bool {
-				return c == '.' || c == '-' || c == ':'
-			})
-
-			for _, splitWord := range splitWords {
-				if m.checkForMention(splitWord, keywords, groups) {
-					continue
-				}
-				if _, ok := systemMentions[splitWord]; !ok && strings.HasPrefix(splitWord, "@") {
-					m.OtherPotentialMentions = append(m.OtherPotentialMentions, splitWord[1:])
-				}
-			}
-		}
+	potentialMentions := model.ParseMentionText(text, func(word string) bool {
+		return m.checkForMention(word, keywords, groups)
+	}, func(word string) {
+		m.processMultibyteWord(word, keywords)
+	})
+	m.OtherPotentialMentions = append(m.OtherPotentialMentions, potentialMentions...)
+}
 
-		if ids, match := isKeywordMultibyte(keywords, word); match {
-			m.addMentions(ids, KeywordMention)
-		}
+// processMultibyteWord adds the users whose multibyte keywords are contained by the word, since those may not be
+// separated from the surrounding text by any punctuation.
+func (m *ExplicitMentions) processMultibyteWord(word string, keywords map[string][]string) {
+	if ids, match := isKeywordMultibyte(keywords, word); match {
+		m.addMentions(ids, KeywordMention)
 	}
 }
 
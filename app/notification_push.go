@@ -501,6 +501,11 @@ func DoesNotifyPropsAllowPushNotification(user *model.User, channelNotifyProps m
 		return false
 	}
 
+	// Nor while the channel is snoozed
+	if model.IsChannelSnoozed(channelNotifyProps, model.GetMillis()) {
+		return false
+	}
+
 	if post.IsSystemMessage() {
 		return false
 	}
@@ -658,7 +663,7 @@ func (a *App) buildFullPushNotificationMessage(contentsConfig string, post *mode
 
 	if a.IsCRTEnabledForUser(user.Id) {
 		msg.IsCRTEnabled = true
-		if post.RootId != "" {
+		if post.IsReply() {
 			if contentsConfig != model.GenericNoChannelNotification {
 				props := map[string]interface{}{"channelName": channelName}
 				msg.ChannelName = userLocale("api.push_notification.title.collapsed_threads", props)
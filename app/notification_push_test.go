@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -33,6 +34,7 @@ func TestDoesNotifyPropsAllowPushNotification(t *testing.T) {
 		withSystemPost       bool
 		wasMentioned         bool
 		isMuted              bool
+		snoozeUntil          int64
 		expected             bool
 	}{
 		{
@@ -332,6 +334,24 @@ func TestDoesNotifyPropsAllowPushNotification(t *testing.T) {
 			isMuted:              true,
 			expected:             false,
 		},
+		{
+			name:                 "When default is ALL, has mentions and channel is SNOOZED",
+			userNotifySetting:    model.UserNotifyAll,
+			channelNotifySetting: "",
+			withSystemPost:       false,
+			wasMentioned:         true,
+			snoozeUntil:          model.GetMillis() + 60*60*1000,
+			expected:             false,
+		},
+		{
+			name:                 "When default is ALL, has mentions and channel snooze has expired",
+			userNotifySetting:    model.UserNotifyAll,
+			channelNotifySetting: "",
+			withSystemPost:       false,
+			wasMentioned:         true,
+			snoozeUntil:          model.GetMillis() - 60*60*1000,
+			expected:             true,
+		},
 	}
 
 	for _, tc := range tt {
@@ -350,6 +370,9 @@ func TestDoesNotifyPropsAllowPushNotification(t *testing.T) {
 			if tc.isMuted {
 				channelNotifyProps[model.MarkUnreadNotifyProp] = model.ChannelMarkUnreadMention
 			}
+			if tc.snoozeUntil != 0 {
+				channelNotifyProps[model.SnoozeUntilNotifyProp] = strconv.FormatInt(tc.snoozeUntil, 10)
+			}
 			assert.Equal(t, tc.expected, DoesNotifyPropsAllowPushNotification(user, channelNotifyProps, post, tc.wasMentioned))
 		})
 	}
@@ -6,6 +6,7 @@ package app
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -217,6 +218,83 @@ func TestSendNotificationsWithManyUsers(t *testing.T) {
 	})
 }
 
+func TestSendNotificationsMaxExpandedMentions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	_, appErr := th.App.UpdateChannelMemberSchemeRoles(channel.Id, th.BasicUser.Id, false, true, false)
+	require.Nil(t, appErr)
+
+	members := []string{th.BasicUser2.Id}
+	th.AddUserToChannel(th.BasicUser2, channel)
+	for i := 0; i < 2; i++ {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+		th.AddUserToChannel(user, channel)
+		members = append(members, user.Id)
+	}
+
+	sendNotifications := func(t *testing.T, message string) []string {
+		t.Helper()
+		post, appErr := th.App.CreatePostMissingChannel(th.Context, &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: channel.Id,
+			Message:   message,
+		}, false)
+		require.Nil(t, appErr)
+
+		mentions, err := th.App.SendNotifications(post, th.BasicTeam, channel, th.BasicUser, nil, true)
+		require.NoError(t, err)
+		return mentions
+	}
+
+	setMax := func(max int64, allowAdmins bool) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.MaxExpandedMentionsPerPost = max
+			*cfg.TeamSettings.AllowAdminsToExceedExpandedMentions = allowAdmins
+		})
+	}
+
+	t.Run("no limit", func(t *testing.T) {
+		setMax(0, false)
+		assert.ElementsMatch(t, members, sendNotifications(t, "@channel"))
+	})
+
+	t.Run("at the limit", func(t *testing.T) {
+		setMax(int64(len(members)), false)
+		assert.ElementsMatch(t, members, sendNotifications(t, "@all"))
+	})
+
+	t.Run("over the limit", func(t *testing.T) {
+		setMax(int64(len(members)-1), false)
+		assert.Empty(t, sendNotifications(t, "@channel"))
+		assert.Empty(t, sendNotifications(t, "@all"))
+	})
+
+	t.Run("over the limit with explicit mentions", func(t *testing.T) {
+		setMax(int64(len(members)-1), false)
+		assert.ElementsMatch(t, []string{th.BasicUser2.Id}, sendNotifications(t, "@channel @"+th.BasicUser2.Username))
+	})
+
+	t.Run("explicit mentions alone are not limited", func(t *testing.T) {
+		setMax(1, false)
+		assert.ElementsMatch(t, []string{th.BasicUser2.Id}, sendNotifications(t, "@"+th.BasicUser2.Username))
+	})
+
+	t.Run("channel admins", func(t *testing.T) {
+		_, appErr := th.App.UpdateChannelMemberSchemeRoles(channel.Id, th.BasicUser.Id, false, true, true)
+		require.Nil(t, appErr)
+		defer th.App.UpdateChannelMemberSchemeRoles(channel.Id, th.BasicUser.Id, false, true, false)
+
+		setMax(1, false)
+		assert.Empty(t, sendNotifications(t, "@channel"))
+
+		setMax(1, true)
+		assert.ElementsMatch(t, members, sendNotifications(t, "@channel"))
+	})
+}
+
 func TestSendOutOfChannelMentions(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -1728,39 +1806,6 @@ func TestAddMentionKeywordsForUser(t *testing.T) {
 	})
 }
 
-func TestGetMentionsEnabledFields(t *testing.T) {
-
-	attachmentWithTextAndPreText := model.SlackAttachment{
-		Text:    "@here with mentions",
-		Pretext: "@Channel some comment for the channel",
-	}
-
-	attachmentWithOutPreText := model.SlackAttachment{
-		Text: "some text",
-	}
-	attachments := []*model.SlackAttachment{
-		&attachmentWithTextAndPreText,
-		&attachmentWithOutPreText,
-	}
-
-	post := &model.Post{
-		Message: "This is the message",
-		Props: model.StringInterface{
-			"attachments": attachments,
-		},
-	}
-	expectedFields := []string{
-		"This is the message",
-		"@Channel some comment for the channel",
-		"@here with mentions",
-		"some text"}
-
-	mentionEnabledFields := getMentionsEnabledFields(post)
-
-	assert.EqualValues(t, 4, len(mentionEnabledFields))
-	assert.EqualValues(t, expectedFields, mentionEnabledFields)
-}
-
 func TestPostNotificationGetChannelName(t *testing.T) {
 	sender := &model.User{Id: model.NewId(), Username: "sender", FirstName: "Sender", LastName: "Sender", Nickname: "Sender"}
 	recipient := &model.User{Id: model.NewId(), Username: "recipient", FirstName: "Recipient", LastName: "Recipient", Nickname: "Recipient"}
@@ -2501,6 +2546,34 @@ func TestUserAllowsEmail(t *testing.T) {
 		assert.False(t, th.App.userAllowsEmail(user, channelMemberNotifcationProps, &model.Post{Type: model.PostTypeAutoResponder}))
 	})
 
+	t.Run("should return false in case the channel is snoozed", func(t *testing.T) {
+		user := th.CreateUser()
+
+		th.App.SetStatusOffline(user.Id, true)
+
+		channelMemberNotificationProps := model.StringMap{
+			model.EmailNotifyProp:       model.ChannelNotifyDefault,
+			model.MarkUnreadNotifyProp:  model.ChannelMarkUnreadAll,
+			model.SnoozeUntilNotifyProp: strconv.FormatInt(model.GetMillis()+60*60*1000, 10),
+		}
+
+		assert.False(t, th.App.userAllowsEmail(user, channelMemberNotificationProps, &model.Post{Type: "some-post-type"}))
+	})
+
+	t.Run("should return true in case the channel snooze has expired", func(t *testing.T) {
+		user := th.CreateUser()
+
+		th.App.SetStatusOffline(user.Id, true)
+
+		channelMemberNotificationProps := model.StringMap{
+			model.EmailNotifyProp:       model.ChannelNotifyDefault,
+			model.MarkUnreadNotifyProp:  model.ChannelMarkUnreadAll,
+			model.SnoozeUntilNotifyProp: strconv.FormatInt(model.GetMillis()-60*60*1000, 10),
+		}
+
+		assert.True(t, th.App.userAllowsEmail(user, channelMemberNotificationProps, &model.Post{Type: "some-post-type"}))
+	})
+
 }
 
 func TestInsertGroupMentions(t *testing.T) {
@@ -1346,3 +1346,153 @@ func TestHookOnCloudLimitsUpdated(t *testing.T) {
 
 	require.True(t, hookCalled)
 }
+
+func TestHookWebSocketEventWillBeSent(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("no plugin implements the hook", func(t *testing.T) {
+		evt := model.NewWebSocketEvent(model.WebsocketEventTyping, "", th.BasicChannel.Id, "", nil)
+		assert.Same(t, evt, th.Server.runWebSocketEventWillBeSentHook(evt))
+	})
+
+	t.Run("plugin suppresses and modifies events", func(t *testing.T) {
+		tearDown, pluginIDs, errs := SetAppEnvironmentWithPlugins(t,
+			[]string{
+				`
+			package main
+
+			import (
+				"github.com/mattermost/mattermost-server/v6/model"
+				"github.com/mattermost/mattermost-server/v6/plugin"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func (p *MyPlugin) WebSocketEventWillBeSent(event *model.WebSocketEvent) *model.WebSocketEvent {
+				if event.EventType() == model.WebsocketEventTyping {
+					return nil
+				}
+
+				data := map[string]interface{}{"seen": true}
+				for k, v := range event.GetData() {
+					data[k] = v
+				}
+				return event.SetData(data)
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`}, th.App, th.NewPluginAPI)
+		defer tearDown()
+		require.NoError(t, errs[0])
+		require.True(t, th.App.GetPluginsEnvironment().IsActive(pluginIDs[0]))
+
+		typing := model.NewWebSocketEvent(model.WebsocketEventTyping, "", th.BasicChannel.Id, "", nil)
+		assert.Nil(t, th.Server.runWebSocketEventWillBeSentHook(typing))
+
+		posted := model.NewWebSocketEvent(model.WebsocketEventPosted, "", th.BasicChannel.Id, "", nil)
+		posted.Add("post", "{}")
+		modified := th.Server.runWebSocketEventWillBeSentHook(posted)
+		require.NotNil(t, modified)
+		assert.Equal(t, model.WebsocketEventPosted, modified.EventType())
+		assert.Equal(t, th.BasicChannel.Id, modified.GetBroadcast().ChannelId)
+		assert.Equal(t, map[string]interface{}{"post": "{}", "seen": true}, modified.GetData())
+	})
+
+	t.Run("invoked once per event", func(t *testing.T) {
+		tearDown, pluginIDs, errs := SetAppEnvironmentWithPlugins(t,
+			[]string{
+				`
+			package main
+
+			import (
+				"strconv"
+				"sync/atomic"
+
+				"github.com/mattermost/mattermost-server/v6/model"
+				"github.com/mattermost/mattermost-server/v6/plugin"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+				calls int64
+			}
+
+			func (p *MyPlugin) WebSocketEventWillBeSent(event *model.WebSocketEvent) *model.WebSocketEvent {
+				if event.GetData()["probe"] != nil {
+					calls := atomic.AddInt64(&p.calls, 1)
+					p.API.KVSet("calls", []byte(strconv.FormatInt(calls, 10)))
+				}
+				return event
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`}, th.App, th.NewPluginAPI)
+		defer tearDown()
+		require.NoError(t, errs[0])
+		require.True(t, th.App.GetPluginsEnvironment().IsActive(pluginIDs[0]))
+
+		s := httptest.NewServer(dummyWebsocketHandler(t))
+		defer s.Close()
+
+		th.Server.HubStart()
+		for _, userID := range []string{th.BasicUser.Id, th.BasicUser.Id, th.BasicUser2.Id} {
+			wc := registerDummyWebConn(t, th.App, s.Listener.Addr(), userID)
+			defer wc.Close()
+		}
+
+		evt := model.NewWebSocketEvent(model.WebsocketEventPosted, "", th.BasicChannel.Id, "", nil)
+		evt.Add("probe", true)
+		th.App.Publish(evt)
+
+		calls, appErr := th.App.GetPluginKey(pluginIDs[0], "calls")
+		require.Nil(t, appErr)
+		assert.Equal(t, "1", string(calls))
+	})
+
+	t.Run("slow plugin's events are dropped", func(t *testing.T) {
+		tearDown, pluginIDs, errs := SetAppEnvironmentWithPlugins(t,
+			[]string{
+				`
+			package main
+
+			import (
+				"time"
+
+				"github.com/mattermost/mattermost-server/v6/model"
+				"github.com/mattermost/mattermost-server/v6/plugin"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func (p *MyPlugin) WebSocketEventWillBeSent(event *model.WebSocketEvent) *model.WebSocketEvent {
+				time.Sleep(2 * time.Second)
+				return event
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`}, th.App, th.NewPluginAPI)
+		defer tearDown()
+		require.NoError(t, errs[0])
+		require.True(t, th.App.GetPluginsEnvironment().IsActive(pluginIDs[0]))
+
+		evt := model.NewWebSocketEvent(model.WebsocketEventTyping, "", th.BasicChannel.Id, "", nil)
+		start := time.Now()
+		assert.Nil(t, th.Server.runWebSocketEventWillBeSentHook(evt))
+		assert.Less(t, time.Since(start), 1500*time.Millisecond)
+
+		require.Eventually(t, func() bool {
+			return len(th.Server.webSocketEventHookSlots) == 0
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
@@ -74,7 +74,7 @@ func (a *App) CreatePostAsUser(c *request.Context, post *model.Post, currentSess
 	// the post is NOT a reply post with CRT enabled
 	_, fromWebhook := post.GetProps()["from_webhook"]
 	_, fromBot := post.GetProps()["from_bot"]
-	isCRTReply := post.RootId != "" && a.IsCRTEnabledForUser(post.UserId)
+	isCRTReply := post.IsReply() && a.IsCRTEnabledForUser(post.UserId)
 	if !fromWebhook && !fromBot && !isCRTReply {
 		if _, err := a.MarkChannelsAsViewed([]string{post.ChannelId}, post.UserId, currentSessionId, true); err != nil {
 			mlog.Warn(
@@ -170,10 +170,12 @@ func (a *App) CreatePost(c *request.Context, post *model.Post, channel *model.Ch
 		a.Srv().seenPendingPostIdsCache.SetWithExpiry(post.PendingPostId, savedPost.Id, PendingPostIDsCacheTTL)
 	}()
 
-	post.SanitizeProps()
+	// the reserved props are stripped from client input by the callers, since the server itself sets
+	// some of them, e.g. on webhook posts and system messages, before creating the post
+	post.SanitizeParticipants()
 
 	var pchan chan store.StoreResult
-	if post.RootId != "" {
+	if post.IsReply() {
 		pchan = make(chan store.StoreResult, 1)
 		go func() {
 			r, pErr := a.Srv().Store.Post().Get(sqlstore.WithMaster(context.Background()), post.RootId, model.GetPostsOptions{}, "", a.Config().GetSanitizeOptions())
@@ -197,6 +199,16 @@ func (a *App) CreatePost(c *request.Context, post *model.Post, channel *model.Ch
 		post.AddProp("from_bot", "true")
 	}
 
+	if err = a.checkSlowMode(post, channel); err != nil {
+		return nil, err
+	}
+
+	if post.IsAnnouncement() {
+		if err = a.checkAnnouncement(post, channel); err != nil {
+			return nil, err
+		}
+	}
+
 	var ephemeralPost *model.Post
 	if post.Type == "" && !a.HasPermissionToChannel(user.Id, channel.Id, model.PermissionUseChannelMentions) {
 		mention := post.DisableMentionHighlights()
@@ -225,7 +237,7 @@ func (a *App) CreatePost(c *request.Context, post *model.Post, channel *model.Ch
 		}
 
 		rootPost := parentPostList.Posts[post.RootId]
-		if rootPost.RootId != "" {
+		if rootPost.IsReply() {
 			return nil, model.NewAppError("createPost", "api.post.create_post.root_id.app_error", nil, "", http.StatusBadRequest)
 		}
 	}
@@ -342,7 +354,7 @@ func (a *App) CreatePost(c *request.Context, post *model.Post, channel *model.Ch
 	rpost = a.PreparePostForClient(rpost, true, false)
 
 	// Make sure poster is following the thread
-	if *a.Config().ServiceSettings.ThreadAutoFollow && rpost.RootId != "" {
+	if rpost.IsReply() && a.isThreadAutoFollowEnabledForUser(user.Id) {
 		_, err := a.Srv().Store.Thread().MaintainMembership(user.Id, rpost.RootId, store.ThreadMembershipOpts{
 			Following:       true,
 			UpdateFollowing: true,
@@ -625,6 +637,12 @@ func (a *App) UpdatePost(c *request.Context, post *model.Post, safeUpdate bool)
 		newPost.HasReactions = post.HasReactions
 		newPost.FileIds = post.FileIds
 		newPost.SetProps(post.GetProps())
+		// the reserved props were stripped from the update, so keep the ones the server set on the post
+		for _, key := range model.ReservedPostProps() {
+			if value, ok := oldPost.GetProps()[key]; ok {
+				newPost.AddProp(key, value)
+			}
+		}
 	}
 
 	// Avoid deep-equal checks if EditAt was already modified through message change
@@ -652,6 +670,13 @@ func (a *App) UpdatePost(c *request.Context, post *model.Post, safeUpdate bool)
 		}
 	}
 
+	pinning := newPost.IsPinned && !oldPost.IsPinned
+	if pinning {
+		if err = a.checkPinnedPostLimit(newPost.ChannelId, false); err != nil {
+			return nil, err
+		}
+	}
+
 	rpost, nErr := a.Srv().Store.Post().Update(newPost, oldPost)
 	if nErr != nil {
 		var appErr *model.AppError
@@ -663,6 +688,19 @@ func (a *App) UpdatePost(c *request.Context, post *model.Post, safeUpdate bool)
 		}
 	}
 
+	if pinning {
+		// Pins racing each other can all pass the check above, so the limit is checked again now that
+		// the post is pinned and the pin is backed out before anyone is told about it if it went over.
+		if err = a.checkPinnedPostLimit(rpost.ChannelId, true); err != nil {
+			unpinned := rpost.Clone()
+			unpinned.IsPinned = false
+			if _, nErr := a.Srv().Store.Post().Overwrite(unpinned); nErr != nil {
+				mlog.Warn("Failed to unpin post over the pinned posts limit", mlog.String("post_id", rpost.Id), mlog.Err(nErr))
+			}
+			return nil, err
+		}
+	}
+
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		a.Srv().Go(func() {
 			pluginContext := pluginContext(c)
@@ -797,6 +835,97 @@ func (a *App) PatchPost(c *request.Context, postID string, patch *model.PostPatc
 	return updatedPost, nil
 }
 
+// checkPinnedPostLimit returns an error if pinning a post would take the channel over
+// ServiceSettings.MaxPinnedPostsPerChannel pinned posts. pinned tells whether the post being pinned
+// is already counted.
+func (a *App) checkPinnedPostLimit(channelID string, pinned bool) *model.AppError {
+	limit := int64(*a.Config().ServiceSettings.MaxPinnedPostsPerChannel)
+	if limit <= 0 {
+		return nil
+	}
+
+	count, err := a.Srv().Store.Channel().GetPinnedPostCount(channelID, false)
+	if err != nil {
+		return model.NewAppError("checkPinnedPostLimit", "app.channel.get_pinnedpost_count.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if !pinned {
+		count++
+	}
+	if count > limit {
+		return model.NewAppError("checkPinnedPostLimit", "app.post.pin_post.limit_reached.app_error", map[string]interface{}{"Limit": limit}, "channel_id="+channelID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// PinPost pins the post on behalf of the given user. It fails once the channel holds
+// ServiceSettings.MaxPinnedPostsPerChannel pinned posts and, if enabled, announces the pin
+// with a system message.
+func (a *App) PinPost(c *request.Context, postID, userID string) (*model.Post, *model.AppError) {
+	post, err := a.GetSinglePost(postID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.IsPinned {
+		return post, nil
+	}
+
+	pinnedPost, err := a.PatchPost(c, postID, &model.PostPatch{IsPinned: model.NewBool(true)})
+	if err != nil {
+		return nil, err
+	}
+
+	if *a.Config().ServiceSettings.EnablePinnedPostSystemMessage {
+		if err := a.postPinnedPostMessage(c, userID, pinnedPost); err != nil {
+			mlog.Warn("Failed to post pinned post system message", mlog.String("post_id", postID), mlog.Err(err))
+		}
+	}
+
+	return pinnedPost, nil
+}
+
+// UnpinPost unpins the post, freeing up a slot towards the channel's pinned posts limit.
+func (a *App) UnpinPost(c *request.Context, postID string) (*model.Post, *model.AppError) {
+	post, err := a.GetSinglePost(postID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.IsPinned {
+		return post, nil
+	}
+
+	return a.PatchPost(c, postID, &model.PostPatch{IsPinned: model.NewBool(false)})
+}
+
+func (a *App) postPinnedPostMessage(c *request.Context, userID string, pinnedPost *model.Post) *model.AppError {
+	user, err := a.GetUser(userID)
+	if err != nil {
+		return err
+	}
+
+	channel, err := a.GetChannel(pinnedPost.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		Message:   i18n.T("app.post.pin_post.system_message", map[string]interface{}{"Username": user.Username}),
+		Type:      model.PostTypePostPinned,
+		UserId:    userID,
+		Props: model.StringInterface{
+			"username":       user.Username,
+			"pinned_post_id": pinnedPost.Id,
+		},
+	}
+
+	_, err = a.CreatePost(c, post, channel, false, true)
+	return err
+}
+
 func (a *App) GetPostsPage(options model.GetPostsOptions) (*model.PostList, *model.AppError) {
 	postList, err := a.Srv().Store.Post().GetPosts(options, false, a.Config().GetSanitizeOptions())
 	if err != nil {
@@ -1130,6 +1259,32 @@ func (a *App) GetPostsForChannelAroundLastUnread(channelID, userID string, limit
 	return postList, nil
 }
 
+// checkSlowMode returns an error if the author of the post must wait longer before posting in the
+// channel again. System messages, webhook posts and channel admins are exempt.
+func (a *App) checkSlowMode(post *model.Post, channel *model.Channel) *model.AppError {
+	if channel.SlowModeSeconds <= 0 || post.IsSystemMessage() || post.GetProp("from_webhook") == "true" {
+		return nil
+	}
+
+	if a.HasPermissionToChannel(post.UserId, channel.Id, model.PermissionManageChannelRoles) {
+		return nil
+	}
+
+	lastPostAt, err := a.Srv().Store.Post().GetLastPostCreateAtForUser(channel.Id, post.UserId)
+	if err != nil {
+		return model.NewAppError("checkSlowMode", "app.post.get_last_post_create_at.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	remaining := lastPostAt + int64(channel.SlowModeSeconds)*1000 - model.GetMillis()
+	if remaining <= 0 {
+		return nil
+	}
+
+	// Round up so that waiting for the reported number of seconds is always enough.
+	seconds := (remaining + 999) / 1000
+	return model.NewAppError("CreatePost", "app.post.create_post.slow_mode.app_error", map[string]interface{}{"Seconds": seconds}, fmt.Sprintf("channel_id=%s, remaining_seconds=%d", channel.Id, seconds), http.StatusTooManyRequests)
+}
+
 func (a *App) DeletePost(postID, deleteByID string) (*model.Post, *model.AppError) {
 	post, nErr := a.Srv().Store.Post().GetSingle(postID, false)
 	if nErr != nil {
@@ -1146,6 +1301,12 @@ func (a *App) DeletePost(postID, deleteByID string) (*model.Post, *model.AppErro
 		return nil, err
 	}
 
+	// Users who can delete the posts of others, such as admins, can still delete their own posts past the time limit.
+	if limit := *a.Config().ServiceSettings.PostDeleteTimeLimit; limit != -1 && deleteByID == post.UserId && model.GetMillis() > post.CreateAt+int64(limit*1000) &&
+		!a.HasPermissionToChannel(deleteByID, post.ChannelId, model.PermissionDeleteOthersPosts) {
+		return nil, model.NewAppError("DeletePost", "api.post.delete_post.permissions_time_limit.app_error", map[string]interface{}{"timeLimit": limit}, "", http.StatusBadRequest)
+	}
+
 	if err := a.Srv().Store.Post().Delete(postID, model.GetMillis(), deleteByID); err != nil {
 		var nfErr *store.ErrNotFound
 		switch {
@@ -1315,6 +1476,10 @@ func (a *App) SearchPostsForUser(c *request.Context, terms string, userID string
 		return nil, model.NewAppError("SearchPostsForUser", "store.sql_post.search.disabled", nil, fmt.Sprintf("teamId=%v userId=%v", teamID, userID), http.StatusNotImplemented)
 	}
 
+	if appErr := a.checkSearchRateLimit(userID); appErr != nil {
+		return nil, appErr
+	}
+
 	finalParamsList := []*model.SearchParams{}
 
 	for _, params := range paramsList {
@@ -1552,13 +1717,7 @@ func (a *App) countMentionsFromPost(user *model.User, post *model.Post) (int, in
 		return 0, 0, err
 	}
 
-	keywords := addMentionKeywordsForUser(
-		map[string][]string{},
-		user,
-		channelMember.NotifyProps,
-		&model.Status{Status: model.StatusOnline}, // Assume the user is online since they would've triggered this
-		true, // Assume channel mentions are always allowed for simplicity
-	)
+	keywords := getMentionKeywordsForUser(user, channelMember.NotifyProps)
 	commentMentions := user.NotifyProps[model.CommentsNotifyProp]
 	checkForCommentMentions := commentMentions == model.CommentsNotifyRoot || commentMentions == model.CommentsNotifyAny
 
@@ -1574,7 +1733,7 @@ func (a *App) countMentionsFromPost(user *model.User, post *model.Post) (int, in
 	countRoot := 0
 	if isPostMention(user, post, keywords, thread.Posts, mentionedByThread, checkForCommentMentions) {
 		count += 1
-		if post.RootId == "" {
+		if post.IsRoot() {
 			countRoot += 1
 		}
 	}
@@ -1595,7 +1754,7 @@ func (a *App) countMentionsFromPost(user *model.User, post *model.Post) (int, in
 		for _, postID := range postList.Order {
 			if isPostMention(user, postList.Posts[postID], keywords, postList.Posts, mentionedByThread, checkForCommentMentions) {
 				count += 1
-				if postList.Posts[postID].RootId == "" {
+				if postList.Posts[postID].IsRoot() {
 					countRoot += 1
 				}
 			}
@@ -1612,7 +1771,7 @@ func (a *App) countMentionsFromPost(user *model.User, post *model.Post) (int, in
 }
 
 func isCommentMention(user *model.User, post *model.Post, otherPosts map[string]*model.Post, mentionedByThread map[string]bool) bool {
-	if post.RootId == "" {
+	if post.IsRoot() {
 		// Not a comment
 		return false
 	}
@@ -1648,15 +1807,31 @@ func isCommentMention(user *model.User, post *model.Post, otherPosts map[string]
 	return mentioned
 }
 
-func isPostMention(user *model.User, post *model.Post, keywords map[string][]string, otherPosts map[string]*model.Post, mentionedByThread map[string]bool, checkForCommentMentions bool) bool {
+// getMentionKeywordsForUser returns the keywords that mention the user in a channel with the given notify props.
+func getMentionKeywordsForUser(user *model.User, channelNotifyProps map[string]string) []string {
+	keywordsMap := addMentionKeywordsForUser(
+		map[string][]string{},
+		user,
+		channelNotifyProps,
+		&model.Status{Status: model.StatusOnline}, // Assume the user is online since they would've triggered this
+		true, // Assume channel mentions are always allowed for simplicity
+	)
+
+	keywords := make([]string, 0, len(keywordsMap))
+	for keyword := range keywordsMap {
+		keywords = append(keywords, keyword)
+	}
+	return keywords
+}
+
+func isPostMention(user *model.User, post *model.Post, keywords []string, otherPosts map[string]*model.Post, mentionedByThread map[string]bool, checkForCommentMentions bool) bool {
 	// Prevent the user from mentioning themselves
 	if post.UserId == user.Id && post.GetProp("from_webhook") != "true" {
 		return false
 	}
 
 	// Check for keyword mentions
-	mentions := getExplicitMentions(post, keywords, make(map[string]*model.Group))
-	if _, ok := mentions.Mentions[user.Id]; ok {
+	if mentioned, _ := model.GetPostMention(post, keywords); mentioned {
 		return true
 	}
 
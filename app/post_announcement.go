@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/throttled/throttled"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// checkAnnouncement returns an error if the author of the announcement isn't an admin of the channel,
+// or if too many announcements have been made in the channel recently according to
+// EmailSettings.AnnouncementEmailsPerHour. The limit is kept in memory, so in a cluster each node
+// allows that many announcements per channel.
+func (a *App) checkAnnouncement(post *model.Post, channel *model.Channel) *model.AppError {
+	if !a.HasPermissionToChannel(post.UserId, channel.Id, model.PermissionManageChannelRoles) {
+		return model.NewAppError("CreatePost", "api.post.announcement.permission.app_error", nil, "user_id="+post.UserId+", channel_id="+channel.Id, http.StatusForbidden)
+	}
+
+	perHour := *a.Config().EmailSettings.AnnouncementEmailsPerHour
+	if perHour == 0 || a.ch.announcementRateLimiter == nil {
+		return nil
+	}
+
+	limited, retryAfter, err := a.ch.announcementRateLimiter.rateLimit(channel.Id, throttled.PerHour(perHour), 0)
+	if err != nil {
+		mlog.Warn("Failed to check the announcement rate limit", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		return nil
+	}
+
+	if limited {
+		return model.NewAppError("CreatePost", "api.post.announcement.rate_limit_exceeded.app_error",
+			map[string]interface{}{"RetryAfter": int(math.Ceil(retryAfter.Seconds()))}, "channel_id="+channel.Id, http.StatusTooManyRequests)
+	}
+
+	return nil
+}
+
+// announcementEmailRecipients returns every member of the channel who should be emailed an
+// announcement, which is everyone apart from its author and bots.
+func announcementEmailRecipients(post *model.Post, profileMap map[string]*model.User) []string {
+	recipients := make([]string, 0, len(profileMap))
+	for id, profile := range profileMap {
+		if id == post.UserId || profile.IsBot {
+			continue
+		}
+		recipients = append(recipients, id)
+	}
+
+	return recipients
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	emailmocks "github.com/mattermost/mattermost-server/v6/app/email/mocks"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestCreatePostAnnouncement(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.SendEmailNotifications = true
+		*cfg.EmailSettings.EnableEmailBatching = false
+		*cfg.EmailSettings.RequireEmailVerification = false
+		*cfg.EmailSettings.AnnouncementEmailsPerHour = 1
+	})
+
+	channel := th.CreateChannel(th.BasicTeam)
+	admin := th.BasicUser
+	_, appErr := th.App.UpdateChannelMemberSchemeRoles(channel.Id, admin.Id, false, true, true)
+	require.Nil(t, appErr)
+
+	members := []*model.User{th.BasicUser2, th.CreateUser(), th.CreateUser()}
+	for _, member := range members {
+		th.LinkUserToTeam(member, th.BasicTeam)
+		th.AddUserToChannel(member, channel)
+
+		// Announcements are emailed even to members who turned off email notifications.
+		_, appErr := th.App.UpdateChannelMemberNotifyProps(map[string]string{model.EmailNotifyProp: "false"}, channel.Id, member.Id)
+		require.Nil(t, appErr)
+	}
+
+	newAnnouncement := func(user *model.User) *model.Post {
+		post := &model.Post{UserId: user.Id, ChannelId: channel.Id, Message: "Office closed tomorrow"}
+		post.AddProp(model.PostPropsAnnouncement, true)
+		return post
+	}
+
+	t.Run("not a channel admin", func(t *testing.T) {
+		_, appErr := th.App.CreatePostAsUser(th.Context, newAnnouncement(th.BasicUser2), "", true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.post.announcement.permission.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	// sendAnnouncement creates an announcement and waits for it to be emailed, or queued for email
+	// batching, to every member.
+	sendAnnouncement := func(t *testing.T, batched bool) {
+		emailed := make(chan string, 10)
+		emailServiceMock := emailmocks.ServiceInterface{}
+		emailServiceMock.On("SendMailWithEmbeddedFiles", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.Anything).
+			Run(func(args mock.Arguments) { emailed <- args.String(0) }).
+			Return(nil)
+		emailServiceMock.On("AddNotificationEmailToBatch", mock.AnythingOfType("*model.User"), mock.AnythingOfType("*model.Post"), mock.AnythingOfType("*model.Team")).
+			Run(func(args mock.Arguments) { emailed <- args.Get(0).(*model.User).Email }).
+			Return(nil)
+		emailService := th.App.Srv().EmailService
+		th.App.Srv().EmailService = &emailServiceMock
+		defer func() { th.App.Srv().EmailService = emailService }()
+
+		_, appErr := th.App.CreatePostAsUser(th.Context, newAnnouncement(admin), "", true)
+		require.Nil(t, appErr)
+
+		expected := []string{}
+		for _, member := range members {
+			expected = append(expected, member.Email)
+		}
+
+		recipients := []string{}
+		for range expected {
+			select {
+			case email := <-emailed:
+				recipients = append(recipients, email)
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "timed out waiting for the announcement emails", "received %v", recipients)
+			}
+		}
+		assert.ElementsMatch(t, expected, recipients)
+
+		if batched {
+			emailServiceMock.AssertNumberOfCalls(t, "AddNotificationEmailToBatch", len(expected))
+			emailServiceMock.AssertNotCalled(t, "SendMailWithEmbeddedFiles", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		} else {
+			emailServiceMock.AssertNotCalled(t, "AddNotificationEmailToBatch", mock.Anything, mock.Anything, mock.Anything)
+		}
+	}
+
+	t.Run("emails every member", func(t *testing.T) {
+		sendAnnouncement(t, false)
+	})
+
+	t.Run("queues emails to every member with email batching enabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.EnableEmailBatching = true
+			*cfg.EmailSettings.AnnouncementEmailsPerHour = 0
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.EnableEmailBatching = false
+			*cfg.EmailSettings.AnnouncementEmailsPerHour = 1
+		})
+
+		sendAnnouncement(t, true)
+	})
+
+	t.Run("rate limited per channel", func(t *testing.T) {
+		_, appErr := th.App.CreatePostAsUser(th.Context, newAnnouncement(admin), "", true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.post.announcement.rate_limit_exceeded.app_error", appErr.Id)
+		assert.Equal(t, http.StatusTooManyRequests, appErr.StatusCode)
+
+		// Other channels have their own limit.
+		_, appErr = th.App.CreatePostAsUser(th.Context, &model.Post{
+			UserId:    admin.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   "Office closed tomorrow",
+			Props:     model.StringInterface{model.PostPropsAnnouncement: true},
+		}, "", true)
+		require.Nil(t, appErr)
+	})
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/services/cache"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
 	"github.com/mattermost/mattermost-server/v6/shared/markdown"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 	"github.com/mattermost/mattermost-server/v6/utils/imgutils"
@@ -35,6 +36,10 @@ const LinkCacheSize = 10000
 const LinkCacheDuration = 1 * time.Hour
 const MaxMetadataImageSize = MaxOpenGraphResponseSize
 
+// maxLinkMetadataRedirects is the number of redirects followed when fetching link metadata, the same
+// as the default of net/http.
+const maxLinkMetadataRedirects = 10
+
 var linkCache = cache.NewLRU(cache.LRUOptions{
 	Size: LinkCacheSize,
 })
@@ -113,6 +118,12 @@ func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost, isEditPo
 		return post
 	}
 
+	// Truncated preview for long messages
+	post.Metadata.TruncatedPreview = ""
+	if preview, truncated := model.TruncatePostMessage(post.Message, *a.Config().ServiceSettings.PostTruncatedPreviewLength); truncated {
+		post.Metadata.TruncatedPreview = preview
+	}
+
 	// Emojis and reaction counts
 	if emojis, reactions, err := a.getEmojisAndReactionsForPost(post); err != nil {
 		mlog.Warn("Failed to get emojis and reactions for a post", mlog.String("post_id", post.Id), mlog.Err(err))
@@ -209,6 +220,39 @@ func (a *App) SanitizePostListMetadataForUser(postList *model.PostList, userID s
 	return clonedPostList, nil
 }
 
+// LocalizePostListForUser renders the system messages in the post list in the locale of the given
+// user. The posts are modified in place, so the list must already have been prepared for the client.
+func (a *App) LocalizePostListForUser(postList *model.PostList, userID string) {
+	for _, post := range postList.Posts {
+		if hasLocalizableSystemMessage(post) {
+			postList.LocalizeSystemMessages(a.getTranslationsForUser(userID))
+			return
+		}
+	}
+}
+
+// LocalizePostForUser renders the system message of the post in the locale of the given user. The
+// post is modified in place, so it must already have been prepared for the client.
+func (a *App) LocalizePostForUser(post *model.Post, userID string) {
+	if hasLocalizableSystemMessage(post) {
+		post.LocalizeSystemMessage(a.getTranslationsForUser(userID))
+	}
+}
+
+func hasLocalizableSystemMessage(post *model.Post) bool {
+	return post.IsSystemMessage() && post.GetProp(model.PostPropsSystemMessageId) != nil
+}
+
+func (a *App) getTranslationsForUser(userID string) i18n.TranslateFunc {
+	user, err := a.GetUser(userID)
+	if err != nil {
+		mlog.Warn("Failed to get user to localize system messages", mlog.String("user_id", userID), mlog.Err(err))
+		return i18n.T
+	}
+
+	return i18n.GetUserTranslations(user.Locale)
+}
+
 func (a *App) getFileMetadataForPost(post *model.Post, fromMaster bool) ([]*model.FileInfo, *model.AppError) {
 	if len(post.FileIds) == 0 {
 		return nil, nil
@@ -423,7 +467,35 @@ func (a *App) isLinkAllowedForPreview(link string) bool {
 		}
 	}
 
-	return true
+	return a.isLinkInPreviewAllowlist(link)
+}
+
+// isLinkInPreviewAllowlist returns whether the host of the link is one of the domains of
+// ServiceSettings.LinkPreviewAllowlist, or a subdomain of one of them. Every link is allowed when
+// the allowlist is empty, as are links to the server itself.
+func (a *App) isLinkInPreviewAllowlist(link string) bool {
+	domains := a.normalizeDomains(*a.Config().ServiceSettings.LinkPreviewAllowlist)
+	if len(domains) == 0 {
+		return true
+	}
+
+	u, err := url.Parse(resolveMetadataURL(link, a.GetSiteURL()))
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if siteURL, err := url.Parse(a.GetSiteURL()); err == nil && host == strings.ToLower(siteURL.Hostname()) {
+		return true
+	}
+
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Given a string, returns the first autolinked URL in the string as well as an array of all Markdown
@@ -585,6 +657,9 @@ func (a *App) getLinkMetadata(requestURL string, timestamp int64, isNewPost bool
 		if (request.URL.Scheme+"://"+request.URL.Host) == a.GetSiteURL() && request.URL.Path == "/api/v4/image" {
 			// /api/v4/image requires authentication, so bypass the API by hitting the proxy directly
 			body, contentType, err = a.ImageProxy().GetImageDirect(a.ImageProxy().GetUnproxiedImageURL(request.URL.String()))
+		} else if !a.isLinkAllowedForPreview(requestURL) {
+			// Nothing is cached so that the link is previewed as soon as its domain is allowed.
+			return nil, nil, nil, fmt.Errorf("link previews are not allowed for %s", request.URL.Host)
 		} else {
 			request.Header.Add("Accept", "image/*")
 			request.Header.Add("Accept", "text/html;q=0.8")
@@ -592,6 +667,17 @@ func (a *App) getLinkMetadata(requestURL string, timestamp int64, isNewPost bool
 
 			client := a.HTTPService().MakeClient(false)
 			client.Timeout = time.Duration(*a.Config().ExperimentalSettings.LinkMetadataTimeoutMilliseconds) * time.Millisecond
+			// Every redirect is checked too so that an allowed domain can't send the request on to a
+			// domain that isn't.
+			client.CheckRedirect = func(redirect *http.Request, via []*http.Request) error {
+				if len(via) >= maxLinkMetadataRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxLinkMetadataRedirects)
+				}
+				if !a.isLinkAllowedForPreview(redirect.URL.String()) {
+					return fmt.Errorf("link previews are not allowed for %s", redirect.URL.Host)
+				}
+				return nil
+			}
 
 			var res *http.Response
 			res, err = client.Do(request)
@@ -782,6 +782,110 @@ func TestPreparePostForClient(t *testing.T) {
 	})
 }
 
+func TestPreparePostForClientTruncatedPreview(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.PostTruncatedPreviewLength = 20
+	})
+
+	t.Run("no preview at or below the threshold", func(t *testing.T) {
+		post := &model.Post{Message: "exactly twenty runes"}
+
+		clientPost := th.App.PreparePostForClient(post, false, false)
+
+		assert.Empty(t, clientPost.Metadata.TruncatedPreview)
+		assert.Equal(t, post.Message, clientPost.Message)
+	})
+
+	t.Run("preview above the threshold", func(t *testing.T) {
+		post := &model.Post{Message: "the quick brown fox jumps over the lazy dog"}
+
+		clientPost := th.App.PreparePostForClient(post, false, false)
+
+		assert.Equal(t, "the quick brown fox", clientPost.Metadata.TruncatedPreview)
+		assert.Equal(t, post.Message, clientPost.Message, "the full message should still be returned")
+	})
+
+	t.Run("no preview for deleted posts", func(t *testing.T) {
+		post := &model.Post{Message: "the quick brown fox jumps over the lazy dog", DeleteAt: model.GetMillis()}
+
+		clientPost := th.App.PreparePostForClient(post, false, false)
+
+		assert.Empty(t, clientPost.Metadata.TruncatedPreview)
+	})
+
+	t.Run("no preview when disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.PostTruncatedPreviewLength = 0
+		})
+
+		post := &model.Post{Message: "the quick brown fox jumps over the lazy dog"}
+
+		clientPost := th.App.PreparePostForClient(post, false, false)
+
+		assert.Empty(t, clientPost.Metadata.TruncatedPreview)
+	})
+}
+
+func TestLocalizePostListForUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.BasicUser2.Locale = "es"
+	_, appErr := th.App.UpdateUser(th.BasicUser2, false)
+	require.Nil(t, appErr)
+
+	channel := th.CreateChannel(th.BasicTeam)
+	require.Nil(t, th.App.postJoinChannelMessage(th.Context, th.BasicUser, channel))
+
+	legacyPost, appErr := th.App.CreatePost(th.Context, &model.Post{
+		ChannelId: channel.Id,
+		UserId:    th.BasicUser.Id,
+		Type:      model.PostTypeJoinChannel,
+		Message:   th.BasicUser.Username + " joined the channel.",
+	}, channel, false, true)
+	require.Nil(t, appErr)
+
+	list, appErr := th.App.GetPosts(channel.Id, 0, 10)
+	require.Nil(t, appErr)
+
+	var joinPost *model.Post
+	for _, post := range list.Posts {
+		if post.Id != legacyPost.Id && post.Type == model.PostTypeJoinChannel {
+			joinPost = post
+		}
+	}
+	require.NotNil(t, joinPost)
+	require.Equal(t, th.BasicUser.Username+" joined the channel.", joinPost.Message)
+
+	t.Run("renders in the locale of each user", func(t *testing.T) {
+		enList := th.App.PreparePostListForClient(list)
+		th.App.LocalizePostListForUser(enList, th.BasicUser.Id)
+		assert.Equal(t, th.BasicUser.Username+" joined the channel.", enList.Posts[joinPost.Id].Message)
+
+		esList := th.App.PreparePostListForClient(list)
+		th.App.LocalizePostListForUser(esList, th.BasicUser2.Id)
+		assert.Equal(t, th.BasicUser.Username+" se unió al canal.", esList.Posts[joinPost.Id].Message)
+
+		// The original posts are left untouched.
+		assert.Equal(t, th.BasicUser.Username+" joined the channel.", list.Posts[joinPost.Id].Message)
+	})
+
+	t.Run("single post", func(t *testing.T) {
+		post := th.App.PreparePostForClient(joinPost, false, false)
+		th.App.LocalizePostForUser(post, th.BasicUser2.Id)
+		assert.Equal(t, th.BasicUser.Username+" se unió al canal.", post.Message)
+	})
+
+	t.Run("falls back to the stored message for old posts", func(t *testing.T) {
+		esList := th.App.PreparePostListForClient(list)
+		th.App.LocalizePostListForUser(esList, th.BasicUser2.Id)
+		assert.Equal(t, legacyPost.Message, esList.Posts[legacyPost.Id].Message)
+	})
+}
+
 func TestPreparePostForClientWithImageProxy(t *testing.T) {
 	setup := func(t *testing.T) *TestHelper {
 		th := Setup(t).InitBasic()
@@ -1677,6 +1781,39 @@ func TestGetFirstLinkAndImages(t *testing.T) {
 	}
 }
 
+func TestIsLinkInPreviewAllowlist(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SiteURL = "https://mattermost.example.org"
+	})
+
+	for name, testCase := range map[string]struct {
+		Allowlist string
+		Link      string
+		Expected  bool
+	}{
+		"empty allowlist":          {"", "https://anything.com/page", true},
+		"allowed domain":           {"github.com, example.com", "https://example.com/page", true},
+		"allowed subdomain":        {"example.com", "https://www.EXAMPLE.com/page", true},
+		"domain with a port":       {"example.com", "https://example.com:8443/page", true},
+		"other domain":             {"example.com", "https://another.com/page", false},
+		"suffix of another domain": {"example.com", "https://notexample.com/page", false},
+		"domain in the path":       {"example.com", "https://another.com/example.com", false},
+		"site URL":                 {"example.com", "https://mattermost.example.org/api/v4/image", true},
+		"relative link":            {"example.com", "/api/v4/image?url=test", true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			th.App.UpdateConfig(func(cfg *model.Config) {
+				*cfg.ServiceSettings.LinkPreviewAllowlist = testCase.Allowlist
+			})
+
+			assert.Equal(t, testCase.Expected, th.App.isLinkInPreviewAllowlist(testCase.Link))
+		})
+	}
+}
+
 func TestGetImagesInMessageAttachments(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -1937,6 +2074,8 @@ func TestGetLinkMetadata(t *testing.T) {
 			writeImage(int(height), int(width))
 		} else if strings.HasPrefix(r.URL.Path, "/opengraph") {
 			writeHTML(params["title"][0])
+		} else if strings.HasPrefix(r.URL.Path, "/redirect") {
+			http.Redirect(w, r, params["location"][0], http.StatusFound)
 		} else if strings.HasPrefix(r.URL.Path, "/json") {
 			w.Header().Set("Content-Type", "application/json")
 
@@ -2422,6 +2561,106 @@ func TestGetLinkMetadata(t *testing.T) {
 		assert.IsType(t, imageproxy.Error{}, err)
 	})
 
+	t.Run("should only fetch domains in the allowlist", func(t *testing.T) {
+		th := setup(t)
+		defer th.TearDown()
+
+		timestamp := int64(1547510400000)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.LinkPreviewAllowlist = "example.com, 127.0.0.1"
+		})
+
+		requestURL := server.URL + "/opengraph?title=Allowed&name=" + t.Name()
+		og, _, _, err := th.App.getLinkMetadata(requestURL, timestamp, false, "")
+		require.NoError(t, err)
+		require.NotNil(t, og)
+		assert.Equal(t, "Allowed", og.Title)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.LinkPreviewAllowlist = "example.com"
+		})
+
+		requestURL = server.URL + "/opengraph?title=NotAllowed&name=" + t.Name()
+		og, img, _, err := th.App.getLinkMetadata(requestURL, timestamp, false, "")
+		assert.Nil(t, og)
+		assert.Nil(t, img)
+		assert.Error(t, err)
+
+		_, _, _, ok := getLinkMetadataFromCache(requestURL, timestamp)
+		assert.False(t, ok, "rejected links should not be cached")
+		_, _, ok = th.App.getLinkMetadataFromDatabase(requestURL, timestamp)
+		assert.False(t, ok, "rejected links should not be saved")
+	})
+
+	t.Run("should only follow redirects to domains in the allowlist", func(t *testing.T) {
+		th := setup(t)
+		defer th.TearDown()
+
+		timestamp := int64(1547510400000)
+
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		port := serverURL.Port()
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "127.0.0.1 localhost"
+			*cfg.ServiceSettings.LinkPreviewAllowlist = "127.0.0.1"
+		})
+
+		location := server.URL + "/opengraph?title=Allowed&name=" + t.Name()
+		requestURL := server.URL + "/redirect?location=" + url.QueryEscape(location)
+		og, _, _, err := th.App.getLinkMetadata(requestURL, timestamp, false, "")
+		require.NoError(t, err)
+		require.NotNil(t, og)
+		assert.Equal(t, "Allowed", og.Title)
+
+		location = "http://localhost:" + port + "/opengraph?title=NotAllowed&name=" + t.Name()
+		requestURL = server.URL + "/redirect?location=" + url.QueryEscape(location)
+		og, img, _, err := th.App.getLinkMetadata(requestURL, timestamp, false, "")
+		assert.Nil(t, og)
+		assert.Nil(t, img)
+		require.IsType(t, &url.Error{}, err)
+		assert.Contains(t, err.Error(), "link previews are not allowed for localhost:"+port)
+	})
+
+	t.Run("should not fetch denied domains", func(t *testing.T) {
+		th := setup(t)
+		defer th.TearDown()
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.RestrictLinkPreviews = "127.0.0.1"
+		})
+
+		requestURL := server.URL + "/opengraph?title=Denied&name=" + t.Name()
+		og, img, _, err := th.App.getLinkMetadata(requestURL, int64(1547510400000), false, "")
+		assert.Nil(t, og)
+		assert.Nil(t, img)
+		assert.Error(t, err)
+	})
+
+	t.Run("should not fetch private addresses by default", func(t *testing.T) {
+		th := Setup(t)
+		defer th.TearDown()
+		linkCache.Purge()
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.LinkPreviewAllowlist = "127.0.0.1 10.0.0.1 192.168.1.1"
+		})
+
+		for _, requestURL := range []string{
+			server.URL + "/opengraph?title=Private&name=" + t.Name(),
+			"http://10.0.0.1/opengraph",
+			"http://192.168.1.1/opengraph",
+		} {
+			og, img, _, err := th.App.getLinkMetadata(requestURL, int64(1547510400000), false, "")
+			assert.Nil(t, og, requestURL)
+			assert.Nil(t, img, requestURL)
+			require.IsType(t, &url.Error{}, err, requestURL)
+			assert.Equal(t, httpservice.AddressForbidden, err.(*url.Error).Err, requestURL)
+		}
+	})
+
 	t.Run("should prefer images for mixed content", func(t *testing.T) {
 		th := setup(t)
 		defer th.TearDown()
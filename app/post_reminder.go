@@ -0,0 +1,196 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// MaxPostReminderAttempts is the number of times sending a post reminder may fail before it is dropped.
+const MaxPostReminderAttempts = 5
+
+// SetPostReminder schedules a direct message to the user about the post at the given time,
+// replacing any reminder the user already had for the post.
+func (a *App) SetPostReminder(userID, postID string, remindAt int64) (*model.PostReminder, *model.AppError) {
+	if remindAt <= model.GetMillis() {
+		return nil, model.NewAppError("SetPostReminder", "app.post_reminder.remind_at.app_error", nil, fmt.Sprintf("remind_at=%d", remindAt), http.StatusBadRequest)
+	}
+
+	if _, appErr := a.GetSinglePost(postID, false); appErr != nil {
+		return nil, appErr
+	}
+
+	reminder, err := a.Srv().Store.PostReminder().Save(&model.PostReminder{
+		PostId:   postID,
+		UserId:   userID,
+		RemindAt: remindAt,
+	})
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SetPostReminder", "app.post_reminder.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return reminder, nil
+}
+
+// GetPostReminders returns the user's pending post reminders in the order that they are due.
+func (a *App) GetPostReminders(userID string) ([]*model.PostReminder, *model.AppError) {
+	reminders, err := a.Srv().Store.PostReminder().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetPostReminders", "app.post_reminder.get_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return reminders, nil
+}
+
+// ClearPostReminder removes the user's pending reminder for the post.
+func (a *App) ClearPostReminder(userID, postID string) *model.AppError {
+	if err := a.Srv().Store.PostReminder().Delete(userID, postID); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("ClearPostReminder", "app.post_reminder.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("ClearPostReminder", "app.post_reminder.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// SendPostReminder sends the user a direct message from the system bot linking to the post of a due
+// reminder, then removes the reminder. Reminders for posts that have been deleted, or whose channel
+// the user can no longer read, are removed without sending anything, as are reminders that failed to
+// send MaxPostReminderAttempts times.
+func (a *App) SendPostReminder(c *request.Context, reminder *model.PostReminder) *model.AppError {
+	if appErr := a.sendPostReminder(c, reminder); appErr != nil {
+		a.recordFailedPostReminder(reminder)
+		return appErr
+	}
+
+	if err := a.Srv().Store.PostReminder().Delete(reminder.UserId, reminder.PostId); err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return model.NewAppError("SendPostReminder", "app.post_reminder.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// recordFailedPostReminder counts a failed attempt to send the reminder, giving up on it once it has
+// failed MaxPostReminderAttempts times so that it isn't retried forever.
+func (a *App) recordFailedPostReminder(reminder *model.PostReminder) {
+	attempts := reminder.FailedAttempts + 1
+	if attempts < MaxPostReminderAttempts {
+		if err := a.Srv().Store.PostReminder().UpdateFailedAttempts(reminder.UserId, reminder.PostId, attempts); err != nil {
+			mlog.Warn("Failed to record failed post reminder attempt", mlog.String("post_id", reminder.PostId), mlog.String("user_id", reminder.UserId), mlog.Err(err))
+		}
+		return
+	}
+
+	mlog.Warn("Giving up on post reminder after too many failed attempts", mlog.String("post_id", reminder.PostId), mlog.String("user_id", reminder.UserId), mlog.Int("failed_attempts", attempts))
+	if err := a.Srv().Store.PostReminder().Delete(reminder.UserId, reminder.PostId); err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			mlog.Warn("Failed to delete post reminder", mlog.String("post_id", reminder.PostId), mlog.String("user_id", reminder.UserId), mlog.Err(err))
+		}
+	}
+}
+
+func (a *App) sendPostReminder(c *request.Context, reminder *model.PostReminder) *model.AppError {
+	post, appErr := a.GetSinglePost(reminder.PostId, false)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return appErr
+	}
+
+	channel, appErr := a.GetChannel(post.ChannelId)
+	if appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.GetUser(reminder.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	if user.DeleteAt != 0 || !a.HasPermissionToReadChannel(user.Id, channel) {
+		return nil
+	}
+
+	author, appErr := a.GetUser(post.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	permalink, appErr := a.postReminderPermalink(user.Id, channel, post.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	systemBot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		return appErr
+	}
+
+	dm, appErr := a.GetOrCreateDirectChannel(c, systemBot.UserId, user.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	message := &model.Post{
+		UserId:    systemBot.UserId,
+		ChannelId: dm.Id,
+		Message: T("app.post_reminder.notification", map[string]interface{}{
+			"Username":  author.Username,
+			"Permalink": permalink,
+		}),
+	}
+
+	if _, appErr := a.CreatePost(c, message, dm, false, true); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+// postReminderPermalink returns a link to the post through the channel's team, or through one of
+// the user's teams for posts in direct and group messages.
+func (a *App) postReminderPermalink(userID string, channel *model.Channel, postID string) (string, *model.AppError) {
+	teamID := channel.TeamId
+	if teamID == "" {
+		teams, appErr := a.GetTeamsForUser(userID)
+		if appErr != nil {
+			return "", appErr
+		}
+		if len(teams) == 0 {
+			return a.GetSiteURL(), nil
+		}
+		teamID = teams[0].Id
+	}
+
+	team, appErr := a.GetTeam(teamID)
+	if appErr != nil {
+		return "", appErr
+	}
+
+	return model.MakePermalink(a.GetSiteURL(), team.Name, postID), nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestSetPostReminder(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	remindAt := model.GetMillis() + 60*60*1000
+
+	reminder, appErr := th.App.SetPostReminder(th.BasicUser.Id, th.BasicPost.Id, remindAt)
+	require.Nil(t, appErr)
+	assert.Equal(t, th.BasicPost.Id, reminder.PostId)
+	assert.Equal(t, th.BasicUser.Id, reminder.UserId)
+	assert.Equal(t, remindAt, reminder.RemindAt)
+
+	t.Run("setting again replaces the reminder", func(t *testing.T) {
+		_, appErr := th.App.SetPostReminder(th.BasicUser.Id, th.BasicPost.Id, remindAt+1000)
+		require.Nil(t, appErr)
+
+		reminders, appErr := th.App.GetPostReminders(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, remindAt+1000, reminders[0].RemindAt)
+	})
+
+	t.Run("time in the past", func(t *testing.T) {
+		_, appErr := th.App.SetPostReminder(th.BasicUser.Id, th.BasicPost.Id, model.GetMillis()-1000)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.post_reminder.remind_at.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("missing post", func(t *testing.T) {
+		_, appErr := th.App.SetPostReminder(th.BasicUser.Id, model.NewId(), remindAt)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
+
+func TestClearPostReminder(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	remindAt := model.GetMillis() + 60*60*1000
+	post := th.CreatePost(th.BasicChannel)
+
+	_, appErr := th.App.SetPostReminder(th.BasicUser.Id, th.BasicPost.Id, remindAt)
+	require.Nil(t, appErr)
+	_, appErr = th.App.SetPostReminder(th.BasicUser.Id, post.Id, remindAt)
+	require.Nil(t, appErr)
+
+	appErr = th.App.ClearPostReminder(th.BasicUser.Id, th.BasicPost.Id)
+	require.Nil(t, appErr)
+
+	reminders, appErr := th.App.GetPostReminders(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, post.Id, reminders[0].PostId)
+
+	appErr = th.App.ClearPostReminder(th.BasicUser.Id, th.BasicPost.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.post_reminder.get.not_found.app_error", appErr.Id)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
+
+func TestSendPostReminder(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	systemBot, appErr := th.App.GetSystemBot()
+	require.Nil(t, appErr)
+
+	remindersFor := func(t *testing.T, user *model.User) []*model.Post {
+		t.Helper()
+
+		dm, appErr := th.App.GetOrCreateDirectChannel(th.Context, systemBot.UserId, user.Id)
+		require.Nil(t, appErr)
+
+		list, appErr := th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, appErr)
+
+		posts := []*model.Post{}
+		for _, post := range list.ToSlice() {
+			if post.UserId == systemBot.UserId {
+				posts = append(posts, post)
+			}
+		}
+		return posts
+	}
+
+	setReminder := func(t *testing.T, user *model.User, post *model.Post) *model.PostReminder {
+		t.Helper()
+
+		reminder, appErr := th.App.SetPostReminder(user.Id, post.Id, model.GetMillis()+1000)
+		require.Nil(t, appErr)
+		return reminder
+	}
+
+	assertCleared := func(t *testing.T, user *model.User) {
+		t.Helper()
+
+		reminders, appErr := th.App.GetPostReminders(user.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, reminders)
+	}
+
+	t.Run("sends a link to the post", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+		th.AddUserToChannel(user, th.BasicChannel)
+
+		reminder := setReminder(t, user, th.BasicPost)
+		appErr := th.App.SendPostReminder(th.Context, reminder)
+		require.Nil(t, appErr)
+
+		posts := remindersFor(t, user)
+		require.Len(t, posts, 1)
+		assert.Contains(t, posts[0].Message, "@"+th.BasicUser.Username)
+		assert.Contains(t, posts[0].Message, model.MakePermalink(th.App.GetSiteURL(), th.BasicTeam.Name, th.BasicPost.Id))
+
+		assertCleared(t, user)
+	})
+
+	t.Run("post in a direct channel", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+
+		dm := th.CreateDmChannel(user)
+		post := th.CreatePost(dm)
+
+		reminder := setReminder(t, user, post)
+		appErr := th.App.SendPostReminder(th.Context, reminder)
+		require.Nil(t, appErr)
+
+		posts := remindersFor(t, user)
+		require.Len(t, posts, 1)
+		assert.Contains(t, posts[0].Message, model.MakePermalink(th.App.GetSiteURL(), th.BasicTeam.Name, post.Id))
+
+		assertCleared(t, user)
+	})
+
+	t.Run("deleted post is cleared without sending", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+		th.AddUserToChannel(user, th.BasicChannel)
+
+		post := th.CreatePost(th.BasicChannel)
+		reminder := setReminder(t, user, post)
+
+		_, appErr := th.App.DeletePost(post.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		appErr = th.App.SendPostReminder(th.Context, reminder)
+		require.Nil(t, appErr)
+
+		assert.Empty(t, remindersFor(t, user))
+		assertCleared(t, user)
+	})
+
+	t.Run("channel the user left is cleared without sending", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+
+		private := th.CreatePrivateChannel(th.BasicTeam)
+		th.AddUserToChannel(user, private)
+		post := th.CreatePost(private)
+
+		reminder := setReminder(t, user, post)
+
+		appErr := th.App.RemoveUserFromChannel(th.Context, user.Id, th.BasicUser.Id, private)
+		require.Nil(t, appErr)
+
+		appErr = th.App.SendPostReminder(th.Context, reminder)
+		require.Nil(t, appErr)
+
+		assert.Empty(t, remindersFor(t, user))
+		assertCleared(t, user)
+	})
+	t.Run("failed reminder is dropped after too many attempts", func(t *testing.T) {
+		// Sending fails because the user doesn't exist.
+		userID := model.NewId()
+		reminder, err := th.App.Srv().Store.PostReminder().Save(&model.PostReminder{PostId: th.BasicPost.Id, UserId: userID, RemindAt: model.GetMillis()})
+		require.NoError(t, err)
+
+		appErr := th.App.SendPostReminder(th.Context, reminder)
+		require.NotNil(t, appErr)
+
+		reminders, appErr := th.App.GetPostReminders(userID)
+		require.Nil(t, appErr)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, 1, reminders[0].FailedAttempts)
+
+		reminder.FailedAttempts = MaxPostReminderAttempts - 1
+		appErr = th.App.SendPostReminder(th.Context, reminder)
+		require.NotNil(t, appErr)
+
+		reminders, appErr = th.App.GetPostReminders(userID)
+		require.Nil(t, appErr)
+		assert.Empty(t, reminders)
+	})
+}
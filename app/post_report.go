@@ -0,0 +1,258 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+	"github.com/mattermost/mattermost-server/v6/utils"
+)
+
+// ReportPost records a report by the given user that the post needs to be reviewed for moderation
+// and notifies the admins of the post's channel and team. A user may only report a post once.
+func (a *App) ReportPost(c *request.Context, userID, postID, reason string) (*model.PostReport, *model.AppError) {
+	if reasons := a.Config().ServiceSettings.PostReportReasons; len(reasons) > 0 && !utils.StringInSlice(reason, reasons) {
+		return nil, model.NewAppError("ReportPost", "app.post_report.invalid_reason.app_error", nil, "reason="+reason, http.StatusBadRequest)
+	}
+
+	post, appErr := a.GetSinglePost(postID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	report := &model.PostReport{
+		PostId:     post.Id,
+		ChannelId:  post.ChannelId,
+		ReporterId: userID,
+		Reason:     reason,
+	}
+
+	report, err := a.Srv().Store.PostReport().Save(report)
+	if err != nil {
+		var appErr *model.AppError
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("ReportPost", "app.post_report.save.exists.app_error", nil, cErr.Error(), http.StatusBadRequest)
+		default:
+			return nil, model.NewAppError("ReportPost", "app.post_report.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if appErr := a.notifyAdminsOfPostReport(c, report); appErr != nil {
+		mlog.Warn("Failed to notify admins of post report", mlog.String("report_id", report.Id), mlog.Err(appErr))
+	}
+
+	return report, nil
+}
+
+// notifyAdminsOfPostReport sends a direct message from the system bot to each admin of the channel
+// and team the reported post belongs to, other than the reporter. Failing to notify one admin doesn't
+// stop the others from being notified.
+func (a *App) notifyAdminsOfPostReport(c *request.Context, report *model.PostReport) *model.AppError {
+	channel, appErr := a.GetChannel(report.ChannelId)
+	if appErr != nil {
+		return appErr
+	}
+
+	// Direct and group messages don't have any admins to notify.
+	if channel.TeamId == "" {
+		return nil
+	}
+
+	team, appErr := a.GetTeam(channel.TeamId)
+	if appErr != nil {
+		return appErr
+	}
+
+	reporter, appErr := a.GetUser(report.ReporterId)
+	if appErr != nil {
+		return appErr
+	}
+
+	admins, appErr := a.getPostReportAdmins(channel)
+	if appErr != nil {
+		return appErr
+	}
+
+	systemBot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		return appErr
+	}
+
+	permalink := model.MakePermalink(a.GetSiteURL(), team.Name, report.PostId)
+	for _, admin := range admins {
+		if admin.Id == report.ReporterId || admin.Id == systemBot.UserId {
+			continue
+		}
+
+		dm, appErr := a.GetOrCreateDirectChannel(c, systemBot.UserId, admin.Id)
+		if appErr != nil {
+			mlog.Warn("Failed to get the direct channel to notify an admin of a post report", mlog.String("report_id", report.Id), mlog.String("admin_id", admin.Id), mlog.Err(appErr))
+			continue
+		}
+
+		T := i18n.GetUserTranslations(admin.Locale)
+		post := &model.Post{
+			UserId:    systemBot.UserId,
+			ChannelId: dm.Id,
+			Message: T("app.post_report.notification", map[string]interface{}{
+				"Username":    reporter.Username,
+				"ChannelName": channel.Name,
+				"Reason":      report.Reason,
+				"Permalink":   permalink,
+			}),
+		}
+
+		if _, appErr := a.CreatePost(c, post, dm, false, true); appErr != nil {
+			mlog.Warn("Failed to notify an admin of a post report", mlog.String("report_id", report.Id), mlog.String("admin_id", admin.Id), mlog.Err(appErr))
+		}
+	}
+
+	return nil
+}
+
+// getPostReportAdmins returns the active channel admins and team admins of the given channel.
+func (a *App) getPostReportAdmins(channel *model.Channel) ([]*model.User, *model.AppError) {
+	const perPage = 200
+
+	var admins []*model.User
+	seen := map[string]bool{}
+	add := func(users []*model.User) {
+		for _, user := range users {
+			if !seen[user.Id] {
+				seen[user.Id] = true
+				admins = append(admins, user)
+			}
+		}
+	}
+
+	channelOptions := &model.UserGetOptions{
+		InChannelId:  channel.Id,
+		ChannelRoles: []string{model.ChannelAdminRoleId},
+		Active:       true,
+		PerPage:      perPage,
+	}
+	for {
+		users, err := a.Srv().Store.User().GetProfilesInChannel(channelOptions)
+		if err != nil {
+			return nil, model.NewAppError("getPostReportAdmins", "app.user.get_profiles.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		add(users)
+
+		if len(users) < perPage {
+			break
+		}
+		channelOptions.Page++
+	}
+
+	teamOptions := &model.UserGetOptions{
+		InTeamId:  channel.TeamId,
+		TeamRoles: []string{model.TeamAdminRoleId},
+		Active:    true,
+		PerPage:   perPage,
+	}
+	for {
+		users, err := a.Srv().Store.User().GetProfiles(teamOptions)
+		if err != nil {
+			return nil, model.NewAppError("getPostReportAdmins", "app.user.get_profiles.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		add(users)
+
+		if len(users) < perPage {
+			break
+		}
+		teamOptions.Page++
+	}
+
+	return admins, nil
+}
+
+// GetPostReports returns a page of post reports along with the reported posts and the reporting users.
+func (a *App) GetPostReports(options model.PostReportGetOptions) ([]*model.PostReportWithContext, *model.AppError) {
+	if options.Status != "" && !model.IsValidPostReportStatus(options.Status) {
+		return nil, model.NewAppError("GetPostReports", "app.post_report.invalid_status.app_error", nil, "status="+options.Status, http.StatusBadRequest)
+	}
+
+	reports, err := a.Srv().Store.PostReport().GetAll(options)
+	if err != nil {
+		return nil, model.NewAppError("GetPostReports", "app.post_report.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if len(reports) == 0 {
+		return []*model.PostReportWithContext{}, nil
+	}
+
+	postIDs := make([]string, 0, len(reports))
+	reporterIDs := make([]string, 0, len(reports))
+	for _, report := range reports {
+		postIDs = append(postIDs, report.PostId)
+		reporterIDs = append(reporterIDs, report.ReporterId)
+	}
+
+	posts, err := a.Srv().Store.Post().GetPostsByIds(postIDs)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetPostReports", "app.post.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+	postsByID := make(map[string]*model.Post, len(posts))
+	for _, post := range posts {
+		postsByID[post.Id] = post
+	}
+
+	users, err := a.Srv().Store.User().GetProfileByIds(context.Background(), model.RemoveDuplicateStrings(reporterIDs), &store.UserGetByIdsOpts{}, true)
+	if err != nil {
+		return nil, model.NewAppError("GetPostReports", "app.user.get_profiles.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	usersByID := make(map[string]*model.User, len(users))
+	for _, user := range users {
+		a.SanitizeProfile(user, true)
+		usersByID[user.Id] = user
+	}
+
+	result := make([]*model.PostReportWithContext, 0, len(reports))
+	for _, report := range reports {
+		result = append(result, &model.PostReportWithContext{
+			PostReport: report,
+			Post:       postsByID[report.PostId],
+			Reporter:   usersByID[report.ReporterId],
+		})
+	}
+
+	return result, nil
+}
+
+// UpdatePostReportStatus sets the moderation status of the report.
+func (a *App) UpdatePostReportStatus(reportID, status string) (*model.PostReport, *model.AppError) {
+	if !model.IsValidPostReportStatus(status) {
+		return nil, model.NewAppError("UpdatePostReportStatus", "app.post_report.invalid_status.app_error", nil, "status="+status, http.StatusBadRequest)
+	}
+
+	report, err := a.Srv().Store.PostReport().UpdateStatus(reportID, status)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdatePostReportStatus", "app.post_report.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("UpdatePostReportStatus", "app.post_report.update_status.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestReportPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channelAdmin := th.BasicUser2
+	_, appErr := th.App.UpdateChannelMemberSchemeRoles(th.BasicChannel.Id, channelAdmin.Id, false, true, true)
+	require.Nil(t, appErr)
+
+	teamAdmin := th.CreateUser()
+	th.LinkUserToTeam(teamAdmin, th.BasicTeam)
+	_, appErr = th.App.UpdateTeamMemberSchemeRoles(th.BasicTeam.Id, teamAdmin.Id, false, true, true)
+	require.Nil(t, appErr)
+
+	systemBot, appErr := th.App.GetSystemBot()
+	require.Nil(t, appErr)
+
+	notificationsFor := func(t *testing.T, user *model.User) []*model.Post {
+		t.Helper()
+
+		dm, appErr := th.App.GetOrCreateDirectChannel(th.Context, systemBot.UserId, user.Id)
+		require.Nil(t, appErr)
+
+		list, appErr := th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, appErr)
+
+		posts := []*model.Post{}
+		for _, post := range list.ToSlice() {
+			if post.UserId == systemBot.UserId {
+				posts = append(posts, post)
+			}
+		}
+		return posts
+	}
+
+	report, appErr := th.App.ReportPost(th.Context, th.BasicUser.Id, th.BasicPost.Id, "spam")
+	require.Nil(t, appErr)
+	assert.Equal(t, th.BasicPost.Id, report.PostId)
+	assert.Equal(t, th.BasicChannel.Id, report.ChannelId)
+	assert.Equal(t, th.BasicUser.Id, report.ReporterId)
+	assert.Equal(t, "spam", report.Reason)
+	assert.Equal(t, model.PostReportStatusOpen, report.Status)
+
+	t.Run("notifies channel and team admins", func(t *testing.T) {
+		for _, admin := range []*model.User{channelAdmin, teamAdmin} {
+			posts := notificationsFor(t, admin)
+			require.Len(t, posts, 1)
+			assert.Contains(t, posts[0].Message, "@"+th.BasicUser.Username)
+			assert.Contains(t, posts[0].Message, "spam")
+			assert.Contains(t, posts[0].Message, model.MakePermalink(th.App.GetSiteURL(), th.BasicTeam.Name, th.BasicPost.Id))
+		}
+	})
+
+	t.Run("duplicate report is suppressed", func(t *testing.T) {
+		_, appErr := th.App.ReportPost(th.Context, th.BasicUser.Id, th.BasicPost.Id, "harassment")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.post_report.save.exists.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		assert.Len(t, notificationsFor(t, channelAdmin), 1)
+		assert.Len(t, notificationsFor(t, teamAdmin), 1)
+	})
+
+	t.Run("reason outside of the configured set", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+
+		_, appErr := th.App.ReportPost(th.Context, th.BasicUser.Id, post.Id, "boring")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.post_report.invalid_reason.app_error", appErr.Id)
+	})
+
+	t.Run("any reason when no set is configured", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.PostReportReasons = []string{}
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.PostReportReasons = model.GetDefaultPostReportReasons()
+		})
+
+		post := th.CreatePost(th.BasicChannel)
+
+		report, appErr := th.App.ReportPost(th.Context, th.BasicUser.Id, post.Id, "boring")
+		require.Nil(t, appErr)
+		assert.Equal(t, "boring", report.Reason)
+	})
+
+	t.Run("does not notify the reporter", func(t *testing.T) {
+		reporterAdmin := th.CreateUser()
+		th.LinkUserToTeam(reporterAdmin, th.BasicTeam)
+		_, appErr := th.App.UpdateTeamMemberSchemeRoles(th.BasicTeam.Id, reporterAdmin.Id, false, true, true)
+		require.Nil(t, appErr)
+		th.AddUserToChannel(reporterAdmin, th.BasicChannel)
+
+		post := th.CreatePost(th.BasicChannel)
+		_, appErr = th.App.ReportPost(th.Context, reporterAdmin.Id, post.Id, "other")
+		require.Nil(t, appErr)
+
+		assert.Empty(t, notificationsFor(t, reporterAdmin))
+	})
+}
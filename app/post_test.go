@@ -671,6 +671,71 @@ func TestDeletePostInArchivedChannel(t *testing.T) {
 	require.Equal(t, "api.post.delete_post.can_not_delete_post_in_deleted.error", err.Id)
 }
 
+func TestDeletePostTimeLimit(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// Posts created by the helper are 10 seconds old.
+	setLimit := func(limit int) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.PostDeleteTimeLimit = limit
+		})
+	}
+	defer setLimit(-1)
+
+	t.Run("within the window", func(t *testing.T) {
+		setLimit(3600)
+		post := th.CreatePost(th.BasicChannel)
+
+		_, appErr := th.App.DeletePost(post.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("beyond the window", func(t *testing.T) {
+		setLimit(5)
+		post := th.CreatePost(th.BasicChannel)
+
+		_, appErr := th.App.DeletePost(post.Id, th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.post.delete_post.permissions_time_limit.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		post, appErr = th.App.GetSinglePost(post.Id, false)
+		require.Nil(t, appErr)
+		assert.Zero(t, post.DeleteAt)
+	})
+
+	t.Run("admin beyond the window", func(t *testing.T) {
+		setLimit(5)
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			UserId:    th.SystemAdminUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   "message",
+			CreateAt:  model.GetMillis() - 10000,
+		}, th.BasicChannel, false, true)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.DeletePost(post.Id, th.SystemAdminUser.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("deleting the posts of others", func(t *testing.T) {
+		setLimit(5)
+		post := th.CreatePost(th.BasicChannel)
+
+		_, appErr := th.App.DeletePost(post.Id, th.SystemAdminUser.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		setLimit(-1)
+		post := th.CreatePost(th.BasicChannel)
+
+		_, appErr := th.App.DeletePost(post.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+	})
+}
+
 func TestCreatePost(t *testing.T) {
 	t.Run("call PreparePostForClient before returning", func(t *testing.T) {
 		th := Setup(t).InitBasic()
@@ -1028,6 +1093,77 @@ func TestPatchPost(t *testing.T) {
 	})
 }
 
+func TestCreatePostSlowMode(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam, func(channel *model.Channel) {
+		channel.SlowModeSeconds = 60
+	})
+	th.AddUserToChannel(th.BasicUser2, channel)
+
+	newPost := func(userID string) *model.Post {
+		return &model.Post{
+			ChannelId: channel.Id,
+			UserId:    userID,
+			Message:   "message " + model.NewId(),
+		}
+	}
+
+	t.Run("rejects a second post within the cooldown", func(t *testing.T) {
+		_, appErr := th.App.CreatePost(th.Context, newPost(th.BasicUser2.Id), channel, false, true)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.CreatePost(th.Context, newPost(th.BasicUser2.Id), channel, false, true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.post.create_post.slow_mode.app_error", appErr.Id)
+		assert.Equal(t, http.StatusTooManyRequests, appErr.StatusCode)
+		assert.Contains(t, appErr.DetailedError, "remaining_seconds=60")
+	})
+
+	t.Run("allows posting once the cooldown has passed", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+		th.AddUserToChannel(user, channel)
+
+		post := newPost(user.Id)
+		post.CreateAt = model.GetMillis() - 61*1000
+		_, appErr := th.App.CreatePost(th.Context, post, channel, false, true)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.CreatePost(th.Context, newPost(user.Id), channel, false, true)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("channel admins are exempt", func(t *testing.T) {
+		_, appErr := th.App.CreatePost(th.Context, newPost(th.BasicUser.Id), channel, false, true)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.CreatePost(th.Context, newPost(th.BasicUser.Id), channel, false, true)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("system and webhook posts bypass slow mode", func(t *testing.T) {
+		systemPost := newPost(th.BasicUser2.Id)
+		systemPost.Type = model.PostTypeHeaderChange
+		_, appErr := th.App.CreatePost(th.Context, systemPost, channel, false, true)
+		require.Nil(t, appErr)
+
+		webhookPost := newPost(th.BasicUser2.Id)
+		webhookPost.AddProp("from_webhook", "true")
+		_, appErr = th.App.CreatePost(th.Context, webhookPost, channel, false, true)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("other channels are unaffected", func(t *testing.T) {
+		_, appErr := th.App.CreatePost(th.Context, newPost(th.BasicUser2.Id), th.BasicChannel, false, true)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.CreatePost(th.Context, newPost(th.BasicUser2.Id), th.BasicChannel, false, true)
+		require.Nil(t, appErr)
+	})
+}
+
 func TestCreatePostAsUser(t *testing.T) {
 	t.Run("marks channel as viewed for regular user", func(t *testing.T) {
 		th := Setup(t).InitBasic()
@@ -2741,6 +2877,82 @@ func TestAutofollowOnPostingAfterUnfollow(t *testing.T) {
 	require.True(t, m.Following)
 }
 
+func TestNoAutofollowWhenThreadAutoFollowDisabled(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.CollapsedThreads = model.CollapsedThreadsDisabled
+		*cfg.ServiceSettings.ThreadAutoFollow = false
+	})
+
+	channel := th.BasicChannel
+	user := th.BasicUser
+	user2 := th.BasicUser2
+	appErr := th.App.JoinChannel(th.Context, channel, user.Id)
+	require.Nil(t, appErr)
+	appErr = th.App.JoinChannel(th.Context, channel, user2.Id)
+	require.Nil(t, appErr)
+	p1, err := th.App.CreatePost(th.Context, &model.Post{UserId: user.Id, ChannelId: channel.Id, Message: "Hi @" + user2.Username}, channel, false, false)
+	require.Nil(t, err)
+	_, err = th.App.CreatePost(th.Context, &model.Post{RootId: p1.Id, UserId: user2.Id, ChannelId: channel.Id, Message: "Hola"}, channel, false, false)
+	require.Nil(t, err)
+
+	// Neither replying nor being part of the thread follows it
+	for _, userID := range []string{user.Id, user2.Id} {
+		_, err = th.App.GetThreadMembershipForUser(userID, p1.Id)
+		require.NotNil(t, err)
+	}
+
+	// but the thread can still be followed and unfollowed explicitly
+	err = th.App.UpdateThreadFollowForUser(user2.Id, th.BasicTeam.Id, p1.Id, true)
+	require.Nil(t, err)
+	m, err := th.App.GetThreadMembershipForUser(user2.Id, p1.Id)
+	require.Nil(t, err)
+	require.True(t, m.Following)
+
+	err = th.App.UpdateThreadFollowForUser(user2.Id, th.BasicTeam.Id, p1.Id, false)
+	require.Nil(t, err)
+	m, err = th.App.GetThreadMembershipForUser(user2.Id, p1.Id)
+	require.Nil(t, err)
+	require.False(t, m.Following)
+}
+
+func TestNoAutofollowWhenUserOptedOut(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ThreadAutoFollow = true
+	})
+
+	channel := th.BasicChannel
+	user := th.BasicUser
+	user2 := th.BasicUser2
+
+	appErr := th.App.UpdatePreferences(user2.Id, model.Preferences{{
+		UserId:   user2.Id,
+		Category: model.PreferenceCategoryDisplaySettings,
+		Name:     model.PreferenceNameThreadAutoFollow,
+		Value:    "off",
+	}})
+	require.Nil(t, appErr)
+
+	p1, err := th.App.CreatePost(th.Context, &model.Post{UserId: user.Id, ChannelId: channel.Id, Message: "Hi"}, channel, false, false)
+	require.Nil(t, err)
+	_, err = th.App.CreatePost(th.Context, &model.Post{RootId: p1.Id, UserId: user2.Id, ChannelId: channel.Id, Message: "Hola"}, channel, false, false)
+	require.Nil(t, err)
+
+	// The user who opted out doesn't follow the thread they replied to
+	_, err = th.App.GetThreadMembershipForUser(user2.Id, p1.Id)
+	require.NotNil(t, err)
+
+	// while the root post's author still does
+	m, err := th.App.GetThreadMembershipForUser(user.Id, p1.Id)
+	require.Nil(t, err)
+	require.True(t, m.Following)
+}
+
 func TestGetPostIfAuthorized(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -2817,3 +3029,99 @@ func TestShouldNotRefollowOnOthersReply(t *testing.T) {
 	require.Nil(t, err)
 	require.True(t, m.Following)
 }
+
+func TestPinPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.MaxPinnedPostsPerChannel = 2
+	})
+
+	post1 := th.CreatePost(th.BasicChannel)
+	post2 := th.CreatePost(th.BasicChannel)
+	post3 := th.CreatePost(th.BasicChannel)
+
+	t.Run("pins posts up to the limit", func(t *testing.T) {
+		pinned, err := th.App.PinPost(th.Context, post1.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+		require.True(t, pinned.IsPinned)
+
+		pinned, err = th.App.PinPost(th.Context, post2.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+		require.True(t, pinned.IsPinned)
+	})
+
+	t.Run("fails once the limit is reached", func(t *testing.T) {
+		_, err := th.App.PinPost(th.Context, post3.Id, th.BasicUser.Id)
+		require.NotNil(t, err)
+		require.Equal(t, "app.post.pin_post.limit_reached.app_error", err.Id)
+		require.Equal(t, http.StatusBadRequest, err.StatusCode)
+
+		post, err := th.App.GetSinglePost(post3.Id, false)
+		require.Nil(t, err)
+		require.False(t, post.IsPinned)
+	})
+
+	t.Run("patching a post to pinned fails once the limit is reached", func(t *testing.T) {
+		_, err := th.App.PatchPost(th.Context, post3.Id, &model.PostPatch{IsPinned: model.NewBool(true)})
+		require.NotNil(t, err)
+		require.Equal(t, "app.post.pin_post.limit_reached.app_error", err.Id)
+
+		post, err := th.App.GetSinglePost(post3.Id, false)
+		require.Nil(t, err)
+		require.False(t, post.IsPinned)
+	})
+
+	t.Run("pinning an already pinned post at the limit succeeds", func(t *testing.T) {
+		pinned, err := th.App.PinPost(th.Context, post1.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+		require.True(t, pinned.IsPinned)
+	})
+
+	t.Run("pins in other channels don't count towards the limit", func(t *testing.T) {
+		otherPost := th.CreatePost(th.CreateChannel(th.BasicTeam))
+		_, err := th.App.PinPost(th.Context, otherPost.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+	})
+
+	t.Run("unpinning frees a slot", func(t *testing.T) {
+		unpinned, err := th.App.UnpinPost(th.Context, post1.Id)
+		require.Nil(t, err)
+		require.False(t, unpinned.IsPinned)
+
+		pinned, err := th.App.PinPost(th.Context, post3.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+		require.True(t, pinned.IsPinned)
+	})
+
+	t.Run("no limit when set to zero", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.MaxPinnedPostsPerChannel = 0
+		})
+
+		_, err := th.App.PinPost(th.Context, post1.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+	})
+
+	t.Run("posts a system message when enabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnablePinnedPostSystemMessage = true
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnablePinnedPostSystemMessage = false
+		})
+
+		post := th.CreatePost(th.BasicChannel)
+		_, err := th.App.PinPost(th.Context, post.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+
+		posts, err := th.App.GetPosts(th.BasicChannel.Id, 0, 1)
+		require.Nil(t, err)
+		require.Len(t, posts.Order, 1)
+		systemPost := posts.Posts[posts.Order[0]]
+		assert.Equal(t, model.PostTypePostPinned, systemPost.Type)
+		assert.Equal(t, post.Id, systemPost.GetProp("pinned_post_id"))
+		assert.Equal(t, th.BasicUser.Username+" pinned a message to the channel.", systemPost.Message)
+	})
+}
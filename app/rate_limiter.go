@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/throttled/throttled"
+	"github.com/throttled/throttled/store/memstore"
+)
+
+const keyedRateLimitingMemstoreSize = 65536
+
+// keyedRateLimiter limits how often something can be done for each key, such as a user or a channel.
+// The quota is passed on every call so that the limiter is recreated whenever the settings it comes
+// from change.
+type keyedRateLimiter struct {
+	mut      sync.Mutex
+	store    throttled.GCRAStore
+	rate     throttled.Rate
+	maxBurst int
+	limiter  *throttled.GCRARateLimiter
+}
+
+func newKeyedRateLimiter() (*keyedRateLimiter, error) {
+	store, err := memstore.New(keyedRateLimitingMemstoreSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to setup rate limiting memstore")
+	}
+
+	return &keyedRateLimiter{store: store}, nil
+}
+
+// rateLimit records an action for the key and returns whether it exceeds the quota, along with the
+// time after which the action can be done again.
+func (rl *keyedRateLimiter) rateLimit(key string, rate throttled.Rate, maxBurst int) (bool, time.Duration, error) {
+	rl.mut.Lock()
+	defer rl.mut.Unlock()
+
+	if rl.limiter == nil || rl.rate != rate || rl.maxBurst != maxBurst {
+		limiter, err := throttled.NewGCRARateLimiter(rl.store, throttled.RateQuota{
+			MaxRate:  rate,
+			MaxBurst: maxBurst,
+		})
+		if err != nil {
+			return false, 0, errors.Wrap(err, "unable to setup rate limiter")
+		}
+
+		rl.limiter = limiter
+		rl.rate = rate
+		rl.maxBurst = maxBurst
+	}
+
+	limited, result, err := rl.limiter.RateLimit(key, 1)
+	if err != nil {
+		return false, 0, errors.Wrap(err, "unable to rate limit")
+	}
+
+	return limited, result.RetryAfter, nil
+}
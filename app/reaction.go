@@ -12,6 +12,7 @@ import (
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/plugin"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/utils"
 )
 
 func (a *App) SaveReactionForPost(c *request.Context, reaction *model.Reaction) (*model.Reaction, *model.AppError) {
@@ -29,6 +30,10 @@ func (a *App) SaveReactionForPost(c *request.Context, reaction *model.Reaction)
 		return nil, model.NewAppError("deleteReactionForPost", "api.reaction.save.archived_channel.app_error", nil, "", http.StatusForbidden)
 	}
 
+	if !channel.IsReactionAllowed(reaction.EmojiName) {
+		return nil, model.NewAppError("SaveReactionForPost", "api.reaction.save.not_allowed.app_error", map[string]interface{}{"EmojiName": reaction.EmojiName}, "channel_id="+channel.Id, http.StatusForbidden)
+	}
+
 	reaction, nErr := a.Srv().Store.Reaction().Save(reaction)
 	if nErr != nil {
 		var appErr *model.AppError
@@ -43,6 +48,8 @@ func (a *App) SaveReactionForPost(c *request.Context, reaction *model.Reaction)
 	// The post is always modified since the UpdateAt always changes
 	a.invalidateCacheForChannelPosts(post.ChannelId)
 
+	a.autoPinPostForReaction(c, reaction, post)
+
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		a.Srv().Go(func() {
 			pluginContext := pluginContext(c)
@@ -57,9 +64,32 @@ func (a *App) SaveReactionForPost(c *request.Context, reaction *model.Reaction)
 		a.sendReactionEvent(model.WebsocketEventReactionAdded, reaction, post)
 	})
 
+	a.Srv().Go(func() {
+		if err := a.handleReactionWebhookEvents(c, reaction, post, channel, false); err != nil {
+			mlog.Warn("Failed to handle reaction webhook events", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	})
+
 	return reaction, nil
 }
 
+// SaveReactions validates the reactions and saves them in a single transaction, updating each post
+// they're on once. Saving a reaction that already exists isn't an error. No events are sent and no
+// hooks are run, so this is meant for imports.
+func (a *App) SaveReactions(reactions []*model.Reaction) *model.AppError {
+	if _, err := a.Srv().Store.Reaction().SaveMultiple(reactions); err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return appErr
+		default:
+			return model.NewAppError("SaveReactions", "app.reaction.save.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
 func (a *App) GetReactionsForPost(postID string) ([]*model.Reaction, *model.AppError) {
 	reactions, err := a.Srv().Store.Reaction().GetForPost(postID, true)
 	if err != nil {
@@ -68,19 +98,20 @@ func (a *App) GetReactionsForPost(postID string) ([]*model.Reaction, *model.AppE
 	return reactions, nil
 }
 
-func (a *App) GetBulkReactionsForPosts(postIDs []string) (map[string][]*model.Reaction, *model.AppError) {
-	reactions := make(map[string][]*model.Reaction)
-
-	allReactions, err := a.Srv().Store.Reaction().BulkGetForPosts(postIDs)
+// GetReactionDetailsForPost returns the post's reactions grouped by emoji, each with a page of the
+// users who reacted with it in the order that they did so.
+func (a *App) GetReactionDetailsForPost(postID string, page, perPage int) ([]*model.ReactionDetails, *model.AppError) {
+	details, err := a.Srv().Store.Reaction().GetDetailsForPost(postID, page*perPage, perPage)
 	if err != nil {
-		return nil, model.NewAppError("GetBulkReactionsForPosts", "app.reaction.bulk_get_for_post_ids.app_error", nil, err.Error(), http.StatusInternalServerError)
+		return nil, model.NewAppError("GetReactionDetailsForPost", "app.reaction.get_for_post.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
+	return details, nil
+}
 
-	for _, reaction := range allReactions {
-		reactionsForPost := reactions[reaction.PostId]
-		reactionsForPost = append(reactionsForPost, reaction)
-
-		reactions[reaction.PostId] = reactionsForPost
+func (a *App) GetBulkReactionsForPosts(postIDs []string) (map[string][]*model.Reaction, *model.AppError) {
+	reactions, err := a.Srv().Store.Reaction().GetForPosts(postIDs)
+	if err != nil {
+		return nil, model.NewAppError("GetBulkReactionsForPosts", "app.reaction.bulk_get_for_post_ids.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
 	reactions = populateEmptyReactions(postIDs, reactions)
@@ -156,9 +187,53 @@ func (a *App) DeleteReactionForPost(c *request.Context, reaction *model.Reaction
 		a.sendReactionEvent(model.WebsocketEventReactionRemoved, reaction, post)
 	})
 
+	a.Srv().Go(func() {
+		if err := a.handleReactionWebhookEvents(c, reaction, post, channel, true); err != nil {
+			mlog.Warn("Failed to handle reaction webhook events", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	})
+
 	return nil
 }
 
+// autoPinPostForReaction pins the post as the system bot once it has been reacted with
+// ServiceSettings.AutoPinEmojiName ServiceSettings.AutoPinReactionThreshold times in one of
+// ServiceSettings.AutoPinChannelIds. Failing to pin the post, for instance because the channel
+// already holds as many pinned posts as allowed, doesn't fail the reaction.
+func (a *App) autoPinPostForReaction(c *request.Context, reaction *model.Reaction, post *model.Post) {
+	settings := a.Config().ServiceSettings
+	threshold := *settings.AutoPinReactionThreshold
+	if threshold == 0 || post.IsPinned || reaction.EmojiName != *settings.AutoPinEmojiName || !utils.StringInSlice(post.ChannelId, settings.AutoPinChannelIds) {
+		return
+	}
+
+	reactions, err := a.Srv().Store.Reaction().GetForPost(post.Id, false)
+	if err != nil {
+		mlog.Warn("Failed to get reactions to pin post automatically", mlog.String("post_id", post.Id), mlog.Err(err))
+		return
+	}
+
+	count := 0
+	for _, r := range reactions {
+		if r.EmojiName == reaction.EmojiName {
+			count++
+		}
+	}
+	if count < threshold {
+		return
+	}
+
+	systemBot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		mlog.Warn("Failed to get the system bot to pin post automatically", mlog.String("post_id", post.Id), mlog.Err(appErr))
+		return
+	}
+
+	if _, appErr := a.PinPost(c, post.Id, systemBot.UserId); appErr != nil {
+		mlog.Warn("Failed to pin post automatically", mlog.String("post_id", post.Id), mlog.Err(appErr))
+	}
+}
+
 func (a *App) sendReactionEvent(event string, reaction *model.Reaction, post *model.Post) {
 	// send out that a reaction has been added/removed
 	message := model.NewWebSocketEvent(event, "", post.ChannelId, "", nil)
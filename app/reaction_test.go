@@ -4,6 +4,7 @@
 package app
 
 import (
+	"net/http"
 	"testing"
 	"time"
 
@@ -14,6 +15,188 @@ import (
 	"github.com/mattermost/mattermost-server/v6/testlib"
 )
 
+func TestSaveReactionForPostAllowedReactions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	channel.AllowedReactions = model.StringArray{"+1", "-1"}
+	channel, appErr := th.App.UpdateChannel(channel)
+	require.Nil(t, appErr)
+
+	post := th.CreatePost(channel)
+
+	t.Run("allowed reaction", func(t *testing.T) {
+		reaction, appErr := th.App.SaveReactionForPost(th.Context, &model.Reaction{
+			UserId:    th.BasicUser.Id,
+			PostId:    post.Id,
+			EmojiName: "+1",
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, "+1", reaction.EmojiName)
+	})
+
+	t.Run("disallowed reaction", func(t *testing.T) {
+		_, appErr := th.App.SaveReactionForPost(th.Context, &model.Reaction{
+			UserId:    th.BasicUser.Id,
+			PostId:    post.Id,
+			EmojiName: "smile",
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.reaction.save.not_allowed.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+
+		reactions, appErr := th.App.GetReactionsForPost(post.Id)
+		require.Nil(t, appErr)
+		require.Len(t, reactions, 1)
+	})
+
+	t.Run("any reaction without an allowlist", func(t *testing.T) {
+		_, appErr := th.App.SaveReactionForPost(th.Context, &model.Reaction{
+			UserId:    th.BasicUser.Id,
+			PostId:    th.BasicPost.Id,
+			EmojiName: "smile",
+		})
+		require.Nil(t, appErr)
+	})
+}
+
+func TestSaveReactionForPostAutoPin(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user3 := th.CreateUser()
+	th.LinkUserToTeam(user3, th.BasicTeam)
+	th.AddUserToChannel(user3, th.BasicChannel)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AutoPinEmojiName = "pushpin"
+		*cfg.ServiceSettings.AutoPinReactionThreshold = 2
+		cfg.ServiceSettings.AutoPinChannelIds = []string{th.BasicChannel.Id}
+	})
+
+	react := func(t *testing.T, user *model.User, post *model.Post, emojiName string) {
+		t.Helper()
+		_, appErr := th.App.SaveReactionForPost(th.Context, &model.Reaction{
+			UserId:    user.Id,
+			PostId:    post.Id,
+			EmojiName: emojiName,
+		})
+		require.Nil(t, appErr)
+	}
+
+	isPinned := func(t *testing.T, post *model.Post) bool {
+		t.Helper()
+		post, appErr := th.App.GetSinglePost(post.Id, false)
+		require.Nil(t, appErr)
+		return post.IsPinned
+	}
+
+	t.Run("reaching the threshold", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+
+		react(t, th.BasicUser, post, "pushpin")
+		assert.False(t, isPinned(t, post))
+
+		react(t, th.BasicUser2, post, "pushpin")
+		assert.True(t, isPinned(t, post))
+	})
+
+	t.Run("not reaching the threshold", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+
+		react(t, th.BasicUser, post, "pushpin")
+		react(t, th.BasicUser2, post, "+1")
+		react(t, user3, post, "smile")
+		assert.False(t, isPinned(t, post))
+	})
+
+	t.Run("channel not configured", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		th.AddUserToChannel(th.BasicUser2, channel)
+		post := th.CreatePost(channel)
+
+		react(t, th.BasicUser, post, "pushpin")
+		react(t, th.BasicUser2, post, "pushpin")
+		assert.False(t, isPinned(t, post))
+	})
+
+	t.Run("pinned posts limit reached", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.MaxPinnedPostsPerChannel = 1
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.MaxPinnedPostsPerChannel = 0
+		})
+
+		post := th.CreatePost(th.BasicChannel)
+
+		react(t, th.BasicUser, post, "pushpin")
+		react(t, th.BasicUser2, post, "pushpin")
+		assert.False(t, isPinned(t, post))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.AutoPinReactionThreshold = 0
+		})
+
+		post := th.CreatePost(th.BasicChannel)
+
+		react(t, th.BasicUser, post, "pushpin")
+		react(t, th.BasicUser2, post, "pushpin")
+		assert.False(t, isPinned(t, post))
+	})
+}
+
+func TestSaveReactions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := th.CreatePost(th.BasicChannel)
+
+	t.Run("batch with a duplicate reaction", func(t *testing.T) {
+		appErr := th.App.SaveReactions([]*model.Reaction{
+			{UserId: th.BasicUser.Id, PostId: post.Id, EmojiName: "+1"},
+			{UserId: th.BasicUser2.Id, PostId: post.Id, EmojiName: "+1"},
+			{UserId: th.BasicUser.Id, PostId: post.Id, EmojiName: "+1"},
+		})
+		require.Nil(t, appErr)
+
+		reactions, appErr := th.App.GetReactionsForPost(post.Id)
+		require.Nil(t, appErr)
+		require.Len(t, reactions, 2)
+
+		updated, appErr := th.App.GetSinglePost(post.Id, false)
+		require.Nil(t, appErr)
+		assert.True(t, updated.HasReactions)
+	})
+
+	t.Run("saving the batch again", func(t *testing.T) {
+		appErr := th.App.SaveReactions([]*model.Reaction{
+			{UserId: th.BasicUser.Id, PostId: post.Id, EmojiName: "+1"},
+		})
+		require.Nil(t, appErr)
+
+		reactions, appErr := th.App.GetReactionsForPost(post.Id)
+		require.Nil(t, appErr)
+		require.Len(t, reactions, 2)
+	})
+
+	t.Run("invalid reaction", func(t *testing.T) {
+		appErr := th.App.SaveReactions([]*model.Reaction{
+			{UserId: th.BasicUser.Id, PostId: post.Id, EmojiName: "smile"},
+			{UserId: th.BasicUser.Id, PostId: post.Id},
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		reactions, appErr := th.App.GetReactionsForPost(post.Id)
+		require.Nil(t, appErr)
+		require.Len(t, reactions, 2)
+	})
+}
+
 func TestSharedChannelSyncForReactionActions(t *testing.T) {
 	t.Run("adding a reaction in a shared channel performs a content sync when sync service is running on that node", func(t *testing.T) {
 		th := Setup(t).InitBasic()
@@ -0,0 +1,159 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// ScheduleChannelDeletion schedules the channel to be archived at the given time, replacing any
+// deletion already scheduled for it, and posts a system message counting down to the deletion.
+func (a *App) ScheduleChannelDeletion(c *request.Context, channelID string, deleteAt int64) (*model.ScheduledChannelDeletion, *model.AppError) {
+	now := model.GetMillis()
+	if deleteAt <= now {
+		return nil, model.NewAppError("ScheduleChannelDeletion", "app.scheduled_channel_deletion.delete_at.app_error", nil, fmt.Sprintf("delete_at=%d", deleteAt), http.StatusBadRequest)
+	}
+
+	channel, appErr := a.GetChannel(channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if channel.DeleteAt > 0 {
+		return nil, model.NewAppError("ScheduleChannelDeletion", "api.channel.delete_channel.deleted.app_error", nil, "channel_id="+channelID, http.StatusBadRequest)
+	}
+
+	if channel.IsGroupOrDirect() {
+		return nil, model.NewAppError("ScheduleChannelDeletion", "api.channel.delete_channel.type.invalid", nil, "channel_id="+channelID, http.StatusBadRequest)
+	}
+
+	if channel.Name == model.DefaultChannelName {
+		return nil, model.NewAppError("ScheduleChannelDeletion", "api.channel.delete_channel.cannot.app_error", map[string]interface{}{"Channel": model.DefaultChannelName}, "", http.StatusBadRequest)
+	}
+
+	deletion, err := a.Srv().Store.ScheduledChannelDeletion().Save(&model.ScheduledChannelDeletion{
+		ChannelId: channelID,
+		DeleteAt:  deleteAt,
+	})
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("ScheduleChannelDeletion", "app.scheduled_channel_deletion.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	remaining := time.Duration(deleteAt-now) * time.Millisecond
+	days := int(remaining / (24 * time.Hour))
+	hours := int(remaining % (24 * time.Hour) / time.Hour)
+	minutes := int(remaining % time.Hour / time.Minute)
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		Type:      model.PostTypeDeletionScheduled,
+		Props: model.StringInterface{
+			"delete_at": deleteAt,
+		},
+	}
+	post.SetSystemMessage(i18n.T, "app.scheduled_channel_deletion.scheduled",
+		strconv.Itoa(days), strconv.Itoa(hours), strconv.Itoa(minutes),
+		time.Unix(0, deleteAt*int64(time.Millisecond)).UTC().Format(time.RFC1123))
+
+	if appErr := a.postScheduledChannelDeletionMessage(c, channel, post); appErr != nil {
+		return nil, appErr
+	}
+
+	return deletion, nil
+}
+
+// CancelChannelDeletion cancels the deletion scheduled for the channel and lets its members know.
+func (a *App) CancelChannelDeletion(c *request.Context, channelID string) *model.AppError {
+	if err := a.Srv().Store.ScheduledChannelDeletion().Delete(channelID); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("CancelChannelDeletion", "app.scheduled_channel_deletion.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("CancelChannelDeletion", "app.scheduled_channel_deletion.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	channel, appErr := a.GetChannel(channelID)
+	if appErr != nil {
+		return appErr
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		Type:      model.PostTypeDeletionCancelled,
+	}
+	post.SetSystemMessage(i18n.T, "app.scheduled_channel_deletion.cancelled")
+
+	return a.postScheduledChannelDeletionMessage(c, channel, post)
+}
+
+// ExecuteScheduledChannelDeletion archives the channel of a due deletion as the system bot, then
+// removes the deletion. Deletions that have since been cancelled or moved to a later time are left
+// alone, and those for channels that no longer exist or are already archived are just removed.
+func (a *App) ExecuteScheduledChannelDeletion(c *request.Context, deletion *model.ScheduledChannelDeletion) *model.AppError {
+	current, err := a.Srv().Store.ScheduledChannelDeletion().Get(deletion.ChannelId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil
+		default:
+			return model.NewAppError("ExecuteScheduledChannelDeletion", "app.scheduled_channel_deletion.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if current.DeleteAt > model.GetMillis() {
+		return nil
+	}
+
+	channel, appErr := a.GetChannel(current.ChannelId)
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		return appErr
+	}
+
+	if appErr == nil && channel.DeleteAt == 0 {
+		if appErr := a.DeleteChannel(c, channel, ""); appErr != nil {
+			return appErr
+		}
+	}
+
+	if err := a.Srv().Store.ScheduledChannelDeletion().Delete(current.ChannelId); err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return model.NewAppError("ExecuteScheduledChannelDeletion", "app.scheduled_channel_deletion.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+func (a *App) postScheduledChannelDeletionMessage(c *request.Context, channel *model.Channel, post *model.Post) *model.AppError {
+	systemBot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		return appErr
+	}
+
+	post.UserId = systemBot.UserId
+	if _, appErr := a.CreatePost(c, post, channel, false, true); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestScheduleChannelDeletion(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	systemBot, appErr := th.App.GetSystemBot()
+	require.Nil(t, appErr)
+
+	deleteAt := model.GetMillis() + (2*24+3)*60*60*1000 + 30*1000
+
+	deletion, appErr := th.App.ScheduleChannelDeletion(th.Context, th.BasicChannel.Id, deleteAt)
+	require.Nil(t, appErr)
+	assert.Equal(t, th.BasicChannel.Id, deletion.ChannelId)
+	assert.Equal(t, deleteAt, deletion.DeleteAt)
+
+	t.Run("posts a countdown", func(t *testing.T) {
+		list, appErr := th.App.GetPosts(th.BasicChannel.Id, 0, 1)
+		require.Nil(t, appErr)
+		post := list.Posts[list.Order[0]]
+
+		assert.Equal(t, model.PostTypeDeletionScheduled, post.Type)
+		assert.Equal(t, systemBot.UserId, post.UserId)
+		assert.EqualValues(t, deleteAt, post.GetProp("delete_at"))
+		assert.Contains(t, post.Message, "2 day(s), 3 hour(s) and 0 minute(s)")
+	})
+
+	t.Run("scheduling again replaces the deletion", func(t *testing.T) {
+		_, appErr := th.App.ScheduleChannelDeletion(th.Context, th.BasicChannel.Id, deleteAt+1000)
+		require.Nil(t, appErr)
+
+		deletion, err := th.App.Srv().Store.ScheduledChannelDeletion().Get(th.BasicChannel.Id)
+		require.NoError(t, err)
+		assert.Equal(t, deleteAt+1000, deletion.DeleteAt)
+	})
+
+	t.Run("time in the past", func(t *testing.T) {
+		_, appErr := th.App.ScheduleChannelDeletion(th.Context, th.BasicChannel.Id, model.GetMillis()-1000)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scheduled_channel_deletion.delete_at.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("direct channel", func(t *testing.T) {
+		dm := th.CreateDmChannel(th.BasicUser2)
+
+		_, appErr := th.App.ScheduleChannelDeletion(th.Context, dm.Id, deleteAt)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.channel.delete_channel.type.invalid", appErr.Id)
+	})
+
+	t.Run("default channel", func(t *testing.T) {
+		townSquare, appErr := th.App.GetChannelByName(model.DefaultChannelName, th.BasicTeam.Id, false)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.ScheduleChannelDeletion(th.Context, townSquare.Id, deleteAt)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.channel.delete_channel.cannot.app_error", appErr.Id)
+	})
+
+	t.Run("archived channel", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		appErr := th.App.DeleteChannel(th.Context, channel, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.ScheduleChannelDeletion(th.Context, channel.Id, deleteAt)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.channel.delete_channel.deleted.app_error", appErr.Id)
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		_, appErr := th.App.ScheduleChannelDeletion(th.Context, model.NewId(), deleteAt)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
+
+func TestCancelChannelDeletion(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+
+	_, appErr := th.App.ScheduleChannelDeletion(th.Context, channel.Id, model.GetMillis()+60*60*1000)
+	require.Nil(t, appErr)
+
+	appErr = th.App.CancelChannelDeletion(th.Context, channel.Id)
+	require.Nil(t, appErr)
+
+	_, err := th.App.Srv().Store.ScheduledChannelDeletion().Get(channel.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	list, appErr := th.App.GetPosts(channel.Id, 0, 1)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.PostTypeDeletionCancelled, list.Posts[list.Order[0]].Type)
+
+	appErr = th.App.CancelChannelDeletion(th.Context, channel.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.scheduled_channel_deletion.get.not_found.app_error", appErr.Id)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
+
+func TestExecuteScheduledChannelDeletion(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	scheduleDue := func(t *testing.T, channelID string) *model.ScheduledChannelDeletion {
+		t.Helper()
+
+		// Deletions can't be scheduled in the past through the app, so make one due directly.
+		deletion, err := th.App.Srv().Store.ScheduledChannelDeletion().Save(&model.ScheduledChannelDeletion{
+			ChannelId: channelID,
+			DeleteAt:  model.GetMillis() - 1000,
+		})
+		require.NoError(t, err)
+		return deletion
+	}
+
+	assertRemoved := func(t *testing.T, channelID string) {
+		t.Helper()
+
+		_, err := th.App.Srv().Store.ScheduledChannelDeletion().Get(channelID)
+		var nfErr *store.ErrNotFound
+		assert.True(t, errors.As(err, &nfErr))
+	}
+
+	t.Run("archives the channel", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		deletion := scheduleDue(t, channel.Id)
+
+		appErr := th.App.ExecuteScheduledChannelDeletion(th.Context, deletion)
+		require.Nil(t, appErr)
+
+		channel, appErr = th.App.GetChannel(channel.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, channel.DeleteAt)
+
+		assertRemoved(t, channel.Id)
+	})
+
+	t.Run("cancelled deletion", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		deletion := scheduleDue(t, channel.Id)
+
+		appErr := th.App.CancelChannelDeletion(th.Context, channel.Id)
+		require.Nil(t, appErr)
+
+		appErr = th.App.ExecuteScheduledChannelDeletion(th.Context, deletion)
+		require.Nil(t, appErr)
+
+		channel, appErr = th.App.GetChannel(channel.Id)
+		require.Nil(t, appErr)
+		assert.Zero(t, channel.DeleteAt)
+	})
+
+	t.Run("deletion moved to a later time", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		deletion := scheduleDue(t, channel.Id)
+
+		_, appErr := th.App.ScheduleChannelDeletion(th.Context, channel.Id, model.GetMillis()+60*60*1000)
+		require.Nil(t, appErr)
+
+		appErr = th.App.ExecuteScheduledChannelDeletion(th.Context, deletion)
+		require.Nil(t, appErr)
+
+		channel, appErr = th.App.GetChannel(channel.Id)
+		require.Nil(t, appErr)
+		assert.Zero(t, channel.DeleteAt)
+
+		_, err := th.App.Srv().Store.ScheduledChannelDeletion().Get(channel.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("channel already archived", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		deletion := scheduleDue(t, channel.Id)
+
+		appErr := th.App.DeleteChannel(th.Context, channel, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		appErr = th.App.ExecuteScheduledChannelDeletion(th.Context, deletion)
+		require.Nil(t, appErr)
+
+		assertRemoved(t, channel.Id)
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		deletion := scheduleDue(t, model.NewId())
+
+		appErr := th.App.ExecuteScheduledChannelDeletion(th.Context, deletion)
+		require.Nil(t, appErr)
+
+		assertRemoved(t, deletion.ChannelId)
+	})
+}
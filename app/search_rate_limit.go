@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/throttled/throttled"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// checkSearchRateLimit returns an error if the user has searched more often than allowed by
+// ServiceSettings.SearchRateLimitPerMinute and ServiceSettings.SearchRateLimitMaxBurst.
+func (a *App) checkSearchRateLimit(userID string) *model.AppError {
+	perMinute := *a.Config().ServiceSettings.SearchRateLimitPerMinute
+	if perMinute == 0 || a.ch.searchRateLimiter == nil {
+		return nil
+	}
+
+	limited, retryAfter, err := a.ch.searchRateLimiter.rateLimit(userID, throttled.PerMin(perMinute), *a.Config().ServiceSettings.SearchRateLimitMaxBurst)
+	if err != nil {
+		// Searches aren't blocked because of a problem with the rate limiter itself.
+		mlog.Warn("Failed to check the search rate limit", mlog.String("user_id", userID), mlog.Err(err))
+		return nil
+	}
+
+	if limited {
+		return model.NewAppError("checkSearchRateLimit", "app.search.rate_limit_exceeded.app_error",
+			map[string]interface{}{"RetryAfter": int(math.Ceil(retryAfter.Seconds()))}, "user_id="+userID, http.StatusTooManyRequests)
+	}
+
+	return nil
+}
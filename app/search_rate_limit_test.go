@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// offsetGCRAStore moves the clock of the rate limiter forward so that tests don't have to wait for it to recover.
+type offsetGCRAStore struct {
+	throttled.GCRAStore
+	offset time.Duration
+}
+
+func (s *offsetGCRAStore) GetWithTime(key string) (int64, time.Time, error) {
+	value, now, err := s.GCRAStore.GetWithTime(key)
+	return value, now.Add(s.offset), err
+}
+
+func TestSearchRateLimit(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	store := &offsetGCRAStore{GCRAStore: th.App.ch.searchRateLimiter.store}
+	th.App.ch.searchRateLimiter.store = store
+	th.App.ch.searchRateLimiter.limiter = nil
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SearchRateLimitPerMinute = 1
+		*cfg.ServiceSettings.SearchRateLimitMaxBurst = 2
+	})
+
+	searchPosts := func(user *model.User) *model.AppError {
+		_, appErr := th.App.SearchPostsForUser(th.Context, "test", user.Id, th.BasicTeam.Id, false, false, 0, 0, 20, "")
+		return appErr
+	}
+
+	requireLimited := func(t *testing.T, appErr *model.AppError) {
+		t.Helper()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.search.rate_limit_exceeded.app_error", appErr.Id)
+		assert.Equal(t, http.StatusTooManyRequests, appErr.StatusCode)
+	}
+
+	t.Run("exceeding the limit", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			require.Nil(t, searchPosts(th.BasicUser), "search %d should be allowed", i)
+		}
+
+		requireLimited(t, searchPosts(th.BasicUser))
+
+		_, appErr := th.App.SearchFilesInTeamForUser(th.Context, "test", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20, "")
+		requireLimited(t, appErr)
+	})
+
+	t.Run("other users are not limited", func(t *testing.T) {
+		require.Nil(t, searchPosts(th.BasicUser2))
+	})
+
+	t.Run("recovering", func(t *testing.T) {
+		store.offset += time.Minute
+
+		require.Nil(t, searchPosts(th.BasicUser))
+		requireLimited(t, searchPosts(th.BasicUser))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.SearchRateLimitPerMinute = 0
+		})
+
+		for i := 0; i < 5; i++ {
+			require.Nil(t, searchPosts(th.BasicUser))
+		}
+	})
+}
@@ -43,6 +43,8 @@ import (
 	"github.com/mattermost/mattermost-server/v6/einterfaces"
 	"github.com/mattermost/mattermost-server/v6/jobs"
 	"github.com/mattermost/mattermost-server/v6/jobs/active_users"
+	"github.com/mattermost/mattermost-server/v6/jobs/archived_channel_purge"
+	"github.com/mattermost/mattermost-server/v6/jobs/edit_history_purge"
 	"github.com/mattermost/mattermost-server/v6/jobs/expirynotify"
 	"github.com/mattermost/mattermost-server/v6/jobs/export_delete"
 	"github.com/mattermost/mattermost-server/v6/jobs/export_process"
@@ -50,8 +52,10 @@ import (
 	"github.com/mattermost/mattermost-server/v6/jobs/import_delete"
 	"github.com/mattermost/mattermost-server/v6/jobs/import_process"
 	"github.com/mattermost/mattermost-server/v6/jobs/migrations"
+	"github.com/mattermost/mattermost-server/v6/jobs/post_reminders"
 	"github.com/mattermost/mattermost-server/v6/jobs/product_notices"
 	"github.com/mattermost/mattermost-server/v6/jobs/resend_invitation_email"
+	"github.com/mattermost/mattermost-server/v6/jobs/scheduled_channel_deletions"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/plugin/scheduler"
 	"github.com/mattermost/mattermost-server/v6/services/awsmeter"
@@ -135,8 +139,12 @@ type Server struct {
 
 	EmailService email.ServiceInterface
 
-	hubs     []*Hub
-	hashSeed maphash.Seed
+	hubs           []*Hub
+	hashSeed       maphash.Seed
+	channelViewers *channelViewerIndex
+	// webSocketEventHookSlots bounds the number of websocket events plugins process at once in the
+	// WebSocketEventWillBeSent hook, so that a slow plugin can't pile up goroutines.
+	webSocketEventHookSlots chan struct{}
 
 	httpService            httpservice.HTTPService
 	PushNotificationsHub   PushNotificationsHub
@@ -157,18 +165,20 @@ type Server struct {
 
 	newStore func() (store.Store, error)
 
-	htmlTemplateWatcher     *templates.Container
-	seenPendingPostIdsCache cache.Cache
-	statusCache             cache.Cache
-	openGraphDataCache      cache.Cache
-	configListenerId        string
-	licenseListenerId       string
-	clusterLeaderListenerId string
-	searchConfigListenerId  string
-	searchLicenseListenerId string
-	loggerLicenseListenerId string
-	configStore             *configWrapper
-	filestore               filestore.FileBackend
+	htmlTemplateWatcher          *templates.Container
+	seenPendingPostIdsCache      cache.Cache
+	statusCache                  cache.Cache
+	openGraphDataCache           cache.Cache
+	reactionWebhookDebounceCache cache.Cache
+	loginAttemptsCache           cache.Cache
+	configListenerId             string
+	licenseListenerId            string
+	clusterLeaderListenerId      string
+	searchConfigListenerId       string
+	searchLicenseListenerId      string
+	loggerLicenseListenerId      string
+	configStore                  *configWrapper
+	filestore                    filestore.FileBackend
 
 	telemetryService *telemetry.TelemetryService
 	userService      *users.UserService
@@ -219,10 +229,12 @@ func NewServer(options ...Option) (*Server, error) {
 		WebSocketRouter: &WebSocketRouter{
 			handlers: make(map[string]webSocketHandler),
 		},
-		licenseListeners: map[string]func(*model.License, *model.License){},
-		hashSeed:         maphash.MakeSeed(),
-		timezones:        timezones.New(),
-		products:         make(map[string]Product),
+		licenseListeners:        map[string]func(*model.License, *model.License){},
+		hashSeed:                maphash.MakeSeed(),
+		channelViewers:          newChannelViewerIndex(),
+		webSocketEventHookSlots: make(chan struct{}, maxConcurrentWebSocketEventHooks),
+		timezones:               timezones.New(),
+		products:                make(map[string]Product),
 	}
 
 	for _, option := range options {
@@ -473,6 +485,16 @@ func NewServer(options ...Option) (*Server, error) {
 	}); err != nil {
 		return nil, errors.Wrap(err, "Unable to create opengraphdata cache")
 	}
+	if s.reactionWebhookDebounceCache, err = s.CacheProvider.NewCache(&cache.CacheOptions{
+		Size: ReactionWebhookDebounceCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "Unable to create reaction webhook debounce cache")
+	}
+	if s.loginAttemptsCache, err = s.CacheProvider.NewCache(&cache.CacheOptions{
+		Size: LoginAttemptsCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "Unable to create login attempts cache")
+	}
 
 	s.createPushNotificationsHub()
 
@@ -653,6 +675,14 @@ func NewServer(options ...Option) (*Server, error) {
 		}
 	})
 
+	s.AddConfigListener(func(old, new *model.Config) {
+		if !*old.ServiceSettings.EnableWelcomeMessage && *new.ServiceSettings.EnableWelcomeMessage {
+			if appErr := s.resetWelcomeMessageEnabledAt(); appErr != nil {
+				mlog.Error("Unable to record when the welcome message was enabled", mlog.Err(appErr))
+			}
+		}
+	})
+
 	// Disable active guest accounts on first run if guest accounts are disabled
 	if !*s.Config().GuestAccountsSettings.Enable {
 		appInstance := New(ServerConnector(s.Channels()))
@@ -738,6 +768,9 @@ func (s *Server) runJobs() {
 	s.Go(func() {
 		runCommandWebhookCleanupJob(s)
 	})
+	s.Go(func() {
+		runGuestChannelInviteCleanupJob(s)
+	})
 	s.Go(func() {
 		runConfigCleanupJob(s)
 	})
@@ -1511,6 +1544,13 @@ func runCommandWebhookCleanupJob(s *Server) {
 	}, time.Hour*1)
 }
 
+func runGuestChannelInviteCleanupJob(s *Server) {
+	doGuestChannelInviteCleanup(s)
+	model.CreateRecurringTask("Guest Channel Invite Cleanup", func() {
+		doGuestChannelInviteCleanup(s)
+	}, time.Hour*1)
+}
+
 func runSessionCleanupJob(s *Server) {
 	doSessionCleanup(s)
 	model.CreateRecurringTask("Session Cleanup", func() {
@@ -1579,6 +1619,15 @@ func doCommandWebhookCleanup(s *Server) {
 	s.Store.CommandWebhook().Cleanup()
 }
 
+// doGuestChannelInviteCleanup deletes the guest channel invites that outlived the invitations they
+// were recorded for.
+func doGuestChannelInviteCleanup(s *Server) {
+	mlog.Debug("Cleaning up guest channel invite store.")
+	if err := s.Store.GuestChannelInvite().Cleanup(model.GetMillis() - InvitationExpiryTime); err != nil {
+		mlog.Warn("Error while cleaning up guest channel invites", mlog.Err(err))
+	}
+}
+
 const (
 	sessionsCleanupBatchSize = 1000
 	jobsCleanupBatchSize     = 1000
@@ -2018,6 +2067,30 @@ func (s *Server) initJobs() {
 		export_delete.MakeScheduler(s.Jobs),
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeArchivedChannelPurge,
+		archived_channel_purge.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store),
+		archived_channel_purge.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeEditHistoryPurge,
+		edit_history_purge.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store),
+		edit_history_purge.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeScheduledChannelDeletions,
+		scheduled_channel_deletions.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store),
+		scheduled_channel_deletions.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypePostReminders,
+		post_reminders.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store),
+		post_reminders.MakeScheduler(s.Jobs),
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeExportProcess,
 		export_process.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
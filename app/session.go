@@ -301,7 +301,7 @@ func (a *App) ExtendSessionExpiryIfNeeded(session *model.Session) bool {
 }
 
 // GetSessionLengthInMillis returns the session length, in milliseconds,
-// based on the type of session (Mobile, SSO, Web/LDAP).
+// based on the type of session (Mobile, SAML, OAuth, LDAP, Web).
 func (a *App) GetSessionLengthInMillis(session *model.Session) int64 {
 	if session == nil {
 		return 0
@@ -310,14 +310,31 @@ func (a *App) GetSessionLengthInMillis(session *model.Session) int64 {
 	var hours int
 	if session.IsMobileApp() {
 		hours = *a.Config().ServiceSettings.SessionLengthMobileInHours
-	} else if session.IsSSOLogin() {
-		hours = *a.Config().ServiceSettings.SessionLengthSSOInHours
 	} else {
-		hours = *a.Config().ServiceSettings.SessionLengthWebInHours
+		hours = a.webSessionLengthInHours(session)
 	}
 	return int64(hours * 60 * 60 * 1000)
 }
 
+// webSessionLengthInHours returns the length of a session outside of the mobile app, which depends on
+// how the user logged in. SAML, OAuth and LDAP logins use the SSO or web session length unless a length
+// is set for the authentication method itself.
+func (a *App) webSessionLengthInHours(session *model.Session) int {
+	settings := a.Config().ServiceSettings
+	switch {
+	case session.IsSaml() && *settings.SessionLengthSAMLInHours > 0:
+		return *settings.SessionLengthSAMLInHours
+	case session.IsOAuthUser() && *settings.SessionLengthOAuthInHours > 0:
+		return *settings.SessionLengthOAuthInHours
+	case session.IsSSOLogin():
+		return *settings.SessionLengthSSOInHours
+	case session.IsLdap() && *settings.SessionLengthLDAPInHours > 0:
+		return *settings.SessionLengthLDAPInHours
+	default:
+		return *settings.SessionLengthWebInHours
+	}
+}
+
 // SetSessionExpireInHours sets the session's expiry the specified number of hours
 // relative to either the session creation date or the current time, depending
 // on the `ExtendSessionOnActivity` config setting.
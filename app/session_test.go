@@ -237,6 +237,33 @@ func TestApp_GetSessionLengthInMillis(t *testing.T) {
 		sessionLength := th.App.GetSessionLengthInMillis(session)
 		require.Equal(t, dayMillis*1, sessionLength)
 	})
+
+	t.Run("get session length by authentication method", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.SessionLengthSAMLInHours = 4 * 24
+			*cfg.ServiceSettings.SessionLengthOAuthInHours = 5 * 24
+			*cfg.ServiceSettings.SessionLengthLDAPInHours = 6 * 24
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.SessionLengthSAMLInHours = 0
+			*cfg.ServiceSettings.SessionLengthOAuthInHours = 0
+			*cfg.ServiceSettings.SessionLengthLDAPInHours = 0
+		})
+
+		for prop, expected := range map[string]int64{
+			model.UserAuthServiceIsSaml:  dayMillis * 4,
+			model.UserAuthServiceIsOAuth: dayMillis * 5,
+			model.UserAuthServiceIsLdap:  dayMillis * 6,
+		} {
+			session, err := th.App.CreateSession(&model.Session{
+				UserId: model.NewId(),
+				Props:  map[string]string{prop: "true"},
+			})
+			require.Nil(t, err)
+
+			require.Equal(t, expected, th.App.GetSessionLengthInMillis(session), prop)
+		}
+	})
 }
 
 func TestApp_ExtendExpiryIfNeeded(t *testing.T) {
@@ -47,6 +47,7 @@ func (a *App) SlackImport(c *request.Context, fileData multipart.File, fileSize
 			}
 			return img, release, err
 		},
+		CreatePlaceholderEmoji: a.createPlaceholderEmoji,
 	}
 
 	importer := slackimport.New(a.ch.srv.Store, actions, a.Config())
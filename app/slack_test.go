@@ -4,8 +4,15 @@
 package app
 
 import (
+	"archive/zip"
+	"context"
+	"io"
+	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/mattermost/mattermost-server/v6/model"
 )
 
@@ -84,3 +91,111 @@ func TestProcessSlackAnnouncement(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestSlackImportReactionsAndPins(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableCustomEmoji = true
+	})
+
+	channelName := "slack-" + model.NewId()
+	files := map[string]string{
+		"users.json": `[
+			{"id": "U1", "name": "slack-` + model.NewId()[:8] + `", "profile": {"email": "` + model.NewId() + `@example.com"}},
+			{"id": "U2", "name": "slack-` + model.NewId()[:8] + `", "profile": {"email": "` + model.NewId() + `@example.com"}}
+		]`,
+		"channels.json": `[
+			{"id": "C1", "name": "` + channelName + `", "creator": "U1", "members": ["U1", "U2"]}
+		]`,
+		channelName + "/2020-01-01.json": `[
+			{
+				"type": "message",
+				"user": "U1",
+				"text": "pinned with reactions",
+				"ts": "1577836800.000100",
+				"pinned_to": ["C1"],
+				"reactions": [
+					{"name": "thumbsup::skin-tone-2", "users": ["U1", "U2"], "count": 2},
+					{"name": "simple_smile", "users": ["U2"], "count": 1},
+					{"name": "party-parrot", "users": ["U1"], "count": 1},
+					{"name": "tada", "users": ["U3"], "count": 1}
+				]
+			},
+			{
+				"type": "message",
+				"user": "U2",
+				"text": "plain",
+				"ts": "1577836900.000100"
+			}
+		]`,
+	}
+
+	zipFile, err := os.CreateTemp("", "slack-import-*.zip")
+	require.NoError(t, err)
+	defer os.Remove(zipFile.Name())
+	defer zipFile.Close()
+
+	zipWriter := zip.NewWriter(zipFile)
+	for name, contents := range files {
+		w, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+
+	info, err := zipFile.Stat()
+	require.NoError(t, err)
+	_, err = zipFile.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+
+	appErr, _ := th.App.SlackImport(th.Context, zipFile, info.Size(), th.BasicTeam.Id)
+	require.Nil(t, appErr)
+
+	channel, appErr := th.App.GetChannelByName(channelName, th.BasicTeam.Id, false)
+	require.Nil(t, appErr)
+
+	list, appErr := th.App.GetPosts(channel.Id, 0, 10)
+	require.Nil(t, appErr)
+
+	postsByMessage := map[string]*model.Post{}
+	for _, post := range list.Posts {
+		postsByMessage[post.Message] = post
+	}
+	pinned := postsByMessage["pinned with reactions"]
+	require.NotNil(t, pinned)
+	plain := postsByMessage["plain"]
+	require.NotNil(t, plain)
+
+	t.Run("pins", func(t *testing.T) {
+		assert.True(t, pinned.IsPinned)
+		assert.False(t, plain.IsPinned)
+	})
+
+	t.Run("reactions", func(t *testing.T) {
+		reactions, appErr := th.App.GetReactionsForPost(pinned.Id)
+		require.Nil(t, appErr)
+
+		emojiNames := []string{}
+		for _, reaction := range reactions {
+			emojiNames = append(emojiNames, reaction.EmojiName)
+		}
+		assert.ElementsMatch(t, []string{"thumbsup", "thumbsup", "slightly_smiling_face", "slack_import_unknown"}, emojiNames)
+		assert.True(t, pinned.HasReactions)
+
+		reactions, appErr = th.App.GetReactionsForPost(plain.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, reactions)
+	})
+
+	t.Run("placeholder emoji", func(t *testing.T) {
+		emoji, err := th.App.Srv().Store.Emoji().GetByName(context.Background(), "slack_import_unknown", false)
+		require.NoError(t, err)
+
+		_, contentType, appErr := th.App.GetEmojiImage(emoji.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "png", contentType)
+	})
+}
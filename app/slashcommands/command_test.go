@@ -87,6 +87,108 @@ func TestCreateCommandPost(t *testing.T) {
 	require.Equal(t, err.Id, "api.context.invalid_param.app_error")
 }
 
+func TestCreateCommandPostEphemeralAttachments(t *testing.T) {
+	th := setup(t).initBasic()
+	defer th.tearDown()
+
+	newAttachments := func() []*model.SlackAttachment {
+		return []*model.SlackAttachment{
+			{
+				Text: "pick one",
+				Actions: []*model.PostAction{
+					{
+						Type: model.PostActionTypeButton,
+						Name: "Approve",
+						Integration: &model.PostActionIntegration{
+							URL: "http://localhost/approve",
+						},
+					},
+					{
+						Type:       model.PostActionTypeSelect,
+						Name:       "Assignee",
+						DataSource: model.PostActionDataSourceUsers,
+						Integration: &model.PostActionIntegration{
+							URL: "http://localhost/assign",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("ephemeral response preserves attachments", func(t *testing.T) {
+		post := &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+		}
+		resp := &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Attachments:  newAttachments(),
+		}
+
+		post, err := th.App.CreateCommandPost(th.Context, post, th.BasicTeam.Id, resp, false)
+		require.Nil(t, err)
+		assert.Equal(t, model.PostTypeEphemeral, post.Type)
+
+		attachments := post.Attachments()
+		require.Len(t, attachments, 1)
+		assert.Equal(t, "pick one", attachments[0].Text)
+		require.Len(t, attachments[0].Actions, 2)
+		for _, action := range attachments[0].Actions {
+			assert.NotEmpty(t, action.Id)
+		}
+		assert.Equal(t, model.PostActionDataSourceUsers, attachments[0].Actions[1].DataSource)
+	})
+
+	t.Run("ephemeral response with attachments in props", func(t *testing.T) {
+		post := &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+		}
+		post.AddProp("attachments", newAttachments())
+		resp := &model.CommandResponse{}
+
+		post, err := th.App.CreateCommandPost(th.Context, post, th.BasicTeam.Id, resp, false)
+		require.Nil(t, err)
+		assert.Equal(t, model.PostTypeEphemeral, post.Type)
+		require.Len(t, post.Attachments(), 1)
+	})
+
+	t.Run("invalid attachment", func(t *testing.T) {
+		post := &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+		}
+		attachments := newAttachments()
+		attachments[0].Actions[0].Type = "slider"
+		resp := &model.CommandResponse{
+			Attachments: attachments,
+		}
+
+		_, err := th.App.CreateCommandPost(th.Context, post, th.BasicTeam.Id, resp, false)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.slack_attachment.is_valid.action_type.app_error", err.Id)
+	})
+
+	t.Run("malformed attachment", func(t *testing.T) {
+		post := &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+		}
+		attachments := newAttachments()
+		attachments[0].Color = "not-a-color"
+		resp := &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeInChannel,
+			Attachments:  attachments,
+		}
+
+		_, err := th.App.CreateCommandPost(th.Context, post, th.BasicTeam.Id, resp, false)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.slack_attachment.validate.color.app_error", err.Id)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+}
+
 func TestExecuteCommand(t *testing.T) {
 	th := setup(t).initBasic()
 	defer th.tearDown()
@@ -696,6 +696,8 @@ func (a *App) AddUserToTeamByToken(c *request.Context, userID string, tokenID st
 			return nil, nil, model.NewAppError("AddUserToTeamByToken", "app.channel.get_channels_by_ids.app_error", nil, err.Error(), http.StatusInternalServerError)
 		}
 
+		a.recordGuestChannelInvites(user.Id, channels)
+
 		for _, channel := range channels {
 			_, err := a.AddUserToChannel(user, channel, false)
 			if err != nil {
@@ -750,14 +752,81 @@ func (a *App) AddUserToTeamByInviteId(c *request.Context, inviteId string, userI
 	}
 	user := result.Data.(*model.User)
 
+	// Users who are already members of the team don't use up the invite link.
+	member, nErr := a.Srv().Store.Team().GetMember(context.Background(), team.Id, user.Id)
+	if nErr != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(nErr, &nfErr) {
+			return nil, nil, model.NewAppError("AddUserToTeamByInviteId", "app.team.get_member.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+	usesInvite := member == nil || member.DeleteAt != 0
+	if usesInvite {
+		if appErr := a.useTeamInviteId(team); appErr != nil {
+			return nil, nil, appErr
+		}
+	}
+
 	teamMember, err := a.JoinUserToTeam(c, team, user, "")
 	if err != nil {
+		if usesInvite {
+			a.releaseTeamInviteId(team)
+		}
 		return nil, nil, err
 	}
 
 	return team, teamMember, nil
 }
 
+// checkTeamInviteId returns an error if the invite link of the team has expired or has been used as many times as
+// allowed.
+func checkTeamInviteId(team *model.Team) *model.AppError {
+	if team.IsInviteIdExpired(model.GetMillis()) {
+		return model.NewAppError("checkTeamInviteId", "app.team.invite_id.expired.app_error", nil, "team_id="+team.Id, http.StatusBadRequest)
+	}
+
+	if team.IsInviteIdExhausted() {
+		return model.NewAppError("checkTeamInviteId", "app.team.invite_id.exhausted.app_error", nil, "team_id="+team.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// useTeamInviteId counts a user joining the team with its invite link, failing if the link has expired or has no
+// uses left. The uses are counted by the store so that concurrent joins can't go over the limit.
+func (a *App) useTeamInviteId(team *model.Team) *model.AppError {
+	if appErr := checkTeamInviteId(team); appErr != nil {
+		return appErr
+	}
+
+	if team.InviteMaxUses == 0 {
+		return nil
+	}
+
+	used, err := a.Srv().Store.Team().IncrementInviteUses(team.Id, team.InviteId)
+	if err != nil {
+		return model.NewAppError("useTeamInviteId", "app.team.invite_id.increment_uses.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if !used {
+		return model.NewAppError("useTeamInviteId", "app.team.invite_id.exhausted.app_error", nil, "team_id="+team.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// releaseTeamInviteId gives back a use of the invite link counted by useTeamInviteId when the user it was counted
+// for didn't end up joining the team.
+func (a *App) releaseTeamInviteId(team *model.Team) {
+	if team.InviteMaxUses == 0 {
+		return
+	}
+
+	if err := a.Srv().Store.Team().ReleaseInviteUse(team.Id, team.InviteId); err != nil {
+		mlog.Warn("Failed to release a use of the team invite link", mlog.String("team_id", team.Id), mlog.Err(err))
+	}
+}
+
 func (a *App) JoinUserToTeam(c *request.Context, team *model.Team, user *model.User, userRequestorId string) (*model.TeamMember, *model.AppError) {
 	teamMember, alreadyAdded, err := a.ch.srv.teamService.JoinUserToTeam(team, user)
 	if err != nil {
@@ -813,6 +882,15 @@ func (a *App) JoinUserToTeam(c *request.Context, team *model.Team, user *model.U
 		)
 	}
 
+	if err := a.applyTeamDefaultTheme(user.Id, team); err != nil {
+		mlog.Warn(
+			"Encountered an issue applying the default theme of the team.",
+			mlog.String("user_id", user.Id),
+			mlog.String("team_id", team.Id),
+			mlog.Err(err),
+		)
+	}
+
 	shouldBeAdmin := team.Email == user.Email
 
 	if !user.IsGuest() {
@@ -839,6 +917,32 @@ func (a *App) JoinUserToTeam(c *request.Context, team *model.Team, user *model.U
 	return teamMember, nil
 }
 
+// applyTeamDefaultTheme gives the user the default theme of the team unless they have already picked a theme,
+// either for all of their teams or for this one.
+func (a *App) applyTeamDefaultTheme(userID string, team *model.Team) *model.AppError {
+	if team.DefaultTheme == "" {
+		return nil
+	}
+
+	themes, err := a.Srv().Store.Preference().GetCategory(userID, model.PreferenceCategoryTheme)
+	if err != nil {
+		return model.NewAppError("applyTeamDefaultTheme", "app.preference.get_category.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, theme := range themes {
+		if theme.Name == "" || theme.Name == team.Id {
+			return nil
+		}
+	}
+
+	return a.UpdatePreferences(userID, model.Preferences{{
+		UserId:   userID,
+		Category: model.PreferenceCategoryTheme,
+		Name:     team.Id,
+		Value:    team.DefaultTheme,
+	}})
+}
+
 func (a *App) GetTeam(teamID string) (*model.Team, *model.AppError) {
 	team, err := a.ch.srv.teamService.GetTeam(teamID)
 	if err != nil {
@@ -896,6 +1000,10 @@ func (a *App) GetTeamByInviteId(inviteId string) (*model.Team, *model.AppError)
 		}
 	}
 
+	if appErr := checkTeamInviteId(team); appErr != nil {
+		return nil, appErr
+	}
+
 	return team, nil
 }
 
@@ -1302,13 +1410,13 @@ func (a *App) LeaveTeam(c *request.Context, team *model.Team, user *model.User,
 func (a *App) postLeaveTeamMessage(c *request.Context, user *model.User, channel *model.Channel) *model.AppError {
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message:   fmt.Sprintf(i18n.T("api.team.leave.left"), user.Username),
 		Type:      model.PostTypeLeaveTeam,
 		UserId:    user.Id,
 		Props: model.StringInterface{
 			"username": user.Username,
 		},
 	}
+	post.SetSystemMessage(i18n.T, "api.team.leave.left", user.Username)
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("postRemoveFromChannelMessage", "api.channel.post_user_add_remove_message_and_forget.error", nil, err.Error(), http.StatusInternalServerError)
@@ -1320,13 +1428,13 @@ func (a *App) postLeaveTeamMessage(c *request.Context, user *model.User, channel
 func (a *App) postRemoveFromTeamMessage(c *request.Context, user *model.User, channel *model.Channel) *model.AppError {
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message:   fmt.Sprintf(i18n.T("api.team.remove_user_from_team.removed"), user.Username),
 		Type:      model.PostTypeRemoveFromTeam,
 		UserId:    user.Id,
 		Props: model.StringInterface{
 			"username": user.Username,
 		},
 	}
+	post.SetSystemMessage(i18n.T, "api.team.remove_user_from_team.removed", user.Username)
 
 	if _, err := a.CreatePost(c, post, channel, false, true); err != nil {
 		return model.NewAppError("postRemoveFromTeamMessage", "api.channel.post_user_add_remove_message_and_forget.error", nil, err.Error(), http.StatusInternalServerError)
@@ -8,8 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1022,6 +1025,182 @@ func TestJoinUserToTeam(t *testing.T) {
 	})
 }
 
+func TestJoinUserToTeamDefaultTheme(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	defaultTheme := `{"sidebarBg":"#145dbf","type":"Custom Theme"}`
+
+	team := th.CreateTeam()
+	team, appErr := th.App.PatchTeam(team.Id, &model.TeamPatch{DefaultTheme: model.NewString(defaultTheme)})
+	require.Nil(t, appErr)
+	require.Equal(t, defaultTheme, team.DefaultTheme)
+
+	t.Run("new member gets the default theme", func(t *testing.T) {
+		user := th.CreateUser()
+
+		_, appErr := th.App.JoinUserToTeam(th.Context, team, user, "")
+		require.Nil(t, appErr)
+
+		theme, appErr := th.App.GetPreferenceByCategoryAndNameForUser(user.Id, model.PreferenceCategoryTheme, team.Id)
+		require.Nil(t, appErr)
+		assert.JSONEq(t, defaultTheme, theme.Value)
+	})
+
+	t.Run("member with their own theme keeps it", func(t *testing.T) {
+		user := th.CreateUser()
+		ownTheme := `{"sidebarBg":"#000000","type":"Custom Theme"}`
+		appErr := th.App.UpdatePreferences(user.Id, model.Preferences{{
+			UserId:   user.Id,
+			Category: model.PreferenceCategoryTheme,
+			Name:     "",
+			Value:    ownTheme,
+		}})
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.JoinUserToTeam(th.Context, team, user, "")
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetPreferenceByCategoryAndNameForUser(user.Id, model.PreferenceCategoryTheme, team.Id)
+		require.NotNil(t, appErr)
+
+		theme, appErr := th.App.GetPreferenceByCategoryAndNameForUser(user.Id, model.PreferenceCategoryTheme, "")
+		require.Nil(t, appErr)
+		assert.JSONEq(t, ownTheme, theme.Value)
+	})
+
+	t.Run("team without a default theme", func(t *testing.T) {
+		user := th.CreateUser()
+
+		_, appErr := th.App.JoinUserToTeam(th.Context, th.CreateTeam(), user, "")
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetPreferenceByCategoryForUser(user.Id, model.PreferenceCategoryTheme)
+		require.NotNil(t, appErr)
+	})
+}
+
+func TestAddUserToTeamByInviteIdLimits(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("expired invite link", func(t *testing.T) {
+		team := th.CreateTeam()
+		team.InviteExpireAt = model.GetMillis() - 1000
+		team, appErr := th.App.UpdateTeam(team)
+		require.Nil(t, appErr)
+
+		user := th.CreateUser()
+		_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, team.InviteId, user.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.invite_id.expired.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		_, appErr = th.App.GetTeamByInviteId(team.InviteId)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.invite_id.expired.app_error", appErr.Id)
+	})
+
+	t.Run("invite link that hasn't expired", func(t *testing.T) {
+		team := th.CreateTeam()
+		team.InviteExpireAt = model.GetMillis() + 60*60*1000
+		team, appErr := th.App.UpdateTeam(team)
+		require.Nil(t, appErr)
+
+		user := th.CreateUser()
+		_, member, appErr := th.App.AddUserToTeamByInviteId(th.Context, team.InviteId, user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, user.Id, member.UserId)
+	})
+
+	t.Run("exhausted invite link", func(t *testing.T) {
+		team := th.CreateTeam()
+		team.InviteMaxUses = 2
+		team, appErr := th.App.UpdateTeam(team)
+		require.Nil(t, appErr)
+
+		user1 := th.CreateUser()
+		_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, team.InviteId, user1.Id)
+		require.Nil(t, appErr)
+
+		// Joining again doesn't use up the invite link
+		_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, team.InviteId, user1.Id)
+		require.Nil(t, appErr)
+
+		user2 := th.CreateUser()
+		_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, team.InviteId, user2.Id)
+		require.Nil(t, appErr)
+
+		user3 := th.CreateUser()
+		_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, team.InviteId, user3.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.invite_id.exhausted.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		_, appErr = th.App.GetTeamMember(team.Id, user3.Id)
+		require.NotNil(t, appErr, "shouldn't have joined the team")
+
+		team, appErr = th.App.GetTeam(team.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, 2, team.InviteUses)
+
+		t.Run("regenerating the invite link", func(t *testing.T) {
+			team, appErr := th.App.RegenerateTeamInviteId(team.Id)
+			require.Nil(t, appErr)
+
+			_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, team.InviteId, user3.Id)
+			require.Nil(t, appErr)
+		})
+	})
+
+	t.Run("concurrent joins don't go over the limit", func(t *testing.T) {
+		team := th.CreateTeam()
+		team.InviteMaxUses = 3
+		team, appErr := th.App.UpdateTeam(team)
+		require.Nil(t, appErr)
+
+		users := make([]*model.User, 6)
+		for i := range users {
+			users[i] = th.CreateUser()
+		}
+
+		var wg sync.WaitGroup
+		var joined int32
+		for _, user := range users {
+			wg.Add(1)
+			go func(user *model.User) {
+				defer wg.Done()
+				if _, _, appErr := th.App.AddUserToTeamByInviteId(th.Context, team.InviteId, user.Id); appErr == nil {
+					atomic.AddInt32(&joined, 1)
+				}
+			}(user)
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(3), joined)
+	})
+
+	t.Run("failing to join gives the use back", func(t *testing.T) {
+		team := th.CreateTeam()
+		team.InviteMaxUses = 2
+		team, appErr := th.App.UpdateTeam(team)
+		require.Nil(t, appErr)
+
+		th.LinkUserToTeam(th.CreateUser(), team)
+		maxUsersPerTeam := *th.App.Config().TeamSettings.MaxUsersPerTeam
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.MaxUsersPerTeam = 1 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.MaxUsersPerTeam = maxUsersPerTeam })
+
+		user := th.CreateUser()
+		_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, team.InviteId, user.Id)
+		require.NotNil(t, appErr)
+
+		team, appErr = th.App.GetTeam(team.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, 0, team.InviteUses)
+	})
+}
+
 func TestLeaveTeamPanic(t *testing.T) {
 	th := SetupWithStoreMock(t)
 	defer th.TearDown()
@@ -94,6 +94,9 @@ func (ts *TeamService) UpdateTeam(team *model.Team, opts UpdateOptions) (*model.
 		oldTeam.AllowedDomains = team.AllowedDomains
 		oldTeam.LastTeamIconUpdate = team.LastTeamIconUpdate
 		oldTeam.GroupConstrained = team.GroupConstrained
+		oldTeam.InviteExpireAt = team.InviteExpireAt
+		oldTeam.InviteMaxUses = team.InviteMaxUses
+		oldTeam.DefaultTheme = team.DefaultTheme
 	}
 
 	oldTeam, err = ts.store.Update(oldTeam)
@@ -15,12 +15,16 @@ import (
 	"github.com/mattermost/mattermost-server/v6/app/request"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/plugin"
+	"github.com/mattermost/mattermost-server/v6/shared/filestore"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 	"github.com/mattermost/mattermost-server/v6/store"
 )
 
 const minFirstPartSize = 5 * 1024 * 1024 // 5MB
 
+// presignedUploadExpiry is how long a URL issued for a direct upload to the file store remains valid.
+const presignedUploadExpiry = 15 * time.Minute
+
 func (a *App) runPluginsHook(c *request.Context, info *model.FileInfo, file io.Reader) *model.AppError {
 	pluginsEnvironment := a.GetPluginsEnvironment()
 	if pluginsEnvironment == nil {
@@ -127,6 +131,10 @@ func (a *App) CreateUploadSession(us *model.UploadSession) (*model.UploadSession
 			return nil, model.NewAppError("CreateUploadSession", "app.upload.create.cannot_upload_to_deleted_channel.app_error",
 				map[string]interface{}{"channelId": us.ChannelId}, "", http.StatusBadRequest)
 		}
+		if !channel.IsFileExtensionAllowed(us.Filename) {
+			return nil, model.NewAppError("CreateUploadSession", "api.file.upload_file.extension_not_allowed.app_error",
+				map[string]interface{}{"Name": us.Filename}, "", http.StatusBadRequest)
+		}
 	}
 
 	us, storeErr := a.Srv().Store.UploadSession().Save(us)
@@ -162,27 +170,32 @@ func (a *App) GetUploadSessionsForUser(userID string) ([]*model.UploadSession, *
 	return uss, nil
 }
 
+// lockUploadSession marks the upload session as being written to, returning false if it already is.
+func (a *App) lockUploadSession(uploadID string) bool {
+	a.ch.uploadLockMapMut.Lock()
+	defer a.ch.uploadLockMapMut.Unlock()
+
+	if a.ch.uploadLockMap[uploadID] {
+		return false
+	}
+	a.ch.uploadLockMap[uploadID] = true
+	return true
+}
+
+func (a *App) unlockUploadSession(uploadID string) {
+	a.ch.uploadLockMapMut.Lock()
+	delete(a.ch.uploadLockMap, uploadID)
+	a.ch.uploadLockMapMut.Unlock()
+}
+
 func (a *App) UploadData(c *request.Context, us *model.UploadSession, rd io.Reader) (*model.FileInfo, *model.AppError) {
 	// prevent more than one caller to upload data at the same time for a given upload session.
 	// This is to avoid possible inconsistencies.
-	a.ch.uploadLockMapMut.Lock()
-	locked := a.ch.uploadLockMap[us.Id]
-	if locked {
-		// session lock is already taken, return error.
-		a.ch.uploadLockMapMut.Unlock()
+	if !a.lockUploadSession(us.Id) {
 		return nil, model.NewAppError("UploadData", "app.upload.upload_data.concurrent.app_error",
 			nil, "", http.StatusBadRequest)
 	}
-	// grab the session lock.
-	a.ch.uploadLockMap[us.Id] = true
-	a.ch.uploadLockMapMut.Unlock()
-
-	// reset the session lock on exit.
-	defer func() {
-		a.ch.uploadLockMapMut.Lock()
-		delete(a.ch.uploadLockMap, us.Id)
-		a.ch.uploadLockMapMut.Unlock()
-	}()
+	defer a.unlockUploadSession(us.Id)
 
 	// fetch the session from store to check for inconsistencies.
 	if storedSession, err := a.GetUploadSession(us.Id); err != nil {
@@ -238,7 +251,12 @@ func (a *App) UploadData(c *request.Context, us *model.UploadSession, rd io.Read
 		return nil, nil
 	}
 
-	// upload is done, create FileInfo
+	return a.createFileInfoForUpload(c, us, uploadPath)
+}
+
+// createFileInfoForUpload creates the FileInfo of a finished upload whose data is stored at uploadPath
+// and deletes its upload session.
+func (a *App) createFileInfoForUpload(c *request.Context, us *model.UploadSession, uploadPath string) (*model.FileInfo, *model.AppError) {
 	file, err := a.FileReader(uploadPath)
 	if err != nil {
 		return nil, model.NewAppError("UploadData", "app.upload.upload_data.read_file.app_error", nil, err.Error(), http.StatusInternalServerError)
@@ -262,6 +280,10 @@ func (a *App) UploadData(c *request.Context, us *model.UploadSession, rd io.Read
 		return nil, err
 	}
 
+	if err := a.scanFileAtPath(info); err != nil {
+		return nil, err
+	}
+
 	// image post-processing
 	if info.IsImage() && !info.IsSvg() {
 		if limitErr := checkImageResolutionLimit(info.Width, info.Height, *a.Config().FileSettings.MaxImageResolution); limitErr != nil {
@@ -313,3 +335,88 @@ func (a *App) UploadData(c *request.Context, us *model.UploadSession, rd io.Read
 
 	return info, nil
 }
+
+// CreatePresignedUpload creates an upload session along with a URL through which the client can upload
+// the file directly to the file store. If the file store doesn't support direct uploads, as is the
+// case for local storage, the URL is left empty and the data must be sent through UploadData instead.
+func (a *App) CreatePresignedUpload(us *model.UploadSession) (*model.PresignedUpload, *model.AppError) {
+	us, appErr := a.CreateUploadSession(us)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	result := &model.PresignedUpload{UploadSession: us}
+
+	backend, ok := a.FileBackend().(filestore.FileBackendWithPresignedUploads)
+	if !ok || us.Type != model.UploadTypeAttachment {
+		return result, nil
+	}
+
+	expiresAt := time.Now().Add(presignedUploadExpiry)
+	url, headers, err := backend.PresignedUploadURL(us.Path, presignedUploadExpiry)
+	if err != nil {
+		return nil, model.NewAppError("CreatePresignedUpload", "app.upload.create_presigned.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	result.URL = url
+	result.ExpiresAt = model.GetMillisForTime(expiresAt)
+	if len(headers) > 0 {
+		result.Headers = make(map[string]string, len(headers))
+		for key := range headers {
+			result.Headers[key] = headers.Get(key)
+		}
+	}
+
+	return result, nil
+}
+
+// CompletePresignedUpload verifies that the file of an upload session created by CreatePresignedUpload
+// has been uploaded to the file store with the expected size and creates its FileInfo.
+func (a *App) CompletePresignedUpload(c *request.Context, us *model.UploadSession) (*model.FileInfo, *model.AppError) {
+	if _, ok := a.FileBackend().(filestore.FileBackendWithPresignedUploads); !ok || us.Type != model.UploadTypeAttachment {
+		return nil, model.NewAppError("CompletePresignedUpload", "app.upload.complete_presigned.not_supported.app_error",
+			nil, "", http.StatusBadRequest)
+	}
+
+	if !a.lockUploadSession(us.Id) {
+		return nil, model.NewAppError("CompletePresignedUpload", "app.upload.upload_data.concurrent.app_error",
+			nil, "", http.StatusBadRequest)
+	}
+	defer a.unlockUploadSession(us.Id)
+
+	// data sent through the server can't be completed here.
+	if storedSession, err := a.GetUploadSession(us.Id); err != nil {
+		return nil, err
+	} else if storedSession.FileOffset != 0 {
+		return nil, model.NewAppError("CompletePresignedUpload", "app.upload.upload_data.concurrent.app_error",
+			nil, "FileOffset mismatch", http.StatusBadRequest)
+	}
+
+	exists, appErr := a.FileExists(us.Path)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !exists {
+		return nil, model.NewAppError("CompletePresignedUpload", "app.upload.complete_presigned.not_found.app_error",
+			map[string]interface{}{"Filename": us.Filename}, "", http.StatusBadRequest)
+	}
+
+	size, appErr := a.FileSize(us.Path)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if size != us.FileSize {
+		if fileErr := a.RemoveFile(us.Path); fileErr != nil {
+			mlog.Warn("Failed to remove file", mlog.Err(fileErr))
+		}
+		return nil, model.NewAppError("CompletePresignedUpload", "app.upload.complete_presigned.size_mismatch.app_error",
+			map[string]interface{}{"Filename": us.Filename, "Expected": us.FileSize, "Actual": size}, "", http.StatusBadRequest)
+	}
+
+	us.FileOffset = us.FileSize
+	if storeErr := a.Srv().Store.UploadSession().Update(us); storeErr != nil {
+		return nil, model.NewAppError("CompletePresignedUpload", "app.upload.upload_data.update.app_error", nil, storeErr.Error(), http.StatusInternalServerError)
+	}
+
+	return a.createFileInfoForUpload(c, us, us.Path)
+}
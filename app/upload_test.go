@@ -8,14 +8,18 @@ import (
 	"io"
 	"io/ioutil"
 	"math/rand"
+	"net/http"
 	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/filestore"
 	"github.com/mattermost/mattermost-server/v6/utils/fileutils"
 )
 
@@ -309,3 +313,158 @@ func TestUploadDataConcurrent(t *testing.T) {
 	require.Nil(t, appErr)
 	require.Equal(t, data, d)
 }
+
+// presignedFileBackend adds presigned uploads to a file backend so that direct uploads can be
+// tested against local storage.
+type presignedFileBackend struct {
+	filestore.FileBackend
+}
+
+func (b presignedFileBackend) PresignedUploadURL(path string, expiry time.Duration) (string, http.Header, error) {
+	return "https://files.example.com/" + path, http.Header{"Content-Type": []string{"binary/octet-stream"}}, nil
+}
+
+func usePresignedFileBackend(th *TestHelper) func() {
+	backend := th.App.ch.filestore
+	th.App.ch.filestore = presignedFileBackend{backend}
+	th.App.Srv().filestore = th.App.ch.filestore
+	return func() {
+		th.App.ch.filestore = backend
+		th.App.Srv().filestore = backend
+	}
+}
+
+func TestCreatePresignedUpload(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	newSession := func() *model.UploadSession {
+		return &model.UploadSession{
+			Id:        model.NewId(),
+			Type:      model.UploadTypeAttachment,
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Filename:  "upload",
+			FileSize:  8 * 1024 * 1024,
+		}
+	}
+
+	t.Run("local storage falls back to uploading through the server", func(t *testing.T) {
+		upload, err := th.App.CreatePresignedUpload(newSession())
+		require.Nil(t, err)
+		require.NotNil(t, upload.UploadSession)
+		assert.Empty(t, upload.URL)
+		assert.Empty(t, upload.Headers)
+		assert.Zero(t, upload.ExpiresAt)
+
+		us, err := th.App.GetUploadSession(upload.UploadSession.Id)
+		require.Nil(t, err)
+		assert.Equal(t, upload.UploadSession.Path, us.Path)
+	})
+
+	t.Run("storage supporting direct uploads", func(t *testing.T) {
+		defer usePresignedFileBackend(th)()
+
+		upload, err := th.App.CreatePresignedUpload(newSession())
+		require.Nil(t, err)
+		require.NotNil(t, upload.UploadSession)
+		assert.Equal(t, "https://files.example.com/"+upload.UploadSession.Path, upload.URL)
+		assert.Equal(t, map[string]string{"Content-Type": "binary/octet-stream"}, upload.Headers)
+		assert.Greater(t, upload.ExpiresAt, model.GetMillis())
+	})
+
+	t.Run("invalid upload session", func(t *testing.T) {
+		defer usePresignedFileBackend(th)()
+
+		us := newSession()
+		us.ChannelId = model.NewId()
+		upload, err := th.App.CreatePresignedUpload(us)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.upload.create.incorrect_channel_id.app_error", err.Id)
+		assert.Nil(t, upload)
+	})
+}
+
+func TestCompletePresignedUpload(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	createUpload := func(t *testing.T) *model.UploadSession {
+		t.Helper()
+
+		upload, err := th.App.CreatePresignedUpload(&model.UploadSession{
+			Id:        model.NewId(),
+			Type:      model.UploadTypeAttachment,
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Filename:  "upload.txt",
+			FileSize:  int64(len(data)),
+		})
+		require.Nil(t, err)
+		return upload.UploadSession
+	}
+
+	t.Run("not supported by local storage", func(t *testing.T) {
+		us := createUpload(t)
+		_, err := th.App.WriteFile(bytes.NewReader(data), us.Path)
+		require.Nil(t, err)
+		defer th.App.RemoveFile(us.Path)
+
+		info, err := th.App.CompletePresignedUpload(th.Context, us)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.upload.complete_presigned.not_supported.app_error", err.Id)
+		assert.Nil(t, info)
+	})
+
+	defer usePresignedFileBackend(th)()
+
+	t.Run("file not uploaded", func(t *testing.T) {
+		us := createUpload(t)
+
+		info, err := th.App.CompletePresignedUpload(th.Context, us)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.upload.complete_presigned.not_found.app_error", err.Id)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Nil(t, info)
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		us := createUpload(t)
+		_, err := th.App.WriteFile(bytes.NewReader(data[:len(data)/2]), us.Path)
+		require.Nil(t, err)
+
+		info, err := th.App.CompletePresignedUpload(th.Context, us)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.upload.complete_presigned.size_mismatch.app_error", err.Id)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Nil(t, info)
+
+		exists, err := th.App.FileExists(us.Path)
+		require.Nil(t, err)
+		assert.False(t, exists, "the mismatched file should have been removed")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		us := createUpload(t)
+		_, err := th.App.WriteFile(bytes.NewReader(data), us.Path)
+		require.Nil(t, err)
+
+		info, err := th.App.CompletePresignedUpload(th.Context, us)
+		require.Nil(t, err)
+		require.NotNil(t, info)
+		assert.Equal(t, us.Path, info.Path)
+		assert.Equal(t, int64(len(data)), info.Size)
+		assert.Equal(t, th.BasicUser.Id, info.CreatorId)
+
+		_, err = th.App.GetUploadSession(us.Id)
+		require.NotNil(t, err, "the upload session should have been deleted")
+
+		d, err := th.App.ReadFile(us.Path)
+		require.Nil(t, err)
+		assert.Equal(t, data, d)
+	})
+}
@@ -99,6 +99,10 @@ func (a *App) CreateUserWithToken(c *request.Context, user *model.User, token *m
 
 	a.AddDirectChannels(team.Id, ruser)
 
+	if token.Type == TokenTypeGuestInvitation {
+		a.recordGuestChannelInvites(ruser.Id, channels)
+	}
+
 	if token.Type == TokenTypeGuestInvitation || (token.Type == TokenTypeTeamInvitation && len(channels) > 0) {
 		for _, channel := range channels {
 			_, err := a.AddChannelMember(c, ruser.Id, channel, ChannelMemberOpts{})
@@ -139,14 +143,22 @@ func (a *App) CreateUserWithInviteId(c *request.Context, user *model.User, invit
 		return nil, model.NewAppError("CreateUserWithInviteId", "api.team.invite_members.invalid_email.app_error", map[string]interface{}{"Addresses": team.AllowedDomains}, "", http.StatusForbidden)
 	}
 
+	// Reserve a use of the invite link before creating the user, so that an account isn't created when the link has
+	// no uses left. The use is given back if the user doesn't end up joining the team.
+	if appErr := a.useTeamInviteId(team); appErr != nil {
+		return nil, appErr
+	}
+
 	user.EmailVerified = false
 
 	ruser, err := a.CreateUser(c, user)
 	if err != nil {
+		a.releaseTeamInviteId(team)
 		return nil, err
 	}
 
 	if _, err := a.JoinUserToTeam(c, team, ruser, ""); err != nil {
+		a.releaseTeamInviteId(team)
 		return nil, err
 	}
 
@@ -226,7 +238,18 @@ func (a *App) createUserOrGuest(c *request.Context, user *model.User, guest bool
 		return nil, err
 	}
 
-	ruser, nErr := a.ch.srv.userService.CreateUser(user, users.UserCreateOptions{Guest: guest})
+	opts := users.UserCreateOptions{Guest: guest}
+
+	// A registered password validator replaces the built-in rules the user service would apply.
+	if a.ch.PasswordValidator != nil && user.AuthService == "" {
+		if err := a.IsPasswordValid(user.Password); err != nil {
+			err.Where = "createUserOrGuest"
+			return nil, err
+		}
+		opts.SkipPasswordValidation = true
+	}
+
+	ruser, nErr := a.ch.srv.userService.CreateUser(user, opts)
 	if nErr != nil {
 		var appErr *model.AppError
 		var invErr *store.ErrInvalidInput
@@ -1280,6 +1303,7 @@ func (a *App) UpdatePassword(user *model.User, newPassword string) *model.AppErr
 	}
 
 	a.InvalidateCacheForUser(user.Id)
+	a.clearFailedLoginAttemptsForUser(user.Id)
 
 	return nil
 }
@@ -2378,7 +2402,7 @@ func (a *App) GetThreadsForUser(userID, teamID string, options model.GetUserThre
 
 	for _, thread := range result.Threads {
 		a.sanitizeProfiles(thread.Participants, false)
-		thread.Post.SanitizeProps()
+		thread.Post.SanitizeParticipants()
 	}
 
 	return &result, nil
@@ -2404,7 +2428,7 @@ func (a *App) GetThreadForUser(teamID string, threadMembership *model.ThreadMemb
 		return nil, model.NewAppError("GetThreadForUser", "app.user.get_threads_for_user.not_found", nil, "thread not found/followed", http.StatusNotFound)
 	}
 	a.sanitizeProfiles(thread.Participants, false)
-	thread.Post.SanitizeProps()
+	thread.Post.SanitizeParticipants()
 	return thread, nil
 }
 
@@ -2487,7 +2511,7 @@ func (a *App) UpdateThreadFollowForUserFromChannelAdd(userID, teamID, threadID s
 		return model.NewAppError("UpdateThreadFollowForUserFromChannelAdd", "app.user.update_thread_follow_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 	a.sanitizeProfiles(userThread.Participants, false)
-	userThread.Post.SanitizeProps()
+	userThread.Post.SanitizeParticipants()
 	sanitizedPost, appErr := a.SanitizePostMetadataForUser(userThread.Post, userID)
 	if appErr != nil {
 		return appErr
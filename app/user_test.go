@@ -839,6 +839,60 @@ func TestCreateUserWithInviteId(t *testing.T) {
 		require.NotNil(t, err)
 		require.Equal(t, "api.team.invite_members.invalid_email.app_error", err.Id)
 	})
+
+	t.Run("exhausted invite link doesn't create the user", func(t *testing.T) {
+		team := th.CreateTeam()
+		team.InviteMaxUses = 1
+		team, appErr := th.App.UpdateTeam(team)
+		require.Nil(t, appErr)
+
+		_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, team.InviteId, th.CreateUser().Id)
+		require.Nil(t, appErr)
+
+		newUser := model.User{Email: strings.ToLower(model.NewId()) + "success+test@example.com", Username: "u" + model.NewId(), Password: "passwd1"}
+		_, appErr = th.App.CreateUserWithInviteId(th.Context, &newUser, team.InviteId, "")
+		require.NotNil(t, appErr)
+		require.Equal(t, "app.team.invite_id.exhausted.app_error", appErr.Id)
+
+		_, appErr = th.App.GetUserByEmail(newUser.Email)
+		require.NotNil(t, appErr, "the user shouldn't have been created")
+	})
+
+	t.Run("failing to create the user gives the use back", func(t *testing.T) {
+		team := th.CreateTeam()
+		team.InviteMaxUses = 1
+		team, appErr := th.App.UpdateTeam(team)
+		require.Nil(t, appErr)
+
+		// the username is taken
+		newUser := model.User{Email: strings.ToLower(model.NewId()) + "success+test@example.com", Username: th.BasicUser.Username, Password: "passwd1"}
+		_, appErr = th.App.CreateUserWithInviteId(th.Context, &newUser, team.InviteId, "")
+		require.NotNil(t, appErr)
+
+		team, appErr = th.App.GetTeam(team.Id)
+		require.Nil(t, appErr)
+		require.Equal(t, 0, team.InviteUses)
+	})
+
+	t.Run("failing to join the team gives the use back", func(t *testing.T) {
+		team := th.CreateTeam()
+		team.InviteMaxUses = 1
+		team, appErr := th.App.UpdateTeam(team)
+		require.Nil(t, appErr)
+
+		th.LinkUserToTeam(th.CreateUser(), team)
+		maxUsersPerTeam := *th.App.Config().TeamSettings.MaxUsersPerTeam
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.MaxUsersPerTeam = 1 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.MaxUsersPerTeam = maxUsersPerTeam })
+
+		newUser := model.User{Email: strings.ToLower(model.NewId()) + "success+test@example.com", Username: "u" + model.NewId(), Password: "passwd1"}
+		_, appErr = th.App.CreateUserWithInviteId(th.Context, &newUser, team.InviteId, "")
+		require.NotNil(t, appErr)
+
+		team, appErr = th.App.GetTeam(team.Id)
+		require.Nil(t, appErr)
+		require.Equal(t, 0, team.InviteUses)
+	})
 }
 
 func TestCreateUserWithToken(t *testing.T) {
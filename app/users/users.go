@@ -20,12 +20,14 @@ import (
 type UserCreateOptions struct {
 	Guest      bool
 	FromImport bool
+	// SkipPasswordValidation is set when the caller has already validated the password.
+	SkipPasswordValidation bool
 }
 
 // CreateUser creates a user
 func (us *UserService) CreateUser(user *model.User, opts UserCreateOptions) (*model.User, error) {
 	if opts.FromImport {
-		return us.createUser(user)
+		return us.createUser(user, opts)
 	}
 
 	user.Roles = model.SystemUserRoleId
@@ -53,14 +55,16 @@ func (us *UserService) CreateUser(user *model.User, opts UserCreateOptions) (*mo
 		user.Locale = *us.config().LocalizationSettings.DefaultClientLocale
 	}
 
-	return us.createUser(user)
+	return us.createUser(user, opts)
 }
 
-func (us *UserService) createUser(user *model.User) (*model.User, error) {
+func (us *UserService) createUser(user *model.User, opts UserCreateOptions) (*model.User, error) {
 	user.MakeNonNil()
 
-	if err := us.isPasswordValid(user.Password); user.AuthService == "" && err != nil {
-		return nil, err
+	if !opts.SkipPasswordValidation && user.AuthService == "" {
+		if err := us.isPasswordValid(user.Password); err != nil {
+			return nil, err
+		}
 	}
 
 	ruser, err := us.store.Save(user)
@@ -27,15 +27,19 @@ import (
 )
 
 const (
-	sendQueueSize          = 256
-	sendSlowWarn           = (sendQueueSize * 50) / 100
-	sendFullWarn           = (sendQueueSize * 95) / 100
-	writeWaitTime          = 30 * time.Second
-	pongWaitTime           = 100 * time.Second
-	pingInterval           = (pongWaitTime * 6) / 10
-	authCheckInterval      = 5 * time.Second
-	webConnMemberCacheTime = 1000 * 60 * 30 // 30 minutes
-	deadQueueSize          = 128            // Approximated from /proc/sys/net/core/wmem_default / 2048 (avg msg size)
+	sendQueueSize              = 256
+	sendSlowWarn               = (sendQueueSize * 50) / 100
+	sendFullWarn               = (sendQueueSize * 95) / 100
+	writeWaitTime              = 30 * time.Second
+	pongWaitTime               = 100 * time.Second
+	pingInterval               = (pongWaitTime * 6) / 10
+	authCheckInterval          = 5 * time.Second
+	webConnMemberCacheTime     = 1000 * 60 * 30 // 30 minutes
+	deadQueueSize              = 128            // Approximated from /proc/sys/net/core/wmem_default / 2048 (avg msg size)
+	reconnectRequestsQueueSize = 4
+	// websocketCompressionThreshold is the size in bytes below which messages are sent uncompressed
+	// even when compression was negotiated, since compressing them costs more than it saves.
+	websocketCompressionThreshold = 4 * 1024
 )
 
 const (
@@ -110,6 +114,9 @@ type WebConn struct {
 	endWritePump chan struct{}
 	pumpFinished chan struct{}
 	pluginPosted chan pluginWSPostedHook
+	// reconnectRequests carries reconnect commands from the read pump to the
+	// write pump, which owns the dead queue.
+	reconnectRequests chan *model.WebSocketRequest
 }
 
 // CheckConnResult indicates whether a connectionID was present in the hub or not.
@@ -211,6 +218,7 @@ func (a *App) NewWebConn(cfg *WebConnConfig) *WebConn {
 		endWritePump:       make(chan struct{}),
 		pumpFinished:       make(chan struct{}),
 		pluginPosted:       make(chan pluginWSPostedHook, 10),
+		reconnectRequests:  make(chan *model.WebSocketRequest, reconnectRequestsQueueSize),
 	}
 
 	wc.SetSession(&cfg.Session)
@@ -495,6 +503,12 @@ func (wc *WebConn) writePump() {
 				return
 			}
 
+		case r := <-wc.reconnectRequests:
+			if err := wc.handleReconnect(r); err != nil {
+				wc.logSocketErr("websocket.reconnect", err)
+				return
+			}
+
 		case <-wc.endWritePump:
 			return
 
@@ -512,6 +526,8 @@ func (wc *WebConn) writePump() {
 // along with setting the write deadline.
 func (wc *WebConn) writeMessageBuf(msgType int, data []byte) error {
 	wc.WebSocket.SetWriteDeadline(time.Now().Add(writeWaitTime))
+	// This has no effect unless compression was negotiated with the client.
+	wc.WebSocket.EnableWriteCompression(len(data) >= websocketCompressionThreshold)
 	return wc.WebSocket.WriteMessage(msgType, data)
 }
 
@@ -624,6 +640,99 @@ func (wc *WebConn) drainDeadQueue(index int) error {
 	return nil
 }
 
+// QueueReconnectRequest hands a reconnect command over to the write pump. It returns false
+// if too many reconnect commands are already pending for this connection.
+func (wc *WebConn) QueueReconnectRequest(r *model.WebSocketRequest) bool {
+	select {
+	case wc.reconnectRequests <- r:
+		return true
+	default:
+		return false
+	}
+}
+
+// handleReconnect answers a reconnect command in which the client reports the last
+// sequence number it received. Missed events still present in the dead queue are
+// replayed before the response. Otherwise the response reports the gap, so the
+// client knows it has to resync.
+func (wc *WebConn) handleReconnect(r *model.WebSocketRequest) error {
+	lastSequence, _ := r.Data["last_sequence"].(float64)
+	next := int64(lastSequence) + 1
+
+	data := map[string]interface{}{
+		"sequence": wc.Sequence,
+	}
+
+	var gap bool
+	var replayed int
+	if next > wc.Sequence {
+		// The client has seen events this connection never sent, so there's
+		// no telling which ones were missed.
+		gap = true
+	} else if next < wc.Sequence {
+		if ok, index := wc.isInDeadQueue(next); ok {
+			var err error
+			if replayed, err = wc.replayDeadQueue(index, next); err != nil {
+				return err
+			}
+		}
+		if next+int64(replayed) < wc.Sequence {
+			gap = true
+			data["missed_from"] = next + int64(replayed)
+			data["missed_to"] = wc.Sequence - 1
+		}
+	}
+	data["gap"] = gap
+	data["replayed"] = replayed
+
+	if m := wc.App.Metrics(); m != nil {
+		switch {
+		case gap:
+			m.IncrementWebsocketReconnectEvent(reconnectNotFound)
+		case replayed > 0:
+			m.IncrementWebsocketReconnectEvent(reconnectFound)
+		default:
+			m.IncrementWebsocketReconnectEvent(reconnectLossless)
+		}
+	}
+
+	resp, err := model.NewWebSocketResponse(model.StatusOk, r.Seq, data).ToJSON()
+	if err != nil {
+		mlog.Warn("Error in encoding websocket response", mlog.Err(err))
+		return nil
+	}
+
+	return wc.writeMessageBuf(websocket.TextMessage, resp)
+}
+
+// replayDeadQueue writes the events of the dead queue starting at index, which holds the
+// event with sequence number from, up to the latest event sent. Unlike drainDeadQueue it
+// doesn't advance the sequence, since the events have been sent before on this connection.
+func (wc *WebConn) replayDeadQueue(index int, from int64) (int, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+
+	replayed := 0
+	for seq := from; seq < wc.Sequence; seq++ {
+		msg := wc.deadQueue[(index+replayed)%deadQueueSize]
+		if msg == nil || msg.GetSequence() != seq {
+			break
+		}
+
+		buf.Reset()
+		if err := msg.Encode(enc); err != nil {
+			mlog.Warn("Error in encoding websocket message", mlog.Err(err))
+			break
+		}
+		if err := wc.writeMessageBuf(websocket.TextMessage, buf.Bytes()); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
 // InvalidateCache resets all internal data of the WebConn.
 func (wc *WebConn) InvalidateCache() {
 	wc.allChannelMembers = nil
@@ -374,3 +374,108 @@ func TestWebConnDrainDeadQueue(t *testing.T) {
 		t.Run("Overwritten First", func(t *testing.T) { run(int64(128), deadQueueSize+10) })
 	})
 }
+
+func TestWebConnHandleReconnect(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	var handler = func(t *testing.T, received chan<- []byte) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			upgrader := &websocket.Upgrader{}
+			conn, err := upgrader.Upgrade(w, req, nil)
+			require.NoError(t, err)
+			defer close(received)
+			for {
+				_, buf, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				received <- buf
+			}
+		}
+	}
+
+	run := func(t *testing.T, sent int, lastSequence int64) ([]*model.WebSocketEvent, *model.WebSocketResponse) {
+		received := make(chan []byte, deadQueueSize*2)
+		s := httptest.NewServer(handler(t, received))
+		defer s.Close()
+
+		d := websocket.Dialer{}
+		c, _, err := d.Dial("ws://"+s.Listener.Addr().String()+"/ws", nil)
+		require.NoError(t, err)
+
+		wc := th.App.NewWebConn(&WebConnConfig{WebSocket: c})
+		for i := 0; i < sent; i++ {
+			msg := model.NewWebSocketEvent("some_event", "", "", "", map[string]bool{})
+			msg = msg.SetSequence(int64(i))
+			wc.addToDeadQueue(msg)
+		}
+		wc.Sequence = int64(sent)
+
+		req := &model.WebSocketRequest{
+			Seq:    1,
+			Action: model.WebsocketReconnect,
+			Data:   map[string]interface{}{"last_sequence": float64(lastSequence)},
+		}
+		require.NoError(t, wc.handleReconnect(req))
+		assert.Equal(t, int64(sent), wc.Sequence, "replaying must not advance the sequence")
+		wc.WebSocket.Close()
+
+		var events []*model.WebSocketEvent
+		var resp *model.WebSocketResponse
+		for buf := range received {
+			require.Nil(t, resp, "no message expected after the response")
+			if ev, jsonErr := model.WebSocketEventFromJSON(bytes.NewReader(buf)); jsonErr == nil && ev.EventType() != "" {
+				events = append(events, ev)
+				continue
+			}
+			resp, err = model.WebSocketResponseFromJSON(bytes.NewReader(buf))
+			require.NoError(t, err)
+		}
+		require.NotNil(t, resp)
+		assert.Equal(t, model.StatusOk, resp.Status)
+		assert.Equal(t, int64(1), resp.SeqReply)
+
+		return events, resp
+	}
+
+	t.Run("no gap", func(t *testing.T) {
+		events, resp := run(t, 10, 9)
+		assert.Empty(t, events)
+		assert.Equal(t, false, resp.Data["gap"])
+		assert.Equal(t, float64(0), resp.Data["replayed"])
+	})
+
+	t.Run("replay from dead queue", func(t *testing.T) {
+		events, resp := run(t, 10, 6)
+		require.Len(t, events, 3)
+		for i, ev := range events {
+			assert.Equal(t, int64(7+i), ev.GetSequence())
+		}
+		assert.Equal(t, false, resp.Data["gap"])
+		assert.Equal(t, float64(3), resp.Data["replayed"])
+	})
+
+	t.Run("replay from cycled dead queue", func(t *testing.T) {
+		events, resp := run(t, deadQueueSize+10, 100)
+		require.Len(t, events, 37)
+		for i, ev := range events {
+			assert.Equal(t, int64(101+i), ev.GetSequence())
+		}
+		assert.Equal(t, false, resp.Data["gap"])
+	})
+
+	t.Run("gap when events were dropped from dead queue", func(t *testing.T) {
+		events, resp := run(t, deadQueueSize+10, 5)
+		assert.Empty(t, events)
+		assert.Equal(t, true, resp.Data["gap"])
+		assert.Equal(t, float64(6), resp.Data["missed_from"])
+		assert.Equal(t, float64(deadQueueSize+9), resp.Data["missed_to"])
+	})
+
+	t.Run("gap when client is ahead", func(t *testing.T) {
+		events, resp := run(t, 10, 20)
+		assert.Empty(t, events)
+		assert.Equal(t, true, resp.Data["gap"])
+	})
+}
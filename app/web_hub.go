@@ -12,12 +12,17 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 const (
 	broadcastQueueSize         = 4096
 	inactiveConnReaperInterval = 5 * time.Minute
+	// webSocketEventHookTimeout bounds the time plugins may take to process an event in the
+	// WebSocketEventWillBeSent hook. Events they don't return in time are dropped.
+	webSocketEventHookTimeout        = 500 * time.Millisecond
+	maxConcurrentWebSocketEventHooks = 64
 )
 
 type webConnActivityMessage struct {
@@ -196,6 +201,10 @@ func (ch *Channels) Publish(message *model.WebSocketEvent) {
 }
 
 func (s *Server) PublishSkipClusterSend(event *model.WebSocketEvent) {
+	if event = s.runWebSocketEventWillBeSentHook(event); event == nil {
+		return
+	}
+
 	if event.GetBroadcast().UserId != "" {
 		hub := s.GetHubForUserId(event.GetBroadcast().UserId)
 		if hub != nil {
@@ -211,6 +220,52 @@ func (s *Server) PublishSkipClusterSend(event *model.WebSocketEvent) {
 	s.SharedChannelSyncHandler(event)
 }
 
+// runWebSocketEventWillBeSentHook gives plugins a chance to modify or suppress the event before it
+// is handed to the hubs. It runs once per event on each server, and returns nil if the event must
+// not be sent, including when plugins don't process it in time.
+func (s *Server) runWebSocketEventWillBeSentHook(event *model.WebSocketEvent) *model.WebSocketEvent {
+	ch := s.Channels()
+	if ch == nil {
+		return event
+	}
+	pluginsEnvironment := ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil || !pluginsEnvironment.IsHookImplemented(plugin.WebSocketEventWillBeSentID) {
+		return event
+	}
+
+	timer := time.NewTimer(webSocketEventHookTimeout)
+	defer timer.Stop()
+
+	select {
+	case s.webSocketEventHookSlots <- struct{}{}:
+	case <-timer.C:
+		mlog.Warn("Timed out waiting for plugins to be free to process websocket event, dropping it", mlog.String("type", event.EventType()))
+		return nil
+	}
+
+	// The channel is buffered so that the goroutine can exit once the plugins return, even if
+	// nobody is waiting for the result anymore.
+	result := make(chan *model.WebSocketEvent, 1)
+	go func() {
+		defer func() { <-s.webSocketEventHookSlots }()
+
+		modified := event
+		pluginsEnvironment.RunMultiPluginHook(func(hooks plugin.Hooks) bool {
+			modified = hooks.WebSocketEventWillBeSent(modified)
+			return modified != nil
+		}, plugin.WebSocketEventWillBeSentID)
+		result <- modified
+	}()
+
+	select {
+	case modified := <-result:
+		return modified
+	case <-timer.C:
+		mlog.Warn("Timed out waiting for plugins to process websocket event, dropping it", mlog.String("type", event.EventType()))
+		return nil
+	}
+}
+
 func (a *App) invalidateCacheForChannel(channel *model.Channel) {
 	a.Srv().Store.Channel().InvalidateChannel(channel.Id)
 	a.Srv().invalidateCacheForChannelByNameSkipClusterSend(channel.TeamId, channel.Name)
@@ -420,6 +475,7 @@ func (h *Hub) Start() {
 		appInstance := New(ServerConnector(h.srv.Channels()))
 
 		connIndex := newHubConnectionIndex(inactiveConnReaperInterval)
+		typingThrottle := newTypingEventThrottle()
 
 		for {
 			select {
@@ -451,6 +507,7 @@ func (h *Hub) Start() {
 				req.result <- res
 			case <-ticker.C:
 				connIndex.RemoveInactiveConnections()
+				typingThrottle.Prune(h.typingEventInterval(), time.Now())
 			case webConn := <-h.register:
 				// Mark the current one as active.
 				// There is no need to check if it was inactive or not,
@@ -478,6 +535,8 @@ func (h *Hub) Start() {
 					continue
 				}
 
+				h.blurConnection(webConn)
+
 				conns := connIndex.ForUser(webConn.UserId)
 				if len(conns) == 0 || areAllInactive(conns) {
 					h.srv.Go(func() {
@@ -523,11 +582,15 @@ func (h *Hub) Start() {
 					mlog.Error("webhub.broadcast: cannot send, closing websocket for user", mlog.String("user_id", directMsg.conn.UserId))
 					close(directMsg.conn.send)
 					connIndex.Remove(directMsg.conn)
+					h.blurConnection(directMsg.conn)
 				}
 			case msg := <-h.broadcast:
 				if metrics := h.srv.Metrics; metrics != nil {
 					metrics.DecrementWebSocketBroadcastBufferSize(strconv.Itoa(h.connectionIndex), 1)
 				}
+				if msg.EventType() == model.WebsocketEventTyping && !typingThrottle.Allow(msg, h.typingEventInterval(), time.Now()) {
+					continue
+				}
 				msg = msg.PrecomputeJSON()
 				broadcast := func(webConn *WebConn) {
 					if !connIndex.Has(webConn) {
@@ -540,6 +603,7 @@ func (h *Hub) Start() {
 							mlog.Error("webhub.broadcast: cannot send, closing websocket for user", mlog.String("user_id", webConn.UserId))
 							close(webConn.send)
 							connIndex.Remove(webConn)
+							h.blurConnection(webConn)
 						}
 					}
 				}
@@ -597,6 +661,59 @@ func (h *Hub) Start() {
 	go doRecoverableStart()
 }
 
+// blurConnection stops the connection from counting as a viewer of the channel it had focused, letting
+// the channel know in the background. It must be called whenever a connection is removed from the hub.
+func (h *Hub) blurConnection(webConn *WebConn) {
+	if changes := h.srv.channelViewers.Blur(webConn.GetConnectionID()); len(changes) > 0 {
+		h.srv.Go(func() {
+			h.srv.publishChannelViewerChanges(changes)
+		})
+	}
+}
+
+// typingEventInterval returns the minimum time between typing events broadcast for the same
+// user and channel.
+func (h *Hub) typingEventInterval() time.Duration {
+	return time.Duration(*h.srv.Config().ServiceSettings.TypingEventBroadcastIntervalMilliseconds) * time.Millisecond
+}
+
+// typingEventThrottle drops typing events that arrive within the broadcast interval of the last
+// one sent for the same user and channel. It is only accessed from the hub goroutine.
+type typingEventThrottle struct {
+	lastSent map[string]time.Time
+}
+
+func newTypingEventThrottle() *typingEventThrottle {
+	return &typingEventThrottle{
+		lastSent: make(map[string]time.Time),
+	}
+}
+
+// Allow reports whether the typing event should be broadcast, recording it as sent if so.
+func (t *typingEventThrottle) Allow(msg *model.WebSocketEvent, interval time.Duration, now time.Time) bool {
+	if interval <= 0 {
+		return true
+	}
+
+	userID, _ := msg.GetData()["user_id"].(string)
+	key := msg.GetBroadcast().ChannelId + ":" + userID
+	if last, ok := t.lastSent[key]; ok && now.Sub(last) < interval {
+		return false
+	}
+
+	t.lastSent[key] = now
+	return true
+}
+
+// Prune forgets the typing events sent longer than the interval ago.
+func (t *typingEventThrottle) Prune(interval time.Duration, now time.Time) {
+	for key, last := range t.lastSent {
+		if now.Sub(last) >= interval {
+			delete(t.lastSent, key)
+		}
+	}
+}
+
 // hubConnectionIndex provides fast addition, removal, and iteration of web connections.
 // It requires 3 functionalities which need to be very fast:
 // - check if a connection exists or not.
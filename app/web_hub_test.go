@@ -4,6 +4,7 @@
 package app
 
 import (
+	"encoding/json"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -39,9 +40,13 @@ func dummyWebsocketHandler(t *testing.T) http.HandlerFunc {
 }
 
 func registerDummyWebConn(t *testing.T, a *App, addr net.Addr, userID string) *WebConn {
-	session, appErr := a.CreateSession(&model.Session{
+	return registerDummyWebConnForSession(t, a, addr, &model.Session{
 		UserId: userID,
 	})
+}
+
+func registerDummyWebConnForSession(t *testing.T, a *App, addr net.Addr, session *model.Session) *WebConn {
+	session, appErr := a.CreateSession(session)
 	require.Nil(t, appErr)
 
 	d := websocket.Dialer{}
@@ -460,6 +465,239 @@ func TestHubIsRegistered(t *testing.T) {
 	assert.False(t, th.App.SessionIsRegistered(*session4))
 }
 
+func TestHubTypingEventThrottle(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.TypingEventBroadcastIntervalMilliseconds = 60000
+	})
+
+	user3 := th.CreateUser()
+	th.LinkUserToTeam(user3, th.BasicTeam)
+	th.AddUserToChannel(user3, th.BasicChannel)
+
+	const markerEvent = "test_typing_throttle_marker"
+
+	typingFrom := make(chan string, 100)
+	marker := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		upgrader := &websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		for {
+			var msg struct {
+				Event string                 `json:"event"`
+				Data  map[string]interface{} `json:"data"`
+			}
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			switch msg.Event {
+			case model.WebsocketEventTyping:
+				userID, _ := msg.Data["user_id"].(string)
+				typingFrom <- userID
+			case markerEvent:
+				close(marker)
+			}
+		}
+	}))
+	defer s.Close()
+
+	th.Server.HubStart()
+	wc := registerDummyWebConn(t, th.App, s.Listener.Addr(), th.BasicUser.Id)
+	defer wc.Close()
+
+	for i := 0; i < 50; i++ {
+		require.Nil(t, th.App.PublishUserTyping(th.BasicUser2.Id, th.BasicChannel.Id, ""))
+		require.Nil(t, th.App.PublishUserTyping(user3.Id, th.BasicChannel.Id, ""))
+	}
+	th.App.Publish(model.NewWebSocketEvent(markerEvent, "", "", th.BasicUser.Id, nil))
+
+	select {
+	case <-marker:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for the marker event")
+	}
+	close(typingFrom)
+
+	var received []string
+	for userID := range typingFrom {
+		received = append(received, userID)
+	}
+	assert.ElementsMatch(t, []string{th.BasicUser2.Id, user3.Id}, received)
+}
+
+func TestTypingEventThrottle(t *testing.T) {
+	newTypingEvent := func(channelID, userID string) *model.WebSocketEvent {
+		event := model.NewWebSocketEvent(model.WebsocketEventTyping, "", channelID, "", nil)
+		event.Add("user_id", userID)
+		return event
+	}
+
+	channelID := model.NewId()
+	userID := model.NewId()
+	now := time.Now()
+
+	throttle := newTypingEventThrottle()
+	assert.True(t, throttle.Allow(newTypingEvent(channelID, userID), time.Second, now))
+	assert.False(t, throttle.Allow(newTypingEvent(channelID, userID), time.Second, now.Add(500*time.Millisecond)))
+	assert.True(t, throttle.Allow(newTypingEvent(model.NewId(), userID), time.Second, now.Add(500*time.Millisecond)))
+	assert.True(t, throttle.Allow(newTypingEvent(channelID, model.NewId()), time.Second, now.Add(500*time.Millisecond)))
+	assert.True(t, throttle.Allow(newTypingEvent(channelID, userID), time.Second, now.Add(time.Second)))
+
+	t.Run("disabled", func(t *testing.T) {
+		throttle := newTypingEventThrottle()
+		assert.True(t, throttle.Allow(newTypingEvent(channelID, userID), 0, now))
+		assert.True(t, throttle.Allow(newTypingEvent(channelID, userID), 0, now))
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		throttle := newTypingEventThrottle()
+		throttle.Allow(newTypingEvent(channelID, userID), time.Second, now)
+		throttle.Allow(newTypingEvent(channelID, model.NewId()), time.Second, now.Add(time.Second))
+
+		throttle.Prune(time.Second, now.Add(1500*time.Millisecond))
+		assert.Len(t, throttle.lastSent, 1)
+	})
+}
+
+func TestHubChannelViewers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	s := httptest.NewServer(dummyWebsocketHandler(t))
+	defer s.Close()
+
+	th.Server.HubStart()
+	wc1 := registerDummyWebConn(t, th.App, s.Listener.Addr(), th.BasicUser.Id)
+	defer wc1.Close()
+	wc2 := registerDummyWebConn(t, th.App, s.Listener.Addr(), th.BasicUser.Id)
+	defer wc2.Close()
+	wc3 := registerDummyWebConn(t, th.App, s.Listener.Addr(), th.BasicUser2.Id)
+	defer wc3.Close()
+
+	channelID := th.BasicChannel.Id
+
+	t.Run("focus and blur", func(t *testing.T) {
+		viewers := th.App.FocusChannel(wc1.GetConnectionID(), th.BasicUser.Id, channelID)
+		assert.ElementsMatch(t, []string{th.BasicUser.Id}, viewers)
+
+		viewers = th.App.FocusChannel(wc3.GetConnectionID(), th.BasicUser2.Id, channelID)
+		assert.ElementsMatch(t, []string{th.BasicUser.Id, th.BasicUser2.Id}, viewers)
+
+		th.App.BlurChannel(wc3.GetConnectionID())
+		assert.ElementsMatch(t, []string{th.BasicUser.Id}, th.App.GetChannelViewers(channelID))
+
+		th.App.BlurChannel(wc1.GetConnectionID())
+		assert.Empty(t, th.App.GetChannelViewers(channelID))
+	})
+
+	t.Run("focusing another channel leaves the previous one", func(t *testing.T) {
+		otherChannel := th.CreateChannel(th.BasicTeam)
+
+		th.App.FocusChannel(wc1.GetConnectionID(), th.BasicUser.Id, channelID)
+		th.App.FocusChannel(wc1.GetConnectionID(), th.BasicUser.Id, otherChannel.Id)
+		assert.Empty(t, th.App.GetChannelViewers(channelID))
+		assert.ElementsMatch(t, []string{th.BasicUser.Id}, th.App.GetChannelViewers(otherChannel.Id))
+
+		th.App.BlurChannel(wc1.GetConnectionID())
+	})
+
+	t.Run("disconnect cleans up", func(t *testing.T) {
+		th.App.FocusChannel(wc1.GetConnectionID(), th.BasicUser.Id, channelID)
+		th.App.FocusChannel(wc2.GetConnectionID(), th.BasicUser.Id, channelID)
+		th.App.FocusChannel(wc3.GetConnectionID(), th.BasicUser2.Id, channelID)
+
+		th.App.HubUnregister(wc3)
+		require.Eventually(t, func() bool {
+			viewers := th.App.GetChannelViewers(channelID)
+			return len(viewers) == 1 && viewers[0] == th.BasicUser.Id
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// The user keeps viewing the channel while any of their connections has it focused.
+		th.App.HubUnregister(wc1)
+		require.Never(t, func() bool {
+			return len(th.App.GetChannelViewers(channelID)) != 1
+		}, 200*time.Millisecond, 10*time.Millisecond)
+
+		th.App.HubUnregister(wc2)
+		require.Eventually(t, func() bool {
+			return len(th.App.GetChannelViewers(channelID)) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestHubChannelViewersSlowConsumer(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	s := httptest.NewServer(dummyWebsocketHandler(t))
+	defer s.Close()
+
+	th.Server.HubStart()
+
+	session, appErr := th.App.CreateSession(&model.Session{UserId: th.BasicUser.Id})
+	require.Nil(t, appErr)
+
+	d := websocket.Dialer{}
+	c, _, err := d.Dial("ws://"+s.Listener.Addr().String()+"/ws", nil)
+	require.NoError(t, err)
+
+	// The connection isn't pumped, so its queue fills up with the hello message.
+	wc := th.App.NewWebConn(&WebConnConfig{
+		WebSocket:   c,
+		Session:     *session,
+		TFunc:       i18n.IdentityTfunc(),
+		Locale:      "en",
+		activeQueue: make(chan model.WebSocketMessage, 1),
+	})
+	defer wc.Close()
+	th.App.HubRegister(wc)
+
+	channelID := th.BasicChannel.Id
+	th.App.FocusChannel(wc.GetConnectionID(), th.BasicUser.Id, channelID)
+
+	th.App.Publish(model.NewWebSocketEvent(model.WebsocketEventTyping, "", "", th.BasicUser.Id, nil))
+	require.Eventually(t, func() bool {
+		return len(th.App.GetChannelViewers(channelID)) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestChannelViewerIndex(t *testing.T) {
+	channelID := model.NewId()
+	otherChannelID := model.NewId()
+	userID := model.NewId()
+	conn1 := model.NewId()
+	conn2 := model.NewId()
+
+	index := newChannelViewerIndex()
+
+	changes := index.Focus(conn1, userID, channelID)
+	assert.Equal(t, []channelViewerChange{{channelID: channelID, userID: userID, viewing: true}}, changes)
+
+	assert.Empty(t, index.Focus(conn1, userID, channelID), "focusing the same channel again changes nothing")
+	assert.Empty(t, index.Focus(conn2, userID, channelID), "the user is already viewing the channel")
+	assert.Equal(t, []string{userID}, index.Viewers(channelID))
+
+	assert.Empty(t, index.Blur(conn1), "the user is still viewing the channel from another connection")
+
+	changes = index.Focus(conn2, userID, otherChannelID)
+	assert.Equal(t, []channelViewerChange{
+		{channelID: channelID, userID: userID, viewing: false},
+		{channelID: otherChannelID, userID: userID, viewing: true},
+	}, changes)
+	assert.Empty(t, index.Viewers(channelID))
+
+	changes = index.Blur(conn2)
+	assert.Equal(t, []channelViewerChange{{channelID: otherChannelID, userID: userID, viewing: false}}, changes)
+	assert.Empty(t, index.Blur(conn2))
+	assert.Empty(t, index.byChannel)
+	assert.Empty(t, index.byConnection)
+}
+
 // Always run this with -benchtime=0.1s
 // See: https://github.com/golang/go/issues/27217.
 func BenchmarkHubConnIndex(b *testing.B) {
@@ -517,3 +755,203 @@ func BenchmarkGetHubForUserId(b *testing.B) {
 		hubSink = th.Server.GetHubForUserId(th.BasicUser.Id)
 	}
 }
+
+func TestHubPluginEventTeamScope(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	otherTeam := th.CreateTeam()
+	otherUser := th.CreateUser()
+	th.LinkUserToTeam(otherUser, otherTeam)
+
+	const pluginEvent = "custom_pluginid_scoped"
+	const markerEvent = "test_team_scope_marker"
+
+	// newServer returns a websocket server reporting the plugin events it received until the marker arrives.
+	newServer := func() (*httptest.Server, chan int) {
+		received := make(chan int, 1)
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			upgrader := &websocket.Upgrader{}
+			conn, err := upgrader.Upgrade(w, req, nil)
+			if err != nil {
+				return
+			}
+			count := 0
+			for {
+				var msg struct {
+					Event string `json:"event"`
+				}
+				if err := conn.ReadJSON(&msg); err != nil {
+					return
+				}
+				switch msg.Event {
+				case pluginEvent:
+					count++
+				case markerEvent:
+					received <- count
+					count = 0
+				}
+			}
+		}))
+		return s, received
+	}
+
+	registerForTeam := func(addr net.Addr, user *model.User, team *model.Team) *WebConn {
+		return registerDummyWebConnForSession(t, th.App, addr, &model.Session{
+			UserId: user.Id,
+			Roles:  user.GetRawRoles(),
+			TeamMembers: []*model.TeamMember{
+				{UserId: user.Id, TeamId: team.Id, Roles: model.TeamUserRoleId},
+			},
+		})
+	}
+
+	teamServer, teamReceived := newServer()
+	defer teamServer.Close()
+	otherServer, otherReceived := newServer()
+	defer otherServer.Close()
+
+	th.Server.HubStart()
+	teamConn := registerForTeam(teamServer.Listener.Addr(), th.BasicUser, th.BasicTeam)
+	defer teamConn.Close()
+	otherConn := registerForTeam(otherServer.Listener.Addr(), otherUser, otherTeam)
+	defer otherConn.Close()
+
+	api := th.SetupPluginAPI()
+
+	// publish sends "scoped" as the plugin with the given broadcast and returns how many
+	// times each connection received it.
+	publish := func(t *testing.T, broadcast *model.WebsocketBroadcast) (int, int) {
+		t.Helper()
+		api.PublishWebSocketEvent("scoped", map[string]interface{}{}, broadcast)
+		th.App.Publish(model.NewWebSocketEvent(markerEvent, "", "", th.BasicUser.Id, nil))
+		th.App.Publish(model.NewWebSocketEvent(markerEvent, "", "", otherUser.Id, nil))
+
+		var counts [2]int
+		for i, received := range []chan int{teamReceived, otherReceived} {
+			select {
+			case counts[i] = <-received:
+			case <-time.After(10 * time.Second):
+				require.FailNow(t, "timed out waiting for the marker event")
+			}
+		}
+		return counts[0], counts[1]
+	}
+
+	t.Run("without a team", func(t *testing.T) {
+		inTeam, outOfTeam := publish(t, &model.WebsocketBroadcast{})
+		assert.Equal(t, 1, inTeam)
+		assert.Equal(t, 1, outOfTeam)
+	})
+
+	t.Run("sent to a team", func(t *testing.T) {
+		inTeam, outOfTeam := publish(t, &model.WebsocketBroadcast{TeamId: th.BasicTeam.Id})
+		assert.Equal(t, 1, inTeam)
+		assert.Equal(t, 0, outOfTeam)
+	})
+
+	t.Run("sent to the other team", func(t *testing.T) {
+		inTeam, outOfTeam := publish(t, &model.WebsocketBroadcast{TeamId: otherTeam.Id})
+		assert.Equal(t, 0, inTeam)
+		assert.Equal(t, 1, outOfTeam)
+	})
+}
+
+func TestHubChannelMemberRolesUpdated(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// newServer returns a websocket server reporting the channel members of the channel_member_updated events it receives.
+	newServer := func() (*httptest.Server, chan *model.ChannelMember) {
+		received := make(chan *model.ChannelMember, 10)
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			upgrader := &websocket.Upgrader{}
+			conn, err := upgrader.Upgrade(w, req, nil)
+			if err != nil {
+				return
+			}
+			for {
+				var msg struct {
+					Event string                 `json:"event"`
+					Data  map[string]interface{} `json:"data"`
+				}
+				if err := conn.ReadJSON(&msg); err != nil {
+					return
+				}
+				if msg.Event != model.WebsocketEventChannelMemberUpdated {
+					continue
+				}
+				var member model.ChannelMember
+				memberJSON, _ := msg.Data["channelMember"].(string)
+				if err := json.Unmarshal([]byte(memberJSON), &member); err != nil {
+					return
+				}
+				received <- &member
+			}
+		}))
+		return s, received
+	}
+
+	receive := func(t *testing.T, received chan *model.ChannelMember) *model.ChannelMember {
+		t.Helper()
+		select {
+		case member := <-received:
+			return member
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "timed out waiting for the channel_member_updated event")
+		}
+		return nil
+	}
+
+	promotedServer, promotedReceived := newServer()
+	defer promotedServer.Close()
+	memberServer, memberReceived := newServer()
+	defer memberServer.Close()
+	outsiderServer, outsiderReceived := newServer()
+	defer outsiderServer.Close()
+
+	outsider := th.CreateUser()
+	th.LinkUserToTeam(outsider, th.BasicTeam)
+
+	th.Server.HubStart()
+	promotedConn := registerDummyWebConn(t, th.App, promotedServer.Listener.Addr(), th.BasicUser.Id)
+	defer promotedConn.Close()
+	memberConn := registerDummyWebConn(t, th.App, memberServer.Listener.Addr(), th.BasicUser2.Id)
+	defer memberConn.Close()
+	outsiderConn := registerDummyWebConn(t, th.App, outsiderServer.Listener.Addr(), outsider.Id)
+	defer outsiderConn.Close()
+
+	_, appErr := th.App.UpdateChannelMemberSchemeRoles(th.BasicChannel.Id, th.BasicUser.Id, false, true, true)
+	require.Nil(t, appErr)
+
+	t.Run("promoted user", func(t *testing.T) {
+		member := receive(t, promotedReceived)
+		assert.Equal(t, th.BasicUser.Id, member.UserId)
+		assert.Equal(t, th.BasicChannel.Id, member.ChannelId)
+		assert.True(t, member.SchemeAdmin)
+		assert.NotEmpty(t, member.NotifyProps)
+
+		select {
+		case <-promotedReceived:
+			assert.Fail(t, "the promoted user should only receive the event once")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("other channel members", func(t *testing.T) {
+		member := receive(t, memberReceived)
+		assert.Equal(t, th.BasicUser.Id, member.UserId)
+		assert.Equal(t, th.BasicChannel.Id, member.ChannelId)
+		assert.True(t, member.SchemeAdmin)
+		assert.Contains(t, member.Roles, model.ChannelAdminRoleId)
+		assert.Empty(t, member.NotifyProps)
+	})
+
+	t.Run("users outside the channel", func(t *testing.T) {
+		select {
+		case <-outsiderReceived:
+			assert.Fail(t, "users outside the channel should not receive the event")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
@@ -12,6 +12,8 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"text/template"
+	"time"
 	"unicode/utf8"
 
 	"github.com/mattermost/mattermost-server/v6/app/request"
@@ -26,6 +28,11 @@ const (
 	TriggerwordsStartsWith = 1
 
 	MaxIntegrationResponseSize = 1024 * 1024 // Posts can be <100KB at most, so this is likely more than enough
+
+	MaxOutgoingWebhookPayloadSize = 256 * 1024
+
+	ReactionWebhookDebounceCacheSize = 10000
+	ReactionWebhookDebounceTTL       = 10 * time.Second
 )
 
 func (a *App) handleWebhookEvents(c *request.Context, post *model.Post, team *model.Team, channel *model.Channel, user *model.User) *model.AppError {
@@ -56,7 +63,7 @@ func (a *App) handleWebhookEvents(c *request.Context, post *model.Post, team *mo
 	relevantHooks := []*model.OutgoingWebhook{}
 	for _, hook := range hooks {
 		if hook.ChannelId == post.ChannelId || hook.ChannelId == "" {
-			if hook.ChannelId == post.ChannelId && len(hook.TriggerWords) == 0 {
+			if hook.ChannelId == post.ChannelId && len(hook.TriggerWords) == 0 && len(hook.TriggerReactions) == 0 {
 				relevantHooks = append(relevantHooks, hook)
 				triggerWord = ""
 			} else if hook.TriggerWhen == TriggerwordsExactMatch && hook.TriggerWordExactMatch(firstWord) {
@@ -94,10 +101,145 @@ func (a *App) handleWebhookEvents(c *request.Context, post *model.Post, team *mo
 	return nil
 }
 
+// handleReactionWebhookEvents triggers the outgoing webhooks configured to fire when the given
+// reaction is added to, or removed from, a post. Repeated toggles of the same reaction by the same
+// user are debounced so that a webhook only fires once per ReactionWebhookDebounceTTL.
+func (a *App) handleReactionWebhookEvents(c *request.Context, reaction *model.Reaction, post *model.Post, channel *model.Channel, removed bool) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil
+	}
+
+	if channel.Type != model.ChannelTypeOpen {
+		return nil
+	}
+
+	hooks, err := a.Srv().Store.Webhook().GetOutgoingByTeam(channel.TeamId, -1, -1)
+	if err != nil {
+		return model.NewAppError("handleReactionWebhookEvents", "app.webhooks.get_outgoing_by_team.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	relevantHooks := []*model.OutgoingWebhook{}
+	for _, hook := range hooks {
+		if (hook.ChannelId == post.ChannelId || hook.ChannelId == "") && hook.TriggersOnReaction(reaction.EmojiName, removed) {
+			relevantHooks = append(relevantHooks, hook)
+		}
+	}
+
+	if len(relevantHooks) == 0 {
+		return nil
+	}
+
+	team, appErr := a.GetTeam(channel.TeamId)
+	if appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.GetUser(reaction.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	reactionEvent := model.OutgoingHookReactionEventAdded
+	if removed {
+		reactionEvent = model.OutgoingHookReactionEventRemoved
+	}
+
+	for _, hook := range relevantHooks {
+		debounceKey := hook.Id + post.Id + reaction.UserId + reaction.EmojiName
+		var seen bool
+		if cacheErr := a.Srv().reactionWebhookDebounceCache.Get(debounceKey, &seen); cacheErr == nil {
+			continue
+		}
+		a.Srv().reactionWebhookDebounceCache.SetWithExpiry(debounceKey, true, ReactionWebhookDebounceTTL)
+
+		payload := &model.OutgoingWebhookPayload{
+			Token:         hook.Token,
+			TeamId:        hook.TeamId,
+			TeamDomain:    team.Name,
+			ChannelId:     post.ChannelId,
+			ChannelName:   channel.Name,
+			Timestamp:     model.GetMillis(),
+			UserId:        user.Id,
+			UserName:      user.Username,
+			PostId:        post.Id,
+			Text:          post.Message,
+			FileIds:       strings.Join(post.FileIds, ","),
+			EmojiName:     reaction.EmojiName,
+			ReactionEvent: reactionEvent,
+		}
+		a.Srv().Go(func(hook *model.OutgoingWebhook) func() {
+			return func() {
+				a.TriggerWebhook(c, payload, hook, post, channel)
+			}
+		}(hook))
+	}
+
+	return nil
+}
+
+var errOutgoingWebhookPayloadTooLarge = errors.New("outgoing webhook payload exceeds the maximum size")
+
+// payloadSizeLimitWriter fails as soon as more than limit bytes have been written to it.
+type payloadSizeLimitWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *payloadSizeLimitWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, errOutgoingWebhookPayloadTooLarge
+	}
+	return w.buf.Write(p)
+}
+
+// outgoingWebhookPayloadTemplate is ServiceSettings.OutgoingWebhookPayloadTemplate as parsed when the
+// config was loaded, so that it isn't parsed again for every webhook triggered.
+type outgoingWebhookPayloadTemplate struct {
+	tmpl *template.Template
+	err  error
+}
+
+func (ch *Channels) loadOutgoingWebhookPayloadTemplate(cfg *model.Config) {
+	payloadTemplate := &outgoingWebhookPayloadTemplate{}
+	if *cfg.ServiceSettings.OutgoingWebhookPayloadTemplate != "" {
+		payloadTemplate.tmpl, payloadTemplate.err = model.ParseOutgoingWebhookPayloadTemplate(*cfg.ServiceSettings.OutgoingWebhookPayloadTemplate)
+		if payloadTemplate.err != nil {
+			mlog.Warn("Failed to parse outgoing webhook payload template", mlog.Err(payloadTemplate.err))
+		}
+	}
+	ch.outgoingWebhookPayloadTemplate.Store(payloadTemplate)
+}
+
+// renderOutgoingWebhookPayload executes the payload template against the payload and
+// returns the rendered body, which must not exceed MaxOutgoingWebhookPayloadSize.
+func renderOutgoingWebhookPayload(tmpl *template.Template, payload *model.OutgoingWebhookPayload) ([]byte, error) {
+	w := &payloadSizeLimitWriter{limit: MaxOutgoingWebhookPayloadSize}
+	if err := tmpl.Execute(w, payload); err != nil {
+		return nil, err
+	}
+
+	return w.buf.Bytes(), nil
+}
+
 func (a *App) TriggerWebhook(c *request.Context, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
 	var body io.Reader
 	var contentType string
-	if hook.ContentType == "application/json" {
+	if payloadTemplate := a.ch.outgoingWebhookPayloadTemplate.Load().(*outgoingWebhookPayloadTemplate); payloadTemplate.tmpl != nil || payloadTemplate.err != nil {
+		if payloadTemplate.err != nil {
+			mlog.Warn("Failed to render outgoing webhook payload template, skipping webhook", mlog.String("webhook_id", hook.Id), mlog.Err(payloadTemplate.err))
+			return
+		}
+		rendered, err := renderOutgoingWebhookPayload(payloadTemplate.tmpl, payload)
+		if err != nil {
+			mlog.Warn("Failed to render outgoing webhook payload template, skipping webhook", mlog.String("webhook_id", hook.Id), mlog.Err(err))
+			return
+		}
+		body = bytes.NewReader(rendered)
+		contentType = hook.ContentType
+		if contentType == "" {
+			contentType = "application/x-www-form-urlencoded"
+		}
+	} else if hook.ContentType == "application/json" {
 		js, jsonErr := json.Marshal(payload)
 		if jsonErr != nil {
 			mlog.Warn("Failed to encode to JSON", mlog.Err(jsonErr))
@@ -300,9 +442,14 @@ func (a *App) CreateWebhookPost(c *request.Context, userID string, channel *mode
 		for key, val := range props {
 			if key == "attachments" {
 				if attachments, success := val.([]*model.SlackAttachment); success {
+					for _, attachment := range attachments {
+						if err := attachment.Validate(); err != nil {
+							return nil, err
+						}
+					}
 					model.ParseSlackAttachment(post, attachments)
 				}
-			} else if key != "override_icon_url" && key != "override_username" && key != "from_webhook" {
+			} else if key != "override_icon_url" && key != "override_username" && !model.IsReservedPostProp(key) {
 				post.AddProp(key, val)
 			}
 		}
@@ -480,7 +627,7 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 		if channel.Type != model.ChannelTypeOpen || channel.TeamId != hook.TeamId {
 			return nil, model.NewAppError("CreateOutgoingWebhook", "api.webhook.create_outgoing.permissions.app_error", nil, "", http.StatusForbidden)
 		}
-	} else if len(hook.TriggerWords) == 0 {
+	} else if len(hook.TriggerWords) == 0 && len(hook.TriggerReactions) == 0 {
 		return nil, model.NewAppError("CreateOutgoingWebhook", "api.webhook.create_outgoing.triggers.app_error", nil, "", http.StatusBadRequest)
 	}
 
@@ -532,7 +679,7 @@ func (a *App) UpdateOutgoingWebhook(oldHook, updatedHook *model.OutgoingWebhook)
 		if channel.TeamId != oldHook.TeamId {
 			return nil, model.NewAppError("UpdateOutgoingWebhook", "api.webhook.create_outgoing.permissions.app_error", nil, "", http.StatusForbidden)
 		}
-	} else if len(updatedHook.TriggerWords) == 0 {
+	} else if len(updatedHook.TriggerWords) == 0 && len(updatedHook.TriggerReactions) == 0 {
 		return nil, model.NewAppError("UpdateOutgoingWebhook", "api.webhook.create_outgoing.triggers.app_error", nil, "", http.StatusInternalServerError)
 	}
 
@@ -779,6 +926,10 @@ func (a *App) HandleIncomingWebhook(c *request.Context, hookID string, req *mode
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.permissions.app_error", nil, "", http.StatusForbidden)
 	}
 
+	if channel.Id != hook.ChannelId && !a.canIncomingWebhookOverrideChannel(hook, channel) {
+		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.channel_override.app_error", nil, "channel_id="+channel.Id, http.StatusForbidden)
+	}
+
 	overrideUsername := hook.Username
 	if req.Username != "" {
 		overrideUsername = req.Username
@@ -793,6 +944,16 @@ func (a *App) HandleIncomingWebhook(c *request.Context, hookID string, req *mode
 	return err
 }
 
+// canIncomingWebhookOverrideChannel reports whether the incoming webhook may post to a channel other
+// than its own, which requires the channel to be allowlisted or the webhook creator to be able to post in it.
+func (a *App) canIncomingWebhookOverrideChannel(hook *model.IncomingWebhook, channel *model.Channel) bool {
+	if utils.StringInSlice(channel.Id, a.Config().ServiceSettings.IncomingWebhookChannelAllowlist) {
+		return true
+	}
+
+	return a.HasPermissionToChannel(hook.UserId, channel.Id, model.PermissionCreatePost)
+}
+
 func (a *App) CreateCommandWebhook(commandID string, args *model.CommandArgs) (*model.CommandWebhook, *model.AppError) {
 	hook := &model.CommandWebhook{
 		CommandId: commandID,
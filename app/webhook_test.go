@@ -307,6 +307,25 @@ func TestCreateWebhookPost(t *testing.T) {
 	assert.Contains(t, post.GetProps(), "attachments", "missing attachments prop")
 	assert.Contains(t, post.GetProps(), "webhook_display_name", "missing webhook_display_name prop")
 
+	post, err = th.App.CreateWebhookPost(th.Context, hook.UserId, th.BasicChannel, "foo", "user", "http://iconurl", "", model.StringInterface{
+		model.PostPropsFromWebhook:  "false",
+		model.PostPropsFromBot:      "true",
+		model.PropsAddChannelMember: "no good",
+	}, "", "")
+	require.Nil(t, err)
+	assert.Equal(t, "true", post.GetProp(model.PostPropsFromWebhook))
+	assert.Nil(t, post.GetProp(model.PostPropsFromBot), "reserved prop should have been stripped")
+	assert.Nil(t, post.GetProp(model.PropsAddChannelMember), "reserved prop should have been stripped")
+
+	// editing the post keeps the reserved props set by the server
+	edited := post.Clone()
+	edited.Message = "edited"
+	edited.SetProps(model.StringInterface{"custom": "value"})
+	edited, err = th.App.UpdatePost(th.Context, edited, false)
+	require.Nil(t, err)
+	assert.Equal(t, "true", edited.GetProp(model.PostPropsFromWebhook))
+	assert.Equal(t, "value", edited.GetProp("custom"))
+
 	_, err = th.App.CreateWebhookPost(th.Context, hook.UserId, th.BasicChannel, "foo", "user", "http://iconurl", "", nil, model.PostTypeSystemGeneric, "")
 	require.NotNil(t, err, "Should have failed - bad post type")
 
@@ -361,6 +380,92 @@ Date:   Thu Mar 1 19:46:48 2018 +0300
 	}, model.PostTypeSlackAttachment, "")
 	require.Nil(t, err)
 	assert.Equal(t, expectedText, post.Message)
+
+	_, err = th.App.CreateWebhookPost(th.Context, hook.UserId, th.BasicChannel, "foo", "user", "http://iconurl", "", model.StringInterface{
+		"attachments": []*model.SlackAttachment{
+			{
+				Text:  "text",
+				Color: "not a color",
+			},
+		},
+		"webhook_display_name": hook.DisplayName,
+	}, model.PostTypeSlackAttachment, "")
+	require.NotNil(t, err, "Should have failed - malformed attachment")
+	assert.Equal(t, "model.slack_attachment.validate.color.app_error", err.Id)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+}
+
+func TestHandleIncomingWebhookChannelOverride(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = true })
+
+	hook, appErr := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	require.Nil(t, appErr)
+	defer th.App.DeleteIncomingWebhook(hook.Id)
+
+	memberChannel := th.CreateChannel(th.BasicTeam)
+
+	otherChannel, appErr := th.App.CreateChannel(th.Context, &model.Channel{
+		TeamId:      th.BasicTeam.Id,
+		Name:        "name" + model.NewId(),
+		DisplayName: "DisplayName",
+		Type:        model.ChannelTypeOpen,
+		CreatorId:   th.BasicUser2.Id,
+	}, true)
+	require.Nil(t, appErr)
+
+	lastMessage := func(t *testing.T, channel *model.Channel) string {
+		t.Helper()
+		list, appErr := th.App.GetPosts(channel.Id, 0, 1)
+		require.Nil(t, appErr)
+		require.Len(t, list.Order, 1)
+		return list.Posts[list.Order[0]].Message
+	}
+
+	t.Run("allowed override to a channel the creator can post in", func(t *testing.T) {
+		appErr := th.App.HandleIncomingWebhook(th.Context, hook.Id, &model.IncomingWebhookRequest{
+			Text:        "to member channel",
+			ChannelName: memberChannel.Name,
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, "to member channel", lastMessage(t, memberChannel))
+	})
+
+	t.Run("disallowed override to a channel the creator cannot post in", func(t *testing.T) {
+		appErr := th.App.HandleIncomingWebhook(th.Context, hook.Id, &model.IncomingWebhookRequest{
+			Text:        "to other channel",
+			ChannelName: otherChannel.Name,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "web.incoming_webhook.channel_override.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("allowed override to an allowlisted channel", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.IncomingWebhookChannelAllowlist = []string{otherChannel.Id}
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.IncomingWebhookChannelAllowlist = []string{}
+		})
+
+		appErr := th.App.HandleIncomingWebhook(th.Context, hook.Id, &model.IncomingWebhookRequest{
+			Text:        "to allowlisted channel",
+			ChannelName: otherChannel.Name,
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, "to allowlisted channel", lastMessage(t, otherChannel))
+	})
+
+	t.Run("webhook's own channel is always allowed", func(t *testing.T) {
+		appErr := th.App.HandleIncomingWebhook(th.Context, hook.Id, &model.IncomingWebhookRequest{
+			Text:        "to own channel",
+			ChannelName: th.BasicChannel.Name,
+		})
+		require.Nil(t, appErr)
+	})
 }
 
 func TestSplitWebhookPost(t *testing.T) {
@@ -713,6 +818,113 @@ func TestTriggerOutGoingWebhookWithUsernameAndIconURL(t *testing.T) {
 
 }
 
+func TestTriggerOutgoingWebhookOnReaction(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	payloads := make(chan *model.OutgoingWebhookPayload, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload model.OutgoingWebhookPayload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads <- &payload
+	}))
+	defer ts.Close()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:        channel.Id,
+		TeamId:           channel.TeamId,
+		CallbackURLs:     []string{ts.URL},
+		CreatorId:        th.BasicUser.Id,
+		TriggerReactions: []string{"eyes"},
+		ContentType:      "application/json",
+	})
+	require.Nil(t, appErr)
+
+	post := th.CreatePost(channel)
+
+	expectNoPayload := func(t *testing.T) {
+		t.Helper()
+		select {
+		case payload := <-payloads:
+			require.Failf(t, "unexpected webhook request", "%+v", payload)
+		case <-time.After(time.Second):
+		}
+	}
+
+	t.Run("should not fire for a post in a reaction-only hook's channel", func(t *testing.T) {
+		th.CreatePost(channel)
+		expectNoPayload(t)
+	})
+
+	t.Run("should not fire on other emoji", func(t *testing.T) {
+		_, appErr := th.App.SaveReactionForPost(th.Context, &model.Reaction{
+			UserId:    th.BasicUser.Id,
+			PostId:    post.Id,
+			EmojiName: "smile",
+		})
+		require.Nil(t, appErr)
+		expectNoPayload(t)
+	})
+
+	reaction := &model.Reaction{
+		UserId:    th.BasicUser.Id,
+		PostId:    post.Id,
+		EmojiName: "eyes",
+	}
+
+	t.Run("should fire on the configured emoji", func(t *testing.T) {
+		_, appErr := th.App.SaveReactionForPost(th.Context, reaction)
+		require.Nil(t, appErr)
+
+		select {
+		case payload := <-payloads:
+			assert.Equal(t, hook.Token, payload.Token)
+			assert.Equal(t, post.Id, payload.PostId)
+			assert.Equal(t, th.BasicUser.Id, payload.UserId)
+			assert.Equal(t, th.BasicUser.Username, payload.UserName)
+			assert.Equal(t, "eyes", payload.EmojiName)
+			assert.Equal(t, model.OutgoingHookReactionEventAdded, payload.ReactionEvent)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "Timeout, webhook was not triggered")
+		}
+	})
+
+	t.Run("should not fire on removal unless configured", func(t *testing.T) {
+		require.Nil(t, th.App.DeleteReactionForPost(th.Context, reaction))
+		expectNoPayload(t)
+	})
+
+	t.Run("should debounce rapid toggles", func(t *testing.T) {
+		_, appErr := th.App.SaveReactionForPost(th.Context, reaction)
+		require.Nil(t, appErr)
+		expectNoPayload(t)
+	})
+
+	t.Run("should fire on removal when configured", func(t *testing.T) {
+		th.Server.reactionWebhookDebounceCache.Purge()
+
+		hook.TriggerOnReactionRemoved = true
+		_, appErr := th.App.UpdateOutgoingWebhook(hook, hook)
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.DeleteReactionForPost(th.Context, reaction))
+
+		select {
+		case payload := <-payloads:
+			assert.Equal(t, "eyes", payload.EmojiName)
+			assert.Equal(t, model.OutgoingHookReactionEventRemoved, payload.ReactionEvent)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "Timeout, webhook was not triggered")
+		}
+	})
+}
+
 type InfiniteReader struct {
 	Prefix string
 }
@@ -811,3 +1023,132 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		require.Nil(t, resp)
 	})
 }
+
+func TestRenderOutgoingWebhookPayload(t *testing.T) {
+	payload := &model.OutgoingWebhookPayload{
+		ChannelName: "town-square",
+		UserName:    "someone",
+		Text:        "  Hello World  ",
+		TriggerWord: "Hello",
+	}
+
+	render := func(payloadTemplate string, payload *model.OutgoingWebhookPayload) ([]byte, error) {
+		tmpl, err := model.ParseOutgoingWebhookPayloadTemplate(payloadTemplate)
+		if err != nil {
+			return nil, err
+		}
+		return renderOutgoingWebhookPayload(tmpl, payload)
+	}
+
+	t.Run("custom template", func(t *testing.T) {
+		body, err := render(`{"who": {{json .UserName}}, "what": {{json (trimSpace .Text | lower)}}, "where": "{{upper .ChannelName}}"}`, payload)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"who": "someone", "what": "hello world", "where": "TOWN-SQUARE"}`, string(body))
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := render(`{{.Text`, payload)
+		require.Error(t, err)
+	})
+
+	t.Run("unknown function", func(t *testing.T) {
+		_, err := render(`{{exec .Text}}`, payload)
+		require.Error(t, err)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := render(`{{.Password}}`, payload)
+		require.Error(t, err)
+	})
+
+	t.Run("output too large", func(t *testing.T) {
+		largePayload := &model.OutgoingWebhookPayload{Text: strings.Repeat("a", MaxOutgoingWebhookPayloadSize)}
+		_, err := render(`{{.Text}}{{.Text}}`, largePayload)
+		require.ErrorIs(t, err, errOutgoingWebhookPayloadTooLarge)
+	})
+}
+
+func TestTriggerOutgoingWebhookWithPayloadTemplate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+	defer th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.OutgoingWebhookPayloadTemplate = ""
+	})
+
+	received := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received <- string(body)
+	}))
+	defer ts.Close()
+
+	hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:    th.BasicChannel.Id,
+		TeamId:       th.BasicTeam.Id,
+		CallbackURLs: []string{ts.URL},
+		CreatorId:    th.BasicUser.Id,
+		TriggerWords: []string{"Abracadabra"},
+		ContentType:  "application/json",
+	})
+	require.Nil(t, appErr)
+
+	payload := &model.OutgoingWebhookPayload{
+		Token:       hook.Token,
+		ChannelId:   th.BasicChannel.Id,
+		UserName:    th.BasicUser.Username,
+		Text:        "Abracadabra",
+		TriggerWord: "Abracadabra",
+	}
+
+	t.Run("custom template", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.OutgoingWebhookPayloadTemplate = `{"message": {{json .Text}}, "user": {{json .UserName}}}`
+		})
+
+		th.App.TriggerWebhook(th.Context, payload, hook, th.BasicPost, th.BasicChannel)
+
+		select {
+		case body := <-received:
+			assert.JSONEq(t, `{"message": "Abracadabra", "user": "`+th.BasicUser.Username+`"}`, body)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "Timeout, webhook not triggered")
+		}
+	})
+
+	t.Run("parsed on config load", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.OutgoingWebhookPayloadTemplate = `{"message": {{json .Text}}}`
+		})
+
+		payloadTemplate := th.App.ch.outgoingWebhookPayloadTemplate.Load().(*outgoingWebhookPayloadTemplate)
+		require.NotNil(t, payloadTemplate.tmpl)
+		require.NoError(t, payloadTemplate.err)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.OutgoingWebhookPayloadTemplate = ""
+		})
+
+		payloadTemplate = th.App.ch.outgoingWebhookPayloadTemplate.Load().(*outgoingWebhookPayloadTemplate)
+		assert.Nil(t, payloadTemplate.tmpl)
+	})
+
+	t.Run("bad template", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.OutgoingWebhookPayloadTemplate = `{"message": {{.Missing}}}`
+		})
+
+		th.App.TriggerWebhook(th.Context, payload, hook, th.BasicPost, th.BasicChannel)
+
+		select {
+		case body := <-received:
+			require.Failf(t, "webhook should have been skipped", "received %s", body)
+		case <-time.After(time.Second):
+		}
+	})
+}
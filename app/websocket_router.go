@@ -79,6 +79,20 @@ func (wr *WebSocketRouter) ServeWebSocket(conn *WebConn, r *model.WebSocketReque
 		return
 	}
 
+	if r.Action == model.WebsocketReconnect {
+		if _, ok := r.Data["last_sequence"].(float64); !ok {
+			err := model.NewAppError("ServeWebSocket", "api.web_socket_router.bad_last_sequence.app_error", nil, "", http.StatusBadRequest)
+			returnWebSocketError(conn.App, conn, r, err)
+			return
+		}
+
+		if !conn.QueueReconnectRequest(r) {
+			err := model.NewAppError("ServeWebSocket", "api.web_socket_router.reconnect_pending.app_error", nil, "", http.StatusTooManyRequests)
+			returnWebSocketError(conn.App, conn, r, err)
+		}
+		return
+	}
+
 	handler, ok := wr.handlers[r.Action]
 	if !ok {
 		err := model.NewAppError("ServeWebSocket", "api.web_socket_router.bad_action.app_error", nil, "", http.StatusInternalServerError)
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// welcomeMessageEnabledAt returns when ServiceSettings.EnableWelcomeMessage was turned on, recording
+// the current time if it hasn't been yet.
+func (s *Server) welcomeMessageEnabledAt() (int64, *model.AppError) {
+	system, err := s.Store.System().InsertIfExists(&model.System{
+		Name:  model.SystemWelcomeMessageEnabledAtKey,
+		Value: strconv.FormatInt(model.GetMillis(), 10),
+	})
+	if err != nil {
+		return 0, model.NewAppError("welcomeMessageEnabledAt", "app.system.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	enabledAt, err := strconv.ParseInt(system.Value, 10, 64)
+	if err != nil {
+		return 0, model.NewAppError("welcomeMessageEnabledAt", "app.system_install_date.parse_int.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return enabledAt, nil
+}
+
+// resetWelcomeMessageEnabledAt records that ServiceSettings.EnableWelcomeMessage has just been turned
+// on, so that the users who joined while it was off aren't sent the message.
+func (s *Server) resetWelcomeMessageEnabledAt() *model.AppError {
+	if err := s.Store.System().SaveOrUpdate(&model.System{
+		Name:  model.SystemWelcomeMessageEnabledAtKey,
+		Value: strconv.FormatInt(model.GetMillis(), 10),
+	}); err != nil {
+		return model.NewAppError("resetWelcomeMessageEnabledAt", "app.system.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// sendWelcomeMessageAsync sends the user the welcome message in the background, so that logging in
+// doesn't wait for it.
+func (a *App) sendWelcomeMessageAsync(c *request.Context, user *model.User) {
+	a.Srv().Go(func() {
+		if appErr := a.sendWelcomeMessage(c, user); appErr != nil {
+			mlog.Warn("Failed to send the welcome message", mlog.String("user_id", user.Id), mlog.Err(appErr))
+		}
+	})
+}
+
+// sendWelcomeMessage sends the user a direct message from the system bot, greeting them in their
+// locale and followed by ServiceSettings.WelcomeMessage, unless they've already been sent one or
+// joined before the message was enabled.
+func (a *App) sendWelcomeMessage(c *request.Context, user *model.User) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableWelcomeMessage || user.IsBot {
+		return nil
+	}
+
+	enabledAt, appErr := a.Srv().welcomeMessageEnabledAt()
+	if appErr != nil {
+		return appErr
+	}
+
+	if user.CreateAt < enabledAt {
+		return nil
+	}
+
+	// The message is claimed by marking it as sent before sending it, so that concurrent logins never
+	// send it twice, even though a failure to send it means it's never retried.
+	claimed, err := a.Srv().Store.Preference().SaveIfNotExists(&model.Preference{
+		UserId:   user.Id,
+		Category: model.PreferenceCategoryOnboarding,
+		Name:     model.PreferenceNameWelcomeMessageSent,
+		Value:    "true",
+	})
+	if err != nil {
+		return model.NewAppError("sendWelcomeMessage", "app.preference.save.updating.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if !claimed {
+		return nil
+	}
+
+	systemBot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		return appErr
+	}
+
+	dm, appErr := a.GetOrCreateDirectChannel(c, systemBot.UserId, user.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	message := T("app.user.welcome_message.greeting", map[string]interface{}{
+		"Username": user.Username,
+		"SiteName": *a.Config().TeamSettings.SiteName,
+	})
+	if welcomeMessage := *a.Config().ServiceSettings.WelcomeMessage; welcomeMessage != "" {
+		message += "\n\n" + welcomeMessage
+	}
+
+	post := &model.Post{
+		UserId:    systemBot.UserId,
+		ChannelId: dm.Id,
+		Message:   message,
+	}
+
+	if _, appErr := a.CreatePost(c, post, dm, false, true); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+)
+
+func TestWelcomeMessage(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableWelcomeMessage = true
+		*cfg.ServiceSettings.WelcomeMessage = "Start by reading the ~handbook channel."
+	})
+
+	systemBot, appErr := th.App.GetSystemBot()
+	require.Nil(t, appErr)
+
+	login := func(t *testing.T, user *model.User) {
+		t.Helper()
+		appErr := th.App.DoLogin(request.EmptyContext(), httptest.NewRecorder(), &http.Request{}, user, "", false, false, false)
+		require.Nil(t, appErr)
+	}
+
+	welcomeMessages := func(t *testing.T, user *model.User) []*model.Post {
+		t.Helper()
+		dm, appErr := th.App.GetOrCreateDirectChannel(th.Context, systemBot.UserId, user.Id)
+		require.Nil(t, appErr)
+		list, appErr := th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, appErr)
+		return list.ToSlice()
+	}
+
+	t.Run("sent on the first login", func(t *testing.T) {
+		user := th.CreateUser()
+		login(t, user)
+
+		var posts []*model.Post
+		require.Eventually(t, func() bool {
+			posts = welcomeMessages(t, user)
+			return len(posts) == 1
+		}, 5*time.Second, 100*time.Millisecond)
+		assert.Equal(t, systemBot.UserId, posts[0].UserId)
+
+		T := i18n.GetUserTranslations(user.Locale)
+		greeting := T("app.user.welcome_message.greeting", map[string]interface{}{
+			"Username": user.Username,
+			"SiteName": *th.App.Config().TeamSettings.SiteName,
+		})
+		assert.Equal(t, greeting+"\n\nStart by reading the ~handbook channel.", posts[0].Message)
+	})
+
+	t.Run("not sent on subsequent logins", func(t *testing.T) {
+		user := th.CreateUser()
+		login(t, user)
+		require.Eventually(t, func() bool {
+			return len(welcomeMessages(t, user)) == 1
+		}, 5*time.Second, 100*time.Millisecond)
+
+		login(t, user)
+		time.Sleep(500 * time.Millisecond)
+		assert.Len(t, welcomeMessages(t, user), 1)
+	})
+
+	t.Run("sent once on concurrent logins", func(t *testing.T) {
+		user := th.CreateUser()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				appErr := th.App.DoLogin(request.EmptyContext(), httptest.NewRecorder(), &http.Request{}, user, "", false, false, false)
+				assert.Nil(t, appErr)
+			}()
+		}
+		wg.Wait()
+
+		require.Eventually(t, func() bool {
+			return len(welcomeMessages(t, user)) >= 1
+		}, 5*time.Second, 100*time.Millisecond)
+		time.Sleep(500 * time.Millisecond)
+		assert.Len(t, welcomeMessages(t, user), 1)
+	})
+
+	t.Run("not sent to users who joined before it was enabled", func(t *testing.T) {
+		appErr := th.App.sendWelcomeMessage(th.Context, th.BasicUser)
+		require.Nil(t, appErr)
+
+		assert.Empty(t, welcomeMessages(t, th.BasicUser))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnableWelcomeMessage = false
+		})
+
+		user := th.CreateUser()
+		appErr := th.App.sendWelcomeMessage(th.Context, user)
+		require.Nil(t, appErr)
+		assert.Empty(t, welcomeMessages(t, user))
+
+		t.Run("not sent to users who joined while it was disabled", func(t *testing.T) {
+			th.App.UpdateConfig(func(cfg *model.Config) {
+				*cfg.ServiceSettings.EnableWelcomeMessage = true
+			})
+
+			appErr := th.App.sendWelcomeMessage(th.Context, user)
+			require.Nil(t, appErr)
+			assert.Empty(t, welcomeMessages(t, user))
+		})
+	})
+}
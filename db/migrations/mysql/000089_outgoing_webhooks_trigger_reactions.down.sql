@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+	EXISTS (
+		SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'OutgoingWebhooks'
+		AND table_schema = DATABASE()
+		AND column_name = 'TriggerOnReactionRemoved'
+	),
+	'ALTER TABLE OutgoingWebhooks DROP COLUMN TriggerOnReactionRemoved;',
+	'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
+
+SET @preparedStatement = (SELECT IF(
+	EXISTS (
+		SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'OutgoingWebhooks'
+		AND table_schema = DATABASE()
+		AND column_name = 'TriggerReactions'
+	),
+	'ALTER TABLE OutgoingWebhooks DROP COLUMN TriggerReactions;',
+	'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+	NOT EXISTS(
+		SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'OutgoingWebhooks'
+		AND table_schema = DATABASE()
+		AND column_name = 'TriggerReactions'
+	),
+	'ALTER TABLE OutgoingWebhooks ADD COLUMN TriggerReactions text;',
+	'SELECT 1'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	NOT EXISTS(
+		SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'OutgoingWebhooks'
+		AND table_schema = DATABASE()
+		AND column_name = 'TriggerOnReactionRemoved'
+	),
+	'ALTER TABLE OutgoingWebhooks ADD COLUMN TriggerOnReactionRemoved BOOLEAN NOT NULL DEFAULT FALSE;',
+	'SELECT 1'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS PostReports (
+    Id varchar(26) NOT NULL,
+    PostId varchar(26) DEFAULT NULL,
+    ChannelId varchar(26) DEFAULT NULL,
+    ReporterId varchar(26) DEFAULT NULL,
+    Reason text,
+    Status varchar(32) DEFAULT NULL,
+    CreateAt bigint(20) DEFAULT NULL,
+    UpdateAt bigint(20) DEFAULT NULL,
+    PRIMARY KEY (Id),
+    UNIQUE KEY idx_postreports_post_id_reporter_id (PostId, ReporterId),
+    KEY idx_postreports_status_create_at (Status, CreateAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+	EXISTS (
+		SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'Channels'
+		AND table_schema = DATABASE()
+		AND column_name = 'SlowModeSeconds'
+	),
+	'ALTER TABLE Channels DROP COLUMN SlowModeSeconds;',
+	'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
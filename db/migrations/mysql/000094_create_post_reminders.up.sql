@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS PostReminders (
+    PostId varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    RemindAt bigint(20) DEFAULT NULL,
+    CreateAt bigint(20) DEFAULT NULL,
+    PRIMARY KEY (PostId, UserId),
+    KEY idx_postreminders_remind_at (RemindAt),
+    KEY idx_postreminders_user_id (UserId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
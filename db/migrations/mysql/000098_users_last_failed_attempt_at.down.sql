@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+	EXISTS (
+		SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'Users'
+		AND table_schema = DATABASE()
+		AND column_name = 'LastFailedAttemptAt'
+	),
+	'ALTER TABLE Users DROP COLUMN LastFailedAttemptAt;',
+	'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
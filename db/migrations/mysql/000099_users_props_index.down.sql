@@ -0,0 +1 @@
+-- MySQL can't index the keys of a JSON column, so there is no equivalent of the Postgres index.
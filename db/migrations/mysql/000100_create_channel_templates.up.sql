@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS ChannelTemplates (
+    Id varchar(26) NOT NULL,
+    TeamId varchar(26) DEFAULT NULL,
+    Name varchar(64) DEFAULT NULL,
+    Type varchar(1) DEFAULT NULL,
+    Purpose varchar(250) DEFAULT NULL,
+    Header text,
+    PinnedMessages text,
+    CreatorId varchar(26) DEFAULT NULL,
+    CreateAt bigint(20) DEFAULT NULL,
+    UpdateAt bigint(20) DEFAULT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_channeltemplates_team_id (TeamId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS GuestChannelInvites (
+    UserId varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    CreateAt bigint(20) DEFAULT NULL,
+    PRIMARY KEY (UserId, ChannelId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
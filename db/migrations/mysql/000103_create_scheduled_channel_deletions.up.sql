@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS ScheduledChannelDeletions (
+    ChannelId varchar(26) NOT NULL,
+    DeleteAt bigint(20) DEFAULT NULL,
+    CreateAt bigint(20) DEFAULT NULL,
+    PRIMARY KEY (ChannelId),
+    KEY idx_scheduledchanneldeletions_delete_at (DeleteAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
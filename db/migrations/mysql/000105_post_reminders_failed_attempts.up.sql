@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+	NOT EXISTS(
+		SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE table_name = 'PostReminders'
+		AND table_schema = DATABASE()
+		AND column_name = 'FailedAttempts'
+	),
+	'ALTER TABLE PostReminders ADD COLUMN FailedAttempts int DEFAULT 0;',
+	'SELECT 1'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
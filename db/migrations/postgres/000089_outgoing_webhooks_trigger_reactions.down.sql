@@ -0,0 +1,2 @@
+ALTER TABLE outgoingwebhooks DROP COLUMN IF EXISTS triggeronreactionremoved;
+ALTER TABLE outgoingwebhooks DROP COLUMN IF EXISTS triggerreactions;
@@ -0,0 +1,2 @@
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS triggerreactions VARCHAR(1024);
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS triggeronreactionremoved bool NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_postreports_status_create_at;
+
+DROP TABLE IF EXISTS postreports;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS postreports (
+    id VARCHAR(26) PRIMARY KEY,
+    postid VARCHAR(26),
+    channelid VARCHAR(26),
+    reporterid VARCHAR(26),
+    reason VARCHAR(1024),
+    status VARCHAR(32),
+    createat bigint,
+    updateat bigint,
+    UNIQUE(postid, reporterid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_postreports_status_create_at ON postreports(status, createat);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_postreminders_user_id;
+DROP INDEX IF EXISTS idx_postreminders_remind_at;
+
+DROP TABLE IF EXISTS postreminders;
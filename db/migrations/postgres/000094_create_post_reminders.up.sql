@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS postreminders (
+    postid VARCHAR(26) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    remindat bigint,
+    createat bigint,
+    PRIMARY KEY (postid, userid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_postreminders_remind_at ON postreminders(remindat);
+CREATE INDEX IF NOT EXISTS idx_postreminders_user_id ON postreminders(userid);
@@ -0,0 +1 @@
+ALTER TABLE fileinfo ADD COLUMN IF NOT EXISTS quarantined boolean NOT NULL DEFAULT false;
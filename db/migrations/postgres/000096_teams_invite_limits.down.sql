@@ -0,0 +1,3 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS inviteuses;
+ALTER TABLE teams DROP COLUMN IF EXISTS invitemaxuses;
+ALTER TABLE teams DROP COLUMN IF EXISTS inviteexpireat;
@@ -0,0 +1,3 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS inviteexpireat bigint DEFAULT 0;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS invitemaxuses integer DEFAULT 0;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS inviteuses integer DEFAULT 0;
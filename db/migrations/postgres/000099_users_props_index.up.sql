@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_users_props ON users USING gin (props jsonb_path_ops);
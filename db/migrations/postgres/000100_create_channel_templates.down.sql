@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_channeltemplates_team_id;
+
+DROP TABLE IF EXISTS channeltemplates;
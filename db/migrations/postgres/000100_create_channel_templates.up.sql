@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS channeltemplates (
+    id VARCHAR(26) PRIMARY KEY,
+    teamid VARCHAR(26),
+    name VARCHAR(64),
+    type VARCHAR(1),
+    purpose VARCHAR(250),
+    header VARCHAR(1024),
+    pinnedmessages TEXT,
+    creatorid VARCHAR(26),
+    createat bigint,
+    updateat bigint
+);
+
+CREATE INDEX IF NOT EXISTS idx_channeltemplates_team_id ON channeltemplates(teamid);
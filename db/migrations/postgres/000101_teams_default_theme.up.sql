@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS defaulttheme VARCHAR(2000) DEFAULT '';
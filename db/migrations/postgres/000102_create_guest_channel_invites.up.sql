@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS guestchannelinvites (
+    userid VARCHAR(26) NOT NULL,
+    channelid VARCHAR(26) NOT NULL,
+    createat bigint,
+    PRIMARY KEY (userid, channelid)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_scheduledchanneldeletions_delete_at;
+
+DROP TABLE IF EXISTS scheduledchanneldeletions;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS scheduledchanneldeletions (
+    channelid VARCHAR(26) NOT NULL,
+    deleteat bigint,
+    createat bigint,
+    PRIMARY KEY (channelid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduledchanneldeletions_delete_at ON scheduledchanneldeletions(deleteat);
@@ -0,0 +1,2 @@
+ALTER TABLE channels DROP COLUMN IF EXISTS allowedfileextensions;
+ALTER TABLE channels DROP COLUMN IF EXISTS blockedfileextensions;
@@ -0,0 +1,2 @@
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS allowedfileextensions text;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS blockedfileextensions text;
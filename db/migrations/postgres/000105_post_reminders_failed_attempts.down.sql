@@ -0,0 +1 @@
+ALTER TABLE postreminders DROP COLUMN IF EXISTS failedattempts;
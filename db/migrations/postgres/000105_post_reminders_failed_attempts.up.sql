@@ -0,0 +1 @@
+ALTER TABLE postreminders ADD COLUMN IF NOT EXISTS failedattempts integer DEFAULT 0;
@@ -0,0 +1,17 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package einterfaces
+
+import (
+	"io"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// FileScannerInterface scans uploaded files for viruses before they can be downloaded.
+type FileScannerInterface interface {
+	// ScanFile reports whether the contents of the file are clean. An error means that the file
+	// couldn't be scanned.
+	ScanFile(info *model.FileInfo, file io.Reader) (bool, error)
+}
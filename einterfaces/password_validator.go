@@ -0,0 +1,15 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package einterfaces
+
+import (
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// PasswordValidatorInterface checks new passwords against a password policy. When one is registered,
+// it replaces the built-in rules from PasswordSettings.
+type PasswordValidatorInterface interface {
+	// ValidatePassword returns the reasons the password fails the policy, or none if it's acceptable.
+	ValidatePassword(password string) []model.PasswordFailureReason
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package archived_channel_purge
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+const schedFreq = 24 * time.Hour
+
+func MakeScheduler(jobServer *jobs.JobServer) model.Scheduler {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.DataRetentionSettings.EnableArchivedChannelDeletion
+	}
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypeArchivedChannelPurge, schedFreq, isEnabled)
+}
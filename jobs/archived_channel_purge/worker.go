@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package archived_channel_purge
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/configservice"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+	"github.com/wiggin77/merror"
+)
+
+const jobName = "ArchivedChannelPurge"
+
+type AppIface interface {
+	configservice.ConfigService
+	PermanentDeleteChannel(channel *model.Channel) *model.AppError
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface, s store.Store) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.DataRetentionSettings.EnableArchivedChannelDeletion
+	}
+	execute := func(job *model.Job) error {
+		retentionTime := time.Duration(*app.Config().DataRetentionSettings.ArchivedChannelRetentionDays) * 24 * time.Hour
+		deletedBefore := model.GetMillisForTime(time.Now().Add(-retentionTime))
+		batchSize := *app.Config().DataRetentionSettings.BatchSize
+
+		multipleErrors := merror.New()
+		purged := 0
+		afterID := ""
+		for {
+			channels, err := s.Channel().GetChannelsDeletedBefore(deletedBefore, afterID, batchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, channel := range channels {
+				// PermanentDeleteChannel also removes the channel's posts, memberships and webhooks.
+				if appErr := app.PermanentDeleteChannel(channel); appErr != nil {
+					mlog.Debug("Worker: Failed to purge archived channel",
+						mlog.Err(appErr), mlog.String("channel_id", channel.Id))
+					multipleErrors.Append(appErr)
+					continue
+				}
+				purged++
+			}
+
+			if len(channels) < batchSize {
+				break
+			}
+			afterID = channels[len(channels)-1].Id
+		}
+
+		mlog.Info("Worker: Purged archived channels", mlog.String("job-name", jobName), mlog.Int("count", purged))
+
+		if err := multipleErrors.ErrorOrNil(); err != nil {
+			mlog.Warn("Worker: errors occurred", mlog.String("job-name", jobName), mlog.Err(err))
+		}
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
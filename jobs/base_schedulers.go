@@ -38,6 +38,33 @@ func (scheduler *PeriodicScheduler) ScheduleJob(_ *model.Config /* pendingJobs *
 	return scheduler.jobs.CreateJob(scheduler.jobType, nil)
 }
 
+// DueScheduler is a PeriodicScheduler which only creates a job when isDue reports that there is work
+// waiting for it, so that checking often doesn't fill the jobs table with jobs that do nothing.
+type DueScheduler struct {
+	*PeriodicScheduler
+	isDue func() (bool, *model.AppError)
+}
+
+func NewDueScheduler(jobs *JobServer, jobType string, period time.Duration, enabledFunc func(cfg *model.Config) bool, isDue func() (bool, *model.AppError)) *DueScheduler {
+	return &DueScheduler{
+		PeriodicScheduler: NewPeriodicScheduler(jobs, jobType, period, enabledFunc),
+		isDue:             isDue,
+	}
+}
+
+func (scheduler *DueScheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	if pendingJobs {
+		return nil, nil
+	}
+
+	due, err := scheduler.isDue()
+	if err != nil || !due {
+		return nil, err
+	}
+
+	return scheduler.PeriodicScheduler.ScheduleJob(cfg, pendingJobs, lastSuccessfulJob)
+}
+
 type DailyScheduler struct {
 	jobs          *JobServer
 	startTimeFunc func(cfg *model.Config) *time.Time
@@ -6,6 +6,8 @@ package jobs
 import (
 	"net/http"
 
+	"github.com/wiggin77/merror"
+
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -33,6 +35,43 @@ func NewSimpleWorker(name string, jobServer *JobServer, execute func(job *model.
 	return &worker
 }
 
+// NewDueItemsWorker returns a SimpleWorker whose jobs process the items that are due when the job
+// starts, in batches of up to batchSize. processBatch handles the next batch, returning how many items
+// it found along with the errors of those it failed to process. Items that failed are still due, so
+// the job stops after a batch with failures rather than fetching them again, and leaves them to the
+// next job.
+func NewDueItemsWorker(name string, jobServer *JobServer, batchSize int, processBatch func(now int64, batchSize int) (int, []error, error), isEnabled func(cfg *model.Config) bool) *SimpleWorker {
+	execute := func(job *model.Job) error {
+		now := model.GetMillis()
+
+		multipleErrors := merror.New()
+		processed := 0
+		for {
+			count, errs, err := processBatch(now, batchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, err := range errs {
+				multipleErrors.Append(err)
+			}
+			processed += count - len(errs)
+
+			if count < batchSize || len(errs) > 0 {
+				break
+			}
+		}
+
+		mlog.Info("Worker: Processed due items", mlog.String("worker", name), mlog.Int("count", processed))
+
+		if err := multipleErrors.ErrorOrNil(); err != nil {
+			mlog.Warn("Worker: errors occurred", mlog.String("worker", name), mlog.Err(err))
+		}
+		return nil
+	}
+	return NewSimpleWorker(name, jobServer, execute, isEnabled)
+}
+
 func (worker *SimpleWorker) Run() {
 	mlog.Debug("Worker started", mlog.String("worker", worker.name))
 
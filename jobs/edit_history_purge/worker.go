@@ -0,0 +1,45 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package edit_history_purge
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/configservice"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const jobName = "EditHistoryPurge"
+
+func MakeWorker(jobServer *jobs.JobServer, config configservice.ConfigService, s store.Store) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.DataRetentionSettings.EnableEditHistoryDeletion
+	}
+	execute := func(job *model.Job) error {
+		retentionTime := time.Duration(*config.Config().DataRetentionSettings.EditHistoryRetentionDays) * 24 * time.Hour
+		endTime := model.GetMillisForTime(time.Now().Add(-retentionTime))
+		batchSize := int64(*config.Config().DataRetentionSettings.BatchSize)
+
+		var purged int64
+		for {
+			deleted, err := s.Post().PermanentDeleteEditHistoryBatch(endTime, batchSize)
+			if err != nil {
+				return err
+			}
+			purged += deleted
+
+			if deleted < batchSize {
+				break
+			}
+		}
+
+		mlog.Info("Worker: Purged edit history", mlog.String("job-name", jobName), mlog.Int64("count", purged))
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package post_reminders
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+const schedFreq = time.Minute
+
+func MakeScheduler(jobServer *jobs.JobServer) model.Scheduler {
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	isDue := func() (bool, *model.AppError) {
+		reminders, err := jobServer.Store.PostReminder().GetDue(model.GetMillis(), 1)
+		if err != nil {
+			return false, model.NewAppError("MakeScheduler", "app.post_reminder.get_due.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		return len(reminders) > 0, nil
+	}
+	return jobs.NewDueScheduler(jobServer, model.JobTypePostReminders, schedFreq, isEnabled, isDue)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package post_reminders
+
+import (
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/configservice"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const (
+	jobName   = "PostReminders"
+	batchSize = 100
+)
+
+type AppIface interface {
+	configservice.ConfigService
+	SendPostReminder(c *request.Context, reminder *model.PostReminder) *model.AppError
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface, s store.Store) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	processBatch := func(now int64, limit int) (int, []error, error) {
+		reminders, err := s.PostReminder().GetDue(now, limit)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		appContext := request.EmptyContext()
+		var errs []error
+		for _, reminder := range reminders {
+			if appErr := app.SendPostReminder(appContext, reminder); appErr != nil {
+				mlog.Debug("Worker: Failed to send post reminder",
+					mlog.Err(appErr), mlog.String("post_id", reminder.PostId), mlog.String("user_id", reminder.UserId))
+				errs = append(errs, appErr)
+			}
+		}
+		return len(reminders), errs, nil
+	}
+	worker := jobs.NewDueItemsWorker(jobName, jobServer, batchSize, processBatch, isEnabled)
+	return worker
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package scheduled_channel_deletions
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+const schedFreq = time.Minute
+
+func MakeScheduler(jobServer *jobs.JobServer) model.Scheduler {
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	isDue := func() (bool, *model.AppError) {
+		deletions, err := jobServer.Store.ScheduledChannelDeletion().GetDue(model.GetMillis(), 1)
+		if err != nil {
+			return false, model.NewAppError("MakeScheduler", "app.scheduled_channel_deletion.get_due.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		return len(deletions) > 0, nil
+	}
+	return jobs.NewDueScheduler(jobServer, model.JobTypeScheduledChannelDeletions, schedFreq, isEnabled, isDue)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package scheduled_channel_deletions
+
+import (
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/configservice"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const (
+	jobName   = "ScheduledChannelDeletions"
+	batchSize = 100
+)
+
+type AppIface interface {
+	configservice.ConfigService
+	ExecuteScheduledChannelDeletion(c *request.Context, deletion *model.ScheduledChannelDeletion) *model.AppError
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface, s store.Store) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	processBatch := func(now int64, limit int) (int, []error, error) {
+		deletions, err := s.ScheduledChannelDeletion().GetDue(now, limit)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		appContext := request.EmptyContext()
+		var errs []error
+		for _, deletion := range deletions {
+			if appErr := app.ExecuteScheduledChannelDeletion(appContext, deletion); appErr != nil {
+				mlog.Debug("Worker: Failed to archive channel scheduled for deletion",
+					mlog.Err(appErr), mlog.String("channel_id", deletion.ChannelId))
+				errs = append(errs, appErr)
+			}
+		}
+		return len(deletions), errs, nil
+	}
+	worker := jobs.NewDueItemsWorker(jobName, jobServer, batchSize, processBatch, isEnabled)
+	return worker
+}
@@ -3,6 +3,7 @@
 package jobs
 
 import (
+	"net/http"
 	"sync"
 	"testing"
 	"time"
@@ -143,3 +144,38 @@ func TestScheduler(t *testing.T) {
 		wg.Wait()
 	})
 }
+
+func TestDueScheduler(t *testing.T) {
+	jobServer, _, _ := makeJobServer(t)
+
+	t.Run("nothing due", func(t *testing.T) {
+		scheduler := NewDueScheduler(jobServer, model.JobTypePostReminders, time.Minute, func(*model.Config) bool { return true }, func() (bool, *model.AppError) {
+			return false, nil
+		})
+
+		job, appErr := scheduler.ScheduleJob(nil, false, nil)
+		assert.Nil(t, appErr)
+		assert.Nil(t, job)
+	})
+
+	t.Run("job already pending", func(t *testing.T) {
+		scheduler := NewDueScheduler(jobServer, model.JobTypePostReminders, time.Minute, func(*model.Config) bool { return true }, func() (bool, *model.AppError) {
+			t.Fatal("isDue shouldn't be checked while a job is pending")
+			return true, nil
+		})
+
+		job, appErr := scheduler.ScheduleJob(nil, true, nil)
+		assert.Nil(t, appErr)
+		assert.Nil(t, job)
+	})
+
+	t.Run("error checking for due items", func(t *testing.T) {
+		scheduler := NewDueScheduler(jobServer, model.JobTypePostReminders, time.Minute, func(*model.Config) bool { return true }, func() (bool, *model.AppError) {
+			return false, model.NewAppError("isDue", "app.post_reminder.get_due.app_error", nil, "", http.StatusInternalServerError)
+		})
+
+		job, appErr := scheduler.ScheduleJob(nil, false, nil)
+		expectErrorId(t, "app.post_reminder.get_due.app_error", appErr)
+		assert.Nil(t, job)
+	})
+}
@@ -690,15 +690,16 @@ func (h auditIncomingWebhook) IsNil() bool {
 }
 
 type auditOutgoingWebhook struct {
-	ID           string
-	ChannelID    string
-	TeamID       string
-	TriggerWords StringArray
-	TriggerWhen  int
-	DisplayName  string
-	Description  string
-	ContentType  string
-	Username     string
+	ID               string
+	ChannelID        string
+	TeamID           string
+	TriggerWords     StringArray
+	TriggerWhen      int
+	TriggerReactions StringArray
+	DisplayName      string
+	Description      string
+	ContentType      string
+	Username         string
 }
 
 // newAuditOutgoingWebhook creates a simplified representation of OutgoingWebhook for output to audit log.
@@ -710,6 +711,7 @@ func newAuditOutgoingWebhook(h *OutgoingWebhook) auditOutgoingWebhook {
 		hook.TeamID = h.TeamId
 		hook.TriggerWords = h.TriggerWords
 		hook.TriggerWhen = h.TriggerWhen
+		hook.TriggerReactions = h.TriggerReactions
 		hook.DisplayName = h.DisplayName
 		hook.Description = h.Description
 		hook.ContentType = h.ContentType
@@ -724,6 +726,7 @@ func (h auditOutgoingWebhook) MarshalJSONObject(enc *gojay.Encoder) {
 	enc.StringKey("team_id", h.TeamID)
 	enc.SliceStringKey("trigger_words", h.TriggerWords)
 	enc.IntKey("trigger_when", h.TriggerWhen)
+	enc.SliceStringKey("trigger_reactions", h.TriggerReactions)
 	enc.StringKey("display", h.DisplayName)
 	enc.StringKey("desc", h.Description)
 	enc.StringKey("content_type", h.ContentType)
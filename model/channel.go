@@ -10,6 +10,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"path/filepath"
 	"sort"
 	"strings"
 	"unicode/utf8"
@@ -32,6 +33,10 @@ const (
 	ChannelHeaderMaxRunes      = 1024
 	ChannelPurposeMaxRunes     = 250
 	ChannelCacheSize           = 25000
+	ChannelSlowModeMaxSeconds  = 6 * 60 * 60
+	ChannelAllowedReactionsMax = 50
+	ChannelFileExtensionsMax   = 100
+	ChannelFileExtensionMaxLen = 16
 
 	ChannelSortByUsername = "username"
 	ChannelSortByStatus   = "status"
@@ -59,6 +64,21 @@ type Channel struct {
 	TotalMsgCountRoot int64                  `json:"total_msg_count_root"`
 	PolicyID          *string                `json:"policy_id"`
 	LastRootPostAt    int64                  `json:"last_root_post_at"`
+	// SlowModeSeconds is the minimum number of seconds a member must wait between posts in the
+	// channel, or 0 if slow mode is disabled.
+	SlowModeSeconds int `json:"slow_mode_seconds"`
+	// DefaultNotifyLevel is the desktop and push notification level given to new members of the
+	// channel in place of their account settings, or empty to keep the account settings.
+	DefaultNotifyLevel string `json:"default_notify_level"`
+	// AllowedReactions holds the names of the emojis that posts in the channel can be reacted
+	// with, or is empty to allow any emoji.
+	AllowedReactions StringArray `json:"allowed_reactions"`
+	// AllowedFileExtensions holds the lowercase extensions, without the leading dot, of the only
+	// files that can be uploaded to the channel, or is empty to allow any extension that isn't blocked.
+	AllowedFileExtensions StringArray `json:"allowed_file_extensions"`
+	// BlockedFileExtensions holds the lowercase extensions, without the leading dot, of files that
+	// can't be uploaded to the channel.
+	BlockedFileExtensions StringArray `json:"blocked_file_extensions"`
 }
 
 type ChannelWithTeamData struct {
@@ -74,11 +94,16 @@ type ChannelsWithCount struct {
 }
 
 type ChannelPatch struct {
-	DisplayName      *string `json:"display_name"`
-	Name             *string `json:"name"`
-	Header           *string `json:"header"`
-	Purpose          *string `json:"purpose"`
-	GroupConstrained *bool   `json:"group_constrained"`
+	DisplayName           *string      `json:"display_name"`
+	Name                  *string      `json:"name"`
+	Header                *string      `json:"header"`
+	Purpose               *string      `json:"purpose"`
+	GroupConstrained      *bool        `json:"group_constrained"`
+	SlowModeSeconds       *int         `json:"slow_mode_seconds"`
+	DefaultNotifyLevel    *string      `json:"default_notify_level"`
+	AllowedReactions      *StringArray `json:"allowed_reactions"`
+	AllowedFileExtensions *StringArray `json:"allowed_file_extensions"`
+	BlockedFileExtensions *StringArray `json:"blocked_file_extensions"`
 }
 
 type ChannelForExport struct {
@@ -155,6 +180,33 @@ type ChannelMemberCountByGroup struct {
 	ChannelMemberTimezonesCount int64  `json:"channel_member_timezones_count"`
 }
 
+// ChannelMemberCountsByType splits the members of a channel into active members, active guests
+// and deactivated users of either kind.
+type ChannelMemberCountsByType struct {
+	MemberCount      int64 `json:"member_count"`
+	GuestCount       int64 `json:"guest_count"`
+	DeactivatedCount int64 `json:"deactivated_count"`
+}
+
+// DuplicateDirectChannels lists the direct channels that exist between the same pair of users. The
+// user ids are sorted, and are equal for a user's channel with themselves. The channel ids are ordered
+// by creation time, oldest first.
+type DuplicateDirectChannels struct {
+	UserIds    []string `json:"user_ids"`
+	ChannelIds []string `json:"channel_ids"`
+}
+
+// ChannelCreationInfo describes who created a channel and when. CreatorId comes from the audit record
+// of the channel's creation when AuditId is set, and from the channel itself otherwise.
+type ChannelCreationInfo struct {
+	ChannelId string `json:"channel_id"`
+	TeamId    string `json:"team_id"`
+	Name      string `json:"name"`
+	CreateAt  int64  `json:"create_at"`
+	CreatorId string `json:"creator_id"`
+	AuditId   string `json:"audit_id,omitempty"`
+}
+
 type ChannelOption func(channel *Channel)
 
 func WithID(ID string) ChannelOption {
@@ -237,6 +289,36 @@ func (o *Channel) IsValid() *AppError {
 		return NewAppError("Channel.IsValid", "model.channel.is_valid.creator_id.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if o.SlowModeSeconds < 0 || o.SlowModeSeconds > ChannelSlowModeMaxSeconds {
+		return NewAppError("Channel.IsValid", "model.channel.is_valid.slow_mode_seconds.app_error", map[string]interface{}{"MaxSeconds": ChannelSlowModeMaxSeconds}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.DefaultNotifyLevel != "" && (o.DefaultNotifyLevel == ChannelNotifyDefault || !IsChannelNotifyLevelValid(o.DefaultNotifyLevel)) {
+		return NewAppError("Channel.IsValid", "model.channel.is_valid.default_notify_level.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.AllowedReactions) > ChannelAllowedReactionsMax {
+		return NewAppError("Channel.IsValid", "model.channel.is_valid.allowed_reactions.app_error", map[string]interface{}{"Max": ChannelAllowedReactionsMax}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, emojiName := range o.AllowedReactions {
+		if emojiName == "" || len(emojiName) > EmojiNameMaxLength || !IsValidAlphaNumHyphenUnderscorePlus(emojiName) {
+			return NewAppError("Channel.IsValid", "model.channel.is_valid.allowed_reactions.app_error", map[string]interface{}{"Max": ChannelAllowedReactionsMax}, "id="+o.Id, http.StatusBadRequest)
+		}
+	}
+
+	for _, extensions := range []StringArray{o.AllowedFileExtensions, o.BlockedFileExtensions} {
+		if len(extensions) > ChannelFileExtensionsMax {
+			return NewAppError("Channel.IsValid", "model.channel.is_valid.file_extensions.app_error", map[string]interface{}{"Max": ChannelFileExtensionsMax}, "id="+o.Id, http.StatusBadRequest)
+		}
+
+		for _, extension := range extensions {
+			if !isValidChannelFileExtension(extension) {
+				return NewAppError("Channel.IsValid", "model.channel.is_valid.file_extension.app_error", map[string]interface{}{"Extension": extension}, "id="+o.Id, http.StatusBadRequest)
+			}
+		}
+	}
+
 	userIds := strings.Split(o.Name, "__")
 	if o.Type != ChannelTypeDirect && len(userIds) == 2 && IsValidId(userIds[0]) && IsValidId(userIds[1]) {
 		return NewAppError("Channel.IsValid", "model.channel.is_valid.name.app_error", nil, "", http.StatusBadRequest)
@@ -292,6 +374,68 @@ func (o *Channel) Patch(patch *ChannelPatch) {
 	if patch.GroupConstrained != nil {
 		o.GroupConstrained = patch.GroupConstrained
 	}
+
+	if patch.SlowModeSeconds != nil {
+		o.SlowModeSeconds = *patch.SlowModeSeconds
+	}
+
+	if patch.DefaultNotifyLevel != nil {
+		o.DefaultNotifyLevel = *patch.DefaultNotifyLevel
+	}
+
+	if patch.AllowedReactions != nil {
+		o.AllowedReactions = *patch.AllowedReactions
+	}
+
+	if patch.AllowedFileExtensions != nil {
+		o.AllowedFileExtensions = *patch.AllowedFileExtensions
+	}
+
+	if patch.BlockedFileExtensions != nil {
+		o.BlockedFileExtensions = *patch.BlockedFileExtensions
+	}
+}
+
+// ChangesRestrictions returns whether the patch changes any of the restrictions channel admins place
+// on the members: slow mode, the default notification level, the allowed reactions and the allowed or
+// blocked file extensions.
+func (p *ChannelPatch) ChangesRestrictions() bool {
+	return p.SlowModeSeconds != nil ||
+		p.DefaultNotifyLevel != nil ||
+		p.AllowedReactions != nil ||
+		p.AllowedFileExtensions != nil ||
+		p.BlockedFileExtensions != nil
+}
+
+// IsReactionAllowed returns whether posts in the channel can be reacted with the given emoji.
+func (o *Channel) IsReactionAllowed(emojiName string) bool {
+	return len(o.AllowedReactions) == 0 || o.AllowedReactions.Contains(emojiName)
+}
+
+// IsFileExtensionAllowed returns whether a file with the given name can be uploaded to the channel.
+// Files without an extension are only allowed when the channel doesn't have an allowlist.
+func (o *Channel) IsFileExtensionAllowed(filename string) bool {
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+
+	if extension != "" && o.BlockedFileExtensions.Contains(extension) {
+		return false
+	}
+
+	return len(o.AllowedFileExtensions) == 0 || (extension != "" && o.AllowedFileExtensions.Contains(extension))
+}
+
+func isValidChannelFileExtension(extension string) bool {
+	if extension == "" || len(extension) > ChannelFileExtensionMaxLen {
+		return false
+	}
+
+	for _, r := range extension {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (o *Channel) MakeNonNil() {
@@ -5,6 +5,7 @@ package model
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -19,6 +20,7 @@ const (
 	IgnoreChannelMentionsOff        = "off"
 	IgnoreChannelMentionsOn         = "on"
 	IgnoreChannelMentionsNotifyProp = "ignore_channel_mentions"
+	SnoozeUntilNotifyProp           = "snooze_until"
 )
 
 type ChannelUnread struct {
@@ -31,6 +33,13 @@ type ChannelUnread struct {
 	NotifyProps      StringMap `json:"-"`
 }
 
+// ChannelUnreadCount is the number of messages and mentions a user hasn't read in a channel.
+type ChannelUnreadCount struct {
+	ChannelId     string `json:"channel_id"`
+	MsgUnread     int64  `json:"msg_unread"`
+	MentionUnread int64  `json:"mention_unread"`
+}
+
 type ChannelUnreadAt struct {
 	TeamId           string    `json:"team_id"`
 	UserId           string    `json:"user_id"`
@@ -60,6 +69,20 @@ type ChannelMember struct {
 	ExplicitRoles    string    `json:"explicit_roles"`
 }
 
+// ChannelMemberTombstone marks that a user was removed from, or left, a channel.
+type ChannelMemberTombstone struct {
+	ChannelId string `json:"channel_id"`
+	UserId    string `json:"user_id"`
+	DeleteAt  int64  `json:"delete_at"`
+}
+
+// ChannelMembersDelta holds the changes to the memberships of a channel since a point in time: the
+// memberships that were created or updated, and tombstones for the ones that were removed.
+type ChannelMembersDelta struct {
+	Members ChannelMembers            `json:"members"`
+	Removed []*ChannelMemberTombstone `json:"removed"`
+}
+
 // The following are some GraphQL methods necessary to return the
 // data in float64 type. The spec doesn't support 64 bit integers,
 // so we have to pass the data in float64. The _ at the end is
@@ -98,6 +121,13 @@ type ChannelMembers []ChannelMember
 
 type ChannelMembersWithTeamData []ChannelMemberWithTeamData
 
+// ChannelMemberWithTeamMember is a channel member along with the team information and the user's
+// membership of the channel's team, so that both the channel and team roles are available.
+type ChannelMemberWithTeamMember struct {
+	ChannelMemberWithTeamData
+	TeamMember TeamMember `json:"team_member"`
+}
+
 type ChannelMemberForExport struct {
 	ChannelMember
 	ChannelName string
@@ -141,6 +171,12 @@ func (o *ChannelMember) IsValid() *AppError {
 		}
 	}
 
+	if snoozeUntil, ok := o.NotifyProps[SnoozeUntilNotifyProp]; ok && snoozeUntil != "" {
+		if until, err := strconv.ParseInt(snoozeUntil, 10, 64); err != nil || until < 0 {
+			return NewAppError("ChannelMember.IsValid", "model.channel_member.is_valid.snooze_until.app_error", nil, "snooze_until="+snoozeUntil, http.StatusBadRequest)
+		}
+	}
+
 	if len(o.Roles) > UserRolesMaxLength {
 		return NewAppError("ChannelMember.IsValid", "model.channel_member.is_valid.roles_limit.app_error",
 			map[string]interface{}{"Limit": UserRolesMaxLength}, "", http.StatusBadRequest)
@@ -173,6 +209,28 @@ func (o *ChannelMember) IsChannelMuted() bool {
 	return o.NotifyProps[MarkUnreadNotifyProp] == ChannelMarkUnreadMention
 }
 
+// IsChannelSnoozed reports whether the user has snoozed the notifications of the channel until a
+// time later than now.
+func (o *ChannelMember) IsChannelSnoozed(now int64) bool {
+	return IsChannelSnoozed(o.NotifyProps, now)
+}
+
+// IsChannelSnoozed reports whether the channel member notify props snooze the notifications of the
+// channel until a time later than now. Snoozes expire on their own once that time has passed.
+func IsChannelSnoozed(channelNotifyProps StringMap, now int64) bool {
+	snoozeUntil, ok := channelNotifyProps[SnoozeUntilNotifyProp]
+	if !ok || snoozeUntil == "" {
+		return false
+	}
+
+	until, err := strconv.ParseInt(snoozeUntil, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return until > now
+}
+
 func IsChannelNotifyLevelValid(notifyLevel string) bool {
 	return notifyLevel == ChannelNotifyDefault ||
 		notifyLevel == ChannelNotifyAll ||
@@ -201,3 +259,54 @@ func GetDefaultChannelNotifyProps() StringMap {
 		IgnoreChannelMentionsNotifyProp: IgnoreChannelMentionsDefault,
 	}
 }
+
+// GetDefaultChannelMemberNotifyProps returns the notify props of a new member of the channel, with
+// the desktop and push notification levels set to the channel's DefaultNotifyLevel if it has one.
+func GetDefaultChannelMemberNotifyProps(channel *Channel) StringMap {
+	props := GetDefaultChannelNotifyProps()
+	ApplyChannelDefaultNotifyLevel(props, channel)
+
+	return props
+}
+
+// ApplyChannelDefaultNotifyLevel sets the desktop and push notification levels of a member of the
+// channel that are still "default" to the channel's DefaultNotifyLevel, if it has one. Levels the
+// member chose are kept.
+func ApplyChannelDefaultNotifyLevel(props StringMap, channel *Channel) {
+	if channel.DefaultNotifyLevel == "" {
+		return
+	}
+
+	for _, prop := range []string{DesktopNotifyProp, PushNotifyProp} {
+		if value, ok := props[prop]; !ok || value == ChannelNotifyDefault {
+			props[prop] = channel.DefaultNotifyLevel
+		}
+	}
+}
+
+// ResolveChannelNotifyProps returns the notification settings in effect for a channel member, with
+// the channel settings that follow the user's account settings replaced by those account settings.
+func ResolveChannelNotifyProps(userNotifyProps, channelNotifyProps StringMap) StringMap {
+	resolved := GetDefaultChannelNotifyProps()
+	for key, value := range channelNotifyProps {
+		resolved[key] = value
+	}
+
+	for _, prop := range []string{DesktopNotifyProp, PushNotifyProp, EmailNotifyProp} {
+		if resolved[prop] == "" || resolved[prop] == ChannelNotifyDefault {
+			resolved[prop] = userNotifyProps[prop]
+		}
+	}
+
+	// Channel mentions are always ignored when the user has turned them off, and by default in muted channels.
+	ignoreChannelMentions := resolved[IgnoreChannelMentionsNotifyProp] == IgnoreChannelMentionsOn ||
+		userNotifyProps[ChannelMentionsNotifyProp] != "true" ||
+		(resolved[IgnoreChannelMentionsNotifyProp] != IgnoreChannelMentionsOff && resolved[MarkUnreadNotifyProp] == ChannelMarkUnreadMention)
+	if ignoreChannelMentions {
+		resolved[IgnoreChannelMentionsNotifyProp] = IgnoreChannelMentionsOn
+	} else {
+		resolved[IgnoreChannelMentionsNotifyProp] = IgnoreChannelMentionsOff
+	}
+
+	return resolved
+}
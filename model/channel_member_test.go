@@ -4,6 +4,7 @@
 package model
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -35,6 +36,125 @@ func TestChannelMemberIsValid(t *testing.T) {
 	o.NotifyProps["mark_unread"] = ChannelMarkUnreadAll
 	require.Nil(t, o.IsValid(), "should be valid")
 
+	o.NotifyProps[SnoozeUntilNotifyProp] = "junk"
+	require.NotNil(t, o.IsValid(), "should be invalid")
+
+	o.NotifyProps[SnoozeUntilNotifyProp] = "-1"
+	require.NotNil(t, o.IsValid(), "should be invalid")
+
+	o.NotifyProps[SnoozeUntilNotifyProp] = strconv.FormatInt(GetMillis(), 10)
+	require.Nil(t, o.IsValid(), "should be valid")
+
+	o.NotifyProps[SnoozeUntilNotifyProp] = ""
+	require.Nil(t, o.IsValid(), "should be valid")
+
 	o.Roles = ""
 	require.Nil(t, o.IsValid(), "should be invalid")
 }
+
+func TestGetDefaultChannelMemberNotifyProps(t *testing.T) {
+	t.Run("channel default", func(t *testing.T) {
+		props := GetDefaultChannelMemberNotifyProps(&Channel{DefaultNotifyLevel: ChannelNotifyMention})
+		require.Equal(t, ChannelNotifyMention, props[DesktopNotifyProp])
+		require.Equal(t, ChannelNotifyMention, props[PushNotifyProp])
+		require.Equal(t, ChannelNotifyDefault, props[EmailNotifyProp])
+	})
+
+	t.Run("no channel default", func(t *testing.T) {
+		require.Equal(t, GetDefaultChannelNotifyProps(), GetDefaultChannelMemberNotifyProps(&Channel{}))
+	})
+}
+
+func TestApplyChannelDefaultNotifyLevel(t *testing.T) {
+	channel := &Channel{DefaultNotifyLevel: ChannelNotifyNone}
+
+	t.Run("replaces default levels", func(t *testing.T) {
+		props := GetDefaultChannelNotifyProps()
+		ApplyChannelDefaultNotifyLevel(props, channel)
+		require.Equal(t, ChannelNotifyNone, props[DesktopNotifyProp])
+		require.Equal(t, ChannelNotifyNone, props[PushNotifyProp])
+	})
+
+	t.Run("keeps chosen levels", func(t *testing.T) {
+		props := GetDefaultChannelNotifyProps()
+		props[DesktopNotifyProp] = ChannelNotifyAll
+		ApplyChannelDefaultNotifyLevel(props, channel)
+		require.Equal(t, ChannelNotifyAll, props[DesktopNotifyProp])
+		require.Equal(t, ChannelNotifyNone, props[PushNotifyProp])
+	})
+
+	t.Run("no channel default", func(t *testing.T) {
+		props := GetDefaultChannelNotifyProps()
+		ApplyChannelDefaultNotifyLevel(props, &Channel{})
+		require.Equal(t, GetDefaultChannelNotifyProps(), props)
+	})
+}
+
+func TestChannelMemberIsChannelSnoozed(t *testing.T) {
+	now := GetMillis()
+
+	for name, tc := range map[string]struct {
+		snoozeUntil *string
+		expected    bool
+	}{
+		"no snooze":     {snoozeUntil: nil, expected: false},
+		"cleared":       {snoozeUntil: NewString(""), expected: false},
+		"in the future": {snoozeUntil: NewString(strconv.FormatInt(now+1000, 10)), expected: true},
+		"in the past":   {snoozeUntil: NewString(strconv.FormatInt(now-1000, 10)), expected: false},
+		"right now":     {snoozeUntil: NewString(strconv.FormatInt(now, 10)), expected: false},
+		"not a number":  {snoozeUntil: NewString("junk"), expected: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			o := ChannelMember{NotifyProps: GetDefaultChannelNotifyProps()}
+			if tc.snoozeUntil != nil {
+				o.NotifyProps[SnoozeUntilNotifyProp] = *tc.snoozeUntil
+			}
+			require.Equal(t, tc.expected, o.IsChannelSnoozed(now))
+			require.Equal(t, tc.expected, IsChannelSnoozed(o.NotifyProps, now))
+		})
+	}
+}
+
+func TestResolveChannelNotifyProps(t *testing.T) {
+	userNotifyProps := StringMap{
+		DesktopNotifyProp:         UserNotifyMention,
+		PushNotifyProp:            UserNotifyAll,
+		EmailNotifyProp:           "true",
+		ChannelMentionsNotifyProp: "true",
+	}
+
+	t.Run("defaults follow the account settings", func(t *testing.T) {
+		resolved := ResolveChannelNotifyProps(userNotifyProps, GetDefaultChannelNotifyProps())
+		require.Equal(t, UserNotifyMention, resolved[DesktopNotifyProp])
+		require.Equal(t, UserNotifyAll, resolved[PushNotifyProp])
+		require.Equal(t, "true", resolved[EmailNotifyProp])
+		require.Equal(t, ChannelMarkUnreadAll, resolved[MarkUnreadNotifyProp])
+		require.Equal(t, IgnoreChannelMentionsOff, resolved[IgnoreChannelMentionsNotifyProp])
+	})
+
+	t.Run("channel overrides are kept", func(t *testing.T) {
+		channelNotifyProps := GetDefaultChannelNotifyProps()
+		channelNotifyProps[DesktopNotifyProp] = ChannelNotifyNone
+		channelNotifyProps[EmailNotifyProp] = "false"
+		channelNotifyProps[SnoozeUntilNotifyProp] = "1000"
+
+		resolved := ResolveChannelNotifyProps(userNotifyProps, channelNotifyProps)
+		require.Equal(t, ChannelNotifyNone, resolved[DesktopNotifyProp])
+		require.Equal(t, UserNotifyAll, resolved[PushNotifyProp])
+		require.Equal(t, "false", resolved[EmailNotifyProp])
+		require.Equal(t, "1000", resolved[SnoozeUntilNotifyProp])
+		require.Equal(t, ChannelNotifyDefault, channelNotifyProps[PushNotifyProp])
+	})
+
+	t.Run("channel mentions", func(t *testing.T) {
+		muted := GetDefaultChannelNotifyProps()
+		muted[MarkUnreadNotifyProp] = ChannelMarkUnreadMention
+		require.Equal(t, IgnoreChannelMentionsOn, ResolveChannelNotifyProps(userNotifyProps, muted)[IgnoreChannelMentionsNotifyProp])
+
+		muted[IgnoreChannelMentionsNotifyProp] = IgnoreChannelMentionsOff
+		require.Equal(t, IgnoreChannelMentionsOff, ResolveChannelNotifyProps(userNotifyProps, muted)[IgnoreChannelMentionsNotifyProp])
+
+		noChannelMentions := StringMap{ChannelMentionsNotifyProp: "false"}
+		require.Equal(t, IgnoreChannelMentionsOn, ResolveChannelNotifyProps(noChannelMentions, muted)[IgnoreChannelMentionsNotifyProp])
+	})
+}
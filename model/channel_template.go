@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	ChannelTemplateNameMaxRunes      = 64
+	ChannelTemplateMaxPinnedMessages = 20
+)
+
+// ChannelTemplate holds the settings applied to the channels created from it in a team,
+// along with the messages posted and pinned in each of them.
+type ChannelTemplate struct {
+	Id             string      `json:"id"`
+	TeamId         string      `json:"team_id"`
+	Name           string      `json:"name"`
+	Type           ChannelType `json:"type"`
+	Purpose        string      `json:"purpose"`
+	Header         string      `json:"header"`
+	PinnedMessages StringArray `json:"pinned_messages"`
+	CreatorId      string      `json:"creator_id"`
+	CreateAt       int64       `json:"create_at"`
+	UpdateAt       int64       `json:"update_at"`
+}
+
+func (t *ChannelTemplate) PreSave() {
+	if t.Id == "" {
+		t.Id = NewId()
+	}
+
+	if t.Type == "" {
+		t.Type = ChannelTypeOpen
+	}
+
+	if t.PinnedMessages == nil {
+		t.PinnedMessages = StringArray{}
+	}
+
+	t.CreateAt = GetMillis()
+	t.UpdateAt = t.CreateAt
+}
+
+func (t *ChannelTemplate) IsValid() *AppError {
+	if !IsValidId(t.Id) {
+		return NewAppError("ChannelTemplate.IsValid", "model.channel_template.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(t.TeamId) {
+		return NewAppError("ChannelTemplate.IsValid", "model.channel_template.is_valid.team_id.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.Name == "" || utf8.RuneCountInString(t.Name) > ChannelTemplateNameMaxRunes {
+		return NewAppError("ChannelTemplate.IsValid", "model.channel_template.is_valid.name.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.Type != ChannelTypeOpen && t.Type != ChannelTypePrivate {
+		return NewAppError("ChannelTemplate.IsValid", "model.channel_template.is_valid.type.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(t.Purpose) > ChannelPurposeMaxRunes {
+		return NewAppError("ChannelTemplate.IsValid", "model.channel_template.is_valid.purpose.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(t.Header) > ChannelHeaderMaxRunes {
+		return NewAppError("ChannelTemplate.IsValid", "model.channel_template.is_valid.header.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if len(t.PinnedMessages) > ChannelTemplateMaxPinnedMessages {
+		return NewAppError("ChannelTemplate.IsValid", "model.channel_template.is_valid.pinned_messages.app_error",
+			map[string]interface{}{"Max": ChannelTemplateMaxPinnedMessages}, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(t.CreatorId) {
+		return NewAppError("ChannelTemplate.IsValid", "model.channel_template.is_valid.creator_id.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.CreateAt == 0 {
+		return NewAppError("ChannelTemplate.IsValid", "model.channel_template.is_valid.create_at.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.UpdateAt == 0 {
+		return NewAppError("ChannelTemplate.IsValid", "model.channel_template.is_valid.update_at.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelTemplateIsValid(t *testing.T) {
+	template := &ChannelTemplate{
+		TeamId:    NewId(),
+		Name:      "Incident",
+		CreatorId: NewId(),
+	}
+	template.PreSave()
+	require.Nil(t, template.IsValid())
+	require.Equal(t, ChannelTypeOpen, template.Type)
+	require.Equal(t, StringArray{}, template.PinnedMessages)
+
+	template.Name = ""
+	require.NotNil(t, template.IsValid())
+
+	template.Name = strings.Repeat("a", ChannelTemplateNameMaxRunes+1)
+	require.NotNil(t, template.IsValid())
+
+	template.Name = "Incident"
+	template.Type = ChannelTypeDirect
+	require.NotNil(t, template.IsValid())
+
+	template.Type = ChannelTypePrivate
+	template.Purpose = strings.Repeat("a", ChannelPurposeMaxRunes+1)
+	require.NotNil(t, template.IsValid())
+
+	template.Purpose = "Handling an incident"
+	template.PinnedMessages = make(StringArray, ChannelTemplateMaxPinnedMessages+1)
+	require.NotNil(t, template.IsValid())
+
+	template.PinnedMessages = StringArray{"Checklist"}
+	require.Nil(t, template.IsValid())
+}
@@ -18,12 +18,17 @@ func TestChannelCopy(t *testing.T) {
 }
 
 func TestChannelPatch(t *testing.T) {
-	p := &ChannelPatch{Name: new(string), DisplayName: new(string), Header: new(string), Purpose: new(string), GroupConstrained: new(bool)}
+	p := &ChannelPatch{Name: new(string), DisplayName: new(string), Header: new(string), Purpose: new(string), GroupConstrained: new(bool), SlowModeSeconds: new(int), DefaultNotifyLevel: new(string), AllowedReactions: new(StringArray), AllowedFileExtensions: new(StringArray), BlockedFileExtensions: new(StringArray)}
 	*p.Name = NewId()
 	*p.DisplayName = NewId()
 	*p.Header = NewId()
 	*p.Purpose = NewId()
 	*p.GroupConstrained = true
+	*p.SlowModeSeconds = 30
+	*p.DefaultNotifyLevel = ChannelNotifyMention
+	*p.AllowedReactions = StringArray{"+1", "-1"}
+	*p.AllowedFileExtensions = StringArray{"pdf", "png"}
+	*p.BlockedFileExtensions = StringArray{"exe"}
 
 	o := Channel{Id: NewId(), Name: NewId()}
 	o.Patch(p)
@@ -33,6 +38,11 @@ func TestChannelPatch(t *testing.T) {
 	require.Equal(t, *p.Header, o.Header)
 	require.Equal(t, *p.Purpose, o.Purpose)
 	require.Equal(t, *p.GroupConstrained, *o.GroupConstrained)
+	require.Equal(t, *p.SlowModeSeconds, o.SlowModeSeconds)
+	require.Equal(t, *p.DefaultNotifyLevel, o.DefaultNotifyLevel)
+	require.Equal(t, *p.AllowedReactions, o.AllowedReactions)
+	require.Equal(t, *p.AllowedFileExtensions, o.AllowedFileExtensions)
+	require.Equal(t, *p.BlockedFileExtensions, o.BlockedFileExtensions)
 }
 
 func TestChannelIsValid(t *testing.T) {
@@ -79,6 +89,86 @@ func TestChannelIsValid(t *testing.T) {
 
 	o.Purpose = strings.Repeat("0123456789", 25)
 	require.Nil(t, o.IsValid())
+
+	o.SlowModeSeconds = -1
+	require.NotNil(t, o.IsValid())
+
+	o.SlowModeSeconds = ChannelSlowModeMaxSeconds + 1
+	require.NotNil(t, o.IsValid())
+
+	o.SlowModeSeconds = ChannelSlowModeMaxSeconds
+	require.Nil(t, o.IsValid())
+
+	o.DefaultNotifyLevel = "junk"
+	require.NotNil(t, o.IsValid())
+
+	o.DefaultNotifyLevel = ChannelNotifyDefault
+	require.NotNil(t, o.IsValid())
+
+	o.DefaultNotifyLevel = ChannelNotifyMention
+	require.Nil(t, o.IsValid())
+
+	o.AllowedReactions = StringArray{"+1", "not an emoji"}
+	require.NotNil(t, o.IsValid())
+
+	o.AllowedReactions = make(StringArray, ChannelAllowedReactionsMax+1)
+	for i := range o.AllowedReactions {
+		o.AllowedReactions[i] = "+1"
+	}
+	require.NotNil(t, o.IsValid())
+
+	o.AllowedReactions = StringArray{"+1", "-1"}
+	require.Nil(t, o.IsValid())
+
+	o.AllowedFileExtensions = StringArray{".pdf"}
+	appErr := o.IsValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.channel.is_valid.file_extension.app_error", appErr.Id)
+
+	o.AllowedFileExtensions = StringArray{"PDF"}
+	require.NotNil(t, o.IsValid())
+
+	o.AllowedFileExtensions = StringArray{"pdf"}
+	o.BlockedFileExtensions = StringArray{""}
+	require.NotNil(t, o.IsValid())
+
+	o.BlockedFileExtensions = make(StringArray, ChannelFileExtensionsMax+1)
+	for i := range o.BlockedFileExtensions {
+		o.BlockedFileExtensions[i] = "exe"
+	}
+	appErr = o.IsValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.channel.is_valid.file_extensions.app_error", appErr.Id)
+
+	o.BlockedFileExtensions = StringArray{"exe"}
+	require.Nil(t, o.IsValid())
+}
+
+func TestChannelIsReactionAllowed(t *testing.T) {
+	o := Channel{}
+	require.True(t, o.IsReactionAllowed("smile"))
+
+	o.AllowedReactions = StringArray{"+1", "-1"}
+	require.True(t, o.IsReactionAllowed("+1"))
+	require.True(t, o.IsReactionAllowed("-1"))
+	require.False(t, o.IsReactionAllowed("smile"))
+}
+
+func TestChannelIsFileExtensionAllowed(t *testing.T) {
+	o := Channel{}
+	require.True(t, o.IsFileExtensionAllowed("report.pdf"))
+	require.True(t, o.IsFileExtensionAllowed("README"))
+
+	o.BlockedFileExtensions = StringArray{"exe"}
+	require.False(t, o.IsFileExtensionAllowed("setup.exe"))
+	require.False(t, o.IsFileExtensionAllowed("SETUP.EXE"))
+	require.True(t, o.IsFileExtensionAllowed("README"))
+
+	o.AllowedFileExtensions = StringArray{"pdf", "exe"}
+	require.True(t, o.IsFileExtensionAllowed("report.PDF"))
+	require.False(t, o.IsFileExtensionAllowed("setup.exe"))
+	require.False(t, o.IsFileExtensionAllowed("image.png"))
+	require.False(t, o.IsFileExtensionAllowed("README"))
 }
 
 func TestChannelPreSave(t *testing.T) {
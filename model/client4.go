@@ -292,6 +292,14 @@ func (c *Client4) postRoute(postId string) string {
 	return fmt.Sprintf(c.postsRoute()+"/%v", postId)
 }
 
+func (c *Client4) postReportsRoute() string {
+	return "/post_reports"
+}
+
+func (c *Client4) postReportRoute(reportId string) string {
+	return fmt.Sprintf("%s/%s", c.postReportsRoute(), reportId)
+}
+
 func (c *Client4) filesRoute() string {
 	return "/files"
 }
@@ -3391,6 +3399,24 @@ func (c *Client4) GetChannelMembers(channelId string, page, perPage int, etag st
 	return ch, BuildResponse(r), nil
 }
 
+// GetChannelMembersSince gets a page of the channel members that were added or updated at or after
+// the given time, along with a page of tombstones for the members that were removed since.
+func (c *Client4) GetChannelMembersSince(channelId string, since int64, page, perPage int) (*ChannelMembersDelta, *Response, error) {
+	query := fmt.Sprintf("?since=%v&page=%v&per_page=%v", since, page, perPage)
+	r, err := c.DoAPIGet(c.channelMembersRoute(channelId)+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var delta ChannelMembersDelta
+	err = json.NewDecoder(r.Body).Decode(&delta)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("GetChannelMembersSince", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &delta, BuildResponse(r), nil
+}
+
 // GetChannelMembersWithTeamData gets a page of all channel members for a user.
 func (c *Client4) GetChannelMembersWithTeamData(userID string, page, perPage int) (ChannelMembersWithTeamData, *Response, error) {
 	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
@@ -3408,6 +3434,43 @@ func (c *Client4) GetChannelMembersWithTeamData(userID string, page, perPage int
 	return ch, BuildResponse(r), nil
 }
 
+// GetResolvedNotifyProps returns, keyed by channel id, the notification settings in effect for the
+// user in a page of their channels, with the channel settings that follow the user's account settings
+// replaced by those account settings.
+func (c *Client4) GetResolvedNotifyProps(userID string, page, perPage int) (map[string]StringMap, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(c.userRoute(userID)+"/notify_props/resolved"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var resolved map[string]StringMap
+	err = json.NewDecoder(r.Body).Decode(&resolved)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("GetResolvedNotifyProps", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return resolved, BuildResponse(r), nil
+}
+
+// SearchChannelMembersForUser returns a page of the user's memberships in channels across all teams whose
+// name or display name matches the term. Must be authenticated as an administrator.
+func (c *Client4) SearchChannelMembersForUser(userID, term string, page, perPage int) (ChannelMembersWithTeamData, *Response, error) {
+	query := fmt.Sprintf("?search=%v&page=%v&per_page=%v", url.QueryEscape(term), page, perPage)
+	r, err := c.DoAPIGet(c.userRoute(userID)+"/channels"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var ch ChannelMembersWithTeamData
+	err = json.NewDecoder(r.Body).Decode(&ch)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("SearchChannelMembersForUser", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return ch, BuildResponse(r), nil
+}
+
 // GetChannelMembersByIds gets the channel members in a channel for a list of user ids.
 func (c *Client4) GetChannelMembersByIds(channelId string, userIds []string) (ChannelMembers, *Response, error) {
 	r, err := c.DoAPIPost(c.channelMembersRoute(channelId)+"/ids", ArrayToJSON(userIds))
@@ -3762,6 +3825,94 @@ func (c *Client4) UnpinPost(postId string) (*Response, error) {
 	return BuildResponse(r), nil
 }
 
+// ReportPost reports a post for moderation by the system admins.
+func (c *Client4) ReportPost(postId, reason string) (*PostReport, *Response, error) {
+	r, err := c.DoAPIPost(c.postRoute(postId)+"/report", MapToJSON(map[string]string{"reason": reason}))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var report PostReport
+	if jsonErr := json.NewDecoder(r.Body).Decode(&report); jsonErr != nil {
+		return nil, nil, NewAppError("ReportPost", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
+// GetPostReports returns a page of post reports, optionally filtered by status.
+// Must be authenticated as a system admin.
+func (c *Client4) GetPostReports(status string, page, perPage int) ([]*PostReportWithContext, *Response, error) {
+	query := fmt.Sprintf("?status=%v&page=%v&per_page=%v", url.QueryEscape(status), page, perPage)
+	r, err := c.DoAPIGet(c.postReportsRoute()+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var reports []*PostReportWithContext
+	if jsonErr := json.NewDecoder(r.Body).Decode(&reports); jsonErr != nil {
+		return nil, nil, NewAppError("GetPostReports", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return reports, BuildResponse(r), nil
+}
+
+// UpdatePostReportStatus sets the status of a post report.
+// Must be authenticated as a system admin.
+func (c *Client4) UpdatePostReportStatus(reportId, status string) (*PostReport, *Response, error) {
+	r, err := c.DoAPIPut(c.postReportRoute(reportId)+"/status", MapToJSON(map[string]string{"status": status}))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var report PostReport
+	if jsonErr := json.NewDecoder(r.Body).Decode(&report); jsonErr != nil {
+		return nil, nil, NewAppError("UpdatePostReportStatus", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
+// SetPostReminder schedules a direct message to the user about the post at the given time, in
+// milliseconds since the epoch.
+func (c *Client4) SetPostReminder(userId, postId string, remindAt int64) (*PostReminder, *Response, error) {
+	buf, err := json.Marshal(map[string]int64{"remind_at": remindAt})
+	if err != nil {
+		return nil, nil, NewAppError("SetPostReminder", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.userRoute(userId)+c.postRoute(postId)+"/reminder", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var reminder PostReminder
+	if jsonErr := json.NewDecoder(r.Body).Decode(&reminder); jsonErr != nil {
+		return nil, nil, NewAppError("SetPostReminder", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &reminder, BuildResponse(r), nil
+}
+
+// GetPostReminders returns the user's pending post reminders in the order that they are due.
+func (c *Client4) GetPostReminders(userId string) ([]*PostReminder, *Response, error) {
+	r, err := c.DoAPIGet(c.userRoute(userId)+"/post_reminders", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var reminders []*PostReminder
+	if jsonErr := json.NewDecoder(r.Body).Decode(&reminders); jsonErr != nil {
+		return nil, nil, NewAppError("GetPostReminders", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return reminders, BuildResponse(r), nil
+}
+
+// ClearPostReminder removes the user's pending reminder for the post.
+func (c *Client4) ClearPostReminder(userId, postId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.userRoute(userId) + c.postRoute(postId) + "/reminder")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GetPost gets a single post.
 func (c *Client4) GetPost(postId string, etag string) (*Post, *Response, error) {
 	r, err := c.DoAPIGet(c.postRoute(postId), etag)
@@ -4355,6 +4506,16 @@ func (c *Client4) GetFileInfo(fileId string) (*FileInfo, *Response, error) {
 	return &fi, BuildResponse(r), nil
 }
 
+// ClearFileQuarantine allows a file quarantined by the virus scanner to be downloaded again.
+func (c *Client4) ClearFileQuarantine(fileId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.fileRoute(fileId) + "/quarantine")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GetFileInfosForPost gets all the file info objects attached to a post.
 func (c *Client4) GetFileInfosForPost(postId string, etag string) ([]*FileInfo, *Response, error) {
 	r, err := c.DoAPIGet(c.postRoute(postId)+"/files/info", etag)
@@ -6447,6 +6608,24 @@ func (c *Client4) GetEmojiByName(name string) (*Emoji, *Response, error) {
 	return &e, BuildResponse(r), nil
 }
 
+// GetEmojisByNames returns the custom emojis with the given names, leaving out any names that don't match one.
+func (c *Client4) GetEmojisByNames(names []string) ([]*Emoji, *Response, error) {
+	buf, err := json.Marshal(names)
+	if err != nil {
+		return nil, nil, NewAppError("GetEmojisByNames", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.emojisRoute()+"/names", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*Emoji
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("GetEmojisByNames", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return list, BuildResponse(r), nil
+}
+
 // GetEmojiImage returns the emoji image.
 func (c *Client4) GetEmojiImage(emojiId string) ([]byte, *Response, error) {
 	r, err := c.DoAPIGet(c.emojiRoute(emojiId)+"/image", "")
@@ -6530,6 +6709,22 @@ func (c *Client4) GetReactions(postId string) ([]*Reaction, *Response, error) {
 	return list, BuildResponse(r), nil
 }
 
+// GetReactionDetails returns the reactions to a post grouped by emoji, each with a page of the users
+// who reacted with it in the order that they did so.
+func (c *Client4) GetReactionDetails(postId string, page, perPage int) ([]*ReactionDetails, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(c.postRoute(postId)+"/reactions/detailed"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*ReactionDetails
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("GetReactionDetails", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return list, BuildResponse(r), nil
+}
+
 // DeleteReaction deletes reaction of a user in a post.
 func (c *Client4) DeleteReaction(reaction *Reaction) (*Response, error) {
 	r, err := c.DoAPIDelete(c.userRoute(reaction.UserId) + c.postRoute(reaction.PostId) + fmt.Sprintf("/reactions/%v", reaction.EmojiName))
@@ -6601,6 +6796,20 @@ func (c *Client4) GetSupportedTimezone() ([]string, *Response, error) {
 	return timezones, BuildResponse(r), nil
 }
 
+// GetServerTime returns the current time and timezone of the server.
+func (c *Client4) GetServerTime() (*ServerTime, *Response, error) {
+	r, err := c.DoAPIGet(c.systemRoute()+"/time", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var serverTime ServerTime
+	if jsonErr := json.NewDecoder(r.Body).Decode(&serverTime); jsonErr != nil {
+		return nil, nil, NewAppError("GetServerTime", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &serverTime, BuildResponse(r), nil
+}
+
 // Open Graph Metadata Section
 
 // OpenGraph return the open graph metadata for a particular url if the site have the metadata.
@@ -7568,6 +7777,27 @@ func (c *Client4) UpdateSidebarCategoriesForTeamForUser(userID, teamID string, c
 	return cat, BuildResponse(r), nil
 }
 
+// UpdateSidebarCategoriesAndOrderForTeamForUser replaces the user's sidebar categories on the team, including
+// their order, in a single request.
+func (c *Client4) UpdateSidebarCategoriesAndOrderForTeamForUser(userID, teamID string, categories *OrderedSidebarCategories) (*OrderedSidebarCategories, *Response, error) {
+	payload, _ := json.Marshal(categories)
+	route := c.userCategoryRoute(userID, teamID) + "/ordered"
+
+	r, err := c.DoAPIPutBytes(route, payload)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var cat *OrderedSidebarCategories
+	err = json.NewDecoder(r.Body).Decode(&cat)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("Client4.UpdateSidebarCategoriesAndOrderForTeamForUser", "model.utils.decode_json.app_error", nil, err.Error(), r.StatusCode)
+	}
+
+	return cat, BuildResponse(r), nil
+}
+
 func (c *Client4) GetSidebarCategoryOrderForTeamForUser(userID, teamID, etag string) ([]string, *Response, error) {
 	route := c.userCategoryRoute(userID, teamID) + "/order"
 	r, err := c.DoAPIGet(route, etag)
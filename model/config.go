@@ -17,6 +17,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mattermost/ldap"
 
@@ -88,6 +89,11 @@ const (
 	CollapsedThreadsDefaultOff = "default_off"
 	CollapsedThreadsAlwaysOn   = "always_on"
 
+	LoginLockoutScopeAccount = "account"
+	// LoginLockoutScopeIP locks out client IP addresses instead of accounts. The failed attempts of
+	// an address are tracked in memory by each server of a cluster separately.
+	LoginLockoutScopeIP = "ip"
+
 	EmailBatchingBufferSize = 256
 	EmailBatchingInterval   = 30
 
@@ -210,6 +216,9 @@ const (
 	DataRetentionSettingsDefaultDeletionJobStartTime = "02:00"
 	DataRetentionSettingsDefaultBatchSize            = 3000
 
+	DataRetentionSettingsDefaultArchivedChannelRetentionDays = 365
+	DataRetentionSettingsDefaultEditHistoryRetentionDays     = 365
+
 	PluginSettingsDefaultDirectory         = "./plugins"
 	PluginSettingsDefaultClientDirectory   = "./client/plugins"
 	PluginSettingsDefaultEnableMarketplace = true
@@ -250,6 +259,10 @@ func GetDefaultAppCustomURLSchemes() []string {
 	return []string{"mmauth://", "mmauthbeta://"}
 }
 
+func GetDefaultPostReportReasons() []string {
+	return []string{"spam", "harassment", "inappropriate", "other"}
+}
+
 var ServerTLSSupportedCiphers = map[string]uint16{
 	"TLS_RSA_WITH_RC4_128_SHA":                tls.TLS_RSA_WITH_RC4_128_SHA,
 	"TLS_RSA_WITH_3DES_EDE_CBC_SHA":           tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA,
@@ -296,10 +309,14 @@ type ServiceSettings struct {
 	WriteTimeout                        *int     `access:"environment_web_server,write_restrictable,cloud_restrictable"`
 	IdleTimeout                         *int     `access:"write_restrictable,cloud_restrictable"`
 	MaximumLoginAttempts                *int     `access:"authentication_password,write_restrictable,cloud_restrictable"`
+	LoginLockoutDurationInMinutes       *int     `access:"authentication_password,write_restrictable,cloud_restrictable"`
+	LoginLockoutScope                   *string  `access:"authentication_password,write_restrictable,cloud_restrictable"`
 	GoroutineHealthThreshold            *int     `access:"write_restrictable,cloud_restrictable"` // telemetry: none
 	EnableOAuthServiceProvider          *bool    `access:"integrations_integration_management"`
 	EnableIncomingWebhooks              *bool    `access:"integrations_integration_management"`
+	IncomingWebhookChannelAllowlist     []string `access:"integrations_integration_management"`
 	EnableOutgoingWebhooks              *bool    `access:"integrations_integration_management"`
+	OutgoingWebhookPayloadTemplate      *string  `access:"integrations_integration_management"`
 	EnableCommands                      *bool    `access:"integrations_integration_management"`
 	EnablePostUsernameOverride          *bool    `access:"integrations_integration_management"`
 	EnablePostIconOverride              *bool    `access:"integrations_integration_management"`
@@ -307,6 +324,7 @@ type ServiceSettings struct {
 	EnableLinkPreviews                  *bool    `access:"site_posts"`
 	EnablePermalinkPreviews             *bool    `access:"site_posts"`
 	RestrictLinkPreviews                *string  `access:"site_posts"`
+	LinkPreviewAllowlist                *string  `access:"site_posts"`
 	EnableTesting                       *bool    `access:"environment_developer,write_restrictable,cloud_restrictable"`
 	EnableDeveloper                     *bool    `access:"environment_developer,write_restrictable,cloud_restrictable"`
 	DeveloperFlags                      *string  `access:"environment_developer"`
@@ -334,19 +352,30 @@ type ServiceSettings struct {
 	// Deprecated
 	SessionLengthSSOInDays  *int `access:"environment_session_lengths,write_restrictable,cloud_restrictable"` // telemetry: none
 	SessionLengthSSOInHours *int `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	// Session lengths for specific authentication methods. Zero uses SessionLengthSSOInHours for SAML
+	// and OAuth logins and SessionLengthWebInHours for LDAP logins.
+	SessionLengthSAMLInHours  *int `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	SessionLengthOAuthInHours *int `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	SessionLengthLDAPInHours  *int `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
 
 	SessionCacheInMinutes                             *int    `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
 	SessionIdleTimeoutInMinutes                       *int    `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
 	WebsocketSecurePort                               *int    `access:"write_restrictable,cloud_restrictable"` // telemetry: none
 	WebsocketPort                                     *int    `access:"write_restrictable,cloud_restrictable"` // telemetry: none
 	WebserverMode                                     *string `access:"environment_web_server,write_restrictable,cloud_restrictable"`
+	EnableWebSocketCompression                        *bool   `access:"environment_web_server,write_restrictable,cloud_restrictable"`
 	EnableGifPicker                                   *bool   `access:"integrations_gif"`
 	GfycatAPIKey                                      *string `access:"integrations_gif"`
 	GfycatAPISecret                                   *string `access:"integrations_gif"`
 	EnableCustomEmoji                                 *bool   `access:"site_emoji"`
 	EnableEmojiPicker                                 *bool   `access:"site_emoji"`
 	PostEditTimeLimit                                 *int    `access:"user_management_permissions"`
+	PostDeleteTimeLimit                               *int    `access:"user_management_permissions"`
+	MaxPinnedPostsPerChannel                          *int    `access:"site_posts"`
+	EnablePinnedPostSystemMessage                     *bool   `access:"site_posts"`
+	PostTruncatedPreviewLength                        *int    `access:"site_posts"`
 	TimeBetweenUserTypingUpdatesMilliseconds          *int64  `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	TypingEventBroadcastIntervalMilliseconds          *int64  `access:"experimental_features,write_restrictable,cloud_restrictable"`
 	EnablePostSearch                                  *bool   `access:"write_restrictable,cloud_restrictable"`
 	EnableFileSearch                                  *bool   `access:"write_restrictable"`
 	MinimumHashtagLength                              *int    `access:"environment_database,write_restrictable,cloud_restrictable"`
@@ -381,6 +410,30 @@ type ServiceSettings struct {
 	CollapsedThreads                                  *string `access:"experimental_features"`
 	ManagedResourcePaths                              *string `access:"environment_web_server,write_restrictable,cloud_restrictable"`
 	EnableCustomGroups                                *bool   `access:"site_users_and_teams"`
+
+	// PostReportReasons is the set of reasons users can pick from when reporting a post. An
+	// empty list allows any free-form reason.
+	PostReportReasons []string `access:"site_posts"`
+
+	// SearchRateLimitPerMinute is the number of post and file searches each user can make per minute once
+	// their burst has been used up, or 0 to not limit searches.
+	SearchRateLimitPerMinute *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	// SearchRateLimitMaxBurst is the number of searches a user can make in quick succession before being limited.
+	SearchRateLimitMaxBurst *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+
+	// AutoPinEmojiName is the emoji that pins a post in one of AutoPinChannelIds once enough users
+	// have reacted to it with it.
+	AutoPinEmojiName *string `access:"site_posts"`
+	// AutoPinReactionThreshold is the number of AutoPinEmojiName reactions that pins a post, or 0 to
+	// not pin posts automatically.
+	AutoPinReactionThreshold *int `access:"site_posts"`
+	// AutoPinChannelIds is the set of channels in which posts are pinned automatically.
+	AutoPinChannelIds []string `access:"site_posts"` // telemetry: none
+
+	// EnableWelcomeMessage sends new users a direct message from the system bot the first time they log in.
+	EnableWelcomeMessage *bool `access:"site_users_and_teams"`
+	// WelcomeMessage is added to the localized greeting of the welcome message.
+	WelcomeMessage *string `access:"site_users_and_teams"`
 }
 
 func (s *ServiceSettings) SetDefaults(isUpdate bool) {
@@ -425,6 +478,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.RestrictLinkPreviews = NewString("")
 	}
 
+	if s.LinkPreviewAllowlist == nil {
+		s.LinkPreviewAllowlist = NewString("")
+	}
+
 	if s.EnableTesting == nil {
 		s.EnableTesting = NewBool(false)
 	}
@@ -485,10 +542,18 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnableIncomingWebhooks = NewBool(true)
 	}
 
+	if s.IncomingWebhookChannelAllowlist == nil {
+		s.IncomingWebhookChannelAllowlist = []string{}
+	}
+
 	if s.EnableOutgoingWebhooks == nil {
 		s.EnableOutgoingWebhooks = NewBool(true)
 	}
 
+	if s.OutgoingWebhookPayloadTemplate == nil {
+		s.OutgoingWebhookPayloadTemplate = NewString("")
+	}
+
 	if s.ConnectionSecurity == nil {
 		s.ConnectionSecurity = NewString("")
 	}
@@ -541,6 +606,14 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.MaximumLoginAttempts = NewInt(ServiceSettingsDefaultMaxLoginAttempts)
 	}
 
+	if s.LoginLockoutDurationInMinutes == nil {
+		s.LoginLockoutDurationInMinutes = NewInt(0)
+	}
+
+	if s.LoginLockoutScope == nil {
+		s.LoginLockoutScope = NewString(LoginLockoutScopeAccount)
+	}
+
 	if s.Forward80To443 == nil {
 		s.Forward80To443 = NewBool(false)
 	}
@@ -553,6 +626,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.TimeBetweenUserTypingUpdatesMilliseconds = NewInt64(5000)
 	}
 
+	if s.TypingEventBroadcastIntervalMilliseconds == nil {
+		s.TypingEventBroadcastIntervalMilliseconds = NewInt64(1000)
+	}
+
 	if s.EnablePostSearch == nil {
 		s.EnablePostSearch = NewBool(true)
 	}
@@ -652,6 +729,18 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.SessionLengthSSOInHours = NewInt(ssoTTLDays * 24)
 	}
 
+	if s.SessionLengthSAMLInHours == nil {
+		s.SessionLengthSAMLInHours = NewInt(0)
+	}
+
+	if s.SessionLengthOAuthInHours == nil {
+		s.SessionLengthOAuthInHours = NewInt(0)
+	}
+
+	if s.SessionLengthLDAPInHours == nil {
+		s.SessionLengthLDAPInHours = NewInt(0)
+	}
+
 	if s.SessionCacheInMinutes == nil {
 		s.SessionCacheInMinutes = NewInt(10)
 	}
@@ -706,6 +795,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		*s.WebserverMode = "gzip"
 	}
 
+	if s.EnableWebSocketCompression == nil {
+		s.EnableWebSocketCompression = NewBool(false)
+	}
+
 	if s.EnableCustomEmoji == nil {
 		s.EnableCustomEmoji = NewBool(true)
 	}
@@ -734,6 +827,54 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.PostEditTimeLimit = NewInt(-1)
 	}
 
+	if s.PostDeleteTimeLimit == nil {
+		s.PostDeleteTimeLimit = NewInt(-1)
+	}
+
+	if s.MaxPinnedPostsPerChannel == nil {
+		s.MaxPinnedPostsPerChannel = NewInt(0)
+	}
+
+	if s.EnablePinnedPostSystemMessage == nil {
+		s.EnablePinnedPostSystemMessage = NewBool(false)
+	}
+
+	if s.PostTruncatedPreviewLength == nil {
+		s.PostTruncatedPreviewLength = NewInt(0)
+	}
+
+	if s.PostReportReasons == nil {
+		s.PostReportReasons = GetDefaultPostReportReasons()
+	}
+
+	if s.SearchRateLimitPerMinute == nil {
+		s.SearchRateLimitPerMinute = NewInt(0)
+	}
+
+	if s.SearchRateLimitMaxBurst == nil {
+		s.SearchRateLimitMaxBurst = NewInt(10)
+	}
+
+	if s.AutoPinEmojiName == nil {
+		s.AutoPinEmojiName = NewString("pushpin")
+	}
+
+	if s.AutoPinReactionThreshold == nil {
+		s.AutoPinReactionThreshold = NewInt(0)
+	}
+
+	if s.AutoPinChannelIds == nil {
+		s.AutoPinChannelIds = []string{}
+	}
+
+	if s.EnableWelcomeMessage == nil {
+		s.EnableWelcomeMessage = NewBool(false)
+	}
+
+	if s.WelcomeMessage == nil {
+		s.WelcomeMessage = NewString("")
+	}
+
 	if s.EnablePreviewFeatures == nil {
 		s.EnablePreviewFeatures = NewBool(true)
 	}
@@ -1583,6 +1724,7 @@ type EmailSettings struct {
 	LoginButtonBorderColor            *string `access:"experimental_features"`
 	LoginButtonTextColor              *string `access:"experimental_features"`
 	EnableInactivityEmail             *bool
+	AnnouncementEmailsPerHour         *int `access:"site_notifications"`
 }
 
 func (s *EmailSettings) SetDefaults(isUpdate bool) {
@@ -1729,6 +1871,10 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 	if s.EnableInactivityEmail == nil {
 		s.EnableInactivityEmail = NewBool(true)
 	}
+
+	if s.AnnouncementEmailsPerHour == nil {
+		s.AnnouncementEmailsPerHour = NewInt(1)
+	}
 }
 
 type RateLimitSettings struct {
@@ -1945,7 +2091,10 @@ type TeamSettings struct {
 	// In seconds.
 	UserStatusAwayTimeout               *int64   `access:"experimental_features"`
 	MaxChannelsPerTeam                  *int64   `access:"site_users_and_teams"`
+	MaxChannelsPerUserPerTeam           *int64   `access:"site_users_and_teams"`
 	MaxNotificationsPerChannel          *int64   `access:"environment_push_notification_server"`
+	MaxExpandedMentionsPerPost          *int64   `access:"site_notifications"`
+	AllowAdminsToExceedExpandedMentions *bool    `access:"site_notifications"`
 	EnableConfirmNotificationsToChannel *bool    `access:"site_notifications"`
 	TeammateNameDisplay                 *string  `access:"site_users_and_teams"`
 	ExperimentalViewArchivedChannels    *bool    `access:"experimental_features,site_users_and_teams"`
@@ -2009,10 +2158,22 @@ func (s *TeamSettings) SetDefaults() {
 		s.MaxChannelsPerTeam = NewInt64(2000)
 	}
 
+	if s.MaxChannelsPerUserPerTeam == nil {
+		s.MaxChannelsPerUserPerTeam = NewInt64(0)
+	}
+
 	if s.MaxNotificationsPerChannel == nil {
 		s.MaxNotificationsPerChannel = NewInt64(1000)
 	}
 
+	if s.MaxExpandedMentionsPerPost == nil {
+		s.MaxExpandedMentionsPerPost = NewInt64(0)
+	}
+
+	if s.AllowAdminsToExceedExpandedMentions == nil {
+		s.AllowAdminsToExceedExpandedMentions = NewBool(false)
+	}
+
 	if s.EnableConfirmNotificationsToChannel == nil {
 		s.EnableConfirmNotificationsToChannel = NewBool(true)
 	}
@@ -2647,14 +2808,18 @@ func (bs *BleveSettings) SetDefaults() {
 }
 
 type DataRetentionSettings struct {
-	EnableMessageDeletion *bool   `access:"compliance_data_retention_policy"`
-	EnableFileDeletion    *bool   `access:"compliance_data_retention_policy"`
-	EnableBoardsDeletion  *bool   `access:"compliance_data_retention_policy"`
-	MessageRetentionDays  *int    `access:"compliance_data_retention_policy"`
-	FileRetentionDays     *int    `access:"compliance_data_retention_policy"`
-	BoardsRetentionDays   *int    `access:"compliance_data_retention_policy"`
-	DeletionJobStartTime  *string `access:"compliance_data_retention_policy"`
-	BatchSize             *int    `access:"compliance_data_retention_policy"`
+	EnableMessageDeletion         *bool   `access:"compliance_data_retention_policy"`
+	EnableFileDeletion            *bool   `access:"compliance_data_retention_policy"`
+	EnableBoardsDeletion          *bool   `access:"compliance_data_retention_policy"`
+	EnableArchivedChannelDeletion *bool   `access:"compliance_data_retention_policy"`
+	EnableEditHistoryDeletion     *bool   `access:"compliance_data_retention_policy"`
+	MessageRetentionDays          *int    `access:"compliance_data_retention_policy"`
+	FileRetentionDays             *int    `access:"compliance_data_retention_policy"`
+	BoardsRetentionDays           *int    `access:"compliance_data_retention_policy"`
+	ArchivedChannelRetentionDays  *int    `access:"compliance_data_retention_policy"`
+	EditHistoryRetentionDays      *int    `access:"compliance_data_retention_policy"`
+	DeletionJobStartTime          *string `access:"compliance_data_retention_policy"`
+	BatchSize                     *int    `access:"compliance_data_retention_policy"`
 }
 
 func (s *DataRetentionSettings) SetDefaults() {
@@ -2670,6 +2835,14 @@ func (s *DataRetentionSettings) SetDefaults() {
 		s.EnableBoardsDeletion = NewBool(false)
 	}
 
+	if s.EnableArchivedChannelDeletion == nil {
+		s.EnableArchivedChannelDeletion = NewBool(false)
+	}
+
+	if s.EnableEditHistoryDeletion == nil {
+		s.EnableEditHistoryDeletion = NewBool(false)
+	}
+
 	if s.MessageRetentionDays == nil {
 		s.MessageRetentionDays = NewInt(DataRetentionSettingsDefaultMessageRetentionDays)
 	}
@@ -2682,6 +2855,14 @@ func (s *DataRetentionSettings) SetDefaults() {
 		s.BoardsRetentionDays = NewInt(DataRetentionSettingsDefaultBoardsRetentionDays)
 	}
 
+	if s.ArchivedChannelRetentionDays == nil {
+		s.ArchivedChannelRetentionDays = NewInt(DataRetentionSettingsDefaultArchivedChannelRetentionDays)
+	}
+
+	if s.EditHistoryRetentionDays == nil {
+		s.EditHistoryRetentionDays = NewInt(DataRetentionSettingsDefaultEditHistoryRetentionDays)
+	}
+
 	if s.DeletionJobStartTime == nil {
 		s.DeletionJobStartTime = NewString(DataRetentionSettingsDefaultDeletionJobStartTime)
 	}
@@ -2934,6 +3115,7 @@ type GuestAccountsSettings struct {
 	AllowEmailAccounts               *bool   `access:"authentication_guest_access"`
 	EnforceMultifactorAuthentication *bool   `access:"authentication_guest_access"`
 	RestrictCreationToDomains        *string `access:"authentication_guest_access"`
+	EnforceChannelInvites            *bool   `access:"authentication_guest_access"`
 }
 
 func (s *GuestAccountsSettings) SetDefaults() {
@@ -2952,6 +3134,10 @@ func (s *GuestAccountsSettings) SetDefaults() {
 	if s.RestrictCreationToDomains == nil {
 		s.RestrictCreationToDomains = NewString("")
 	}
+
+	if s.EnforceChannelInvites == nil {
+		s.EnforceChannelInvites = NewBool(false)
+	}
 }
 
 type ImageProxySettings struct {
@@ -3328,10 +3514,18 @@ func (s *TeamSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_channels.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.MaxChannelsPerUserPerTeam < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.max_channels_per_user.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.MaxNotificationsPerChannel <= 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_notify_per_channel.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.MaxExpandedMentionsPerPost < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.max_expanded_mentions_per_post.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if !(*s.RestrictDirectMessage == DirectMessageAny || *s.RestrictDirectMessage == DirectMessageTeam) {
 		return NewAppError("Config.IsValid", "model.config.is_valid.restrict_direct_message.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -3424,6 +3618,10 @@ func (s *EmailSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.email_notification_contents_type.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.AnnouncementEmailsPerHour < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.announcement_emails_per_hour.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -3621,10 +3819,73 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.time_between_user_typing.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.TypingEventBroadcastIntervalMilliseconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.typing_event_broadcast_interval.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.MaximumLoginAttempts <= 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.login_attempts.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.LoginLockoutDurationInMinutes < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.login_lockout_duration.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.LoginLockoutScope != LoginLockoutScopeAccount && *s.LoginLockoutScope != LoginLockoutScopeIP {
+		return NewAppError("Config.IsValid", "model.config.is_valid.login_lockout_scope.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// IP addresses are only locked out in memory, where a lockout without a duration would never be lifted
+	if *s.LoginLockoutScope == LoginLockoutScopeIP && *s.LoginLockoutDurationInMinutes == 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.login_lockout_duration_ip.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.MaxPinnedPostsPerChannel < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.max_pinned_posts_per_channel.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.PostTruncatedPreviewLength < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.post_truncated_preview_length.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.OutgoingWebhookPayloadTemplate != "" {
+		if _, err := ParseOutgoingWebhookPayloadTemplate(*s.OutgoingWebhookPayloadTemplate); err != nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_webhook_payload_template.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	for _, reason := range s.PostReportReasons {
+		if reason == "" || utf8.RuneCountInString(reason) > PostReportReasonMaxRunes {
+			return NewAppError("Config.IsValid", "model.config.is_valid.post_report_reasons.app_error", map[string]interface{}{"MaxLength": PostReportReasonMaxRunes}, "", http.StatusBadRequest)
+		}
+	}
+
+	if *s.SearchRateLimitPerMinute < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.search_rate_limit_per_minute.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.SearchRateLimitMaxBurst < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.search_rate_limit_max_burst.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.AutoPinReactionThreshold < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.auto_pin_reaction_threshold.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.AutoPinReactionThreshold > 0 && (*s.AutoPinEmojiName == "" || len(*s.AutoPinEmojiName) > EmojiNameMaxLength || !IsValidAlphaNumHyphenUnderscorePlus(*s.AutoPinEmojiName)) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.auto_pin_emoji_name.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, channelID := range s.AutoPinChannelIds {
+		if !IsValidId(channelID) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.auto_pin_channel_ids.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if utf8.RuneCountInString(*s.WelcomeMessage) > PostMessageMaxRunesV1 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.welcome_message.app_error", map[string]interface{}{"MaxLength": PostMessageMaxRunesV1}, "", http.StatusBadRequest)
+	}
+
 	if *s.SiteURL != "" {
 		if _, err := url.ParseRequestURI(*s.SiteURL); err != nil {
 			return NewAppError("Config.IsValid", "model.config.is_valid.site_url.app_error", nil, err.Error(), http.StatusBadRequest)
@@ -3738,6 +3999,14 @@ func (s *DataRetentionSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.file_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.ArchivedChannelRetentionDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.archived_channel_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.EditHistoryRetentionDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.edit_history_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if _, err := time.Parse("15:04", *s.DeletionJobStartTime); err != nil {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.deletion_job_start_time.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
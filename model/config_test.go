@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1504,6 +1505,117 @@ func TestConfigServiceSettingsIsValid(t *testing.T) {
 	require.Equal(t, "model.config.is_valid.collapsed_threads.app_error", err.Id)
 }
 
+func TestConfigServiceSettingsPostReportReasons(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	require.Equal(t, GetDefaultPostReportReasons(), cfg.ServiceSettings.PostReportReasons)
+
+	cfg.ServiceSettings.PostReportReasons = []string{}
+	require.Nil(t, cfg.ServiceSettings.isValid())
+
+	cfg.ServiceSettings.PostReportReasons = []string{"spam", ""}
+	err := cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.post_report_reasons.app_error", err.Id)
+
+	cfg.ServiceSettings.PostReportReasons = []string{strings.Repeat("a", PostReportReasonMaxRunes+1)}
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.post_report_reasons.app_error", err.Id)
+}
+
+func TestConfigServiceSettingsOutgoingWebhookPayloadTemplate(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	require.Equal(t, "", *cfg.ServiceSettings.OutgoingWebhookPayloadTemplate)
+	require.Nil(t, cfg.ServiceSettings.isValid())
+
+	*cfg.ServiceSettings.OutgoingWebhookPayloadTemplate = `{"message": {{json .Text}}, "user": {{upper .UserName}}}`
+	require.Nil(t, cfg.ServiceSettings.isValid())
+
+	*cfg.ServiceSettings.OutgoingWebhookPayloadTemplate = `{"message": {{json .Text}`
+	err := cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.outgoing_webhook_payload_template.app_error", err.Id)
+
+	*cfg.ServiceSettings.OutgoingWebhookPayloadTemplate = `{"message": {{unknown .Text}}}`
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.outgoing_webhook_payload_template.app_error", err.Id)
+}
+
+func TestConfigServiceSettingsAutoPin(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	require.Equal(t, "pushpin", *cfg.ServiceSettings.AutoPinEmojiName)
+	require.Equal(t, 0, *cfg.ServiceSettings.AutoPinReactionThreshold)
+	require.Empty(t, cfg.ServiceSettings.AutoPinChannelIds)
+
+	*cfg.ServiceSettings.AutoPinReactionThreshold = -1
+	err := cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.auto_pin_reaction_threshold.app_error", err.Id)
+
+	*cfg.ServiceSettings.AutoPinReactionThreshold = 3
+	*cfg.ServiceSettings.AutoPinEmojiName = "not an emoji"
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.auto_pin_emoji_name.app_error", err.Id)
+
+	*cfg.ServiceSettings.AutoPinEmojiName = "pushpin"
+	cfg.ServiceSettings.AutoPinChannelIds = []string{"not an id"}
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.auto_pin_channel_ids.app_error", err.Id)
+
+	cfg.ServiceSettings.AutoPinChannelIds = []string{NewId()}
+	require.Nil(t, cfg.ServiceSettings.isValid())
+}
+
+func TestConfigServiceSettingsWelcomeMessage(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	require.False(t, *cfg.ServiceSettings.EnableWelcomeMessage)
+	require.Empty(t, *cfg.ServiceSettings.WelcomeMessage)
+
+	*cfg.ServiceSettings.WelcomeMessage = strings.Repeat("a", PostMessageMaxRunesV1)
+	require.Nil(t, cfg.ServiceSettings.isValid())
+
+	*cfg.ServiceSettings.WelcomeMessage += "a"
+	err := cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.welcome_message.app_error", err.Id)
+}
+
+func TestConfigServiceSettingsLoginLockout(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	require.Equal(t, 0, *cfg.ServiceSettings.LoginLockoutDurationInMinutes)
+	require.Equal(t, LoginLockoutScopeAccount, *cfg.ServiceSettings.LoginLockoutScope)
+
+	*cfg.ServiceSettings.LoginLockoutDurationInMinutes = 30
+	*cfg.ServiceSettings.LoginLockoutScope = LoginLockoutScopeIP
+	require.Nil(t, cfg.ServiceSettings.isValid())
+
+	*cfg.ServiceSettings.LoginLockoutDurationInMinutes = -1
+	err := cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.login_lockout_duration.app_error", err.Id)
+
+	*cfg.ServiceSettings.LoginLockoutDurationInMinutes = 0
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.login_lockout_duration_ip.app_error", err.Id)
+
+	*cfg.ServiceSettings.LoginLockoutScope = LoginLockoutScopeAccount
+	require.Nil(t, cfg.ServiceSettings.isValid())
+
+	*cfg.ServiceSettings.LoginLockoutScope = "team"
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.login_lockout_scope.app_error", err.Id)
+}
+
 func TestConfigDefaultCallsPluginState(t *testing.T) {
 	t.Run("should enable Calls plugin by default on self-hosted", func(t *testing.T) {
 		c1 := Config{}
@@ -25,6 +25,14 @@ type Emoji struct {
 	Name      string `json:"name"`
 }
 
+// UnusedEmojiList holds custom emoji that have no reactions and were not found in any message
+// created since MessagesScannedSince. Messages older than that are not scanned, so an emoji only
+// used in older messages is still reported as unused.
+type UnusedEmojiList struct {
+	Emoji                []*Emoji `json:"emoji"`
+	MessagesScannedSince int64    `json:"messages_scanned_since"`
+}
+
 func inSystemEmoji(emojiName string) bool {
 	_, ok := SystemEmojis[emojiName]
 	return ok
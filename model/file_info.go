@@ -56,6 +56,9 @@ type FileInfo struct {
 	Content         string  `json:"-"`
 	RemoteId        *string `json:"remote_id"`
 	Archived        bool    `json:"archived"`
+	// Quarantined is set when the file failed, or could not complete, a virus scan. Its contents
+	// can't be downloaded until the quarantine is cleared.
+	Quarantined bool `json:"quarantined"`
 }
 
 func (fi *FileInfo) PreSave() {
@@ -0,0 +1,39 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+// GuestChannelInvite records that a guest was explicitly invited to a channel. When
+// GuestAccountsSettings.EnforceChannelInvites is enabled, guests can only be added to the channels
+// they have an invite for.
+type GuestChannelInvite struct {
+	UserId    string `json:"user_id"`
+	ChannelId string `json:"channel_id"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+func (i *GuestChannelInvite) PreSave() {
+	if i.CreateAt == 0 {
+		i.CreateAt = GetMillis()
+	}
+}
+
+func (i *GuestChannelInvite) IsValid() *AppError {
+	if !IsValidId(i.UserId) {
+		return NewAppError("GuestChannelInvite.IsValid", "model.guest_channel_invite.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(i.ChannelId) {
+		return NewAppError("GuestChannelInvite.IsValid", "model.guest_channel_invite.is_valid.channel_id.app_error", nil, "user_id="+i.UserId, http.StatusBadRequest)
+	}
+
+	if i.CreateAt == 0 {
+		return NewAppError("GuestChannelInvite.IsValid", "model.guest_channel_invite.is_valid.create_at.app_error", nil, "user_id="+i.UserId, http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestChannelInviteIsValid(t *testing.T) {
+	invite := &GuestChannelInvite{
+		UserId:    NewId(),
+		ChannelId: NewId(),
+	}
+	require.NotNil(t, invite.IsValid())
+
+	invite.PreSave()
+	require.Nil(t, invite.IsValid())
+
+	invite.UserId = ""
+	require.NotNil(t, invite.IsValid())
+
+	invite.UserId = NewId()
+	invite.ChannelId = "junk"
+	require.NotNil(t, invite.IsValid())
+}
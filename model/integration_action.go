@@ -24,6 +24,8 @@ import (
 const (
 	PostActionTypeButton                        = "button"
 	PostActionTypeSelect                        = "select"
+	PostActionDataSourceUsers                   = "users"
+	PostActionDataSourceChannels                = "channels"
 	InteractiveDialogTriggerTimeoutMilliseconds = 3000
 )
 
@@ -397,7 +399,7 @@ func AddPostActionCookies(o *Post, secret []byte) *Post {
 			}
 
 			c.PostId = p.Id
-			if p.RootId == "" {
+			if p.IsRoot() {
 				c.RootPostId = p.Id
 			} else {
 				c.RootPostId = p.RootId
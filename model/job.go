@@ -27,6 +27,10 @@ const (
 	JobTypeCloud                        = "cloud"
 	JobTypeResendInvitationEmail        = "resend_invitation_email"
 	JobTypeExtractContent               = "extract_content"
+	JobTypeArchivedChannelPurge         = "archived_channel_purge"
+	JobTypePostReminders                = "post_reminders"
+	JobTypeEditHistoryPurge             = "edit_history_purge"
+	JobTypeScheduledChannelDeletions    = "scheduled_channel_deletions"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -55,6 +59,10 @@ var AllJobTypes = [...]string{
 	JobTypeExportDelete,
 	JobTypeCloud,
 	JobTypeExtractContent,
+	JobTypeArchivedChannelPurge,
+	JobTypePostReminders,
+	JobTypeEditHistoryPurge,
+	JobTypeScheduledChannelDeletions,
 }
 
 type Job struct {
@@ -4,30 +4,34 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"text/template"
 )
 
 type OutgoingWebhook struct {
-	Id           string      `json:"id"`
-	Token        string      `json:"token"`
-	CreateAt     int64       `json:"create_at"`
-	UpdateAt     int64       `json:"update_at"`
-	DeleteAt     int64       `json:"delete_at"`
-	CreatorId    string      `json:"creator_id"`
-	ChannelId    string      `json:"channel_id"`
-	TeamId       string      `json:"team_id"`
-	TriggerWords StringArray `json:"trigger_words"`
-	TriggerWhen  int         `json:"trigger_when"`
-	CallbackURLs StringArray `json:"callback_urls"`
-	DisplayName  string      `json:"display_name"`
-	Description  string      `json:"description"`
-	ContentType  string      `json:"content_type"`
-	Username     string      `json:"username"`
-	IconURL      string      `json:"icon_url"`
+	Id                       string      `json:"id"`
+	Token                    string      `json:"token"`
+	CreateAt                 int64       `json:"create_at"`
+	UpdateAt                 int64       `json:"update_at"`
+	DeleteAt                 int64       `json:"delete_at"`
+	CreatorId                string      `json:"creator_id"`
+	ChannelId                string      `json:"channel_id"`
+	TeamId                   string      `json:"team_id"`
+	TriggerWords             StringArray `json:"trigger_words"`
+	TriggerWhen              int         `json:"trigger_when"`
+	TriggerReactions         StringArray `json:"trigger_reactions"`
+	TriggerOnReactionRemoved bool        `json:"trigger_on_reaction_removed"`
+	CallbackURLs             StringArray `json:"callback_urls"`
+	DisplayName              string      `json:"display_name"`
+	Description              string      `json:"description"`
+	ContentType              string      `json:"content_type"`
+	Username                 string      `json:"username"`
+	IconURL                  string      `json:"icon_url"`
 }
 
 type OutgoingWebhookPayload struct {
@@ -43,6 +47,10 @@ type OutgoingWebhookPayload struct {
 	Text        string `json:"text"`
 	TriggerWord string `json:"trigger_word"`
 	FileIds     string `json:"file_ids"`
+
+	// EmojiName and ReactionEvent are only set when the webhook was triggered by a reaction.
+	EmojiName     string `json:"emoji_name,omitempty"`
+	ReactionEvent string `json:"reaction_event,omitempty"`
 }
 
 type OutgoingWebhookResponse struct {
@@ -57,6 +65,11 @@ type OutgoingWebhookResponse struct {
 
 const OutgoingHookResponseTypeComment = "comment"
 
+const (
+	OutgoingHookReactionEventAdded   = "added"
+	OutgoingHookReactionEventRemoved = "removed"
+)
+
 func (o *OutgoingWebhookPayload) ToFormValues() string {
 	v := url.Values{}
 	v.Set("token", o.Token)
@@ -71,10 +84,38 @@ func (o *OutgoingWebhookPayload) ToFormValues() string {
 	v.Set("text", o.Text)
 	v.Set("trigger_word", o.TriggerWord)
 	v.Set("file_ids", o.FileIds)
+	if o.ReactionEvent != "" {
+		v.Set("emoji_name", o.EmojiName)
+		v.Set("reaction_event", o.ReactionEvent)
+	}
 
 	return v.Encode()
 }
 
+// outgoingWebhookPayloadTemplateFuncs is the function set available to outgoing webhook payload
+// templates in addition to the text/template builtins.
+var outgoingWebhookPayloadTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"trimSpace": strings.TrimSpace,
+	"replace":   strings.ReplaceAll,
+	"split":     strings.Split,
+	"join":      strings.Join,
+	"contains":  strings.Contains,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+}
+
+// ParseOutgoingWebhookPayloadTemplate parses a ServiceSettings.OutgoingWebhookPayloadTemplate, to be
+// executed against an OutgoingWebhookPayload.
+func ParseOutgoingWebhookPayloadTemplate(payloadTemplate string) (*template.Template, error) {
+	return template.New("payload").Funcs(outgoingWebhookPayloadTemplateFuncs).Option("missingkey=error").Parse(payloadTemplate)
+}
+
 func (o *OutgoingWebhook) IsValid() *AppError {
 
 	if !IsValidId(o.Id) {
@@ -117,6 +158,16 @@ func (o *OutgoingWebhook) IsValid() *AppError {
 		}
 	}
 
+	if len(fmt.Sprintf("%s", o.TriggerReactions)) > 1024 {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.trigger_reactions.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, emojiName := range o.TriggerReactions {
+		if emojiName == "" || len(emojiName) > EmojiNameMaxLength || !IsValidAlphaNumHyphenUnderscorePlus(emojiName) {
+			return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.trigger_reactions.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
 	if len(o.CallbackURLs) == 0 || len(fmt.Sprintf("%s", o.CallbackURLs)) > 1024 {
 		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.callback.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -185,6 +236,22 @@ func (o *OutgoingWebhook) TriggerWordExactMatch(word string) bool {
 	return false
 }
 
+// TriggersOnReaction returns whether a reaction with the given emoji being added, or removed
+// if removed is true, should trigger the webhook.
+func (o *OutgoingWebhook) TriggersOnReaction(emojiName string, removed bool) bool {
+	if removed && !o.TriggerOnReactionRemoved {
+		return false
+	}
+
+	for _, trigger := range o.TriggerReactions {
+		if trigger == emojiName {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (o *OutgoingWebhook) TriggerWordStartsWith(word string) bool {
 	if word == "" {
 		return false
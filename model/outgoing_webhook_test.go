@@ -83,6 +83,12 @@ func TestOutgoingWebhookIsValid(t *testing.T) {
 
 	o.IconURL = strings.Repeat("1", 1024)
 	assert.Nilf(t, o.IsValid(), "IconURL length %d should be valid", len(o.IconURL))
+
+	o.TriggerReactions = []string{"not valid!"}
+	assert.NotNilf(t, o.IsValid(), "%v for TriggerReactions should be invalid", o.TriggerReactions)
+
+	o.TriggerReactions = []string{"eyes", "+1"}
+	assert.Nilf(t, o.IsValid(), "%v for TriggerReactions should be valid", o.TriggerReactions)
 }
 
 func TestOutgoingWebhookPayloadToFormValues(t *testing.T) {
@@ -128,6 +134,17 @@ func TestOutgoingWebhookPreUpdate(t *testing.T) {
 	o.PreUpdate()
 }
 
+func TestOutgoingWebhookTriggersOnReaction(t *testing.T) {
+	o := OutgoingWebhook{Id: NewId(), TriggerReactions: []string{"eyes"}}
+	assert.True(t, o.TriggersOnReaction("eyes", false), "Should return true")
+	assert.False(t, o.TriggersOnReaction("smile", false), "Should return false")
+	assert.False(t, o.TriggersOnReaction("eyes", true), "Should return false when removal isn't configured")
+
+	o.TriggerOnReactionRemoved = true
+	assert.True(t, o.TriggersOnReaction("eyes", true), "Should return true")
+	assert.False(t, o.TriggersOnReaction("smile", true), "Should return false")
+}
+
 func TestOutgoingWebhookTriggerWordStartsWith(t *testing.T) {
 	o := OutgoingWebhook{Id: NewId()}
 	o.TriggerWords = append(o.TriggerWords, "foo")
@@ -3,6 +3,14 @@
 
 package model
 
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const permalinkPathSegment = "pl"
+
 type Permalink struct {
 	PreviewPost *PreviewPost `json:"preview_post"`
 }
@@ -29,3 +37,47 @@ func NewPreviewPost(post *Post, team *Team, channel *Channel) *PreviewPost {
 		ChannelID:          channel.Id,
 	}
 }
+
+// MakePermalink returns the canonical permalink to a post, in the form
+// <siteURL>/<teamName>/pl/<postId>. The siteURL may contain a subpath and
+// any trailing slashes are ignored.
+func MakePermalink(siteURL, teamName, postId string) string {
+	return strings.TrimRight(siteURL, "/") + "/" + teamName + "/" + permalinkPathSegment + "/" + postId
+}
+
+// ParsePermalink splits a permalink produced by MakePermalink into the site URL
+// (including any subpath), the team name and the post id.
+func ParsePermalink(permalink string) (siteURL, teamName, postId string, err error) {
+	u, err := url.Parse(strings.TrimSpace(permalink))
+	if err != nil {
+		return "", "", "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", "", "", errors.New("permalink must be an absolute URL")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", "", "", errors.New("permalink must not contain a query or fragment")
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 3 || segments[len(segments)-2] != permalinkPathSegment {
+		return "", "", "", errors.New("permalink path must end with /<team>/pl/<post_id>")
+	}
+
+	teamName = segments[len(segments)-3]
+	postId = segments[len(segments)-1]
+	if !IsValidTeamName(teamName) {
+		return "", "", "", errors.New("permalink contains an invalid team name")
+	}
+	if !IsValidId(postId) {
+		return "", "", "", errors.New("permalink contains an invalid post id")
+	}
+
+	u.Path = ""
+	if subpath := segments[:len(segments)-3]; len(subpath) > 0 {
+		u.Path = "/" + strings.Join(subpath, "/")
+	}
+	u.RawPath = ""
+
+	return u.String(), teamName, postId, nil
+}
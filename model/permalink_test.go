@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakePermalink(t *testing.T) {
+	postId := NewId()
+
+	for name, tc := range map[string]struct {
+		SiteURL  string
+		Expected string
+	}{
+		"no subpath": {
+			SiteURL:  "https://example.com",
+			Expected: "https://example.com/team/pl/" + postId,
+		},
+		"no subpath, trailing slash": {
+			SiteURL:  "https://example.com/",
+			Expected: "https://example.com/team/pl/" + postId,
+		},
+		"subpath": {
+			SiteURL:  "https://example.com/chat",
+			Expected: "https://example.com/chat/team/pl/" + postId,
+		},
+		"subpath, trailing slashes": {
+			SiteURL:  "https://example.com/chat//",
+			Expected: "https://example.com/chat/team/pl/" + postId,
+		},
+		"nested subpath with port": {
+			SiteURL:  "http://localhost:8065/a/b",
+			Expected: "http://localhost:8065/a/b/team/pl/" + postId,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, MakePermalink(tc.SiteURL, "team", postId))
+		})
+	}
+}
+
+func TestParsePermalink(t *testing.T) {
+	postId := NewId()
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, siteURL := range []string{
+			"https://example.com",
+			"https://example.com/chat",
+			"http://localhost:8065/a/b",
+		} {
+			parsedSiteURL, teamName, parsedPostId, err := ParsePermalink(MakePermalink(siteURL, "my-team", postId))
+			require.NoError(t, err)
+			assert.Equal(t, siteURL, parsedSiteURL)
+			assert.Equal(t, "my-team", teamName)
+			assert.Equal(t, postId, parsedPostId)
+		}
+	})
+
+	t.Run("trailing slash on site URL is dropped", func(t *testing.T) {
+		parsedSiteURL, _, _, err := ParsePermalink(MakePermalink("https://example.com/chat/", "team", postId))
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/chat", parsedSiteURL)
+	})
+
+	t.Run("invalid permalinks", func(t *testing.T) {
+		for _, permalink := range []string{
+			"",
+			"/team/pl/" + postId,
+			"https://example.com/pl/" + postId,
+			"https://example.com/team/pl",
+			"https://example.com/team/channels/" + postId,
+			"https://example.com/team/pl/notanid",
+			"https://example.com/Team_Name!/pl/" + postId,
+			"https://example.com/team/pl/" + postId + "?foo=bar",
+		} {
+			_, _, _, err := ParsePermalink(permalink)
+			assert.Error(t, err, permalink)
+		}
+	})
+}
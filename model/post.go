@@ -6,6 +6,7 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"regexp"
@@ -14,6 +15,7 @@ import (
 	"sync"
 	"unicode/utf8"
 
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
 	"github.com/mattermost/mattermost-server/v6/shared/markdown"
 )
 
@@ -42,8 +44,11 @@ const (
 	PostTypePurposeChange          = "system_purpose_change"
 	PostTypeChannelDeleted         = "system_channel_deleted"
 	PostTypeChannelRestored        = "system_channel_restored"
+	PostTypeDeletionScheduled      = "system_channel_deletion_scheduled"
+	PostTypeDeletionCancelled      = "system_channel_deletion_cancelled"
 	PostTypeEphemeral              = "system_ephemeral"
 	PostTypeChangeChannelPrivacy   = "system_change_chan_privacy"
+	PostTypePostPinned             = "system_post_pinned"
 	PostTypeAddBotTeamsChannels    = "add_bot_teams_channels"
 	PostTypeSystemWarnMetricStatus = "warn_metric_status"
 	PostTypeMe                     = "me"
@@ -69,6 +74,16 @@ const (
 	PostPropsGroupHighlightDisabled   = "disable_group_highlight"
 
 	PostPropsPreviewedPost = "previewed_post"
+
+	PostPropsSystemMessageId   = "system_message_id"
+	PostPropsSystemMessageArgs = "system_message_args"
+
+	PostPropsFromWebhook  = "from_webhook"
+	PostPropsFromBot      = "from_bot"
+	PostPropsFromPlugin   = "from_plugin"
+	PostPropsFromOAuthApp = "from_oauth_app"
+
+	PostPropsAnnouncement = "announcement"
 )
 
 const (
@@ -247,6 +262,9 @@ type GetPostsSinceOptions struct {
 	CollapsedThreads         bool
 	CollapsedThreadsExtended bool
 	SortAscending            bool
+	// ExcludeUserId is the id of a user whose posts are left out. Their root posts are still included outside of the
+	// order of the list when they are needed for the replies of other users.
+	ExcludeUserId string
 }
 
 type GetPostsSinceForSyncCursor struct {
@@ -254,6 +272,13 @@ type GetPostsSinceForSyncCursor struct {
 	LastPostId       string
 }
 
+// GetRecentPostsForUserCursor identifies the oldest post returned by a previous page of a user's recent
+// posts. The zero value starts from the most recent post.
+type GetRecentPostsForUserCursor struct {
+	LastPostCreateAt int64
+	LastPostId       string
+}
+
 type GetPostsSinceForSyncOptions struct {
 	ChannelId       string
 	ExcludeRemoteId string
@@ -274,6 +299,15 @@ type GetPostsOptions struct {
 	Direction                string // Only accepts up|down. Indicates the order in which to send the items.
 }
 
+type GetFlaggedPostsOptions struct {
+	// Only include posts on a specific team, and direct and group messages. "" for any team.
+	TeamId string
+	// Only include posts on a specific channel. "" for any channel.
+	ChannelId string
+	Page      int
+	PerPage   int
+}
+
 type PostCountOptions struct {
 	// Only include posts on a specific team. "" for any team.
 	TeamId          string
@@ -352,6 +386,7 @@ func (o *Post) IsValid(maxPostSize int) *AppError {
 		PostTypeChannelDeleted,
 		PostTypeChannelRestored,
 		PostTypeChangeChannelPrivacy,
+		PostTypePostPinned,
 		PostTypeAddBotTeamsChannels,
 		PostTypeSystemWarnMetricStatus,
 		PostTypeMe:
@@ -373,27 +408,63 @@ func (o *Post) IsValid(maxPostSize int) *AppError {
 		return NewAppError("Post.IsValid", "model.post.is_valid.props.app_error", nil, "id="+o.Id, http.StatusBadRequest)
 	}
 
+	if !o.isIntegrationMetadataValid() {
+		return NewAppError("Post.IsValid", "model.post.is_valid.integration_metadata.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
 	return nil
 }
 
+// SanitizeProps strips the reserved props from a post supplied by a client or an integration so that
+// it can't pass itself off as having been created by the server.
 func (o *Post) SanitizeProps() {
 	if o == nil {
 		return
 	}
-	membersToSanitize := []string{
-		PropsAddChannelMember,
-	}
 
-	for _, member := range membersToSanitize {
+	for _, member := range ReservedPostProps() {
 		if _, ok := o.GetProps()[member]; ok {
 			o.DelProp(member)
 		}
 	}
+	o.SanitizeParticipants()
+}
+
+// SanitizeParticipants removes the private fields of the thread participants attached to the post.
+func (o *Post) SanitizeParticipants() {
+	if o == nil {
+		return
+	}
+
 	for _, p := range o.Participants {
 		p.Sanitize(map[string]bool{})
 	}
 }
 
+// ReservedPostProps returns the keys of the props which the server sets to describe where a post came
+// from or how it was generated. Clients rely on them, so they're never accepted from external input.
+func ReservedPostProps() []string {
+	return []string{
+		PostPropsFromWebhook,
+		PostPropsFromBot,
+		PostPropsFromPlugin,
+		PostPropsFromOAuthApp,
+		PropsAddChannelMember,
+		PostPropsSystemMessageId,
+		PostPropsSystemMessageArgs,
+	}
+}
+
+// IsReservedPostProp returns true if the given prop key is one of ReservedPostProps.
+func IsReservedPostProp(key string) bool {
+	for _, reserved := range ReservedPostProps() {
+		if key == reserved {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *Post) PreSave() {
 	if o.Id == "" {
 		o.Id = NewId()
@@ -474,10 +545,91 @@ func (o *Post) GetProp(key string) interface{} {
 	return o.Props[key]
 }
 
+// IsRoot returns whether the post starts a thread rather than replying to one. The ParentId field of
+// older versions was always the same as RootId and was merged into it when it was removed, so RootId
+// alone determines whether a post is a reply.
+func (o *Post) IsRoot() bool {
+	return o.RootId == ""
+}
+
+// IsReply returns whether the post is a reply in the thread of another post.
+func (o *Post) IsReply() bool {
+	return !o.IsRoot()
+}
+
+// IsAnnouncement returns whether the post is an announcement, which is emailed to every member of
+// the channel regardless of their notification preferences.
+func (o *Post) IsAnnouncement() bool {
+	switch v := o.GetProp(PostPropsAnnouncement).(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
 func (o *Post) IsSystemMessage() bool {
 	return len(o.Type) >= len(PostSystemMessagePrefix) && o.Type[:len(PostSystemMessagePrefix)] == PostSystemMessagePrefix
 }
 
+// SetSystemMessage renders the message of a system post using the given translation function, and
+// keeps the translation id and its arguments in the post's props so that the message can be
+// rendered again in the locale of each recipient.
+func (o *Post) SetSystemMessage(T i18n.TranslateFunc, translationID string, args ...string) {
+	o.AddProp(PostPropsSystemMessageId, translationID)
+	o.AddProp(PostPropsSystemMessageArgs, args)
+	o.Message = renderSystemMessage(T, translationID, args)
+}
+
+// LocalizeSystemMessage renders the message of a system post created with SetSystemMessage using
+// the given translation function. Other posts, including system posts created before messages
+// were stored this way, keep their message as is.
+func (o *Post) LocalizeSystemMessage(T i18n.TranslateFunc) {
+	if !o.IsSystemMessage() {
+		return
+	}
+
+	translationID, ok := o.GetProp(PostPropsSystemMessageId).(string)
+	if !ok || translationID == "" {
+		return
+	}
+
+	var args []string
+	switch v := o.GetProp(PostPropsSystemMessageArgs).(type) {
+	case nil:
+	case []string:
+		args = v
+	case []interface{}:
+		// Props read back from the database are decoded without their original types.
+		args = make([]string, 0, len(v))
+		for _, arg := range v {
+			s, ok := arg.(string)
+			if !ok {
+				return
+			}
+			args = append(args, s)
+		}
+	default:
+		return
+	}
+
+	o.Message = renderSystemMessage(T, translationID, args)
+}
+
+func renderSystemMessage(T i18n.TranslateFunc, translationID string, args []string) string {
+	if len(args) == 0 {
+		return T(translationID)
+	}
+
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	return fmt.Sprintf(T(translationID), values...)
+}
+
 // IsRemote returns true if the post originated on a remote cluster.
 func (o *Post) IsRemote() bool {
 	return o.RemoteId != nil && *o.RemoteId != ""
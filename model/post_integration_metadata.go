@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// PostPropsIntegrationMetadata holds structured metadata that integrations attach to a post, as a
+	// map of namespaces to maps of keys to values, e.g. {"com.example.jira": {"ticket": "MM-123"}}.
+	PostPropsIntegrationMetadata = "integration_metadata"
+
+	PostIntegrationMetadataMaxNamespaces = 10
+	PostIntegrationMetadataMaxKeys       = 20
+	PostIntegrationMetadataValueMaxRunes = 256
+)
+
+var (
+	validIntegrationMetadataNamespace = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*$`)
+	validIntegrationMetadataKey       = regexp.MustCompile(`^[a-z0-9_-]+$`)
+)
+
+// IntegrationMetadataFilter matches the posts whose integration metadata has the given value for the
+// key in the namespace.
+type IntegrationMetadataFilter struct {
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+}
+
+// ParseIntegrationMetadataFilter parses a filter of the form <namespace>.<key>=<value>. Namespaces may
+// contain dots but keys can't, so the key is whatever follows the last dot before the equals sign.
+func ParseIntegrationMetadataFilter(text string) (IntegrationMetadataFilter, bool) {
+	path, value, ok := strings.Cut(text, "=")
+	if !ok {
+		return IntegrationMetadataFilter{}, false
+	}
+
+	dot := strings.LastIndex(path, ".")
+	if dot == -1 {
+		return IntegrationMetadataFilter{}, false
+	}
+
+	filter := IntegrationMetadataFilter{
+		Namespace: path[:dot],
+		Key:       path[dot+1:],
+		Value:     value,
+	}
+	if !IsValidIntegrationMetadataNamespace(filter.Namespace) || !IsValidIntegrationMetadataKey(filter.Key) {
+		return IntegrationMetadataFilter{}, false
+	}
+
+	return filter, true
+}
+
+func IsValidIntegrationMetadataNamespace(namespace string) bool {
+	return len(namespace) <= 64 && validIntegrationMetadataNamespace.MatchString(namespace)
+}
+
+func IsValidIntegrationMetadataKey(key string) bool {
+	return len(key) <= 64 && validIntegrationMetadataKey.MatchString(key)
+}
+
+// GetIntegrationMetadata returns the integration metadata of the post, or nil if it has none or it
+// isn't a map of namespaces to maps of strings.
+func (o *Post) GetIntegrationMetadata() map[string]map[string]string {
+	metadata, ok := integrationMetadataFromProp(o.GetProp(PostPropsIntegrationMetadata))
+	if !ok {
+		return nil
+	}
+	return metadata
+}
+
+// SetIntegrationMetadata sets the value of the key in the namespace of the post's integration metadata.
+func (o *Post) SetIntegrationMetadata(namespace, key, value string) {
+	metadata := o.GetIntegrationMetadata()
+	if metadata == nil {
+		metadata = map[string]map[string]string{}
+	}
+	if metadata[namespace] == nil {
+		metadata[namespace] = map[string]string{}
+	}
+	metadata[namespace][key] = value
+
+	o.AddProp(PostPropsIntegrationMetadata, metadata)
+}
+
+func (o *Post) isIntegrationMetadataValid() bool {
+	prop := o.GetProp(PostPropsIntegrationMetadata)
+	if prop == nil {
+		return true
+	}
+
+	metadata, ok := integrationMetadataFromProp(prop)
+	if !ok || len(metadata) > PostIntegrationMetadataMaxNamespaces {
+		return false
+	}
+
+	for namespace, values := range metadata {
+		if !IsValidIntegrationMetadataNamespace(namespace) || len(values) > PostIntegrationMetadataMaxKeys {
+			return false
+		}
+
+		for key, value := range values {
+			if !IsValidIntegrationMetadataKey(key) || utf8.RuneCountInString(value) > PostIntegrationMetadataValueMaxRunes {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// integrationMetadataFromProp converts the prop, which has lost its types if it was read from JSON.
+func integrationMetadataFromProp(prop interface{}) (map[string]map[string]string, bool) {
+	switch v := prop.(type) {
+	case map[string]map[string]string:
+		return v, true
+	case map[string]interface{}:
+		metadata := make(map[string]map[string]string, len(v))
+		for namespace, rawValues := range v {
+			switch values := rawValues.(type) {
+			case map[string]string:
+				metadata[namespace] = values
+			case map[string]interface{}:
+				metadata[namespace] = make(map[string]string, len(values))
+				for key, rawValue := range values {
+					value, ok := rawValue.(string)
+					if !ok {
+						return nil, false
+					}
+					metadata[namespace][key] = value
+				}
+			default:
+				return nil, false
+			}
+		}
+		return metadata, true
+	default:
+		return nil, false
+	}
+}
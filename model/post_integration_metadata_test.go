@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostIntegrationMetadata(t *testing.T) {
+	post := &Post{}
+	assert.Nil(t, post.GetIntegrationMetadata())
+
+	post.SetIntegrationMetadata("com.example.jira", "ticket", "MM-123")
+	post.SetIntegrationMetadata("com.example.jira", "status", "open")
+	post.SetIntegrationMetadata("ci", "status", "failed")
+
+	expected := map[string]map[string]string{
+		"com.example.jira": {"ticket": "MM-123", "status": "open"},
+		"ci":               {"status": "failed"},
+	}
+	assert.Equal(t, expected, post.GetIntegrationMetadata())
+
+	t.Run("read from JSON", func(t *testing.T) {
+		data, err := json.Marshal(post)
+		require.NoError(t, err)
+
+		var decoded Post
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, expected, decoded.GetIntegrationMetadata())
+		assert.True(t, decoded.isIntegrationMetadataValid())
+	})
+
+	t.Run("not a map of strings", func(t *testing.T) {
+		post := &Post{}
+		post.AddProp(PostPropsIntegrationMetadata, map[string]interface{}{"ci": map[string]interface{}{"attempt": 2}})
+		assert.Nil(t, post.GetIntegrationMetadata())
+		assert.False(t, post.isIntegrationMetadataValid())
+	})
+}
+
+func TestPostIsValidIntegrationMetadata(t *testing.T) {
+	newPost := func() *Post {
+		return &Post{
+			Id:        NewId(),
+			CreateAt:  GetMillis(),
+			UpdateAt:  GetMillis(),
+			UserId:    NewId(),
+			ChannelId: NewId(),
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		post := newPost()
+		post.SetIntegrationMetadata("com.example.jira", "ticket_id", "MM-123")
+		assert.Nil(t, post.IsValid(PostMessageMaxRunesV2))
+	})
+
+	for name, tc := range map[string]struct {
+		namespace string
+		key       string
+		value     string
+	}{
+		"invalid namespace": {namespace: "Com.Example", key: "ticket", value: "MM-123"},
+		"empty namespace":   {namespace: "", key: "ticket", value: "MM-123"},
+		"invalid key":       {namespace: "ci", key: "build.status", value: "failed"},
+		"value too long":    {namespace: "ci", key: "log", value: strings.Repeat("a", PostIntegrationMetadataValueMaxRunes+1)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			post := newPost()
+			post.SetIntegrationMetadata(tc.namespace, tc.key, tc.value)
+
+			appErr := post.IsValid(PostMessageMaxRunesV2)
+			require.NotNil(t, appErr)
+			assert.Equal(t, "model.post.is_valid.integration_metadata.app_error", appErr.Id)
+		})
+	}
+
+	t.Run("too many keys", func(t *testing.T) {
+		post := newPost()
+		for i := 0; i <= PostIntegrationMetadataMaxKeys; i++ {
+			post.SetIntegrationMetadata("ci", "key"+strings.Repeat("a", i), "value")
+		}
+		require.NotNil(t, post.IsValid(PostMessageMaxRunesV2))
+	})
+}
+
+func TestParseIntegrationMetadataFilter(t *testing.T) {
+	for input, expected := range map[string]*IntegrationMetadataFilter{
+		"ci.status=failed":              {Namespace: "ci", Key: "status", Value: "failed"},
+		"com.example.jira.ticket=MM-12": {Namespace: "com.example.jira", Key: "ticket", Value: "MM-12"},
+		"ci.status=":                    {Namespace: "ci", Key: "status", Value: ""},
+		"status=failed":                 nil,
+		"ci.status":                     nil,
+		"CI.status=failed":              nil,
+	} {
+		t.Run(input, func(t *testing.T) {
+			filter, ok := ParseIntegrationMetadataFilter(input)
+			if expected == nil {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, *expected, filter)
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"io"
 	"sort"
+
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
 )
 
 type PostList struct {
@@ -132,6 +134,14 @@ func (o *PostList) UniqueOrder() {
 	o.Order = order
 }
 
+// LocalizeSystemMessages renders the system messages in the list using the given translation
+// function. See Post.LocalizeSystemMessage.
+func (o *PostList) LocalizeSystemMessages(T i18n.TranslateFunc) {
+	for _, post := range o.Posts {
+		post.LocalizeSystemMessage(T)
+	}
+}
+
 func (o *PostList) Extend(other *PostList) {
 	for postId := range other.Posts {
 		o.AddPost(other.Posts[postId])
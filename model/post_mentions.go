@@ -0,0 +1,171 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/v6/shared/markdown"
+)
+
+var channelMentionKeywords = map[string]bool{"@here": true, "@channel": true, "@all": true}
+
+// IsChannelMentionKeyword returns whether the keyword mentions everyone in a channel instead of a specific user.
+func IsChannelMentionKeyword(keyword string) bool {
+	return channelMentionKeywords[strings.ToLower(keyword)]
+}
+
+// MentionsEnabledFields returns the values of the fields of the post in which mentions are possible. These are the
+// message and the pretext and text of each attachment.
+func (o *Post) MentionsEnabledFields() StringArray {
+	ret := []string{}
+
+	ret = append(ret, o.Message)
+	for _, attachment := range o.Attachments() {
+		if attachment.Pretext != "" {
+			ret = append(ret, attachment.Pretext)
+		}
+		if attachment.Text != "" {
+			ret = append(ret, attachment.Text)
+		}
+	}
+	return ret
+}
+
+// ParsePostMentions splits the plain text of the mention enabled fields of the post into words as done by
+// ParseMentionText, skipping code blocks, links and any other markdown that can't contain a mention.
+func ParsePostMentions(post *Post, checkForMention func(word string) bool, processWord func(word string)) []string {
+	var potentialMentions []string
+
+	buf := ""
+	for _, message := range post.MentionsEnabledFields() {
+		markdown.Inspect(message, func(node interface{}) bool {
+			text, ok := node.(*markdown.Text)
+			if !ok {
+				potentialMentions = append(potentialMentions, ParseMentionText(buf, checkForMention, processWord)...)
+				buf = ""
+				return true
+			}
+			buf += text.Text
+			return false
+		})
+	}
+	potentialMentions = append(potentialMentions, ParseMentionText(buf, checkForMention, processWord)...)
+
+	return potentialMentions
+}
+
+// ParseMentionText splits the text into the words that could be mentions and calls checkForMention with each of them,
+// followed by the variants of the word without its trailing punctuation until one of them is a mention. Once a word
+// has been checked, processWord is called with it. The words starting with an @ that aren't mentions are returned
+// without the @ so that the caller can look for users who would have been mentioned by them.
+func ParseMentionText(text string, checkForMention func(word string) bool, processWord func(word string)) []string {
+	var potentialMentions []string
+
+	for _, word := range strings.FieldsFunc(text, func(c rune) bool {
+		// Split on any whitespace or punctuation that can't be part of an at mention or emoji pattern
+		return !(c == ':' || c == '.' || c == '-' || c == '_' || c == '@' || unicode.IsLetter(c) || unicode.IsNumber(c))
+	}) {
+		// skip word with format ':word:' with an assumption that it is an emoji format only
+		if word[0] == ':' && word[len(word)-1] == ':' {
+			continue
+		}
+
+		word = strings.TrimLeft(word, ":.-_")
+
+		if checkForMention(word) {
+			continue
+		}
+
+		foundWithoutSuffix := false
+		wordWithoutSuffix := word
+
+		for wordWithoutSuffix != "" && strings.LastIndexAny(wordWithoutSuffix, ".-:_") == (len(wordWithoutSuffix)-1) {
+			wordWithoutSuffix = wordWithoutSuffix[0 : len(wordWithoutSuffix)-1]
+
+			if checkForMention(wordWithoutSuffix) {
+				foundWithoutSuffix = true
+				break
+			}
+		}
+
+		if foundWithoutSuffix {
+			continue
+		}
+
+		if !channelMentionKeywords[word] && strings.HasPrefix(word, "@") {
+			// No need to bother about unicode as we are looking for ASCII characters.
+			last := word[len(word)-1]
+			switch last {
+			// If the word is possibly at the end of a sentence, remove that character.
+			case '.', '-', ':':
+				word = word[:len(word)-1]
+			}
+			potentialMentions = append(potentialMentions, word[1:])
+		} else if strings.ContainsAny(word, ".-:") {
+			// This word contains a character that may be the end of a sentence, so split further
+			splitWords := strings.FieldsFunc(word, func(c rune) bool {
+				return c == '.' || c == '-' || c == ':'
+			})
+
+			for _, splitWord := range splitWords {
+				if checkForMention(splitWord) {
+					continue
+				}
+				if !channelMentionKeywords[splitWord] && strings.HasPrefix(splitWord, "@") {
+					potentialMentions = append(potentialMentions, splitWord[1:])
+				}
+			}
+		}
+
+		processWord(word)
+	}
+
+	return potentialMentions
+}
+
+// GetPostMention returns whether the post mentions a user with the given mention keywords and whether that mention
+// is direct, meaning that a keyword of the user was used instead of @channel, @all or @here. The keywords are matched
+// case insensitively unless they contain upper case letters, like the first name of a user who asked for it to be
+// matched exactly.
+func GetPostMention(post *Post, keywords []string) (mentioned bool, direct bool) {
+	keywordSet := make(map[string]bool, len(keywords))
+	var multibyteKeywords []string
+	for _, keyword := range keywords {
+		keywordSet[keyword] = true
+		if len(keyword) != utf8.RuneCountInString(keyword) {
+			multibyteKeywords = append(multibyteKeywords, keyword)
+		}
+	}
+
+	addMention := func(keyword string) {
+		mentioned = true
+		if !IsChannelMentionKeyword(keyword) {
+			direct = true
+		}
+	}
+
+	ParsePostMentions(post, func(word string) bool {
+		for _, keyword := range []string{strings.ToLower(word), word} {
+			if keywordSet[keyword] {
+				addMention(keyword)
+				return true
+			}
+		}
+		return false
+	}, func(word string) {
+		if len(word) == utf8.RuneCountInString(word) {
+			return
+		}
+		for _, keyword := range multibyteKeywords {
+			if strings.Contains(word, keyword) {
+				addMention(keyword)
+			}
+		}
+	})
+
+	return mentioned, direct
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostMentionsEnabledFields(t *testing.T) {
+	attachmentWithTextAndPreText := SlackAttachment{
+		Text:    "@here with mentions",
+		Pretext: "@Channel some comment for the channel",
+	}
+
+	attachmentWithOutPreText := SlackAttachment{
+		Text: "some text",
+	}
+	attachments := []*SlackAttachment{
+		&attachmentWithTextAndPreText,
+		&attachmentWithOutPreText,
+	}
+
+	post := &Post{
+		Message: "This is the message",
+		Props: StringInterface{
+			"attachments": attachments,
+		},
+	}
+	expectedFields := []string{
+		"This is the message",
+		"@Channel some comment for the channel",
+		"@here with mentions",
+		"some text"}
+
+	mentionEnabledFields := post.MentionsEnabledFields()
+
+	assert.EqualValues(t, 4, len(mentionEnabledFields))
+	assert.EqualValues(t, expectedFields, mentionEnabledFields)
+}
+
+func TestGetPostMention(t *testing.T) {
+	keywords := []string{"@user", "user", "banana", "Fruity", "@channel", "@all", "@here", "マッターモスト"}
+
+	for name, tc := range map[string]struct {
+		Message   string
+		Mentioned bool
+		Direct    bool
+	}{
+		"no mention": {
+			Message: "a message about apples",
+		},
+		"username": {
+			Message:   "hello @user",
+			Mentioned: true,
+			Direct:    true,
+		},
+		"username in another case": {
+			Message:   "hello @USER",
+			Mentioned: true,
+			Direct:    true,
+		},
+		"username followed by punctuation": {
+			Message:   "hello @user.",
+			Mentioned: true,
+			Direct:    true,
+		},
+		"another username": {
+			Message: "hello @user2",
+		},
+		"keyword": {
+			Message:   "who wants a banana?",
+			Mentioned: true,
+			Direct:    true,
+		},
+		"keyword inside a word": {
+			Message: "who wants bananas?",
+		},
+		"case sensitive keyword": {
+			Message:   "hi Fruity",
+			Mentioned: true,
+			Direct:    true,
+		},
+		"case sensitive keyword in another case": {
+			Message: "hi fruity",
+		},
+		"multibyte keyword": {
+			Message:   "元気ですかマッターモストさん",
+			Mentioned: true,
+			Direct:    true,
+		},
+		"at channel": {
+			Message:   "hello @channel",
+			Mentioned: true,
+		},
+		"at all": {
+			Message:   "hello @all!",
+			Mentioned: true,
+		},
+		"at here": {
+			Message:   "@here, look at this",
+			Mentioned: true,
+		},
+		"at channel and username": {
+			Message:   "@channel and especially @user",
+			Mentioned: true,
+			Direct:    true,
+		},
+		"username in code": {
+			Message: "run `echo @user`",
+		},
+		"at channel in a code block": {
+			Message: "```\n@channel\n```",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mentioned, direct := GetPostMention(&Post{Message: tc.Message}, keywords)
+			assert.Equal(t, tc.Mentioned, mentioned, "mentioned")
+			assert.Equal(t, tc.Direct, direct, "direct")
+		})
+	}
+
+	t.Run("channel mention not in the keywords", func(t *testing.T) {
+		mentioned, direct := GetPostMention(&Post{Message: "hello @channel"}, []string{"@user"})
+		assert.False(t, mentioned)
+		assert.False(t, direct)
+	})
+
+	t.Run("mention in an attachment", func(t *testing.T) {
+		post := &Post{
+			Message: "see below",
+			Props: StringInterface{
+				"attachments": []*SlackAttachment{{Text: "ping @user"}},
+			},
+		}
+
+		mentioned, direct := GetPostMention(post, keywords)
+		assert.True(t, mentioned)
+		assert.True(t, direct)
+	})
+}
+
+func TestParseMentionText(t *testing.T) {
+	var checked, processed []string
+	potentialMentions := ParseMentionText("hi @user1, @user2. @channel and user3:", func(word string) bool {
+		checked = append(checked, word)
+		return word == "@user1"
+	}, func(word string) {
+		processed = append(processed, word)
+	})
+
+	assert.Equal(t, []string{"user2"}, potentialMentions)
+	assert.Equal(t, []string{"hi", "@user1", "@user2.", "@user2", "@channel", "and", "user3:", "user3", "user3"}, checked)
+	assert.Equal(t, []string{"hi", "@user2", "@channel", "and", "user3:"}, processed)
+}
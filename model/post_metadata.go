@@ -3,6 +3,12 @@
 
 package model
 
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
 type PostMetadata struct {
 	// Embeds holds information required to render content embedded in the post. This includes the OpenGraph metadata
 	// for links in the post.
@@ -22,6 +28,10 @@ type PostMetadata struct {
 
 	// Reactions holds reactions made to the post.
 	Reactions []*Reaction `json:"reactions,omitempty"`
+
+	// TruncatedPreview holds the start of the message for posts longer than ServiceSettings.PostTruncatedPreviewLength,
+	// so that clients can collapse long posts at the same point. The post's message is still returned in full.
+	TruncatedPreview string `json:"truncated_preview,omitempty"`
 }
 
 type PostImage struct {
@@ -55,10 +65,37 @@ func (p *PostMetadata) Copy() *PostMetadata {
 	copy(reactionsCopy, p.Reactions)
 
 	return &PostMetadata{
-		Embeds:    embedsCopy,
-		Emojis:    emojisCopy,
-		Files:     filesCopy,
-		Images:    imagesCopy,
-		Reactions: reactionsCopy,
+		Embeds:           embedsCopy,
+		Emojis:           emojisCopy,
+		Files:            filesCopy,
+		Images:           imagesCopy,
+		Reactions:        reactionsCopy,
+		TruncatedPreview: p.TruncatedPreview,
+	}
+}
+
+// TruncatePostMessage returns the start of message if it's longer than maxRunes runes. The message is cut at the
+// last whitespace before the limit when there is one in its second half, and a code block left open by the cut is
+// closed. The second return value is false if the message didn't need to be truncated.
+func TruncatePostMessage(message string, maxRunes int) (string, bool) {
+	if maxRunes <= 0 || utf8.RuneCountInString(message) <= maxRunes {
+		return message, false
 	}
+
+	runes := []rune(message)[:maxRunes]
+	cut := len(runes)
+	for i := len(runes) - 1; i >= maxRunes/2; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+
+	preview := strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace)
+
+	if strings.Count(preview, "```")%2 == 1 {
+		preview += "\n```"
+	}
+
+	return preview, true
 }
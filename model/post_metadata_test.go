@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncatePostMessage(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Message           string
+		MaxRunes          int
+		ExpectedPreview   string
+		ExpectedTruncated bool
+	}{
+		"disabled": {
+			Message:         strings.Repeat("a", 100),
+			MaxRunes:        0,
+			ExpectedPreview: strings.Repeat("a", 100),
+		},
+		"shorter than the limit": {
+			Message:         "short message",
+			MaxRunes:        20,
+			ExpectedPreview: "short message",
+		},
+		"exactly the limit": {
+			Message:         "exactly twenty runes",
+			MaxRunes:        20,
+			ExpectedPreview: "exactly twenty runes",
+		},
+		"cut at the last whitespace": {
+			Message:           "the quick brown fox jumps over the lazy dog",
+			MaxRunes:          22,
+			ExpectedPreview:   "the quick brown fox",
+			ExpectedTruncated: true,
+		},
+		"cut mid-word without whitespace in the second half": {
+			Message:           "a " + strings.Repeat("b", 30),
+			MaxRunes:          10,
+			ExpectedPreview:   "a bbbbbbbb",
+			ExpectedTruncated: true,
+		},
+		"counts runes rather than bytes": {
+			Message:           strings.Repeat("ü", 10),
+			MaxRunes:          5,
+			ExpectedPreview:   strings.Repeat("ü", 5),
+			ExpectedTruncated: true,
+		},
+		"closes an open code block": {
+			Message:           "code:\n```\nfirst line\nsecond line\n```",
+			MaxRunes:          21,
+			ExpectedPreview:   "code:\n```\nfirst line\n```",
+			ExpectedTruncated: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			preview, truncated := TruncatePostMessage(tc.Message, tc.MaxRunes)
+			assert.Equal(t, tc.ExpectedPreview, preview)
+			assert.Equal(t, tc.ExpectedTruncated, truncated)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+// PostReminder is a request by a user to be sent a direct message about a post at a later time.
+// A user has at most one reminder for any given post.
+type PostReminder struct {
+	PostId   string `json:"post_id"`
+	UserId   string `json:"user_id"`
+	RemindAt int64  `json:"remind_at"`
+	CreateAt int64  `json:"create_at"`
+	// FailedAttempts is the number of times sending the reminder has failed.
+	FailedAttempts int `json:"failed_attempts"`
+}
+
+func (r *PostReminder) PreSave() {
+	r.CreateAt = GetMillis()
+	r.FailedAttempts = 0
+}
+
+func (r *PostReminder) IsValid() *AppError {
+	if !IsValidId(r.PostId) {
+		return NewAppError("PostReminder.IsValid", "model.post_reminder.is_valid.post_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.UserId) {
+		return NewAppError("PostReminder.IsValid", "model.post_reminder.is_valid.user_id.app_error", nil, "post_id="+r.PostId, http.StatusBadRequest)
+	}
+
+	if r.RemindAt <= 0 {
+		return NewAppError("PostReminder.IsValid", "model.post_reminder.is_valid.remind_at.app_error", nil, "post_id="+r.PostId, http.StatusBadRequest)
+	}
+
+	if r.CreateAt == 0 {
+		return NewAppError("PostReminder.IsValid", "model.post_reminder.is_valid.create_at.app_error", nil, "post_id="+r.PostId, http.StatusBadRequest)
+	}
+
+	return nil
+}
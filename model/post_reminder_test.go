@@ -0,0 +1,33 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostReminderIsValid(t *testing.T) {
+	reminder := &PostReminder{
+		PostId:   NewId(),
+		UserId:   NewId(),
+		RemindAt: GetMillis(),
+	}
+	require.NotNil(t, reminder.IsValid())
+
+	reminder.PreSave()
+	require.Nil(t, reminder.IsValid())
+
+	reminder.RemindAt = 0
+	require.NotNil(t, reminder.IsValid())
+
+	reminder.RemindAt = GetMillis()
+	reminder.UserId = ""
+	require.NotNil(t, reminder.IsValid())
+
+	reminder.UserId = NewId()
+	reminder.PostId = "junk"
+	require.NotNil(t, reminder.IsValid())
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	PostReportStatusOpen      = "open"
+	PostReportStatusResolved  = "resolved"
+	PostReportStatusDismissed = "dismissed"
+
+	PostReportReasonMaxRunes = 1024
+)
+
+// PostReport is a report made by a user flagging a post for review by the system admins.
+type PostReport struct {
+	Id         string `json:"id"`
+	PostId     string `json:"post_id"`
+	ChannelId  string `json:"channel_id"`
+	ReporterId string `json:"reporter_id"`
+	Reason     string `json:"reason"`
+	Status     string `json:"status"`
+	CreateAt   int64  `json:"create_at"`
+	UpdateAt   int64  `json:"update_at"`
+}
+
+// PostReportWithContext is a PostReport along with the reported post and the reporting user.
+type PostReportWithContext struct {
+	*PostReport
+	Post     *Post `json:"post"`
+	Reporter *User `json:"reporter"`
+}
+
+type PostReportGetOptions struct {
+	// Only include reports with a specific status. "" for any status.
+	Status  string
+	Page    int
+	PerPage int
+}
+
+func IsValidPostReportStatus(status string) bool {
+	switch status {
+	case PostReportStatusOpen, PostReportStatusResolved, PostReportStatusDismissed:
+		return true
+	}
+	return false
+}
+
+func (r *PostReport) PreSave() {
+	if r.Id == "" {
+		r.Id = NewId()
+	}
+
+	if r.Status == "" {
+		r.Status = PostReportStatusOpen
+	}
+
+	r.CreateAt = GetMillis()
+	r.UpdateAt = r.CreateAt
+}
+
+func (r *PostReport) PreUpdate() {
+	r.UpdateAt = GetMillis()
+}
+
+func (r *PostReport) IsValid() *AppError {
+	if !IsValidId(r.Id) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.PostId) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.post_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.ChannelId) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.channel_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.ReporterId) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.reporter_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(r.Reason) > PostReportReasonMaxRunes {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.reason.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidPostReportStatus(r.Status) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.status.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.CreateAt == 0 {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.create_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.UpdateAt == 0 {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.update_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostReportIsValid(t *testing.T) {
+	report := &PostReport{
+		PostId:     NewId(),
+		ChannelId:  NewId(),
+		ReporterId: NewId(),
+	}
+	report.PreSave()
+	require.Nil(t, report.IsValid())
+	require.Equal(t, PostReportStatusOpen, report.Status)
+
+	report.Reason = strings.Repeat("a", PostReportReasonMaxRunes+1)
+	require.NotNil(t, report.IsValid())
+
+	report.Reason = "spam"
+	report.Status = "escalated"
+	require.NotNil(t, report.IsValid())
+
+	report.Status = PostReportStatusDismissed
+	report.ReporterId = ""
+	require.NotNil(t, report.IsValid())
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
 )
 
 func TestPostToJSON(t *testing.T) {
@@ -101,6 +103,119 @@ func TestPostIsSystemMessage(t *testing.T) {
 	require.True(t, post2.IsSystemMessage())
 }
 
+func TestPostIsRootIsReply(t *testing.T) {
+	t.Run("root", func(t *testing.T) {
+		post := &Post{Id: NewId()}
+		require.True(t, post.IsRoot())
+		require.False(t, post.IsReply())
+	})
+
+	t.Run("reply", func(t *testing.T) {
+		post := &Post{Id: NewId(), RootId: NewId()}
+		require.False(t, post.IsRoot())
+		require.True(t, post.IsReply())
+	})
+
+	t.Run("legacy parent", func(t *testing.T) {
+		rootID := NewId()
+
+		var reply Post
+		require.NoError(t, json.Unmarshal([]byte(`{"id":"`+NewId()+`","root_id":"`+rootID+`","parent_id":"`+rootID+`"}`), &reply))
+		require.False(t, reply.IsRoot())
+		require.True(t, reply.IsReply())
+
+		var root Post
+		require.NoError(t, json.Unmarshal([]byte(`{"id":"`+NewId()+`","root_id":"","parent_id":""}`), &root))
+		require.True(t, root.IsRoot())
+		require.False(t, root.IsReply())
+	})
+}
+
+func TestPostIsAnnouncement(t *testing.T) {
+	post := &Post{}
+	require.False(t, post.IsAnnouncement())
+
+	post.AddProp(PostPropsAnnouncement, true)
+	require.True(t, post.IsAnnouncement())
+
+	post.AddProp(PostPropsAnnouncement, "true")
+	require.True(t, post.IsAnnouncement())
+
+	post.AddProp(PostPropsAnnouncement, false)
+	require.False(t, post.IsAnnouncement())
+
+	post.AddProp(PostPropsAnnouncement, "yes")
+	require.False(t, post.IsAnnouncement())
+}
+
+func TestPostLocalizeSystemMessage(t *testing.T) {
+	translations := map[string]map[string]string{
+		"en": {"joined": "%v joined the channel.", "archived": "The channel was archived."},
+		"es": {"joined": "%v se unió al canal.", "archived": "El canal fue archivado."},
+	}
+	tfunc := func(locale string) i18n.TranslateFunc {
+		return func(translationID string, args ...interface{}) string {
+			return translations[locale][translationID]
+		}
+	}
+
+	t.Run("renders in each locale", func(t *testing.T) {
+		post := &Post{Type: PostTypeJoinChannel}
+		post.SetSystemMessage(tfunc("en"), "joined", "alice")
+		assert.Equal(t, "alice joined the channel.", post.Message)
+		assert.Equal(t, "joined", post.GetProp(PostPropsSystemMessageId))
+
+		post.LocalizeSystemMessage(tfunc("es"))
+		assert.Equal(t, "alice se unió al canal.", post.Message)
+
+		post.LocalizeSystemMessage(tfunc("en"))
+		assert.Equal(t, "alice joined the channel.", post.Message)
+	})
+
+	t.Run("without arguments", func(t *testing.T) {
+		post := &Post{Type: PostTypeChannelDeleted}
+		post.SetSystemMessage(tfunc("en"), "archived")
+		assert.Equal(t, "The channel was archived.", post.Message)
+
+		post.LocalizeSystemMessage(tfunc("es"))
+		assert.Equal(t, "El canal fue archivado.", post.Message)
+	})
+
+	t.Run("after a JSON round trip", func(t *testing.T) {
+		post := &Post{Type: PostTypeJoinChannel}
+		post.SetSystemMessage(tfunc("en"), "joined", "alice")
+
+		b, err := json.Marshal(post)
+		require.NoError(t, err)
+		var decoded Post
+		require.NoError(t, json.Unmarshal(b, &decoded))
+
+		decoded.LocalizeSystemMessage(tfunc("es"))
+		assert.Equal(t, "alice se unió al canal.", decoded.Message)
+	})
+
+	t.Run("falls back to the stored message for old posts", func(t *testing.T) {
+		post := &Post{Type: PostTypeJoinChannel, Message: "alice joined the channel."}
+		post.LocalizeSystemMessage(tfunc("es"))
+		assert.Equal(t, "alice joined the channel.", post.Message)
+	})
+
+	t.Run("ignores malformed arguments", func(t *testing.T) {
+		post := &Post{Type: PostTypeJoinChannel, Message: "alice joined the channel."}
+		post.AddProp(PostPropsSystemMessageId, "joined")
+		post.AddProp(PostPropsSystemMessageArgs, []interface{}{1})
+		post.LocalizeSystemMessage(tfunc("es"))
+		assert.Equal(t, "alice joined the channel.", post.Message)
+	})
+
+	t.Run("ignores regular posts", func(t *testing.T) {
+		post := &Post{Message: "hello"}
+		post.AddProp(PostPropsSystemMessageId, "joined")
+		post.LocalizeSystemMessage(tfunc("es"))
+		assert.Equal(t, "hello", post.Message)
+	})
+}
+
 func TestPostChannelMentions(t *testing.T) {
 	post := Post{Message: "~a ~b ~b ~c/~d."}
 	assert.Equal(t, []string{"a", "b", "c", "d"}, post.ChannelMentions())
@@ -139,6 +254,30 @@ func TestPostSanitizeProps(t *testing.T) {
 	require.Nil(t, post3.GetProp(PropsAddChannelMember))
 
 	require.NotNil(t, post3.GetProp("attachments"))
+
+	props := StringInterface{
+		"attachments":                     "good",
+		PostPropsMentionHighlightDisabled: true,
+	}
+	for _, key := range ReservedPostProps() {
+		props[key] = "true"
+	}
+	post4 := &Post{Message: "test", Props: props}
+
+	post4.SanitizeProps()
+
+	for _, key := range ReservedPostProps() {
+		require.True(t, IsReservedPostProp(key))
+		require.Nil(t, post4.GetProp(key), key)
+	}
+	require.Equal(t, StringInterface{
+		"attachments":                     "good",
+		PostPropsMentionHighlightDisabled: true,
+	}, post4.GetProps())
+	require.False(t, IsReservedPostProp("attachments"))
+
+	var nilPost *Post
+	require.NotPanics(t, nilPost.SanitizeProps)
 }
 
 func TestPost_AttachmentsEqual(t *testing.T) {
@@ -30,6 +30,7 @@ const (
 	PreferenceNameUseMilitaryTime         = "use_military_time"
 	PreferenceRecommendedNextSteps        = "recommended_next_steps"
 	PreferenceNameInsights                = "insights_tutorial_state"
+	PreferenceNameThreadAutoFollow        = "thread_auto_follow"
 
 	PreferenceCategoryTheme = "theme"
 	// the name for theme props is the team id
@@ -37,6 +38,9 @@ const (
 	PreferenceCategoryAuthorizedOAuthApp = "oauth_app"
 	// the name for oauth_app is the client_id and value is the current scope
 
+	PreferenceCategoryIgnoredUser = "ignored_user"
+	// the name for ignored_user is the id of the user whose posts are hidden and value is "true"
+
 	PreferenceCategoryLast    = "last"
 	PreferenceNameLastChannel = "channel"
 	PreferenceNameLastTeam    = "team"
@@ -47,6 +51,9 @@ const (
 
 	PreferenceCustomStatusModalViewed = "custom_status_modal_viewed"
 
+	PreferenceCategoryOnboarding     = "onboarding"
+	PreferenceNameWelcomeMessageSent = "welcome_message_sent"
+
 	PreferenceCategoryNotifications = "notifications"
 	PreferenceNameEmailInterval     = "email_interval"
 
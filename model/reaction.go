@@ -18,6 +18,20 @@ type Reaction struct {
 	RemoteId  *string `json:"remote_id"`
 }
 
+// ReactionUser is a user who reacted to a post with a given emoji and when they did so.
+type ReactionUser struct {
+	UserId   string `json:"user_id"`
+	CreateAt int64  `json:"create_at"`
+}
+
+// ReactionDetails describes the reactions to a post with a single emoji. Users holds a page of the
+// reacting users in the order that they reacted, while Count is the total number of them.
+type ReactionDetails struct {
+	EmojiName string          `json:"emoji_name"`
+	Count     int64           `json:"count"`
+	Users     []*ReactionUser `json:"users"`
+}
+
 func (o *Reaction) IsValid() *AppError {
 	if !IsValidId(o.UserId) {
 		return NewAppError("Reaction.IsValid", "model.reaction.is_valid.user_id.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
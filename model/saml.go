@@ -14,6 +14,7 @@ const (
 	UserAuthServiceIsSaml   = "isSaml"
 	UserAuthServiceIsMobile = "isMobile"
 	UserAuthServiceIsOAuth  = "isOAuthUser"
+	UserAuthServiceIsLdap   = "isLdap"
 )
 
 type SamlAuthRequest struct {
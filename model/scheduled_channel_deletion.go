@@ -0,0 +1,36 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+// ScheduledChannelDeletion is a pending request to archive a channel at a later time.
+// A channel has at most one scheduled deletion.
+type ScheduledChannelDeletion struct {
+	ChannelId string `json:"channel_id"`
+	DeleteAt  int64  `json:"delete_at"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+func (d *ScheduledChannelDeletion) PreSave() {
+	d.CreateAt = GetMillis()
+}
+
+func (d *ScheduledChannelDeletion) IsValid() *AppError {
+	if !IsValidId(d.ChannelId) {
+		return NewAppError("ScheduledChannelDeletion.IsValid", "model.scheduled_channel_deletion.is_valid.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if d.DeleteAt <= 0 {
+		return NewAppError("ScheduledChannelDeletion.IsValid", "model.scheduled_channel_deletion.is_valid.delete_at.app_error", nil, "channel_id="+d.ChannelId, http.StatusBadRequest)
+	}
+
+	if d.CreateAt == 0 {
+		return NewAppError("ScheduledChannelDeletion.IsValid", "model.scheduled_channel_deletion.is_valid.create_at.app_error", nil, "channel_id="+d.ChannelId, http.StatusBadRequest)
+	}
+
+	return nil
+}
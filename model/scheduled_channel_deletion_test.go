@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledChannelDeletionIsValid(t *testing.T) {
+	deletion := &ScheduledChannelDeletion{
+		ChannelId: NewId(),
+		DeleteAt:  GetMillis(),
+	}
+	require.NotNil(t, deletion.IsValid())
+
+	deletion.PreSave()
+	require.Nil(t, deletion.IsValid())
+
+	deletion.DeleteAt = 0
+	require.NotNil(t, deletion.IsValid())
+
+	deletion.DeleteAt = GetMillis()
+	deletion.ChannelId = "junk"
+	require.NotNil(t, deletion.IsValid())
+}
@@ -35,6 +35,9 @@ type SearchParams struct {
 	// True if this search doesn't originate from a "current user".
 	SearchWithoutUserId bool   `json:"search_without_userid,omitempty"`
 	Modifier            string `json:"modifier"`
+	// IntegrationMetadata and ExcludedIntegrationMetadata filter on the metadata integrations attached to posts.
+	IntegrationMetadata         []IntegrationMetadataFilter `json:"integration_metadata,omitempty"`
+	ExcludedIntegrationMetadata []IntegrationMetadataFilter `json:"excluded_integration_metadata,omitempty"`
 }
 
 // Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
@@ -109,7 +112,7 @@ func (p *SearchParams) GetExcludedDateMillis() (int64, int64) {
 	return GetStartOfDayMillis(date, p.TimeZoneOffset), GetEndOfDayMillis(date, p.TimeZoneOffset)
 }
 
-var searchFlags = [...]string{"from", "channel", "in", "before", "after", "on", "ext"}
+var searchFlags = [...]string{"from", "channel", "in", "before", "after", "on", "ext", "meta"}
 
 type flag struct {
 	name    string
@@ -270,6 +273,8 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 	excludedDate := ""
 	excludedExtensions := []string{}
 	extensions := []string{}
+	var integrationMetadata []IntegrationMetadataFilter
+	var excludedIntegrationMetadata []IntegrationMetadataFilter
 
 	for _, flag := range flags {
 		if flag.name == "in" || flag.name == "channel" {
@@ -308,6 +313,16 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			} else {
 				extensions = append(extensions, flag.value)
 			}
+		} else if flag.name == "meta" {
+			filter, ok := ParseIntegrationMetadataFilter(flag.value)
+			if !ok {
+				continue
+			}
+			if flag.exclude {
+				excludedIntegrationMetadata = append(excludedIntegrationMetadata, filter)
+			} else {
+				integrationMetadata = append(integrationMetadata, filter)
+			}
 		}
 	}
 
@@ -331,6 +346,9 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			OnDate:             onDate,
 			ExcludedDate:       excludedDate,
 			TimeZoneOffset:     timeZoneOffset,
+
+			IntegrationMetadata:         integrationMetadata,
+			ExcludedIntegrationMetadata: excludedIntegrationMetadata,
 		})
 	}
 
@@ -352,6 +370,9 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			OnDate:             onDate,
 			ExcludedDate:       excludedDate,
 			TimeZoneOffset:     timeZoneOffset,
+
+			IntegrationMetadata:         integrationMetadata,
+			ExcludedIntegrationMetadata: excludedIntegrationMetadata,
 		})
 	}
 
@@ -363,7 +384,8 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			len(extensions) != 0 || len(excludedExtensions) != 0 ||
 			afterDate != "" || excludedAfterDate != "" ||
 			beforeDate != "" || excludedBeforeDate != "" ||
-			onDate != "" || excludedDate != "") {
+			onDate != "" || excludedDate != "" ||
+			len(integrationMetadata) != 0 || len(excludedIntegrationMetadata) != 0) {
 		paramsList = append(paramsList, &SearchParams{
 			Terms:              "",
 			ExcludedTerms:      "",
@@ -381,6 +403,9 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			OnDate:             onDate,
 			ExcludedDate:       excludedDate,
 			TimeZoneOffset:     timeZoneOffset,
+
+			IntegrationMetadata:         integrationMetadata,
+			ExcludedIntegrationMetadata: excludedIntegrationMetadata,
 		})
 	}
 
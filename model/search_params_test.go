@@ -1665,6 +1665,47 @@ func TestParseSearchParams(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:  "input is a meta filter and a term should result in a single IntegrationMetadata filter and a term",
+			Input: "testing meta:com.example.jira.ticket=MM-123",
+			Output: []*SearchParams{
+				{
+					Terms:               "testing",
+					ExcludedTerms:       "",
+					IsHashtag:           false,
+					InChannels:          []string{},
+					ExcludedChannels:    []string{},
+					FromUsers:           []string{},
+					ExcludedUsers:       []string{},
+					Extensions:          []string{},
+					ExcludedExtensions:  []string{},
+					IntegrationMetadata: []IntegrationMetadataFilter{{Namespace: "com.example.jira", Key: "ticket", Value: "MM-123"}},
+				},
+			},
+		},
+		{
+			Name:  "input is an excluded meta filter should result in a single ExcludedIntegrationMetadata filter",
+			Input: "-meta:ci.status=failed",
+			Output: []*SearchParams{
+				{
+					Terms:                       "",
+					ExcludedTerms:               "",
+					IsHashtag:                   false,
+					InChannels:                  []string{},
+					ExcludedChannels:            []string{},
+					FromUsers:                   []string{},
+					ExcludedUsers:               []string{},
+					Extensions:                  []string{},
+					ExcludedExtensions:          []string{},
+					ExcludedIntegrationMetadata: []IntegrationMetadataFilter{{Namespace: "ci", Key: "status", Value: "failed"}},
+				},
+			},
+		},
+		{
+			Name:   "input is a meta filter without a namespace should be ignored",
+			Input:  "meta:ticket=MM-123",
+			Output: []*SearchParams{},
+		},
 	} {
 		t.Run(testCase.Name, func(t *testing.T) {
 			require.Equal(t, testCase.Output, ParseSearchParams(testCase.Input, 0))
@@ -199,6 +199,19 @@ func (s *Session) IsOAuthUser() bool {
 	return isOAuthUser
 }
 
+func (s *Session) IsLdap() bool {
+	val, ok := s.Props[UserAuthServiceIsLdap]
+	if !ok {
+		return false
+	}
+	isLdap, err := strconv.ParseBool(val)
+	if err != nil {
+		mlog.Debug("Error parsing boolean property from Session", mlog.Err(err))
+		return false
+	}
+	return isLdap
+}
+
 func (s *Session) IsSSOLogin() bool {
 	return s.IsOAuthUser() || s.IsSaml()
 }
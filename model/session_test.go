@@ -133,3 +133,21 @@ func TestSessionIsOAuthUser(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionIsLdap(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Session     Session
+		isLdap      bool
+	}{
+		{"False on empty props", Session{}, false},
+		{"True when key is set to true", Session{Props: StringMap{UserAuthServiceIsLdap: strconv.FormatBool(true)}}, true},
+		{"False when key is set to false", Session{Props: StringMap{UserAuthServiceIsLdap: strconv.FormatBool(false)}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			require.Equal(t, tc.isLdap, tc.Session.IsLdap())
+		})
+	}
+}
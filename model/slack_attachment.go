@@ -5,11 +5,18 @@ package model
 
 import (
 	"fmt"
+	"net/http"
 	"regexp"
+	"strconv"
 )
 
+// SlackAttachmentMaxActions is the maximum number of actions an attachment can have.
+const SlackAttachmentMaxActions = 25
+
 var linkWithTextRegex = regexp.MustCompile(`<([^<\|]+)\|([^>]+)>`)
 
+var slackAttachmentColorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
 type SlackAttachment struct {
 	Id         int64                   `json:"id"`
 	Fallback   string                  `json:"fallback"`
@@ -114,6 +121,72 @@ func (s *SlackAttachment) Equals(input *SlackAttachment) bool {
 	return s.Timestamp == input.Timestamp
 }
 
+// IsValid checks that the interactive elements of the attachment are well formed.
+func (s *SlackAttachment) IsValid() *AppError {
+	for _, action := range s.Actions {
+		if action == nil {
+			return NewAppError("SlackAttachment.IsValid", "model.slack_attachment.is_valid.action.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if action.Name == "" {
+			return NewAppError("SlackAttachment.IsValid", "model.slack_attachment.is_valid.action_name.app_error", nil, "id="+action.Id, http.StatusBadRequest)
+		}
+
+		switch action.Type {
+		case "", PostActionTypeButton:
+		case PostActionTypeSelect:
+			switch action.DataSource {
+			case "", PostActionDataSourceUsers, PostActionDataSourceChannels:
+			default:
+				return NewAppError("SlackAttachment.IsValid", "model.slack_attachment.is_valid.action_data_source.app_error", nil, "data_source="+action.DataSource, http.StatusBadRequest)
+			}
+
+			for _, option := range action.Options {
+				if option == nil || option.Value == "" {
+					return NewAppError("SlackAttachment.IsValid", "model.slack_attachment.is_valid.action_option.app_error", nil, "id="+action.Id, http.StatusBadRequest)
+				}
+			}
+		default:
+			return NewAppError("SlackAttachment.IsValid", "model.slack_attachment.is_valid.action_type.app_error", nil, "type="+action.Type, http.StatusBadRequest)
+		}
+
+		if action.Integration != nil && action.Integration.URL == "" {
+			return NewAppError("SlackAttachment.IsValid", "model.slack_attachment.is_valid.action_integration.app_error", nil, "id="+action.Id, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the attachment is well formed on top of IsValid: each field has a title or a value,
+// the color is either good, warning, danger or a hex color code, and there are at most
+// SlackAttachmentMaxActions actions. The error params identify the offending field or value.
+func (s *SlackAttachment) Validate() *AppError {
+	if err := s.IsValid(); err != nil {
+		return err
+	}
+
+	for i, field := range s.Fields {
+		if field == nil || (field.Title == "" && (field.Value == nil || field.Value == "")) {
+			return NewAppError("SlackAttachment.Validate", "model.slack_attachment.validate.field.app_error", map[string]interface{}{"Index": i}, "index="+strconv.Itoa(i), http.StatusBadRequest)
+		}
+	}
+
+	switch s.Color {
+	case "", "good", "warning", "danger":
+	default:
+		if !slackAttachmentColorRegex.MatchString(s.Color) {
+			return NewAppError("SlackAttachment.Validate", "model.slack_attachment.validate.color.app_error", map[string]interface{}{"Color": s.Color}, "color="+s.Color, http.StatusBadRequest)
+		}
+	}
+
+	if len(s.Actions) > SlackAttachmentMaxActions {
+		return NewAppError("SlackAttachment.Validate", "model.slack_attachment.validate.actions.app_error", map[string]interface{}{"Max": SlackAttachmentMaxActions}, "actions="+strconv.Itoa(len(s.Actions)), http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 type SlackAttachmentField struct {
 	Title string              `json:"title"`
 	Value interface{}         `json:"value"`
@@ -4,9 +4,11 @@
 package model
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseSlackAttachment(t *testing.T) {
@@ -42,3 +44,118 @@ func TestParseSlackAttachment(t *testing.T) {
 		assert.Equal(t, expectedPost, post)
 	})
 }
+
+func TestSlackAttachmentIsValid(t *testing.T) {
+	validAction := func() *PostAction {
+		return &PostAction{
+			Type: PostActionTypeSelect,
+			Name: "Pick",
+			Options: []*PostActionOptions{
+				{Text: "One", Value: "1"},
+			},
+			Integration: &PostActionIntegration{URL: "http://localhost"},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(action *PostAction)
+		errID  string
+	}{
+		{"valid", func(action *PostAction) {}, ""},
+		{"default type", func(action *PostAction) { action.Type = "" }, ""},
+		{"nil integration", func(action *PostAction) { action.Integration = nil }, ""},
+		{"missing name", func(action *PostAction) { action.Name = "" }, "model.slack_attachment.is_valid.action_name.app_error"},
+		{"unknown type", func(action *PostAction) { action.Type = "slider" }, "model.slack_attachment.is_valid.action_type.app_error"},
+		{"unknown data source", func(action *PostAction) { action.DataSource = "teams" }, "model.slack_attachment.is_valid.action_data_source.app_error"},
+		{"option without value", func(action *PostAction) { action.Options[0].Value = "" }, "model.slack_attachment.is_valid.action_option.app_error"},
+		{"integration without url", func(action *PostAction) { action.Integration.URL = "" }, "model.slack_attachment.is_valid.action_integration.app_error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			action := validAction()
+			tc.modify(action)
+			attachment := &SlackAttachment{Actions: []*PostAction{action}}
+
+			err := attachment.IsValid()
+			if tc.errID == "" {
+				require.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				require.Equal(t, tc.errID, err.Id)
+			}
+		})
+	}
+
+	t.Run("nil action", func(t *testing.T) {
+		attachment := &SlackAttachment{Actions: []*PostAction{nil}}
+		err := attachment.IsValid()
+		require.NotNil(t, err)
+		require.Equal(t, "model.slack_attachment.is_valid.action.app_error", err.Id)
+	})
+}
+
+func TestSlackAttachmentValidate(t *testing.T) {
+	validAttachment := func() *SlackAttachment {
+		return &SlackAttachment{
+			Color: "#439FE0",
+			Text:  "text",
+			Fields: []*SlackAttachmentField{
+				{Title: "Priority", Value: "High"},
+				{Title: "Acknowledged"},
+				{Value: "Only a value"},
+			},
+			Actions: []*PostAction{
+				{Name: "Ack", Integration: &PostActionIntegration{URL: "http://localhost"}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(attachment *SlackAttachment)
+		errID  string
+	}{
+		{"valid", func(attachment *SlackAttachment) {}, ""},
+		{"no color", func(attachment *SlackAttachment) { attachment.Color = "" }, ""},
+		{"named color", func(attachment *SlackAttachment) { attachment.Color = "warning" }, ""},
+		{"short hex color", func(attachment *SlackAttachment) { attachment.Color = "#fff" }, ""},
+		{"hex color without hash", func(attachment *SlackAttachment) { attachment.Color = "439FE0" }, "model.slack_attachment.validate.color.app_error"},
+		{"invalid hex color", func(attachment *SlackAttachment) { attachment.Color = "#439FEZ" }, "model.slack_attachment.validate.color.app_error"},
+		{"unknown color name", func(attachment *SlackAttachment) { attachment.Color = "red" }, "model.slack_attachment.validate.color.app_error"},
+		{"nil field", func(attachment *SlackAttachment) { attachment.Fields[1] = nil }, "model.slack_attachment.validate.field.app_error"},
+		{"empty field", func(attachment *SlackAttachment) { attachment.Fields[2] = &SlackAttachmentField{Value: ""} }, "model.slack_attachment.validate.field.app_error"},
+		{"invalid action", func(attachment *SlackAttachment) { attachment.Actions[0].Name = "" }, "model.slack_attachment.is_valid.action_name.app_error"},
+		{"too many actions", func(attachment *SlackAttachment) {
+			for len(attachment.Actions) <= SlackAttachmentMaxActions {
+				attachment.Actions = append(attachment.Actions, &PostAction{Name: "Action"})
+			}
+		}, "model.slack_attachment.validate.actions.app_error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attachment := validAttachment()
+			tc.modify(attachment)
+
+			err := attachment.Validate()
+			if tc.errID == "" {
+				require.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				require.Equal(t, tc.errID, err.Id)
+				require.Equal(t, http.StatusBadRequest, err.StatusCode)
+			}
+		})
+	}
+
+	t.Run("the error identifies the field", func(t *testing.T) {
+		attachment := validAttachment()
+		attachment.Fields[2] = &SlackAttachmentField{}
+
+		err := attachment.Validate()
+		require.NotNil(t, err)
+		require.Equal(t, 2, err.params["Index"])
+	})
+}
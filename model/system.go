@@ -31,6 +31,7 @@ const (
 	SystemWarnMetricLastRunTimestampKey    = "LastWarnMetricRunTimestamp"
 	SystemFirstAdminVisitMarketplace       = "FirstAdminVisitMarketplace"
 	SystemFirstAdminSetupComplete          = "FirstAdminSetupComplete"
+	SystemWelcomeMessageEnabledAtKey       = "WelcomeMessageEnabledAt"
 	AwsMeteringReportInterval              = 1
 	AwsMeteringDimensionUsageHrs           = "UsageHrs"
 )
@@ -72,6 +73,17 @@ type ServerBusyState struct {
 	ExpiresTS string `json:"expires_ts,omitempty"`
 }
 
+// ServerTime describes the current time of the server and the timezone it is running in.
+type ServerTime struct {
+	// The current time of the server in milliseconds since the epoch.
+	CurrentTime int64 `json:"current_time"`
+	// The name of the timezone, as an IANA name when the server is configured with one
+	// and as the zone abbreviation otherwise.
+	Timezone string `json:"timezone"`
+	// The offset of the timezone from UTC in seconds.
+	UTCOffset int `json:"utc_offset"`
+}
+
 type SupportPacket struct {
 	ServerOS              string   `yaml:"server_os"`
 	ServerArchitecture    string   `yaml:"server_architecture"`
@@ -4,6 +4,7 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -12,15 +13,17 @@ import (
 )
 
 const (
-	TeamOpen                    = "O"
-	TeamInvite                  = "I"
-	TeamAllowedDomainsMaxLength = 500
-	TeamCompanyNameMaxLength    = 64
-	TeamDescriptionMaxLength    = 255
-	TeamDisplayNameMaxRunes     = 64
-	TeamEmailMaxLength          = 128
-	TeamNameMaxLength           = 64
-	TeamNameMinLength           = 2
+	TeamOpen                     = "O"
+	TeamInvite                   = "I"
+	TeamAllowedDomainsMaxLength  = 500
+	TeamCompanyNameMaxLength     = 64
+	TeamDefaultChannelsMaxLength = 1024
+	TeamDefaultThemeMaxRunes     = 2000
+	TeamDescriptionMaxLength     = 255
+	TeamDisplayNameMaxRunes      = 64
+	TeamEmailMaxLength           = 128
+	TeamNameMaxLength            = 64
+	TeamNameMinLength            = 2
 )
 
 type Team struct {
@@ -42,16 +45,32 @@ type Team struct {
 	GroupConstrained    *bool   `json:"group_constrained"`
 	PolicyID            *string `json:"policy_id"`
 	CloudLimitsArchived bool    `json:"cloud_limits_archived"`
+	// DefaultChannels holds the names of channels which new members are added to on joining the team,
+	// in addition to the channels from DefaultChannelNames.
+	DefaultChannels StringArray `json:"default_channels"`
+	// InviteExpireAt is the time after which the invite link of the team can't be used anymore, or 0 if it never expires.
+	InviteExpireAt int64 `json:"invite_expire_at"`
+	// InviteMaxUses is the number of users who can join the team with its invite link, or 0 if there is no limit.
+	InviteMaxUses int `json:"invite_max_uses"`
+	// InviteUses is the number of users who have joined the team with its invite link since it was generated.
+	InviteUses int `json:"invite_uses"`
+	// DefaultTheme holds the theme, in the same format as the value of a theme preference, given to new members
+	// of the team who haven't picked a theme of their own, or is empty to leave their theme as it is.
+	DefaultTheme string `json:"default_theme"`
 }
 
 type TeamPatch struct {
-	DisplayName         *string `json:"display_name"`
-	Description         *string `json:"description"`
-	CompanyName         *string `json:"company_name"`
-	AllowedDomains      *string `json:"allowed_domains"`
-	AllowOpenInvite     *bool   `json:"allow_open_invite"`
-	GroupConstrained    *bool   `json:"group_constrained"`
-	CloudLimitsArchived *bool   `json:"cloud_limits_archived"`
+	DisplayName         *string      `json:"display_name"`
+	Description         *string      `json:"description"`
+	CompanyName         *string      `json:"company_name"`
+	AllowedDomains      *string      `json:"allowed_domains"`
+	AllowOpenInvite     *bool        `json:"allow_open_invite"`
+	GroupConstrained    *bool        `json:"group_constrained"`
+	CloudLimitsArchived *bool        `json:"cloud_limits_archived"`
+	DefaultChannels     *StringArray `json:"default_channels"`
+	InviteExpireAt      *int64       `json:"invite_expire_at"`
+	InviteMaxUses       *int         `json:"invite_max_uses"`
+	DefaultTheme        *string      `json:"default_theme"`
 }
 
 type TeamForExport struct {
@@ -68,6 +87,12 @@ type TeamsWithCount struct {
 	TotalCount int64   `json:"total_count"`
 }
 
+// TeamWithMemberCount is a team along with the number of its members who haven't been deactivated.
+type TeamWithMemberCount struct {
+	Team
+	ActiveMemberCount int64 `json:"active_member_count"`
+}
+
 func (o *Invites) ToEmailList() []string {
 	emailList := make([]string, len(o.Invites))
 	for _, invite := range o.Invites {
@@ -137,6 +162,31 @@ func (o *Team) IsValid() *AppError {
 		return NewAppError("Team.IsValid", "model.team.is_valid.domains.app_error", nil, "id="+o.Id, http.StatusBadRequest)
 	}
 
+	if len(ArrayToJSON(o.DefaultChannels)) > TeamDefaultChannelsMaxLength {
+		return NewAppError("Team.IsValid", "model.team.is_valid.default_channels.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, channelName := range o.DefaultChannels {
+		if !IsValidChannelIdentifier(channelName) {
+			return NewAppError("Team.IsValid", "model.team.is_valid.default_channels.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	}
+
+	if o.InviteExpireAt < 0 {
+		return NewAppError("Team.IsValid", "model.team.is_valid.invite_expire_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.InviteMaxUses < 0 {
+		return NewAppError("Team.IsValid", "model.team.is_valid.invite_max_uses.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.DefaultTheme != "" {
+		var theme map[string]string
+		if utf8.RuneCountInString(o.DefaultTheme) > TeamDefaultThemeMaxRunes || json.Unmarshal([]byte(o.DefaultTheme), &theme) != nil {
+			return NewAppError("Team.IsValid", "model.team.is_valid.default_theme.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -252,6 +302,32 @@ func (o *Team) Patch(patch *TeamPatch) {
 	if patch.CloudLimitsArchived != nil {
 		o.CloudLimitsArchived = *patch.CloudLimitsArchived
 	}
+
+	if patch.DefaultChannels != nil {
+		o.DefaultChannels = *patch.DefaultChannels
+	}
+
+	if patch.InviteExpireAt != nil {
+		o.InviteExpireAt = *patch.InviteExpireAt
+	}
+
+	if patch.InviteMaxUses != nil {
+		o.InviteMaxUses = *patch.InviteMaxUses
+	}
+
+	if patch.DefaultTheme != nil {
+		o.DefaultTheme = *patch.DefaultTheme
+	}
+}
+
+// IsInviteIdExpired returns whether the invite link of the team had expired at the given time.
+func (o *Team) IsInviteIdExpired(now int64) bool {
+	return o.InviteExpireAt != 0 && now >= o.InviteExpireAt
+}
+
+// IsInviteIdExhausted returns whether the invite link of the team has been used as many times as allowed.
+func (o *Team) IsInviteIdExhausted() bool {
+	return o.InviteMaxUses != 0 && o.InviteUses >= o.InviteMaxUses
 }
 
 func (o *Team) IsGroupConstrained() bool {
@@ -48,6 +48,71 @@ func TestTeamIsValid(t *testing.T) {
 	o.InviteId = NewId()
 	err = o.IsValid()
 	require.Nil(t, err, err)
+
+	o.DefaultChannels = StringArray{"off-topic", "Not A Channel"}
+	err = o.IsValid()
+	require.NotNil(t, err, "should be invalid")
+
+	o.DefaultChannels = StringArray{}
+	for i := 0; i < 50; i++ {
+		o.DefaultChannels = append(o.DefaultChannels, strings.Repeat("a", 25))
+	}
+	err = o.IsValid()
+	require.NotNil(t, err, "should be invalid")
+
+	o.DefaultChannels = StringArray{"off-topic", "announcements"}
+	err = o.IsValid()
+	require.Nil(t, err, err)
+
+	o.InviteExpireAt = -1
+	err = o.IsValid()
+	require.NotNil(t, err, "should be invalid")
+
+	o.InviteExpireAt = GetMillis()
+	o.InviteMaxUses = -1
+	err = o.IsValid()
+	require.NotNil(t, err, "should be invalid")
+
+	o.InviteMaxUses = 10
+	err = o.IsValid()
+	require.Nil(t, err, err)
+
+	o.DefaultTheme = "not a theme"
+	err = o.IsValid()
+	require.NotNil(t, err, "should be invalid")
+
+	o.DefaultTheme = `{"sidebarBg":"` + strings.Repeat("a", TeamDefaultThemeMaxRunes) + `"}`
+	err = o.IsValid()
+	require.NotNil(t, err, "should be invalid")
+
+	o.DefaultTheme = `{"sidebarBg":"#145dbf","type":"Custom Theme"}`
+	err = o.IsValid()
+	require.Nil(t, err, err)
+}
+
+func TestTeamInviteIdLimits(t *testing.T) {
+	now := GetMillis()
+
+	t.Run("no limits", func(t *testing.T) {
+		o := Team{InviteUses: 100}
+		require.False(t, o.IsInviteIdExpired(now))
+		require.False(t, o.IsInviteIdExhausted())
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		o := Team{InviteExpireAt: now + 1000}
+		require.False(t, o.IsInviteIdExpired(now))
+		require.True(t, o.IsInviteIdExpired(now+1000))
+		require.True(t, o.IsInviteIdExpired(now+2000))
+	})
+
+	t.Run("max uses", func(t *testing.T) {
+		o := Team{InviteMaxUses: 2, InviteUses: 1}
+		require.False(t, o.IsInviteIdExhausted())
+
+		o.InviteUses = 2
+		require.True(t, o.IsInviteIdExhausted())
+	})
 }
 
 func TestTeamPreSave(t *testing.T) {
@@ -122,6 +187,9 @@ func TestTeamPatch(t *testing.T) {
 		AllowedDomains:   new(string),
 		AllowOpenInvite:  new(bool),
 		GroupConstrained: new(bool),
+		InviteExpireAt:   new(int64),
+		InviteMaxUses:    new(int),
+		DefaultTheme:     new(string),
 	}
 
 	*p.DisplayName = NewId()
@@ -130,6 +198,9 @@ func TestTeamPatch(t *testing.T) {
 	*p.AllowedDomains = NewId()
 	*p.AllowOpenInvite = true
 	*p.GroupConstrained = true
+	*p.InviteExpireAt = GetMillis()
+	*p.InviteMaxUses = 5
+	*p.DefaultTheme = `{"sidebarBg":"#145dbf"}`
 
 	o := Team{Id: NewId()}
 	o.Patch(p)
@@ -140,4 +211,7 @@ func TestTeamPatch(t *testing.T) {
 	require.Equal(t, *p.AllowedDomains, o.AllowedDomains, "AllowedDomains did not update")
 	require.Equal(t, *p.AllowOpenInvite, o.AllowOpenInvite, "AllowOpenInvite did not update")
 	require.Equal(t, *p.GroupConstrained, *o.GroupConstrained)
+	require.Equal(t, *p.InviteExpireAt, o.InviteExpireAt)
+	require.Equal(t, *p.InviteMaxUses, o.InviteMaxUses)
+	require.Equal(t, *p.DefaultTheme, o.DefaultTheme)
 }
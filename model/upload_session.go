@@ -47,6 +47,21 @@ type UploadSession struct {
 	ReqFileId string `json:"req_file_id"`
 }
 
+// PresignedUpload contains the information needed by a client to upload a file
+// directly to the file store instead of sending it through the server.
+type PresignedUpload struct {
+	// The upload session tracking the file.
+	UploadSession *UploadSession `json:"upload_session"`
+	// The URL to which the file should be uploaded with a PUT request. If empty,
+	// the file store doesn't support direct uploads and the data should be sent
+	// to the server through the upload session instead.
+	URL string `json:"url,omitempty"`
+	// The headers that must be sent along with the upload request.
+	Headers map[string]string `json:"headers,omitempty"`
+	// The timestamp after which the URL can no longer be used.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
+
 // PreSave is a utility function used to fill required information.
 func (us *UploadSession) PreSave() {
 	if us.Id == "" {
@@ -43,6 +43,8 @@ const (
 	FirstNameNotifyProp            = "first_name"
 	AutoResponderActiveNotifyProp  = "auto_responder_active"
 	AutoResponderMessageNotifyProp = "auto_responder_message"
+	AutoResponderStartNotifyProp   = "auto_responder_start"
+	AutoResponderEndNotifyProp     = "auto_responder_end"
 	DesktopThreadsNotifyProp       = "desktop_threads"
 	PushThreadsNotifyProp          = "push_threads"
 	EmailThreadsNotifyProp         = "email_threads"
@@ -91,6 +93,7 @@ type User struct {
 	LastPasswordUpdate     int64     `json:"last_password_update,omitempty"`
 	LastPictureUpdate      int64     `json:"last_picture_update,omitempty"`
 	FailedAttempts         int       `json:"failed_attempts,omitempty"`
+	LastFailedAttemptAt    int64     `json:"last_failed_attempt_at,omitempty"`
 	Locale                 string    `json:"locale"`
 	Timezone               StringMap `json:"timezone"`
 	MfaActive              bool      `json:"mfa_active,omitempty"`
@@ -591,6 +594,7 @@ func (u *User) SanitizeInput(isAdmin bool) {
 	u.LastPasswordUpdate = 0
 	u.LastPictureUpdate = 0
 	u.FailedAttempts = 0
+	u.LastFailedAttemptAt = 0
 	u.MfaActive = false
 	u.MfaSecret = ""
 	u.Email = strings.TrimSpace(u.Email)
@@ -605,6 +609,7 @@ func (u *User) ClearNonProfileFields() {
 	u.NotifyProps = StringMap{}
 	u.LastPasswordUpdate = 0
 	u.FailedAttempts = 0
+	u.LastFailedAttemptAt = 0
 }
 
 func (u *User) SanitizeProfile(options map[string]bool) {
@@ -959,3 +964,14 @@ type UsersWithGroupsAndCount struct {
 	Users []*UserWithGroups `json:"users"`
 	Count int64             `json:"total_count"`
 }
+
+// PasswordFailureReason describes one of the ways in which a password fails to satisfy the
+// password policy.
+//
+//msgp:ignore PasswordFailureReason
+type PasswordFailureReason struct {
+	// Id is the translation id of the message explaining the failure.
+	Id string `json:"id"`
+	// Params holds the values interpolated into the translated message.
+	Params map[string]interface{} `json:"params,omitempty"`
+}
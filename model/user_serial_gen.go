@@ -17,8 +17,8 @@ func (z *User) DecodeMsg(dc *msgp.Reader) (err error) {
 		err = msgp.WrapError(err)
 		return
 	}
-	if zb0001 != 33 {
-		err = msgp.ArrayError{Wanted: 33, Got: zb0001}
+	if zb0001 != 34 {
+		err = msgp.ArrayError{Wanted: 34, Got: zb0001}
 		return
 	}
 	z.Id, err = dc.ReadString()
@@ -138,6 +138,11 @@ func (z *User) DecodeMsg(dc *msgp.Reader) (err error) {
 		err = msgp.WrapError(err, "FailedAttempts")
 		return
 	}
+	z.LastFailedAttemptAt, err = dc.ReadInt64()
+	if err != nil {
+		err = msgp.WrapError(err, "LastFailedAttemptAt")
+		return
+	}
 	z.Locale, err = dc.ReadString()
 	if err != nil {
 		err = msgp.WrapError(err, "Locale")
@@ -215,8 +220,8 @@ func (z *User) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *User) EncodeMsg(en *msgp.Writer) (err error) {
-	// array header, size 33
-	err = en.Append(0xdc, 0x0, 0x21)
+	// array header, size 34
+	err = en.Append(0xdc, 0x0, 0x22)
 	if err != nil {
 		return
 	}
@@ -332,6 +337,11 @@ func (z *User) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "FailedAttempts")
 		return
 	}
+	err = en.WriteInt64(z.LastFailedAttemptAt)
+	if err != nil {
+		err = msgp.WrapError(err, "LastFailedAttemptAt")
+		return
+	}
 	err = en.WriteString(z.Locale)
 	if err != nil {
 		err = msgp.WrapError(err, "Locale")
@@ -405,8 +415,8 @@ func (z *User) EncodeMsg(en *msgp.Writer) (err error) {
 // MarshalMsg implements msgp.Marshaler
 func (z *User) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// array header, size 33
-	o = append(o, 0xdc, 0x0, 0x21)
+	// array header, size 34
+	o = append(o, 0xdc, 0x0, 0x22)
 	o = msgp.AppendString(o, z.Id)
 	o = msgp.AppendInt64(o, z.CreateAt)
 	o = msgp.AppendInt64(o, z.UpdateAt)
@@ -440,6 +450,7 @@ func (z *User) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.AppendInt64(o, z.LastPasswordUpdate)
 	o = msgp.AppendInt64(o, z.LastPictureUpdate)
 	o = msgp.AppendInt(o, z.FailedAttempts)
+	o = msgp.AppendInt64(o, z.LastFailedAttemptAt)
 	o = msgp.AppendString(o, z.Locale)
 	o, err = z.Timezone.MarshalMsg(o)
 	if err != nil {
@@ -471,8 +482,8 @@ func (z *User) UnmarshalMsg(bts []byte) (o []byte, err error) {
 		err = msgp.WrapError(err)
 		return
 	}
-	if zb0001 != 33 {
-		err = msgp.ArrayError{Wanted: 33, Got: zb0001}
+	if zb0001 != 34 {
+		err = msgp.ArrayError{Wanted: 34, Got: zb0001}
 		return
 	}
 	z.Id, bts, err = msgp.ReadStringBytes(bts)
@@ -591,6 +602,11 @@ func (z *User) UnmarshalMsg(bts []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "FailedAttempts")
 		return
 	}
+	z.LastFailedAttemptAt, bts, err = msgp.ReadInt64Bytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "LastFailedAttemptAt")
+		return
+	}
 	z.Locale, bts, err = msgp.ReadStringBytes(bts)
 	if err != nil {
 		err = msgp.WrapError(err, "Locale")
@@ -674,7 +690,7 @@ func (z *User) Msgsize() (s int) {
 	} else {
 		s += msgp.StringPrefixSize + len(*z.AuthData)
 	}
-	s += msgp.StringPrefixSize + len(z.AuthService) + msgp.StringPrefixSize + len(z.Email) + msgp.BoolSize + msgp.StringPrefixSize + len(z.Nickname) + msgp.StringPrefixSize + len(z.FirstName) + msgp.StringPrefixSize + len(z.LastName) + msgp.StringPrefixSize + len(z.Position) + msgp.StringPrefixSize + len(z.Roles) + msgp.BoolSize + z.Props.Msgsize() + z.NotifyProps.Msgsize() + msgp.Int64Size + msgp.Int64Size + msgp.IntSize + msgp.StringPrefixSize + len(z.Locale) + z.Timezone.Msgsize() + msgp.BoolSize + msgp.StringPrefixSize + len(z.MfaSecret)
+	s += msgp.StringPrefixSize + len(z.AuthService) + msgp.StringPrefixSize + len(z.Email) + msgp.BoolSize + msgp.StringPrefixSize + len(z.Nickname) + msgp.StringPrefixSize + len(z.FirstName) + msgp.StringPrefixSize + len(z.LastName) + msgp.StringPrefixSize + len(z.Position) + msgp.StringPrefixSize + len(z.Roles) + msgp.BoolSize + z.Props.Msgsize() + z.NotifyProps.Msgsize() + msgp.Int64Size + msgp.Int64Size + msgp.IntSize + msgp.Int64Size + msgp.StringPrefixSize + len(z.Locale) + z.Timezone.Msgsize() + msgp.BoolSize + msgp.StringPrefixSize + len(z.MfaSecret)
 	if z.RemoteId == nil {
 		s += msgp.NilSize
 	} else {
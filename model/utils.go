@@ -195,6 +195,23 @@ func AppErrorInit(t i18n.TranslateFunc) {
 	})
 }
 
+// Ids of the AppErrors most commonly checked for by callers and clients. They are meant for matching
+// errors, e.g. with Is; code creating these errors keeps the literal id so that i18n-extract finds it.
+const (
+	AppErrorIdSessionExpired       = "api.context.session_expired.app_error"
+	AppErrorIdPermissions          = "api.context.permissions.app_error"
+	AppErrorIdMfaRequired          = "api.context.mfa_required.app_error"
+	AppErrorIdInvalidBodyParam     = "api.context.invalid_body_param.app_error"
+	AppErrorIdInvalidURLParam      = "api.context.invalid_url_param.app_error"
+	AppErrorIdServerBusy           = "api.context.server_busy.app_error"
+	AppErrorIdMarshal              = "api.marshal_error"
+	AppErrorIdUnmarshal            = "api.unmarshal_error"
+	AppErrorIdInvalidPassword      = "api.user.check_user_password.invalid.app_error"
+	AppErrorIdChannelMemberMissing = "app.channel.get_member.missing.app_error"
+	AppErrorIdTeamMemberMissing    = "app.team.get_member.missing.app_error"
+	AppErrorIdDecodeJSON           = "model.utils.decode_json.app_error"
+)
+
 type AppError struct {
 	Id            string `json:"id"`
 	Message       string `json:"message"`               // Message to be display to the end user without debugging information
@@ -204,10 +221,44 @@ type AppError struct {
 	Where         string `json:"-"`                     // The function where it happened in the form of Struct.Func
 	IsOAuth       bool   `json:"is_oauth,omitempty"`    // Whether the error is OAuth specific
 	params        map[string]interface{}
+	wrapped       error
 }
 
 func (er *AppError) Error() string {
-	return er.Where + ": " + er.Message + ", " + er.DetailedError
+	msg := er.Where + ": " + er.Message + ", " + er.DetailedError
+	if er.wrapped != nil {
+		msg += ", " + er.wrapped.Error()
+	}
+	return msg
+}
+
+// Wrap records err as the cause of the AppError so that it can be found with errors.Is and errors.As.
+func (er *AppError) Wrap(err error) *AppError {
+	er.wrapped = err
+	return er
+}
+
+// Unwrap returns the error wrapped by the AppError, if any.
+func (er *AppError) Unwrap() error {
+	if er == nil {
+		return nil
+	}
+	return er.wrapped
+}
+
+// Is reports whether target is an AppError with the same id, so that errors.Is can match an
+// AppError by its id.
+func (er *AppError) Is(target error) bool {
+	appErr, ok := target.(*AppError)
+	if !ok || er == nil || appErr == nil {
+		return false
+	}
+	return appErr.Id != "" && appErr.Id == er.Id
+}
+
+// Is reports whether err is, or wraps, an AppError with the given id.
+func Is(err error, id string) bool {
+	return errors.Is(err, &AppError{Id: id})
 }
 
 func (er *AppError) Translate(T i18n.TranslateFunc) {
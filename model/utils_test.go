@@ -5,6 +5,7 @@ package model
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -80,6 +81,54 @@ func TestAppError(t *testing.T) {
 	t.Log(err.Error())
 }
 
+func TestAppErrorWrap(t *testing.T) {
+	cause := errors.New("connection refused")
+	appErr := NewAppError("TestAppErrorWrap", "app.test.app_error", nil, "details", http.StatusInternalServerError).Wrap(cause)
+
+	assert.True(t, errors.Is(appErr, cause))
+	assert.Equal(t, cause, appErr.Unwrap())
+	assert.Contains(t, appErr.Error(), "details")
+	assert.Contains(t, appErr.Error(), "connection refused")
+
+	assert.Nil(t, NewAppError("TestAppErrorWrap", "app.test.app_error", nil, "", http.StatusInternalServerError).Unwrap())
+}
+
+func TestAppErrorIs(t *testing.T) {
+	appErr := NewAppError("TestAppErrorIs", AppErrorIdPermissions, nil, "", http.StatusForbidden)
+	wrapped := fmt.Errorf("checking permissions: %w", appErr)
+
+	t.Run("matches by id", func(t *testing.T) {
+		assert.True(t, Is(appErr, AppErrorIdPermissions))
+		assert.True(t, Is(wrapped, AppErrorIdPermissions))
+		assert.True(t, errors.Is(wrapped, &AppError{Id: AppErrorIdPermissions}))
+
+		assert.False(t, Is(appErr, AppErrorIdSessionExpired))
+		assert.False(t, Is(appErr, ""))
+		assert.False(t, Is(errors.New(AppErrorIdPermissions), AppErrorIdPermissions))
+		assert.False(t, Is(nil, AppErrorIdPermissions))
+	})
+
+	t.Run("matches an AppError wrapped by another", func(t *testing.T) {
+		outer := NewAppError("TestAppErrorIs", "app.test.app_error", nil, "", http.StatusInternalServerError).Wrap(wrapped)
+
+		assert.True(t, Is(outer, "app.test.app_error"))
+		assert.True(t, Is(outer, AppErrorIdPermissions))
+	})
+
+	t.Run("errors.As finds the AppError", func(t *testing.T) {
+		var target *AppError
+		require.True(t, errors.As(wrapped, &target))
+		assert.Equal(t, AppErrorIdPermissions, target.Id)
+		assert.Equal(t, http.StatusForbidden, target.StatusCode)
+	})
+
+	t.Run("nil AppError", func(t *testing.T) {
+		var nilErr *AppError
+		assert.False(t, Is(nilErr, AppErrorIdPermissions))
+		assert.Nil(t, nilErr.Unwrap())
+	})
+}
+
 func TestAppErrorJunk(t *testing.T) {
 	rerr := AppErrorFromJSON(strings.NewReader("<html><body>This is a broken test</body></html>"))
 	require.Equal(t, "body: <html><body>This is a broken test</body></html>", rerr.DetailedError)
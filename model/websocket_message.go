@@ -4,7 +4,9 @@
 package model
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 )
@@ -43,6 +45,7 @@ const (
 	WebsocketEventStatusChange                        = "status_change"
 	WebsocketEventHello                               = "hello"
 	WebsocketAuthenticationChallenge                  = "authentication_challenge"
+	WebsocketReconnect                                = "reconnect"
 	WebsocketEventReactionAdded                       = "reaction_added"
 	WebsocketEventReactionRemoved                     = "reaction_removed"
 	WebsocketEventResponse                            = "response"
@@ -77,8 +80,162 @@ const (
 	WebsocketEventThreadReadChanged                   = "thread_read_changed"
 	WebsocketFirstAdminVisitMarketplaceStatusReceived = "first_admin_visit_marketplace_status_received"
 	WebsocketEventIntegrationsUsageChanged            = "integrations_usage_changed"
+	WebsocketEventChannelViewerChanged                = "channel_viewer_changed"
+	WebsocketEventChannelSnoozeUpdated                = "channel_snooze_updated"
 )
 
+// WebSocketEventType is the type of the event names carried by a WebSocketEvent.
+// It doesn't catch a misspelt event name at compile time: the untyped Websocket*
+// constants and string literals alike convert to it implicitly, and only string
+// variables need an explicit conversion. Misspelt names are instead rejected by
+// WebSocketEventBuilder.Build in debug mode, which checks them against IsKnown.
+type WebSocketEventType string
+
+// IsKnown reports whether t is one of the event types declared by this package.
+func (t WebSocketEventType) IsKnown() bool {
+	switch t {
+	case WebsocketEventTyping,
+		WebsocketEventPosted,
+		WebsocketEventPostEdited,
+		WebsocketEventPostDeleted,
+		WebsocketEventPostUnread,
+		WebsocketEventChannelConverted,
+		WebsocketEventChannelCreated,
+		WebsocketEventChannelDeleted,
+		WebsocketEventChannelRestored,
+		WebsocketEventChannelUpdated,
+		WebsocketEventChannelMemberUpdated,
+		WebsocketEventChannelSchemeUpdated,
+		WebsocketEventDirectAdded,
+		WebsocketEventGroupAdded,
+		WebsocketEventNewUser,
+		WebsocketEventAddedToTeam,
+		WebsocketEventLeaveTeam,
+		WebsocketEventUpdateTeam,
+		WebsocketEventDeleteTeam,
+		WebsocketEventRestoreTeam,
+		WebsocketEventUpdateTeamScheme,
+		WebsocketEventUserAdded,
+		WebsocketEventUserUpdated,
+		WebsocketEventUserRoleUpdated,
+		WebsocketEventMemberroleUpdated,
+		WebsocketEventUserRemoved,
+		WebsocketEventPreferenceChanged,
+		WebsocketEventPreferencesChanged,
+		WebsocketEventPreferencesDeleted,
+		WebsocketEventEphemeralMessage,
+		WebsocketEventStatusChange,
+		WebsocketEventHello,
+		WebsocketAuthenticationChallenge,
+		WebsocketReconnect,
+		WebsocketEventReactionAdded,
+		WebsocketEventReactionRemoved,
+		WebsocketEventResponse,
+		WebsocketEventEmojiAdded,
+		WebsocketEventChannelViewed,
+		WebsocketEventPluginStatusesChanged,
+		WebsocketEventPluginEnabled,
+		WebsocketEventPluginDisabled,
+		WebsocketEventRoleUpdated,
+		WebsocketEventLicenseChanged,
+		WebsocketEventConfigChanged,
+		WebsocketEventOpenDialog,
+		WebsocketEventGuestsDeactivated,
+		WebsocketEventUserActivationStatusChange,
+		WebsocketEventReceivedGroup,
+		WebsocketEventReceivedGroupAssociatedToTeam,
+		WebsocketEventReceivedGroupNotAssociatedToTeam,
+		WebsocketEventReceivedGroupAssociatedToChannel,
+		WebsocketEventReceivedGroupNotAssociatedToChannel,
+		WebsocketEventGroupMemberDelete,
+		WebsocketEventGroupMemberAdd,
+		WebsocketEventSidebarCategoryCreated,
+		WebsocketEventSidebarCategoryUpdated,
+		WebsocketEventSidebarCategoryDeleted,
+		WebsocketEventSidebarCategoryOrderUpdated,
+		WebsocketWarnMetricStatusReceived,
+		WebsocketWarnMetricStatusRemoved,
+		WebsocketEventCloudPaymentStatusUpdated,
+		WebsocketEventCloudSubscriptionChanged,
+		WebsocketEventThreadUpdated,
+		WebsocketEventThreadFollowChanged,
+		WebsocketEventThreadReadChanged,
+		WebsocketFirstAdminVisitMarketplaceStatusReceived,
+		WebsocketEventIntegrationsUsageChanged,
+		WebsocketEventChannelViewerChanged,
+		WebsocketEventChannelSnoozeUpdated:
+		return true
+	}
+	return false
+}
+
+// WebSocketEventBuilder builds a WebSocketEvent for a given event type.
+type WebSocketEventBuilder struct {
+	event     WebSocketEventType
+	teamID    string
+	channelID string
+	userID    string
+	omitUsers map[string]bool
+	data      map[string]interface{}
+	debug     bool
+}
+
+// NewWebSocketEventBuilder returns a builder for an event of the given type.
+func NewWebSocketEventBuilder(event WebSocketEventType) *WebSocketEventBuilder {
+	return &WebSocketEventBuilder{
+		event: event,
+		data:  make(map[string]interface{}),
+	}
+}
+
+// Debug enables the rejection of unknown event types in Build.
+func (b *WebSocketEventBuilder) Debug(debug bool) *WebSocketEventBuilder {
+	b.debug = debug
+	return b
+}
+
+func (b *WebSocketEventBuilder) TeamID(teamID string) *WebSocketEventBuilder {
+	b.teamID = teamID
+	return b
+}
+
+func (b *WebSocketEventBuilder) ChannelID(channelID string) *WebSocketEventBuilder {
+	b.channelID = channelID
+	return b
+}
+
+func (b *WebSocketEventBuilder) UserID(userID string) *WebSocketEventBuilder {
+	b.userID = userID
+	return b
+}
+
+func (b *WebSocketEventBuilder) OmitUsers(omitUsers map[string]bool) *WebSocketEventBuilder {
+	b.omitUsers = omitUsers
+	return b
+}
+
+func (b *WebSocketEventBuilder) Add(key string, value interface{}) *WebSocketEventBuilder {
+	b.data[key] = value
+	return b
+}
+
+// Build returns the event. An empty event type is always rejected, and in debug
+// mode so is any event type not declared by this package.
+func (b *WebSocketEventBuilder) Build() (*WebSocketEvent, error) {
+	if b.event == "" {
+		return nil, errors.New("websocket event type is empty")
+	}
+	if b.debug && !b.event.IsKnown() {
+		return nil, fmt.Errorf("unknown websocket event type %q", b.event)
+	}
+
+	ev := NewWebSocketEvent(string(b.event), b.teamID, b.channelID, b.userID, b.omitUsers)
+	for key, value := range b.data {
+		ev.Add(key, value)
+	}
+	return ev, nil
+}
+
 type WebSocketMessage interface {
 	ToJSON() ([]byte, error)
 	IsValid() bool
@@ -299,6 +456,23 @@ func (ev *WebSocketEvent) Encode(enc *json.Encoder) error {
 	})
 }
 
+// GobEncode encodes the event as JSON since none of its fields are exported. This allows events to
+// be passed to and returned from plugin hooks.
+func (ev *WebSocketEvent) GobEncode() ([]byte, error) {
+	return ev.ToJSON()
+}
+
+// GobDecode decodes an event encoded by GobEncode.
+func (ev *WebSocketEvent) GobDecode(data []byte) error {
+	decoded, err := WebSocketEventFromJSON(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	*ev = *decoded
+	return nil
+}
+
 func WebSocketEventFromJSON(data io.Reader) (*WebSocketEvent, error) {
 	var ev WebSocketEvent
 	var o webSocketEventJSON
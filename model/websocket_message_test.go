@@ -5,6 +5,12 @@ package model
 
 import (
 	"bytes"
+	"encoding/gob"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -89,6 +95,29 @@ func TestWebSocketEventFromJSON(t *testing.T) {
 	require.Equal(t, ev.GetBroadcast(), &WebsocketBroadcast{UserId: "userid"})
 }
 
+func TestWebSocketEventGob(t *testing.T) {
+	ev := NewWebSocketEvent("test", "teamid", "channelid", "", nil)
+	ev.Add("key", "val")
+
+	for name, ev := range map[string]*WebSocketEvent{
+		"plain":       ev.SetSequence(45),
+		"precomputed": ev.PrecomputeJSON().SetSequence(45),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, gob.NewEncoder(&buf).Encode(ev))
+
+			var decoded *WebSocketEvent
+			require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+			require.Equal(t, "test", decoded.EventType())
+			require.Equal(t, int64(45), decoded.GetSequence())
+			require.Equal(t, map[string]interface{}{"key": "val"}, decoded.GetData())
+			require.Equal(t, "teamid", decoded.GetBroadcast().TeamId)
+			require.Equal(t, "channelid", decoded.GetBroadcast().ChannelId)
+		})
+	}
+}
+
 func TestWebSocketResponse(t *testing.T) {
 	m := NewWebSocketResponse("OK", 1, map[string]interface{}{})
 	e := NewWebSocketError(1, &AppError{})
@@ -234,3 +263,76 @@ func TestWebSocketEventDeepCopy(t *testing.T) {
 	})
 	require.NotEqual(t, ev.data, evCopy.data)
 }
+
+func TestWebSocketEventBuilder(t *testing.T) {
+	t.Run("builds the same event as NewWebSocketEvent", func(t *testing.T) {
+		teamID, channelID, userID := NewId(), NewId(), NewId()
+		omitUsers := map[string]bool{NewId(): true}
+
+		ev, err := NewWebSocketEventBuilder(WebsocketEventPosted).
+			Debug(true).
+			TeamID(teamID).
+			ChannelID(channelID).
+			UserID(userID).
+			OmitUsers(omitUsers).
+			Add("post", "{}").
+			Build()
+		require.NoError(t, err)
+
+		expected := NewWebSocketEvent(WebsocketEventPosted, teamID, channelID, userID, omitUsers)
+		expected.Add("post", "{}")
+
+		expectedJSON, err := expected.ToJSON()
+		require.NoError(t, err)
+		actualJSON, err := ev.ToJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, string(expectedJSON), string(actualJSON))
+	})
+
+	t.Run("rejects an empty event type", func(t *testing.T) {
+		_, err := NewWebSocketEventBuilder("").Build()
+		require.Error(t, err)
+	})
+
+	t.Run("rejects unknown event types in debug mode", func(t *testing.T) {
+		_, err := NewWebSocketEventBuilder("not_an_event").Debug(true).Build()
+		require.Error(t, err)
+	})
+
+	t.Run("allows unknown event types outside of debug mode", func(t *testing.T) {
+		ev, err := NewWebSocketEventBuilder("custom_plugin_event").Build()
+		require.NoError(t, err)
+		require.Equal(t, "custom_plugin_event", ev.EventType())
+	})
+}
+
+func TestWebSocketEventTypeIsKnown(t *testing.T) {
+	// Every event constant declared in websocket_message.go must be handled by IsKnown.
+	f, err := parser.ParseFile(token.NewFileSet(), "websocket_message.go", nil, 0)
+	require.NoError(t, err)
+
+	var count int
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if !strings.HasPrefix(name.Name, "Websocket") {
+					continue
+				}
+				lit, ok := valueSpec.Values[i].(*ast.BasicLit)
+				require.True(t, ok)
+				value, err := strconv.Unquote(lit.Value)
+				require.NoError(t, err)
+				assert.True(t, WebSocketEventType(value).IsKnown(), "%s is missing from WebSocketEventType.IsKnown", name.Name)
+				count++
+			}
+		}
+	}
+	require.NotZero(t, count)
+
+	require.False(t, WebSocketEventType("not_an_event").IsKnown())
+}
@@ -935,7 +935,8 @@ type API interface {
 	// PublishWebSocketEvent sends an event to WebSocket connections.
 	// event is the type and will be prepended with "custom_<pluginid>_".
 	// payload is the data sent with the event. Interface values must be primitive Go types or mattermost-server/model types.
-	// broadcast determines to which users to send the event.
+	// broadcast determines to which users to send the event. Set only broadcast.TeamId to send
+	// the event to the connections of the users in that team.
 	//
 	// Minimum server version: 5.2
 	PublishWebSocketEvent(event string, payload map[string]interface{}, broadcast *model.WebsocketBroadcast)
@@ -807,6 +807,40 @@ func (s *hooksRPCServer) OnCloudLimitsUpdated(args *Z_OnCloudLimitsUpdatedArgs,
 	return nil
 }
 
+func init() {
+	hookNameToId["WebSocketEventWillBeSent"] = WebSocketEventWillBeSentID
+}
+
+type Z_WebSocketEventWillBeSentArgs struct {
+	A *model.WebSocketEvent
+}
+
+type Z_WebSocketEventWillBeSentReturns struct {
+	A *model.WebSocketEvent
+}
+
+func (g *hooksRPCClient) WebSocketEventWillBeSent(event *model.WebSocketEvent) *model.WebSocketEvent {
+	_args := &Z_WebSocketEventWillBeSentArgs{event}
+	_returns := &Z_WebSocketEventWillBeSentReturns{}
+	if g.implemented[WebSocketEventWillBeSentID] {
+		if err := g.client.Call("Plugin.WebSocketEventWillBeSent", _args, _returns); err != nil {
+			g.log.Error("RPC call WebSocketEventWillBeSent to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) WebSocketEventWillBeSent(args *Z_WebSocketEventWillBeSentArgs, returns *Z_WebSocketEventWillBeSentReturns) error {
+	if hook, ok := s.impl.(interface {
+		WebSocketEventWillBeSent(event *model.WebSocketEvent) *model.WebSocketEvent
+	}); ok {
+		returns.A = hook.WebSocketEventWillBeSent(args.A)
+	} else {
+		return encodableError(fmt.Errorf("Hook WebSocketEventWillBeSent called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
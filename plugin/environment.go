@@ -465,6 +465,35 @@ func (env *Environment) HooksForPlugin(id string) (Hooks, error) {
 	return nil, fmt.Errorf("plugin not found: %v", id)
 }
 
+// IsHookImplemented returns whether any active plugin or product implements the given hook. It lets
+// callers of frequently invoked hooks skip preparing the hook's arguments when nobody would use them.
+func (env *Environment) IsHookImplemented(hookId int) bool {
+	implemented := false
+
+	env.registeredPlugins.Range(func(key, value interface{}) bool {
+		rp := value.(registeredPlugin)
+		if rp.supervisor != nil && rp.supervisor.Implements(hookId) && env.IsActive(rp.BundleInfo.Manifest.Id) {
+			implemented = true
+			return false
+		}
+		return true
+	})
+
+	if implemented {
+		return true
+	}
+
+	env.registeredProducts.Range(func(key, value interface{}) bool {
+		if value.(*registeredProduct).Implements(hookId) {
+			implemented = true
+			return false
+		}
+		return true
+	})
+
+	return implemented
+}
+
 // RunMultiPluginHook invokes hookRunnerFunc for each active plugin that implements the given hookId.
 //
 // If hookRunnerFunc returns false, iteration will not continue. The iteration order among active
@@ -43,6 +43,7 @@ const (
 	OnInstallID                     = 25
 	OnSendDailyTelemetryID          = 26
 	OnCloudLimitsUpdatedID          = 27
+	WebSocketEventWillBeSentID      = 28
 	TotalHooksID                    = iota
 )
 
@@ -270,4 +271,17 @@ type Hooks interface {
 	//
 	// Minimum server version: 7.0
 	OnCloudLimitsUpdated(limits *model.ProductLimits)
+
+	// WebSocketEventWillBeSent is invoked once for each websocket event before it is broadcast to
+	// the connections of this server.
+	//
+	// To send the event unmodified, return the given event. To change what is sent, return a
+	// modified event, e.g. built with SetData. To keep it from particular users or connections,
+	// return an event whose broadcast omits them, e.g. built with SetBroadcast. To prevent the
+	// event from being sent at all, return nil.
+	//
+	// The hook only has a short time to return. If it takes longer, the event is not sent.
+	//
+	// Minimum server version: 7.1
+	WebSocketEventWillBeSent(event *model.WebSocketEvent) *model.WebSocketEvent
 }
@@ -212,3 +212,10 @@ func (hooks *hooksTimerLayer) OnCloudLimitsUpdated(limits *model.ProductLimits)
 	hooks.hooksImpl.OnCloudLimitsUpdated(limits)
 	hooks.recordTime(startTime, "OnCloudLimitsUpdated", true)
 }
+
+func (hooks *hooksTimerLayer) WebSocketEventWillBeSent(event *model.WebSocketEvent) *model.WebSocketEvent {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.WebSocketEventWillBeSent(event)
+	hooks.recordTime(startTime, "WebSocketEventWillBeSent", true)
+	return _returnsA
+}
@@ -313,6 +313,22 @@ func (_m *Hooks) UserWillLogIn(c *plugin.Context, user *model.User) string {
 	return r0
 }
 
+// WebSocketEventWillBeSent provides a mock function with given fields: event
+func (_m *Hooks) WebSocketEventWillBeSent(event *model.WebSocketEvent) *model.WebSocketEvent {
+	ret := _m.Called(event)
+
+	var r0 *model.WebSocketEvent
+	if rf, ok := ret.Get(0).(func(*model.WebSocketEvent) *model.WebSocketEvent); ok {
+		r0 = rf(event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WebSocketEvent)
+		}
+	}
+
+	return r0
+}
+
 // WebSocketMessageHasBeenPosted provides a mock function with given fields: webConnID, userID, req
 func (_m *Hooks) WebSocketMessageHasBeenPosted(webConnID string, userID string, req *model.WebSocketRequest) {
 	_m.Called(webConnID, userID, req)
@@ -145,3 +145,7 @@ func (a *hooksAdapter) OnInstall(c *Context, event model.OnInstallEvent) error {
 func (a *hooksAdapter) OnSendDailyTelemetry() {}
 
 func (a *hooksAdapter) OnCloudLimitsUpdated(limits *model.ProductLimits) {}
+
+func (a *hooksAdapter) WebSocketEventWillBeSent(event *model.WebSocketEvent) *model.WebSocketEvent {
+	return event
+}
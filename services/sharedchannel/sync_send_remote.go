@@ -208,7 +208,7 @@ func (scs *Service) fetchPostsForSync(sd *syncData) error {
 	// in the list and must be sync'd before the child post. This is and edge case that likely only
 	// happens during load testing or bulk imports.
 	for _, p := range posts {
-		if p.RootId != "" {
+		if p.IsReply() {
 			root, err := scs.server.GetStore().Post().GetSingle(p.RootId, true)
 			if err == nil {
 				if (root.CreateAt >= cursor.LastPostUpdateAt || root.UpdateAt >= cursor.LastPostUpdateAt) && !containsPost(sd.posts, root) {
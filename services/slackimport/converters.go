@@ -22,6 +22,25 @@ func slackConvertTimeStamp(ts string) int64 {
 	return timeStamp * 1000 // Convert to milliseconds
 }
 
+// slackEmojiAliases maps the names Slack gives to some emojis to the ones they have in Mattermost.
+var slackEmojiAliases = map[string]string{
+	"simple_smile": "slightly_smiling_face",
+}
+
+// slackConvertEmojiName converts the name of an emoji in a Slack reaction to the one it is known by
+// in Mattermost, dropping the skin tone Slack appends to it as in "thumbsup::skin-tone-2".
+func slackConvertEmojiName(emojiName string) string {
+	emojiName = strings.ToLower(strings.Trim(emojiName, ":"))
+	if i := strings.Index(emojiName, "::"); i != -1 {
+		emojiName = emojiName[:i]
+	}
+
+	if alias, ok := slackEmojiAliases[emojiName]; ok {
+		return alias
+	}
+	return emojiName
+}
+
 func slackConvertChannelName(channelName string, channelId string) string {
 	newName := strings.Trim(channelName, "_-")
 	if len(newName) == 1 {
@@ -6,6 +6,7 @@ package slackimport
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"errors"
 	"image"
 	"io"
@@ -56,6 +57,11 @@ type slackFile struct {
 	Title string `json:"title"`
 }
 
+type slackReaction struct {
+	Name  string   `json:"name"`
+	Users []string `json:"users"`
+}
+
 type slackPost struct {
 	User        string                   `json:"user"`
 	BotId       string                   `json:"bot_id"`
@@ -70,12 +76,17 @@ type slackPost struct {
 	File        *slackFile               `json:"file"`
 	Files       []*slackFile             `json:"files"`
 	Attachments []*model.SlackAttachment `json:"attachments"`
+	Reactions   []*slackReaction         `json:"reactions"`
+	PinnedTo    []string                 `json:"pinned_to"`
 }
 
 var isValidChannelNameCharacters = regexp.MustCompile(`^[a-zA-Z0-9\-_]+$`).MatchString
 
 const slackImportMaxFileSize = 1024 * 1024 * 70
 
+// slackImportPlaceholderEmojiName is the custom emoji that reactions with emojis unknown to Mattermost are imported as.
+const slackImportPlaceholderEmojiName = "slack_import_unknown"
+
 type slackComment struct {
 	User    string `json:"user"`
 	Comment string `json:"comment"`
@@ -95,6 +106,7 @@ type Actions struct {
 	InvalidateAllCaches    func()
 	MaxPostSize            func() int
 	PrepareImage           func(fileData []byte) (image.Image, func(), error)
+	CreatePlaceholderEmoji func(name string, creatorID string) (*model.Emoji, *model.AppError)
 }
 
 // SlackImporter is a service that allows to import slack dumps into mattermost
@@ -102,6 +114,9 @@ type SlackImporter struct {
 	store   store.Store
 	actions Actions
 	config  *model.Config
+
+	// hasPlaceholderEmoji is set once the placeholder emoji for unknown reactions is known to exist.
+	hasPlaceholderEmoji bool
 }
 
 // New creates a new SlackImporter service instance. It receive a store, a set of actions and the current config.
@@ -335,6 +350,7 @@ func (si *SlackImporter) slackAddPosts(teamId string, channel *model.Channel, po
 				ChannelId: channel.Id,
 				Message:   sPost.Text,
 				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
+				IsPinned:  len(sPost.PinnedTo) > 0,
 			}
 			if sPost.Upload {
 				if sPost.File != nil {
@@ -354,6 +370,7 @@ func (si *SlackImporter) slackAddPosts(teamId string, channel *model.Channel, po
 				newPost.RootId = threads[sPost.ThreadTS]
 			}
 			postId := si.oldImportPost(&newPost)
+			si.slackAddReactions(postId, sPost, users)
 			// If post is thread starter
 			if sPost.ThreadTS == sPost.TimeStamp {
 				threads[sPost.ThreadTS] = postId
@@ -400,9 +417,11 @@ func (si *SlackImporter) slackAddPosts(teamId string, channel *model.Channel, po
 				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
 				Message:   sPost.Text,
 				Type:      model.PostTypeSlackAttachment,
+				IsPinned:  len(sPost.PinnedTo) > 0,
 			}
 
 			postId := si.oldImportIncomingWebhookPost(post, props)
+			si.slackAddReactions(postId, sPost, users)
 			// If post is thread starter
 			if sPost.ThreadTS == sPost.TimeStamp {
 				threads[sPost.ThreadTS] = postId
@@ -449,8 +468,10 @@ func (si *SlackImporter) slackAddPosts(teamId string, channel *model.Channel, po
 				ChannelId: channel.Id,
 				Message:   "*" + sPost.Text + "*",
 				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
+				IsPinned:  len(sPost.PinnedTo) > 0,
 			}
 			postId := si.oldImportPost(&newPost)
+			si.slackAddReactions(postId, sPost, users)
 			// If post is thread starter
 			if sPost.ThreadTS == sPost.TimeStamp {
 				threads[sPost.ThreadTS] = postId
@@ -516,6 +537,70 @@ func (si *SlackImporter) slackAddPosts(teamId string, channel *model.Channel, po
 	}
 }
 
+// slackAddReactions adds the reactions of the Slack post to the post imported from it. Reactions
+// with an emoji that is neither a system nor a custom emoji are added with the placeholder emoji.
+func (si *SlackImporter) slackAddReactions(postId string, sPost slackPost, users map[string]*model.User) {
+	if postId == "" || len(sPost.Reactions) == 0 {
+		return
+	}
+
+	createAt := slackConvertTimeStamp(sPost.TimeStamp)
+	var reactions []*model.Reaction
+	for _, sReaction := range sPost.Reactions {
+		for _, sUser := range sReaction.Users {
+			user := users[sUser]
+			if user == nil {
+				mlog.Debug("Slack Import: Unable to add the reaction as the Slack user does not exist in Mattermost.", mlog.String("user", sUser))
+				continue
+			}
+
+			emojiName, ok := si.slackResolveEmojiName(sReaction.Name, user.Id)
+			if !ok {
+				continue
+			}
+
+			reactions = append(reactions, &model.Reaction{
+				UserId:    user.Id,
+				PostId:    postId,
+				EmojiName: emojiName,
+				CreateAt:  createAt,
+			})
+		}
+	}
+
+	if len(reactions) == 0 {
+		return
+	}
+
+	if _, err := si.store.Reaction().SaveMultiple(reactions); err != nil {
+		mlog.Warn("Slack Import: Unable to save the reactions to the post.", mlog.String("post_id", postId), mlog.Err(err))
+	}
+}
+
+// slackResolveEmojiName returns the name of the Mattermost emoji to import a reaction with the given
+// Slack emoji as, creating the placeholder emoji on behalf of the user the first time it's needed.
+func (si *SlackImporter) slackResolveEmojiName(slackEmojiName, userId string) (string, bool) {
+	emojiName := slackConvertEmojiName(slackEmojiName)
+	if _, ok := model.GetSystemEmojiId(emojiName); ok {
+		return emojiName, true
+	}
+	if _, err := si.store.Emoji().GetByName(context.Background(), emojiName, true); err == nil {
+		return emojiName, true
+	}
+
+	if !si.hasPlaceholderEmoji {
+		if _, err := si.store.Emoji().GetByName(context.Background(), slackImportPlaceholderEmojiName, true); err != nil {
+			if _, appErr := si.actions.CreatePlaceholderEmoji(slackImportPlaceholderEmojiName, userId); appErr != nil {
+				mlog.Warn("Slack Import: Unable to create the placeholder emoji for unknown reactions.", mlog.String("emoji_name", emojiName), mlog.Err(appErr))
+				return "", false
+			}
+		}
+		si.hasPlaceholderEmoji = true
+	}
+
+	return slackImportPlaceholderEmojiName, true
+}
+
 func (si *SlackImporter) slackUploadFile(slackPostFile *slackFile, uploads map[string]*zip.File, teamId string, channelId string, userId string, slackTimestamp string) (*model.FileInfo, bool) {
 	if slackPostFile == nil {
 		mlog.Warn("Slack Import: Unable to attach the file to the post as the latter has no file section present in Slack export.")
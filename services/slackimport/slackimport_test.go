@@ -182,6 +182,35 @@ func TestSlackParseMultipleAttachments(t *testing.T) {
 	assert.Equal(t, 2, len(posts[8].Files))
 }
 
+func TestSlackParsePostsReactionsAndPins(t *testing.T) {
+	posts, err := slackParsePosts(strings.NewReader(`[
+		{
+			"type": "message",
+			"user": "U1",
+			"text": "pinned",
+			"ts": "1577836800.000100",
+			"pinned_to": ["C1"],
+			"reactions": [
+				{"name": "thumbsup::skin-tone-2", "users": ["U1", "U2"], "count": 2}
+			]
+		}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, []string{"C1"}, posts[0].PinnedTo)
+	require.Len(t, posts[0].Reactions, 1)
+	assert.Equal(t, "thumbsup::skin-tone-2", posts[0].Reactions[0].Name)
+	assert.Equal(t, []string{"U1", "U2"}, posts[0].Reactions[0].Users)
+}
+
+func TestSlackConvertEmojiName(t *testing.T) {
+	assert.Equal(t, "tada", slackConvertEmojiName("tada"))
+	assert.Equal(t, "thumbsup", slackConvertEmojiName("thumbsup::skin-tone-2"))
+	assert.Equal(t, "wave", slackConvertEmojiName(":Wave:"))
+	assert.Equal(t, "slightly_smiling_face", slackConvertEmojiName("simple_smile"))
+	assert.Equal(t, "party-parrot", slackConvertEmojiName("party-parrot"))
+}
+
 func TestSlackSanitiseChannelProperties(t *testing.T) {
 	c1 := model.Channel{
 		DisplayName: "display-name",
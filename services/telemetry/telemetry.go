@@ -367,10 +367,13 @@ func (ts *TelemetryService) trackConfig() {
 	cfg := ts.srv.Config()
 	ts.SendTelemetry(TrackConfigService, map[string]interface{}{
 		"web_server_mode":                                         *cfg.ServiceSettings.WebserverMode,
+		"enable_websocket_compression":                            *cfg.ServiceSettings.EnableWebSocketCompression,
 		"enable_security_fix_alert":                               *cfg.ServiceSettings.EnableSecurityFixAlert,
 		"enable_insecure_outgoing_connections":                    *cfg.ServiceSettings.EnableInsecureOutgoingConnections,
 		"enable_incoming_webhooks":                                cfg.ServiceSettings.EnableIncomingWebhooks,
+		"incoming_webhook_channel_allowlist":                      len(cfg.ServiceSettings.IncomingWebhookChannelAllowlist),
 		"enable_outgoing_webhooks":                                cfg.ServiceSettings.EnableOutgoingWebhooks,
+		"isdefault_outgoing_webhook_payload_template":             isDefault(*cfg.ServiceSettings.OutgoingWebhookPayloadTemplate, ""),
 		"enable_commands":                                         *cfg.ServiceSettings.EnableCommands,
 		"enable_post_username_override":                           cfg.ServiceSettings.EnablePostUsernameOverride,
 		"enable_post_icon_override":                               cfg.ServiceSettings.EnablePostIconOverride,
@@ -393,10 +396,15 @@ func (ts *TelemetryService) trackConfig() {
 		"uses_letsencrypt":                                        *cfg.ServiceSettings.UseLetsEncrypt,
 		"forward_80_to_443":                                       *cfg.ServiceSettings.Forward80To443,
 		"maximum_login_attempts":                                  *cfg.ServiceSettings.MaximumLoginAttempts,
+		"login_lockout_duration_in_minutes":                       *cfg.ServiceSettings.LoginLockoutDurationInMinutes,
+		"login_lockout_scope":                                     *cfg.ServiceSettings.LoginLockoutScope,
 		"extend_session_length_with_activity":                     *cfg.ServiceSettings.ExtendSessionLengthWithActivity,
 		"session_length_web_in_hours":                             *cfg.ServiceSettings.SessionLengthWebInHours,
 		"session_length_mobile_in_hours":                          *cfg.ServiceSettings.SessionLengthMobileInHours,
 		"session_length_sso_in_hours":                             *cfg.ServiceSettings.SessionLengthSSOInHours,
+		"session_length_saml_in_hours":                            *cfg.ServiceSettings.SessionLengthSAMLInHours,
+		"session_length_oauth_in_hours":                           *cfg.ServiceSettings.SessionLengthOAuthInHours,
+		"session_length_ldap_in_hours":                            *cfg.ServiceSettings.SessionLengthLDAPInHours,
 		"session_cache_in_minutes":                                *cfg.ServiceSettings.SessionCacheInMinutes,
 		"session_idle_timeout_in_minutes":                         *cfg.ServiceSettings.SessionIdleTimeoutInMinutes,
 		"isdefault_site_url":                                      isDefault(*cfg.ServiceSettings.SiteURL, model.ServiceSettingsDefaultSiteURL),
@@ -412,9 +420,21 @@ func (ts *TelemetryService) trackConfig() {
 		"cors_debug":                                              *cfg.ServiceSettings.CorsDebug,
 		"isdefault_allowed_untrusted_internal_connections":        isDefault(*cfg.ServiceSettings.AllowedUntrustedInternalConnections, ""),
 		"post_edit_time_limit":                                    *cfg.ServiceSettings.PostEditTimeLimit,
+		"post_delete_time_limit":                                  *cfg.ServiceSettings.PostDeleteTimeLimit,
+		"max_pinned_posts_per_channel":                            *cfg.ServiceSettings.MaxPinnedPostsPerChannel,
+		"enable_pinned_post_system_message":                       *cfg.ServiceSettings.EnablePinnedPostSystemMessage,
+		"post_truncated_preview_length":                           *cfg.ServiceSettings.PostTruncatedPreviewLength,
+		"isdefault_post_report_reasons":                           isDefaultArray(cfg.ServiceSettings.PostReportReasons, model.GetDefaultPostReportReasons()),
+		"search_rate_limit_per_minute":                            *cfg.ServiceSettings.SearchRateLimitPerMinute,
+		"search_rate_limit_max_burst":                             *cfg.ServiceSettings.SearchRateLimitMaxBurst,
+		"isdefault_auto_pin_emoji_name":                           isDefault(*cfg.ServiceSettings.AutoPinEmojiName, "pushpin"),
+		"auto_pin_reaction_threshold":                             *cfg.ServiceSettings.AutoPinReactionThreshold,
+		"enable_welcome_message":                                  *cfg.ServiceSettings.EnableWelcomeMessage,
+		"isdefault_welcome_message":                               isDefault(*cfg.ServiceSettings.WelcomeMessage, ""),
 		"enable_user_typing_messages":                             *cfg.ServiceSettings.EnableUserTypingMessages,
 		"enable_channel_viewed_messages":                          *cfg.ServiceSettings.EnableChannelViewedMessages,
 		"time_between_user_typing_updates_milliseconds":           *cfg.ServiceSettings.TimeBetweenUserTypingUpdatesMilliseconds,
+		"typing_event_broadcast_interval_milliseconds":            *cfg.ServiceSettings.TypingEventBroadcastIntervalMilliseconds,
 		"cluster_log_timeout_milliseconds":                        *cfg.ServiceSettings.ClusterLogTimeoutMilliseconds,
 		"enable_post_search":                                      *cfg.ServiceSettings.EnablePostSearch,
 		"minimum_hashtag_length":                                  *cfg.ServiceSettings.MinimumHashtagLength,
@@ -446,6 +466,7 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_permalink_previews":                               *cfg.ServiceSettings.EnablePermalinkPreviews,
 		"enable_file_search":                                      *cfg.ServiceSettings.EnableFileSearch,
 		"restrict_link_previews":                                  isDefault(*cfg.ServiceSettings.RestrictLinkPreviews, ""),
+		"link_preview_allowlist":                                  isDefault(*cfg.ServiceSettings.LinkPreviewAllowlist, ""),
 		"enable_custom_groups":                                    *cfg.ServiceSettings.EnableCustomGroups,
 	})
 
@@ -457,9 +478,12 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_custom_brand":                     *cfg.TeamSettings.EnableCustomBrand,
 		"restrict_direct_message":                 *cfg.TeamSettings.RestrictDirectMessage,
 		"max_notifications_per_channel":           *cfg.TeamSettings.MaxNotificationsPerChannel,
+		"max_expanded_mentions_per_post":          *cfg.TeamSettings.MaxExpandedMentionsPerPost,
+		"admins_can_exceed_expanded_mentions":     *cfg.TeamSettings.AllowAdminsToExceedExpandedMentions,
 		"enable_confirm_notifications_to_channel": *cfg.TeamSettings.EnableConfirmNotificationsToChannel,
 		"max_users_per_team":                      *cfg.TeamSettings.MaxUsersPerTeam,
 		"max_channels_per_team":                   *cfg.TeamSettings.MaxChannelsPerTeam,
+		"max_channels_per_user_per_team":          *cfg.TeamSettings.MaxChannelsPerUserPerTeam,
 		"teammate_name_display":                   *cfg.TeamSettings.TeammateNameDisplay,
 		"experimental_view_archived_channels":     *cfg.TeamSettings.ExperimentalViewArchivedChannels,
 		"lock_teammate_name_display":              *cfg.TeamSettings.LockTeammateNameDisplay,
@@ -579,6 +603,7 @@ func (ts *TelemetryService) trackConfig() {
 		"isdefault_login_button_text_color":    isDefault(*cfg.EmailSettings.LoginButtonTextColor, ""),
 		"smtp_server_timeout":                  *cfg.EmailSettings.SMTPServerTimeout,
 		"enable_inactivity_email":              *cfg.EmailSettings.EnableInactivityEmail,
+		"announcement_emails_per_hour":         *cfg.EmailSettings.AnnouncementEmailsPerHour,
 	})
 
 	ts.SendTelemetry(TrackConfigRate, map[string]interface{}{
@@ -769,16 +794,20 @@ func (ts *TelemetryService) trackConfig() {
 	ts.trackPluginConfig(cfg, model.PluginSettingsDefaultMarketplaceURL)
 
 	ts.SendTelemetry(TrackConfigDataRetention, map[string]interface{}{
-		"enable_message_deletion":       *cfg.DataRetentionSettings.EnableMessageDeletion,
-		"enable_file_deletion":          *cfg.DataRetentionSettings.EnableFileDeletion,
-		"enable_boards_deletion":        *cfg.DataRetentionSettings.EnableBoardsDeletion,
-		"message_retention_days":        *cfg.DataRetentionSettings.MessageRetentionDays,
-		"file_retention_days":           *cfg.DataRetentionSettings.FileRetentionDays,
-		"boards_retention_days":         *cfg.DataRetentionSettings.BoardsRetentionDays,
-		"deletion_job_start_time":       *cfg.DataRetentionSettings.DeletionJobStartTime,
-		"batch_size":                    *cfg.DataRetentionSettings.BatchSize,
-		"cleanup_jobs_threshold_days":   *cfg.JobSettings.CleanupJobsThresholdDays,
-		"cleanup_config_threshold_days": *cfg.JobSettings.CleanupConfigThresholdDays,
+		"enable_message_deletion":          *cfg.DataRetentionSettings.EnableMessageDeletion,
+		"enable_file_deletion":             *cfg.DataRetentionSettings.EnableFileDeletion,
+		"enable_boards_deletion":           *cfg.DataRetentionSettings.EnableBoardsDeletion,
+		"enable_archived_channel_deletion": *cfg.DataRetentionSettings.EnableArchivedChannelDeletion,
+		"enable_edit_history_deletion":     *cfg.DataRetentionSettings.EnableEditHistoryDeletion,
+		"message_retention_days":           *cfg.DataRetentionSettings.MessageRetentionDays,
+		"file_retention_days":              *cfg.DataRetentionSettings.FileRetentionDays,
+		"boards_retention_days":            *cfg.DataRetentionSettings.BoardsRetentionDays,
+		"archived_channel_retention_days":  *cfg.DataRetentionSettings.ArchivedChannelRetentionDays,
+		"edit_history_retention_days":      *cfg.DataRetentionSettings.EditHistoryRetentionDays,
+		"deletion_job_start_time":          *cfg.DataRetentionSettings.DeletionJobStartTime,
+		"batch_size":                       *cfg.DataRetentionSettings.BatchSize,
+		"cleanup_jobs_threshold_days":      *cfg.JobSettings.CleanupJobsThresholdDays,
+		"cleanup_config_threshold_days":    *cfg.JobSettings.CleanupConfigThresholdDays,
 	})
 
 	ts.SendTelemetry(TrackConfigMessageExport, map[string]interface{}{
@@ -805,6 +834,7 @@ func (ts *TelemetryService) trackConfig() {
 		"allow_email_accounts":                   *cfg.GuestAccountsSettings.AllowEmailAccounts,
 		"enforce_multifactor_authentication":     *cfg.GuestAccountsSettings.EnforceMultifactorAuthentication,
 		"isdefault_restrict_creation_to_domains": isDefault(*cfg.GuestAccountsSettings.RestrictCreationToDomains, ""),
+		"enforce_channel_invites":                *cfg.GuestAccountsSettings.EnforceChannelInvites,
 	})
 
 	ts.SendTelemetry(TrackConfigImageProxy, map[string]interface{}{
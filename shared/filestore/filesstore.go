@@ -5,6 +5,7 @@ package filestore
 
 import (
 	"io"
+	"net/http"
 	"time"
 
 	"github.com/pkg/errors"
@@ -39,6 +40,16 @@ type FileBackend interface {
 	RemoveDirectory(path string) error
 }
 
+// FileBackendWithPresignedUploads is implemented by the backends that can issue URLs through which
+// a client uploads a file directly to the store instead of sending it through the server.
+type FileBackendWithPresignedUploads interface {
+	FileBackend
+
+	// PresignedUploadURL returns a URL valid for the given duration to which the file at path can be
+	// uploaded with a PUT request, along with the headers that the request must include.
+	PresignedUploadURL(path string, expiry time.Duration) (string, http.Header, error)
+}
+
 type FileBackendSettings struct {
 	DriverName              string
 	Directory               string
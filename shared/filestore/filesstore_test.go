@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"net/http"
 	"os"
 	"testing"
 	"time"
@@ -498,6 +499,42 @@ func (s *FileBackendTestSuite) TestFileModTime() {
 	})
 }
 
+func (s *FileBackendTestSuite) TestPresignedUploadURL() {
+	backend, ok := s.backend.(FileBackendWithPresignedUploads)
+	if s.settings.DriverName == driverLocal {
+		s.False(ok, "local storage should not support presigned uploads")
+		return
+	}
+	s.Require().True(ok, "S3 storage should support presigned uploads")
+
+	data := []byte("some data uploaded directly")
+	path := "tests/" + randomString()
+
+	url, headers, err := backend.PresignedUploadURL(path, time.Minute)
+	s.Require().NoError(err)
+	s.NotEmpty(url)
+
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+	s.Require().NoError(err)
+	for key, values := range headers {
+		req.Header[key] = values
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Equal(http.StatusOK, resp.StatusCode)
+	defer s.backend.RemoveFile(path)
+
+	exists, err := s.backend.FileExists(path)
+	s.NoError(err)
+	s.True(exists)
+
+	size, err := s.backend.FileSize(path)
+	s.NoError(err)
+	s.Equal(int64(len(data)), size)
+}
+
 func BenchmarkS3WriteFile(b *testing.B) {
 	settings := FileBackendSettings{
 		DriverName:              driverS3,
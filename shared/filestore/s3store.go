@@ -7,6 +7,7 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -367,6 +368,27 @@ func (b *S3FileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
 	return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
 }
 
+func (b *S3FileBackend) PresignedUploadURL(path string, expiry time.Duration) (string, http.Header, error) {
+	path = filepath.Join(b.pathPrefix, path)
+
+	headers := http.Header{}
+	if ext := filepath.Ext(path); isFileExtImage(ext) {
+		headers.Set("Content-Type", getImageMimeType(ext))
+	} else {
+		headers.Set("Content-Type", "binary/octet-stream")
+	}
+	if b.encrypt {
+		encrypt.NewSSE().Marshal(headers)
+	}
+
+	u, err := b.client.PresignHeader(context.Background(), http.MethodPut, b.bucket, path, expiry, nil, headers)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "unable to presign the upload of the file %s", path)
+	}
+
+	return u.String(), headers, nil
+}
+
 func (b *S3FileBackend) RemoveFile(path string) error {
 	path = filepath.Join(b.pathPrefix, path)
 	if err := b.client.RemoveObject(context.Background(), b.bucket, path, s3.RemoveObjectOptions{}); err != nil {
@@ -34,6 +34,7 @@ func getMockStore() *mocks.Store {
 	fakeReaction := model.Reaction{PostId: "123"}
 	mockReactionsStore := mocks.ReactionStore{}
 	mockReactionsStore.On("Save", &fakeReaction).Return(&model.Reaction{}, nil)
+	mockReactionsStore.On("SaveMultiple", []*model.Reaction{&fakeReaction}).Return([]*model.Reaction{}, nil)
 	mockReactionsStore.On("Delete", &fakeReaction).Return(&model.Reaction{}, nil)
 	mockReactionsStore.On("GetForPost", "123", false).Return([]*model.Reaction{&fakeReaction}, nil)
 	mockReactionsStore.On("GetForPost", "123", true).Return([]*model.Reaction{&fakeReaction}, nil)
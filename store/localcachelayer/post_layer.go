@@ -102,8 +102,9 @@ func (s LocalCachePostStore) GetPostsSince(options model.GetPostsSinceOptions, a
 
 	list, err := s.PostStore.GetPostsSince(options, allowFromCache, sanitizeOptions)
 
+	// The latest update can't be known when some of the posts were left out
 	latestUpdate := options.Time
-	if err == nil {
+	if err == nil && options.ExcludeUserId == "" {
 		for _, p := range list.ToSlice() {
 			if latestUpdate < p.UpdateAt {
 				latestUpdate = p.UpdateAt
@@ -28,6 +28,19 @@ func (s LocalCacheReactionStore) Save(reaction *model.Reaction) (*model.Reaction
 	return s.ReactionStore.Save(reaction)
 }
 
+func (s LocalCacheReactionStore) SaveMultiple(reactions []*model.Reaction) ([]*model.Reaction, error) {
+	defer func() {
+		invalidated := make(map[string]bool, len(reactions))
+		for _, reaction := range reactions {
+			if !invalidated[reaction.PostId] {
+				invalidated[reaction.PostId] = true
+				s.rootStore.doInvalidateCacheCluster(s.rootStore.reactionCache, reaction.PostId)
+			}
+		}
+	}()
+	return s.ReactionStore.SaveMultiple(reactions)
+}
+
 func (s LocalCacheReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
 	defer s.rootStore.doInvalidateCacheCluster(s.rootStore.reactionCache, reaction.PostId)
 	return s.ReactionStore.Delete(reaction)
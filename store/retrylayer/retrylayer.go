@@ -22,43 +22,48 @@ const mySQLDeadlockCode = uint16(1213)
 
 type RetryLayer struct {
 	store.Store
-	AuditStore                store.AuditStore
-	BotStore                  store.BotStore
-	ChannelStore              store.ChannelStore
-	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
-	ClusterDiscoveryStore     store.ClusterDiscoveryStore
-	CommandStore              store.CommandStore
-	CommandWebhookStore       store.CommandWebhookStore
-	ComplianceStore           store.ComplianceStore
-	EmojiStore                store.EmojiStore
-	FileInfoStore             store.FileInfoStore
-	GroupStore                store.GroupStore
-	JobStore                  store.JobStore
-	LicenseStore              store.LicenseStore
-	LinkMetadataStore         store.LinkMetadataStore
-	OAuthStore                store.OAuthStore
-	PluginStore               store.PluginStore
-	PostStore                 store.PostStore
-	PreferenceStore           store.PreferenceStore
-	ProductNoticesStore       store.ProductNoticesStore
-	ReactionStore             store.ReactionStore
-	RemoteClusterStore        store.RemoteClusterStore
-	RetentionPolicyStore      store.RetentionPolicyStore
-	RoleStore                 store.RoleStore
-	SchemeStore               store.SchemeStore
-	SessionStore              store.SessionStore
-	SharedChannelStore        store.SharedChannelStore
-	StatusStore               store.StatusStore
-	SystemStore               store.SystemStore
-	TeamStore                 store.TeamStore
-	TermsOfServiceStore       store.TermsOfServiceStore
-	ThreadStore               store.ThreadStore
-	TokenStore                store.TokenStore
-	UploadSessionStore        store.UploadSessionStore
-	UserStore                 store.UserStore
-	UserAccessTokenStore      store.UserAccessTokenStore
-	UserTermsOfServiceStore   store.UserTermsOfServiceStore
-	WebhookStore              store.WebhookStore
+	AuditStore                    store.AuditStore
+	BotStore                      store.BotStore
+	ChannelStore                  store.ChannelStore
+	ChannelMemberHistoryStore     store.ChannelMemberHistoryStore
+	ChannelTemplateStore          store.ChannelTemplateStore
+	ClusterDiscoveryStore         store.ClusterDiscoveryStore
+	CommandStore                  store.CommandStore
+	CommandWebhookStore           store.CommandWebhookStore
+	ComplianceStore               store.ComplianceStore
+	EmojiStore                    store.EmojiStore
+	FileInfoStore                 store.FileInfoStore
+	GroupStore                    store.GroupStore
+	GuestChannelInviteStore       store.GuestChannelInviteStore
+	JobStore                      store.JobStore
+	LicenseStore                  store.LicenseStore
+	LinkMetadataStore             store.LinkMetadataStore
+	OAuthStore                    store.OAuthStore
+	PluginStore                   store.PluginStore
+	PostStore                     store.PostStore
+	PostReminderStore             store.PostReminderStore
+	PostReportStore               store.PostReportStore
+	PreferenceStore               store.PreferenceStore
+	ProductNoticesStore           store.ProductNoticesStore
+	ReactionStore                 store.ReactionStore
+	RemoteClusterStore            store.RemoteClusterStore
+	RetentionPolicyStore          store.RetentionPolicyStore
+	RoleStore                     store.RoleStore
+	ScheduledChannelDeletionStore store.ScheduledChannelDeletionStore
+	SchemeStore                   store.SchemeStore
+	SessionStore                  store.SessionStore
+	SharedChannelStore            store.SharedChannelStore
+	StatusStore                   store.StatusStore
+	SystemStore                   store.SystemStore
+	TeamStore                     store.TeamStore
+	TermsOfServiceStore           store.TermsOfServiceStore
+	ThreadStore                   store.ThreadStore
+	TokenStore                    store.TokenStore
+	UploadSessionStore            store.UploadSessionStore
+	UserStore                     store.UserStore
+	UserAccessTokenStore          store.UserAccessTokenStore
+	UserTermsOfServiceStore       store.UserTermsOfServiceStore
+	WebhookStore                  store.WebhookStore
 }
 
 func (s *RetryLayer) Audit() store.AuditStore {
@@ -77,6 +82,10 @@ func (s *RetryLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return s.ChannelMemberHistoryStore
 }
 
+func (s *RetryLayer) ChannelTemplate() store.ChannelTemplateStore {
+	return s.ChannelTemplateStore
+}
+
 func (s *RetryLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
@@ -105,6 +114,10 @@ func (s *RetryLayer) Group() store.GroupStore {
 	return s.GroupStore
 }
 
+func (s *RetryLayer) GuestChannelInvite() store.GuestChannelInviteStore {
+	return s.GuestChannelInviteStore
+}
+
 func (s *RetryLayer) Job() store.JobStore {
 	return s.JobStore
 }
@@ -129,6 +142,14 @@ func (s *RetryLayer) Post() store.PostStore {
 	return s.PostStore
 }
 
+func (s *RetryLayer) PostReminder() store.PostReminderStore {
+	return s.PostReminderStore
+}
+
+func (s *RetryLayer) PostReport() store.PostReportStore {
+	return s.PostReportStore
+}
+
 func (s *RetryLayer) Preference() store.PreferenceStore {
 	return s.PreferenceStore
 }
@@ -153,6 +174,10 @@ func (s *RetryLayer) Role() store.RoleStore {
 	return s.RoleStore
 }
 
+func (s *RetryLayer) ScheduledChannelDeletion() store.ScheduledChannelDeletionStore {
+	return s.ScheduledChannelDeletionStore
+}
+
 func (s *RetryLayer) Scheme() store.SchemeStore {
 	return s.SchemeStore
 }
@@ -229,6 +254,11 @@ type RetryLayerChannelMemberHistoryStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerChannelTemplateStore struct {
+	store.ChannelTemplateStore
+	Root *RetryLayer
+}
+
 type RetryLayerClusterDiscoveryStore struct {
 	store.ClusterDiscoveryStore
 	Root *RetryLayer
@@ -264,6 +294,11 @@ type RetryLayerGroupStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerGuestChannelInviteStore struct {
+	store.GuestChannelInviteStore
+	Root *RetryLayer
+}
+
 type RetryLayerJobStore struct {
 	store.JobStore
 	Root *RetryLayer
@@ -294,6 +329,16 @@ type RetryLayerPostStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerPostReminderStore struct {
+	store.PostReminderStore
+	Root *RetryLayer
+}
+
+type RetryLayerPostReportStore struct {
+	store.PostReportStore
+	Root *RetryLayer
+}
+
 type RetryLayerPreferenceStore struct {
 	store.PreferenceStore
 	Root *RetryLayer
@@ -324,6 +369,11 @@ type RetryLayerRoleStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerScheduledChannelDeletionStore struct {
+	store.ScheduledChannelDeletionStore
+	Root *RetryLayer
+}
+
 type RetryLayerSchemeStore struct {
 	store.SchemeStore
 	Root *RetryLayer
@@ -1130,6 +1180,27 @@ func (s *RetryLayerChannelStore) GetByNames(team_id string, names []string, allo
 
 }
 
+func (s *RetryLayerChannelStore) GetByNamesIncludeDeleted(team_id string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetByNamesIncludeDeleted(team_id, names, allowFromCache)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetChannelCounts(teamID string, userID string) (*model.ChannelCounts, error) {
 
 	tries := 0
@@ -1193,6 +1264,27 @@ func (s *RetryLayerChannelStore) GetChannelMembersTimezones(channelID string) ([
 
 }
 
+func (s *RetryLayerChannelStore) GetChannelMembersWithTeamData(channelID string, offset int, limit int) ([]*model.ChannelMemberWithTeamMember, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetChannelMembersWithTeamData(channelID, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetChannelUnread(channelID string, userID string) (*model.ChannelUnread, error) {
 
 	tries := 0
@@ -1214,6 +1306,27 @@ func (s *RetryLayerChannelStore) GetChannelUnread(channelID string, userID strin
 
 }
 
+func (s *RetryLayerChannelStore) GetChannelUnreadCountsForUser(userID string, teamID string) ([]*model.ChannelUnreadCount, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetChannelUnreadCountsForUser(userID, teamID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetChannels(teamID string, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, error) {
 
 	tries := 0
@@ -1319,6 +1432,27 @@ func (s *RetryLayerChannelStore) GetChannelsByUser(userID string, includeDeleted
 
 }
 
+func (s *RetryLayerChannelStore) GetChannelsDeletedBefore(deletedBefore int64, afterChannelID string, limit int) (model.ChannelList, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetChannelsDeletedBefore(deletedBefore, afterChannelID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetChannelsWithCursor(teamId string, userId string, opts *model.ChannelSearchOpts, afterChannelID string) (model.ChannelList, error) {
 
 	tries := 0
@@ -1361,6 +1495,27 @@ func (s *RetryLayerChannelStore) GetChannelsWithTeamDataByIds(channelIds []strin
 
 }
 
+func (s *RetryLayerChannelStore) GetCreationInfo(teamID string, offset int, limit int) ([]*model.ChannelCreationInfo, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetCreationInfo(teamID, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetDeleted(team_id string, offset int, limit int, userID string) (model.ChannelList, error) {
 
 	tries := 0
@@ -1403,6 +1558,48 @@ func (s *RetryLayerChannelStore) GetDeletedByName(team_id string, name string) (
 
 }
 
+func (s *RetryLayerChannelStore) GetDirectChannelsForUsers(userA string, userB string) ([]*model.Channel, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetDirectChannelsForUsers(userA, userB)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelStore) GetDuplicateDirectChannels() ([]*model.DuplicateDirectChannels, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetDuplicateDirectChannels()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetFileCount(channelID string) (int64, error) {
 
 	tries := 0
@@ -1556,6 +1753,27 @@ func (s *RetryLayerChannelStore) GetMemberCountsByGroup(ctx context.Context, cha
 
 }
 
+func (s *RetryLayerChannelStore) GetMemberCountsByType(channelID string) (*model.ChannelMemberCountsByType, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetMemberCountsByType(channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetMemberForPost(postID string, userID string) (*model.ChannelMember, error) {
 
 	tries := 0
@@ -1724,6 +1942,48 @@ func (s *RetryLayerChannelStore) GetMembersInfoByChannelIds(channelIDs []string)
 
 }
 
+func (s *RetryLayerChannelStore) GetMembersNotInTeam(afterChannelID string, afterUserID string, limit int) (model.ChannelMembers, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetMembersNotInTeam(afterChannelID, afterUserID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelStore) GetMembersUpdatedSince(channelID string, since int64, offset int, limit int) (model.ChannelMembers, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetMembersUpdatedSince(channelID, since, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetMoreChannels(teamID string, userID string, offset int, limit int) (model.ChannelList, error) {
 
 	tries := 0
@@ -1913,6 +2173,27 @@ func (s *RetryLayerChannelStore) GetSidebarCategoryOrder(userID string, teamID s
 
 }
 
+func (s *RetryLayerChannelStore) GetTeamChannelCountForUser(teamID string, userID string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetTeamChannelCountForUser(teamID, userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetTeamChannels(teamID string) (model.ChannelList, error) {
 
 	tries := 0
@@ -2108,6 +2389,27 @@ func (s *RetryLayerChannelStore) IsUserInChannelUseCache(userID string, channelI
 
 }
 
+func (s *RetryLayerChannelStore) MergeDirectChannels(canonicalID string, duplicateIDs []string, deleteAt int64) ([]string, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.MergeDirectChannels(canonicalID, duplicateIDs, deleteAt)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) MigrateChannelMembers(fromChannelID string, fromUserID string) (map[string]string, error) {
 
 	tries := 0
@@ -2465,11 +2767,11 @@ func (s *RetryLayerChannelStore) SearchArchivedInTeam(teamID string, term string
 
 }
 
-func (s *RetryLayerChannelStore) SearchForUserInTeam(userID string, teamID string, term string, includeDeleted bool) (model.ChannelList, error) {
+func (s *RetryLayerChannelStore) SearchByPurpose(teamID string, term string, page int, perPage int) (model.ChannelList, error) {
 
 	tries := 0
 	for {
-		result, err := s.ChannelStore.SearchForUserInTeam(userID, teamID, term, includeDeleted)
+		result, err := s.ChannelStore.SearchByPurpose(teamID, term, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -2486,7 +2788,28 @@ func (s *RetryLayerChannelStore) SearchForUserInTeam(userID string, teamID strin
 
 }
 
-func (s *RetryLayerChannelStore) SearchGroupChannels(userID string, term string) (model.ChannelList, error) {
+func (s *RetryLayerChannelStore) SearchForUserInTeam(userID string, teamID string, term string, includeDeleted bool) (model.ChannelList, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.SearchForUserInTeam(userID, teamID, term, includeDeleted)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelStore) SearchGroupChannels(userID string, term string) (model.ChannelList, error) {
 
 	tries := 0
 	for {
@@ -2528,6 +2851,27 @@ func (s *RetryLayerChannelStore) SearchInTeam(teamID string, term string, includ
 
 }
 
+func (s *RetryLayerChannelStore) SearchMembersForUser(userID string, term string, includePrivate bool, page int, perPage int) (model.ChannelMembersWithTeamData, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.SearchMembersForUser(userID, term, includePrivate, page, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) SearchMore(userID string, teamID string, term string) (model.ChannelList, error) {
 
 	tries := 0
@@ -2759,6 +3103,27 @@ func (s *RetryLayerChannelStore) UpdateSidebarCategories(userID string, teamID s
 
 }
 
+func (s *RetryLayerChannelStore) UpdateSidebarCategoriesAndOrder(userID string, teamID string, categories []*model.SidebarCategoryWithChannels, categoryOrder []string) ([]*model.SidebarCategoryWithChannels, []*model.SidebarCategoryWithChannels, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.ChannelStore.UpdateSidebarCategoriesAndOrder(userID, teamID, categories, categoryOrder)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) UpdateSidebarCategoryOrder(userID string, teamID string, categoryOrder []string) error {
 
 	tries := 0
@@ -2864,6 +3229,27 @@ func (s *RetryLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int
 
 }
 
+func (s *RetryLayerChannelMemberHistoryStore) GetChannelMemberHistory(channelID string, from int64, to int64) ([]*model.ChannelMemberHistory, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelMemberHistoryStore.GetChannelMemberHistory(channelID, from, to)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 
 	tries := 0
@@ -2906,6 +3292,27 @@ func (s *RetryLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime
 
 }
 
+func (s *RetryLayerChannelMemberHistoryStore) GetUsersLeftSince(channelID string, since int64, offset int, limit int) ([]*model.ChannelMemberHistory, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelMemberHistoryStore.GetUsersLeftSince(channelID, since, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
 
 	tries := 0
@@ -2990,6 +3397,90 @@ func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPo
 
 }
 
+func (s *RetryLayerChannelTemplateStore) Delete(id string) error {
+
+	tries := 0
+	for {
+		err := s.ChannelTemplateStore.Delete(id)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelTemplateStore) Get(id string) (*model.ChannelTemplate, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelTemplateStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelTemplateStore) GetForTeam(teamID string) ([]*model.ChannelTemplate, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelTemplateStore.GetForTeam(teamID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelTemplateStore) Save(template *model.ChannelTemplate) (*model.ChannelTemplate, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelTemplateStore.Save(template)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerClusterDiscoveryStore) Cleanup() error {
 
 	tries := 0
@@ -3605,6 +4096,27 @@ func (s *RetryLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji
 
 }
 
+func (s *RetryLayerEmojiStore) GetUnusedCustomEmoji(messagesSince int64, limit int) (*model.UnusedEmojiList, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmojiStore.GetUnusedCustomEmoji(messagesSince, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 
 	tries := 0
@@ -3821,6 +4333,27 @@ func (s *RetryLayerFileInfoStore) GetForPost(postID string, readFromMaster bool,
 
 }
 
+func (s *RetryLayerFileInfoStore) GetForPosts(postIds []string) (map[string][]*model.FileInfo, error) {
+
+	tries := 0
+	for {
+		result, err := s.FileInfoStore.GetForPosts(postIds)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
 
 	tries := 0
@@ -4037,6 +4570,27 @@ func (s *RetryLayerFileInfoStore) SetContent(fileID string, content string) erro
 
 }
 
+func (s *RetryLayerFileInfoStore) SetQuarantined(fileID string, quarantined bool) error {
+
+	tries := 0
+	for {
+		err := s.FileInfoStore.SetQuarantined(fileID, quarantined)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
 
 	tries := 0
@@ -4562,6 +5116,27 @@ func (s *RetryLayerGroupStore) GetByUser(userID string) ([]*model.Group, error)
 
 }
 
+func (s *RetryLayerGroupStore) GetChannelMemberCountsByGroup(channelID string) ([]*model.ChannelMemberCountByGroup, error) {
+
+	tries := 0
+	for {
+		result, err := s.GroupStore.GetChannelMemberCountsByGroup(channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
 
 	tries := 0
@@ -5129,11 +5704,11 @@ func (s *RetryLayerGroupStore) UpsertMembers(groupID string, userIDs []string) (
 
 }
 
-func (s *RetryLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
+func (s *RetryLayerGuestChannelInviteStore) Cleanup(expiryTime int64) error {
 
 	tries := 0
 	for {
-		err := s.JobStore.Cleanup(expiryTime, batchSize)
+		err := s.GuestChannelInviteStore.Cleanup(expiryTime)
 		if err == nil {
 			return nil
 		}
@@ -5150,13 +5725,97 @@ func (s *RetryLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
 
 }
 
-func (s *RetryLayerJobStore) Delete(id string) (string, error) {
+func (s *RetryLayerGuestChannelInviteStore) Delete(userID string, channelID string) error {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.Delete(id)
+		err := s.GuestChannelInviteStore.Delete(userID, channelID)
 		if err == nil {
-			return result, nil
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerGuestChannelInviteStore) Exists(userID string, channelID string) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.GuestChannelInviteStore.Exists(userID, channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerGuestChannelInviteStore) Save(invite *model.GuestChannelInvite) error {
+
+	tries := 0
+	for {
+		err := s.GuestChannelInviteStore.Save(invite)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
+
+	tries := 0
+	for {
+		err := s.JobStore.Cleanup(expiryTime, batchSize)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerJobStore) Delete(id string) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.JobStore.Delete(id)
+		if err == nil {
+			return result, nil
 		}
 		if !isRepeatableError(err) {
 			return result, err
@@ -6158,6 +6817,27 @@ func (s *RetryLayerPluginStore) SetWithOptions(pluginID string, key string, valu
 
 }
 
+func (s *RetryLayerPostStore) AnalyticsBotPostCount(teamID string, since int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.AnalyticsBotPostCount(teamID, since)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
 
 	tries := 0
@@ -6311,6 +6991,27 @@ func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afte
 
 }
 
+func (s *RetryLayerPostStore) GetEarliestPostTimeForChannels(channelIDs []string, excludeSystemPosts bool) (map[string]int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetEarliestPostTimeForChannels(channelIDs, excludeSystemPosts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
 
 	return s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
@@ -6380,6 +7081,48 @@ func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID strin
 
 }
 
+func (s *RetryLayerPostStore) GetFlaggedPostsForUserPaged(userID string, options model.GetFlaggedPostsOptions) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetFlaggedPostsForUserPaged(userID, options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetLastPostCreateAtForUser(channelID string, userID string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetLastPostCreateAtForUser(channelID, userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostStore) GetLastPostRowCreateAt() (int64, error) {
 
 	tries := 0
@@ -6575,11 +7318,11 @@ func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions, sanit
 
 }
 
-func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
+func (s *RetryLayerPostStore) GetPostsAround(postID string, before int, after int) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
+		result, err := s.PostStore.GetPostsAround(postID, before, after)
 		if err == nil {
 			return result, nil
 		}
@@ -6596,11 +7339,11 @@ func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPos
 
 }
 
-func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsBefore(options, sanitizeOptions)
+		result, err := s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6617,11 +7360,11 @@ func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions, sani
 
 }
 
-func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsByIds(postIds)
+		result, err := s.PostStore.GetPostsBefore(options, sanitizeOptions)
 		if err == nil {
 			return result, nil
 		}
@@ -6638,11 +7381,11 @@ func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, er
 
 }
 
-func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int64) ([]*model.Post, error) {
+func (s *RetryLayerPostStore) GetPostsBetween(channelID string, from int64, to int64, page int, perPage int) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsCreatedAt(channelID, timestamp)
+		result, err := s.PostStore.GetPostsBetween(channelID, from, to, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -6659,11 +7402,11 @@ func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int6
 
 }
 
-func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPostsByHashtag(teamID string, hashtag string, channelIDs []string, page int, perPage int) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsSince(options, allowFromCache, sanitizeOptions)
+		result, err := s.PostStore.GetPostsByHashtag(teamID, hashtag, channelIDs, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -6680,32 +7423,32 @@ func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions,
 
 }
 
-func (s *RetryLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
+func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.GetPostsSinceForSync(options, cursor, limit)
+		result, err := s.PostStore.GetPostsByIds(postIds)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetRecentSearchesForUser(userID string) ([]*model.SearchParams, error) {
+func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int64) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetRecentSearchesForUser(userID)
+		result, err := s.PostStore.GetPostsCreatedAt(channelID, timestamp)
 		if err == nil {
 			return result, nil
 		}
@@ -6722,11 +7465,11 @@ func (s *RetryLayerPostStore) GetRecentSearchesForUser(userID string) ([]*model.
 
 }
 
-func (s *RetryLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
+func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetRepliesForExport(parentID)
+		result, err := s.PostStore.GetPostsSince(options, allowFromCache, sanitizeOptions)
 		if err == nil {
 			return result, nil
 		}
@@ -6743,80 +7486,74 @@ func (s *RetryLayerPostStore) GetRepliesForExport(parentID string) ([]*model.Rep
 
 }
 
-func (s *RetryLayerPostStore) GetSingle(id string, inclDeleted bool) (*model.Post, error) {
+func (s *RetryLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetSingle(id, inclDeleted)
+		result, resultVar1, err := s.PostStore.GetPostsSinceForSync(options, cursor, limit)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
+func (s *RetryLayerPostStore) GetPostsUpdatedSince(channelID string, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.HasAutoResponsePostByUserSince(options, userId)
+		result, resultVar1, err := s.PostStore.GetPostsUpdatedSince(channelID, cursor, limit)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
-
-	s.PostStore.InvalidateLastPostTimeCache(channelID)
-
-}
-
-func (s *RetryLayerPostStore) LogRecentSearch(userID string, searchQuery []byte, createAt int64) error {
+func (s *RetryLayerPostStore) GetRecentPostsForUser(userID string, cursor model.GetRecentPostsForUserCursor, limit int) ([]*model.Post, model.GetRecentPostsForUserCursor, error) {
 
 	tries := 0
 	for {
-		err := s.PostStore.LogRecentSearch(userID, searchQuery, createAt)
+		result, resultVar1, err := s.PostStore.GetRecentPostsForUser(userID, cursor, limit)
 		if err == nil {
-			return nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+func (s *RetryLayerPostStore) GetRecentSearchesForUser(userID string) ([]*model.SearchParams, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Overwrite(post)
+		result, err := s.PostStore.GetRecentSearchesForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -6833,32 +7570,32 @@ func (s *RetryLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+func (s *RetryLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
+		result, err := s.PostStore.GetRepliesForExport(parentID)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerPostStore) GetSingle(id string, inclDeleted bool) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+		result, err := s.PostStore.GetSingle(id, inclDeleted)
 		if err == nil {
 			return result, nil
 		}
@@ -6875,42 +7612,195 @@ func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+func (s *RetryLayerPostStore) GetThreadParticipants(rootID string, limit int) ([]string, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+		result, err := s.PostStore.GetThreadParticipants(rootID, limit)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteByChannel(channelID string) error {
+func (s *RetryLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.PostStore.PermanentDeleteByChannel(channelID)
+		result, err := s.PostStore.HasAutoResponsePostByUserSince(options, userId)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
+
+	s.PostStore.InvalidateLastPostTimeCache(channelID)
+
+}
+
+func (s *RetryLayerPostStore) LogRecentSearch(userID string, searchQuery []byte, createAt int64) error {
+
+	tries := 0
+	for {
+		err := s.PostStore.LogRecentSearch(userID, searchQuery, createAt)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Overwrite(post)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.PostStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteBatchReturningIds(endTime int64, limit int) ([]string, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.PermanentDeleteBatchReturningIds(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteByChannel(channelID string) error {
+
+	tries := 0
+	for {
+		err := s.PostStore.PermanentDeleteByChannel(channelID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
@@ -6921,28 +7811,217 @@ func (s *RetryLayerPostStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		err := s.PostStore.PermanentDeleteByUser(userID)
+		err := s.PostStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteEditHistoryBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.PermanentDeleteEditHistoryBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) Save(post *model.Post) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Save(post)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.PostStore.SaveMultiple(posts)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Search(teamID, userID, params)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) SearchPostsForUser(paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.SearchPostsForUser(paramsList, userID, teamID, page, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Update(newPost, oldPost)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReminderStore) Delete(userID string, postID string) error {
+
+	tries := 0
+	for {
+		err := s.PostReminderStore.Delete(userID, postID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReminderStore) GetDue(before int64, limit int) ([]*model.PostReminder, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReminderStore.GetDue(before, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReminderStore) GetForUser(userID string) ([]*model.PostReminder, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReminderStore.GetForUser(userID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) Save(post *model.Post) (*model.Post, error) {
+func (s *RetryLayerPostReminderStore) Save(reminder *model.PostReminder) (*model.PostReminder, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Save(post)
+		result, err := s.PostReminderStore.Save(reminder)
 		if err == nil {
 			return result, nil
 		}
@@ -6959,32 +8038,32 @@ func (s *RetryLayerPostStore) Save(post *model.Post) (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+func (s *RetryLayerPostReminderStore) UpdateFailedAttempts(userID string, postID string, attempts int) error {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.SaveMultiple(posts)
+		err := s.PostReminderStore.UpdateFailedAttempts(userID, postID, attempts)
 		if err == nil {
-			return result, resultVar1, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
+func (s *RetryLayerPostReportStore) Get(id string) (*model.PostReport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Search(teamID, userID, params)
+		result, err := s.PostReportStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -7001,11 +8080,11 @@ func (s *RetryLayerPostStore) Search(teamID string, userID string, params *model
 
 }
 
-func (s *RetryLayerPostStore) SearchPostsForUser(paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
+func (s *RetryLayerPostReportStore) GetAll(options model.PostReportGetOptions) ([]*model.PostReport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.SearchPostsForUser(paramsList, userID, teamID, page, perPage)
+		result, err := s.PostReportStore.GetAll(options)
 		if err == nil {
 			return result, nil
 		}
@@ -7022,11 +8101,32 @@ func (s *RetryLayerPostStore) SearchPostsForUser(paramsList []*model.SearchParam
 
 }
 
-func (s *RetryLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+func (s *RetryLayerPostReportStore) Save(report *model.PostReport) (*model.PostReport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Update(newPost, oldPost)
+		result, err := s.PostReportStore.Save(report)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReportStore) UpdateStatus(id string, status string) (*model.PostReport, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReportStore.UpdateStatus(id, status)
 		if err == nil {
 			return result, nil
 		}
@@ -7211,6 +8311,27 @@ func (s *RetryLayerPreferenceStore) GetCategory(userID string, category string)
 
 }
 
+func (s *RetryLayerPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.GetCategoryAndName(category, name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
@@ -7253,6 +8374,27 @@ func (s *RetryLayerPreferenceStore) Save(preferences model.Preferences) error {
 
 }
 
+func (s *RetryLayerPreferenceStore) SaveIfNotExists(preference *model.Preference) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.SaveIfNotExists(preference)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerProductNoticesStore) Clear(notices []string) error {
 
 	tries := 0
@@ -7421,6 +8563,27 @@ func (s *RetryLayerReactionStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 }
 
+func (s *RetryLayerReactionStore) GetDetailsForPost(postID string, offset int, limit int) ([]*model.ReactionDetails, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.GetDetailsForPost(postID, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
 
 	tries := 0
@@ -7463,6 +8626,48 @@ func (s *RetryLayerReactionStore) GetForPostSince(postId string, since int64, ex
 
 }
 
+func (s *RetryLayerReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.GetForPosts(postIds)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) GetOrphanedReactions(limit int) ([]*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.GetOrphanedReactions(limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerReactionStore) GetTopForTeamSince(teamID string, userID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
 
 	tries := 0
@@ -7547,6 +8752,27 @@ func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reactio
 
 }
 
+func (s *RetryLayerReactionStore) SaveMultiple(reactions []*model.Reaction) ([]*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.SaveMultiple(reactions)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerRemoteClusterStore) Delete(remoteClusterId string) (bool, error) {
 
 	tries := 0
@@ -8044,39 +9270,123 @@ func (s *RetryLayerRetentionPolicyStore) RemoveChannels(policyId string, channel
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) RemoveTeams(policyId string, teamIds []string) error {
+
+	tries := 0
+	for {
+		err := s.RetentionPolicyStore.RemoveTeams(policyId, teamIds)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.Save(policy)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.AllChannelSchemeRoles()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) RemoveTeams(policyId string, teamIds []string) error {
+func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.RemoveTeams(policyId, teamIds)
+		result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *RetryLayerRoleStore) Delete(roleID string) (*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.Save(policy)
+		result, err := s.RoleStore.Delete(roleID)
 		if err == nil {
 			return result, nil
 		}
@@ -8093,11 +9403,11 @@ func (s *RetryLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithT
 
 }
 
-func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+func (s *RetryLayerRoleStore) Get(roleID string) (*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.AllChannelSchemeRoles()
+		result, err := s.RoleStore.Get(roleID)
 		if err == nil {
 			return result, nil
 		}
@@ -8114,11 +9424,11 @@ func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+		result, err := s.RoleStore.GetAll()
 		if err == nil {
 			return result, nil
 		}
@@ -8135,11 +9445,11 @@ func (s *RetryLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string)
 
 }
 
-func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+func (s *RetryLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+		result, err := s.RoleStore.GetByName(ctx, name)
 		if err == nil {
 			return result, nil
 		}
@@ -8156,11 +9466,11 @@ func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*mod
 
 }
 
-func (s *RetryLayerRoleStore) Delete(roleID string) (*model.Role, error) {
+func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Delete(roleID)
+		result, err := s.RoleStore.GetByNames(names)
 		if err == nil {
 			return result, nil
 		}
@@ -8177,32 +9487,32 @@ func (s *RetryLayerRoleStore) Delete(roleID string) (*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) Get(roleID string) (*model.Role, error) {
+func (s *RetryLayerRoleStore) PermanentDeleteAll() error {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Get(roleID)
+		err := s.RoleStore.PermanentDeleteAll()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
+func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetAll()
+		result, err := s.RoleStore.Save(role)
 		if err == nil {
 			return result, nil
 		}
@@ -8219,32 +9529,32 @@ func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+func (s *RetryLayerScheduledChannelDeletionStore) Delete(channelID string) error {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetByName(ctx, name)
+		err := s.ScheduledChannelDeletionStore.Delete(channelID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+func (s *RetryLayerScheduledChannelDeletionStore) Get(channelID string) (*model.ScheduledChannelDeletion, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetByNames(names)
+		result, err := s.ScheduledChannelDeletionStore.Get(channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -8261,32 +9571,32 @@ func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error)
 
 }
 
-func (s *RetryLayerRoleStore) PermanentDeleteAll() error {
+func (s *RetryLayerScheduledChannelDeletionStore) GetDue(before int64, limit int) ([]*model.ScheduledChannelDeletion, error) {
 
 	tries := 0
 	for {
-		err := s.RoleStore.PermanentDeleteAll()
+		result, err := s.ScheduledChannelDeletionStore.GetDue(before, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+func (s *RetryLayerScheduledChannelDeletionStore) Save(deletion *model.ScheduledChannelDeletion) (*model.ScheduledChannelDeletion, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Save(role)
+		result, err := s.ScheduledChannelDeletionStore.Save(deletion)
 		if err == nil {
 			return result, nil
 		}
@@ -8681,6 +9991,27 @@ func (s *RetryLayerSessionStore) RemoveAllSessions() error {
 
 }
 
+func (s *RetryLayerSessionStore) RemoveExpiredSessions(now int64, limit int) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.RemoveExpiredSessions(now, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerSessionStore) Save(session *model.Session) (*model.Session, error) {
 
 	tries := 0
@@ -10094,6 +11425,27 @@ func (s *RetryLayerTeamStore) GetMember(ctx context.Context, teamID string, user
 
 }
 
+func (s *RetryLayerTeamStore) GetMemberCountsByScheme() (map[string]int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.TeamStore.GetMemberCountsByScheme()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerTeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
 
 	tries := 0
@@ -10136,6 +11488,27 @@ func (s *RetryLayerTeamStore) GetMembersByIds(teamID string, userIds []string, r
 
 }
 
+func (s *RetryLayerTeamStore) GetMembersByIdsInOrder(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, []string, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.TeamStore.GetMembersByIdsInOrder(teamID, userIds, restrictions)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
 
 	tries := 0
@@ -10241,6 +11614,27 @@ func (s *RetryLayerTeamStore) GetTeamsForUserWithPagination(userID string, page
 
 }
 
+func (s *RetryLayerTeamStore) GetTeamsWithMemberCount(offset int, limit int) ([]*model.TeamWithMemberCount, error) {
+
+	tries := 0
+	for {
+		result, err := s.TeamStore.GetTeamsWithMemberCount(offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
 
 	tries := 0
@@ -10304,6 +11698,27 @@ func (s *RetryLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 
 }
 
+func (s *RetryLayerTeamStore) IncrementInviteUses(teamID string, inviteID string) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.TeamStore.IncrementInviteUses(teamID, inviteID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
 
 	s.TeamStore.InvalidateAllTeamIdsForUser(userID)
@@ -10352,6 +11767,27 @@ func (s *RetryLayerTeamStore) PermanentDelete(teamID string) error {
 
 }
 
+func (s *RetryLayerTeamStore) ReleaseInviteUse(teamID string, inviteID string) error {
+
+	tries := 0
+	for {
+		err := s.TeamStore.ReleaseInviteUse(teamID, inviteID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
 
 	tries := 0
@@ -11234,6 +12670,27 @@ func (s *RetryLayerThreadStore) PermanentDeleteBatchThreadMembershipsForRetentio
 
 }
 
+func (s *RetryLayerThreadStore) RecomputeReplyCounts(afterPostID string, limit int) (string, int64, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.ThreadStore.RecomputeReplyCounts(afterPostID, limit)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
 
 	tries := 0
@@ -11492,6 +12949,27 @@ func (s *RetryLayerUserStore) AnalyticsActiveCount(timestamp int64, options mode
 
 }
 
+func (s *RetryLayerUserStore) AnalyticsActiveCountByDay(since int64) (model.AnalyticsRows, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.AnalyticsActiveCountByDay(since)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
 
 	tries := 0
@@ -11939,6 +13417,27 @@ func (s *RetryLayerUserStore) GetByUsername(username string) (*model.User, error
 
 }
 
+func (s *RetryLayerUserStore) GetByUsernames(usernames []string) ([]*model.User, []string, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.UserStore.GetByUsernames(usernames)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
 
 	tries := 0
@@ -12605,6 +14104,27 @@ func (s *RetryLayerUserStore) Search(teamID string, term string, options *model.
 
 }
 
+func (s *RetryLayerUserStore) SearchByAttribute(key string, value string, page int, perPage int) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.SearchByAttribute(key, value, page, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserStore) SearchInChannel(channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
 
 	tries := 0
@@ -13796,6 +15316,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.BotStore = &RetryLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &RetryLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &RetryLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ChannelTemplateStore = &RetryLayerChannelTemplateStore{ChannelTemplateStore: childStore.ChannelTemplate(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &RetryLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CommandStore = &RetryLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
 	newStore.CommandWebhookStore = &RetryLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
@@ -13803,18 +15324,22 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.EmojiStore = &RetryLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
 	newStore.FileInfoStore = &RetryLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &RetryLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.GuestChannelInviteStore = &RetryLayerGuestChannelInviteStore{GuestChannelInviteStore: childStore.GuestChannelInvite(), Root: &newStore}
 	newStore.JobStore = &RetryLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &RetryLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &RetryLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.OAuthStore = &RetryLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.PluginStore = &RetryLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &RetryLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostReminderStore = &RetryLayerPostReminderStore{PostReminderStore: childStore.PostReminder(), Root: &newStore}
+	newStore.PostReportStore = &RetryLayerPostReportStore{PostReportStore: childStore.PostReport(), Root: &newStore}
 	newStore.PreferenceStore = &RetryLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ProductNoticesStore = &RetryLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
 	newStore.ReactionStore = &RetryLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
 	newStore.RemoteClusterStore = &RetryLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
 	newStore.RetentionPolicyStore = &RetryLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
 	newStore.RoleStore = &RetryLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.ScheduledChannelDeletionStore = &RetryLayerScheduledChannelDeletionStore{ScheduledChannelDeletionStore: childStore.ScheduledChannelDeletion(), Root: &newStore}
 	newStore.SchemeStore = &RetryLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &RetryLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.SharedChannelStore = &RetryLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
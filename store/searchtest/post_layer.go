@@ -64,6 +64,11 @@ var searchPostStoreTests = []searchTest{
 		Fn:   testSearchOrExcludePostsBySpecificUser,
 		Tags: []string{EngineAll},
 	},
+	{
+		Name: "Should be able to search or exclude messages by integration metadata",
+		Fn:   testSearchOrExcludePostsByIntegrationMetadata,
+		Tags: []string{EngineMySql, EnginePostgres},
+	},
 	{
 		Name: "Should be able to search or exclude messages written in a specific channel",
 		Fn:   testSearchOrExcludePostsInChannel,
@@ -633,6 +638,66 @@ func testSearchOrExcludePostsBySpecificUser(t *testing.T, th *SearchTestHelper)
 	th.checkPostInSearchResults(t, p1.Id, results.Posts)
 }
 
+func testSearchOrExcludePostsByIntegrationMetadata(t *testing.T, th *SearchTestHelper) {
+	createPost := func(message, status string) *model.Post {
+		post := th.createPostModel(th.User.Id, th.ChannelBasic.Id, message, "", model.PostTypeDefault, 1000000, false)
+		post.SetIntegrationMetadata("com.example.ci", "status", status)
+		post, err := th.Store.Post().Save(post)
+		require.NoError(t, err)
+		return post
+	}
+
+	p1 := createPost("build finished", "failed")
+	p2 := createPost("build finished", "passed")
+	_, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "build finished", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	t.Run("Should be able to search by integration metadata", func(t *testing.T) {
+		params := &model.SearchParams{
+			Terms:               "build",
+			IntegrationMetadata: []model.IntegrationMetadataFilter{{Namespace: "com.example.ci", Key: "status", Value: "failed"}},
+		}
+		results, err := th.Store.Post().SearchPostsForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+	})
+
+	t.Run("Should be able to search by integration metadata without terms", func(t *testing.T) {
+		params := &model.SearchParams{
+			IntegrationMetadata: []model.IntegrationMetadataFilter{{Namespace: "com.example.ci", Key: "status", Value: "passed"}},
+		}
+		results, err := th.Store.Post().SearchPostsForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+	})
+
+	t.Run("Should be able to exclude by integration metadata", func(t *testing.T) {
+		params := &model.SearchParams{
+			Terms:                       "build",
+			ExcludedIntegrationMetadata: []model.IntegrationMetadataFilter{{Namespace: "com.example.ci", Key: "status", Value: "failed"}},
+		}
+		results, err := th.Store.Post().SearchPostsForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 2)
+		require.NotContains(t, results.Posts, p1.Id)
+	})
+
+	t.Run("Should not match another namespace", func(t *testing.T) {
+		params := &model.SearchParams{
+			IntegrationMetadata: []model.IntegrationMetadataFilter{{Namespace: "com.example.other", Key: "status", Value: "failed"}},
+		}
+		results, err := th.Store.Post().SearchPostsForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+		require.Empty(t, results.Posts)
+	})
+}
+
 func testSearchOrExcludePostsInChannel(t *testing.T, th *SearchTestHelper) {
 	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test fromuser", "", model.PostTypeDefault, 0, false)
 	require.NoError(t, err)
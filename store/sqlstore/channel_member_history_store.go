@@ -267,3 +267,50 @@ func (s SqlChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since
 
 	return channelIds, nil
 }
+
+// GetUsersLeftSince returns a page of the users that have left the channel after a given time, but
+// have not rejoined it again, along with the time they last left, ordered by user id.
+func (s SqlChannelMemberHistoryStore) GetUsersLeftSince(channelID string, since int64, offset, limit int) ([]*model.ChannelMemberHistory, error) {
+	query, params, err := s.getQueryBuilder().
+		Select("ChannelId", "UserId", "MAX(JoinTime) AS JoinTime", "MAX(LeaveTime) AS LeaveTime").
+		From("ChannelMemberHistory").
+		GroupBy("ChannelId", "UserId").
+		Where(sq.Eq{"ChannelId": channelID}).
+		Having("MAX(LeaveTime) > MAX(JoinTime) AND MAX(LeaveTime) IS NOT NULL AND MAX(LeaveTime) >= ?", since).
+		OrderBy("UserId").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_member_history_to_sql")
+	}
+	histories := []*model.ChannelMemberHistory{}
+	err = s.GetReplicaX().Select(&histories, query, params...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetUsersLeftSince channelId=%s since=%d", channelID, since)
+	}
+
+	return histories, nil
+}
+
+func (s SqlChannelMemberHistoryStore) GetChannelMemberHistory(channelID string, from, to int64) ([]*model.ChannelMemberHistory, error) {
+	query, params, err := s.getQueryBuilder().
+		Select("ChannelId", "UserId", "JoinTime", "LeaveTime").
+		From("ChannelMemberHistory").
+		Where(sq.And{
+			sq.Eq{"ChannelId": channelID},
+			sq.LtOrEq{"JoinTime": to},
+			sq.Or{sq.Eq{"LeaveTime": nil}, sq.GtOrEq{"LeaveTime": from}},
+		}).
+		OrderBy("JoinTime", "UserId").ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_member_history_to_sql")
+	}
+	histories := []*model.ChannelMemberHistory{}
+	err = s.GetReplicaX().Select(&histories, query, params...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetChannelMemberHistory channelId=%s from=%d to=%d", channelID, from, to)
+	}
+
+	return histories, nil
+}
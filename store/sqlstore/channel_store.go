@@ -32,6 +32,14 @@ const (
 	ChannelCacheDuration = 15 * time.Minute // 15 mins
 )
 
+const (
+	// channelCreationAuditAction is the action of the audit records saved when channels are created
+	// through the API.
+	channelCreationAuditAction = "/api/v4/channels"
+	// channelCreationAuditWindow is how long after a channel is created its audit record is expected.
+	channelCreationAuditWindow = time.Minute
+)
+
 type SqlChannelStore struct {
 	*SqlStore
 	metrics einterfaces.MetricsInterface
@@ -105,6 +113,19 @@ type channelMemberWithTeamWithSchemeRoles struct {
 
 type channelMemberWithTeamWithSchemeRolesList []channelMemberWithTeamWithSchemeRoles
 
+type channelMemberWithTeamMemberWithSchemeRoles struct {
+	channelMemberWithTeamWithSchemeRoles
+	TeamMemberTeamId               string
+	TeamMemberRoles                string
+	TeamMemberDeleteAt             int64
+	TeamMemberSchemeGuest          sql.NullBool
+	TeamMemberSchemeUser           sql.NullBool
+	TeamMemberSchemeAdmin          sql.NullBool
+	TeamSchemeDefaultTeamGuestRole sql.NullString
+	TeamSchemeDefaultTeamUserRole  sql.NullString
+	TeamSchemeDefaultTeamAdminRole sql.NullString
+}
+
 func channelMemberSliceColumns() []string {
 	return []string{"ChannelId", "UserId", "Roles", "LastViewedAt", "MsgCount", "MsgCountRoot", "MentionCount", "MentionCountRoot", "NotifyProps", "LastUpdateAt", "SchemeUser", "SchemeAdmin", "SchemeGuest"}
 }
@@ -328,6 +349,26 @@ func (db channelMemberWithTeamWithSchemeRoles) ToModel() *model.ChannelMemberWit
 	}
 }
 
+func (db channelMemberWithTeamMemberWithSchemeRoles) ToModel() *model.ChannelMemberWithTeamMember {
+	teamMember := teamMemberWithSchemeRoles{
+		TeamId:                     db.TeamMemberTeamId,
+		UserId:                     db.UserId,
+		Roles:                      db.TeamMemberRoles,
+		DeleteAt:                   db.TeamMemberDeleteAt,
+		SchemeGuest:                db.TeamMemberSchemeGuest,
+		SchemeUser:                 db.TeamMemberSchemeUser,
+		SchemeAdmin:                db.TeamMemberSchemeAdmin,
+		TeamSchemeDefaultGuestRole: db.TeamSchemeDefaultTeamGuestRole,
+		TeamSchemeDefaultUserRole:  db.TeamSchemeDefaultTeamUserRole,
+		TeamSchemeDefaultAdminRole: db.TeamSchemeDefaultTeamAdminRole,
+	}
+
+	return &model.ChannelMemberWithTeamMember{
+		ChannelMemberWithTeamData: *db.channelMemberWithTeamWithSchemeRoles.ToModel(),
+		TeamMember:                *teamMember.ToModel(),
+	}
+}
+
 func (db channelMemberWithSchemeRolesList) ToModel() model.ChannelMembers {
 	cms := model.ChannelMembers{}
 
@@ -681,9 +722,9 @@ func (s SqlChannelStore) saveChannelT(transaction *sqlxTxWrapper, channel *model
 	}
 
 	if _, err := transaction.NamedExec(`INSERT INTO Channels
-		(Id, CreateAt, UpdateAt, DeleteAt, TeamId, Type, DisplayName, Name, Header, Purpose, LastPostAt, TotalMsgCount, ExtraUpdateAt, CreatorId, SchemeId, GroupConstrained, Shared, TotalMsgCountRoot, LastRootPostAt)
+		(Id, CreateAt, UpdateAt, DeleteAt, TeamId, Type, DisplayName, Name, Header, Purpose, LastPostAt, TotalMsgCount, ExtraUpdateAt, CreatorId, SchemeId, GroupConstrained, Shared, TotalMsgCountRoot, LastRootPostAt, SlowModeSeconds, DefaultNotifyLevel, AllowedReactions, AllowedFileExtensions, BlockedFileExtensions)
 		VALUES
-		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :TeamId, :Type, :DisplayName, :Name, :Header, :Purpose, :LastPostAt, :TotalMsgCount, :ExtraUpdateAt, :CreatorId, :SchemeId, :GroupConstrained, :Shared, :TotalMsgCountRoot, :LastRootPostAt)`, channel); err != nil {
+		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :TeamId, :Type, :DisplayName, :Name, :Header, :Purpose, :LastPostAt, :TotalMsgCount, :ExtraUpdateAt, :CreatorId, :SchemeId, :GroupConstrained, :Shared, :TotalMsgCountRoot, :LastRootPostAt, :SlowModeSeconds, :DefaultNotifyLevel, :AllowedReactions, :AllowedFileExtensions, :BlockedFileExtensions)`, channel); err != nil {
 		if IsUniqueConstraintError(err, []string{"Name", "channels_name_teamid_key"}) {
 			dupChannel := model.Channel{}
 			s.GetMasterX().Get(&dupChannel, "SELECT * FROM Channels WHERE TeamId = ? AND Name = ?", channel.TeamId, channel.Name)
@@ -747,7 +788,12 @@ func (s SqlChannelStore) updateChannelT(transaction *sqlxTxWrapper, channel *mod
 			GroupConstrained=:GroupConstrained,
 			Shared=:Shared,
 			TotalMsgCountRoot=:TotalMsgCountRoot,
-			LastRootPostAt=:LastRootPostAt
+			LastRootPostAt=:LastRootPostAt,
+			SlowModeSeconds=:SlowModeSeconds,
+			DefaultNotifyLevel=:DefaultNotifyLevel,
+			AllowedReactions=:AllowedReactions,
+			AllowedFileExtensions=:AllowedFileExtensions,
+			BlockedFileExtensions=:BlockedFileExtensions
 		WHERE Id=:Id`, channel)
 	if err != nil {
 		if IsUniqueConstraintError(err, []string{"Name", "channels_name_teamid_key"}) {
@@ -795,6 +841,40 @@ func (s SqlChannelStore) GetChannelUnread(channelId, userId string) (*model.Chan
 	return &unreadChannel, nil
 }
 
+// GetChannelUnreadCountsForUser returns the unread messages and mentions of each of the user's
+// undeleted channels in the team in a single query. Channels the user has muted, that is, marked as
+// unread only when mentioned, have no unread messages but still report their mentions.
+func (s SqlChannelStore) GetChannelUnreadCountsForUser(userID, teamID string) ([]*model.ChannelUnreadCount, error) {
+	markUnread := "ChannelMembers.NotifyProps->>'" + model.MarkUnreadNotifyProp + "'"
+	if s.DriverName() == model.DatabaseDriverMysql {
+		markUnread = "JSON_UNQUOTE(JSON_EXTRACT(ChannelMembers.NotifyProps, '$." + model.MarkUnreadNotifyProp + "'))"
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Select("Channels.Id ChannelId").
+		Column("CASE WHEN "+markUnread+" = ? THEN 0 ELSE Channels.TotalMsgCount - ChannelMembers.MsgCount END MsgUnread", model.ChannelMarkUnreadMention).
+		Column("ChannelMembers.MentionCount MentionUnread").
+		From("Channels").
+		Join("ChannelMembers ON Channels.Id = ChannelMembers.ChannelId").
+		Where(sq.Eq{
+			"ChannelMembers.UserId": userID,
+			"Channels.TeamId":       teamID,
+			"Channels.DeleteAt":     0,
+		}).
+		OrderBy("Channels.Id").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_unread_counts_tosql")
+	}
+
+	unreads := []*model.ChannelUnreadCount{}
+	if err := s.GetReplicaX().Select(&unreads, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get channel unread counts for userId=%s, teamId=%s", userID, teamID)
+	}
+
+	return unreads, nil
+}
+
 //nolint:unparam
 func (s SqlChannelStore) InvalidateChannel(id string) {
 }
@@ -1399,6 +1479,31 @@ func (s SqlChannelStore) GetPublicChannelsByIdsForTeam(teamId string, channelIds
 	return data, nil
 }
 
+// GetTeamChannelCountForUser returns the number of channels of the team that the user is a member
+// of, not counting archived channels.
+func (s SqlChannelStore) GetTeamChannelCountForUser(teamID string, userID string) (int64, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("ChannelMembers").
+		Join("Channels ON Channels.Id = ChannelMembers.ChannelId").
+		Where(sq.Eq{
+			"ChannelMembers.UserId": userID,
+			"Channels.TeamId":       teamID,
+			"Channels.DeleteAt":     0,
+		}).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "GetTeamChannelCountForUser_ToSql")
+	}
+
+	var count int64
+	if err := s.GetReplicaX().Get(&count, query, args...); err != nil {
+		return 0, errors.Wrapf(err, "failed to count channels with teamId=%s and userId=%s", teamID, userID)
+	}
+
+	return count, nil
+}
+
 func (s SqlChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, error) {
 	data := []struct {
 		Id                string
@@ -1451,6 +1556,14 @@ func (s SqlChannelStore) GetByName(teamId string, name string, allowFromCache bo
 }
 
 func (s SqlChannelStore) GetByNames(teamId string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+	return s.getByNames(teamId, names, false, allowFromCache)
+}
+
+func (s SqlChannelStore) GetByNamesIncludeDeleted(teamId string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+	return s.getByNames(teamId, names, true, allowFromCache)
+}
+
+func (s SqlChannelStore) getByNames(teamId string, names []string, includeDeleted bool, allowFromCache bool) ([]*model.Channel, error) {
 	var channels []*model.Channel
 
 	if allowFromCache {
@@ -1462,7 +1575,7 @@ func (s SqlChannelStore) GetByNames(teamId string, names []string, allowFromCach
 			}
 			visited[name] = struct{}{}
 			var cacheItem *model.Channel
-			if err := channelByNameCache.Get(teamId+name, &cacheItem); err == nil {
+			if err := channelByNameCache.Get(teamId+name, &cacheItem); err == nil && (includeDeleted || cacheItem.DeleteAt == 0) {
 				channels = append(channels, cacheItem)
 			} else {
 				misses = append(misses, name)
@@ -1475,13 +1588,11 @@ func (s SqlChannelStore) GetByNames(teamId string, names []string, allowFromCach
 		builder := s.getQueryBuilder().
 			Select("*").
 			From("Channels").
-			Where(
-				sq.And{
-					sq.Eq{"Name": names},
-					sq.Eq{"DeleteAt": 0},
-				},
-			)
+			Where(sq.Eq{"Name": names})
 
+		if !includeDeleted {
+			builder = builder.Where(sq.Eq{"DeleteAt": 0})
+		}
 		if teamId != "" {
 			builder = builder.Where(sq.Eq{"TeamId": teamId})
 		}
@@ -1611,6 +1722,376 @@ func (s SqlChannelStore) GetDeleted(teamId string, offset int, limit int, userId
 	return channels, nil
 }
 
+func (s SqlChannelStore) GetDuplicateDirectChannels() ([]*model.DuplicateDirectChannels, error) {
+	// A direct channel has one member for a user's channel with themselves and two otherwise, so the
+	// smallest and largest member ids identify the pair of users either way.
+	pairs := s.getQueryBuilder().
+		Select("Channels.Id AS ChannelId", "Channels.CreateAt", "MIN(ChannelMembers.UserId) AS UserA", "MAX(ChannelMembers.UserId) AS UserB").
+		From("Channels").
+		InnerJoin("ChannelMembers ON ChannelMembers.ChannelId = Channels.Id").
+		Where(sq.Eq{"Channels.Type": model.ChannelTypeDirect}).
+		GroupBy("Channels.Id", "Channels.CreateAt")
+
+	duplicates := s.getQueryBuilder().
+		Select("Pairs.UserA", "Pairs.UserB").
+		FromSelect(pairs, "Pairs").
+		GroupBy("Pairs.UserA", "Pairs.UserB").
+		Having("COUNT(*) > 1")
+
+	query := s.getQueryBuilder().
+		Select("Pairs.ChannelId", "Pairs.UserA", "Pairs.UserB").
+		FromSelect(pairs, "Pairs").
+		JoinClause(duplicates.Prefix("INNER JOIN (").Suffix(") Duplicates ON Duplicates.UserA = Pairs.UserA AND Duplicates.UserB = Pairs.UserB")).
+		OrderBy("Pairs.UserA", "Pairs.UserB", "Pairs.CreateAt", "Pairs.ChannelId")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDuplicateDirectChannels_ToSql")
+	}
+
+	var rows []struct {
+		ChannelId string
+		UserA     string
+		UserB     string
+	}
+	if err := s.GetReplicaX().Select(&rows, sql, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find duplicate direct channels")
+	}
+
+	result := []*model.DuplicateDirectChannels{}
+	for _, row := range rows {
+		last := len(result) - 1
+		if last < 0 || result[last].UserIds[0] != row.UserA || result[last].UserIds[1] != row.UserB {
+			result = append(result, &model.DuplicateDirectChannels{UserIds: []string{row.UserA, row.UserB}})
+			last++
+		}
+		result[last].ChannelIds = append(result[last].ChannelIds, row.ChannelId)
+	}
+
+	return result, nil
+}
+
+func channelCreationAuditExtraInfo(name string) string {
+	return "name=" + name
+}
+
+func (s SqlChannelStore) GetCreationInfo(teamID string, offset, limit int) ([]*model.ChannelCreationInfo, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("Id AS ChannelId", "TeamId", "Name", "CreateAt", "CreatorId").
+		From("Channels").
+		Where(sq.Eq{
+			"TeamId": teamID,
+			"Type":   []model.ChannelType{model.ChannelTypeOpen, model.ChannelTypePrivate},
+		}).
+		OrderBy("CreateAt", "Id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "GetCreationInfo_ToSql")
+	}
+
+	infos := []*model.ChannelCreationInfo{}
+	if err := s.GetReplicaX().Select(&infos, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find channel creation info for team_id=%s", teamID)
+	}
+
+	if len(infos) == 0 {
+		return infos, nil
+	}
+
+	// Audit records of channel creations only identify the channel by the name it was created with,
+	// so they are matched to the channels by name and time.
+	extraInfos := make([]string, 0, len(infos))
+	minCreateAt, maxCreateAt := infos[0].CreateAt, infos[0].CreateAt
+	for _, info := range infos {
+		extraInfos = append(extraInfos, channelCreationAuditExtraInfo(info.Name))
+		if info.CreateAt < minCreateAt {
+			minCreateAt = info.CreateAt
+		}
+		if info.CreateAt > maxCreateAt {
+			maxCreateAt = info.CreateAt
+		}
+	}
+
+	query, args, err = s.getQueryBuilder().
+		Select("Id", "CreateAt", "UserId", "ExtraInfo").
+		From("Audits").
+		Where(sq.Eq{"Action": channelCreationAuditAction, "ExtraInfo": extraInfos}).
+		Where(sq.GtOrEq{"CreateAt": minCreateAt}).
+		Where(sq.LtOrEq{"CreateAt": maxCreateAt + channelCreationAuditWindow.Milliseconds()}).
+		OrderBy("CreateAt", "Id").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "GetCreationInfo_Audits_ToSql")
+	}
+
+	audits := []*model.Audit{}
+	if err := s.GetReplicaX().Select(&audits, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find channel creation audits for team_id=%s", teamID)
+	}
+
+	used := make(map[string]bool, len(audits))
+	for _, info := range infos {
+		for _, audit := range audits {
+			if used[audit.Id] || audit.ExtraInfo != channelCreationAuditExtraInfo(info.Name) {
+				continue
+			}
+
+			// The audit record is saved once the channel has been created.
+			if audit.CreateAt < info.CreateAt || audit.CreateAt > info.CreateAt+channelCreationAuditWindow.Milliseconds() {
+				continue
+			}
+
+			used[audit.Id] = true
+			info.AuditId = audit.Id
+			if audit.UserId != "" {
+				info.CreatorId = audit.UserId
+			}
+			break
+		}
+	}
+
+	return infos, nil
+}
+
+// joinLeavePostTypes are the types of the posts that aren't counted in the message counts of a channel.
+var joinLeavePostTypes = []string{
+	model.PostTypeJoinLeave,
+	model.PostTypeAddRemove,
+	model.PostTypeJoinChannel,
+	model.PostTypeLeaveChannel,
+	model.PostTypeJoinTeam,
+	model.PostTypeLeaveTeam,
+	model.PostTypeAddToChannel,
+	model.PostTypeRemoveFromChannel,
+	model.PostTypeAddToTeam,
+	model.PostTypeRemoveFromTeam,
+}
+
+func (s SqlChannelStore) GetDirectChannelsForUsers(userA, userB string) ([]*model.Channel, error) {
+	pair := []string{userA, userB}
+	sort.Strings(pair)
+
+	// A direct channel has one member for a user's channel with themselves and two otherwise, so the
+	// smallest and largest member ids identify the pair of users either way.
+	pairChannels := s.getQueryBuilder().
+		Select("ChannelMembers.ChannelId").
+		From("ChannelMembers").
+		InnerJoin("Channels ON Channels.Id = ChannelMembers.ChannelId").
+		Where(sq.Eq{"Channels.Type": model.ChannelTypeDirect}).
+		Where(sq.Expr("ChannelMembers.ChannelId IN (SELECT ChannelId FROM ChannelMembers WHERE UserId = ?)", pair[0])).
+		GroupBy("ChannelMembers.ChannelId").
+		Having("MIN(ChannelMembers.UserId) = ? AND MAX(ChannelMembers.UserId) = ?", pair[0], pair[1])
+
+	query, args, err := s.getQueryBuilder().
+		Select("Channels.*").
+		From("Channels").
+		JoinClause(pairChannels.Prefix("INNER JOIN (").Suffix(") PairChannels ON PairChannels.ChannelId = Channels.Id")).
+		OrderBy("Channels.CreateAt", "Channels.Id").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDirectChannelsForUsers_ToSql")
+	}
+
+	channels := []*model.Channel{}
+	if err := s.GetReplicaX().Select(&channels, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find direct channels between userA=%s and userB=%s", userA, userB)
+	}
+
+	return channels, nil
+}
+
+func (s SqlChannelStore) MergeDirectChannels(canonicalID string, duplicateIDs []string, deleteAt int64) ([]string, error) {
+	if len(duplicateIDs) == 0 {
+		return nil, nil
+	}
+
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	// Channels archived by an earlier merge have already had their posts moved.
+	query, args, err := s.getQueryBuilder().
+		Select("Id").
+		From("Channels").
+		Where(sq.Eq{"Id": duplicateIDs, "DeleteAt": 0}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "MergeDirectChannels_Duplicates_ToSql")
+	}
+	var mergedIDs []string
+	if err := transaction.Select(&mergedIDs, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get channels with ids=%v", duplicateIDs)
+	}
+	if len(mergedIDs) == 0 {
+		return nil, nil
+	}
+
+	channelIDs := append([]string{canonicalID}, mergedIDs...)
+
+	// The counts are computed from the posts about to be moved rather than from the counts stored on
+	// the channels, since those don't add up once the posts share a channel.
+	joinLeave := "Type IN (" + sq.Placeholders(len(joinLeavePostTypes)) + ")"
+	query, args, err = s.getQueryBuilder().
+		Select().
+		Column("COALESCE(SUM(CASE WHEN "+joinLeave+" THEN 0 ELSE 1 END), 0) AS TotalMsgCount", makeStringArgs(joinLeavePostTypes)...).
+		Column("COALESCE(SUM(CASE WHEN "+joinLeave+" OR RootId <> '' THEN 0 ELSE 1 END), 0) AS TotalMsgCountRoot", makeStringArgs(joinLeavePostTypes)...).
+		Column("COALESCE(MAX(CreateAt), 0) AS LastPostAt").
+		Column("COALESCE(MAX(CASE WHEN RootId = '' THEN CreateAt ELSE 0 END), 0) AS LastRootPostAt").
+		From("Posts").
+		Where(sq.Eq{"ChannelId": channelIDs}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "MergeDirectChannels_ChannelCounts_ToSql")
+	}
+	var merged model.Channel
+	if err := transaction.Get(&merged, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to count posts in channels with ids=%v", channelIDs)
+	}
+
+	query, args, err = s.getQueryBuilder().
+		Update("Channels").
+		Set("TotalMsgCount", merged.TotalMsgCount).
+		Set("TotalMsgCountRoot", merged.TotalMsgCountRoot).
+		Set("LastPostAt", sq.Expr("GREATEST(LastPostAt, ?)", merged.LastPostAt)).
+		Set("LastRootPostAt", sq.Expr("GREATEST(LastRootPostAt, ?)", merged.LastRootPostAt)).
+		Where(sq.Eq{"Id": canonicalID}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "MergeDirectChannels_UpdateChannel_ToSql")
+	}
+	if _, err := transaction.Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to update channel with id=%s", canonicalID)
+	}
+
+	// Each member has read the posts of every channel up to the time they last viewed that channel,
+	// so they keep the same unread messages once the posts are merged.
+	query, args, err = s.getQueryBuilder().
+		Select(
+			"ChannelMembers.UserId",
+			"COUNT(Posts.Id) AS MsgCount",
+			"COALESCE(SUM(CASE WHEN Posts.RootId = '' THEN 1 ELSE 0 END), 0) AS MsgCountRoot",
+		).
+		From("ChannelMembers").
+		LeftJoin("Posts ON Posts.ChannelId = ChannelMembers.ChannelId AND Posts.CreateAt <= ChannelMembers.LastViewedAt AND Posts.Type NOT IN ("+sq.Placeholders(len(joinLeavePostTypes))+")", makeStringArgs(joinLeavePostTypes)...).
+		Where(sq.Eq{"ChannelMembers.ChannelId": channelIDs}).
+		GroupBy("ChannelMembers.UserId").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "MergeDirectChannels_MemberCounts_ToSql")
+	}
+	var viewed []*model.ChannelMember
+	if err := transaction.Select(&viewed, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to count posts viewed in channels with ids=%v", channelIDs)
+	}
+
+	query, args, err = s.getQueryBuilder().
+		Select(
+			"UserId",
+			"MAX(LastViewedAt) AS LastViewedAt",
+			"SUM(MentionCount) AS MentionCount",
+			"SUM(MentionCountRoot) AS MentionCountRoot",
+		).
+		From("ChannelMembers").
+		Where(sq.Eq{"ChannelId": channelIDs}).
+		GroupBy("UserId").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "MergeDirectChannels_MemberMentions_ToSql")
+	}
+	var members []*model.ChannelMember
+	if err := transaction.Select(&members, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get channel members with channel ids=%v", channelIDs)
+	}
+
+	viewedByUser := make(map[string]*model.ChannelMember, len(viewed))
+	for _, member := range viewed {
+		viewedByUser[member.UserId] = member
+	}
+	for _, member := range members {
+		if counts, ok := viewedByUser[member.UserId]; ok {
+			member.MsgCount = counts.MsgCount
+			member.MsgCountRoot = counts.MsgCountRoot
+		}
+
+		query, args, err := s.getQueryBuilder().
+			Update("ChannelMembers").
+			Set("LastViewedAt", member.LastViewedAt).
+			Set("MsgCount", member.MsgCount).
+			Set("MsgCountRoot", member.MsgCountRoot).
+			Set("MentionCount", member.MentionCount).
+			Set("MentionCountRoot", member.MentionCountRoot).
+			Set("LastUpdateAt", model.GetMillis()).
+			Where(sq.Eq{"ChannelId": canonicalID, "UserId": member.UserId}).
+			ToSql()
+		if err != nil {
+			return nil, errors.Wrap(err, "MergeDirectChannels_UpdateMember_ToSql")
+		}
+		if _, err := transaction.Exec(query, args...); err != nil {
+			return nil, errors.Wrapf(err, "failed to update channel member with channelId=%s and userId=%s", canonicalID, member.UserId)
+		}
+	}
+
+	for _, table := range []string{"Posts", "Threads"} {
+		query, args, err := s.getQueryBuilder().
+			Update(table).
+			Set("ChannelId", canonicalID).
+			Where(sq.Eq{"ChannelId": mergedIDs}).
+			ToSql()
+		if err != nil {
+			return nil, errors.Wrapf(err, "MergeDirectChannels_%s_ToSql", table)
+		}
+		if _, err := transaction.Exec(query, args...); err != nil {
+			return nil, errors.Wrapf(err, "failed to move %s to channel with id=%s", table, canonicalID)
+		}
+	}
+
+	for _, channelID := range mergedIDs {
+		if err := s.setDeleteAtT(transaction, channelID, deleteAt, deleteAt); err != nil {
+			return nil, errors.Wrap(err, "setDeleteAtT")
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	for _, channelID := range mergedIDs {
+		s.InvalidateChannel(channelID)
+	}
+
+	return mergedIDs, nil
+}
+
+func (s SqlChannelStore) GetChannelsDeletedBefore(deletedBefore int64, afterChannelID string, limit int) (model.ChannelList, error) {
+	query := s.getQueryBuilder().
+		Select("*").
+		From("Channels").
+		Where(sq.And{
+			sq.NotEq{"DeleteAt": 0},
+			sq.Lt{"DeleteAt": deletedBefore},
+			sq.Gt{"Id": afterChannelID},
+		}).
+		OrderBy("Id").
+		Limit(uint64(limit))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "GetChannelsDeletedBefore_ToSql")
+	}
+
+	channels := model.ChannelList{}
+	if err := s.GetReplicaX().Select(&channels, sql, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get channels deleted before %d", deletedBefore)
+	}
+
+	return channels, nil
+}
+
 var channelMembersWithSchemeSelectQuery = `
 	SELECT
 		ChannelMembers.*,
@@ -1968,6 +2449,99 @@ func (s SqlChannelStore) GetMembers(channelID string, offset, limit int) (model.
 	return dbMembers.ToModel(), nil
 }
 
+func (s SqlChannelStore) GetMembersNotInTeam(afterChannelID, afterUserID string, limit int) (model.ChannelMembers, error) {
+	query := s.channelMembersForTeamWithSchemeSelectQuery.
+		LeftJoin("TeamMembers ON TeamMembers.TeamId = Channels.TeamId AND TeamMembers.UserId = ChannelMembers.UserId AND TeamMembers.DeleteAt = 0").
+		Where(sq.And{
+			sq.NotEq{"Channels.TeamId": ""},
+			sq.Eq{"TeamMembers.UserId": nil},
+			sq.Expr("(ChannelMembers.ChannelId, ChannelMembers.UserId) > (?, ?)", afterChannelID, afterUserID),
+		}).
+		OrderBy("ChannelMembers.ChannelId", "ChannelMembers.UserId").
+		Limit(uint64(limit))
+
+	dbMembers := channelMemberWithSchemeRolesList{}
+	if err := s.GetReplicaX().SelectBuilder(&dbMembers, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get ChannelMembers not in the team of their channel")
+	}
+
+	return dbMembers.ToModel(), nil
+}
+
+func (s SqlChannelStore) GetChannelMembersWithTeamData(channelID string, offset, limit int) ([]*model.ChannelMemberWithTeamMember, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(
+			"ChannelMembers.*",
+			"Teams.DisplayName TeamDisplayName",
+			"Teams.Name TeamName",
+			"Teams.UpdateAt TeamUpdateAt",
+			"TeamScheme.DefaultChannelGuestRole TeamSchemeDefaultGuestRole",
+			"TeamScheme.DefaultChannelUserRole TeamSchemeDefaultUserRole",
+			"TeamScheme.DefaultChannelAdminRole TeamSchemeDefaultAdminRole",
+			"ChannelScheme.DefaultChannelGuestRole ChannelSchemeDefaultGuestRole",
+			"ChannelScheme.DefaultChannelUserRole ChannelSchemeDefaultUserRole",
+			"ChannelScheme.DefaultChannelAdminRole ChannelSchemeDefaultAdminRole",
+			"TeamMembers.TeamId TeamMemberTeamId",
+			"TeamMembers.Roles TeamMemberRoles",
+			"TeamMembers.DeleteAt TeamMemberDeleteAt",
+			"TeamMembers.SchemeGuest TeamMemberSchemeGuest",
+			"TeamMembers.SchemeUser TeamMemberSchemeUser",
+			"TeamMembers.SchemeAdmin TeamMemberSchemeAdmin",
+			"TeamScheme.DefaultTeamGuestRole TeamSchemeDefaultTeamGuestRole",
+			"TeamScheme.DefaultTeamUserRole TeamSchemeDefaultTeamUserRole",
+			"TeamScheme.DefaultTeamAdminRole TeamSchemeDefaultTeamAdminRole",
+		).
+		From("ChannelMembers").
+		InnerJoin("Channels ON ChannelMembers.ChannelId = Channels.Id").
+		InnerJoin("Teams ON Channels.TeamId = Teams.Id").
+		InnerJoin("TeamMembers ON TeamMembers.TeamId = Channels.TeamId AND TeamMembers.UserId = ChannelMembers.UserId").
+		LeftJoin("Schemes ChannelScheme ON Channels.SchemeId = ChannelScheme.Id").
+		LeftJoin("Schemes TeamScheme ON Teams.SchemeId = TeamScheme.Id").
+		Where(sq.Eq{"ChannelMembers.ChannelId": channelID}).
+		OrderBy("ChannelMembers.UserId").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetChannelMembersWithTeamData_ToSql ChannelID=%s", channelID)
+	}
+
+	dbMembers := []channelMemberWithTeamMemberWithSchemeRoles{}
+	if err := s.GetReplicaX().Select(&dbMembers, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ChannelMembers with team members with channelId=%s", channelID)
+	}
+
+	members := make([]*model.ChannelMemberWithTeamMember, 0, len(dbMembers))
+	for _, dbMember := range dbMembers {
+		members = append(members, dbMember.ToModel())
+	}
+
+	return members, nil
+}
+
+// GetMembersUpdatedSince returns a page of the members of the channel that were added or updated at
+// or after the given time, oldest update first.
+func (s SqlChannelStore) GetMembersUpdatedSince(channelID string, since int64, offset, limit int) (model.ChannelMembers, error) {
+	sql, args, err := s.channelMembersForTeamWithSchemeSelectQuery.
+		Where(sq.Eq{"ChannelId": channelID}).
+		Where(sq.GtOrEq{"ChannelMembers.LastUpdateAt": since}).
+		OrderBy("ChannelMembers.LastUpdateAt ASC", "ChannelMembers.UserId ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetMembersUpdatedSince_ToSql ChannelID=%s", channelID)
+	}
+
+	dbMembers := channelMemberWithSchemeRolesList{}
+	err = s.GetReplicaX().Select(&dbMembers, sql, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ChannelMembers with channelId=%s since=%d", channelID, since)
+	}
+
+	return dbMembers.ToModel(), nil
+}
+
 func (s SqlChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, error) {
 	dbMembersTimezone := []model.StringMap{}
 	err := s.GetReplicaX().Select(&dbMembersTimezone, `
@@ -2301,6 +2875,28 @@ func (s SqlChannelStore) GetMemberCountsByGroup(ctx context.Context, channelID s
 	return data, nil
 }
 
+// GetMemberCountsByType returns the number of active members, active guests and deactivated
+// users in the channel.
+func (s SqlChannelStore) GetMemberCountsByType(channelId string) (*model.ChannelMemberCountsByType, error) {
+	var counts model.ChannelMemberCountsByType
+	err := s.GetReplicaX().Get(&counts, `
+		SELECT
+			COALESCE(SUM(CASE WHEN Users.DeleteAt = 0 AND COALESCE(ChannelMembers.SchemeGuest, FALSE) = FALSE THEN 1 ELSE 0 END), 0) AS MemberCount,
+			COALESCE(SUM(CASE WHEN Users.DeleteAt = 0 AND ChannelMembers.SchemeGuest = TRUE THEN 1 ELSE 0 END), 0) AS GuestCount,
+			COALESCE(SUM(CASE WHEN Users.DeleteAt != 0 THEN 1 ELSE 0 END), 0) AS DeactivatedCount
+		FROM
+			ChannelMembers,
+			Users
+		WHERE
+			ChannelMembers.UserId = Users.Id
+			AND ChannelMembers.ChannelId = ?`, channelId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to count ChannelMembers by type with channelId=%s", channelId)
+	}
+
+	return &counts, nil
+}
+
 //nolint:unparam
 func (s SqlChannelStore) InvalidatePinnedPostCount(channelId string) {
 }
@@ -2904,6 +3500,76 @@ func (s SqlChannelStore) GetMembersForUserWithPagination(userId string, page, pe
 	return dbMembers.ToModel(), nil
 }
 
+func (s SqlChannelStore) SearchMembersForUser(userID, term string, includePrivate bool, page, perPage int) (model.ChannelMembersWithTeamData, error) {
+	channelTypes := []model.ChannelType{model.ChannelTypeOpen}
+	if includePrivate {
+		channelTypes = append(channelTypes, model.ChannelTypePrivate)
+	}
+
+	conditions := sq.And{
+		sq.Eq{"ChannelMembers.UserId": userID},
+		sq.Eq{"Channels.Type": channelTypes},
+	}
+
+	if term != "" {
+		term = wildcardSearchTerm(sanitizeSearchTerm(term, "\\"))
+
+		operatorKeyword := "ILIKE"
+		if s.DriverName() == model.DatabaseDriverMysql {
+			operatorKeyword = "LIKE"
+		}
+
+		conditions = append(conditions, sq.Expr(fmt.Sprintf("(Channels.Name %[1]s ? OR Channels.DisplayName %[1]s ?)", operatorKeyword), term, term))
+	}
+
+	where, args, err := conditions.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "search_members_for_user_tosql")
+	}
+
+	dbMembers := channelMemberWithTeamWithSchemeRolesList{}
+	query := channelMembersWithSchemeSelectQuery + "WHERE " + where + " ORDER BY Channels.DisplayName ASC, ChannelMembers.ChannelId ASC LIMIT ? OFFSET ?"
+	args = append(args, perPage, page*perPage)
+	if err := s.GetReplicaX().Select(&dbMembers, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to search ChannelMembers data with userId=%s", userID)
+	}
+
+	return dbMembers.ToModel(), nil
+}
+
+func (s SqlChannelStore) SearchByPurpose(teamID, term string, page, perPage int) (model.ChannelList, error) {
+	channels := model.ChannelList{}
+
+	likeClause := s.buildLIKEClauseX(term, "Channels.Purpose")
+	if likeClause == nil {
+		return channels, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select("*").
+		From("Channels").
+		Where(sq.Eq{
+			"Channels.TeamId":   teamID,
+			"Channels.Type":     []model.ChannelType{model.ChannelTypeOpen, model.ChannelTypePrivate},
+			"Channels.DeleteAt": 0,
+		}).
+		Where(likeClause).
+		OrderBy("Channels.DisplayName ASC", "Channels.Id ASC").
+		Offset(uint64(page * perPage)).
+		Limit(uint64(perPage))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "search_by_purpose_tosql")
+	}
+
+	if err := s.GetReplicaX().Select(&channels, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Channels with teamId=%s by purpose", teamID)
+	}
+
+	return channels, nil
+}
+
 func (s SqlChannelStore) GetTeamMembersForChannel(channelID string) ([]string, error) {
 	teamMemberIDs := []string{}
 	if err := s.GetReplicaX().Select(&teamMemberIDs, `SELECT tm.UserId
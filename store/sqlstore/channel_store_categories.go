@@ -567,22 +567,16 @@ func (s SqlChannelStore) updateSidebarCategoryOrderT(transaction *sqlxTxWrapper,
 	return nil
 }
 
-func (s SqlChannelStore) UpdateSidebarCategoryOrder(userId, teamId string, categoryOrder []string) error {
-	transaction, err := s.GetMasterX().Beginx()
-	if err != nil {
-		return errors.Wrap(err, "begin_transaction")
-	}
-
-	defer finalizeTransactionX(transaction)
-
-	// Ensure no invalid categories are included and that no categories are left out
+// validateSidebarCategoryOrder ensures no invalid categories are included in categoryOrder and that no
+// categories are left out.
+func (s SqlChannelStore) validateSidebarCategoryOrder(userId, teamId string, categoryOrder []string) error {
 	existingOrder, err := s.GetSidebarCategoryOrder(userId, teamId)
 	if err != nil {
 		return err
 	}
 
 	if len(existingOrder) != len(categoryOrder) {
-		return errors.New("cannot update category order, passed list of categories different size than in DB")
+		return store.NewErrInvalidInput("SidebarCategories", "order", fmt.Sprintf("%v", categoryOrder))
 	}
 
 	for _, originalCategoryId := range existingOrder {
@@ -598,6 +592,21 @@ func (s SqlChannelStore) UpdateSidebarCategoryOrder(userId, teamId string, categ
 		}
 	}
 
+	return nil
+}
+
+func (s SqlChannelStore) UpdateSidebarCategoryOrder(userId, teamId string, categoryOrder []string) error {
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+
+	defer finalizeTransactionX(transaction)
+
+	if err := s.validateSidebarCategoryOrder(userId, teamId, categoryOrder); err != nil {
+		return err
+	}
+
 	if err := s.updateSidebarCategoryOrderT(transaction, categoryOrder); err != nil {
 		return err
 	}
@@ -617,6 +626,51 @@ func (s SqlChannelStore) UpdateSidebarCategories(userId, teamId string, categori
 	}
 	defer finalizeTransactionX(transaction)
 
+	updatedCategories, originalCategories, err := s.updateSidebarCategoriesT(transaction, userId, categories)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return updatedCategories, originalCategories, nil
+}
+
+// UpdateSidebarCategoriesAndOrder updates the given categories and the order of all of the user's categories
+// on the team in a single transaction.
+func (s SqlChannelStore) UpdateSidebarCategoriesAndOrder(userId, teamId string, categories []*model.SidebarCategoryWithChannels, categoryOrder []string) ([]*model.SidebarCategoryWithChannels, []*model.SidebarCategoryWithChannels, error) {
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	if err = s.validateSidebarCategoryOrder(userId, teamId, categoryOrder); err != nil {
+		return nil, nil, err
+	}
+
+	// Both of these update SidebarCategories before touching SidebarChannels, which keeps the
+	// order of the queries the same as in UpdateSidebarCategories.
+	if err = s.updateSidebarCategoryOrderT(transaction, categoryOrder); err != nil {
+		return nil, nil, err
+	}
+
+	updatedCategories, originalCategories, err := s.updateSidebarCategoriesT(transaction, userId, categories)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return updatedCategories, originalCategories, nil
+}
+
+func (s SqlChannelStore) updateSidebarCategoriesT(transaction *sqlxTxWrapper, userId string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, []*model.SidebarCategoryWithChannels, error) {
+	var err error
 	updatedCategories := []*model.SidebarCategoryWithChannels{}
 	originalCategories := []*model.SidebarCategoryWithChannels{}
 	for _, category := range categories {
@@ -768,10 +822,6 @@ func (s SqlChannelStore) UpdateSidebarCategories(userId, teamId string, categori
 		updatedCategories[i] = populated
 	}
 
-	if err = transaction.Commit(); err != nil {
-		return nil, nil, errors.Wrap(err, "commit_transaction")
-	}
-
 	return updatedCategories, originalCategories, nil
 }
 
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlChannelTemplateStore struct {
+	*SqlStore
+}
+
+func newSqlChannelTemplateStore(sqlStore *SqlStore) store.ChannelTemplateStore {
+	return &SqlChannelTemplateStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func channelTemplateColumns() []string {
+	return []string{"Id", "TeamId", "Name", "Type", "Purpose", "Header", "PinnedMessages", "CreatorId", "CreateAt", "UpdateAt"}
+}
+
+func (s *SqlChannelTemplateStore) Save(template *model.ChannelTemplate) (*model.ChannelTemplate, error) {
+	if template.Id != "" {
+		return nil, store.NewErrInvalidInput("ChannelTemplate", "id", template.Id)
+	}
+
+	template.PreSave()
+	if err := template.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("ChannelTemplates").
+		Columns(channelTemplateColumns()...).
+		Values(template.Id, template.TeamId, template.Name, template.Type, template.Purpose, template.Header,
+			template.PinnedMessages, template.CreatorId, template.CreateAt, template.UpdateAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_template_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ChannelTemplate with id=%s", template.Id)
+	}
+
+	return template, nil
+}
+
+func (s *SqlChannelTemplateStore) Get(id string) (*model.ChannelTemplate, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(channelTemplateColumns()...).
+		From("ChannelTemplates").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_template_tosql")
+	}
+
+	var template model.ChannelTemplate
+	if err := s.GetReplicaX().Get(&template, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ChannelTemplate", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get ChannelTemplate with id=%s", id)
+	}
+
+	return &template, nil
+}
+
+func (s *SqlChannelTemplateStore) GetForTeam(teamID string) ([]*model.ChannelTemplate, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(channelTemplateColumns()...).
+		From("ChannelTemplates").
+		Where(sq.Eq{"TeamId": teamID}).
+		OrderBy("Name", "Id").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_templates_tosql")
+	}
+
+	templates := []*model.ChannelTemplate{}
+	if err := s.GetReplicaX().Select(&templates, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ChannelTemplates with teamId=%s", teamID)
+	}
+
+	return templates, nil
+}
+
+func (s *SqlChannelTemplateStore) Delete(id string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("ChannelTemplates").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "channel_template_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete ChannelTemplate with id=%s", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("ChannelTemplate", id)
+	}
+
+	return nil
+}
@@ -133,6 +133,41 @@ func (es SqlEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*mode
 	return emojis, nil
 }
 
+// GetUnusedCustomEmoji returns up to limit custom emoji that no active reaction uses and whose
+// :name: doesn't appear in any message posted since messagesSince. Scanning messages is bounded to
+// keep the query affordable, so usage in older messages is not taken into account.
+func (es SqlEmojiStore) GetUnusedCustomEmoji(messagesSince int64, limit int) (*model.UnusedEmojiList, error) {
+	emojis := []*model.Emoji{}
+
+	if err := es.GetReplicaX().Select(&emojis,
+		`SELECT
+			Emoji.*
+		FROM
+			Emoji
+		WHERE
+			Emoji.DeleteAt = 0
+			AND NOT EXISTS (
+				SELECT 1 FROM Reactions
+				WHERE Reactions.EmojiName = Emoji.Name
+				AND Reactions.DeleteAt = 0
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM Posts
+				WHERE Posts.CreateAt >= ?
+				AND Posts.DeleteAt = 0
+				AND Posts.Message LIKE CONCAT('%:', Emoji.Name, ':%')
+			)
+		ORDER BY Emoji.Name
+		LIMIT ?`, messagesSince, limit); err != nil {
+		return nil, errors.Wrap(err, "could not get unused emojis")
+	}
+
+	return &model.UnusedEmojiList{
+		Emoji:                emojis,
+		MessagesScannedSince: messagesSince,
+	}, nil
+}
+
 // getBy returns one active (not deleted) emoji, found by any one column (what/key).
 func (es SqlEmojiStore) getBy(ctx context.Context, what, key string) (*model.Emoji, error) {
 	var emoji model.Emoji
@@ -42,6 +42,7 @@ type fileInfoWithChannelID struct {
 	Content         string
 	RemoteId        *string
 	Archived        bool
+	Quarantined     bool
 }
 
 func (fi fileInfoWithChannelID) ToModel() *model.FileInfo {
@@ -66,6 +67,7 @@ func (fi fileInfoWithChannelID) ToModel() *model.FileInfo {
 		MiniPreview:     fi.MiniPreview,
 		Content:         fi.Content,
 		RemoteId:        fi.RemoteId,
+		Quarantined:     fi.Quarantined,
 	}
 }
 
@@ -105,6 +107,7 @@ func newSqlFileInfoStore(sqlStore *SqlStore, metrics einterfaces.MetricsInterfac
 		"Coalesce(FileInfo.Content, '') AS Content",
 		"Coalesce(FileInfo.RemoteId, '') AS RemoteId",
 		"FileInfo.Archived",
+		"FileInfo.Quarantined",
 	}
 
 	return s
@@ -119,10 +122,10 @@ func (fs SqlFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
 	query := `
 		INSERT INTO FileInfo
 		(Id, CreatorId, PostId, CreateAt, UpdateAt, DeleteAt, Path, ThumbnailPath, PreviewPath,
-			Name, Extension, Size, MimeType, Width, Height, HasPreviewImage, MiniPreview, Content, RemoteId)
+			Name, Extension, Size, MimeType, Width, Height, HasPreviewImage, MiniPreview, Content, RemoteId, Quarantined)
 		VALUES
 		(:Id, :CreatorId, :PostId, :CreateAt, :UpdateAt, :DeleteAt, :Path, :ThumbnailPath, :PreviewPath,
-			:Name, :Extension, :Size, :MimeType, :Width, :Height, :HasPreviewImage, :MiniPreview, :Content, :RemoteId)
+			:Name, :Extension, :Size, :MimeType, :Width, :Height, :HasPreviewImage, :MiniPreview, :Content, :RemoteId, :Quarantined)
 	`
 
 	if _, err := fs.GetMasterX().NamedExec(query, info); err != nil {
@@ -366,6 +369,36 @@ func (fs SqlFileInfoStore) GetForPost(postId string, readFromMaster, includeDele
 	return infos, nil
 }
 
+func (fs SqlFileInfoStore) GetForPosts(postIds []string) (map[string][]*model.FileInfo, error) {
+	infosByPost := make(map[string][]*model.FileInfo)
+	if len(postIds) == 0 {
+		return infosByPost, nil
+	}
+
+	query := fs.getQueryBuilder().
+		Select(fs.queryFields...).
+		From("FileInfo").
+		Where(sq.Eq{"PostId": postIds}).
+		Where(sq.Eq{"DeleteAt": 0}).
+		OrderBy("PostId", "CreateAt")
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	infos := []*model.FileInfo{}
+	if err := fs.GetReplicaX().Select(&infos, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find FileInfos for posts")
+	}
+
+	for _, info := range infos {
+		infosByPost[info.PostId] = append(infosByPost[info.PostId], info)
+	}
+
+	return infosByPost, nil
+}
+
 func (fs SqlFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, error) {
 	infos := []*model.FileInfo{}
 
@@ -439,6 +472,24 @@ func (fs SqlFileInfoStore) SetContent(fileId, content string) error {
 	return nil
 }
 
+func (fs SqlFileInfoStore) SetQuarantined(fileID string, quarantined bool) error {
+	query, args, err := fs.getQueryBuilder().
+		Update("FileInfo").
+		Set("Quarantined", quarantined).
+		Set("UpdateAt", model.GetMillis()).
+		Where(sq.Eq{"Id": fileID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "file_info_tosql")
+	}
+
+	if _, err := fs.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to update FileInfo quarantine with id=%s", fileID)
+	}
+
+	return nil
+}
+
 func (fs SqlFileInfoStore) DeleteForPost(postId string) (string, error) {
 	if _, err := fs.GetMasterX().Exec(
 		`UPDATE
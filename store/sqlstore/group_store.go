@@ -479,6 +479,33 @@ func (s *SqlGroupStore) GetMemberCount(groupID string) (int64, error) {
 	return count, nil
 }
 
+func (s *SqlGroupStore) GetChannelMemberCountsByGroup(channelID string) ([]*model.ChannelMemberCountByGroup, error) {
+	query := s.getQueryBuilder().
+		Select("GroupChannels.GroupId, COUNT(ChannelMembers.UserId) AS ChannelMemberCount").
+		From("GroupChannels").
+		Join("UserGroups ON UserGroups.Id = GroupChannels.GroupId AND UserGroups.DeleteAt = 0").
+		LeftJoin("GroupMembers ON GroupMembers.GroupId = GroupChannels.GroupId AND GroupMembers.DeleteAt = 0").
+		LeftJoin("ChannelMembers ON ChannelMembers.UserId = GroupMembers.UserId AND ChannelMembers.ChannelId = GroupChannels.ChannelId").
+		Where(sq.Eq{
+			"GroupChannels.ChannelId": channelID,
+			"GroupChannels.DeleteAt":  0,
+		}).
+		GroupBy("GroupChannels.GroupId").
+		OrderBy("GroupChannels.GroupId")
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "get_channel_member_counts_by_group_tosql")
+	}
+
+	counts := []*model.ChannelMemberCountByGroup{}
+	if err := s.GetReplicaX().Select(&counts, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to count ChannelMembers by Group with channelId=%s", channelID)
+	}
+
+	return counts, nil
+}
+
 func (s *SqlGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, error) {
 	groupMembers := []*model.User{}
 
@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlGuestChannelInviteStore struct {
+	*SqlStore
+}
+
+func newSqlGuestChannelInviteStore(sqlStore *SqlStore) store.GuestChannelInviteStore {
+	return &SqlGuestChannelInviteStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlGuestChannelInviteStore) Save(invite *model.GuestChannelInvite) error {
+	invite.PreSave()
+	if err := invite.IsValid(); err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("GuestChannelInvites").
+		Columns("UserId", "ChannelId", "CreateAt").
+		Values(invite.UserId, invite.ChannelId, invite.CreateAt)
+
+	// Inviting a guest to a channel they were already invited to keeps the original invite.
+	if s.DriverName() == model.DatabaseDriverMysql {
+		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE CreateAt = CreateAt"))
+	} else {
+		query = query.SuffixExpr(sq.Expr("ON CONFLICT (userid, channelid) DO NOTHING"))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "guest_channel_invite_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(sql, args...); err != nil {
+		return errors.Wrapf(err, "failed to save GuestChannelInvite with user_id=%s, channel_id=%s", invite.UserId, invite.ChannelId)
+	}
+
+	return nil
+}
+
+func (s *SqlGuestChannelInviteStore) Exists(userID, channelID string) (bool, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("GuestChannelInvites").
+		Where(sq.Eq{"UserId": userID, "ChannelId": channelID}).
+		ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "guest_channel_invite_tosql")
+	}
+
+	// Invites are usually checked right after being saved when a guest joins from an invitation,
+	// so read from the master to avoid missing them.
+	var count int64
+	if err := s.GetMasterX().Get(&count, query, args...); err != nil {
+		return false, errors.Wrapf(err, "failed to count GuestChannelInvites with user_id=%s, channel_id=%s", userID, channelID)
+	}
+
+	return count > 0, nil
+}
+
+func (s *SqlGuestChannelInviteStore) Delete(userID, channelID string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("GuestChannelInvites").
+		Where(sq.Eq{"UserId": userID, "ChannelId": channelID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "guest_channel_invite_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete GuestChannelInvite with user_id=%s, channel_id=%s", userID, channelID)
+	}
+
+	return nil
+}
+
+func (s *SqlGuestChannelInviteStore) Cleanup(expiryTime int64) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("GuestChannelInvites").
+		Where(sq.Lt{"CreateAt": expiryTime}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "guest_channel_invite_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrap(err, "failed to delete expired GuestChannelInvites")
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlPostReminderStore struct {
+	*SqlStore
+}
+
+func newSqlPostReminderStore(sqlStore *SqlStore) store.PostReminderStore {
+	return &SqlPostReminderStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func postReminderColumns() []string {
+	return []string{"PostId", "UserId", "RemindAt", "CreateAt", "FailedAttempts"}
+}
+
+func (s *SqlPostReminderStore) Save(reminder *model.PostReminder) (*model.PostReminder, error) {
+	reminder.PreSave()
+	if err := reminder.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("PostReminders").
+		Columns(postReminderColumns()...).
+		Values(reminder.PostId, reminder.UserId, reminder.RemindAt, reminder.CreateAt, reminder.FailedAttempts)
+
+	if s.DriverName() == model.DatabaseDriverMysql {
+		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE RemindAt = ?, CreateAt = ?, FailedAttempts = ?", reminder.RemindAt, reminder.CreateAt, reminder.FailedAttempts))
+	} else {
+		query = query.SuffixExpr(sq.Expr("ON CONFLICT (postid, userid) DO UPDATE SET RemindAt = ?, CreateAt = ?, FailedAttempts = ?", reminder.RemindAt, reminder.CreateAt, reminder.FailedAttempts))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_reminder_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(sql, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save PostReminder with post_id=%s, user_id=%s", reminder.PostId, reminder.UserId)
+	}
+
+	return reminder, nil
+}
+
+func (s *SqlPostReminderStore) GetForUser(userID string) ([]*model.PostReminder, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(postReminderColumns()...).
+		From("PostReminders").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("RemindAt", "PostId").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_reminders_tosql")
+	}
+
+	reminders := []*model.PostReminder{}
+	if err := s.GetReplicaX().Select(&reminders, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find PostReminders with user_id=%s", userID)
+	}
+
+	return reminders, nil
+}
+
+func (s *SqlPostReminderStore) GetDue(before int64, limit int) ([]*model.PostReminder, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(postReminderColumns()...).
+		From("PostReminders").
+		Where(sq.LtOrEq{"RemindAt": before}).
+		OrderBy("RemindAt", "PostId", "UserId").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_reminders_tosql")
+	}
+
+	// Due reminders are deleted as soon as they are sent, so read from the master to avoid
+	// sending the same reminder twice.
+	reminders := []*model.PostReminder{}
+	if err := s.GetMasterX().Select(&reminders, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find due PostReminders")
+	}
+
+	return reminders, nil
+}
+
+func (s *SqlPostReminderStore) Delete(userID, postID string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("PostReminders").
+		Where(sq.Eq{"UserId": userID, "PostId": postID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "post_reminder_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete PostReminder with post_id=%s, user_id=%s", postID, userID)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("PostReminder", "post_id="+postID+", user_id="+userID)
+	}
+
+	return nil
+}
+
+func (s *SqlPostReminderStore) UpdateFailedAttempts(userID, postID string, attempts int) error {
+	query, args, err := s.getQueryBuilder().
+		Update("PostReminders").
+		Set("FailedAttempts", attempts).
+		Where(sq.Eq{"UserId": userID, "PostId": postID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "post_reminder_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to update PostReminder with post_id=%s, user_id=%s", postID, userID)
+	}
+
+	return nil
+}
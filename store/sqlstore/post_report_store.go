@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlPostReportStore struct {
+	*SqlStore
+}
+
+func newSqlPostReportStore(sqlStore *SqlStore) store.PostReportStore {
+	return &SqlPostReportStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func postReportColumns() []string {
+	return []string{"Id", "PostId", "ChannelId", "ReporterId", "Reason", "Status", "CreateAt", "UpdateAt"}
+}
+
+func (s *SqlPostReportStore) Save(report *model.PostReport) (*model.PostReport, error) {
+	if report.Id != "" {
+		return nil, store.NewErrInvalidInput("PostReport", "id", report.Id)
+	}
+
+	report.PreSave()
+	if err := report.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("PostReports").
+		Columns(postReportColumns()...).
+		Values(report.Id, report.PostId, report.ChannelId, report.ReporterId, report.Reason, report.Status, report.CreateAt, report.UpdateAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_report_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		if IsUniqueConstraintError(err, []string{"idx_postreports_post_id_reporter_id", "postreports_postid_reporterid_key"}) {
+			return nil, store.NewErrConflict("PostReport", err, "post_id="+report.PostId+", reporter_id="+report.ReporterId)
+		}
+		return nil, errors.Wrapf(err, "failed to save PostReport with id=%s", report.Id)
+	}
+
+	return report, nil
+}
+
+func (s *SqlPostReportStore) Get(id string) (*model.PostReport, error) {
+	return s.get(s.GetReplicaX(), id)
+}
+
+func (s *SqlPostReportStore) get(db *sqlxDBWrapper, id string) (*model.PostReport, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(postReportColumns()...).
+		From("PostReports").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_report_tosql")
+	}
+
+	var report model.PostReport
+	if err := db.Get(&report, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("PostReport", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get PostReport with id=%s", id)
+	}
+
+	return &report, nil
+}
+
+func (s *SqlPostReportStore) GetAll(options model.PostReportGetOptions) ([]*model.PostReport, error) {
+	if options.Page < 0 {
+		return nil, store.NewErrInvalidInput("PostReport", "Page", options.Page)
+	}
+	if options.PerPage < 0 {
+		return nil, store.NewErrInvalidInput("PostReport", "PerPage", options.PerPage)
+	}
+
+	builder := s.getQueryBuilder().
+		Select(postReportColumns()...).
+		From("PostReports").
+		OrderBy("CreateAt DESC", "Id DESC").
+		Limit(uint64(options.PerPage)).
+		Offset(uint64(options.Page * options.PerPage))
+
+	if options.Status != "" {
+		builder = builder.Where(sq.Eq{"Status": options.Status})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_reports_tosql")
+	}
+
+	reports := []*model.PostReport{}
+	if err := s.GetReplicaX().Select(&reports, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find PostReports")
+	}
+
+	return reports, nil
+}
+
+func (s *SqlPostReportStore) UpdateStatus(id, status string) (*model.PostReport, error) {
+	report, err := s.get(s.GetMasterX(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Status = status
+	report.PreUpdate()
+	if appErr := report.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("PostReports").
+		Set("Status", report.Status).
+		Set("UpdateAt", report.UpdateAt).
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_report_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to update PostReport with id=%s", id)
+	}
+
+	return report, nil
+}
@@ -463,6 +463,29 @@ func (s *SqlPostStore) GetFlaggedPostsForChannel(userId, channelId string, offse
 	return s.getFlaggedPosts(userId, channelId, "", offset, limit)
 }
 
+func (s *SqlPostStore) GetFlaggedPostsForUserPaged(userId string, options model.GetFlaggedPostsOptions) (*model.PostList, error) {
+	if options.Page < 0 {
+		return nil, store.NewErrInvalidInput("Post", "Page", options.Page)
+	}
+	if options.PerPage <= 0 {
+		return nil, store.NewErrInvalidInput("Post", "PerPage", options.PerPage)
+	}
+
+	// Fetch one extra post to know whether there is another page.
+	pl, err := s.getFlaggedPosts(userId, options.ChannelId, options.TeamId, options.Page*options.PerPage, options.PerPage+1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pl.Order) > options.PerPage {
+		delete(pl.Posts, pl.Order[options.PerPage])
+		pl.Order = pl.Order[:options.PerPage]
+		pl.HasNext = true
+	}
+
+	return pl, nil
+}
+
 // TODO: convert to squirrel HW
 func (s *SqlPostStore) getFlaggedPosts(userId, channelId, teamId string, offset int, limit int) (*model.PostList, error) {
 	pl := model.NewPostList()
@@ -505,7 +528,7 @@ func (s *SqlPostStore) getFlaggedPosts(userId, channelId, teamId string, offset
 						AND UserId = ?
 				)
 				TEAM_FILTER
-            ORDER BY CreateAt DESC
+            ORDER BY CreateAt DESC, Id DESC
             LIMIT ? OFFSET ?`
 
 	queryParams := []interface{}{userId, model.PreferenceCategoryFlaggedPost}
@@ -538,7 +561,7 @@ func (s *SqlPostStore) buildFlaggedPostTeamFilterClause(teamId string, queryPara
 		return "", queryParams
 	}
 
-	return "AND B.TeamId = ? OR B.TeamId = ''", append(queryParams, teamId)
+	return "AND (B.TeamId = ? OR B.TeamId = '')", append(queryParams, teamId)
 }
 
 func (s *SqlPostStore) buildFlaggedPostChannelFilterClause(channelId string, queryParams []interface{}) (string, []interface{}) {
@@ -1212,7 +1235,7 @@ func (s *SqlPostStore) getPostsSinceCollapsedThreads(options model.GetPostsSince
 	)
 	var posts []*postWithExtra
 
-	postFetchQuery, args, _ := s.getQueryBuilder().
+	postFetchQuery := s.getQueryBuilder().
 		Select(columns...).
 		From("Posts").
 		LeftJoin("Threads ON Threads.PostId = Posts.Id").
@@ -1220,10 +1243,18 @@ func (s *SqlPostStore) getPostsSinceCollapsedThreads(options model.GetPostsSince
 		Where(sq.Eq{"Posts.DeleteAt": 0}).
 		Where(sq.Eq{"Posts.ChannelId": options.ChannelId}).
 		Where(sq.Gt{"Posts.UpdateAt": options.Time}).
-		Where(sq.Eq{"Posts.RootId": ""}).
-		OrderBy("Posts.CreateAt DESC").ToSql()
+		Where(sq.Eq{"Posts.RootId": ""})
 
-	err := s.GetReplicaX().Select(&posts, postFetchQuery, args...)
+	if options.ExcludeUserId != "" {
+		postFetchQuery = postFetchQuery.Where(sq.NotEq{"Posts.UserId": options.ExcludeUserId})
+	}
+
+	query, args, err := postFetchQuery.OrderBy("Posts.CreateAt DESC").ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "getPostsSinceCollapsedThreads_tosql")
+	}
+
+	err = s.GetReplicaX().Select(&posts, query, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
 	}
@@ -1252,6 +1283,11 @@ func (s *SqlPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFr
 	var query string
 	var params []interface{}
 
+	excludeUserQuery := ""
+	if options.ExcludeUserId != "" {
+		excludeUserQuery = " AND UserId != ?"
+	}
+
 	// union of IDs and then join to get full posts is faster in mysql
 	if s.DriverName() == model.DatabaseDriverMysql {
 		query = `SELECT *` + replyCountQuery1 + ` FROM Posts p1 JOIN (
@@ -1261,7 +1297,7 @@ func (s *SqlPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFr
 				  Posts p2
 			  WHERE
 				  (UpdateAt > ?
-					  AND ChannelId = ?)
+					  AND ChannelId = ?` + excludeUserQuery + `)
 				  LIMIT 1000)
 			  UNION
 				  (SELECT
@@ -1277,19 +1313,22 @@ func (s *SqlPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFr
 						  Posts
 					  WHERE
 						  UpdateAt > ?
-							  AND ChannelId = ?
+							  AND ChannelId = ?` + excludeUserQuery + `
 					  LIMIT 1000) temp_tab))
 			) j ON p1.Id = j.Id
           ORDER BY CreateAt ` + order
 
 		params = []interface{}{options.Time, options.ChannelId, options.Time, options.ChannelId}
+		if options.ExcludeUserId != "" {
+			params = []interface{}{options.Time, options.ChannelId, options.ExcludeUserId, options.Time, options.ChannelId, options.ExcludeUserId}
+		}
 	} else if s.DriverName() == model.DatabaseDriverPostgres {
 		query = `WITH cte AS (SELECT
 		       *
 		FROM
 		       Posts
 		WHERE
-		       UpdateAt > ? AND ChannelId = ?
+		       UpdateAt > ? AND ChannelId = ?` + excludeUserQuery + `
 		       LIMIT 1000)
 		(SELECT *` + replyCountQuery2 + ` FROM cte)
 		UNION
@@ -1297,6 +1336,9 @@ func (s *SqlPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFr
 		ORDER BY CreateAt ` + order
 
 		params = []interface{}{options.Time, options.ChannelId}
+		if options.ExcludeUserId != "" {
+			params = append(params, options.ExcludeUserId)
+		}
 	}
 	err := s.GetReplicaX().Select(&posts, query, params...)
 	if err != nil {
@@ -1307,7 +1349,8 @@ func (s *SqlPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFr
 
 	for _, p := range posts {
 		list.AddPost(p)
-		if p.UpdateAt > options.Time {
+		// The roots of the threads are fetched regardless of their author
+		if p.UpdateAt > options.Time && (options.ExcludeUserId == "" || p.UserId != options.ExcludeUserId) {
 			list.AddOrder(p.Id)
 		}
 	}
@@ -1378,6 +1421,185 @@ func (s *SqlPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOp
 	return posts, cursor, nil
 }
 
+func (s *SqlPostStore) GetPostsUpdatedSince(channelID string, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("Posts").
+		Where(sq.And{
+			sq.Eq{"ChannelId": channelID},
+			sq.Or{sq.Gt{"UpdateAt": cursor.LastPostUpdateAt}, sq.And{sq.Eq{"UpdateAt": cursor.LastPostUpdateAt}, sq.Gt{"Id": cursor.LastPostId}}},
+			sq.Eq{"OriginalId": ""},
+		}).
+		OrderBy("UpdateAt", "Id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, cursor, errors.Wrap(err, "getpostsupdatedsince_tosql")
+	}
+
+	posts := []*model.Post{}
+	if err := s.GetReplicaX().Select(&posts, query, args...); err != nil {
+		return nil, cursor, errors.Wrapf(err, "failed to find Posts with channelId=%s", channelID)
+	}
+
+	if len(posts) != 0 {
+		cursor.LastPostUpdateAt = posts[len(posts)-1].UpdateAt
+		cursor.LastPostId = posts[len(posts)-1].Id
+	}
+	return posts, cursor, nil
+}
+
+func (s *SqlPostStore) GetPostsBetween(channelID string, from, to int64, page, perPage int) ([]*model.Post, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("Posts").
+		Where(sq.And{
+			sq.Eq{"ChannelId": channelID},
+			sq.GtOrEq{"CreateAt": from},
+			sq.LtOrEq{"CreateAt": to},
+			sq.Eq{"DeleteAt": 0},
+			sq.Eq{"OriginalId": ""},
+		}).
+		OrderBy("CreateAt", "Id").
+		Offset(uint64(page * perPage)).
+		Limit(uint64(perPage)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "getpostsbetween_tosql")
+	}
+
+	posts := []*model.Post{}
+	if err := s.GetReplicaX().Select(&posts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", channelID)
+	}
+
+	return posts, nil
+}
+
+func (s *SqlPostStore) GetRecentPostsForUser(userID string, cursor model.GetRecentPostsForUserCursor, limit int) ([]*model.Post, model.GetRecentPostsForUserCursor, error) {
+	query := s.getQueryBuilder().
+		Select("p.*").
+		From("Posts p").
+		InnerJoin("ChannelMembers cm ON cm.ChannelId = p.ChannelId AND cm.UserId = p.UserId").
+		Where(sq.And{
+			sq.Eq{"p.UserId": userID},
+			sq.Eq{"p.DeleteAt": 0},
+			sq.NotLike{"p.Type": model.PostSystemMessagePrefix + "%"},
+		}).
+		OrderBy("p.CreateAt DESC", "p.Id DESC").
+		Limit(uint64(limit))
+
+	if cursor.LastPostCreateAt != 0 {
+		query = query.Where(sq.Or{
+			sq.Lt{"p.CreateAt": cursor.LastPostCreateAt},
+			sq.And{sq.Eq{"p.CreateAt": cursor.LastPostCreateAt}, sq.Lt{"p.Id": cursor.LastPostId}},
+		})
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, cursor, errors.Wrap(err, "getrecentpostsforuser_tosql")
+	}
+
+	posts := []*model.Post{}
+	if err := s.GetReplicaX().Select(&posts, queryString, args...); err != nil {
+		return nil, cursor, errors.Wrapf(err, "failed to get recent posts for userId=%s", userID)
+	}
+
+	if len(posts) != 0 {
+		cursor.LastPostCreateAt = posts[len(posts)-1].CreateAt
+		cursor.LastPostId = posts[len(posts)-1].Id
+	}
+	return posts, cursor, nil
+}
+
+// GetThreadParticipants returns the ids of up to limit distinct users who replied to the thread
+// rooted at rootID, ordered by the time of their first reply. Deleted replies and system messages
+// aren't counted.
+func (s *SqlPostStore) GetThreadParticipants(rootID string, limit int) ([]string, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("UserId").
+		From("Posts").
+		Where(sq.And{
+			sq.Eq{"RootId": rootID},
+			sq.Eq{"DeleteAt": 0},
+			sq.NotLike{"Type": model.PostSystemMessagePrefix + "%"},
+		}).
+		GroupBy("UserId").
+		OrderBy("MIN(CreateAt) ASC", "UserId ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "getthreadparticipants_tosql")
+	}
+
+	userIDs := []string{}
+	if err := s.GetReplicaX().Select(&userIDs, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get thread participants for rootId=%s", rootID)
+	}
+
+	return userIDs, nil
+}
+
+func (s *SqlPostStore) GetPostsByHashtag(teamID, hashtag string, channelIDs []string, page, perPage int) (*model.PostList, error) {
+	list := model.NewPostList()
+
+	tag := strings.ToLower(strings.TrimPrefix(hashtag, "#"))
+	if tag == "" || len(channelIDs) == 0 {
+		return list, nil
+	}
+
+	// The full-text index narrows down the candidates, which are then matched against the whole tag
+	// since the index also matches the posts with a tag that only contains it.
+	var searchClause, matchClause sq.Sqlizer
+	matchTerm := "% #" + sanitizeSearchTerm(tag, "*") + " %"
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		searchClause = sq.Expr("to_tsvector('english', Hashtags) @@ plainto_tsquery('english', ?)", tag)
+		matchClause = sq.Expr("(' ' || LOWER(Hashtags) || ' ') LIKE ? ESCAPE '*'", matchTerm)
+	} else {
+		searchClause = sq.Expr("MATCH (Hashtags) AGAINST (? IN BOOLEAN MODE)", `"`+tag+`"`)
+		matchClause = sq.Expr("CONCAT(' ', LOWER(Hashtags), ' ') LIKE ? ESCAPE '*'", matchTerm)
+	}
+
+	channelQuery := s.getSubQueryBuilder().
+		Select("Id").
+		From("Channels").
+		Where(sq.And{
+			sq.Eq{"Id": channelIDs},
+			sq.Or{sq.Eq{"TeamId": teamID}, sq.Eq{"TeamId": ""}},
+		})
+
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("Posts").
+		Where(sq.And{
+			sq.Expr("ChannelId IN (?)", channelQuery),
+			sq.Eq{"DeleteAt": 0},
+			searchClause,
+			matchClause,
+		}).
+		OrderBy("CreateAt DESC", "Id DESC").
+		Limit(uint64(perPage)).
+		Offset(uint64(page * perPage)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "getpostsbyhashtag_tosql")
+	}
+
+	var posts []*model.Post
+	if err := s.GetSearchReplicaX().Select(&posts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get posts with hashtag=%s", hashtag)
+	}
+
+	for _, p := range posts {
+		list.AddPost(p)
+		list.AddOrder(p.Id)
+	}
+	list.MakeNonNil()
+
+	return list, nil
+}
+
 func (s *SqlPostStore) GetPostsBefore(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	return s.getPostsAround(true, options, sanitizeOptions)
 }
@@ -1386,6 +1608,120 @@ func (s *SqlPostStore) GetPostsAfter(options model.GetPostsOptions, sanitizeOpti
 	return s.getPostsAround(false, options, sanitizeOptions)
 }
 
+// GetPostsAround returns the given post along with up to before posts preceding it and up to after
+// posts following it in the same channel. Posts sharing the target's CreateAt are ordered by Id so
+// that none of them are skipped at the window boundaries. The roots of any replies in the window,
+// including the target itself, are added to Posts but not to Order.
+func (s *SqlPostStore) GetPostsAround(postId string, before, after int) (*model.PostList, error) {
+	if before < 0 {
+		return nil, store.NewErrInvalidInput("Post", "<before>", before)
+	}
+	if after < 0 {
+		return nil, store.NewErrInvalidInput("Post", "<after>", after)
+	}
+
+	target, err := s.GetSingle(postId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	postsBefore, err := s.getPostsWindow(target, true, before)
+	if err != nil {
+		return nil, err
+	}
+	postsAfter, err := s.getPostsWindow(target, false, after)
+	if err != nil {
+		return nil, err
+	}
+
+	list := model.NewPostList()
+	// Order is newest first, so the posts after the target come first in reverse.
+	for i := len(postsAfter) - 1; i >= 0; i-- {
+		list.AddPost(postsAfter[i])
+		list.AddOrder(postsAfter[i].Id)
+	}
+	list.AddPost(target)
+	list.AddOrder(target.Id)
+	for _, post := range postsBefore {
+		list.AddPost(post)
+		list.AddOrder(post.Id)
+	}
+
+	rootIds := []string{}
+	for _, post := range list.Posts {
+		if post.RootId != "" {
+			if _, ok := list.Posts[post.RootId]; !ok {
+				rootIds = append(rootIds, post.RootId)
+			}
+		}
+	}
+	if len(rootIds) > 0 {
+		roots, err := s.GetPostsByIds(model.RemoveDuplicateStrings(rootIds))
+		if err != nil {
+			var nfErr *store.ErrNotFound
+			if !errors.As(err, &nfErr) {
+				return nil, err
+			}
+		}
+		for _, root := range roots {
+			if root.DeleteAt == 0 {
+				list.AddPost(root)
+			}
+		}
+	}
+
+	return list, nil
+}
+
+// getPostsWindow returns up to limit non-deleted posts in the channel of the given post that were
+// created before or after it, ordered by their distance from the post.
+func (s *SqlPostStore) getPostsWindow(post *model.Post, before bool, limit int) ([]*model.Post, error) {
+	posts := []*model.Post{}
+	if limit == 0 {
+		return posts, nil
+	}
+
+	direction := ">"
+	sort := "ASC"
+	if before {
+		direction = "<"
+		sort = "DESC"
+	}
+
+	replyCountSubQuery := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("Posts").
+		Where(sq.Expr("Posts.RootId = (CASE WHEN p.RootId = '' THEN p.Id ELSE p.RootId END) AND Posts.DeleteAt = 0"))
+
+	query, args, err := s.getQueryBuilder().
+		Select("p.*").
+		Column(sq.Alias(replyCountSubQuery, "ReplyCount")).
+		From("Posts p").
+		Where(sq.And{
+			sq.Eq{"p.ChannelId": post.ChannelId},
+			sq.Eq{"p.DeleteAt": 0},
+			sq.Or{
+				sq.Expr("p.CreateAt "+direction+" ?", post.CreateAt),
+				sq.And{
+					sq.Eq{"p.CreateAt": post.CreateAt},
+					sq.Expr("p.Id "+direction+" ?", post.Id),
+				},
+			},
+		}).
+		OrderBy("p.CreateAt "+sort, "p.Id "+sort).
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_tosql")
+	}
+
+	if err := s.GetReplicaX().Select(&posts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", post.ChannelId)
+	}
+
+	return posts, nil
+}
+
 func (s *SqlPostStore) getPostsAround(before bool, options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	if options.Page < 0 {
 		return nil, store.NewErrInvalidInput("Post", "<options.Page>", options.Page)
@@ -1735,6 +2071,61 @@ var specialSearchChar = []string{
 	":",
 }
 
+// buildIntegrationMetadataFilterClause handles meta: filters, which match the values integrations
+// stored in the integration metadata prop of the posts.
+func (s *SqlPostStore) buildIntegrationMetadataFilterClause(params *model.SearchParams, builder sq.SelectBuilder) (sq.SelectBuilder, error) {
+	build := func(filter model.IntegrationMetadataFilter, exclude bool) error {
+		if s.DriverName() == model.DatabaseDriverPostgres {
+			metadata, err := json.Marshal(map[string]interface{}{
+				model.PostPropsIntegrationMetadata: map[string]map[string]string{
+					filter.Namespace: {filter.Key: filter.Value},
+				},
+			})
+			if err != nil {
+				return errors.Wrap(err, "failed to marshal the integration metadata")
+			}
+
+			if exclude {
+				builder = builder.Where("NOT Props @> ?::jsonb", string(metadata))
+			} else {
+				builder = builder.Where("Props @> ?::jsonb", string(metadata))
+			}
+			return nil
+		}
+
+		namespace, err := json.Marshal(filter.Namespace)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the integration metadata namespace")
+		}
+		key, err := json.Marshal(filter.Key)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the integration metadata key")
+		}
+		path := fmt.Sprintf("$.%s.%s.%s", model.PostPropsIntegrationMetadata, namespace, key)
+
+		if exclude {
+			builder = builder.Where("COALESCE(JSON_UNQUOTE(JSON_EXTRACT(Props, ?)), '') <> ?", path, filter.Value)
+		} else {
+			builder = builder.Where("JSON_UNQUOTE(JSON_EXTRACT(Props, ?)) = ?", path, filter.Value)
+		}
+		return nil
+	}
+
+	for _, filter := range params.IntegrationMetadata {
+		if err := build(filter, false); err != nil {
+			return builder, err
+		}
+	}
+
+	for _, filter := range params.ExcludedIntegrationMetadata {
+		if err := build(filter, true); err != nil {
+			return builder, err
+		}
+	}
+
+	return builder, nil
+}
+
 func (s *SqlPostStore) buildCreateDateFilterClause(params *model.SearchParams, builder sq.SelectBuilder) sq.SelectBuilder {
 	// handle after: before: on: filters
 	if params.OnDate != "" {
@@ -1857,6 +2248,7 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 	if params.Terms == "" && params.ExcludedTerms == "" &&
 		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
 		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
+		len(params.IntegrationMetadata) == 0 && len(params.ExcludedIntegrationMetadata) == 0 &&
 		params.OnDate == "" && params.AfterDate == "" && params.BeforeDate == "" {
 		return list, nil
 	}
@@ -1876,6 +2268,10 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 		return nil, errors.Wrap(err, "failed to build search post filter clause")
 	}
 	baseQuery = s.buildCreateDateFilterClause(params, baseQuery)
+	baseQuery, err = s.buildIntegrationMetadataFilterClause(params, baseQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build integration metadata filter clause")
+	}
 
 	termMap := map[string]bool{}
 	terms := params.Terms
@@ -2195,6 +2591,32 @@ func (s *SqlPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int6
 	return v, nil
 }
 
+// AnalyticsBotPostCount counts the non deleted posts created by bot accounts since the given time,
+// across every team when teamId is empty.
+func (s *SqlPostStore) AnalyticsBotPostCount(teamId string, since int64) (int64, error) {
+	query := s.getQueryBuilder().
+		Select("COUNT(p.Id) AS Value").
+		From("Posts p").
+		Join("Bots b ON (b.UserId = p.UserId)").
+		Where(sq.And{
+			sq.GtOrEq{"p.CreateAt": since},
+			sq.Eq{"p.DeleteAt": 0},
+		})
+
+	if teamId != "" {
+		query = query.
+			Join("Channels c ON (c.Id = p.ChannelId)").
+			Where(sq.Eq{"c.TeamId": teamId})
+	}
+
+	var v int64
+	if err := s.GetReplicaX().GetBuilder(&v, query); err != nil {
+		return 0, errors.Wrapf(err, "failed to count bot Posts with teamId=%s", teamId)
+	}
+
+	return v, nil
+}
+
 func (s *SqlPostStore) GetLastPostRowCreateAt() (int64, error) {
 	query := `SELECT CREATEAT FROM Posts ORDER BY CREATEAT DESC LIMIT 1`
 	var createAt int64
@@ -2206,6 +2628,28 @@ func (s *SqlPostStore) GetLastPostRowCreateAt() (int64, error) {
 	return createAt, nil
 }
 
+// GetLastPostCreateAtForUser returns the creation time of the most recent non-system post made by
+// the user in the channel, including deleted posts, or 0 if there is none.
+func (s *SqlPostStore) GetLastPostCreateAtForUser(channelID, userID string) (int64, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("COALESCE(MAX(CreateAt), 0)").
+		From("Posts").
+		Where(sq.Eq{"ChannelId": channelID, "UserId": userID}).
+		Where(sq.NotLike{"Type": model.PostSystemMessagePrefix + "%"}).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "post_tosql")
+	}
+
+	// Read from the master so that replica lag can't let a post through slow mode.
+	var createAt int64
+	if err := s.GetMasterX().Get(&createAt, query, args...); err != nil {
+		return 0, errors.Wrapf(err, "failed to get last post createat for channelId=%s, userId=%s", channelID, userID)
+	}
+
+	return createAt, nil
+}
+
 func (s *SqlPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
 	query := `SELECT * FROM Posts WHERE CreateAt = ? AND ChannelId = ?`
 
@@ -2336,6 +2780,87 @@ func (s *SqlPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64,
 	return rowsAffected, nil
 }
 
+func (s *SqlPostStore) PermanentDeleteEditHistoryBatch(endTime int64, limit int64) (int64, error) {
+	// The original of an edited post is a deleted copy pointing to the post through OriginalId,
+	// deleted at the time of the edit.
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM Posts WHERE Id = any (array (SELECT Id FROM Posts WHERE OriginalId != '' AND DeleteAt != 0 AND DeleteAt < ? LIMIT ?))"
+	} else {
+		query = "DELETE FROM Posts WHERE OriginalId != '' AND DeleteAt != 0 AND DeleteAt < ? LIMIT ?"
+	}
+
+	sqlResult, err := s.GetMasterX().Exec(query, endTime, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete the edit history of Posts")
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete the edit history of Posts")
+	}
+	return rowsAffected, nil
+}
+
+// PermanentDeleteBatchReturningIds deletes up to limit posts created before endTime and returns the ids
+// of the deleted posts. Posts locked by a concurrent call are left to that call, so that concurrent
+// retention runs never report the same post.
+func (s *SqlPostStore) PermanentDeleteBatchReturningIds(endTime int64, limit int) ([]string, error) {
+	ids := []string{}
+
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query := `
+			DELETE FROM Posts
+			WHERE Id IN (
+				SELECT Id FROM Posts
+				WHERE CreateAt < ?
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING Id`
+
+		if err := s.GetMasterX().Select(&ids, query, endTime, limit); err != nil {
+			return nil, errors.Wrap(err, "failed to delete Posts")
+		}
+
+		return ids, nil
+	}
+
+	// MySQL 5.7 supports neither RETURNING nor SKIP LOCKED, so the batch is locked first
+	// and a concurrent call waits for it to be deleted.
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	if err = transaction.Select(&ids, "SELECT Id FROM Posts WHERE CreateAt < ? LIMIT ? FOR UPDATE", endTime, limit); err != nil {
+		return nil, errors.Wrap(err, "failed to find Posts to delete")
+	}
+
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Delete("Posts").
+		Where(sq.Eq{"Id": ids}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "permanent_delete_batch_returning_ids_tosql")
+	}
+
+	if _, err = transaction.Exec(query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to delete Posts")
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return ids, nil
+}
+
 func (s *SqlPostStore) GetOldest() (*model.Post, error) {
 	var post model.Post
 	err := s.GetReplicaX().Get(&post, "SELECT * FROM Posts ORDER BY CreateAt LIMIT 1")
@@ -2736,6 +3261,44 @@ func (s *SqlPostStore) GetOldestEntityCreationTime() (int64, error) {
 	return oldest, nil
 }
 
+// GetEarliestPostTimeForChannels returns the CreateAt of the first post that hasn't been deleted in
+// each of the given channels. Channels without any posts are left out of the result.
+func (s *SqlPostStore) GetEarliestPostTimeForChannels(channelIDs []string, excludeSystemPosts bool) (map[string]int64, error) {
+	result := make(map[string]int64, len(channelIDs))
+	if len(channelIDs) == 0 {
+		return result, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select("ChannelId", "MIN(CreateAt) AS CreateAt").
+		From("Posts").
+		Where(sq.Eq{"ChannelId": channelIDs, "DeleteAt": 0}).
+		GroupBy("ChannelId")
+
+	if excludeSystemPosts {
+		query = query.Where(sq.NotLike{"Type": model.PostSystemMessagePrefix + "%"})
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "get_earliest_post_time_tosql")
+	}
+
+	rows := []struct {
+		ChannelId string
+		CreateAt  int64
+	}{}
+	if err := s.GetReplicaX().Select(&rows, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to get the earliest post time for channels")
+	}
+
+	for _, row := range rows {
+		result[row.ChannelId] = row.CreateAt
+	}
+
+	return result, nil
+}
+
 // Deletes a thread and a thread membership if the postId is a root post
 func (s *SqlPostStore) permanentDeleteThreads(transaction *sqlxTxWrapper, postId string) error {
 	if _, err := transaction.Exec("DELETE FROM Threads WHERE PostId = ?", postId); err != nil {
@@ -58,6 +58,45 @@ func (s SqlPreferenceStore) Save(preferences model.Preferences) error {
 	return nil
 }
 
+func (s SqlPreferenceStore) SaveIfNotExists(preference *model.Preference) (bool, error) {
+	preference.PreUpdate()
+
+	if err := preference.IsValid(); err != nil {
+		return false, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("Preferences").
+		Columns("UserId", "Category", "Name", "Value").
+		Values(preference.UserId, preference.Category, preference.Name, preference.Value)
+
+	if s.DriverName() == model.DatabaseDriverMysql {
+		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE Value = Value"))
+	} else if s.DriverName() == model.DatabaseDriverPostgres {
+		query = query.SuffixExpr(sq.Expr("ON CONFLICT (userid, category, name) DO NOTHING"))
+	} else {
+		return false, store.NewErrNotImplemented("failed to save preference because of missing driver")
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to generate sqlquery")
+	}
+
+	result, err := s.GetMasterX().Exec(queryString, args...)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to save Preference")
+	}
+
+	// Neither database counts the row as affected when it already existed.
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get rows affected")
+	}
+
+	return rows == 1, nil
+}
+
 func (s SqlPreferenceStore) save(transaction *sqlxTxWrapper, preference *model.Preference) error {
 	preference.PreUpdate()
 
@@ -158,6 +197,23 @@ func (s SqlPreferenceStore) GetCategory(userId string, category string) (model.P
 
 }
 
+func (s SqlPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, error) {
+	var preferences model.Preferences
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("Preferences").
+		Where(sq.Eq{"Category": category}).
+		Where(sq.Eq{"Name": name}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build sql query to get preference")
+	}
+	if err = s.GetReplicaX().Select(&preferences, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Preference with category=%s, name=%s", category, name)
+	}
+	return preferences, nil
+}
+
 func (s SqlPreferenceStore) GetAll(userId string) (model.Preferences, error) {
 	var preferences model.Preferences
 	query, args, err := s.getQueryBuilder().
@@ -4,6 +4,8 @@
 package sqlstore
 
 import (
+	"sort"
+
 	sq "github.com/mattermost/squirrel"
 
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -47,6 +49,52 @@ func (s *SqlReactionStore) Save(reaction *model.Reaction) (*model.Reaction, erro
 	return reaction, nil
 }
 
+// SaveMultiple saves the reactions in a single transaction, updating each of their posts once. Reactions
+// that already exist are saved again rather than failing the batch.
+func (s *SqlReactionStore) SaveMultiple(reactions []*model.Reaction) ([]*model.Reaction, error) {
+	for _, reaction := range reactions {
+		reaction.PreSave()
+		if err := reaction.IsValid(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(reactions) == 0 {
+		return reactions, nil
+	}
+
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	postIds := []string{}
+	seen := make(map[string]bool)
+	for _, reaction := range reactions {
+		if err := s.saveReactionT(transaction, reaction); err != nil {
+			return nil, errors.Wrapf(err, "failed to save Reaction with postId=%s, userId=%s, emojiName=%s", reaction.PostId, reaction.UserId, reaction.EmojiName)
+		}
+
+		if !seen[reaction.PostId] {
+			seen[reaction.PostId] = true
+			postIds = append(postIds, reaction.PostId)
+		}
+	}
+
+	for _, postId := range postIds {
+		if err := updatePostForReactionsOnInsert(transaction, postId); err != nil {
+			return nil, errors.Wrapf(err, "failed to update Post with id=%s", postId)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return reactions, nil
+}
+
 func (s *SqlReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
 	reaction.PreUpdate()
 
@@ -89,6 +137,50 @@ func (s *SqlReactionStore) GetForPost(postId string, allowFromCache bool) ([]*mo
 	return reactions, nil
 }
 
+func (s *SqlReactionStore) GetDetailsForPost(postID string, offset, limit int) ([]*model.ReactionDetails, error) {
+	queryString, args, err := s.getQueryBuilder().
+		Select("EmojiName", "UserId", "CreateAt").
+		From("Reactions").
+		Where(sq.Eq{"PostId": postID}).
+		Where(sq.Eq{"COALESCE(DeleteAt, 0)": 0}).
+		OrderBy("EmojiName", "CreateAt", "UserId").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "reactions_getdetailsforpost_tosql")
+	}
+
+	reactions := []*model.Reaction{}
+	if err := s.GetReplicaX().Select(&reactions, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Reactions with postId=%s", postID)
+	}
+
+	// The reactions are grouped by emoji and ordered by time within each group, so the first
+	// reaction of a group is the one that first used the emoji.
+	details := []*model.ReactionDetails{}
+	firstUsedAt := map[string]int64{}
+	var detail *model.ReactionDetails
+	for _, reaction := range reactions {
+		if detail == nil || detail.EmojiName != reaction.EmojiName {
+			detail = &model.ReactionDetails{EmojiName: reaction.EmojiName, Users: []*model.ReactionUser{}}
+			details = append(details, detail)
+			firstUsedAt[reaction.EmojiName] = reaction.CreateAt
+		}
+
+		if detail.Count >= int64(offset) && len(detail.Users) < limit {
+			detail.Users = append(detail.Users, &model.ReactionUser{UserId: reaction.UserId, CreateAt: reaction.CreateAt})
+		}
+		detail.Count++
+	}
+
+	// The details are already ordered by emoji name, which breaks the ties between emojis first
+	// used at the same time.
+	sort.SliceStable(details, func(i, j int) bool {
+		return firstUsedAt[details[i].EmojiName] < firstUsedAt[details[j].EmojiName]
+	})
+
+	return details, nil
+}
+
 // GetForPostSince returns all reactions associated with `postId` updated after `since`.
 func (s *SqlReactionStore) GetForPostSince(postId string, since int64, excludeRemoteId string, inclDeleted bool) ([]*model.Reaction, error) {
 	query := s.getQueryBuilder().
@@ -144,6 +236,26 @@ func (s *SqlReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction,
 	return reactions, nil
 }
 
+// GetForPosts returns the reactions of each of the posts that are not deleted, keyed by post id. Posts without
+// any reactions are left out of the map.
+func (s *SqlReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	reactionsByPost := make(map[string][]*model.Reaction)
+	if len(postIds) == 0 {
+		return reactionsByPost, nil
+	}
+
+	reactions, err := s.BulkGetForPosts(postIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		reactionsByPost[reaction.PostId] = append(reactionsByPost[reaction.PostId], reaction)
+	}
+
+	return reactionsByPost, nil
+}
+
 func (s *SqlReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 	var reactions []*model.Reaction
 	now := model.GetMillis()
@@ -188,6 +300,27 @@ func (s *SqlReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 	return nil
 }
 
+func (s *SqlReactionStore) GetOrphanedReactions(limit int) ([]*model.Reaction, error) {
+	queryString, args, err := s.getQueryBuilder().
+		Select("Reactions.UserId", "Reactions.PostId", "Reactions.EmojiName", "Reactions.CreateAt",
+			"COALESCE(Reactions.UpdateAt, Reactions.CreateAt) As UpdateAt", "COALESCE(Reactions.DeleteAt, 0) As DeleteAt", "Reactions.RemoteId").
+		From("Reactions").
+		LeftJoin("Posts ON Reactions.PostId = Posts.Id").
+		Where(sq.Eq{"Posts.Id": nil}).
+		OrderBy("Reactions.PostId", "Reactions.UserId", "Reactions.EmojiName").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "reactions_getorphaned_tosql")
+	}
+
+	reactions := []*model.Reaction{}
+	if err := s.GetReplicaX().Select(&reactions, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to get orphaned Reactions")
+	}
+	return reactions, nil
+}
+
 // DeleteOrphanedRows removes entries from Reactions when a corresponding post no longer exists.
 func (s *SqlReactionStore) DeleteOrphanedRows(limit int) (deleted int64, err error) {
 	// We need the extra level of nesting to deal with MySQL's locking
@@ -350,6 +483,13 @@ func (s *SqlReactionStore) GetTopForUserSince(userID string, teamID string, sinc
 }
 
 func (s *SqlReactionStore) saveReactionAndUpdatePost(transaction *sqlxTxWrapper, reaction *model.Reaction) error {
+	if err := s.saveReactionT(transaction, reaction); err != nil {
+		return err
+	}
+	return updatePostForReactionsOnInsert(transaction, reaction.PostId)
+}
+
+func (s *SqlReactionStore) saveReactionT(transaction *sqlxTxWrapper, reaction *model.Reaction) error {
 	reaction.DeleteAt = 0
 
 	if s.DriverName() == model.DatabaseDriverMysql {
@@ -375,7 +515,7 @@ func (s *SqlReactionStore) saveReactionAndUpdatePost(transaction *sqlxTxWrapper,
 			return err
 		}
 	}
-	return updatePostForReactionsOnInsert(transaction, reaction.PostId)
+	return nil
 }
 
 func deleteReactionAndUpdatePost(transaction *sqlxTxWrapper, reaction *model.Reaction) error {
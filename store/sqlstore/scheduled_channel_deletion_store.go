@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlScheduledChannelDeletionStore struct {
+	*SqlStore
+}
+
+func newSqlScheduledChannelDeletionStore(sqlStore *SqlStore) store.ScheduledChannelDeletionStore {
+	return &SqlScheduledChannelDeletionStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func scheduledChannelDeletionColumns() []string {
+	return []string{"ChannelId", "DeleteAt", "CreateAt"}
+}
+
+func (s *SqlScheduledChannelDeletionStore) Save(deletion *model.ScheduledChannelDeletion) (*model.ScheduledChannelDeletion, error) {
+	deletion.PreSave()
+	if err := deletion.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("ScheduledChannelDeletions").
+		Columns(scheduledChannelDeletionColumns()...).
+		Values(deletion.ChannelId, deletion.DeleteAt, deletion.CreateAt)
+
+	if s.DriverName() == model.DatabaseDriverMysql {
+		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE DeleteAt = ?, CreateAt = ?", deletion.DeleteAt, deletion.CreateAt))
+	} else {
+		query = query.SuffixExpr(sq.Expr("ON CONFLICT (channelid) DO UPDATE SET DeleteAt = ?, CreateAt = ?", deletion.DeleteAt, deletion.CreateAt))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "scheduled_channel_deletion_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(sql, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ScheduledChannelDeletion with channel_id=%s", deletion.ChannelId)
+	}
+
+	return deletion, nil
+}
+
+func (s *SqlScheduledChannelDeletionStore) Get(channelID string) (*model.ScheduledChannelDeletion, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(scheduledChannelDeletionColumns()...).
+		From("ScheduledChannelDeletions").
+		Where(sq.Eq{"ChannelId": channelID}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "scheduled_channel_deletion_tosql")
+	}
+
+	// The deletion is checked right before a channel is archived, so read from the master so that a
+	// cancellation is never missed.
+	var deletion model.ScheduledChannelDeletion
+	if err := s.GetMasterX().Get(&deletion, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ScheduledChannelDeletion", "channel_id="+channelID)
+		}
+		return nil, errors.Wrapf(err, "failed to find ScheduledChannelDeletion with channel_id=%s", channelID)
+	}
+
+	return &deletion, nil
+}
+
+func (s *SqlScheduledChannelDeletionStore) GetDue(before int64, limit int) ([]*model.ScheduledChannelDeletion, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(scheduledChannelDeletionColumns()...).
+		From("ScheduledChannelDeletions").
+		Where(sq.LtOrEq{"DeleteAt": before}).
+		OrderBy("DeleteAt", "ChannelId").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "scheduled_channel_deletions_tosql")
+	}
+
+	deletions := []*model.ScheduledChannelDeletion{}
+	if err := s.GetMasterX().Select(&deletions, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find due ScheduledChannelDeletions")
+	}
+
+	return deletions, nil
+}
+
+func (s *SqlScheduledChannelDeletionStore) Delete(channelID string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("ScheduledChannelDeletions").
+		Where(sq.Eq{"ChannelId": channelID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "scheduled_channel_deletion_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete ScheduledChannelDeletion with channel_id=%s", channelID)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("ScheduledChannelDeletion", "channel_id="+channelID)
+	}
+
+	return nil
+}
@@ -300,6 +300,22 @@ func (me SqlSessionStore) AnalyticsSessionCount() (int64, error) {
 }
 
 func (me SqlSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
+	for {
+		removed, err := me.RemoveExpiredSessions(expiryTime, int(batchSize))
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			return nil
+		}
+
+		time.Sleep(sessionsCleanupDelay)
+	}
+}
+
+// RemoveExpiredSessions deletes a single batch of up to limit sessions that expired before now,
+// skipping the sessions that never expire, and returns the number of sessions removed.
+func (me SqlSessionStore) RemoveExpiredSessions(now int64, limit int) (int64, error) {
 	var query string
 	if me.DriverName() == model.DatabaseDriverPostgres {
 		query = "DELETE FROM Sessions WHERE Id IN (SELECT Id FROM Sessions WHERE ExpiresAt != 0 AND ? > ExpiresAt LIMIT ?)"
@@ -307,21 +323,15 @@ func (me SqlSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
 		query = "DELETE FROM Sessions WHERE ExpiresAt != 0 AND ? > ExpiresAt LIMIT ?"
 	}
 
-	var rowsAffected int64 = 1
-
-	for rowsAffected > 0 {
-		sqlResult, err := me.GetMasterX().Exec(query, expiryTime, batchSize)
-		if err != nil {
-			return errors.Wrap(err, "unable to delete sessions")
-		}
-		var rowErr error
-		rowsAffected, rowErr = sqlResult.RowsAffected()
-		if rowErr != nil {
-			return errors.Wrap(err, "unable to delete sessions")
-		}
+	sqlResult, err := me.GetMasterX().Exec(query, now, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to delete sessions")
+	}
 
-		time.Sleep(sessionsCleanupDelay)
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get the number of deleted sessions")
 	}
 
-	return nil
+	return rowsAffected, nil
 }
@@ -108,6 +108,11 @@ type SqlStoreStores struct {
 	UserTermsOfService   store.UserTermsOfServiceStore
 	linkMetadata         store.LinkMetadataStore
 	sharedchannel        store.SharedChannelStore
+	postReport           store.PostReportStore
+	postReminder         store.PostReminderStore
+	channelTemplate      store.ChannelTemplateStore
+	guestChannelInvite   store.GuestChannelInviteStore
+	scheduledDeletion    store.ScheduledChannelDeletionStore
 }
 
 type SqlStore struct {
@@ -201,6 +206,11 @@ func New(settings model.SqlSettings, metrics einterfaces.MetricsInterface) *SqlS
 	store.stores.UserTermsOfService = newSqlUserTermsOfServiceStore(store)
 	store.stores.linkMetadata = newSqlLinkMetadataStore(store)
 	store.stores.sharedchannel = newSqlSharedChannelStore(store)
+	store.stores.postReport = newSqlPostReportStore(store)
+	store.stores.postReminder = newSqlPostReminderStore(store)
+	store.stores.channelTemplate = newSqlChannelTemplateStore(store)
+	store.stores.guestChannelInvite = newSqlGuestChannelInviteStore(store)
+	store.stores.scheduledDeletion = newSqlScheduledChannelDeletionStore(store)
 	store.stores.reaction = newSqlReactionStore(store)
 	store.stores.role = newSqlRoleStore(store)
 	store.stores.scheme = newSqlSchemeStore(store)
@@ -898,6 +908,26 @@ func (ss *SqlStore) LinkMetadata() store.LinkMetadataStore {
 	return ss.stores.linkMetadata
 }
 
+func (ss *SqlStore) PostReport() store.PostReportStore {
+	return ss.stores.postReport
+}
+
+func (ss *SqlStore) PostReminder() store.PostReminderStore {
+	return ss.stores.postReminder
+}
+
+func (ss *SqlStore) ChannelTemplate() store.ChannelTemplateStore {
+	return ss.stores.channelTemplate
+}
+
+func (ss *SqlStore) GuestChannelInvite() store.GuestChannelInviteStore {
+	return ss.stores.guestChannelInvite
+}
+
+func (ss *SqlStore) ScheduledChannelDeletion() store.ScheduledChannelDeletionStore {
+	return ss.stores.scheduledDeletion
+}
+
 func (ss *SqlStore) SharedChannel() store.SharedChannelStore {
 	return ss.stores.sharedchannel
 }
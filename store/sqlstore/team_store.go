@@ -227,10 +227,12 @@ func (s SqlTeamStore) Save(team *model.Team) (*model.Team, error) {
 
 	if _, err := s.GetMasterX().NamedExec(`INSERT INTO Teams
 		(Id, CreateAt, UpdateAt, DeleteAt, DisplayName, Name, Description, Email, Type, CompanyName, AllowedDomains,
-		InviteId, AllowOpenInvite, LastTeamIconUpdate, SchemeId, GroupConstrained, CloudLimitsArchived)
+		InviteId, AllowOpenInvite, LastTeamIconUpdate, SchemeId, GroupConstrained, CloudLimitsArchived, DefaultChannels,
+		InviteExpireAt, InviteMaxUses, InviteUses, DefaultTheme)
 		VALUES
 		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :DisplayName, :Name, :Description, :Email, :Type, :CompanyName, :AllowedDomains,
-		:InviteId, :AllowOpenInvite, :LastTeamIconUpdate, :SchemeId, :GroupConstrained, :CloudLimitsArchived)`, team); err != nil {
+		:InviteId, :AllowOpenInvite, :LastTeamIconUpdate, :SchemeId, :GroupConstrained, :CloudLimitsArchived, :DefaultChannels,
+		:InviteExpireAt, :InviteMaxUses, 0, :DefaultTheme)`, team); err != nil {
 		if IsUniqueConstraintError(err, []string{"Name", "teams_name_key"}) {
 			return nil, store.NewErrInvalidInput("Team", "id", team.Id)
 		}
@@ -264,11 +266,17 @@ func (s SqlTeamStore) Update(team *model.Team) (*model.Team, error) {
 	team.CreateAt = oldTeam.CreateAt
 	team.UpdateAt = model.GetMillis()
 
+	// The uses of the invite link are only counted by IncrementInviteUses so that concurrent joins aren't lost, but
+	// they start over when the link is regenerated. MySQL assigns the columns in order, so InviteUses has to be set
+	// before InviteId is changed.
 	res, err := s.GetMasterX().NamedExec(`UPDATE Teams
-			SET CreateAt=:CreateAt, UpdateAt=:UpdateAt, DeleteAt=:DeleteAt, DisplayName=:DisplayName, Name=:Name,
+			SET InviteUses=CASE WHEN InviteId=:InviteId THEN InviteUses ELSE 0 END,
+				CreateAt=:CreateAt, UpdateAt=:UpdateAt, DeleteAt=:DeleteAt, DisplayName=:DisplayName, Name=:Name,
 				Description=:Description, Email=:Email, Type=:Type, CompanyName=:CompanyName, AllowedDomains=:AllowedDomains,
 				InviteId=:InviteId, AllowOpenInvite=:AllowOpenInvite, LastTeamIconUpdate=:LastTeamIconUpdate,
-				SchemeId=:SchemeId, GroupConstrained=:GroupConstrained, CloudLimitsArchived=:CloudLimitsArchived
+				SchemeId=:SchemeId, GroupConstrained=:GroupConstrained, CloudLimitsArchived=:CloudLimitsArchived,
+				DefaultChannels=:DefaultChannels, InviteExpireAt=:InviteExpireAt, InviteMaxUses=:InviteMaxUses,
+				DefaultTheme=:DefaultTheme
 			WHERE Id=:Id`, team)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to update Team with id=%s", team.Id)
@@ -615,6 +623,36 @@ func (s SqlTeamStore) GetAllPage(offset int, limit int, opts *model.TeamSearch)
 	return teams, nil
 }
 
+// GetTeamsWithMemberCount returns teams, up to a total limit passed as parameter and paginated by offset number passed as parameter,
+// along with the number of members of each team who haven't left it or been deactivated.
+func (s SqlTeamStore) GetTeamsWithMemberCount(offset int, limit int) ([]*model.TeamWithMemberCount, error) {
+	memberCounts := s.getQueryBuilder().
+		Select("TeamMembers.TeamId", "COUNT(TeamMembers.UserId) AS ActiveMemberCount").
+		From("TeamMembers").
+		Join("Users ON Users.Id = TeamMembers.UserId").
+		Where(sq.Eq{"TeamMembers.DeleteAt": 0, "Users.DeleteAt": 0}).
+		GroupBy("TeamMembers.TeamId")
+
+	query, args, err := s.getQueryBuilder().
+		Select("Teams.*", "COALESCE(MemberCounts.ActiveMemberCount, 0) AS ActiveMemberCount").
+		From("Teams").
+		JoinClause(memberCounts.Prefix("LEFT JOIN (").Suffix(") AS MemberCounts ON MemberCounts.TeamId = Teams.Id")).
+		OrderBy("Teams.DisplayName", "Teams.Id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_tosql")
+	}
+
+	teams := []*model.TeamWithMemberCount{}
+	if err := s.GetReplicaX().Select(&teams, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find Teams with member counts")
+	}
+
+	return teams, nil
+}
+
 // GetTeamsByUserId returns from the database all teams that userId belongs to.
 func (s SqlTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, error) {
 	teams := []*model.Team{}
@@ -1089,6 +1127,42 @@ func (s SqlTeamStore) GetMembersByIds(teamId string, userIds []string, restricti
 	return dbMembers.ToModel(), nil
 }
 
+// GetMembersByIdsInOrder returns the active members of the team with the given user ids, in the order
+// of userIds, along with the ids which aren't active members of the team. Duplicate ids are ignored.
+func (s SqlTeamStore) GetMembersByIdsInOrder(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, []string, error) {
+	if len(userIds) == 0 {
+		return []*model.TeamMember{}, []string{}, nil
+	}
+
+	members, err := s.GetMembersByIds(teamId, userIds, restrictions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	membersByUserId := make(map[string]*model.TeamMember, len(members))
+	for _, member := range members {
+		membersByUserId[member.UserId] = member
+	}
+
+	ordered := make([]*model.TeamMember, 0, len(members))
+	missing := []string{}
+	seen := make(map[string]bool, len(userIds))
+	for _, userId := range userIds {
+		if seen[userId] {
+			continue
+		}
+		seen[userId] = true
+
+		if member, ok := membersByUserId[userId]; ok {
+			ordered = append(ordered, member)
+		} else {
+			missing = append(missing, userId)
+		}
+	}
+
+	return ordered, missing, nil
+}
+
 // GetTeamsForUser returns a list of teams that the user is a member of. Expects userId to be passed as a parameter. It can also negative the teamID passed.
 func (s SqlTeamStore) GetTeamsForUser(ctx context.Context, userId, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
 	query := s.getTeamMembersWithSchemeSelectQuery().
@@ -1253,6 +1327,50 @@ func (s SqlTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) err
 	return nil
 }
 
+// IncrementInviteUses counts a use of the given invite link of the team, returning false without counting it if the
+// link has already been used as many times as allowed or isn't the current invite link of the team.
+func (s SqlTeamStore) IncrementInviteUses(teamID, inviteID string) (bool, error) {
+	query, args, err := s.getQueryBuilder().
+		Update("Teams").
+		Set("InviteUses", sq.Expr("InviteUses + 1")).
+		Where(sq.Eq{"Id": teamID, "InviteId": inviteID}).
+		Where(sq.Or{sq.Eq{"InviteMaxUses": 0}, sq.Expr("InviteUses < InviteMaxUses")}).ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "team_tosql")
+	}
+
+	res, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to increment invite uses of Team with id=%s", teamID)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get rows_affected")
+	}
+
+	return count == 1, nil
+}
+
+// ReleaseInviteUse gives back a use of the given invite link of the team counted by IncrementInviteUses, unless the
+// link has since been regenerated and its uses started over.
+func (s SqlTeamStore) ReleaseInviteUse(teamID, inviteID string) error {
+	query, args, err := s.getQueryBuilder().
+		Update("Teams").
+		Set("InviteUses", sq.Expr("InviteUses - 1")).
+		Where(sq.Eq{"Id": teamID, "InviteId": inviteID}).
+		Where(sq.Gt{"InviteUses": 0}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "team_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to release invite use of Team with id=%s", teamID)
+	}
+
+	return nil
+}
+
 // GetTeamsByScheme returns from the database all teams that match the schemeId provided as parameter, up to
 // a total limit passed as parameter and paginated by offset number passed as parameter.
 func (s SqlTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, error) {
@@ -1437,6 +1555,35 @@ func (s SqlTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, er
 	return count, nil
 }
 
+func (s SqlTeamStore) GetMemberCountsByScheme() (map[string]int64, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("COALESCE(Teams.SchemeId, '') AS SchemeId", "COUNT(DISTINCT TeamMembers.UserId) AS MemberCount").
+		From("TeamMembers").
+		Join("Teams ON Teams.Id = TeamMembers.TeamId").
+		Join("Users ON Users.Id = TeamMembers.UserId").
+		Where(sq.Eq{"TeamMembers.DeleteAt": 0, "Teams.DeleteAt": 0, "Users.DeleteAt": 0}).
+		GroupBy("COALESCE(Teams.SchemeId, '')").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_tosql")
+	}
+
+	var rows []struct {
+		SchemeId    string
+		MemberCount int64
+	}
+	if err := s.GetReplicaX().Select(&rows, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to count TeamMembers by scheme")
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.SchemeId] = row.MemberCount
+	}
+
+	return counts, nil
+}
+
 // GetAllForExportAfter returns teams for export, up to a total limit passed as parameter where Teams.Id is greater than the afterId passed as parameter.
 func (s SqlTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.TeamForExport, error) {
 	data := []*model.TeamForExport{}
@@ -935,3 +935,65 @@ func (s *SqlThreadStore) GetThreadUnreadReplyCount(threadMembership *model.Threa
 
 	return unreadReplies, nil
 }
+
+// RecomputeReplyCounts repairs the ReplyCount and LastReplyAt of a batch of threads from the Posts table.
+func (s *SqlThreadStore) RecomputeReplyCounts(afterPostID string, limit int) (string, int64, error) {
+	query := s.getQueryBuilder().
+		Select(
+			"Threads.PostId",
+			"Threads.ReplyCount",
+			"Threads.LastReplyAt",
+			"COUNT(Posts.Id) AS ActualReplyCount",
+			"COALESCE(MAX(Posts.CreateAt), 0) AS ActualLastReplyAt",
+		).
+		From("Threads").
+		LeftJoin("Posts ON Posts.RootId = Threads.PostId AND Posts.DeleteAt = 0").
+		Where(sq.Gt{"Threads.PostId": afterPostID}).
+		GroupBy("Threads.PostId", "Threads.ReplyCount", "Threads.LastReplyAt").
+		OrderBy("Threads.PostId").
+		Limit(uint64(limit))
+
+	var threads []struct {
+		PostId            string
+		ReplyCount        int64
+		LastReplyAt       int64
+		ActualReplyCount  int64
+		ActualLastReplyAt int64
+	}
+	if err := s.GetMasterX().SelectBuilder(&threads, query); err != nil {
+		return "", 0, errors.Wrap(err, "failed to count thread replies")
+	}
+
+	if len(threads) == 0 {
+		return "", 0, nil
+	}
+
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return "", 0, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	var repaired int64
+	for _, thread := range threads {
+		if thread.ReplyCount == thread.ActualReplyCount && thread.LastReplyAt == thread.ActualLastReplyAt {
+			continue
+		}
+
+		update := s.getQueryBuilder().
+			Update("Threads").
+			Set("ReplyCount", thread.ActualReplyCount).
+			Set("LastReplyAt", thread.ActualLastReplyAt).
+			Where(sq.Eq{"PostId": thread.PostId})
+		if _, err := transaction.ExecBuilder(update); err != nil {
+			return "", 0, errors.Wrapf(err, "failed to update thread with postId=%s", thread.PostId)
+		}
+		repaired++
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return "", 0, errors.Wrap(err, "commit_transaction")
+	}
+
+	return threads[len(threads)-1].PostId, repaired, nil
+}
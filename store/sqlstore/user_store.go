@@ -51,7 +51,7 @@ func newSqlUserStore(sqlStore *SqlStore, metrics einterfaces.MetricsInterface) s
 
 	// note: we are providing field names explicitly here to maintain order of columns (needed when using raw queries)
 	us.usersQuery = us.getQueryBuilder().
-		Select("u.Id", "u.CreateAt", "u.UpdateAt", "u.DeleteAt", "u.Username", "u.Password", "u.AuthData", "u.AuthService", "u.Email", "u.EmailVerified", "u.Nickname", "u.FirstName", "u.LastName", "u.Position", "u.Roles", "u.AllowMarketing", "u.Props", "u.NotifyProps", "u.LastPasswordUpdate", "u.LastPictureUpdate", "u.FailedAttempts", "u.Locale", "u.Timezone", "u.MfaActive", "u.MfaSecret",
+		Select("u.Id", "u.CreateAt", "u.UpdateAt", "u.DeleteAt", "u.Username", "u.Password", "u.AuthData", "u.AuthService", "u.Email", "u.EmailVerified", "u.Nickname", "u.FirstName", "u.LastName", "u.Position", "u.Roles", "u.AllowMarketing", "u.Props", "u.NotifyProps", "u.LastPasswordUpdate", "u.LastPictureUpdate", "u.FailedAttempts", "u.LastFailedAttemptAt", "u.Locale", "u.Timezone", "u.MfaActive", "u.MfaSecret",
 			"b.UserId IS NOT NULL AS IsBot", "COALESCE(b.Description, '') AS BotDescription", "COALESCE(b.LastIconUpdate, 0) AS BotLastIconUpdate", "u.RemoteId").
 		From("Users u").
 		LeftJoin("Bots b ON ( b.UserId = u.Id )")
@@ -63,12 +63,12 @@ func (us SqlUserStore) insert(user *model.User) (sql.Result, error) {
 	query := `INSERT INTO Users
 		(Id, CreateAt, UpdateAt, DeleteAt, Username, Password, AuthData, AuthService,
 			Email, EmailVerified, Nickname, FirstName, LastName, Position, Roles, AllowMarketing,
-			Props, NotifyProps, LastPasswordUpdate, LastPictureUpdate, FailedAttempts,
+			Props, NotifyProps, LastPasswordUpdate, LastPictureUpdate, FailedAttempts, LastFailedAttemptAt,
 			Locale, Timezone, MfaActive, MfaSecret, RemoteId)
 		VALUES
 		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :Username, :Password, :AuthData, :AuthService,
 			:Email, :EmailVerified, :Nickname, :FirstName, :LastName, :Position, :Roles, :AllowMarketing,
-			:Props, :NotifyProps, :LastPasswordUpdate, :LastPictureUpdate, :FailedAttempts,
+			:Props, :NotifyProps, :LastPasswordUpdate, :LastPictureUpdate, :FailedAttempts, :LastFailedAttemptAt,
 			:Locale, :Timezone, :MfaActive, :MfaSecret, :RemoteId)`
 
 	user.Props = wrapBinaryParamStringMap(us.IsBinaryParamEnabled(), user.Props)
@@ -168,6 +168,7 @@ func (us SqlUserStore) Update(user *model.User, trustedUpdateData bool) (*model.
 	user.LastPictureUpdate = oldUser.LastPictureUpdate
 	user.EmailVerified = oldUser.EmailVerified
 	user.FailedAttempts = oldUser.FailedAttempts
+	user.LastFailedAttemptAt = oldUser.LastFailedAttemptAt
 	user.MfaSecret = oldUser.MfaSecret
 	user.MfaActive = oldUser.MfaActive
 
@@ -198,7 +199,7 @@ func (us SqlUserStore) Update(user *model.User, trustedUpdateData bool) (*model.
 				Nickname=:Nickname, FirstName=:FirstName, LastName=:LastName, Position=:Position, Roles=:Roles,
 				AllowMarketing=:AllowMarketing, Props=:Props, NotifyProps=:NotifyProps,
 				LastPasswordUpdate=:LastPasswordUpdate, LastPictureUpdate=:LastPictureUpdate,
-				FailedAttempts=:FailedAttempts,Locale=:Locale, Timezone=:Timezone, MfaActive=:MfaActive,
+				FailedAttempts=:FailedAttempts, LastFailedAttemptAt=:LastFailedAttemptAt, Locale=:Locale, Timezone=:Timezone, MfaActive=:MfaActive,
 				MfaSecret=:MfaSecret, RemoteId=:RemoteId
 			WHERE Id=:Id`
 
@@ -278,15 +279,22 @@ func (us SqlUserStore) UpdateUpdateAt(userId string) (int64, error) {
 func (us SqlUserStore) UpdatePassword(userId, hashedPassword string) error {
 	updateAt := model.GetMillis()
 
-	if _, err := us.GetMasterX().Exec("UPDATE Users SET Password = ?, LastPasswordUpdate = ?, UpdateAt = ?, AuthData = NULL, AuthService = '', FailedAttempts = 0 WHERE Id = ?", hashedPassword, updateAt, updateAt, userId); err != nil {
+	if _, err := us.GetMasterX().Exec("UPDATE Users SET Password = ?, LastPasswordUpdate = ?, UpdateAt = ?, AuthData = NULL, AuthService = '', FailedAttempts = 0, LastFailedAttemptAt = 0 WHERE Id = ?", hashedPassword, updateAt, updateAt, userId); err != nil {
 		return errors.Wrapf(err, "failed to update User with userId=%s", userId)
 	}
 
 	return nil
 }
 
+// UpdateFailedPasswordAttempts sets the number of consecutive failed login attempts of the user,
+// recording the current time as the time of the last failed attempt unless attempts is 0.
 func (us SqlUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) error {
-	if _, err := us.GetMasterX().Exec("UPDATE Users SET FailedAttempts = ? WHERE Id = ?", attempts, userId); err != nil {
+	var lastFailedAttemptAt int64
+	if attempts > 0 {
+		lastFailedAttemptAt = model.GetMillis()
+	}
+
+	if _, err := us.GetMasterX().Exec("UPDATE Users SET FailedAttempts = ?, LastFailedAttemptAt = ? WHERE Id = ?", attempts, lastFailedAttemptAt, userId); err != nil {
 		return errors.Wrapf(err, "failed to update User with userId=%s", userId)
 	}
 
@@ -301,6 +309,7 @@ func (us SqlUserStore) UpdateAuthData(userId string, service string, authData *s
 		Set("LastPasswordUpdate", updateAt).
 		Set("UpdateAt", updateAt).
 		Set("FailedAttempts", 0).
+		Set("LastFailedAttemptAt", 0).
 		Set("AuthService", service).
 		Set("AuthData", authData).
 		Where(sq.Eq{"Id": userId})
@@ -421,7 +430,7 @@ func (us SqlUserStore) Get(ctx context.Context, id string) (*model.User, error)
 		&user.Password, &user.AuthData, &user.AuthService, &user.Email, &user.EmailVerified,
 		&user.Nickname, &user.FirstName, &user.LastName, &user.Position, &user.Roles,
 		&user.AllowMarketing, &props, &notifyProps, &user.LastPasswordUpdate, &user.LastPictureUpdate,
-		&user.FailedAttempts, &user.Locale, &timezone, &user.MfaActive, &user.MfaSecret,
+		&user.FailedAttempts, &user.LastFailedAttemptAt, &user.Locale, &timezone, &user.MfaActive, &user.MfaSecret,
 		&user.IsBot, &user.BotDescription, &user.BotLastIconUpdate, &user.RemoteId)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -703,12 +712,15 @@ func (us SqlUserStore) InvalidateProfilesInChannelCacheByUser(userId string) {}
 func (us SqlUserStore) InvalidateProfilesInChannelCache(channelId string) {}
 
 func (us SqlUserStore) GetProfilesInChannel(options *model.UserGetOptions) ([]*model.User, error) {
+	isPostgreSQL := us.DriverName() == model.DatabaseDriverPostgres
 	query := us.usersQuery.
 		Join("ChannelMembers cm ON ( cm.UserId = u.Id )").
 		Where("cm.ChannelId = ?", options.InChannelId).
 		OrderBy("u.Username ASC").
 		Offset(uint64(options.Page * options.PerPage)).Limit(uint64(options.PerPage))
 
+	query = applyMultiRoleFilters(query, nil, nil, options.ChannelRoles, isPostgreSQL)
+
 	if options.Inactive {
 		query = query.Where("u.DeleteAt != 0")
 	} else if options.Active {
@@ -793,7 +805,7 @@ func (us SqlUserStore) GetAllProfilesInChannel(ctx context.Context, channelID st
 	for rows.Next() {
 		var user model.User
 		var props, notifyProps, timezone []byte
-		if err = rows.Scan(&user.Id, &user.CreateAt, &user.UpdateAt, &user.DeleteAt, &user.Username, &user.Password, &user.AuthData, &user.AuthService, &user.Email, &user.EmailVerified, &user.Nickname, &user.FirstName, &user.LastName, &user.Position, &user.Roles, &user.AllowMarketing, &props, &notifyProps, &user.LastPasswordUpdate, &user.LastPictureUpdate, &user.FailedAttempts, &user.Locale, &timezone, &user.MfaActive, &user.MfaSecret, &user.IsBot, &user.BotDescription, &user.BotLastIconUpdate, &user.RemoteId); err != nil {
+		if err = rows.Scan(&user.Id, &user.CreateAt, &user.UpdateAt, &user.DeleteAt, &user.Username, &user.Password, &user.AuthData, &user.AuthService, &user.Email, &user.EmailVerified, &user.Nickname, &user.FirstName, &user.LastName, &user.Position, &user.Roles, &user.AllowMarketing, &props, &notifyProps, &user.LastPasswordUpdate, &user.LastPictureUpdate, &user.FailedAttempts, &user.LastFailedAttemptAt, &user.Locale, &timezone, &user.MfaActive, &user.MfaSecret, &user.IsBot, &user.BotDescription, &user.BotLastIconUpdate, &user.RemoteId); err != nil {
 			return nil, errors.Wrap(err, "failed to scan values from rows into User entity")
 		}
 		if err = json.Unmarshal(props, &user.Props); err != nil {
@@ -1193,6 +1205,47 @@ func (us SqlUserStore) GetByUsername(username string) (*model.User, error) {
 	return &user, nil
 }
 
+// GetByUsernames returns the users with the given usernames, matched case-insensitively, along with
+// the requested usernames that don't belong to any user.
+func (us SqlUserStore) GetByUsernames(usernames []string) ([]*model.User, []string, error) {
+	if len(usernames) == 0 {
+		return []*model.User{}, []string{}, nil
+	}
+
+	lowered := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		lowered = append(lowered, strings.ToLower(username))
+	}
+
+	query := us.usersQuery.
+		Where(sq.Eq{"u.Username": model.RemoveDuplicateStrings(lowered)}).
+		OrderBy("u.Username ASC")
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get_by_usernames_tosql")
+	}
+
+	users := []*model.User{}
+	if err := us.GetReplicaX().Select(&users, queryString, args...); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to find Users")
+	}
+
+	found := make(map[string]bool, len(users))
+	for _, user := range users {
+		found[user.Username] = true
+	}
+
+	missing := []string{}
+	for i, username := range usernames {
+		if !found[lowered[i]] {
+			missing = append(missing, username)
+		}
+	}
+
+	return users, missing, nil
+}
+
 func (us SqlUserStore) GetForLogin(loginId string, allowSignInWithUsername, allowSignInWithEmail bool) (*model.User, error) {
 	query := us.usersQuery
 	if allowSignInWithUsername && allowSignInWithEmail {
@@ -1315,6 +1368,35 @@ func (us SqlUserStore) AnalyticsActiveCount(timePeriod int64, options model.User
 	return v, nil
 }
 
+func (us SqlUserStore) AnalyticsActiveCountByDay(since int64) (model.AnalyticsRows, error) {
+	day := "DATE(FROM_UNIXTIME(Activity.LastActivityAt / 1000))"
+	if us.DriverName() == model.DatabaseDriverPostgres {
+		day = "TO_CHAR(DATE(TO_TIMESTAMP(Activity.LastActivityAt / 1000)), 'YYYY-MM-DD')"
+	}
+
+	// Sessions and statuses only keep the last time they were used, so a user counts towards each day
+	// that one of their sessions or their status was last active on.
+	query := `SELECT
+			` + day + ` AS Name,
+			COUNT(DISTINCT Activity.UserId) AS Value
+		FROM (
+			SELECT UserId, LastActivityAt FROM Sessions WHERE LastActivityAt >= ?
+			UNION
+			SELECT UserId, LastActivityAt FROM Status WHERE LastActivityAt >= ?
+		) AS Activity
+		LEFT JOIN Bots ON Activity.UserId = Bots.UserId
+		WHERE Bots.UserId IS NULL
+		GROUP BY ` + day + `
+		ORDER BY Name`
+
+	rows := model.AnalyticsRows{}
+	if err := us.GetReplicaX().Select(&rows, query, since, since); err != nil {
+		return nil, errors.Wrapf(err, "failed to count active Users by day since=%d", since)
+	}
+
+	return rows, nil
+}
+
 func (us SqlUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
 	query := us.getQueryBuilder().Select("COUNT(*)").From("Status AS s").Where("LastActivityAt > ? AND LastActivityAt <= ?", startTime, endTime)
 
@@ -1465,6 +1547,49 @@ func (us SqlUserStore) SearchNotInGroup(groupID string, term string, options *mo
 	return us.performSearch(query, term, options)
 }
 
+func (us SqlUserStore) SearchByAttribute(key, value string, page, perPage int) ([]*model.User, error) {
+	if key == "" {
+		return nil, store.NewErrInvalidInput("User", "<key>", key)
+	}
+
+	query := us.usersQuery.
+		Where("u.DeleteAt = 0").
+		OrderBy("u.Username ASC").
+		Offset(uint64(page * perPage)).
+		Limit(uint64(perPage))
+
+	if us.DriverName() == model.DatabaseDriverPostgres {
+		// Containment can make use of the GIN index on the props.
+		attribute, err := json.Marshal(map[string]string{key: value})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal the attribute")
+		}
+		query = query.Where("u.Props @> ?::jsonb", string(attribute))
+	} else {
+		path, err := json.Marshal(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal the attribute key")
+		}
+		query = query.Where("JSON_UNQUOTE(JSON_EXTRACT(u.Props, ?)) = ?", "$."+string(path), value)
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "search_by_attribute_tosql")
+	}
+
+	users := []*model.User{}
+	if err := us.GetReplicaX().Select(&users, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Users with attribute %s", key)
+	}
+
+	for _, u := range users {
+		u.Sanitize(map[string]bool{})
+	}
+
+	return users, nil
+}
+
 func generateSearchQuery(query sq.SelectBuilder, terms []string, fields []string, isPostgreSQL bool) sq.SelectBuilder {
 	for _, term := range terms {
 		searchFields := []string{}
@@ -187,10 +187,10 @@ func (s SqlWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.Ou
 
 	if _, err := s.GetMasterX().NamedExec(`INSERT INTO OutgoingWebhooks
 			(Id, Token, CreateAt, UpdateAt, DeleteAt, CreatorId, ChannelId, TeamId, TriggerWords, TriggerWhen,
-			CallbackURLs, DisplayName, Description, ContentType, Username, IconURL)
+			TriggerReactions, TriggerOnReactionRemoved, CallbackURLs, DisplayName, Description, ContentType, Username, IconURL)
 			VALUES
 			(:Id, :Token, :CreateAt, :UpdateAt, :DeleteAt, :CreatorId, :ChannelId, :TeamId, :TriggerWords, :TriggerWhen,
-			:CallbackURLs, :DisplayName, :Description, :ContentType, :Username, :IconURL)`, webhook); err != nil {
+			:TriggerReactions, :TriggerOnReactionRemoved, :CallbackURLs, :DisplayName, :Description, :ContentType, :Username, :IconURL)`, webhook); err != nil {
 		return nil, errors.Wrapf(err, "failed to save OutgoingWebhook with id=%s", webhook.Id)
 	}
 
@@ -346,6 +346,7 @@ func (s SqlWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.Out
 	_, err := s.GetMasterX().NamedExec(`UPDATE OutgoingWebhooks SET
 			CreateAt = :CreateAt, UpdateAt = :UpdateAt, DeleteAt = :DeleteAt, Token = :Token, CreatorId = :CreatorId,
 			ChannelId = :ChannelId, TeamId = :TeamId, TriggerWords = :TriggerWords, TriggerWhen = :TriggerWhen,
+			TriggerReactions = :TriggerReactions, TriggerOnReactionRemoved = :TriggerOnReactionRemoved,
 			CallbackURLs = :CallbackURLs, DisplayName = :DisplayName, Description = :Description,
 			ContentType = :ContentType, Username = :Username, IconURL = :IconURL WHERE Id = :Id`, hook)
 	if err != nil {
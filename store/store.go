@@ -58,6 +58,11 @@ type Store interface {
 	UserTermsOfService() UserTermsOfServiceStore
 	LinkMetadata() LinkMetadataStore
 	SharedChannel() SharedChannelStore
+	PostReport() PostReportStore
+	PostReminder() PostReminderStore
+	ChannelTemplate() ChannelTemplateStore
+	GuestChannelInvite() GuestChannelInviteStore
+	ScheduledChannelDeletion() ScheduledChannelDeletionStore
 	MarkSystemRanUnitTests()
 	Close()
 	LockToMaster()
@@ -118,6 +123,8 @@ type TeamStore interface {
 	SearchPrivate(opts *model.TeamSearch) ([]*model.Team, error)
 	GetAll() ([]*model.Team, error)
 	GetAllPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, error)
+	// GetTeamsWithMemberCount returns a page of teams ordered by display name along with their active member counts.
+	GetTeamsWithMemberCount(offset int, limit int) ([]*model.TeamWithMemberCount, error)
 	GetAllPrivateTeamListing() ([]*model.Team, error)
 	GetAllTeamListing() ([]*model.Team, error)
 	GetTeamsByUserId(userID string) ([]*model.Team, error)
@@ -132,6 +139,9 @@ type TeamStore interface {
 	GetMember(ctx context.Context, teamID string, userID string) (*model.TeamMember, error)
 	GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error)
 	GetMembersByIds(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error)
+	// GetMembersByIdsInOrder returns the members with the given user ids in the same order as userIds,
+	// along with the user ids which aren't members of the team.
+	GetMembersByIdsInOrder(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, []string, error)
 	GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error)
 	GetActiveMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error)
 	GetTeamsForUser(ctx context.Context, userID, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error)
@@ -143,11 +153,19 @@ type TeamStore interface {
 	RemoveAllMembersByTeam(teamID string) error
 	RemoveAllMembersByUser(userID string) error
 	UpdateLastTeamIconUpdate(teamID string, curTime int64) error
+	// IncrementInviteUses counts a use of the team's invite link if it has any uses left.
+	IncrementInviteUses(teamID, inviteID string) (bool, error)
+	// ReleaseInviteUse gives back a use of the team's invite link counted by IncrementInviteUses.
+	ReleaseInviteUse(teamID, inviteID string) error
 	GetTeamsByScheme(schemeID string, offset int, limit int) ([]*model.Team, error)
 	MigrateTeamMembers(fromTeamID string, fromUserID string) (map[string]string, error)
 	ResetAllTeamSchemes() error
 	ClearAllCustomRoleAssignments() error
 	AnalyticsGetTeamCountForScheme(schemeID string) (int64, error)
+	// GetMemberCountsByScheme returns the number of distinct active users who are members of the
+	// non-deleted teams of each team scheme, keyed by scheme id. Teams without a scheme are counted
+	// under the empty key.
+	GetMemberCountsByScheme() (map[string]int64, error)
 	GetAllForExportAfter(limit int, afterID string) ([]*model.TeamForExport, error)
 	GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error)
 	UserBelongsToTeams(userID string, teamIds []string) (bool, error)
@@ -171,6 +189,20 @@ type ChannelStore interface {
 	Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error)
 	CreateDirectChannel(userID *model.User, otherUserID *model.User, channelOptions ...model.ChannelOption) (*model.Channel, error)
 	SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error)
+	// GetDuplicateDirectChannels finds the pairs of users that have more than one direct channel
+	// between them.
+	GetDuplicateDirectChannels() ([]*model.DuplicateDirectChannels, error)
+	// GetDirectChannelsForUsers returns every direct channel between the two users, including archived
+	// ones, ordered by creation time.
+	GetDirectChannelsForUsers(userA, userB string) ([]*model.Channel, error)
+	// MergeDirectChannels moves the posts and threads of the duplicate direct channels into the
+	// canonical one, folds their message counts and member read state into it and archives them, all
+	// in one transaction. Duplicates that are already archived are skipped, and the ids of the ones
+	// that were merged are returned.
+	MergeDirectChannels(canonicalID string, duplicateIDs []string, deleteAt int64) ([]string, error)
+	// GetCreationInfo returns who created each open and private channel of the team and when, oldest
+	// first. The creator is taken from the audit record of the channel's creation where there is one.
+	GetCreationInfo(teamID string, offset, limit int) ([]*model.ChannelCreationInfo, error)
 	Update(channel *model.Channel) (*model.Channel, error)
 	UpdateSidebarChannelCategoryOnMove(channel *model.Channel, newTeamID string) error
 	ClearSidebarOnTeamLeave(userID, teamID string) error
@@ -185,9 +217,14 @@ type ChannelStore interface {
 	PermanentDeleteByTeam(teamID string) error
 	GetByName(team_id string, name string, allowFromCache bool) (*model.Channel, error)
 	GetByNames(team_id string, names []string, allowFromCache bool) ([]*model.Channel, error)
+	// GetByNamesIncludeDeleted is GetByNames, but also returns the channels that have been archived.
+	GetByNamesIncludeDeleted(team_id string, names []string, allowFromCache bool) ([]*model.Channel, error)
 	GetByNameIncludeDeleted(team_id string, name string, allowFromCache bool) (*model.Channel, error)
 	GetDeletedByName(team_id string, name string) (*model.Channel, error)
 	GetDeleted(team_id string, offset int, limit int, userID string) (model.ChannelList, error)
+	// GetChannelsDeletedBefore returns up to limit channels archived before the given time, ordered by id
+	// and starting after afterChannelID so that callers can walk through them in batches.
+	GetChannelsDeletedBefore(deletedBefore int64, afterChannelID string, limit int) (model.ChannelList, error)
 	GetChannels(teamID, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, error)
 	GetChannelsWithCursor(teamId string, userId string, opts *model.ChannelSearchOpts, afterChannelID string) (model.ChannelList, error)
 	GetChannelsByUser(userID string, includeDeleted bool, lastDeleteAt, pageSize int, fromChannelID string) (model.ChannelList, error)
@@ -199,6 +236,7 @@ type ChannelStore interface {
 	GetPublicChannelsForTeam(teamID string, offset int, limit int) (model.ChannelList, error)
 	GetPublicChannelsByIdsForTeam(teamID string, channelIds []string) (model.ChannelList, error)
 	GetChannelCounts(teamID string, userID string) (*model.ChannelCounts, error)
+	GetTeamChannelCountForUser(teamID string, userID string) (int64, error)
 	GetTeamChannels(teamID string) (model.ChannelList, error)
 	GetAll(teamID string) ([]*model.Channel, error)
 	GetChannelsByIds(channelIds []string, includeDeleted bool) ([]*model.Channel, error)
@@ -212,6 +250,13 @@ type ChannelStore interface {
 	// It replaces existing fields and creates new ones which don't exist.
 	UpdateMemberNotifyProps(channelID, userID string, props map[string]string) (*model.ChannelMember, error)
 	GetMembers(channelID string, offset, limit int) (model.ChannelMembers, error)
+	GetMembersUpdatedSince(channelID string, since int64, offset, limit int) (model.ChannelMembers, error)
+	// GetChannelMembersWithTeamData returns a page of the channel's members, ordered by user id, each with
+	// the user's membership of the channel's team. Members who aren't in the team are left out.
+	GetChannelMembersWithTeamData(channelID string, offset, limit int) ([]*model.ChannelMemberWithTeamMember, error)
+	// GetMembersNotInTeam returns up to limit memberships of team channels whose user is no longer a
+	// member of the team, ordered by channel and user id and starting after the given ones.
+	GetMembersNotInTeam(afterChannelID, afterUserID string, limit int) (model.ChannelMembers, error)
 	GetMember(ctx context.Context, channelID string, userID string) (*model.ChannelMember, error)
 	GetChannelMembersTimezones(channelID string) ([]model.StringMap, error)
 	GetAllChannelMembersForUser(userID string, allowFromCache bool, includeDeleted bool) (map[string]string, error)
@@ -225,6 +270,7 @@ type ChannelStore interface {
 	GetFileCount(channelID string) (int64, error)
 	GetMemberCount(channelID string, allowFromCache bool) (int64, error)
 	GetMemberCountsByGroup(ctx context.Context, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error)
+	GetMemberCountsByType(channelID string) (*model.ChannelMemberCountsByType, error)
 	InvalidatePinnedPostCount(channelID string)
 	GetPinnedPostCount(channelID string, allowFromCache bool) (int64, error)
 	InvalidateGuestCount(channelID string)
@@ -242,6 +288,9 @@ type ChannelStore interface {
 	GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error)
 	GetTeamMembersForChannel(channelID string) ([]string, error)
 	GetMembersForUserWithPagination(userID string, page, perPage int) (model.ChannelMembersWithTeamData, error)
+	// SearchMembersForUser returns a page of the user's memberships in public, and optionally private,
+	// channels whose name or display name matches the term, ordered by channel display name.
+	SearchMembersForUser(userID, term string, includePrivate bool, page, perPage int) (model.ChannelMembersWithTeamData, error)
 	GetMembersForUserWithCursor(userID, teamID string, opts *ChannelMemberGraphQLSearchOpts) (model.ChannelMembers, error)
 	Autocomplete(userID, term string, includeDeleted, isGuest bool) (model.ChannelListWithTeamData, error)
 	AutocompleteInTeam(teamID, userID, term string, includeDeleted, isGuest bool) (model.ChannelList, error)
@@ -252,11 +301,17 @@ type ChannelStore interface {
 	SearchForUserInTeam(userID string, teamID string, term string, includeDeleted bool) (model.ChannelList, error)
 	SearchMore(userID string, teamID string, term string) (model.ChannelList, error)
 	SearchGroupChannels(userID, term string) (model.ChannelList, error)
+	// SearchByPurpose returns a page of the team's public and private channels whose purpose contains
+	// the term, ordered by display name. Archived channels are left out.
+	SearchByPurpose(teamID, term string, page, perPage int) (model.ChannelList, error)
 	GetMembersByIds(channelID string, userIds []string) (model.ChannelMembers, error)
 	GetMembersByChannelIds(channelIds []string, userID string) (model.ChannelMembers, error)
 	GetMembersInfoByChannelIds(channelIDs []string) (map[string][]*model.User, error)
 	AnalyticsDeletedTypeCount(teamID string, channelType model.ChannelType) (int64, error)
 	GetChannelUnread(channelID, userID string) (*model.ChannelUnread, error)
+	// GetChannelUnreadCountsForUser returns the unread messages and mentions of each of the user's
+	// channels in the team. The messages of muted channels aren't counted as unread.
+	GetChannelUnreadCountsForUser(userID, teamID string) ([]*model.ChannelUnreadCount, error)
 	ClearCaches()
 	GetChannelsByScheme(schemeID string, offset int, limit int) (model.ChannelList, error)
 	MigrateChannelMembers(fromChannelID string, fromUserID string) (map[string]string, error)
@@ -269,6 +324,7 @@ type ChannelStore interface {
 	CreateSidebarCategory(userID, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, error)
 	UpdateSidebarCategoryOrder(userID, teamID string, categoryOrder []string) error
 	UpdateSidebarCategories(userID, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, []*model.SidebarCategoryWithChannels, error)
+	UpdateSidebarCategoriesAndOrder(userID, teamID string, categories []*model.SidebarCategoryWithChannels, categoryOrder []string) ([]*model.SidebarCategoryWithChannels, []*model.SidebarCategoryWithChannels, error)
 	UpdateSidebarChannelsByPreferences(preferences model.Preferences) error
 	DeleteSidebarChannelsByPreferences(preferences model.Preferences) error
 	DeleteSidebarCategory(categoryID string) error
@@ -299,11 +355,18 @@ type ChannelStore interface {
 type ChannelMemberHistoryStore interface {
 	LogJoinEvent(userID string, channelID string, joinTime int64) error
 	LogLeaveEvent(userID string, channelID string, leaveTime int64) error
+	// GetUsersInChannelDuring returns everyone who was a member of the channel at some point during the
+	// given window, including members who have since left. If the member history doesn't reach back to the
+	// start of the window, the current members of the channel are returned instead.
 	GetUsersInChannelDuring(startTime int64, endTime int64, channelID string) ([]*model.ChannelMemberHistoryResult, error)
 	PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error)
 	DeleteOrphanedRows(limit int) (deleted int64, err error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetChannelsLeftSince(userID string, since int64) ([]string, error)
+	GetUsersLeftSince(channelID string, since int64, offset, limit int) ([]*model.ChannelMemberHistory, error)
+	// GetChannelMemberHistory returns the membership intervals of the channel that overlap the given
+	// window, ordered by join time. The LeaveTime of members still in the channel is nil.
+	GetChannelMemberHistory(channelID string, from, to int64) ([]*model.ChannelMemberHistory, error)
 }
 type ThreadStore interface {
 	GetThreadFollowers(threadID string, fetchOnlyActive bool) ([]string, error)
@@ -331,6 +394,10 @@ type ThreadStore interface {
 	PermanentDeleteBatchThreadMembershipsForRetentionPolicies(now, globalPolicyEndTime, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error)
 	DeleteOrphanedRows(limit int) (deleted int64, err error)
 	GetThreadUnreadReplyCount(threadMembership *model.ThreadMembership) (int64, error)
+	// RecomputeReplyCounts recomputes ReplyCount and LastReplyAt from the non deleted replies of up to limit
+	// threads whose root post id comes after afterPostID. It returns the id of the last thread of the batch,
+	// empty once every thread has been processed, and the number of threads whose values were repaired.
+	RecomputeReplyCounts(afterPostID string, limit int) (lastPostID string, repaired int64, err error)
 }
 
 type PostStore interface {
@@ -347,8 +414,14 @@ type PostStore interface {
 	// @openTracingParams userID, teamID, offset, limit
 	GetFlaggedPostsForTeam(userID, teamID string, offset int, limit int) (*model.PostList, error)
 	GetFlaggedPostsForChannel(userID, channelID string, offset int, limit int) (*model.PostList, error)
+	// GetFlaggedPostsForUserPaged returns a page of the posts flagged by the user, optionally scoped to a team
+	// and/or channel, excluding posts in channels the user is no longer a member of.
+	GetFlaggedPostsForUserPaged(userID string, options model.GetFlaggedPostsOptions) (*model.PostList, error)
 	GetPostsBefore(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error)
 	GetPostsAfter(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error)
+	// GetPostsAround returns the post with up to before posts preceding it and up to after posts
+	// following it in its channel, ordered newest first with the post in between.
+	GetPostsAround(postID string, before, after int) (*model.PostList, error)
 	GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error)
 	GetPostAfterTime(channelID string, timestamp int64, collapsedThreads bool) (*model.Post, error)
 	GetPostIdAfterTime(channelID string, timestamp int64, collapsedThreads bool) (string, error)
@@ -358,9 +431,11 @@ type PostStore interface {
 	AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error)
 	AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error)
 	AnalyticsPostCount(options *model.PostCountOptions) (int64, error)
+	AnalyticsBotPostCount(teamID string, since int64) (int64, error)
 	ClearCaches()
 	InvalidateLastPostTimeCache(channelID string)
 	GetLastPostRowCreateAt() (int64, error)
+	GetLastPostCreateAtForUser(channelID, userID string) (int64, error)
 	GetPostsCreatedAt(channelID string, timestamp int64) ([]*model.Post, error)
 	Overwrite(post *model.Post) (*model.Post, error)
 	OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error)
@@ -369,6 +444,10 @@ type PostStore interface {
 	PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error)
 	DeleteOrphanedRows(limit int) (deleted int64, err error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
+	PermanentDeleteBatchReturningIds(endTime int64, limit int) ([]string, error)
+	// PermanentDeleteEditHistoryBatch deletes up to limit of the original versions of edited posts that were
+	// replaced before endTime, leaving the current versions of the posts alone.
+	PermanentDeleteEditHistoryBatch(endTime int64, limit int64) (int64, error)
 	GetOldest() (*model.Post, error)
 	GetMaxPostSize() int
 	GetParentsForExportAfter(limit int, afterID string) ([]*model.PostForExport, error)
@@ -378,8 +457,25 @@ type PostStore interface {
 	GetRecentSearchesForUser(userID string) ([]*model.SearchParams, error)
 	LogRecentSearch(userID string, searchQuery []byte, createAt int64) error
 	GetOldestEntityCreationTime() (int64, error)
+	GetEarliestPostTimeForChannels(channelIDs []string, excludeSystemPosts bool) (map[string]int64, error)
 	HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error)
 	GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error)
+	// GetPostsUpdatedSince returns up to limit posts of the channel created, edited or deleted after the
+	// cursor, ordered by UpdateAt and Id, along with the cursor to fetch the next page from. The copies
+	// kept as the edit history of a post are left out.
+	GetPostsUpdatedSince(channelID string, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error)
+	// GetPostsBetween returns a page of the channel's posts created within the time window, both ends
+	// included, ordered by CreateAt. Deleted posts and the edit history of posts are left out.
+	GetPostsBetween(channelID string, from, to int64, page, perPage int) ([]*model.Post, error)
+	// GetRecentPostsForUser returns up to limit of the user's own posts, newest first, in the channels
+	// they are still a member of, along with the cursor to pass to fetch the following page.
+	GetRecentPostsForUser(userID string, cursor model.GetRecentPostsForUserCursor, limit int) ([]*model.Post, model.GetRecentPostsForUserCursor, error)
+	// GetThreadParticipants returns the ids of up to limit distinct users who replied to the thread,
+	// ordered by the time of their first reply.
+	GetThreadParticipants(rootID string, limit int) ([]string, error)
+	// GetPostsByHashtag returns a page of the posts, newest first, tagged with exactly the given hashtag
+	// in the given channels of the team, including direct and group messages.
+	GetPostsByHashtag(teamID, hashtag string, channelIDs []string, page, perPage int) (*model.PostList, error)
 }
 
 type UserStore interface {
@@ -416,6 +512,7 @@ type UserStore interface {
 	GetAllUsingAuthService(authService string) ([]*model.User, error)
 	GetAllNotInAuthService(authServices []string) ([]*model.User, error)
 	GetByUsername(username string) (*model.User, error)
+	GetByUsernames(usernames []string) ([]*model.User, []string, error)
 	GetForLogin(loginID string, allowSignInWithUsername, allowSignInWithEmail bool) (*model.User, error)
 	VerifyEmail(userID, email string) (string, error)
 	GetEtagForAllProfiles() string
@@ -425,6 +522,9 @@ type UserStore interface {
 	PermanentDelete(userID string) error
 	AnalyticsActiveCount(timestamp int64, options model.UserCountOptions) (int64, error)
 	AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error)
+	// AnalyticsActiveCountByDay returns, for each day since the given time, the number of distinct users other
+	// than bots with session or status activity on that day, oldest day first. Days without activity are left out.
+	AnalyticsActiveCountByDay(since int64) (model.AnalyticsRows, error)
 	GetUnreadCount(userID string) (int64, error)
 	GetUnreadCountForChannel(userID string, channelID string) (int64, error)
 	GetAnyUnreadPostCountForChannel(userID string, channelID string) (int64, error)
@@ -437,6 +537,9 @@ type UserStore interface {
 	SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, error)
 	SearchInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error)
 	SearchNotInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error)
+	// SearchByAttribute returns a page of the active users, ordered by username, whose profile
+	// attribute stored under key in their props has exactly the given value.
+	SearchByAttribute(key, value string, page, perPage int) ([]*model.User, error)
 	AnalyticsGetInactiveUsersCount() (int64, error)
 	AnalyticsGetExternalUsers(hostDomain string) (bool, error)
 	AnalyticsGetSystemAdminCount() (int64, error)
@@ -486,6 +589,9 @@ type SessionStore interface {
 	UpdateProps(session *model.Session) error
 	AnalyticsSessionCount() (int64, error)
 	Cleanup(expiryTime int64, batchSize int64) error
+	// RemoveExpiredSessions deletes up to limit of the sessions that expired before now, returning
+	// the number of sessions removed so that the caller can keep going until none are left.
+	RemoveExpiredSessions(now int64, limit int) (int64, error)
 }
 
 type AuditStore interface {
@@ -608,9 +714,14 @@ type CommandWebhookStore interface {
 
 type PreferenceStore interface {
 	Save(preferences model.Preferences) error
+	// SaveIfNotExists saves the preference unless the user already has one with the same category
+	// and name, reporting whether it was saved.
+	SaveIfNotExists(preference *model.Preference) (bool, error)
 	GetCategory(userID string, category string) (model.Preferences, error)
 	Get(userID string, category string, name string) (*model.Preference, error)
 	GetAll(userID string) (model.Preferences, error)
+	// GetCategoryAndName returns the preferences of every user with the given category and name.
+	GetCategoryAndName(category string, name string) (model.Preferences, error)
 	Delete(userID, category, name string) error
 	DeleteCategory(userID string, category string) error
 	DeleteCategoryAndName(category string, name string) error
@@ -642,6 +753,7 @@ type EmojiStore interface {
 	GetList(offset, limit int, sort string) ([]*model.Emoji, error)
 	Delete(emoji *model.Emoji, timestamp int64) error
 	Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error)
+	GetUnusedCustomEmoji(messagesSince int64, limit int) (*model.UnusedEmojiList, error)
 }
 
 type StatusStore interface {
@@ -662,6 +774,8 @@ type FileInfoStore interface {
 	GetByIds(ids []string) ([]*model.FileInfo, error)
 	GetByPath(path string) (*model.FileInfo, error)
 	GetForPost(postID string, readFromMaster, includeDeleted, allowFromCache bool) ([]*model.FileInfo, error)
+	// GetForPosts returns the file infos of each of the posts that aren't deleted, keyed by post id, in a single query.
+	GetForPosts(postIds []string) (map[string][]*model.FileInfo, error)
 	GetForUser(userID string) ([]*model.FileInfo, error)
 	GetWithOptions(page, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error)
 	InvalidateFileInfosForPostCache(postID string, deleted bool)
@@ -671,6 +785,7 @@ type FileInfoStore interface {
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	PermanentDeleteByUser(userID string) (int64, error)
 	SetContent(fileID, content string) error
+	SetQuarantined(fileID string, quarantined bool) error
 	Search(paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.FileInfoList, error)
 	CountAll() (int64, error)
 	GetFilesBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.FileForIndexing, error)
@@ -688,11 +803,22 @@ type UploadSessionStore interface {
 
 type ReactionStore interface {
 	Save(reaction *model.Reaction) (*model.Reaction, error)
+	// SaveMultiple saves the reactions in a single transaction. Saving a reaction that already exists
+	// is not an error.
+	SaveMultiple(reactions []*model.Reaction) ([]*model.Reaction, error)
 	Delete(reaction *model.Reaction) (*model.Reaction, error)
 	GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error)
 	GetForPostSince(postId string, since int64, excludeRemoteId string, inclDeleted bool) ([]*model.Reaction, error)
+	// GetDetailsForPost returns the post's reactions grouped by emoji, in the order in which each emoji
+	// was first used, with a page of the reacting users for each emoji in the order they reacted.
+	GetDetailsForPost(postID string, offset, limit int) ([]*model.ReactionDetails, error)
 	DeleteAllWithEmojiName(emojiName string) error
 	BulkGetForPosts(postIds []string) ([]*model.Reaction, error)
+	// GetForPosts returns the reactions of each of the posts, keyed by post id, in a single query.
+	GetForPosts(postIds []string) (map[string][]*model.Reaction, error)
+	// GetOrphanedReactions returns up to limit of the reactions whose post no longer exists, which
+	// DeleteOrphanedRows removes in batches.
+	GetOrphanedReactions(limit int) ([]*model.Reaction, error)
 	DeleteOrphanedRows(limit int) (int64, error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetTopForTeamSince(teamID string, userID string, since int64, offset int, limit int) (*model.TopReactionList, error)
@@ -794,6 +920,50 @@ type UserTermsOfServiceStore interface {
 	Delete(userID, termsOfServiceId string) error
 }
 
+type PostReportStore interface {
+	Save(report *model.PostReport) (*model.PostReport, error)
+	Get(id string) (*model.PostReport, error)
+	GetAll(options model.PostReportGetOptions) ([]*model.PostReport, error)
+	UpdateStatus(id, status string) (*model.PostReport, error)
+}
+
+type PostReminderStore interface {
+	// Save stores the reminder, replacing any reminder the user already had for the post.
+	Save(reminder *model.PostReminder) (*model.PostReminder, error)
+	// GetForUser returns the user's reminders in the order that they are due.
+	GetForUser(userID string) ([]*model.PostReminder, error)
+	// GetDue returns up to limit reminders that are due at or before the given time, oldest first.
+	GetDue(before int64, limit int) ([]*model.PostReminder, error)
+	Delete(userID, postID string) error
+	UpdateFailedAttempts(userID, postID string, attempts int) error
+}
+
+type ChannelTemplateStore interface {
+	Save(template *model.ChannelTemplate) (*model.ChannelTemplate, error)
+	Get(id string) (*model.ChannelTemplate, error)
+	// GetForTeam returns the templates of the team ordered by name.
+	GetForTeam(teamID string) ([]*model.ChannelTemplate, error)
+	Delete(id string) error
+}
+
+type GuestChannelInviteStore interface {
+	// Save records the invite. Saving an invite that already exists is not an error.
+	Save(invite *model.GuestChannelInvite) error
+	Exists(userID, channelID string) (bool, error)
+	Delete(userID, channelID string) error
+	// Cleanup deletes the invites created before the expiry time.
+	Cleanup(expiryTime int64) error
+}
+
+type ScheduledChannelDeletionStore interface {
+	// Save stores the deletion, replacing any deletion already scheduled for the channel.
+	Save(deletion *model.ScheduledChannelDeletion) (*model.ScheduledChannelDeletion, error)
+	Get(channelID string) (*model.ScheduledChannelDeletion, error)
+	// GetDue returns up to limit deletions that are due at or before the given time, oldest first.
+	GetDue(before int64, limit int) ([]*model.ScheduledChannelDeletion, error)
+	Delete(channelID string) error
+}
+
 type GroupStore interface {
 	Create(group *model.Group) (*model.Group, error)
 	CreateWithUserIds(group *model.GroupWithUserIds) (*model.Group, error)
@@ -810,6 +980,11 @@ type GroupStore interface {
 	GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error)
 	GetMemberCount(groupID string) (int64, error)
 
+	// GetChannelMemberCountsByGroup returns, for each group linked to the given channel, the number of
+	// the group's members who are also members of the channel. Linked groups without any such
+	// members are included with a count of zero.
+	GetChannelMemberCountsByGroup(channelID string) ([]*model.ChannelMemberCountByGroup, error)
+
 	GetNonMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error)
 
 	GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, error)
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"math"
+	"sort"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,9 +21,12 @@ func TestChannelMemberHistoryStore(t *testing.T, ss store.Store) {
 	t.Run("TestLogLeaveEvent", func(t *testing.T) { testLogLeaveEvent(t, ss) })
 	t.Run("TestGetUsersInChannelAtChannelMemberHistory", func(t *testing.T) { testGetUsersInChannelAtChannelMemberHistory(t, ss) })
 	t.Run("TestGetUsersInChannelAtChannelMembers", func(t *testing.T) { testGetUsersInChannelAtChannelMembers(t, ss) })
+	t.Run("TestGetUsersInChannelDuringWithMemberLeavingMidWindow", func(t *testing.T) { testGetUsersInChannelDuringWithMemberLeavingMidWindow(t, ss) })
 	t.Run("TestPermanentDeleteBatch", func(t *testing.T) { testPermanentDeleteBatch(t, ss) })
 	t.Run("TestPermanentDeleteBatchForRetentionPolicies", func(t *testing.T) { testPermanentDeleteBatchForRetentionPolicies(t, ss) })
 	t.Run("TestGetChannelsLeftSince", func(t *testing.T) { testGetChannelsLeftSince(t, ss) })
+	t.Run("TestGetUsersLeftSince", func(t *testing.T) { testGetUsersLeftSince(t, ss) })
+	t.Run("TestGetChannelMemberHistory", func(t *testing.T) { testGetChannelMemberHistory(t, ss) })
 }
 
 func testLogJoinEvent(t *testing.T, ss store.Store) {
@@ -288,6 +292,60 @@ func testGetUsersInChannelAtChannelMembers(t *testing.T, ss store.Store) {
 	assert.Equal(t, leaveTime+200, *channelMembers[0].LeaveTime)
 }
 
+func testGetUsersInChannelDuringWithMemberLeavingMidWindow(t *testing.T, ss store.Store) {
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Display " + model.NewId(),
+		Name:        NewTestId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	stayed, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(stayed.Id)) }()
+
+	left, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(left.Id)) }()
+
+	startTime := model.GetMillis() - 10000
+	endTime := startTime + 5000
+
+	// both users joined before the window starts, so the member history covers the whole window
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(stayed.Id, channel.Id, startTime-1000))
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(left.Id, channel.Id, startTime-500))
+
+	// one of them leaves half way through the window
+	leaveTime := startTime + 2500
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(left.Id, channel.Id, leaveTime))
+
+	channelMembers, err := ss.ChannelMemberHistory().GetUsersInChannelDuring(startTime, endTime, channel.Id)
+	require.NoError(t, err)
+	require.Len(t, channelMembers, 2)
+
+	assert.Equal(t, stayed.Id, channelMembers[0].UserId)
+	assert.Equal(t, startTime-1000, channelMembers[0].JoinTime)
+	assert.Nil(t, channelMembers[0].LeaveTime)
+
+	assert.Equal(t, left.Id, channelMembers[1].UserId)
+	assert.Equal(t, startTime-500, channelMembers[1].JoinTime)
+	require.NotNil(t, channelMembers[1].LeaveTime)
+	assert.Equal(t, leaveTime, *channelMembers[1].LeaveTime)
+
+	// the user who left isn't included in a window that starts after they left
+	channelMembers, err = ss.ChannelMemberHistory().GetUsersInChannelDuring(leaveTime+100, endTime, channel.Id)
+	require.NoError(t, err)
+	require.Len(t, channelMembers, 1)
+	assert.Equal(t, stayed.Id, channelMembers[0].UserId)
+}
+
 func testPermanentDeleteBatch(t *testing.T, ss store.Store) {
 	// create a test channel
 	channel := &model.Channel{
@@ -441,3 +499,106 @@ func testGetChannelsLeftSince(t *testing.T, ss store.Store) {
 	require.NoError(t, err)
 	assert.Equal(t, []string{channel.Id}, ids)
 }
+
+func testGetUsersLeftSince(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	userID := model.NewId()
+
+	joinTime := int64(1000)
+	err := ss.ChannelMemberHistory().LogJoinEvent(userID, channelID, joinTime)
+	require.NoError(t, err)
+
+	// has not left
+	histories, err := ss.ChannelMemberHistory().GetUsersLeftSince(channelID, joinTime, 0, 100)
+	require.NoError(t, err)
+	assert.Empty(t, histories)
+
+	// left
+	err = ss.ChannelMemberHistory().LogLeaveEvent(userID, channelID, joinTime+100)
+	require.NoError(t, err)
+	histories, err = ss.ChannelMemberHistory().GetUsersLeftSince(channelID, joinTime+100, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, histories, 1)
+	assert.Equal(t, userID, histories[0].UserId)
+	assert.Equal(t, channelID, histories[0].ChannelId)
+	require.NotNil(t, histories[0].LeaveTime)
+	assert.Equal(t, joinTime+100, *histories[0].LeaveTime)
+	histories, err = ss.ChannelMemberHistory().GetUsersLeftSince(channelID, joinTime+200, 0, 100)
+	require.NoError(t, err)
+	assert.Empty(t, histories)
+
+	// rejoined
+	err = ss.ChannelMemberHistory().LogJoinEvent(userID, channelID, joinTime+200)
+	require.NoError(t, err)
+	histories, err = ss.ChannelMemberHistory().GetUsersLeftSince(channelID, joinTime+100, 0, 100)
+	require.NoError(t, err)
+	assert.Empty(t, histories)
+
+	// paginated
+	otherUserIDs := []string{model.NewId(), model.NewId()}
+	sort.Strings(otherUserIDs)
+	for _, otherUserID := range otherUserIDs {
+		require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(otherUserID, channelID, joinTime))
+		require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(otherUserID, channelID, joinTime+300))
+	}
+	histories, err = ss.ChannelMemberHistory().GetUsersLeftSince(channelID, joinTime+300, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, histories, 1)
+	assert.Equal(t, otherUserIDs[1], histories[0].UserId)
+}
+
+func testGetChannelMemberHistory(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	leftUserID := model.NewId()
+	stayingUserID := model.NewId()
+	earlierUserID := model.NewId()
+
+	// joined and left within the window
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(leftUserID, channelID, 1100))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(leftUserID, channelID, 1500))
+
+	// joined within the window and never left
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(stayingUserID, channelID, 1200))
+
+	// left before the window
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(earlierUserID, channelID, 100))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(earlierUserID, channelID, 500))
+
+	// another channel
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(leftUserID, model.NewId(), 1100))
+
+	histories, err := ss.ChannelMemberHistory().GetChannelMemberHistory(channelID, 1000, 2000)
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+
+	assert.Equal(t, leftUserID, histories[0].UserId)
+	assert.Equal(t, channelID, histories[0].ChannelId)
+	assert.Equal(t, int64(1100), histories[0].JoinTime)
+	require.NotNil(t, histories[0].LeaveTime)
+	assert.Equal(t, int64(1500), *histories[0].LeaveTime)
+
+	assert.Equal(t, stayingUserID, histories[1].UserId)
+	assert.Equal(t, int64(1200), histories[1].JoinTime)
+	assert.Nil(t, histories[1].LeaveTime)
+
+	// the interval of the user who left is still returned when the window starts after they joined
+	histories, err = ss.ChannelMemberHistory().GetChannelMemberHistory(channelID, 1300, 1400)
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	assert.Equal(t, leftUserID, histories[0].UserId)
+
+	// window after the user left
+	histories, err = ss.ChannelMemberHistory().GetChannelMemberHistory(channelID, 1600, 2000)
+	require.NoError(t, err)
+	require.Len(t, histories, 1)
+	assert.Equal(t, stayingUserID, histories[0].UserId)
+
+	// rejoining adds another interval
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(leftUserID, channelID, 1700))
+	histories, err = ss.ChannelMemberHistory().GetChannelMemberHistory(channelID, 1000, 2000)
+	require.NoError(t, err)
+	require.Len(t, histories, 3)
+	assert.Equal(t, leftUserID, histories[2].UserId)
+	assert.Equal(t, int64(1700), histories[2].JoinTime)
+	assert.Nil(t, histories[2].LeaveTime)
+}
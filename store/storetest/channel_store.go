@@ -71,6 +71,7 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("CreateDirectChannel", func(t *testing.T) { testChannelStoreCreateDirectChannel(t, ss) })
 	t.Run("Update", func(t *testing.T) { testChannelStoreUpdate(t, ss) })
 	t.Run("GetChannelUnread", func(t *testing.T) { testGetChannelUnread(t, ss) })
+	t.Run("GetChannelUnreadCountsForUser", func(t *testing.T) { testGetChannelUnreadCountsForUser(t, ss) })
 	t.Run("Get", func(t *testing.T) { testChannelStoreGet(t, ss, s) })
 	t.Run("GetMany", func(t *testing.T) { testChannelStoreGetMany(t, ss, s) })
 	t.Run("GetChannelsByIds", func(t *testing.T) { testChannelStoreGetChannelsByIds(t, ss) })
@@ -80,8 +81,14 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("Delete", func(t *testing.T) { testChannelStoreDelete(t, ss) })
 	t.Run("GetByName", func(t *testing.T) { testChannelStoreGetByName(t, ss) })
 	t.Run("GetByNames", func(t *testing.T) { testChannelStoreGetByNames(t, ss) })
+	t.Run("GetByNamesIncludeDeleted", func(t *testing.T) { testChannelStoreGetByNamesIncludeDeleted(t, ss) })
 	t.Run("GetDeletedByName", func(t *testing.T) { testChannelStoreGetDeletedByName(t, ss) })
 	t.Run("GetDeleted", func(t *testing.T) { testChannelStoreGetDeleted(t, ss) })
+	t.Run("GetChannelsDeletedBefore", func(t *testing.T) { testChannelStoreGetChannelsDeletedBefore(t, ss) })
+	t.Run("GetDuplicateDirectChannels", func(t *testing.T) { testChannelStoreGetDuplicateDirectChannels(t, ss) })
+	t.Run("GetDirectChannelsForUsers", func(t *testing.T) { testChannelStoreGetDirectChannelsForUsers(t, ss) })
+	t.Run("MergeDirectChannels", func(t *testing.T) { testChannelStoreMergeDirectChannels(t, ss) })
+	t.Run("GetCreationInfo", func(t *testing.T) { testChannelStoreGetCreationInfo(t, ss) })
 	t.Run("ChannelMemberStore", func(t *testing.T) { testChannelMemberStore(t, ss) })
 	t.Run("SaveMember", func(t *testing.T) { testChannelSaveMember(t, ss) })
 	t.Run("SaveMultipleMembers", func(t *testing.T) { testChannelSaveMultipleMembers(t, ss) })
@@ -100,18 +107,25 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("GetPublicChannelsForTeam", func(t *testing.T) { testChannelStoreGetPublicChannelsForTeam(t, ss) })
 	t.Run("GetPublicChannelsByIdsForTeam", func(t *testing.T) { testChannelStoreGetPublicChannelsByIdsForTeam(t, ss) })
 	t.Run("GetChannelCounts", func(t *testing.T) { testChannelStoreGetChannelCounts(t, ss) })
+	t.Run("GetTeamChannelCountForUser", func(t *testing.T) { testChannelStoreGetTeamChannelCountForUser(t, ss) })
 	t.Run("GetMembersForUser", func(t *testing.T) { testChannelStoreGetMembersForUser(t, ss) })
 	t.Run("GetMembersForUserWithCursor", func(t *testing.T) { testChannelStoreGetMembersForUserWithCursor(t, ss) })
 	t.Run("GetMembersForUserWithPagination", func(t *testing.T) { testChannelStoreGetMembersForUserWithPagination(t, ss) })
+	t.Run("SearchMembersForUser", func(t *testing.T) { testChannelStoreSearchMembersForUser(t, ss) })
+	t.Run("SearchByPurpose", func(t *testing.T) { testChannelStoreSearchByPurpose(t, ss) })
 	t.Run("CountPostsAfter", func(t *testing.T) { testCountPostsAfter(t, ss) })
 	t.Run("UpdateLastViewedAt", func(t *testing.T) { testChannelStoreUpdateLastViewedAt(t, ss) })
 	t.Run("IncrementMentionCount", func(t *testing.T) { testChannelStoreIncrementMentionCount(t, ss) })
 	t.Run("UpdateChannelMember", func(t *testing.T) { testUpdateChannelMember(t, ss) })
 	t.Run("GetMember", func(t *testing.T) { testGetMember(t, ss) })
+	t.Run("GetMembersUpdatedSince", func(t *testing.T) { testGetMembersUpdatedSince(t, ss) })
+	t.Run("GetChannelMembersWithTeamData", func(t *testing.T) { testGetChannelMembersWithTeamData(t, ss) })
+	t.Run("GetMembersNotInTeam", func(t *testing.T) { testGetMembersNotInTeam(t, ss) })
 	t.Run("GetMemberForPost", func(t *testing.T) { testChannelStoreGetMemberForPost(t, ss) })
 	t.Run("GetMemberCount", func(t *testing.T) { testGetMemberCount(t, ss) })
 	t.Run("GetMemberCountsByGroup", func(t *testing.T) { testGetMemberCountsByGroup(t, ss) })
 	t.Run("GetGuestCount", func(t *testing.T) { testGetGuestCount(t, ss) })
+	t.Run("GetMemberCountsByType", func(t *testing.T) { testGetMemberCountsByType(t, ss) })
 	t.Run("SearchMore", func(t *testing.T) { testChannelStoreSearchMore(t, ss) })
 	t.Run("SearchInTeam", func(t *testing.T) { testChannelStoreSearchInTeam(t, ss) })
 	t.Run("Autocomplete", func(t *testing.T) { testAutocomplete(t, ss) })
@@ -403,6 +417,72 @@ func testGetChannelUnread(t *testing.T, ss store.Store) {
 	require.EqualValues(t, 10, ch2.MsgCount, "wrong MsgCount for channel 2")
 }
 
+func testGetChannelUnreadCountsForUser(t *testing.T, ss store.Store) {
+	teamID := model.NewId()
+	userID := model.NewId()
+
+	newChannel := func(teamID string, totalMsgCount int64) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:        teamID,
+			DisplayName:   "DisplayName",
+			Name:          NewTestId(),
+			Type:          model.ChannelTypeOpen,
+			TotalMsgCount: totalMsgCount,
+		}, -1)
+		require.NoError(t, err)
+		return channel
+	}
+
+	addMember := func(channel *model.Channel, msgCount, mentionCount int64, muted bool) {
+		notifyProps := model.GetDefaultChannelNotifyProps()
+		if muted {
+			notifyProps[model.MarkUnreadNotifyProp] = model.ChannelMarkUnreadMention
+		}
+		_, err := ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:    channel.Id,
+			UserId:       userID,
+			NotifyProps:  notifyProps,
+			MsgCount:     msgCount,
+			MentionCount: mentionCount,
+		})
+		require.NoError(t, err)
+	}
+
+	read := newChannel(teamID, 10)
+	addMember(read, 10, 0, false)
+	unread := newChannel(teamID, 10)
+	addMember(unread, 4, 2, false)
+	muted := newChannel(teamID, 10)
+	addMember(muted, 0, 1, true)
+
+	// None of these are included.
+	otherTeam := newChannel(model.NewId(), 10)
+	addMember(otherTeam, 0, 0, false)
+	deleted := newChannel(teamID, 10)
+	addMember(deleted, 0, 0, false)
+	require.NoError(t, ss.Channel().Delete(deleted.Id, model.GetMillis()))
+	newChannel(teamID, 10)
+
+	unreads, err := ss.Channel().GetChannelUnreadCountsForUser(userID, teamID)
+	require.NoError(t, err)
+
+	byChannel := map[string]*model.ChannelUnreadCount{}
+	for _, count := range unreads {
+		byChannel[count.ChannelId] = count
+	}
+	assert.Equal(t, map[string]*model.ChannelUnreadCount{
+		read.Id:   {ChannelId: read.Id, MsgUnread: 0, MentionUnread: 0},
+		unread.Id: {ChannelId: unread.Id, MsgUnread: 6, MentionUnread: 2},
+		muted.Id:  {ChannelId: muted.Id, MsgUnread: 0, MentionUnread: 1},
+	}, byChannel)
+
+	t.Run("user without channels in the team", func(t *testing.T) {
+		unreads, err := ss.Channel().GetChannelUnreadCountsForUser(model.NewId(), teamID)
+		require.NoError(t, err)
+		assert.Empty(t, unreads)
+	})
+}
+
 func testChannelStoreGet(t *testing.T, ss store.Store, s SqlStore) {
 	o1 := model.Channel{}
 	o1.TeamId = model.NewId()
@@ -886,6 +966,67 @@ func testChannelStoreGetByNames(t *testing.T, ss store.Store) {
 	assert.Empty(t, channels)
 }
 
+func testChannelStoreGetByNamesIncludeDeleted(t *testing.T, ss store.Store) {
+	teamID := model.NewId()
+
+	active, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      teamID,
+		DisplayName: "Name",
+		Name:        NewTestId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, nErr)
+
+	archived, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      teamID,
+		DisplayName: "Name",
+		Name:        NewTestId(),
+		Type:        model.ChannelTypePrivate,
+	}, -1)
+	require.NoError(t, nErr)
+
+	nErr = ss.Channel().Delete(archived.Id, model.GetMillis())
+	require.NoError(t, nErr)
+
+	otherTeam, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Name",
+		Name:        NewTestId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, nErr)
+
+	names := []string{active.Name, "missing", archived.Name, otherTeam.Name, active.Name}
+
+	// The second pass is served from the cache filled by the first.
+	for _, allowFromCache := range []bool{false, true} {
+		t.Run("allowFromCache="+strconv.FormatBool(allowFromCache), func(t *testing.T) {
+			idsOf := func(channels []*model.Channel) []string {
+				ids := []string{}
+				for _, channel := range channels {
+					ids = append(ids, channel.Id)
+				}
+				sort.Strings(ids)
+				return ids
+			}
+
+			channels, err := ss.Channel().GetByNamesIncludeDeleted(teamID, names, allowFromCache)
+			require.NoError(t, err)
+			expected := []string{active.Id, archived.Id}
+			sort.Strings(expected)
+			assert.Equal(t, expected, idsOf(channels))
+
+			channels, err = ss.Channel().GetByNames(teamID, names, allowFromCache)
+			require.NoError(t, err)
+			assert.Equal(t, []string{active.Id}, idsOf(channels))
+
+			channels, err = ss.Channel().GetByNamesIncludeDeleted(teamID, []string{"missing"}, allowFromCache)
+			require.NoError(t, err)
+			assert.Empty(t, channels)
+		})
+	}
+}
+
 func testChannelStoreGetDeletedByName(t *testing.T, ss store.Store) {
 	o1 := &model.Channel{}
 	o1.TeamId = model.NewId()
@@ -967,6 +1108,333 @@ func testChannelStoreGetDeleted(t *testing.T, ss store.Store) {
 
 }
 
+func testChannelStoreGetChannelsDeletedBefore(t *testing.T, ss store.Store) {
+	teamID := model.NewId()
+	now := model.GetMillis()
+	threshold := now - 365*24*60*60*1000
+
+	newChannel := func(deleteAt int64) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamID,
+			DisplayName: "DisplayName",
+			Name:        NewTestId(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err)
+
+		if deleteAt != 0 {
+			require.NoError(t, ss.Channel().Delete(channel.Id, deleteAt))
+		}
+		return channel
+	}
+
+	old1 := newChannel(threshold - 1000)
+	old2 := newChannel(threshold - 24*60*60*1000)
+	recent := newChannel(threshold + 1000)
+	active := newChannel(0)
+
+	t.Run("returns only channels archived before the threshold", func(t *testing.T) {
+		channels, err := ss.Channel().GetChannelsDeletedBefore(threshold, "", 10000)
+		require.NoError(t, err)
+
+		ids := map[string]bool{}
+		for _, channel := range channels {
+			assert.NotZero(t, channel.DeleteAt)
+			assert.Less(t, channel.DeleteAt, threshold)
+			ids[channel.Id] = true
+		}
+
+		assert.True(t, ids[old1.Id])
+		assert.True(t, ids[old2.Id])
+		assert.False(t, ids[recent.Id])
+		assert.False(t, ids[active.Id])
+	})
+
+	t.Run("returns channels in batches", func(t *testing.T) {
+		var ids []string
+		afterID := ""
+		for {
+			channels, err := ss.Channel().GetChannelsDeletedBefore(threshold, afterID, 1)
+			require.NoError(t, err)
+			if len(channels) == 0 {
+				break
+			}
+			require.Len(t, channels, 1)
+			require.Greater(t, channels[0].Id, afterID)
+
+			afterID = channels[0].Id
+			ids = append(ids, afterID)
+		}
+
+		assert.Contains(t, ids, old1.Id)
+		assert.Contains(t, ids, old2.Id)
+		assert.NotContains(t, ids, recent.Id)
+		assert.NotContains(t, ids, active.Id)
+	})
+}
+
+func testChannelStoreGetDuplicateDirectChannels(t *testing.T, ss store.Store) {
+	newUser := func() *model.User {
+		user, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+		require.NoError(t, err)
+		return user
+	}
+
+	newDirectChannel := func(user1, user2 *model.User) *model.Channel {
+		channel, err := ss.Channel().SaveDirectChannel(
+			&model.Channel{Name: NewTestId(), DisplayName: "DisplayName", Type: model.ChannelTypeDirect},
+			&model.ChannelMember{UserId: user1.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+			&model.ChannelMember{UserId: user2.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+		)
+		require.NoError(t, err)
+		return channel
+	}
+
+	sortedIds := func(user1, user2 *model.User) []string {
+		if user1.Id > user2.Id {
+			return []string{user2.Id, user1.Id}
+		}
+		return []string{user1.Id, user2.Id}
+	}
+
+	u1 := newUser()
+	u2 := newUser()
+	u3 := newUser()
+
+	// The same two users, created in either order.
+	dm1 := newDirectChannel(u1, u2)
+	time.Sleep(time.Millisecond)
+	dm2 := newDirectChannel(u2, u1)
+
+	// A user's channel with themselves.
+	self1 := newDirectChannel(u3, u3)
+	time.Sleep(time.Millisecond)
+	self2 := newDirectChannel(u3, u3)
+
+	// Not duplicated.
+	newDirectChannel(u1, u3)
+	newDirectChannel(u1, u1)
+
+	duplicates, err := ss.Channel().GetDuplicateDirectChannels()
+	require.NoError(t, err)
+
+	byPair := map[string]*model.DuplicateDirectChannels{}
+	for _, duplicate := range duplicates {
+		require.Len(t, duplicate.UserIds, 2)
+		require.Greater(t, len(duplicate.ChannelIds), 1)
+		byPair[duplicate.UserIds[0]+duplicate.UserIds[1]] = duplicate
+	}
+
+	pair := sortedIds(u1, u2)
+	require.Contains(t, byPair, pair[0]+pair[1])
+	assert.Equal(t, []string{dm1.Id, dm2.Id}, byPair[pair[0]+pair[1]].ChannelIds)
+
+	require.Contains(t, byPair, u3.Id+u3.Id)
+	assert.Equal(t, []string{self1.Id, self2.Id}, byPair[u3.Id+u3.Id].ChannelIds)
+
+	pair = sortedIds(u1, u3)
+	assert.NotContains(t, byPair, pair[0]+pair[1])
+	assert.NotContains(t, byPair, u1.Id+u1.Id)
+}
+
+func testChannelStoreGetDirectChannelsForUsers(t *testing.T, ss store.Store) {
+	newUser := func() *model.User {
+		user, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+		require.NoError(t, err)
+		return user
+	}
+
+	newDirectChannel := func(user1, user2 *model.User) *model.Channel {
+		channel, err := ss.Channel().SaveDirectChannel(
+			&model.Channel{Name: NewTestId(), DisplayName: "DisplayName", Type: model.ChannelTypeDirect},
+			&model.ChannelMember{UserId: user1.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+			&model.ChannelMember{UserId: user2.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+		)
+		require.NoError(t, err)
+		return channel
+	}
+
+	channelIds := func(channels []*model.Channel) []string {
+		ids := []string{}
+		for _, channel := range channels {
+			ids = append(ids, channel.Id)
+		}
+		return ids
+	}
+
+	u1 := newUser()
+	u2 := newUser()
+	u3 := newUser()
+
+	dm1 := newDirectChannel(u1, u2)
+	time.Sleep(time.Millisecond)
+	dm2 := newDirectChannel(u2, u1)
+	self := newDirectChannel(u1, u1)
+	newDirectChannel(u1, u3)
+
+	err := ss.Channel().Delete(dm2.Id, model.GetMillis())
+	require.NoError(t, err)
+
+	channels, err := ss.Channel().GetDirectChannelsForUsers(u2.Id, u1.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{dm1.Id, dm2.Id}, channelIds(channels))
+
+	channels, err = ss.Channel().GetDirectChannelsForUsers(u1.Id, u1.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{self.Id}, channelIds(channels))
+
+	channels, err = ss.Channel().GetDirectChannelsForUsers(u2.Id, u3.Id)
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+}
+
+func testChannelStoreMergeDirectChannels(t *testing.T, ss store.Store) {
+	newUser := func() *model.User {
+		user, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+		require.NoError(t, err)
+		return user
+	}
+
+	newDirectChannel := func(user1, user2 *model.User) *model.Channel {
+		channel, err := ss.Channel().SaveDirectChannel(
+			&model.Channel{Name: NewTestId(), DisplayName: "DisplayName", Type: model.ChannelTypeDirect},
+			&model.ChannelMember{UserId: user1.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+			&model.ChannelMember{UserId: user2.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+		)
+		require.NoError(t, err)
+		return channel
+	}
+
+	newPost := func(channel *model.Channel, user *model.User, postType string) *model.Post {
+		post, err := ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: user.Id, Message: "message", Type: postType})
+		require.NoError(t, err)
+		return post
+	}
+
+	u1 := newUser()
+	u2 := newUser()
+
+	canonical := newDirectChannel(u1, u2)
+	duplicate := newDirectChannel(u1, u2)
+
+	canonicalPost := newPost(canonical, u1, model.PostTypeDefault)
+	duplicatePost := newPost(duplicate, u1, model.PostTypeDefault)
+	joinPost := newPost(duplicate, u1, model.PostTypeJoinChannel)
+
+	// u2 has read the duplicate channel but not the canonical one.
+	_, err := ss.Channel().UpdateLastViewedAt([]string{duplicate.Id}, u2.Id)
+	require.NoError(t, err)
+
+	assertMerged := func(t *testing.T) {
+		t.Helper()
+
+		merged, err := ss.Channel().Get(canonical.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), merged.TotalMsgCount)
+		assert.Equal(t, int64(2), merged.TotalMsgCountRoot)
+		assert.Equal(t, joinPost.CreateAt, merged.LastPostAt)
+
+		member, err := ss.Channel().GetMember(context.Background(), canonical.Id, u2.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), member.MsgCount)
+		assert.Equal(t, int64(1), merged.TotalMsgCount-member.MsgCount, "the canonical post should still be unread")
+
+		archived, err := ss.Channel().Get(duplicate.Id, false)
+		require.NoError(t, err)
+		assert.NotZero(t, archived.DeleteAt)
+
+		for _, postID := range []string{canonicalPost.Id, duplicatePost.Id} {
+			post, err := ss.Post().GetSingle(postID, false)
+			require.NoError(t, err)
+			assert.Equal(t, canonical.Id, post.ChannelId)
+		}
+	}
+
+	mergedIDs, err := ss.Channel().MergeDirectChannels(canonical.Id, []string{duplicate.Id}, model.GetMillis())
+	require.NoError(t, err)
+	assert.Equal(t, []string{duplicate.Id}, mergedIDs)
+	assertMerged(t)
+
+	t.Run("merging again doesn't count the posts twice", func(t *testing.T) {
+		mergedIDs, err := ss.Channel().MergeDirectChannels(canonical.Id, []string{duplicate.Id}, model.GetMillis())
+		require.NoError(t, err)
+		assert.Empty(t, mergedIDs)
+		assertMerged(t)
+	})
+}
+
+func testChannelStoreGetCreationInfo(t *testing.T, ss store.Store) {
+	teamID := model.NewId()
+
+	newChannel := func(channelType model.ChannelType, creatorID string) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamID,
+			DisplayName: "DisplayName",
+			Name:        NewTestId(),
+			Type:        channelType,
+			CreatorId:   creatorID,
+		}, -1)
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+		return channel
+	}
+
+	saveCreationAudit := func(channel *model.Channel, userID string) *model.Audit {
+		audit := &model.Audit{UserId: userID, Action: "/api/v4/channels", ExtraInfo: "name=" + channel.Name}
+		require.NoError(t, ss.Audit().Save(audit))
+		return audit
+	}
+
+	// The audit record agrees with the channel.
+	creator := model.NewId()
+	agreeing := newChannel(model.ChannelTypeOpen, creator)
+	agreeingAudit := saveCreationAudit(agreeing, creator)
+
+	// The channel's creator was lost, but the audit record has it.
+	auditCreator := model.NewId()
+	lost := newChannel(model.ChannelTypePrivate, "")
+	lostAudit := saveCreationAudit(lost, auditCreator)
+
+	// The channel was created without going through the API so there is no audit record.
+	unaudited := newChannel(model.ChannelTypeOpen, creator)
+
+	// An audit record saved before the channel existed belongs to a channel with the same name that
+	// was created earlier.
+	early := &model.Channel{TeamId: teamID, DisplayName: "DisplayName", Name: NewTestId(), Type: model.ChannelTypeOpen, CreatorId: creator}
+	saveCreationAudit(early, model.NewId())
+	time.Sleep(time.Millisecond)
+	_, err := ss.Channel().Save(early, -1)
+	require.NoError(t, err)
+
+	// Direct channels aren't included.
+	_, err = ss.Channel().CreateDirectChannel(&model.User{Id: model.NewId()}, &model.User{Id: model.NewId()})
+	require.NoError(t, err)
+
+	infos, err := ss.Channel().GetCreationInfo(teamID, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, []*model.ChannelCreationInfo{
+		{ChannelId: agreeing.Id, TeamId: teamID, Name: agreeing.Name, CreateAt: agreeing.CreateAt, CreatorId: creator, AuditId: agreeingAudit.Id},
+		{ChannelId: lost.Id, TeamId: teamID, Name: lost.Name, CreateAt: lost.CreateAt, CreatorId: auditCreator, AuditId: lostAudit.Id},
+		{ChannelId: unaudited.Id, TeamId: teamID, Name: unaudited.Name, CreateAt: unaudited.CreateAt, CreatorId: creator},
+		{ChannelId: early.Id, TeamId: teamID, Name: early.Name, CreateAt: early.CreateAt, CreatorId: creator},
+	}, infos)
+
+	t.Run("paginated", func(t *testing.T) {
+		infos, err := ss.Channel().GetCreationInfo(teamID, 1, 2)
+		require.NoError(t, err)
+		require.Len(t, infos, 2)
+		require.Equal(t, lost.Id, infos[0].ChannelId)
+		require.Equal(t, auditCreator, infos[0].CreatorId)
+		require.Equal(t, unaudited.Id, infos[1].ChannelId)
+	})
+
+	t.Run("other team", func(t *testing.T) {
+		infos, err := ss.Channel().GetCreationInfo(model.NewId(), 0, 100)
+		require.NoError(t, err)
+		require.Empty(t, infos)
+	})
+}
+
 func testChannelMemberStore(t *testing.T, ss store.Store) {
 	c1 := &model.Channel{}
 	c1.TeamId = model.NewId()
@@ -4333,6 +4801,51 @@ func testChannelStoreGetChannelCounts(t *testing.T, ss store.Store) {
 	require.Len(t, counts.UpdateTimes, 1, "wrong number of update times")
 }
 
+func testChannelStoreGetTeamChannelCountForUser(t *testing.T, ss store.Store) {
+	teamID := model.NewId()
+	userID := model.NewId()
+
+	saveChannel := func(teamID string) *model.Channel {
+		t.Helper()
+
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamID,
+			DisplayName: "Channel",
+			Name:        NewTestId(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err)
+
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      userID,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, err)
+
+		return channel
+	}
+
+	saveChannel(teamID)
+	archived := saveChannel(teamID)
+	saveChannel(model.NewId())
+
+	count, err := ss.Channel().GetTeamChannelCountForUser(teamID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	err = ss.Channel().Delete(archived.Id, model.GetMillis())
+	require.NoError(t, err)
+
+	count, err = ss.Channel().GetTeamChannelCountForUser(teamID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = ss.Channel().GetTeamChannelCountForUser(teamID, model.NewId())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
 func testChannelStoreGetMembersForUser(t *testing.T, ss store.Store) {
 	t1 := model.Team{}
 	t1.DisplayName = "Name"
@@ -4672,6 +5185,157 @@ func testChannelStoreGetMembersForUserWithPagination(t *testing.T, ss store.Stor
 	assert.Len(t, members, 1)
 }
 
+func testChannelStoreSearchByPurpose(t *testing.T, ss store.Store) {
+	keyword := "incident" + NewTestId()
+
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "team",
+		Name:        NewTestId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	newChannel := func(teamID, displayName, purpose string, channelType model.ChannelType) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamID,
+			DisplayName: displayName,
+			Name:        NewTestId(),
+			Purpose:     purpose,
+			Type:        channelType,
+		}, -1)
+		require.NoError(t, err)
+		return channel
+	}
+
+	c1 := newChannel(team.Id, "A", "Coordinate the "+keyword+" response", model.ChannelTypeOpen)
+	c2 := newChannel(team.Id, "B", strings.ToUpper(keyword)+" reviews", model.ChannelTypePrivate)
+	c3 := newChannel(team.Id, "C", "Follow up on each "+keyword, model.ChannelTypeOpen)
+	newChannel(team.Id, "D", "Chat about anything", model.ChannelTypeOpen)
+	newChannel(model.NewId(), "E", "Another team's "+keyword, model.ChannelTypeOpen)
+
+	archived := newChannel(team.Id, "F", "Old "+keyword, model.ChannelTypeOpen)
+	err = ss.Channel().Delete(archived.Id, model.GetMillis())
+	require.NoError(t, err)
+
+	channelIds := func(channels model.ChannelList) []string {
+		ids := make([]string, 0, len(channels))
+		for _, channel := range channels {
+			ids = append(ids, channel.Id)
+		}
+		return ids
+	}
+
+	t.Run("matching purposes", func(t *testing.T) {
+		channels, err := ss.Channel().SearchByPurpose(team.Id, keyword, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{c1.Id, c2.Id, c3.Id}, channelIds(channels))
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		channels, err := ss.Channel().SearchByPurpose(team.Id, keyword, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{c3.Id}, channelIds(channels))
+	})
+
+	t.Run("non-matching purposes", func(t *testing.T) {
+		channels, err := ss.Channel().SearchByPurpose(team.Id, NewTestId(), 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, channels)
+	})
+
+	t.Run("empty term", func(t *testing.T) {
+		channels, err := ss.Channel().SearchByPurpose(team.Id, "", 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, channels)
+	})
+}
+
+func testChannelStoreSearchMembersForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	prefix := "search" + NewTestId()
+
+	var teams []*model.Team
+	for i := 0; i < 2; i++ {
+		team, err := ss.Team().Save(&model.Team{
+			DisplayName: "team" + strconv.Itoa(i),
+			Name:        NewTestId(),
+			Email:       MakeEmail(),
+			Type:        model.TeamOpen,
+		})
+		require.NoError(t, err)
+		teams = append(teams, team)
+	}
+
+	newChannel := func(team *model.Team, displayName string, channelType model.ChannelType) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      team.Id,
+			DisplayName: displayName,
+			Name:        NewTestId(),
+			Type:        channelType,
+		}, -1)
+		require.NoError(t, err)
+
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      userId,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, err)
+
+		return channel
+	}
+
+	c1 := newChannel(teams[0], prefix+" A", model.ChannelTypeOpen)
+	c2 := newChannel(teams[1], prefix+" B", model.ChannelTypeOpen)
+	c3 := newChannel(teams[1], prefix+" C", model.ChannelTypePrivate)
+	newChannel(teams[0], "Other "+NewTestId(), model.ChannelTypeOpen)
+
+	channelIds := func(members model.ChannelMembersWithTeamData) []string {
+		ids := make([]string, 0, len(members))
+		for _, member := range members {
+			ids = append(ids, member.ChannelId)
+		}
+		return ids
+	}
+
+	t.Run("public channels only", func(t *testing.T) {
+		members, err := ss.Channel().SearchMembersForUser(userId, prefix, false, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{c1.Id, c2.Id}, channelIds(members))
+		assert.Equal(t, teams[0].DisplayName, members[0].TeamDisplayName)
+		assert.Equal(t, teams[1].Name, members[1].TeamName)
+	})
+
+	t.Run("including private channels", func(t *testing.T) {
+		members, err := ss.Channel().SearchMembersForUser(userId, strings.ToUpper(prefix), true, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{c1.Id, c2.Id, c3.Id}, channelIds(members))
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		members, err := ss.Channel().SearchMembersForUser(userId, prefix, true, 0, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{c1.Id, c2.Id}, channelIds(members))
+
+		members, err = ss.Channel().SearchMembersForUser(userId, prefix, true, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{c3.Id}, channelIds(members))
+	})
+
+	t.Run("empty term matches every channel", func(t *testing.T) {
+		members, err := ss.Channel().SearchMembersForUser(userId, "", true, 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, members, 4)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		members, err := ss.Channel().SearchMembersForUser(userId, NewTestId(), true, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, members)
+	})
+}
+
 func testCountPostsAfter(t *testing.T, ss store.Store) {
 	t.Run("should count all posts with or without the given user ID", func(t *testing.T) {
 		userId1 := model.NewId()
@@ -4937,6 +5601,222 @@ func testUpdateChannelMember(t *testing.T, ss store.Store) {
 	require.Error(t, err, "bad user id - should fail")
 }
 
+func testGetMembersUpdatedSince(t *testing.T, ss store.Store) {
+	c1 := &model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: model.NewId(),
+		Name:        model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}
+	_, nErr := ss.Channel().Save(c1, -1)
+	require.NoError(t, nErr)
+
+	m1, err := ss.Channel().SaveMember(&model.ChannelMember{
+		ChannelId:   c1.Id,
+		UserId:      model.NewId(),
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+	since := model.GetMillis()
+
+	m2, err := ss.Channel().SaveMember(&model.ChannelMember{
+		ChannelId:   c1.Id,
+		UserId:      model.NewId(),
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	members, err := ss.Channel().GetMembersUpdatedSince(c1.Id, m1.LastUpdateAt, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	members, err = ss.Channel().GetMembersUpdatedSince(c1.Id, since, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, m2.UserId, members[0].UserId)
+
+	members, err = ss.Channel().GetMembersUpdatedSince(c1.Id, m1.LastUpdateAt, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, m2.UserId, members[0].UserId)
+
+	members, err = ss.Channel().GetMembersUpdatedSince(model.NewId(), 0, 0, 100)
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
+
+func testGetChannelMembersWithTeamData(t *testing.T, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "Team",
+		Name:        NewTestId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	channel, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "Channel",
+		Name:        NewTestId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, nErr)
+
+	addMember := func(userID string, teamAdmin, channelAdmin bool) {
+		_, err := ss.Team().SaveMember(&model.TeamMember{TeamId: team.Id, UserId: userID, SchemeUser: true, SchemeAdmin: teamAdmin}, -1)
+		require.NoError(t, err)
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      userID,
+			SchemeUser:  true,
+			SchemeAdmin: channelAdmin,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, err)
+	}
+
+	ids := []string{model.NewId(), model.NewId()}
+	sort.Strings(ids)
+	teamAdminID, channelAdminID := ids[0], ids[1]
+	addMember(teamAdminID, true, false)
+	addMember(channelAdminID, false, true)
+
+	// A channel member who isn't in the team.
+	_, err = ss.Channel().SaveMember(&model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      model.NewId(),
+		SchemeUser:  true,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	members, err := ss.Channel().GetChannelMembersWithTeamData(channel.Id, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	assert.Equal(t, teamAdminID, members[0].UserId)
+	assert.Equal(t, channel.Id, members[0].ChannelId)
+	assert.Equal(t, "channel_user", members[0].Roles)
+	assert.False(t, members[0].SchemeAdmin)
+	assert.Equal(t, team.Name, members[0].TeamName)
+	assert.Equal(t, team.DisplayName, members[0].TeamDisplayName)
+	assert.Equal(t, team.Id, members[0].TeamMember.TeamId)
+	assert.Equal(t, teamAdminID, members[0].TeamMember.UserId)
+	assert.Equal(t, "team_user team_admin", members[0].TeamMember.Roles)
+	assert.True(t, members[0].TeamMember.SchemeAdmin)
+
+	assert.Equal(t, channelAdminID, members[1].UserId)
+	assert.Equal(t, "channel_user channel_admin", members[1].Roles)
+	assert.True(t, members[1].SchemeAdmin)
+	assert.Equal(t, "team_user", members[1].TeamMember.Roles)
+	assert.False(t, members[1].TeamMember.SchemeAdmin)
+
+	t.Run("paginated", func(t *testing.T) {
+		members, err := ss.Channel().GetChannelMembersWithTeamData(channel.Id, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		assert.Equal(t, channelAdminID, members[0].UserId)
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		members, err := ss.Channel().GetChannelMembersWithTeamData(model.NewId(), 0, 100)
+		require.NoError(t, err)
+		assert.Empty(t, members)
+	})
+}
+
+func testGetMembersNotInTeam(t *testing.T, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "Team",
+		Name:        NewTestId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	saveChannel := func(channelType model.ChannelType) *model.Channel {
+		channel, nErr := ss.Channel().Save(&model.Channel{
+			TeamId:      team.Id,
+			DisplayName: "Channel",
+			Name:        NewTestId(),
+			Type:        channelType,
+		}, -1)
+		require.NoError(t, nErr)
+		return channel
+	}
+	openChannel := saveChannel(model.ChannelTypeOpen)
+	privateChannel := saveChannel(model.ChannelTypePrivate)
+
+	saveUser := func() *model.User {
+		user, nErr := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+		require.NoError(t, nErr)
+		return user
+	}
+	addChannelMember := func(channelID, userID string) {
+		_, nErr := ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channelID,
+			UserId:      userID,
+			SchemeUser:  true,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, nErr)
+	}
+
+	teamMember := saveUser()
+	_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: team.Id, UserId: teamMember.Id, SchemeUser: true}, -1)
+	require.NoError(t, err)
+	addChannelMember(openChannel.Id, teamMember.Id)
+
+	// A user removed from the team who kept their channel memberships.
+	removedMember := saveUser()
+	member, err := ss.Team().SaveMember(&model.TeamMember{TeamId: team.Id, UserId: removedMember.Id, SchemeUser: true}, -1)
+	require.NoError(t, err)
+	addChannelMember(openChannel.Id, removedMember.Id)
+	addChannelMember(privateChannel.Id, removedMember.Id)
+	member.DeleteAt = model.GetMillis()
+	_, err = ss.Team().UpdateMember(member)
+	require.NoError(t, err)
+
+	// A user who was never in the team.
+	neverMember := saveUser()
+	addChannelMember(privateChannel.Id, neverMember.Id)
+
+	// Direct channels don't belong to a team.
+	dm, err := ss.Channel().CreateDirectChannel(teamMember, neverMember)
+	require.NoError(t, err)
+
+	channelIDs := map[string]bool{openChannel.Id: true, privateChannel.Id: true, dm.Id: true}
+
+	// Other tests leave inconsistent memberships behind, so only the ones of the channels above are kept.
+	var found []string
+	afterChannelID, afterUserID := "", ""
+	for {
+		members, err := ss.Channel().GetMembersNotInTeam(afterChannelID, afterUserID, 2)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(members), 2)
+		if len(members) == 0 {
+			break
+		}
+
+		for _, member := range members {
+			if channelIDs[member.ChannelId] {
+				found = append(found, member.ChannelId+" "+member.UserId)
+			}
+		}
+
+		last := members[len(members)-1]
+		afterChannelID, afterUserID = last.ChannelId, last.UserId
+	}
+
+	assert.ElementsMatch(t, []string{
+		openChannel.Id + " " + removedMember.Id,
+		privateChannel.Id + " " + removedMember.Id,
+		privateChannel.Id + " " + neverMember.Id,
+	}, found)
+}
+
 func testGetMember(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 
@@ -5479,6 +6359,103 @@ func testGetGuestCount(t *testing.T, ss store.Store) {
 	})
 }
 
+func testGetMemberCountsByType(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	c1 := model.Channel{
+		TeamId:      teamId,
+		DisplayName: "Channel1",
+		Name:        NewTestId(),
+		Type:        model.ChannelTypeOpen,
+	}
+	_, nErr := ss.Channel().Save(&c1, -1)
+	require.NoError(t, nErr)
+
+	c2 := model.Channel{
+		TeamId:      teamId,
+		DisplayName: "Channel2",
+		Name:        NewTestId(),
+		Type:        model.ChannelTypeOpen,
+	}
+	_, nErr = ss.Channel().Save(&c2, -1)
+	require.NoError(t, nErr)
+
+	addMember := func(t *testing.T, channelId string, guest bool, deleteAt int64) {
+		t.Helper()
+
+		roles := model.SystemUserRoleId
+		if guest {
+			roles = model.SystemGuestRoleId
+		}
+		u, err := ss.User().Save(&model.User{
+			Email:    MakeEmail(),
+			DeleteAt: deleteAt,
+			Roles:    roles,
+		})
+		require.NoError(t, err)
+		_, nErr := ss.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: u.Id, SchemeGuest: guest, SchemeUser: !guest}, -1)
+		require.NoError(t, nErr)
+
+		_, nErr = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channelId,
+			UserId:      u.Id,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+			SchemeGuest: guest,
+			SchemeUser:  !guest,
+		})
+		require.NoError(t, nErr)
+	}
+
+	t.Run("empty channel", func(t *testing.T) {
+		counts, err := ss.Channel().GetMemberCountsByType(c1.Id)
+		require.NoError(t, err)
+		require.Equal(t, &model.ChannelMemberCountsByType{}, counts)
+	})
+
+	t.Run("mix of members, guests and deactivated users", func(t *testing.T) {
+		addMember(t, c1.Id, false, 0)
+		addMember(t, c1.Id, false, 0)
+		addMember(t, c1.Id, false, 0)
+		addMember(t, c1.Id, true, 0)
+		addMember(t, c1.Id, true, 0)
+		addMember(t, c1.Id, false, 10000)
+		addMember(t, c1.Id, true, 10000)
+
+		counts, err := ss.Channel().GetMemberCountsByType(c1.Id)
+		require.NoError(t, err)
+		require.Equal(t, &model.ChannelMemberCountsByType{
+			MemberCount:      3,
+			GuestCount:       2,
+			DeactivatedCount: 2,
+		}, counts)
+
+		// The counts agree with the existing single-type counters.
+		memberCount, err := ss.Channel().GetMemberCount(c1.Id, false)
+		require.NoError(t, err)
+		require.Equal(t, counts.MemberCount+counts.GuestCount, memberCount)
+
+		guestCount, err := ss.Channel().GetGuestCount(c1.Id, false)
+		require.NoError(t, err)
+		require.Equal(t, counts.GuestCount, guestCount)
+	})
+
+	t.Run("members of other channels aren't counted", func(t *testing.T) {
+		addMember(t, c2.Id, false, 0)
+		addMember(t, c2.Id, true, 10000)
+
+		counts, err := ss.Channel().GetMemberCountsByType(c2.Id)
+		require.NoError(t, err)
+		require.Equal(t, &model.ChannelMemberCountsByType{
+			MemberCount:      1,
+			DeactivatedCount: 1,
+		}, counts)
+
+		counts, err = ss.Channel().GetMemberCountsByType(c1.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), counts.MemberCount)
+	})
+}
+
 func testChannelStoreSearchMore(t *testing.T, ss store.Store) {
 	teamId := model.NewId()
 	otherTeamId := model.NewId()
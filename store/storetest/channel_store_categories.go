@@ -22,6 +22,7 @@ func TestChannelStoreCategories(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("GetSidebarCategory", func(t *testing.T) { testGetSidebarCategory(t, ss, s) })
 	t.Run("GetSidebarCategories", func(t *testing.T) { testGetSidebarCategories(t, ss) })
 	t.Run("UpdateSidebarCategories", func(t *testing.T) { testUpdateSidebarCategories(t, ss) })
+	t.Run("UpdateSidebarCategoriesAndOrder", func(t *testing.T) { testUpdateSidebarCategoriesAndOrder(t, ss) })
 	t.Run("ClearSidebarOnTeamLeave", func(t *testing.T) { testClearSidebarOnTeamLeave(t, ss, s) })
 	t.Run("DeleteSidebarCategory", func(t *testing.T) { testDeleteSidebarCategory(t, ss, s) })
 	t.Run("UpdateSidebarChannelsByPreferences", func(t *testing.T) { testUpdateSidebarChannelsByPreferences(t, ss) })
@@ -1723,6 +1724,70 @@ func setupInitialSidebarCategories(t *testing.T, ss store.Store) (string, string
 	return userId, teamId
 }
 
+func testUpdateSidebarCategoriesAndOrder(t *testing.T, ss store.Store) {
+	t.Run("should update categories and their order together", func(t *testing.T) {
+		userId := model.NewId()
+		teamId := model.NewId()
+
+		res, err := ss.Channel().CreateInitialSidebarCategories(userId, teamId)
+		require.NoError(t, err)
+		require.NotEmpty(t, res)
+
+		initialCategories, err := ss.Channel().GetSidebarCategories(userId, teamId)
+		require.NoError(t, err)
+
+		favoritesCategory := initialCategories.Categories[0]
+		channelsCategory := initialCategories.Categories[1]
+		dmsCategory := initialCategories.Categories[2]
+
+		channelId := model.NewId()
+		favoritesCategory.Channels = []string{channelId}
+
+		updated, original, err := ss.Channel().UpdateSidebarCategoriesAndOrder(userId, teamId, []*model.SidebarCategoryWithChannels{
+			favoritesCategory,
+		}, []string{dmsCategory.Id, channelsCategory.Id, favoritesCategory.Id})
+		require.NoError(t, err)
+		require.Len(t, updated, 1)
+		require.Len(t, original, 1)
+		assert.Equal(t, []string{channelId}, updated[0].Channels)
+		assert.Empty(t, original[0].Channels)
+
+		got, err := ss.Channel().GetSidebarCategories(userId, teamId)
+		require.NoError(t, err)
+		assert.Equal(t, model.SidebarCategoryOrder{dmsCategory.Id, channelsCategory.Id, favoritesCategory.Id}, got.Order)
+		assert.Equal(t, []string{channelId}, got.Categories[2].Channels)
+		assert.Equal(t, "Favorites", got.Categories[2].DisplayName)
+	})
+
+	t.Run("should not update categories when the order is invalid", func(t *testing.T) {
+		userId := model.NewId()
+		teamId := model.NewId()
+
+		res, err := ss.Channel().CreateInitialSidebarCategories(userId, teamId)
+		require.NoError(t, err)
+		require.NotEmpty(t, res)
+
+		initialCategories, err := ss.Channel().GetSidebarCategories(userId, teamId)
+		require.NoError(t, err)
+
+		favoritesCategory := initialCategories.Categories[0]
+		channelsCategory := initialCategories.Categories[1]
+		favoritesCategory.Channels = []string{model.NewId()}
+
+		_, _, err = ss.Channel().UpdateSidebarCategoriesAndOrder(userId, teamId, []*model.SidebarCategoryWithChannels{
+			favoritesCategory,
+		}, []string{channelsCategory.Id, favoritesCategory.Id})
+		require.Error(t, err)
+		var invErr *store.ErrInvalidInput
+		assert.ErrorAs(t, err, &invErr)
+
+		got, err := ss.Channel().GetSidebarCategories(userId, teamId)
+		require.NoError(t, err)
+		assert.Equal(t, initialCategories.Order, got.Order)
+		assert.Empty(t, got.Categories[0].Channels)
+	})
+}
+
 func testClearSidebarOnTeamLeave(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("should delete all sidebar categories and channels on the team", func(t *testing.T) {
 		userId, teamId := setupInitialSidebarCategories(t, ss)
@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestChannelTemplateStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testChannelTemplateStoreSaveGet(t, ss) })
+	t.Run("GetForTeam", func(t *testing.T) { testChannelTemplateStoreGetForTeam(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testChannelTemplateStoreDelete(t, ss) })
+}
+
+func testChannelTemplateStoreSaveGet(t *testing.T, ss store.Store) {
+	template := &model.ChannelTemplate{
+		TeamId:         model.NewId(),
+		Name:           "Incident",
+		Type:           model.ChannelTypePrivate,
+		Purpose:        "Coordinate the response to an incident",
+		Header:         "Runbook: https://example.com/runbook",
+		PinnedMessages: model.StringArray{"Status: investigating", "Owner: TBD"},
+		CreatorId:      model.NewId(),
+	}
+
+	saved, err := ss.ChannelTemplate().Save(template)
+	require.NoError(t, err)
+	require.NotEmpty(t, saved.Id)
+
+	t.Run("get", func(t *testing.T) {
+		got, err := ss.ChannelTemplate().Get(saved.Id)
+		require.NoError(t, err)
+		require.Equal(t, saved, got)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		_, err := ss.ChannelTemplate().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("save existing id", func(t *testing.T) {
+		_, err := ss.ChannelTemplate().Save(saved)
+		require.Error(t, err)
+	})
+
+	t.Run("save invalid", func(t *testing.T) {
+		_, err := ss.ChannelTemplate().Save(&model.ChannelTemplate{TeamId: model.NewId()})
+		require.Error(t, err)
+	})
+
+	t.Run("save without pinned messages", func(t *testing.T) {
+		saved, err := ss.ChannelTemplate().Save(&model.ChannelTemplate{
+			TeamId:    model.NewId(),
+			Name:      "Empty",
+			CreatorId: model.NewId(),
+		})
+		require.NoError(t, err)
+
+		got, err := ss.ChannelTemplate().Get(saved.Id)
+		require.NoError(t, err)
+		require.Equal(t, model.ChannelTypeOpen, got.Type)
+		require.Empty(t, got.PinnedMessages)
+	})
+}
+
+func testChannelTemplateStoreGetForTeam(t *testing.T, ss store.Store) {
+	teamID := model.NewId()
+
+	var templates []*model.ChannelTemplate
+	for _, name := range []string{"b", "a", "c"} {
+		template, err := ss.ChannelTemplate().Save(&model.ChannelTemplate{
+			TeamId:    teamID,
+			Name:      name,
+			CreatorId: model.NewId(),
+		})
+		require.NoError(t, err)
+		templates = append(templates, template)
+	}
+
+	_, err := ss.ChannelTemplate().Save(&model.ChannelTemplate{
+		TeamId:    model.NewId(),
+		Name:      "other team",
+		CreatorId: model.NewId(),
+	})
+	require.NoError(t, err)
+
+	got, err := ss.ChannelTemplate().GetForTeam(teamID)
+	require.NoError(t, err)
+	require.Equal(t, []*model.ChannelTemplate{templates[1], templates[0], templates[2]}, got)
+
+	got, err = ss.ChannelTemplate().GetForTeam(model.NewId())
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func testChannelTemplateStoreDelete(t *testing.T, ss store.Store) {
+	template, err := ss.ChannelTemplate().Save(&model.ChannelTemplate{
+		TeamId:    model.NewId(),
+		Name:      "Delete me",
+		CreatorId: model.NewId(),
+	})
+	require.NoError(t, err)
+
+	err = ss.ChannelTemplate().Delete(template.Id)
+	require.NoError(t, err)
+
+	var nfErr *store.ErrNotFound
+	_, err = ss.ChannelTemplate().Get(template.Id)
+	require.True(t, errors.As(err, &nfErr))
+
+	err = ss.ChannelTemplate().Delete(template.Id)
+	require.True(t, errors.As(err, &nfErr))
+}
@@ -22,6 +22,7 @@ func TestEmojiStore(t *testing.T, ss store.Store) {
 	t.Run("EmojiGetMultipleByName", func(t *testing.T) { testEmojiGetMultipleByName(t, ss) })
 	t.Run("EmojiGetList", func(t *testing.T) { testEmojiGetList(t, ss) })
 	t.Run("EmojiSearch", func(t *testing.T) { testEmojiSearch(t, ss) })
+	t.Run("EmojiGetUnusedCustomEmoji", func(t *testing.T) { testEmojiGetUnusedCustomEmoji(t, ss) })
 }
 
 func testEmojiSaveDelete(t *testing.T, ss store.Store) {
@@ -303,3 +304,87 @@ func testEmojiSearch(t *testing.T, ss store.Store) {
 		assert.Equal(t, shouldFind[i], found, emoji.Name)
 	}
 }
+
+func testEmojiGetUnusedCustomEmoji(t *testing.T, ss store.Store) {
+	emojis := []model.Emoji{
+		{
+			CreatorId: model.NewId(),
+			Name:      "unused" + model.NewId(),
+		},
+		{
+			CreatorId: model.NewId(),
+			Name:      "reacted" + model.NewId(),
+		},
+		{
+			CreatorId: model.NewId(),
+			Name:      "inmessage" + model.NewId(),
+		},
+		{
+			CreatorId: model.NewId(),
+			Name:      "inoldmessage" + model.NewId(),
+		},
+	}
+
+	for i, emoji := range emojis {
+		data, err := ss.Emoji().Save(&emoji)
+		require.NoError(t, err)
+		emojis[i] = *data
+	}
+	defer func() {
+		for _, emoji := range emojis {
+			err := ss.Emoji().Delete(&emoji, time.Now().Unix())
+			require.NoError(t, err)
+		}
+	}()
+
+	now := model.GetMillis()
+	since := now - 1000*60*60
+
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "message",
+	})
+	require.NoError(t, err)
+
+	_, err = ss.Reaction().Save(&model.Reaction{
+		UserId:    model.NewId(),
+		PostId:    post.Id,
+		EmojiName: emojis[1].Name,
+	})
+	require.NoError(t, err)
+
+	_, err = ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "look at this :" + emojis[2].Name + ": emoji",
+	})
+	require.NoError(t, err)
+
+	_, err = ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		CreateAt:  since - 1000,
+		Message:   ":" + emojis[3].Name + ":",
+	})
+	require.NoError(t, err)
+
+	result, err := ss.Emoji().GetUnusedCustomEmoji(since, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, since, result.MessagesScannedSince)
+
+	// Emoji only used in messages older than the scanned window are reported as unused.
+	shouldFind := []bool{true, false, false, true}
+	for i, emoji := range emojis {
+		found := false
+
+		for _, unusedEmoji := range result.Emoji {
+			if emoji.Id == unusedEmoji.Id {
+				found = true
+				break
+			}
+		}
+
+		assert.Equal(t, shouldFind[i], found, emoji.Name)
+	}
+}
@@ -20,6 +20,7 @@ func TestFileInfoStore(t *testing.T, ss store.Store) {
 	t.Run("FileInfoSaveGet", func(t *testing.T) { testFileInfoSaveGet(t, ss) })
 	t.Run("FileInfoSaveGetByPath", func(t *testing.T) { testFileInfoSaveGetByPath(t, ss) })
 	t.Run("FileInfoGetForPost", func(t *testing.T) { testFileInfoGetForPost(t, ss) })
+	t.Run("FileInfoGetForPosts", func(t *testing.T) { testFileInfoGetForPosts(t, ss) })
 	t.Run("FileInfoGetForUser", func(t *testing.T) { testFileInfoGetForUser(t, ss) })
 	t.Run("FileInfoGetWithOptions", func(t *testing.T) { testFileInfoGetWithOptions(t, ss) })
 	t.Run("FileInfoAttachToPost", func(t *testing.T) { testFileInfoAttachToPost(t, ss) })
@@ -30,6 +31,7 @@ func TestFileInfoStore(t *testing.T, ss store.Store) {
 	t.Run("GetFilesBatchForIndexing", func(t *testing.T) { testFileInfoStoreGetFilesBatchForIndexing(t, ss) })
 	t.Run("CountAll", func(t *testing.T) { testFileInfoStoreCountAll(t, ss) })
 	t.Run("GetStorageUsage", func(t *testing.T) { testFileInfoGetStorageUsage(t, ss) })
+	t.Run("SetQuarantined", func(t *testing.T) { testFileInfoSetQuarantined(t, ss) })
 }
 
 func testFileInfoSaveGet(t *testing.T, ss store.Store) {
@@ -215,6 +217,64 @@ func testFileInfoGetForPost(t *testing.T, ss store.Store) {
 	}
 }
 
+func testFileInfoGetForPosts(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	post1Id := model.NewId()
+	post2Id := model.NewId()
+	postWithoutFilesId := model.NewId()
+	otherPostId := model.NewId()
+
+	save := func(postId string, createAt, deleteAt int64) *model.FileInfo {
+		info, err := ss.FileInfo().Save(&model.FileInfo{
+			PostId:    postId,
+			CreatorId: userId,
+			Path:      "file.txt",
+			CreateAt:  createAt,
+			DeleteAt:  deleteAt,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { ss.FileInfo().PermanentDelete(info.Id) })
+		return info
+	}
+
+	post1Second := save(post1Id, 2000, 0)
+	post1First := save(post1Id, 1000, 0)
+	post2File := save(post2Id, 1000, 0)
+	save(post2Id, 2000, 123)
+	save(otherPostId, 1000, 0)
+
+	t.Run("several posts", func(t *testing.T) {
+		infos, err := ss.FileInfo().GetForPosts([]string{post1Id, post2Id, postWithoutFilesId})
+		require.NoError(t, err)
+		require.Len(t, infos, 2)
+
+		ids := func(infos []*model.FileInfo) []string {
+			ids := []string{}
+			for _, info := range infos {
+				ids = append(ids, info.Id)
+			}
+			return ids
+		}
+
+		assert.Equal(t, []string{post1First.Id, post1Second.Id}, ids(infos[post1Id]))
+		assert.Equal(t, []string{post2File.Id}, ids(infos[post2Id]))
+		assert.NotContains(t, infos, postWithoutFilesId)
+		assert.NotContains(t, infos, otherPostId)
+	})
+
+	t.Run("only posts without files", func(t *testing.T) {
+		infos, err := ss.FileInfo().GetForPosts([]string{postWithoutFilesId})
+		require.NoError(t, err)
+		assert.Empty(t, infos)
+	})
+
+	t.Run("no posts", func(t *testing.T) {
+		infos, err := ss.FileInfo().GetForPosts([]string{})
+		require.NoError(t, err)
+		assert.Empty(t, infos)
+	})
+}
+
 func testFileInfoGetForUser(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 	userId2 := model.NewId()
@@ -772,3 +832,25 @@ func testFileInfoGetStorageUsage(t *testing.T, ss store.Store) {
 	require.NoError(t, err)
 	require.Equal(t, int64(30), usage)
 }
+
+func testFileInfoSetQuarantined(t *testing.T, ss store.Store) {
+	info, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId:   model.NewId(),
+		Path:        "file.txt",
+		Quarantined: true,
+	})
+	require.NoError(t, err)
+	defer ss.FileInfo().PermanentDelete(info.Id)
+
+	rinfo, err := ss.FileInfo().Get(info.Id)
+	require.NoError(t, err)
+	assert.True(t, rinfo.Quarantined)
+
+	err = ss.FileInfo().SetQuarantined(info.Id, false)
+	require.NoError(t, err)
+
+	rinfo, err = ss.FileInfo().Get(info.Id)
+	require.NoError(t, err)
+	assert.False(t, rinfo.Quarantined)
+	assert.Greater(t, rinfo.UpdateAt, info.UpdateAt-1)
+}
@@ -73,6 +73,7 @@ func TestGroupStore(t *testing.T, ss store.Store) {
 	t.Run("ChannelMembersMinusGroupMembers", func(t *testing.T) { testChannelMembersMinusGroupMembers(t, ss) })
 
 	t.Run("GetMemberCount", func(t *testing.T) { groupTestGetMemberCount(t, ss) })
+	t.Run("GetChannelMemberCountsByGroup", func(t *testing.T) { groupTestGetChannelMemberCountsByGroup(t, ss) })
 
 	t.Run("AdminRoleGroupsForSyncableMember_Channel", func(t *testing.T) { groupTestAdminRoleGroupsForSyncableMemberChannel(t, ss) })
 	t.Run("AdminRoleGroupsForSyncableMember_Team", func(t *testing.T) { groupTestAdminRoleGroupsForSyncableMemberTeam(t, ss) })
@@ -4132,6 +4133,106 @@ func groupTestGetMemberCount(t *testing.T, ss store.Store) {
 	require.Equal(t, int64(1), count)
 }
 
+func groupTestGetChannelMemberCountsByGroup(t *testing.T, ss store.Store) {
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "A Name",
+		Name:        NewTestId(),
+		Type:        model.ChannelTypePrivate,
+	}, 9999)
+	require.NoError(t, err)
+
+	var groups []*model.Group
+	for i := 0; i < 4; i++ {
+		group, err := ss.Group().Create(&model.Group{
+			Name:        model.NewString(model.NewId()),
+			DisplayName: model.NewId(),
+			Source:      model.GroupSourceLdap,
+			RemoteId:    model.NewString(model.NewId()),
+		})
+		require.NoError(t, err)
+		groups = append(groups, group)
+	}
+
+	t.Run("empty slice for channel with no linked groups", func(t *testing.T) {
+		counts, err := ss.Group().GetChannelMemberCountsByGroup(channel.Id)
+		require.NoError(t, err)
+		require.Equal(t, []*model.ChannelMemberCountByGroup{}, counts)
+	})
+
+	// groups[0], groups[1] and groups[2] are linked to the channel, groups[3] isn't.
+	for _, group := range groups[:3] {
+		_, err = ss.Group().CreateGroupSyncable(model.NewGroupChannel(group.Id, channel.Id, false))
+		require.NoError(t, err)
+	}
+
+	var users []*model.User
+	for i := 0; i < 4; i++ {
+		user, err := ss.User().Save(&model.User{
+			Email:    MakeEmail(),
+			Username: model.NewId(),
+		})
+		require.NoError(t, err)
+		users = append(users, user)
+	}
+
+	// users[0..2] are channel members, users[3] isn't.
+	for _, user := range users[:3] {
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      user.Id,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, err)
+	}
+
+	// users[0] and users[1] are in both groups[0] and groups[1], users[2] only in groups[1],
+	// users[3] is in groups[0] and in the unlinked groups[3]. groups[2] has no members.
+	_, err = ss.Group().UpsertMembers(groups[0].Id, []string{users[0].Id, users[1].Id, users[3].Id})
+	require.NoError(t, err)
+	_, err = ss.Group().UpsertMembers(groups[1].Id, []string{users[0].Id, users[1].Id, users[2].Id})
+	require.NoError(t, err)
+	_, err = ss.Group().UpsertMembers(groups[3].Id, []string{users[0].Id, users[3].Id})
+	require.NoError(t, err)
+
+	countsByGroup := func(t *testing.T) map[string]int64 {
+		t.Helper()
+		counts, err := ss.Group().GetChannelMemberCountsByGroup(channel.Id)
+		require.NoError(t, err)
+
+		m := make(map[string]int64, len(counts))
+		for _, c := range counts {
+			m[c.GroupId] = c.ChannelMemberCount
+		}
+		require.Len(t, m, len(counts))
+		return m
+	}
+
+	t.Run("counts only channel members of linked groups", func(t *testing.T) {
+		require.Equal(t, map[string]int64{
+			groups[0].Id: 2,
+			groups[1].Id: 3,
+			groups[2].Id: 0,
+		}, countsByGroup(t))
+	})
+
+	t.Run("ignores deleted group members", func(t *testing.T) {
+		_, err = ss.Group().DeleteMember(groups[1].Id, users[2].Id)
+		require.NoError(t, err)
+
+		require.Equal(t, int64(2), countsByGroup(t)[groups[1].Id])
+	})
+
+	t.Run("ignores unlinked groups", func(t *testing.T) {
+		_, err = ss.Group().DeleteGroupSyncable(groups[0].Id, channel.Id, model.GroupSyncableTypeChannel)
+		require.NoError(t, err)
+
+		counts := countsByGroup(t)
+		require.NotContains(t, counts, groups[0].Id)
+		require.Len(t, counts, 2)
+	})
+}
+
 func groupTestAdminRoleGroupsForSyncableMemberChannel(t *testing.T, ss store.Store) {
 	user := &model.User{
 		Email:    MakeEmail(),
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestGuestChannelInviteStore(t *testing.T, ss store.Store) {
+	t.Run("SaveExists", func(t *testing.T) { testGuestChannelInviteStoreSaveExists(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testGuestChannelInviteStoreDelete(t, ss) })
+	t.Run("Cleanup", func(t *testing.T) { testGuestChannelInviteStoreCleanup(t, ss) })
+}
+
+func testGuestChannelInviteStoreSaveExists(t *testing.T, ss store.Store) {
+	invite := &model.GuestChannelInvite{
+		UserId:    model.NewId(),
+		ChannelId: model.NewId(),
+	}
+
+	exists, err := ss.GuestChannelInvite().Exists(invite.UserId, invite.ChannelId)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	err = ss.GuestChannelInvite().Save(invite)
+	require.NoError(t, err)
+
+	exists, err = ss.GuestChannelInvite().Exists(invite.UserId, invite.ChannelId)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	t.Run("other channel", func(t *testing.T) {
+		exists, err := ss.GuestChannelInvite().Exists(invite.UserId, model.NewId())
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		exists, err := ss.GuestChannelInvite().Exists(model.NewId(), invite.ChannelId)
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+
+	t.Run("save again", func(t *testing.T) {
+		err := ss.GuestChannelInvite().Save(&model.GuestChannelInvite{
+			UserId:    invite.UserId,
+			ChannelId: invite.ChannelId,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("save invalid", func(t *testing.T) {
+		err := ss.GuestChannelInvite().Save(&model.GuestChannelInvite{UserId: model.NewId()})
+		require.Error(t, err)
+	})
+}
+
+func testGuestChannelInviteStoreDelete(t *testing.T, ss store.Store) {
+	invite := &model.GuestChannelInvite{
+		UserId:    model.NewId(),
+		ChannelId: model.NewId(),
+	}
+	require.NoError(t, ss.GuestChannelInvite().Save(invite))
+
+	err := ss.GuestChannelInvite().Delete(invite.UserId, invite.ChannelId)
+	require.NoError(t, err)
+
+	exists, err := ss.GuestChannelInvite().Exists(invite.UserId, invite.ChannelId)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	t.Run("missing invite", func(t *testing.T) {
+		err := ss.GuestChannelInvite().Delete(invite.UserId, invite.ChannelId)
+		require.NoError(t, err)
+	})
+}
+
+func testGuestChannelInviteStoreCleanup(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	expired := &model.GuestChannelInvite{
+		UserId:    model.NewId(),
+		ChannelId: model.NewId(),
+		CreateAt:  now - 2000,
+	}
+	require.NoError(t, ss.GuestChannelInvite().Save(expired))
+
+	current := &model.GuestChannelInvite{
+		UserId:    model.NewId(),
+		ChannelId: model.NewId(),
+		CreateAt:  now,
+	}
+	require.NoError(t, ss.GuestChannelInvite().Save(current))
+
+	err := ss.GuestChannelInvite().Cleanup(now - 1000)
+	require.NoError(t, err)
+
+	exists, err := ss.GuestChannelInvite().Exists(expired.UserId, expired.ChannelId)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	exists, err = ss.GuestChannelInvite().Exists(current.UserId, current.ChannelId)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
@@ -35,6 +35,29 @@ func (_m *ChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int64, error
 	return r0, r1
 }
 
+// GetChannelMemberHistory provides a mock function with given fields: channelID, from, to
+func (_m *ChannelMemberHistoryStore) GetChannelMemberHistory(channelID string, from int64, to int64) ([]*model.ChannelMemberHistory, error) {
+	ret := _m.Called(channelID, from, to)
+
+	var r0 []*model.ChannelMemberHistory
+	if rf, ok := ret.Get(0).(func(string, int64, int64) []*model.ChannelMemberHistory); ok {
+		r0 = rf(channelID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMemberHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int64) error); ok {
+		r1 = rf(channelID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannelsLeftSince provides a mock function with given fields: userID, since
 func (_m *ChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 	ret := _m.Called(userID, since)
@@ -81,6 +104,29 @@ func (_m *ChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, en
 	return r0, r1
 }
 
+// GetUsersLeftSince provides a mock function with given fields: channelID, since, offset, limit
+func (_m *ChannelMemberHistoryStore) GetUsersLeftSince(channelID string, since int64, offset int, limit int) ([]*model.ChannelMemberHistory, error) {
+	ret := _m.Called(channelID, since, offset, limit)
+
+	var r0 []*model.ChannelMemberHistory
+	if rf, ok := ret.Get(0).(func(string, int64, int, int) []*model.ChannelMemberHistory); ok {
+		r0 = rf(channelID, since, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMemberHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int, int) error); ok {
+		r1 = rf(channelID, since, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LogJoinEvent provides a mock function with given fields: userID, channelID, joinTime
 func (_m *ChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
 	ret := _m.Called(userID, channelID, joinTime)
@@ -584,6 +584,29 @@ func (_m *ChannelStore) GetByNames(team_id string, names []string, allowFromCach
 	return r0, r1
 }
 
+// GetByNamesIncludeDeleted provides a mock function with given fields: team_id, names, allowFromCache
+func (_m *ChannelStore) GetByNamesIncludeDeleted(team_id string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+	ret := _m.Called(team_id, names, allowFromCache)
+
+	var r0 []*model.Channel
+	if rf, ok := ret.Get(0).(func(string, []string, bool) []*model.Channel); ok {
+		r0 = rf(team_id, names, allowFromCache)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Channel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string, bool) error); ok {
+		r1 = rf(team_id, names, allowFromCache)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannelCounts provides a mock function with given fields: teamID, userID
 func (_m *ChannelStore) GetChannelCounts(teamID string, userID string) (*model.ChannelCounts, error) {
 	ret := _m.Called(teamID, userID)
@@ -653,6 +676,29 @@ func (_m *ChannelStore) GetChannelMembersTimezones(channelID string) ([]model.St
 	return r0, r1
 }
 
+// GetChannelMembersWithTeamData provides a mock function with given fields: channelID, offset, limit
+func (_m *ChannelStore) GetChannelMembersWithTeamData(channelID string, offset int, limit int) ([]*model.ChannelMemberWithTeamMember, error) {
+	ret := _m.Called(channelID, offset, limit)
+
+	var r0 []*model.ChannelMemberWithTeamMember
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.ChannelMemberWithTeamMember); ok {
+		r0 = rf(channelID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMemberWithTeamMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(channelID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannelUnread provides a mock function with given fields: channelID, userID
 func (_m *ChannelStore) GetChannelUnread(channelID string, userID string) (*model.ChannelUnread, error) {
 	ret := _m.Called(channelID, userID)
@@ -676,6 +722,29 @@ func (_m *ChannelStore) GetChannelUnread(channelID string, userID string) (*mode
 	return r0, r1
 }
 
+// GetChannelUnreadCountsForUser provides a mock function with given fields: userID, teamID
+func (_m *ChannelStore) GetChannelUnreadCountsForUser(userID string, teamID string) ([]*model.ChannelUnreadCount, error) {
+	ret := _m.Called(userID, teamID)
+
+	var r0 []*model.ChannelUnreadCount
+	if rf, ok := ret.Get(0).(func(string, string) []*model.ChannelUnreadCount); ok {
+		r0 = rf(userID, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelUnreadCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannels provides a mock function with given fields: teamID, userID, opts
 func (_m *ChannelStore) GetChannels(teamID string, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, error) {
 	ret := _m.Called(teamID, userID, opts)
@@ -791,6 +860,29 @@ func (_m *ChannelStore) GetChannelsByUser(userID string, includeDeleted bool, la
 	return r0, r1
 }
 
+// GetChannelsDeletedBefore provides a mock function with given fields: deletedBefore, afterChannelID, limit
+func (_m *ChannelStore) GetChannelsDeletedBefore(deletedBefore int64, afterChannelID string, limit int) (model.ChannelList, error) {
+	ret := _m.Called(deletedBefore, afterChannelID, limit)
+
+	var r0 model.ChannelList
+	if rf, ok := ret.Get(0).(func(int64, string, int) model.ChannelList); ok {
+		r0 = rf(deletedBefore, afterChannelID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.ChannelList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, int) error); ok {
+		r1 = rf(deletedBefore, afterChannelID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannelsWithCursor provides a mock function with given fields: teamId, userId, opts, afterChannelID
 func (_m *ChannelStore) GetChannelsWithCursor(teamId string, userId string, opts *model.ChannelSearchOpts, afterChannelID string) (model.ChannelList, error) {
 	ret := _m.Called(teamId, userId, opts, afterChannelID)
@@ -837,6 +929,29 @@ func (_m *ChannelStore) GetChannelsWithTeamDataByIds(channelIds []string, includ
 	return r0, r1
 }
 
+// GetCreationInfo provides a mock function with given fields: teamID, offset, limit
+func (_m *ChannelStore) GetCreationInfo(teamID string, offset int, limit int) ([]*model.ChannelCreationInfo, error) {
+	ret := _m.Called(teamID, offset, limit)
+
+	var r0 []*model.ChannelCreationInfo
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.ChannelCreationInfo); ok {
+		r0 = rf(teamID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelCreationInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(teamID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeleted provides a mock function with given fields: team_id, offset, limit, userID
 func (_m *ChannelStore) GetDeleted(team_id string, offset int, limit int, userID string) (model.ChannelList, error) {
 	ret := _m.Called(team_id, offset, limit, userID)
@@ -883,6 +998,52 @@ func (_m *ChannelStore) GetDeletedByName(team_id string, name string) (*model.Ch
 	return r0, r1
 }
 
+// GetDirectChannelsForUsers provides a mock function with given fields: userA, userB
+func (_m *ChannelStore) GetDirectChannelsForUsers(userA string, userB string) ([]*model.Channel, error) {
+	ret := _m.Called(userA, userB)
+
+	var r0 []*model.Channel
+	if rf, ok := ret.Get(0).(func(string, string) []*model.Channel); ok {
+		r0 = rf(userA, userB)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Channel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userA, userB)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuplicateDirectChannels provides a mock function with given fields:
+func (_m *ChannelStore) GetDuplicateDirectChannels() ([]*model.DuplicateDirectChannels, error) {
+	ret := _m.Called()
+
+	var r0 []*model.DuplicateDirectChannels
+	if rf, ok := ret.Get(0).(func() []*model.DuplicateDirectChannels); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.DuplicateDirectChannels)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFileCount provides a mock function with given fields: channelID
 func (_m *ChannelStore) GetFileCount(channelID string) (int64, error) {
 	ret := _m.Called(channelID)
@@ -1052,6 +1213,29 @@ func (_m *ChannelStore) GetMemberCountsByGroup(ctx context.Context, channelID st
 	return r0, r1
 }
 
+// GetMemberCountsByType provides a mock function with given fields: channelID
+func (_m *ChannelStore) GetMemberCountsByType(channelID string) (*model.ChannelMemberCountsByType, error) {
+	ret := _m.Called(channelID)
+
+	var r0 *model.ChannelMemberCountsByType
+	if rf, ok := ret.Get(0).(func(string) *model.ChannelMemberCountsByType); ok {
+		r0 = rf(channelID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelMemberCountsByType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMemberForPost provides a mock function with given fields: postID, userID
 func (_m *ChannelStore) GetMemberForPost(postID string, userID string) (*model.ChannelMember, error) {
 	ret := _m.Called(postID, userID)
@@ -1236,6 +1420,52 @@ func (_m *ChannelStore) GetMembersInfoByChannelIds(channelIDs []string) (map[str
 	return r0, r1
 }
 
+// GetMembersNotInTeam provides a mock function with given fields: afterChannelID, afterUserID, limit
+func (_m *ChannelStore) GetMembersNotInTeam(afterChannelID string, afterUserID string, limit int) (model.ChannelMembers, error) {
+	ret := _m.Called(afterChannelID, afterUserID, limit)
+
+	var r0 model.ChannelMembers
+	if rf, ok := ret.Get(0).(func(string, string, int) model.ChannelMembers); ok {
+		r0 = rf(afterChannelID, afterUserID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.ChannelMembers)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(afterChannelID, afterUserID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembersUpdatedSince provides a mock function with given fields: channelID, since, offset, limit
+func (_m *ChannelStore) GetMembersUpdatedSince(channelID string, since int64, offset int, limit int) (model.ChannelMembers, error) {
+	ret := _m.Called(channelID, since, offset, limit)
+
+	var r0 model.ChannelMembers
+	if rf, ok := ret.Get(0).(func(string, int64, int, int) model.ChannelMembers); ok {
+		r0 = rf(channelID, since, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.ChannelMembers)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int, int) error); ok {
+		r1 = rf(channelID, since, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMoreChannels provides a mock function with given fields: teamID, userID, offset, limit
 func (_m *ChannelStore) GetMoreChannels(teamID string, userID string, offset int, limit int) (model.ChannelList, error) {
 	ret := _m.Called(teamID, userID, offset, limit)
@@ -1441,6 +1671,27 @@ func (_m *ChannelStore) GetSidebarCategoryOrder(userID string, teamID string) ([
 	return r0, r1
 }
 
+// GetTeamChannelCountForUser provides a mock function with given fields: teamID, userID
+func (_m *ChannelStore) GetTeamChannelCountForUser(teamID string, userID string) (int64, error) {
+	ret := _m.Called(teamID, userID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
+		r0 = rf(teamID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(teamID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTeamChannels provides a mock function with given fields: teamID
 func (_m *ChannelStore) GetTeamChannels(teamID string) (model.ChannelList, error) {
 	ret := _m.Called(teamID)
@@ -1640,6 +1891,29 @@ func (_m *ChannelStore) IsUserInChannelUseCache(userID string, channelID string)
 	return r0
 }
 
+// MergeDirectChannels provides a mock function with given fields: canonicalID, duplicateIDs, deleteAt
+func (_m *ChannelStore) MergeDirectChannels(canonicalID string, duplicateIDs []string, deleteAt int64) ([]string, error) {
+	ret := _m.Called(canonicalID, duplicateIDs, deleteAt)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, []string, int64) []string); ok {
+		r0 = rf(canonicalID, duplicateIDs, deleteAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string, int64) error); ok {
+		r1 = rf(canonicalID, duplicateIDs, deleteAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MigrateChannelMembers provides a mock function with given fields: fromChannelID, fromUserID
 func (_m *ChannelStore) MigrateChannelMembers(fromChannelID string, fromUserID string) (map[string]string, error) {
 	ret := _m.Called(fromChannelID, fromUserID)
@@ -1957,6 +2231,29 @@ func (_m *ChannelStore) SearchArchivedInTeam(teamID string, term string, userID
 	return r0, r1
 }
 
+// SearchByPurpose provides a mock function with given fields: teamID, term, page, perPage
+func (_m *ChannelStore) SearchByPurpose(teamID string, term string, page int, perPage int) (model.ChannelList, error) {
+	ret := _m.Called(teamID, term, page, perPage)
+
+	var r0 model.ChannelList
+	if rf, ok := ret.Get(0).(func(string, string, int, int) model.ChannelList); ok {
+		r0 = rf(teamID, term, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.ChannelList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int, int) error); ok {
+		r1 = rf(teamID, term, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SearchForUserInTeam provides a mock function with given fields: userID, teamID, term, includeDeleted
 func (_m *ChannelStore) SearchForUserInTeam(userID string, teamID string, term string, includeDeleted bool) (model.ChannelList, error) {
 	ret := _m.Called(userID, teamID, term, includeDeleted)
@@ -2026,6 +2323,29 @@ func (_m *ChannelStore) SearchInTeam(teamID string, term string, includeDeleted
 	return r0, r1
 }
 
+// SearchMembersForUser provides a mock function with given fields: userID, term, includePrivate, page, perPage
+func (_m *ChannelStore) SearchMembersForUser(userID string, term string, includePrivate bool, page int, perPage int) (model.ChannelMembersWithTeamData, error) {
+	ret := _m.Called(userID, term, includePrivate, page, perPage)
+
+	var r0 model.ChannelMembersWithTeamData
+	if rf, ok := ret.Get(0).(func(string, string, bool, int, int) model.ChannelMembersWithTeamData); ok {
+		r0 = rf(userID, term, includePrivate, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.ChannelMembersWithTeamData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, bool, int, int) error); ok {
+		r1 = rf(userID, term, includePrivate, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SearchMore provides a mock function with given fields: userID, teamID, term
 func (_m *ChannelStore) SearchMore(userID string, teamID string, term string) (model.ChannelList, error) {
 	ret := _m.Called(userID, teamID, term)
@@ -2261,6 +2581,38 @@ func (_m *ChannelStore) UpdateSidebarCategories(userID string, teamID string, ca
 	return r0, r1, r2
 }
 
+// UpdateSidebarCategoriesAndOrder provides a mock function with given fields: userID, teamID, categories, categoryOrder
+func (_m *ChannelStore) UpdateSidebarCategoriesAndOrder(userID string, teamID string, categories []*model.SidebarCategoryWithChannels, categoryOrder []string) ([]*model.SidebarCategoryWithChannels, []*model.SidebarCategoryWithChannels, error) {
+	ret := _m.Called(userID, teamID, categories, categoryOrder)
+
+	var r0 []*model.SidebarCategoryWithChannels
+	if rf, ok := ret.Get(0).(func(string, string, []*model.SidebarCategoryWithChannels, []string) []*model.SidebarCategoryWithChannels); ok {
+		r0 = rf(userID, teamID, categories, categoryOrder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SidebarCategoryWithChannels)
+		}
+	}
+
+	var r1 []*model.SidebarCategoryWithChannels
+	if rf, ok := ret.Get(1).(func(string, string, []*model.SidebarCategoryWithChannels, []string) []*model.SidebarCategoryWithChannels); ok {
+		r1 = rf(userID, teamID, categories, categoryOrder)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*model.SidebarCategoryWithChannels)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, []*model.SidebarCategoryWithChannels, []string) error); ok {
+		r2 = rf(userID, teamID, categories, categoryOrder)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateSidebarCategoryOrder provides a mock function with given fields: userID, teamID, categoryOrder
 func (_m *ChannelStore) UpdateSidebarCategoryOrder(userID string, teamID string, categoryOrder []string) error {
 	ret := _m.Called(userID, teamID, categoryOrder)
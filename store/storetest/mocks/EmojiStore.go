@@ -122,6 +122,29 @@ func (_m *EmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error)
 	return r0, r1
 }
 
+// GetUnusedCustomEmoji provides a mock function with given fields: messagesSince, limit
+func (_m *EmojiStore) GetUnusedCustomEmoji(messagesSince int64, limit int) (*model.UnusedEmojiList, error) {
+	ret := _m.Called(messagesSince, limit)
+
+	var r0 *model.UnusedEmojiList
+	if rf, ok := ret.Get(0).(func(int64, int) *model.UnusedEmojiList); ok {
+		r0 = rf(messagesSince, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UnusedEmojiList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(messagesSince, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: emoji
 func (_m *EmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	ret := _m.Called(emoji)
@@ -190,6 +190,29 @@ func (_m *FileInfoStore) GetForPost(postID string, readFromMaster bool, includeD
 	return r0, r1
 }
 
+// GetForPosts provides a mock function with given fields: postIds
+func (_m *FileInfoStore) GetForPosts(postIds []string) (map[string][]*model.FileInfo, error) {
+	ret := _m.Called(postIds)
+
+	var r0 map[string][]*model.FileInfo
+	if rf, ok := ret.Get(0).(func([]string) map[string][]*model.FileInfo); ok {
+		r0 = rf(postIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]*model.FileInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(postIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetForUser provides a mock function with given fields: userID
 func (_m *FileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
 	ret := _m.Called(userID)
@@ -401,6 +424,20 @@ func (_m *FileInfoStore) SetContent(fileID string, content string) error {
 	return r0
 }
 
+// SetQuarantined provides a mock function with given fields: fileID, quarantined
+func (_m *FileInfoStore) SetQuarantined(fileID string, quarantined bool) error {
+	ret := _m.Called(fileID, quarantined)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(fileID, quarantined)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Upsert provides a mock function with given fields: info
 func (_m *FileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
 	ret := _m.Called(info)
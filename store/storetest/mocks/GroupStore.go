@@ -554,6 +554,29 @@ func (_m *GroupStore) GetByUser(userID string) ([]*model.Group, error) {
 	return r0, r1
 }
 
+// GetChannelMemberCountsByGroup provides a mock function with given fields: channelID
+func (_m *GroupStore) GetChannelMemberCountsByGroup(channelID string) ([]*model.ChannelMemberCountByGroup, error) {
+	ret := _m.Called(channelID)
+
+	var r0 []*model.ChannelMemberCountByGroup
+	if rf, ok := ret.Get(0).(func(string) []*model.ChannelMemberCountByGroup); ok {
+		r0 = rf(channelID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMemberCountByGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetGroupSyncable provides a mock function with given fields: groupID, syncableID, syncableType
 func (_m *GroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
 	ret := _m.Called(groupID, syncableID, syncableType)
@@ -16,6 +16,27 @@ type PostStore struct {
 	mock.Mock
 }
 
+// AnalyticsBotPostCount provides a mock function with given fields: teamID, since
+func (_m *PostStore) AnalyticsBotPostCount(teamID string, since int64) (int64, error) {
+	ret := _m.Called(teamID, since)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, int64) int64); ok {
+		r0 = rf(teamID, since)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(teamID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AnalyticsPostCount provides a mock function with given fields: options
 func (_m *PostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
 	ret := _m.Called(options)
@@ -169,6 +190,29 @@ func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID strin
 	return r0, r1
 }
 
+// GetEarliestPostTimeForChannels provides a mock function with given fields: channelIDs, excludeSystemPosts
+func (_m *PostStore) GetEarliestPostTimeForChannels(channelIDs []string, excludeSystemPosts bool) (map[string]int64, error) {
+	ret := _m.Called(channelIDs, excludeSystemPosts)
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func([]string, bool) map[string]int64); ok {
+		r0 = rf(channelIDs, excludeSystemPosts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string, bool) error); ok {
+		r1 = rf(channelIDs, excludeSystemPosts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEtag provides a mock function with given fields: channelID, allowFromCache, collapsedThreads
 func (_m *PostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
 	ret := _m.Called(channelID, allowFromCache, collapsedThreads)
@@ -252,6 +296,50 @@ func (_m *PostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset
 	return r0, r1
 }
 
+// GetFlaggedPostsForUserPaged provides a mock function with given fields: userID, options
+func (_m *PostStore) GetFlaggedPostsForUserPaged(userID string, options model.GetFlaggedPostsOptions) (*model.PostList, error) {
+	ret := _m.Called(userID, options)
+
+	var r0 *model.PostList
+	if rf, ok := ret.Get(0).(func(string, model.GetFlaggedPostsOptions) *model.PostList); ok {
+		r0 = rf(userID, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, model.GetFlaggedPostsOptions) error); ok {
+		r1 = rf(userID, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastPostCreateAtForUser provides a mock function with given fields: channelID, userID
+func (_m *PostStore) GetLastPostCreateAtForUser(channelID string, userID string) (int64, error) {
+	ret := _m.Called(channelID, userID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
+		r0 = rf(channelID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(channelID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLastPostRowCreateAt provides a mock function with given fields:
 func (_m *PostStore) GetLastPostRowCreateAt() (int64, error) {
 	ret := _m.Called()
@@ -465,6 +553,29 @@ func (_m *PostStore) GetPostsAfter(options model.GetPostsOptions, sanitizeOption
 	return r0, r1
 }
 
+// GetPostsAround provides a mock function with given fields: postID, before, after
+func (_m *PostStore) GetPostsAround(postID string, before int, after int) (*model.PostList, error) {
+	ret := _m.Called(postID, before, after)
+
+	var r0 *model.PostList
+	if rf, ok := ret.Get(0).(func(string, int, int) *model.PostList); ok {
+		r0 = rf(postID, before, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(postID, before, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPostsBatchForIndexing provides a mock function with given fields: startTime, startPostID, limit
 func (_m *PostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
 	ret := _m.Called(startTime, startPostID, limit)
@@ -511,6 +622,52 @@ func (_m *PostStore) GetPostsBefore(options model.GetPostsOptions, sanitizeOptio
 	return r0, r1
 }
 
+// GetPostsBetween provides a mock function with given fields: channelID, from, to, page, perPage
+func (_m *PostStore) GetPostsBetween(channelID string, from int64, to int64, page int, perPage int) ([]*model.Post, error) {
+	ret := _m.Called(channelID, from, to, page, perPage)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(string, int64, int64, int, int) []*model.Post); ok {
+		r0 = rf(channelID, from, to, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int64, int, int) error); ok {
+		r1 = rf(channelID, from, to, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostsByHashtag provides a mock function with given fields: teamID, hashtag, channelIDs, page, perPage
+func (_m *PostStore) GetPostsByHashtag(teamID string, hashtag string, channelIDs []string, page int, perPage int) (*model.PostList, error) {
+	ret := _m.Called(teamID, hashtag, channelIDs, page, perPage)
+
+	var r0 *model.PostList
+	if rf, ok := ret.Get(0).(func(string, string, []string, int, int) *model.PostList); ok {
+		r0 = rf(teamID, hashtag, channelIDs, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []string, int, int) error); ok {
+		r1 = rf(teamID, hashtag, channelIDs, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPostsByIds provides a mock function with given fields: postIds
 func (_m *PostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 	ret := _m.Called(postIds)
@@ -610,6 +767,66 @@ func (_m *PostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOpti
 	return r0, r1, r2
 }
 
+// GetPostsUpdatedSince provides a mock function with given fields: channelID, cursor, limit
+func (_m *PostStore) GetPostsUpdatedSince(channelID string, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
+	ret := _m.Called(channelID, cursor, limit)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(string, model.GetPostsSinceForSyncCursor, int) []*model.Post); ok {
+		r0 = rf(channelID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 model.GetPostsSinceForSyncCursor
+	if rf, ok := ret.Get(1).(func(string, model.GetPostsSinceForSyncCursor, int) model.GetPostsSinceForSyncCursor); ok {
+		r1 = rf(channelID, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(model.GetPostsSinceForSyncCursor)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, model.GetPostsSinceForSyncCursor, int) error); ok {
+		r2 = rf(channelID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetRecentPostsForUser provides a mock function with given fields: userID, cursor, limit
+func (_m *PostStore) GetRecentPostsForUser(userID string, cursor model.GetRecentPostsForUserCursor, limit int) ([]*model.Post, model.GetRecentPostsForUserCursor, error) {
+	ret := _m.Called(userID, cursor, limit)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(string, model.GetRecentPostsForUserCursor, int) []*model.Post); ok {
+		r0 = rf(userID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 model.GetRecentPostsForUserCursor
+	if rf, ok := ret.Get(1).(func(string, model.GetRecentPostsForUserCursor, int) model.GetRecentPostsForUserCursor); ok {
+		r1 = rf(userID, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(model.GetRecentPostsForUserCursor)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, model.GetRecentPostsForUserCursor, int) error); ok {
+		r2 = rf(userID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetRecentSearchesForUser provides a mock function with given fields: userID
 func (_m *PostStore) GetRecentSearchesForUser(userID string) ([]*model.SearchParams, error) {
 	ret := _m.Called(userID)
@@ -679,6 +896,29 @@ func (_m *PostStore) GetSingle(id string, inclDeleted bool) (*model.Post, error)
 	return r0, r1
 }
 
+// GetThreadParticipants provides a mock function with given fields: rootID, limit
+func (_m *PostStore) GetThreadParticipants(rootID string, limit int) ([]string, error) {
+	ret := _m.Called(rootID, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, int) []string); ok {
+		r0 = rf(rootID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(rootID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasAutoResponsePostByUserSince provides a mock function with given fields: options, userId
 func (_m *PostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
 	ret := _m.Called(options, userId)
@@ -821,6 +1061,29 @@ func (_m *PostStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalP
 	return r0, r1, r2
 }
 
+// PermanentDeleteBatchReturningIds provides a mock function with given fields: endTime, limit
+func (_m *PostStore) PermanentDeleteBatchReturningIds(endTime int64, limit int) ([]string, error) {
+	ret := _m.Called(endTime, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int64, int) []string); ok {
+		r0 = rf(endTime, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(endTime, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteByChannel provides a mock function with given fields: channelID
 func (_m *PostStore) PermanentDeleteByChannel(channelID string) error {
 	ret := _m.Called(channelID)
@@ -849,6 +1112,27 @@ func (_m *PostStore) PermanentDeleteByUser(userID string) error {
 	return r0
 }
 
+// PermanentDeleteEditHistoryBatch provides a mock function with given fields: endTime, limit
+func (_m *PostStore) PermanentDeleteEditHistoryBatch(endTime int64, limit int64) (int64, error) {
+	ret := _m.Called(endTime, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(endTime, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(endTime, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: post
 func (_m *PostStore) Save(post *model.Post) (*model.Post, error) {
 	ret := _m.Called(post)
@@ -167,6 +167,29 @@ func (_m *PreferenceStore) GetCategory(userID string, category string) (model.Pr
 	return r0, r1
 }
 
+// GetCategoryAndName provides a mock function with given fields: category, name
+func (_m *PreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, error) {
+	ret := _m.Called(category, name)
+
+	var r0 model.Preferences
+	if rf, ok := ret.Get(0).(func(string, string) model.Preferences); ok {
+		r0 = rf(category, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Preferences)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(category, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteByUser provides a mock function with given fields: userID
 func (_m *PreferenceStore) PermanentDeleteByUser(userID string) error {
 	ret := _m.Called(userID)
@@ -194,3 +217,24 @@ func (_m *PreferenceStore) Save(preferences model.Preferences) error {
 
 	return r0
 }
+
+// SaveIfNotExists provides a mock function with given fields: preference
+func (_m *PreferenceStore) SaveIfNotExists(preference *model.Preference) (bool, error) {
+	ret := _m.Called(preference)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*model.Preference) bool); ok {
+		r0 = rf(preference)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Preference) error); ok {
+		r1 = rf(preference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -95,6 +95,29 @@ func (_m *ReactionStore) DeleteOrphanedRows(limit int) (int64, error) {
 	return r0, r1
 }
 
+// GetDetailsForPost provides a mock function with given fields: postID, offset, limit
+func (_m *ReactionStore) GetDetailsForPost(postID string, offset int, limit int) ([]*model.ReactionDetails, error) {
+	ret := _m.Called(postID, offset, limit)
+
+	var r0 []*model.ReactionDetails
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.ReactionDetails); ok {
+		r0 = rf(postID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ReactionDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(postID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetForPost provides a mock function with given fields: postID, allowFromCache
 func (_m *ReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
 	ret := _m.Called(postID, allowFromCache)
@@ -141,6 +164,52 @@ func (_m *ReactionStore) GetForPostSince(postId string, since int64, excludeRemo
 	return r0, r1
 }
 
+// GetForPosts provides a mock function with given fields: postIds
+func (_m *ReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	ret := _m.Called(postIds)
+
+	var r0 map[string][]*model.Reaction
+	if rf, ok := ret.Get(0).(func([]string) map[string][]*model.Reaction); ok {
+		r0 = rf(postIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]*model.Reaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(postIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrphanedReactions provides a mock function with given fields: limit
+func (_m *ReactionStore) GetOrphanedReactions(limit int) ([]*model.Reaction, error) {
+	ret := _m.Called(limit)
+
+	var r0 []*model.Reaction
+	if rf, ok := ret.Get(0).(func(int) []*model.Reaction); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTopForTeamSince provides a mock function with given fields: teamID, userID, since, offset, limit
 func (_m *ReactionStore) GetTopForTeamSince(teamID string, userID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
 	ret := _m.Called(teamID, userID, since, offset, limit)
@@ -230,3 +299,26 @@ func (_m *ReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error)
 
 	return r0, r1
 }
+
+// SaveMultiple provides a mock function with given fields: reactions
+func (_m *ReactionStore) SaveMultiple(reactions []*model.Reaction) ([]*model.Reaction, error) {
+	ret := _m.Called(reactions)
+
+	var r0 []*model.Reaction
+	if rf, ok := ret.Get(0).(func([]*model.Reaction) []*model.Reaction); ok {
+		r0 = rf(reactions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]*model.Reaction) error); ok {
+		r1 = rf(reactions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
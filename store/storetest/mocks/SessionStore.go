@@ -206,6 +206,27 @@ func (_m *SessionStore) RemoveAllSessions() error {
 	return r0
 }
 
+// RemoveExpiredSessions provides a mock function with given fields: now, limit
+func (_m *SessionStore) RemoveExpiredSessions(now int64, limit int) (int64, error) {
+	ret := _m.Called(now, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int) int64); ok {
+		r0 = rf(now, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: session
 func (_m *SessionStore) Save(session *model.Session) (*model.Session, error) {
 	ret := _m.Called(session)
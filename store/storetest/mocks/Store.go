@@ -86,6 +86,22 @@ func (_m *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return r0
 }
 
+// ChannelTemplate provides a mock function with given fields:
+func (_m *Store) ChannelTemplate() store.ChannelTemplateStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelTemplateStore
+	if rf, ok := ret.Get(0).(func() store.ChannelTemplateStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelTemplateStore)
+		}
+	}
+
+	return r0
+}
+
 // CheckIntegrity provides a mock function with given fields:
 func (_m *Store) CheckIntegrity() <-chan model.IntegrityCheckResult {
 	ret := _m.Called()
@@ -337,6 +353,22 @@ func (_m *Store) Group() store.GroupStore {
 	return r0
 }
 
+// GuestChannelInvite provides a mock function with given fields:
+func (_m *Store) GuestChannelInvite() store.GuestChannelInviteStore {
+	ret := _m.Called()
+
+	var r0 store.GuestChannelInviteStore
+	if rf, ok := ret.Get(0).(func() store.GuestChannelInviteStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.GuestChannelInviteStore)
+		}
+	}
+
+	return r0
+}
+
 // Job provides a mock function with given fields:
 func (_m *Store) Job() store.JobStore {
 	ret := _m.Called()
@@ -443,6 +475,38 @@ func (_m *Store) Post() store.PostStore {
 	return r0
 }
 
+// PostReminder provides a mock function with given fields:
+func (_m *Store) PostReminder() store.PostReminderStore {
+	ret := _m.Called()
+
+	var r0 store.PostReminderStore
+	if rf, ok := ret.Get(0).(func() store.PostReminderStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostReminderStore)
+		}
+	}
+
+	return r0
+}
+
+// PostReport provides a mock function with given fields:
+func (_m *Store) PostReport() store.PostReportStore {
+	ret := _m.Called()
+
+	var r0 store.PostReportStore
+	if rf, ok := ret.Get(0).(func() store.PostReportStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostReportStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with given fields:
 func (_m *Store) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -572,6 +636,22 @@ func (_m *Store) Role() store.RoleStore {
 	return r0
 }
 
+// ScheduledChannelDeletion provides a mock function with given fields:
+func (_m *Store) ScheduledChannelDeletion() store.ScheduledChannelDeletionStore {
+	ret := _m.Called()
+
+	var r0 store.ScheduledChannelDeletionStore
+	if rf, ok := ret.Get(0).(func() store.ScheduledChannelDeletionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ScheduledChannelDeletionStore)
+		}
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *Store) Scheme() store.SchemeStore {
 	ret := _m.Called()
@@ -443,6 +443,29 @@ func (_m *TeamStore) GetMember(ctx context.Context, teamID string, userID string
 	return r0, r1
 }
 
+// GetMemberCountsByScheme provides a mock function with given fields:
+func (_m *TeamStore) GetMemberCountsByScheme() (map[string]int64, error) {
+	ret := _m.Called()
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func() map[string]int64); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMembers provides a mock function with given fields: teamID, offset, limit, teamMembersGetOptions
 func (_m *TeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
 	ret := _m.Called(teamID, offset, limit, teamMembersGetOptions)
@@ -489,6 +512,38 @@ func (_m *TeamStore) GetMembersByIds(teamID string, userIds []string, restrictio
 	return r0, r1
 }
 
+// GetMembersByIdsInOrder provides a mock function with given fields: teamID, userIds, restrictions
+func (_m *TeamStore) GetMembersByIdsInOrder(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, []string, error) {
+	ret := _m.Called(teamID, userIds, restrictions)
+
+	var r0 []*model.TeamMember
+	if rf, ok := ret.Get(0).(func(string, []string, *model.ViewUsersRestrictions) []*model.TeamMember); ok {
+		r0 = rf(teamID, userIds, restrictions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TeamMember)
+		}
+	}
+
+	var r1 []string
+	if rf, ok := ret.Get(1).(func(string, []string, *model.ViewUsersRestrictions) []string); ok {
+		r1 = rf(teamID, userIds, restrictions)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, []string, *model.ViewUsersRestrictions) error); ok {
+		r2 = rf(teamID, userIds, restrictions)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetTeamMembersForExport provides a mock function with given fields: userID
 func (_m *TeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
 	ret := _m.Called(userID)
@@ -604,6 +659,29 @@ func (_m *TeamStore) GetTeamsForUserWithPagination(userID string, page int, perP
 	return r0, r1
 }
 
+// GetTeamsWithMemberCount provides a mock function with given fields: offset, limit
+func (_m *TeamStore) GetTeamsWithMemberCount(offset int, limit int) ([]*model.TeamWithMemberCount, error) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []*model.TeamWithMemberCount
+	if rf, ok := ret.Get(0).(func(int, int) []*model.TeamWithMemberCount); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TeamWithMemberCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalMemberCount provides a mock function with given fields: teamID, restrictions
 func (_m *TeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
 	ret := _m.Called(teamID, restrictions)
@@ -669,6 +747,27 @@ func (_m *TeamStore) GroupSyncedTeamCount() (int64, error) {
 	return r0, r1
 }
 
+// IncrementInviteUses provides a mock function with given fields: teamID, inviteID
+func (_m *TeamStore) IncrementInviteUses(teamID string, inviteID string) (bool, error) {
+	ret := _m.Called(teamID, inviteID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(teamID, inviteID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(teamID, inviteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InvalidateAllTeamIdsForUser provides a mock function with given fields: userID
 func (_m *TeamStore) InvalidateAllTeamIdsForUser(userID string) {
 	_m.Called(userID)
@@ -711,6 +810,20 @@ func (_m *TeamStore) PermanentDelete(teamID string) error {
 	return r0
 }
 
+// ReleaseInviteUse provides a mock function with given fields: teamID, inviteID
+func (_m *TeamStore) ReleaseInviteUse(teamID string, inviteID string) error {
+	ret := _m.Called(teamID, inviteID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(teamID, inviteID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveAllMembersByTeam provides a mock function with given fields: teamID
 func (_m *TeamStore) RemoveAllMembersByTeam(teamID string) error {
 	ret := _m.Called(teamID)
@@ -453,6 +453,34 @@ func (_m *ThreadStore) PermanentDeleteBatchThreadMembershipsForRetentionPolicies
 	return r0, r1, r2
 }
 
+// RecomputeReplyCounts provides a mock function with given fields: afterPostID, limit
+func (_m *ThreadStore) RecomputeReplyCounts(afterPostID string, limit int) (string, int64, error) {
+	ret := _m.Called(afterPostID, limit)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, int) string); ok {
+		r0 = rf(afterPostID, limit)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(string, int) int64); ok {
+		r1 = rf(afterPostID, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, int) error); ok {
+		r2 = rf(afterPostID, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateMembership provides a mock function with given fields: membership
 func (_m *ThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
 	ret := _m.Called(membership)
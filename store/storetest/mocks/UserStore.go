@@ -39,6 +39,29 @@ func (_m *UserStore) AnalyticsActiveCount(timestamp int64, options model.UserCou
 	return r0, r1
 }
 
+// AnalyticsActiveCountByDay provides a mock function with given fields: since
+func (_m *UserStore) AnalyticsActiveCountByDay(since int64) (model.AnalyticsRows, error) {
+	ret := _m.Called(since)
+
+	var r0 model.AnalyticsRows
+	if rf, ok := ret.Get(0).(func(int64) model.AnalyticsRows); ok {
+		r0 = rf(since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.AnalyticsRows)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AnalyticsActiveCountForPeriod provides a mock function with given fields: startTime, endTime, options
 func (_m *UserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
 	ret := _m.Called(startTime, endTime, options)
@@ -504,6 +527,38 @@ func (_m *UserStore) GetByUsername(username string) (*model.User, error) {
 	return r0, r1
 }
 
+// GetByUsernames provides a mock function with given fields: usernames
+func (_m *UserStore) GetByUsernames(usernames []string) ([]*model.User, []string, error) {
+	ret := _m.Called(usernames)
+
+	var r0 []*model.User
+	if rf, ok := ret.Get(0).(func([]string) []*model.User); ok {
+		r0 = rf(usernames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	var r1 []string
+	if rf, ok := ret.Get(1).(func([]string) []string); ok {
+		r1 = rf(usernames)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func([]string) error); ok {
+		r2 = rf(usernames)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetChannelGroupUsers provides a mock function with given fields: channelID
 func (_m *UserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
 	ret := _m.Called(channelID)
@@ -1205,6 +1260,29 @@ func (_m *UserStore) Search(teamID string, term string, options *model.UserSearc
 	return r0, r1
 }
 
+// SearchByAttribute provides a mock function with given fields: key, value, page, perPage
+func (_m *UserStore) SearchByAttribute(key string, value string, page int, perPage int) ([]*model.User, error) {
+	ret := _m.Called(key, value, page, perPage)
+
+	var r0 []*model.User
+	if rf, ok := ret.Get(0).(func(string, string, int, int) []*model.User); ok {
+		r0 = rf(key, value, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int, int) error); ok {
+		r1 = rf(key, value, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SearchInChannel provides a mock function with given fields: channelID, term, options
 func (_m *UserStore) SearchInChannel(channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
 	ret := _m.Called(channelID, term, options)
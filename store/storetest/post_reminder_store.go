@@ -0,0 +1,131 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestPostReminderStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetForUser", func(t *testing.T) { testPostReminderStoreSaveGetForUser(t, ss) })
+	t.Run("GetDue", func(t *testing.T) { testPostReminderStoreGetDue(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testPostReminderStoreDelete(t, ss) })
+	t.Run("UpdateFailedAttempts", func(t *testing.T) { testPostReminderStoreUpdateFailedAttempts(t, ss) })
+}
+
+func testPostReminderStoreSaveGetForUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	now := model.GetMillis()
+
+	later, err := ss.PostReminder().Save(&model.PostReminder{PostId: model.NewId(), UserId: userID, RemindAt: now + 2000})
+	require.NoError(t, err)
+	require.NotZero(t, later.CreateAt)
+
+	sooner, err := ss.PostReminder().Save(&model.PostReminder{PostId: model.NewId(), UserId: userID, RemindAt: now + 1000})
+	require.NoError(t, err)
+
+	_, err = ss.PostReminder().Save(&model.PostReminder{PostId: sooner.PostId, UserId: model.NewId(), RemindAt: now})
+	require.NoError(t, err)
+
+	reminders, err := ss.PostReminder().GetForUser(userID)
+	require.NoError(t, err)
+	require.Equal(t, []*model.PostReminder{sooner, later}, reminders)
+
+	t.Run("saving again replaces the reminder", func(t *testing.T) {
+		updated, err := ss.PostReminder().Save(&model.PostReminder{PostId: later.PostId, UserId: userID, RemindAt: now + 500})
+		require.NoError(t, err)
+
+		reminders, err := ss.PostReminder().GetForUser(userID)
+		require.NoError(t, err)
+		require.Equal(t, []*model.PostReminder{updated, sooner}, reminders)
+	})
+
+	t.Run("invalid reminder", func(t *testing.T) {
+		_, err := ss.PostReminder().Save(&model.PostReminder{PostId: model.NewId(), UserId: userID})
+		var appErr *model.AppError
+		require.True(t, errors.As(err, &appErr))
+	})
+
+	t.Run("user without reminders", func(t *testing.T) {
+		reminders, err := ss.PostReminder().GetForUser(model.NewId())
+		require.NoError(t, err)
+		require.Empty(t, reminders)
+	})
+}
+
+func testPostReminderStoreGetDue(t *testing.T, ss store.Store) {
+	// Other tests may leave due reminders behind, so place these far in the past.
+	userID := model.NewId()
+	base := int64(1000)
+
+	first, err := ss.PostReminder().Save(&model.PostReminder{PostId: model.NewId(), UserId: userID, RemindAt: base})
+	require.NoError(t, err)
+	second, err := ss.PostReminder().Save(&model.PostReminder{PostId: model.NewId(), UserId: userID, RemindAt: base + 1})
+	require.NoError(t, err)
+	_, err = ss.PostReminder().Save(&model.PostReminder{PostId: model.NewId(), UserId: userID, RemindAt: base + 2})
+	require.NoError(t, err)
+
+	due, err := ss.PostReminder().GetDue(base+1, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*model.PostReminder{first, second}, due)
+
+	due, err = ss.PostReminder().GetDue(base+2, 1)
+	require.NoError(t, err)
+	require.Equal(t, []*model.PostReminder{first}, due)
+
+	due, err = ss.PostReminder().GetDue(base-1, 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	for _, reminder := range []*model.PostReminder{first, second} {
+		require.NoError(t, ss.PostReminder().Delete(reminder.UserId, reminder.PostId))
+	}
+}
+
+func testPostReminderStoreDelete(t *testing.T, ss store.Store) {
+	reminder, err := ss.PostReminder().Save(&model.PostReminder{PostId: model.NewId(), UserId: model.NewId(), RemindAt: model.GetMillis()})
+	require.NoError(t, err)
+
+	err = ss.PostReminder().Delete(reminder.UserId, reminder.PostId)
+	require.NoError(t, err)
+
+	reminders, err := ss.PostReminder().GetForUser(reminder.UserId)
+	require.NoError(t, err)
+	require.Empty(t, reminders)
+
+	err = ss.PostReminder().Delete(reminder.UserId, reminder.PostId)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testPostReminderStoreUpdateFailedAttempts(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+
+	reminder, err := ss.PostReminder().Save(&model.PostReminder{PostId: model.NewId(), UserId: userID, RemindAt: model.GetMillis()})
+	require.NoError(t, err)
+
+	err = ss.PostReminder().UpdateFailedAttempts(userID, reminder.PostId, 2)
+	require.NoError(t, err)
+
+	reminders, err := ss.PostReminder().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	require.Equal(t, 2, reminders[0].FailedAttempts)
+
+	t.Run("saving again clears the failed attempts", func(t *testing.T) {
+		_, err := ss.PostReminder().Save(&model.PostReminder{PostId: reminder.PostId, UserId: userID, RemindAt: model.GetMillis()})
+		require.NoError(t, err)
+
+		reminders, err := ss.PostReminder().GetForUser(userID)
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		require.Zero(t, reminders[0].FailedAttempts)
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestPostReportStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testPostReportStoreSaveGet(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testPostReportStoreGetAll(t, ss) })
+	t.Run("UpdateStatus", func(t *testing.T) { testPostReportStoreUpdateStatus(t, ss) })
+}
+
+func testPostReportStoreSaveGet(t *testing.T, ss store.Store) {
+	report := &model.PostReport{
+		PostId:     model.NewId(),
+		ChannelId:  model.NewId(),
+		ReporterId: model.NewId(),
+		Reason:     "spam",
+	}
+
+	saved, err := ss.PostReport().Save(report)
+	require.NoError(t, err)
+	require.NotEmpty(t, saved.Id)
+	require.Equal(t, model.PostReportStatusOpen, saved.Status)
+
+	t.Run("get", func(t *testing.T) {
+		got, err := ss.PostReport().Get(saved.Id)
+		require.NoError(t, err)
+		require.Equal(t, saved, got)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		_, err := ss.PostReport().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("save existing id", func(t *testing.T) {
+		_, err := ss.PostReport().Save(saved)
+		require.Error(t, err)
+	})
+
+	t.Run("save duplicate report", func(t *testing.T) {
+		_, err := ss.PostReport().Save(&model.PostReport{
+			PostId:     report.PostId,
+			ChannelId:  report.ChannelId,
+			ReporterId: report.ReporterId,
+		})
+		var cErr *store.ErrConflict
+		require.True(t, errors.As(err, &cErr))
+	})
+
+	t.Run("save invalid", func(t *testing.T) {
+		_, err := ss.PostReport().Save(&model.PostReport{PostId: model.NewId()})
+		require.Error(t, err)
+	})
+}
+
+func testPostReportStoreGetAll(t *testing.T, ss store.Store) {
+	postID := model.NewId()
+	channelID := model.NewId()
+
+	var reports []*model.PostReport
+	for i := 0; i < 3; i++ {
+		report, err := ss.PostReport().Save(&model.PostReport{
+			PostId:     postID,
+			ChannelId:  channelID,
+			ReporterId: model.NewId(),
+		})
+		require.NoError(t, err)
+		reports = append(reports, report)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	_, err := ss.PostReport().UpdateStatus(reports[0].Id, model.PostReportStatusResolved)
+	require.NoError(t, err)
+
+	// Other tests may have created reports, so only the ones created here are considered.
+	filter := func(all []*model.PostReport) []string {
+		ids := []string{}
+		for _, report := range all {
+			if report.PostId == postID {
+				ids = append(ids, report.Id)
+			}
+		}
+		return ids
+	}
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := ss.PostReport().GetAll(model.PostReportGetOptions{Page: -1, PerPage: 10})
+		require.Error(t, err)
+	})
+
+	t.Run("all statuses", func(t *testing.T) {
+		all, err := ss.PostReport().GetAll(model.PostReportGetOptions{PerPage: 1000})
+		require.NoError(t, err)
+		require.Equal(t, []string{reports[2].Id, reports[1].Id, reports[0].Id}, filter(all))
+	})
+
+	t.Run("filtered by status", func(t *testing.T) {
+		open, err := ss.PostReport().GetAll(model.PostReportGetOptions{Status: model.PostReportStatusOpen, PerPage: 1000})
+		require.NoError(t, err)
+		require.Equal(t, []string{reports[2].Id, reports[1].Id}, filter(open))
+		for _, report := range open {
+			require.Equal(t, model.PostReportStatusOpen, report.Status)
+		}
+
+		resolved, err := ss.PostReport().GetAll(model.PostReportGetOptions{Status: model.PostReportStatusResolved, PerPage: 1000})
+		require.NoError(t, err)
+		require.Equal(t, []string{reports[0].Id}, filter(resolved))
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		page, err := ss.PostReport().GetAll(model.PostReportGetOptions{PerPage: 1})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+
+		page2, err := ss.PostReport().GetAll(model.PostReportGetOptions{Page: 1, PerPage: 1})
+		require.NoError(t, err)
+		require.Len(t, page2, 1)
+		require.NotEqual(t, page[0].Id, page2[0].Id)
+	})
+}
+
+func testPostReportStoreUpdateStatus(t *testing.T, ss store.Store) {
+	report, err := ss.PostReport().Save(&model.PostReport{
+		PostId:     model.NewId(),
+		ChannelId:  model.NewId(),
+		ReporterId: model.NewId(),
+	})
+	require.NoError(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+
+	updated, err := ss.PostReport().UpdateStatus(report.Id, model.PostReportStatusDismissed)
+	require.NoError(t, err)
+	require.Equal(t, model.PostReportStatusDismissed, updated.Status)
+	require.Greater(t, updated.UpdateAt, report.UpdateAt)
+
+	got, err := ss.PostReport().Get(report.Id)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+
+	t.Run("invalid status", func(t *testing.T) {
+		_, err := ss.PostReport().UpdateStatus(report.Id, "escalated")
+		require.Error(t, err)
+	})
+
+	t.Run("missing report", func(t *testing.T) {
+		_, err := ss.PostReport().UpdateStatus(model.NewId(), model.PostReportStatusResolved)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -32,22 +33,29 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("GetWithChildren", func(t *testing.T) { testPostStoreGetWithChildren(t, ss) })
 	t.Run("GetPostsWithDetails", func(t *testing.T) { testPostStoreGetPostsWithDetails(t, ss) })
 	t.Run("GetPostsBeforeAfter", func(t *testing.T) { testPostStoreGetPostsBeforeAfter(t, ss) })
+	t.Run("GetPostsAround", func(t *testing.T) { testPostStoreGetPostsAround(t, ss) })
 	t.Run("GetPostsSince", func(t *testing.T) { testPostStoreGetPostsSince(t, ss) })
 	t.Run("GetPosts", func(t *testing.T) { testPostStoreGetPosts(t, ss) })
 	t.Run("GetPostBeforeAfter", func(t *testing.T) { testPostStoreGetPostBeforeAfter(t, ss) })
 	t.Run("UserCountsWithPostsByDay", func(t *testing.T) { testUserCountsWithPostsByDay(t, ss) })
 	t.Run("PostCountsByDuration", func(t *testing.T) { testPostCountsByDay(t, ss) })
+	t.Run("AnalyticsBotPostCount", func(t *testing.T) { testPostStoreAnalyticsBotPostCount(t, ss) })
 	t.Run("GetFlaggedPostsForTeam", func(t *testing.T) { testPostStoreGetFlaggedPostsForTeam(t, ss, s) })
 	t.Run("GetFlaggedPosts", func(t *testing.T) { testPostStoreGetFlaggedPosts(t, ss) })
 	t.Run("GetFlaggedPostsForChannel", func(t *testing.T) { testPostStoreGetFlaggedPostsForChannel(t, ss) })
+	t.Run("GetFlaggedPostsForUserPaged", func(t *testing.T) { testPostStoreGetFlaggedPostsForUserPaged(t, ss) })
 	t.Run("GetPostsCreatedAt", func(t *testing.T) { testPostStoreGetPostsCreatedAt(t, ss) })
 	t.Run("GetLastPostRowCreateAt", func(t *testing.T) { testPostStoreGetLastPostRowCreateAt(t, ss) })
+	t.Run("GetLastPostCreateAtForUser", func(t *testing.T) { testPostStoreGetLastPostCreateAtForUser(t, ss) })
 	t.Run("Overwrite", func(t *testing.T) { testPostStoreOverwrite(t, ss) })
 	t.Run("OverwriteMultiple", func(t *testing.T) { testPostStoreOverwriteMultiple(t, ss) })
 	t.Run("GetPostsByIds", func(t *testing.T) { testPostStoreGetPostsByIds(t, ss) })
 	t.Run("GetPostsBatchForIndexing", func(t *testing.T) { testPostStoreGetPostsBatchForIndexing(t, ss) })
 	t.Run("PermanentDeleteBatch", func(t *testing.T) { testPostStorePermanentDeleteBatch(t, ss) })
+	t.Run("PermanentDeleteBatchReturningIds", func(t *testing.T) { testPostStorePermanentDeleteBatchReturningIds(t, ss) })
+	t.Run("PermanentDeleteEditHistoryBatch", func(t *testing.T) { testPostStorePermanentDeleteEditHistoryBatch(t, ss) })
 	t.Run("GetOldest", func(t *testing.T) { testPostStoreGetOldest(t, ss) })
+	t.Run("GetEarliestPostTimeForChannels", func(t *testing.T) { testPostStoreGetEarliestPostTimeForChannels(t, ss) })
 	t.Run("TestGetMaxPostSize", func(t *testing.T) { testGetMaxPostSize(t, ss) })
 	t.Run("GetParentsForExportAfter", func(t *testing.T) { testPostStoreGetParentsForExportAfter(t, ss) })
 	t.Run("GetRepliesForExport", func(t *testing.T) { testPostStoreGetRepliesForExport(t, ss) })
@@ -57,6 +65,11 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("GetForThread", func(t *testing.T) { testPostStoreGetForThread(t, ss) })
 	t.Run("HasAutoResponsePostByUserSince", func(t *testing.T) { testHasAutoResponsePostByUserSince(t, ss) })
 	t.Run("GetPostsSinceForSync", func(t *testing.T) { testGetPostsSinceForSync(t, ss, s) })
+	t.Run("GetPostsUpdatedSince", func(t *testing.T) { testPostStoreGetPostsUpdatedSince(t, ss) })
+	t.Run("GetPostsBetween", func(t *testing.T) { testPostStoreGetPostsBetween(t, ss) })
+	t.Run("GetRecentPostsForUser", func(t *testing.T) { testPostStoreGetRecentPostsForUser(t, ss) })
+	t.Run("GetThreadParticipants", func(t *testing.T) { testPostStoreGetThreadParticipants(t, ss) })
+	t.Run("GetPostsByHashtag", func(t *testing.T) { testPostStoreGetPostsByHashtag(t, ss) })
 }
 
 func testPostStoreSave(t *testing.T, ss store.Store) {
@@ -1638,6 +1651,154 @@ func testPostStoreGetPostsBeforeAfter(t *testing.T, ss store.Store) {
 	})
 }
 
+func testPostStoreGetPostsAround(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	userId := model.NewId()
+
+	var posts []*model.Post
+	for i := 0; i < 10; i++ {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channelId,
+			UserId:    userId,
+			Message:   "message",
+		})
+		require.NoError(t, err)
+
+		posts = append(posts, post)
+
+		time.Sleep(time.Millisecond)
+	}
+
+	postIds := func(list *model.PostList) []string {
+		ids := make([]string, 0, len(list.Posts))
+		for id := range list.Posts {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	t.Run("should return error if negative before/after are passed", func(t *testing.T) {
+		postList, err := ss.Post().GetPostsAround(posts[5].Id, -1, 2)
+		assert.Nil(t, postList)
+		assert.IsType(t, &store.ErrInvalidInput{}, err)
+
+		postList, err = ss.Post().GetPostsAround(posts[5].Id, 2, -1)
+		assert.Nil(t, postList)
+		assert.IsType(t, &store.ErrInvalidInput{}, err)
+	})
+
+	t.Run("should return not found for a missing post", func(t *testing.T) {
+		postList, err := ss.Post().GetPostsAround(model.NewId(), 2, 2)
+		assert.Nil(t, postList)
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("should center the window on the post", func(t *testing.T) {
+		postList, err := ss.Post().GetPostsAround(posts[5].Id, 2, 3)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{posts[8].Id, posts[7].Id, posts[6].Id, posts[5].Id, posts[4].Id, posts[3].Id}, postList.Order)
+		assert.ElementsMatch(t, postList.Order, postIds(postList))
+	})
+
+	t.Run("should return only the post when before and after are zero", func(t *testing.T) {
+		postList, err := ss.Post().GetPostsAround(posts[5].Id, 0, 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{posts[5].Id}, postList.Order)
+		assert.ElementsMatch(t, postList.Order, postIds(postList))
+	})
+
+	t.Run("should truncate the window at the start of the channel", func(t *testing.T) {
+		postList, err := ss.Post().GetPostsAround(posts[1].Id, 5, 2)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{posts[3].Id, posts[2].Id, posts[1].Id, posts[0].Id}, postList.Order)
+		assert.ElementsMatch(t, postList.Order, postIds(postList))
+
+		postList, err = ss.Post().GetPostsAround(posts[0].Id, 5, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{posts[1].Id, posts[0].Id}, postList.Order)
+	})
+
+	t.Run("should truncate the window at the end of the channel", func(t *testing.T) {
+		postList, err := ss.Post().GetPostsAround(posts[8].Id, 2, 5)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{posts[9].Id, posts[8].Id, posts[7].Id, posts[6].Id}, postList.Order)
+		assert.ElementsMatch(t, postList.Order, postIds(postList))
+
+		postList, err = ss.Post().GetPostsAround(posts[9].Id, 1, 5)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{posts[9].Id, posts[8].Id}, postList.Order)
+	})
+
+	t.Run("should not skip posts sharing the post's create time", func(t *testing.T) {
+		otherChannelId := model.NewId()
+		createAt := model.GetMillis()
+
+		var tied []*model.Post
+		for i := 0; i < 3; i++ {
+			post, err := ss.Post().Save(&model.Post{
+				ChannelId: otherChannelId,
+				UserId:    userId,
+				Message:   "message",
+				CreateAt:  createAt,
+			})
+			require.NoError(t, err)
+			tied = append(tied, post)
+		}
+		sort.Slice(tied, func(i, j int) bool { return tied[i].Id < tied[j].Id })
+
+		postList, err := ss.Post().GetPostsAround(tied[1].Id, 5, 5)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{tied[2].Id, tied[1].Id, tied[0].Id}, postList.Order)
+	})
+
+	t.Run("should include thread roots", func(t *testing.T) {
+		threadChannelId := model.NewId()
+
+		root, err := ss.Post().Save(&model.Post{
+			ChannelId: threadChannelId,
+			UserId:    userId,
+			Message:   "root",
+		})
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+
+		var others []*model.Post
+		for i := 0; i < 3; i++ {
+			post, err := ss.Post().Save(&model.Post{
+				ChannelId: threadChannelId,
+				UserId:    userId,
+				Message:   "message",
+			})
+			require.NoError(t, err)
+			others = append(others, post)
+			time.Sleep(time.Millisecond)
+		}
+
+		reply, err := ss.Post().Save(&model.Post{
+			ChannelId: threadChannelId,
+			UserId:    userId,
+			RootId:    root.Id,
+			Message:   "reply",
+		})
+		require.NoError(t, err)
+
+		postList, err := ss.Post().GetPostsAround(reply.Id, 1, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{reply.Id, others[2].Id}, postList.Order)
+		assert.ElementsMatch(t, []string{reply.Id, others[2].Id, root.Id}, postIds(postList))
+		assert.Equal(t, "root", postList.Posts[root.Id].Message)
+	})
+}
+
 func testPostStoreGetPostsSince(t *testing.T, ss store.Store) {
 	t.Run("should return posts created after the given time", func(t *testing.T) {
 		channelId := model.NewId()
@@ -1759,6 +1920,79 @@ func testPostStoreGetPostsSince(t *testing.T, ss store.Store) {
 		assert.Len(t, postList.Posts, 1)
 		assert.NotNil(t, postList.Posts[post1.Id])
 	})
+
+	t.Run("should exclude the posts of the given user", func(t *testing.T) {
+		channelId := model.NewId()
+		userId := model.NewId()
+		otherUserId := model.NewId()
+
+		post1, err := ss.Post().Save(&model.Post{
+			ChannelId: channelId,
+			UserId:    userId,
+			Message:   "message",
+		})
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+
+		post2, err := ss.Post().Save(&model.Post{
+			ChannelId: channelId,
+			UserId:    otherUserId,
+			Message:   "message",
+		})
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+
+		post3, err := ss.Post().Save(&model.Post{
+			ChannelId: channelId,
+			UserId:    userId,
+			Message:   "message",
+		})
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+
+		post4, err := ss.Post().Save(&model.Post{
+			ChannelId: channelId,
+			UserId:    otherUserId,
+			Message:   "message",
+			RootId:    post1.Id,
+		})
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+
+		_, err = ss.Post().Save(&model.Post{
+			ChannelId: channelId,
+			UserId:    userId,
+			Message:   "message",
+			RootId:    post1.Id,
+		})
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+
+		options := model.GetPostsSinceOptions{ChannelId: channelId, Time: post1.CreateAt - 1, ExcludeUserId: userId}
+		postList, err := ss.Post().GetPostsSince(options, true, map[string]bool{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{post4.Id, post2.Id}, postList.Order)
+
+		assert.Len(t, postList.Posts, 3)
+		assert.NotNil(t, postList.Posts[post1.Id], "should return the parent post")
+		assert.NotNil(t, postList.Posts[post2.Id])
+		assert.NotNil(t, postList.Posts[post4.Id])
+
+		// Leaving out the latest posts shouldn't stop them from being returned to others
+		postList, err = ss.Post().GetPostsSince(model.GetPostsSinceOptions{ChannelId: channelId, Time: post4.CreateAt}, true, map[string]bool{})
+		require.NoError(t, err)
+		assert.Len(t, postList.Order, 1)
+
+		t.Run("with collapsed threads", func(t *testing.T) {
+			options.CollapsedThreads = true
+			postList, err := ss.Post().GetPostsSince(options, false, map[string]bool{})
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{post2.Id}, postList.Order)
+			assert.Nil(t, postList.Posts[post3.Id])
+		})
+	})
 }
 
 func testPostStoreGetPosts(t *testing.T, ss store.Store) {
@@ -2183,6 +2417,83 @@ func testPostCountsByDay(t *testing.T, ss store.Store) {
 	assert.Equal(t, int64(3), r2)
 }
 
+func testPostStoreAnalyticsBotPostCount(t *testing.T, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "DisplayName",
+		Name:        NewTestId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	saveChannel := func(teamID string) *model.Channel {
+		channel, nErr := ss.Channel().Save(&model.Channel{
+			TeamId:      teamID,
+			DisplayName: "Channel",
+			Name:        NewTestId(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, nErr)
+		return channel
+	}
+	channel := saveChannel(team.Id)
+	otherChannel := saveChannel(model.NewId())
+
+	bot, err := ss.Bot().Save(&model.Bot{
+		Username: NewTestId(),
+		OwnerId:  model.NewId(),
+		UserId:   model.NewId(),
+	})
+	require.NoError(t, err)
+	humanID := model.NewId()
+
+	since := model.GetMillis() - 1000*60*60
+	savePost := func(channelID, userID string, createAt int64) *model.Post {
+		post, nErr := ss.Post().Save(&model.Post{
+			ChannelId: channelID,
+			UserId:    userID,
+			Message:   NewTestId(),
+			CreateAt:  createAt,
+		})
+		require.NoError(t, nErr)
+		return post
+	}
+
+	savePost(channel.Id, bot.UserId, since)
+	savePost(channel.Id, bot.UserId, since+1)
+	deleted := savePost(channel.Id, bot.UserId, since+2)
+	savePost(channel.Id, bot.UserId, since-1)
+	savePost(channel.Id, humanID, since+1)
+	savePost(otherChannel.Id, bot.UserId, since+1)
+	savePost(otherChannel.Id, humanID, since+1)
+
+	require.NoError(t, ss.Post().Delete(deleted.Id, model.GetMillis(), bot.UserId))
+
+	t.Run("single team", func(t *testing.T) {
+		count, err := ss.Post().AnalyticsBotPostCount(team.Id, since)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("all teams", func(t *testing.T) {
+		count, err := ss.Post().AnalyticsBotPostCount("", since)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, count, int64(3))
+	})
+
+	t.Run("since excludes older posts", func(t *testing.T) {
+		count, err := ss.Post().AnalyticsBotPostCount(team.Id, since+1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("team without bot posts", func(t *testing.T) {
+		count, err := ss.Post().AnalyticsBotPostCount(model.NewId(), 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
 func testPostStoreGetFlaggedPostsForTeam(t *testing.T, ss store.Store, s SqlStore) {
 	c1 := &model.Channel{}
 	c1.TeamId = model.NewId()
@@ -2516,6 +2827,125 @@ func testPostStoreGetFlaggedPosts(t *testing.T, ss store.Store) {
 	require.Len(t, r4.Order, 2, "should have 2 posts")
 }
 
+func testPostStoreGetFlaggedPostsForUserPaged(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	teamID := model.NewId()
+	otherTeamID := model.NewId()
+
+	createChannel := func(teamID string) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamID,
+			DisplayName: "Channel",
+			Name:        NewTestId(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err)
+
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      userID,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, err)
+		return channel
+	}
+
+	flagPost := func(channelID string) *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Message:   NewTestId(),
+		})
+		require.NoError(t, err)
+		time.Sleep(2 * time.Millisecond)
+
+		err = ss.Preference().Save(model.Preferences{{
+			UserId:   userID,
+			Category: model.PreferenceCategoryFlaggedPost,
+			Name:     post.Id,
+			Value:    "true",
+		}})
+		require.NoError(t, err)
+		return post
+	}
+
+	c1 := createChannel(teamID)
+	c2 := createChannel(teamID)
+	c3 := createChannel(otherTeamID)
+	leftChannel := createChannel(teamID)
+
+	dm, err := ss.Channel().CreateDirectChannel(&model.User{Id: userID}, &model.User{Id: model.NewId()})
+	require.NoError(t, err)
+
+	p1 := flagPost(c1.Id)
+	p2 := flagPost(c1.Id)
+	p3 := flagPost(c2.Id)
+	p4 := flagPost(c3.Id)
+	p5 := flagPost(dm.Id)
+	flagPost(leftChannel.Id)
+
+	err = ss.Channel().RemoveMember(leftChannel.Id, userID)
+	require.NoError(t, err)
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{Page: -1, PerPage: 10})
+		require.Error(t, err)
+
+		_, err = ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{PerPage: 0})
+		require.Error(t, err)
+	})
+
+	t.Run("all teams", func(t *testing.T) {
+		r, err := ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{PerPage: 10})
+		require.NoError(t, err)
+		require.Equal(t, []string{p5.Id, p4.Id, p3.Id, p2.Id, p1.Id}, r.Order)
+		require.False(t, r.HasNext)
+	})
+
+	t.Run("team scoped", func(t *testing.T) {
+		r, err := ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{TeamId: teamID, PerPage: 10})
+		require.NoError(t, err)
+		require.Equal(t, []string{p5.Id, p3.Id, p2.Id, p1.Id}, r.Order)
+
+		r, err = ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{TeamId: otherTeamID, PerPage: 10})
+		require.NoError(t, err)
+		require.Equal(t, []string{p5.Id, p4.Id}, r.Order)
+	})
+
+	t.Run("channel scoped", func(t *testing.T) {
+		r, err := ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{ChannelId: c1.Id, PerPage: 10})
+		require.NoError(t, err)
+		require.Equal(t, []string{p2.Id, p1.Id}, r.Order)
+
+		r, err = ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{TeamId: otherTeamID, ChannelId: c1.Id, PerPage: 10})
+		require.NoError(t, err)
+		require.Empty(t, r.Order)
+	})
+
+	t.Run("channel the user left", func(t *testing.T) {
+		r, err := ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{ChannelId: leftChannel.Id, PerPage: 10})
+		require.NoError(t, err)
+		require.Empty(t, r.Order)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		r, err := ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{TeamId: teamID, PerPage: 3})
+		require.NoError(t, err)
+		require.Equal(t, []string{p5.Id, p3.Id, p2.Id}, r.Order)
+		require.Len(t, r.Posts, 3)
+		require.True(t, r.HasNext)
+
+		r, err = ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{TeamId: teamID, Page: 1, PerPage: 3})
+		require.NoError(t, err)
+		require.Equal(t, []string{p1.Id}, r.Order)
+		require.False(t, r.HasNext)
+
+		r, err = ss.Post().GetFlaggedPostsForUserPaged(userID, model.GetFlaggedPostsOptions{TeamId: teamID, Page: 2, PerPage: 3})
+		require.NoError(t, err)
+		require.Empty(t, r.Order)
+	})
+}
+
 func testPostStoreGetFlaggedPostsForChannel(t *testing.T, ss store.Store) {
 	c1 := &model.Channel{}
 	c1.TeamId = model.NewId()
@@ -2674,6 +3104,432 @@ func testPostStoreGetLastPostRowCreateAt(t *testing.T, ss store.Store) {
 	assert.Equal(t, createAt, createTime2)
 }
 
+func testPostStoreGetLastPostCreateAtForUser(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	userId := model.NewId()
+
+	createAt, err := ss.Post().GetLastPostCreateAtForUser(channelId, userId)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), createAt)
+
+	createTime := model.GetMillis()
+	_, err = ss.Post().Save(&model.Post{ChannelId: channelId, UserId: userId, Message: NewTestId(), CreateAt: createTime})
+	require.NoError(t, err)
+
+	deleted, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: userId, Message: NewTestId(), CreateAt: createTime + 1})
+	require.NoError(t, err)
+	require.NoError(t, ss.Post().Delete(deleted.Id, model.GetMillis(), userId))
+
+	// Neither system messages, posts by other users nor posts in other channels count.
+	_, err = ss.Post().Save(&model.Post{ChannelId: channelId, UserId: userId, Message: NewTestId(), Type: model.PostTypeJoinChannel, CreateAt: createTime + 2})
+	require.NoError(t, err)
+	_, err = ss.Post().Save(&model.Post{ChannelId: channelId, UserId: model.NewId(), Message: NewTestId(), CreateAt: createTime + 3})
+	require.NoError(t, err)
+	_, err = ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: userId, Message: NewTestId(), CreateAt: createTime + 4})
+	require.NoError(t, err)
+
+	createAt, err = ss.Post().GetLastPostCreateAtForUser(channelId, userId)
+	require.NoError(t, err)
+	assert.Equal(t, createTime+1, createAt)
+}
+
+func testPostStoreGetRecentPostsForUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	teamID := model.NewId()
+
+	newChannel := func() *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamID,
+			DisplayName: "DisplayName",
+			Name:        NewTestId(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err)
+
+		for _, id := range []string{userID, otherUserID} {
+			_, err = ss.Channel().SaveMember(&model.ChannelMember{
+				ChannelId:   channel.Id,
+				UserId:      id,
+				NotifyProps: model.GetDefaultChannelNotifyProps(),
+			})
+			require.NoError(t, err)
+		}
+		return channel
+	}
+
+	channel1 := newChannel()
+	channel2 := newChannel()
+	leftChannel := newChannel()
+
+	createTime := model.GetMillis()
+	newPost := func(channel *model.Channel, userID string, createAt int64, postType string) *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channel.Id,
+			UserId:    userID,
+			Message:   NewTestId(),
+			CreateAt:  createAt,
+			Type:      postType,
+		})
+		require.NoError(t, err)
+		return post
+	}
+
+	p1 := newPost(channel1, userID, createTime, "")
+	p2 := newPost(channel2, userID, createTime+1, "")
+	p3 := newPost(channel1, userID, createTime+2, "")
+	p4 := newPost(channel2, userID, createTime+2, "")
+	p5 := newPost(channel1, userID, createTime+3, "")
+
+	// None of these are included.
+	newPost(leftChannel, userID, createTime+4, "")
+	newPost(channel1, otherUserID, createTime+5, "")
+	newPost(channel2, userID, createTime+6, model.PostTypeJoinChannel)
+	deleted := newPost(channel1, userID, createTime+7, "")
+	require.NoError(t, ss.Post().Delete(deleted.Id, model.GetMillis(), userID))
+
+	require.NoError(t, ss.Channel().RemoveMember(leftChannel.Id, userID))
+
+	// Posts sharing a CreateAt are ordered by id.
+	expected := []string{p5.Id, p3.Id, p4.Id, p2.Id, p1.Id}
+	if p3.Id < p4.Id {
+		expected = []string{p5.Id, p4.Id, p3.Id, p2.Id, p1.Id}
+	}
+
+	t.Run("all at once", func(t *testing.T) {
+		posts, cursor, err := ss.Post().GetRecentPostsForUser(userID, model.GetRecentPostsForUserCursor{}, 100)
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(posts))
+		for _, post := range posts {
+			ids = append(ids, post.Id)
+		}
+		assert.Equal(t, expected, ids)
+		assert.Equal(t, p1.CreateAt, cursor.LastPostCreateAt)
+		assert.Equal(t, p1.Id, cursor.LastPostId)
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		var ids []string
+		cursor := model.GetRecentPostsForUserCursor{}
+		for {
+			posts, nextCursor, err := ss.Post().GetRecentPostsForUser(userID, cursor, 2)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(posts), 2)
+			if len(posts) == 0 {
+				assert.Equal(t, cursor, nextCursor)
+				break
+			}
+
+			for _, post := range posts {
+				ids = append(ids, post.Id)
+			}
+			cursor = nextCursor
+		}
+		assert.Equal(t, expected, ids)
+	})
+
+	t.Run("user without posts", func(t *testing.T) {
+		posts, _, err := ss.Post().GetRecentPostsForUser(model.NewId(), model.GetRecentPostsForUserCursor{}, 100)
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+	})
+}
+
+func testPostStoreGetPostsBetween(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	userID := model.NewId()
+
+	save := func(channelID string, createAt int64) *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channelID,
+			UserId:    userID,
+			Message:   NewTestId(),
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		return post
+	}
+
+	ids := func(posts []*model.Post) []string {
+		postIDs := make([]string, 0, len(posts))
+		for _, post := range posts {
+			postIDs = append(postIDs, post.Id)
+		}
+		return postIDs
+	}
+
+	from := model.GetMillis() - 100000
+	to := from + 10000
+
+	save(channelID, from-1)
+	first := save(channelID, from)
+	deleted := save(channelID, from+4000)
+	middle := save(channelID, from+5000)
+	last := save(channelID, to)
+	save(channelID, to+1)
+	save(model.NewId(), from+5000)
+
+	err := ss.Post().Delete(deleted.Id, model.GetMillis(), userID)
+	require.NoError(t, err)
+
+	t.Run("both ends of the window are included", func(t *testing.T) {
+		posts, err := ss.Post().GetPostsBetween(channelID, from, to, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{first.Id, middle.Id, last.Id}, ids(posts))
+	})
+
+	t.Run("window of a single millisecond", func(t *testing.T) {
+		posts, err := ss.Post().GetPostsBetween(channelID, to, to, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{last.Id}, ids(posts))
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		posts, err := ss.Post().GetPostsBetween(channelID, from, to, 0, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{first.Id, middle.Id}, ids(posts))
+
+		posts, err = ss.Post().GetPostsBetween(channelID, from, to, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{last.Id}, ids(posts))
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		posts, err := ss.Post().GetPostsBetween(channelID, to+2, to+1000, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+	})
+}
+
+func testPostStoreGetPostsUpdatedSince(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	userID := model.NewId()
+
+	save := func(createAt int64) *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channelID,
+			UserId:    userID,
+			Message:   NewTestId(),
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		return post
+	}
+
+	ids := func(posts []*model.Post) []string {
+		postIDs := make([]string, 0, len(posts))
+		for _, post := range posts {
+			postIDs = append(postIDs, post.Id)
+		}
+		return postIDs
+	}
+
+	now := model.GetMillis()
+	old := save(now - 10000)
+	edited := save(now - 9000)
+	_, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    userID,
+		Message:   NewTestId(),
+	})
+	require.NoError(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+	since := model.GetMillis()
+	time.Sleep(2 * time.Millisecond)
+
+	recent := save(0)
+
+	t.Run("only posts updated after the given time", func(t *testing.T) {
+		posts, _, err := ss.Post().GetPostsUpdatedSince(channelID, model.GetPostsSinceForSyncCursor{LastPostUpdateAt: since}, 100)
+		require.NoError(t, err)
+		assert.Equal(t, []string{recent.Id}, ids(posts))
+
+		posts, _, err = ss.Post().GetPostsUpdatedSince(channelID, model.GetPostsSinceForSyncCursor{}, 100)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{old.Id, edited.Id, recent.Id}, ids(posts))
+	})
+
+	t.Run("paged by the cursor", func(t *testing.T) {
+		posts, cursor, err := ss.Post().GetPostsUpdatedSince(channelID, model.GetPostsSinceForSyncCursor{}, 2)
+		require.NoError(t, err)
+		require.Len(t, posts, 2)
+		assert.Equal(t, posts[1].UpdateAt, cursor.LastPostUpdateAt)
+		assert.Equal(t, posts[1].Id, cursor.LastPostId)
+
+		next, nextCursor, err := ss.Post().GetPostsUpdatedSince(channelID, cursor, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{recent.Id}, ids(next))
+
+		assert.ElementsMatch(t, []string{old.Id, edited.Id, recent.Id}, append(ids(posts), ids(next)...))
+
+		next, _, err = ss.Post().GetPostsUpdatedSince(channelID, nextCursor, 2)
+		require.NoError(t, err)
+		assert.Empty(t, next)
+	})
+
+	t.Run("edited old post reappears", func(t *testing.T) {
+		time.Sleep(2 * time.Millisecond)
+
+		// Update turns the old post it's given into the copy kept as the edit history, so pass it a clone.
+		editedPost := edited.Clone()
+		editedPost.Message = "edited"
+		_, err := ss.Post().Update(editedPost, edited.Clone())
+		require.NoError(t, err)
+
+		posts, _, err := ss.Post().GetPostsUpdatedSince(channelID, model.GetPostsSinceForSyncCursor{LastPostUpdateAt: since}, 100)
+		require.NoError(t, err)
+		require.Equal(t, []string{recent.Id, edited.Id}, ids(posts))
+		assert.Equal(t, "edited", posts[1].Message)
+	})
+
+	t.Run("deleted post is included", func(t *testing.T) {
+		time.Sleep(2 * time.Millisecond)
+
+		err := ss.Post().Delete(old.Id, model.GetMillis(), userID)
+		require.NoError(t, err)
+
+		posts, _, err := ss.Post().GetPostsUpdatedSince(channelID, model.GetPostsSinceForSyncCursor{LastPostUpdateAt: since}, 100)
+		require.NoError(t, err)
+		require.Equal(t, []string{recent.Id, edited.Id, old.Id}, ids(posts))
+		assert.NotZero(t, posts[2].DeleteAt)
+	})
+}
+
+func testPostStoreGetThreadParticipants(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	userID1 := model.NewId()
+	userID2 := model.NewId()
+	userID3 := model.NewId()
+	userID4 := model.NewId()
+
+	createTime := model.GetMillis()
+	newPost := func(userID, rootID string, createAt int64, postType string) *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channelID,
+			UserId:    userID,
+			RootId:    rootID,
+			Message:   NewTestId(),
+			CreateAt:  createAt,
+			Type:      postType,
+		})
+		require.NoError(t, err)
+		return post
+	}
+
+	root := newPost(userID1, "", createTime, "")
+	newPost(userID2, root.Id, createTime+1, "")
+	newPost(userID3, root.Id, createTime+2, "")
+	newPost(userID2, root.Id, createTime+3, "")
+	newPost(userID1, root.Id, createTime+4, "")
+	newPost(userID3, root.Id, createTime+5, "")
+
+	// None of these are counted.
+	deleted := newPost(userID4, root.Id, createTime+6, "")
+	require.NoError(t, ss.Post().Delete(deleted.Id, model.GetMillis(), userID4))
+	newPost(userID4, root.Id, createTime+7, model.PostTypeAddToChannel)
+	otherRoot := newPost(userID4, "", createTime+8, "")
+	newPost(userID4, otherRoot.Id, createTime+9, "")
+
+	t.Run("distinct and ordered by first reply", func(t *testing.T) {
+		participants, err := ss.Post().GetThreadParticipants(root.Id, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{userID2, userID3, userID1}, participants)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		participants, err := ss.Post().GetThreadParticipants(root.Id, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{userID2, userID3}, participants)
+	})
+
+	t.Run("thread without replies", func(t *testing.T) {
+		participants, err := ss.Post().GetThreadParticipants(newPost(userID1, "", createTime+10, "").Id, 10)
+		require.NoError(t, err)
+		assert.Empty(t, participants)
+	})
+}
+
+func testPostStoreGetPostsByHashtag(t *testing.T, ss store.Store) {
+	teamID := model.NewId()
+	userID := model.NewId()
+
+	newChannel := func(teamID string, channelType model.ChannelType) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamID,
+			DisplayName: "DisplayName",
+			Name:        "z-z-z" + model.NewId() + "b",
+			Type:        channelType,
+		}, -1)
+		require.NoError(t, err)
+		return channel
+	}
+	channel := newChannel(teamID, model.ChannelTypeOpen)
+	otherChannel := newChannel(teamID, model.ChannelTypeOpen)
+	otherTeamChannel := newChannel(model.NewId(), model.ChannelTypeOpen)
+	groupChannel := newChannel("", model.ChannelTypeGroup)
+
+	createTime := model.GetMillis()
+	newPost := func(channelID, hashtags string, createAt int64) *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channelID,
+			UserId:    userID,
+			Message:   hashtags,
+			Hashtags:  hashtags,
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		return post
+	}
+
+	tagged := newPost(channel.Id, "#mattermost", createTime)
+	taggedWithOthers := newPost(channel.Id, "#chat #Mattermost #server", createTime+1)
+	taggedInGroup := newPost(groupChannel.Id, "#mattermost", createTime+2)
+
+	// None of these are returned.
+	newPost(channel.Id, "#mattermost-server", createTime+3)
+	newPost(channel.Id, "#mattermostserver #supermattermost", createTime+4)
+	newPost(channel.Id, "", createTime+5)
+	newPost(otherChannel.Id, "#mattermost", createTime+6)
+	newPost(otherTeamChannel.Id, "#mattermost", createTime+7)
+	deleted := newPost(channel.Id, "#mattermost", createTime+8)
+	require.NoError(t, ss.Post().Delete(deleted.Id, model.GetMillis(), userID))
+
+	channelIDs := []string{channel.Id, otherTeamChannel.Id, groupChannel.Id}
+
+	t.Run("exact matches only", func(t *testing.T) {
+		list, err := ss.Post().GetPostsByHashtag(teamID, "#mattermost", channelIDs, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{taggedInGroup.Id, taggedWithOthers.Id, tagged.Id}, list.Order)
+		assert.Len(t, list.Posts, 3)
+	})
+
+	t.Run("case insensitive and without the leading hash", func(t *testing.T) {
+		list, err := ss.Post().GetPostsByHashtag(teamID, "MatterMost", channelIDs, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{taggedInGroup.Id, taggedWithOthers.Id, tagged.Id}, list.Order)
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		list, err := ss.Post().GetPostsByHashtag(teamID, "#mattermost", channelIDs, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{tagged.Id}, list.Order)
+	})
+
+	t.Run("tag only used as part of longer tags", func(t *testing.T) {
+		list, err := ss.Post().GetPostsByHashtag(teamID, "#super", channelIDs, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, list.Order)
+	})
+
+	t.Run("no channels", func(t *testing.T) {
+		list, err := ss.Post().GetPostsByHashtag(teamID, "#mattermost", []string{}, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, list.Order)
+	})
+}
+
 func testPostStoreGetPostsCreatedAt(t *testing.T, ss store.Store) {
 	createTime := model.GetMillis() + 1
 
@@ -2941,6 +3797,56 @@ func testPostStoreOverwrite(t *testing.T, ss store.Store) {
 	})
 }
 
+func testPostStoreGetEarliestPostTimeForChannels(t *testing.T, ss store.Store) {
+	channelID1 := model.NewId()
+	channelID2 := model.NewId()
+	channelID3 := model.NewId()
+
+	savePost := func(channelID string, createAt int64, postType string, deleteAt int64) {
+		t.Helper()
+
+		_, err := ss.Post().Save(&model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Message:   NewTestId(),
+			CreateAt:  createAt,
+			Type:      postType,
+			DeleteAt:  deleteAt,
+		})
+		require.NoError(t, err)
+	}
+
+	savePost(channelID1, 1000, model.PostTypeJoinChannel, 0)
+	savePost(channelID1, 2000, "", 0)
+	savePost(channelID1, 3000, "", 0)
+	savePost(channelID2, 1500, "", 1600)
+	savePost(channelID2, 2500, "", 0)
+
+	t.Run("including system posts", func(t *testing.T) {
+		times, err := ss.Post().GetEarliestPostTimeForChannels([]string{channelID1, channelID2, channelID3}, false)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int64{channelID1: 1000, channelID2: 2500}, times)
+	})
+
+	t.Run("excluding system posts", func(t *testing.T) {
+		times, err := ss.Post().GetEarliestPostTimeForChannels([]string{channelID1, channelID2, channelID3}, true)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int64{channelID1: 2000, channelID2: 2500}, times)
+	})
+
+	t.Run("subset of channels", func(t *testing.T) {
+		times, err := ss.Post().GetEarliestPostTimeForChannels([]string{channelID2}, false)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int64{channelID2: 2500}, times)
+	})
+
+	t.Run("no channels", func(t *testing.T) {
+		times, err := ss.Post().GetEarliestPostTimeForChannels([]string{}, false)
+		require.NoError(t, err)
+		assert.Empty(t, times)
+	})
+}
+
 func testPostStoreGetPostsByIds(t *testing.T, ss store.Store) {
 	o1 := &model.Post{}
 	o1.ChannelId = model.NewId()
@@ -3053,6 +3959,183 @@ func testPostStoreGetPostsBatchForIndexing(t *testing.T, ss store.Store) {
 	require.Len(t, r, 0, "Expected 0 post in results. Got %v", len(r))
 }
 
+func testPostStorePermanentDeleteBatchReturningIds(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	// Other tests may leave old posts behind, so only the posts created here are checked for.
+	savePosts := func(t *testing.T, count int, createAt int64) map[string]bool {
+		t.Helper()
+		ids := make(map[string]bool, count)
+		for i := 0; i < count; i++ {
+			post, err := ss.Post().Save(&model.Post{
+				ChannelId: channelId,
+				UserId:    model.NewId(),
+				Message:   NewTestId(),
+				CreateAt:  createAt,
+			})
+			require.NoError(t, err)
+			ids[post.Id] = true
+		}
+		return ids
+	}
+
+	requireDeleted := func(t *testing.T, ids []string) {
+		t.Helper()
+		for _, id := range ids {
+			_, err := ss.Post().GetSingle(id, true)
+			require.Error(t, err, "post %s should have been deleted", id)
+		}
+	}
+
+	t.Run("returns the ids of the deleted posts", func(t *testing.T) {
+		oldIds := savePosts(t, 3, 1000)
+		recent := savePosts(t, 1, 100000)
+
+		var deleted []string
+		for {
+			ids, err := ss.Post().PermanentDeleteBatchReturningIds(2000, 2)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(ids), 2)
+			if len(ids) == 0 {
+				break
+			}
+			requireDeleted(t, ids)
+			deleted = append(deleted, ids...)
+		}
+
+		for id := range oldIds {
+			assert.Contains(t, deleted, id)
+		}
+		for id := range recent {
+			assert.NotContains(t, deleted, id)
+			_, err := ss.Post().GetSingle(id, false)
+			require.NoError(t, err, "recent post shouldn't have been deleted")
+		}
+	})
+
+	t.Run("concurrent calls don't return the same ids", func(t *testing.T) {
+		oldIds := savePosts(t, 20, 1000)
+
+		var mut sync.Mutex
+		var wg sync.WaitGroup
+		deleted := map[string]int{}
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					ids, err := ss.Post().PermanentDeleteBatchReturningIds(2000, 3)
+					if !assert.NoError(t, err) || len(ids) == 0 {
+						return
+					}
+
+					mut.Lock()
+					for _, id := range ids {
+						deleted[id]++
+					}
+					mut.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		for id, count := range deleted {
+			assert.Equal(t, 1, count, "post %s returned more than once", id)
+		}
+		for id := range oldIds {
+			assert.Contains(t, deleted, id)
+		}
+	})
+}
+
+func testPostStorePermanentDeleteEditHistoryBatch(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	userID := model.NewId()
+
+	edit := func(post *model.Post) (*model.Post, *model.Post) {
+		original := post.Clone()
+		edited := post.Clone()
+		edited.Message = NewTestId()
+		edited.EditAt = model.GetMillis()
+		edited, err := ss.Post().Update(edited, original)
+		require.NoError(t, err)
+		// Update changes the given post into the copy of its original.
+		return edited, original
+	}
+
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: channelID,
+		UserId:    userID,
+		Message:   NewTestId(),
+	})
+	require.NoError(t, err)
+	post, original1 := edit(post)
+	time.Sleep(2 * time.Millisecond)
+	post, original2 := edit(post)
+	require.Equal(t, post.Id, original1.OriginalId)
+	require.Equal(t, post.Id, original2.OriginalId)
+
+	deletedPost, err := ss.Post().Save(&model.Post{
+		ChannelId: channelID,
+		UserId:    userID,
+		Message:   NewTestId(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, ss.Post().Delete(deletedPost.Id, original1.DeleteAt-1, userID))
+
+	exists := func(postID string) bool {
+		_, err := ss.Post().GetSingle(postID, true)
+		if err != nil {
+			var nfErr *store.ErrNotFound
+			require.ErrorAs(t, err, &nfErr)
+			return false
+		}
+		return true
+	}
+
+	t.Run("keeps the originals replaced within the window", func(t *testing.T) {
+		deleted, err := ss.Post().PermanentDeleteEditHistoryBatch(original1.DeleteAt, 10)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+		assert.True(t, exists(original1.Id))
+		assert.True(t, exists(original2.Id))
+	})
+
+	t.Run("purges the originals older than the window", func(t *testing.T) {
+		deleted, err := ss.Post().PermanentDeleteEditHistoryBatch(original2.DeleteAt, 10)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, deleted)
+		assert.False(t, exists(original1.Id))
+		assert.True(t, exists(original2.Id))
+	})
+
+	t.Run("limited to the batch size", func(t *testing.T) {
+		post, original3 := edit(post)
+
+		deleted, err := ss.Post().PermanentDeleteEditHistoryBatch(model.GetMillis()+1, 1)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, deleted)
+
+		deleted, err = ss.Post().PermanentDeleteEditHistoryBatch(model.GetMillis()+1, 1)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, deleted)
+		assert.False(t, exists(original2.Id))
+		assert.False(t, exists(original3.Id))
+
+		deleted, err = ss.Post().PermanentDeleteEditHistoryBatch(model.GetMillis()+1, 1)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+
+		current, err := ss.Post().GetSingle(post.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, post.Message, current.Message)
+	})
+
+	t.Run("keeps deleted posts that aren't edit history", func(t *testing.T) {
+		assert.True(t, exists(deletedPost.Id))
+	})
+}
+
 func testPostStorePermanentDeleteBatch(t *testing.T, ss store.Store) {
 	team, err := ss.Team().Save(&model.Team{
 		DisplayName: "DisplayName",
@@ -15,8 +15,10 @@ import (
 
 func TestPreferenceStore(t *testing.T, ss store.Store) {
 	t.Run("PreferenceSave", func(t *testing.T) { testPreferenceSave(t, ss) })
+	t.Run("PreferenceSaveIfNotExists", func(t *testing.T) { testPreferenceSaveIfNotExists(t, ss) })
 	t.Run("PreferenceGet", func(t *testing.T) { testPreferenceGet(t, ss) })
 	t.Run("PreferenceGetCategory", func(t *testing.T) { testPreferenceGetCategory(t, ss) })
+	t.Run("PreferenceGetCategoryAndName", func(t *testing.T) { testPreferenceGetCategoryAndName(t, ss) })
 	t.Run("PreferenceGetAll", func(t *testing.T) { testPreferenceGetAll(t, ss) })
 	t.Run("PreferenceDeleteByUser", func(t *testing.T) { testPreferenceDeleteByUser(t, ss) })
 	t.Run("PreferenceDelete", func(t *testing.T) { testPreferenceDelete(t, ss) })
@@ -61,6 +63,32 @@ func testPreferenceSave(t *testing.T, ss store.Store) {
 	}
 }
 
+func testPreferenceSaveIfNotExists(t *testing.T, ss store.Store) {
+	preference := &model.Preference{
+		UserId:   model.NewId(),
+		Category: model.PreferenceCategoryOnboarding,
+		Name:     model.NewId(),
+		Value:    "value1",
+	}
+
+	saved, err := ss.Preference().SaveIfNotExists(preference)
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	saved, err = ss.Preference().SaveIfNotExists(&model.Preference{
+		UserId:   preference.UserId,
+		Category: preference.Category,
+		Name:     preference.Name,
+		Value:    "value2",
+	})
+	require.NoError(t, err)
+	assert.False(t, saved)
+
+	data, err := ss.Preference().Get(preference.UserId, preference.Category, preference.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "value1", data.Value)
+}
+
 func testPreferenceGet(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 	category := model.PreferenceCategoryDirectChannelShow
@@ -150,6 +178,52 @@ func testPreferenceGetCategory(t *testing.T, ss store.Store) {
 	require.Equal(t, 0, len(preferencesByCategory), "shouldn't have got any preferences")
 }
 
+func testPreferenceGetCategoryAndName(t *testing.T, ss store.Store) {
+	category := model.PreferenceCategoryIgnoredUser
+	name := model.NewId()
+
+	preferences := model.Preferences{
+		{
+			UserId:   model.NewId(),
+			Category: category,
+			Name:     name,
+			Value:    "true",
+		},
+		// same category/name, different user
+		{
+			UserId:   model.NewId(),
+			Category: category,
+			Name:     name,
+			Value:    "true",
+		},
+		// same category, different name
+		{
+			UserId:   model.NewId(),
+			Category: category,
+			Name:     model.NewId(),
+			Value:    "true",
+		},
+		// same name, different category
+		{
+			UserId:   model.NewId(),
+			Category: model.NewId(),
+			Name:     name,
+			Value:    "true",
+		},
+	}
+
+	err := ss.Preference().Save(preferences)
+	require.NoError(t, err)
+
+	result, err := ss.Preference().GetCategoryAndName(category, name)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, model.Preferences{preferences[0], preferences[1]}, result)
+
+	result, err = ss.Preference().GetCategoryAndName(category, model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
 func testPreferenceGetAll(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 	category := model.PreferenceCategoryDirectChannelShow
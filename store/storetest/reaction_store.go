@@ -20,12 +20,16 @@ import (
 
 func TestReactionStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("ReactionSave", func(t *testing.T) { testReactionSave(t, ss) })
+	t.Run("ReactionSaveMultiple", func(t *testing.T) { testReactionSaveMultiple(t, ss) })
 	t.Run("ReactionDelete", func(t *testing.T) { testReactionDelete(t, ss) })
 	t.Run("ReactionGetForPost", func(t *testing.T) { testReactionGetForPost(t, ss) })
 	t.Run("ReactionGetForPostSince", func(t *testing.T) { testReactionGetForPostSince(t, ss, s) })
+	t.Run("ReactionGetDetailsForPost", func(t *testing.T) { testReactionGetDetailsForPost(t, ss) })
 	t.Run("ReactionDeleteAllWithEmojiName", func(t *testing.T) { testReactionDeleteAllWithEmojiName(t, ss, s) })
 	t.Run("PermanentDeleteBatch", func(t *testing.T) { testReactionStorePermanentDeleteBatch(t, ss) })
+	t.Run("ReactionGetOrphanedReactions", func(t *testing.T) { testReactionGetOrphanedReactions(t, ss) })
 	t.Run("ReactionBulkGetForPosts", func(t *testing.T) { testReactionBulkGetForPosts(t, ss) })
+	t.Run("ReactionGetForPosts", func(t *testing.T) { testReactionGetForPosts(t, ss) })
 	t.Run("ReactionDeadlock", func(t *testing.T) { testReactionDeadlock(t, ss) })
 }
 
@@ -112,6 +116,64 @@ func testReactionSave(t *testing.T, ss store.Store) {
 
 }
 
+func testReactionSaveMultiple(t *testing.T, ss store.Store) {
+	post1, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: model.NewId()})
+	require.NoError(t, err)
+	post2, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: model.NewId()})
+	require.NoError(t, err)
+
+	existing := &model.Reaction{UserId: model.NewId(), PostId: post1.Id, EmojiName: "smile"}
+	_, err = ss.Reaction().Save(existing)
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond)
+
+	userID := model.NewId()
+	reactions := []*model.Reaction{
+		{UserId: userID, PostId: post1.Id, EmojiName: "smile"},
+		{UserId: userID, PostId: post1.Id, EmojiName: "smile"},
+		{UserId: existing.UserId, PostId: existing.PostId, EmojiName: existing.EmojiName},
+		{UserId: userID, PostId: post2.Id, EmojiName: "+1"},
+	}
+	saved, err := ss.Reaction().SaveMultiple(reactions)
+	require.NoError(t, err)
+	require.Len(t, saved, len(reactions))
+
+	got, err := ss.Reaction().GetForPost(post1.Id, false)
+	require.NoError(t, err)
+	require.Len(t, got, 2, "should've saved the duplicate reactions once")
+
+	got, err = ss.Reaction().GetForPost(post2.Id, false)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	postList, err := ss.Post().Get(context.Background(), post2.Id, model.GetPostsOptions{}, "", map[string]bool{})
+	require.NoError(t, err)
+	assert.True(t, postList.Posts[post2.Id].HasReactions, "should've set HasReactions = true on post")
+	assert.NotEqual(t, post2.UpdateAt, postList.Posts[post2.Id].UpdateAt, "should've marked post as updated")
+
+	t.Run("invalid reaction", func(t *testing.T) {
+		post, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: model.NewId()})
+		require.NoError(t, err)
+
+		_, err = ss.Reaction().SaveMultiple([]*model.Reaction{
+			{UserId: model.NewId(), PostId: post.Id, EmojiName: "smile"},
+			{UserId: model.NewId(), PostId: post.Id},
+		})
+		require.Error(t, err)
+
+		got, err := ss.Reaction().GetForPost(post.Id, false)
+		require.NoError(t, err)
+		require.Empty(t, got, "shouldn't have saved any of the reactions")
+	})
+
+	t.Run("no reactions", func(t *testing.T) {
+		saved, err := ss.Reaction().SaveMultiple([]*model.Reaction{})
+		require.NoError(t, err)
+		require.Empty(t, saved)
+	})
+}
+
 func testReactionDelete(t *testing.T, ss store.Store) {
 	t.Run("Delete", func(t *testing.T) {
 		post, err := ss.Post().Save(&model.Post{
@@ -182,6 +244,77 @@ func testReactionDelete(t *testing.T, ss store.Store) {
 	})
 }
 
+func testReactionGetDetailsForPost(t *testing.T, ss store.Store) {
+	postId := model.NewId()
+	user1 := model.NewId()
+	user2 := model.NewId()
+	user3 := model.NewId()
+	createAt := model.GetMillis()
+
+	reactions := []*model.Reaction{
+		{UserId: user2, PostId: postId, EmojiName: "smile", CreateAt: createAt},
+		{UserId: user1, PostId: postId, EmojiName: "sad", CreateAt: createAt + 1},
+		{UserId: user3, PostId: postId, EmojiName: "smile", CreateAt: createAt + 2},
+		{UserId: user1, PostId: postId, EmojiName: "smile", CreateAt: createAt + 3},
+		{UserId: user2, PostId: postId, EmojiName: "sad", CreateAt: createAt + 4},
+		{UserId: user3, PostId: postId, EmojiName: "angry", CreateAt: createAt + 5},
+		{UserId: user1, PostId: model.NewId(), EmojiName: "smile", CreateAt: createAt},
+	}
+	for _, reaction := range reactions {
+		_, err := ss.Reaction().Save(reaction)
+		require.NoError(t, err)
+	}
+
+	_, err := ss.Reaction().Delete(reactions[5])
+	require.NoError(t, err)
+
+	userIds := func(users []*model.ReactionUser) []string {
+		ids := make([]string, 0, len(users))
+		for _, user := range users {
+			ids = append(ids, user.UserId)
+		}
+		return ids
+	}
+
+	t.Run("ordered by first use and reaction time", func(t *testing.T) {
+		details, err := ss.Reaction().GetDetailsForPost(postId, 0, 100)
+		require.NoError(t, err)
+		require.Len(t, details, 2)
+
+		assert.Equal(t, "smile", details[0].EmojiName)
+		assert.Equal(t, int64(3), details[0].Count)
+		assert.Equal(t, []string{user2, user3, user1}, userIds(details[0].Users))
+		assert.Equal(t, createAt, details[0].Users[0].CreateAt)
+
+		assert.Equal(t, "sad", details[1].EmojiName)
+		assert.Equal(t, int64(2), details[1].Count)
+		assert.Equal(t, []string{user1, user2}, userIds(details[1].Users))
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		details, err := ss.Reaction().GetDetailsForPost(postId, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, details, 2)
+
+		assert.Equal(t, int64(3), details[0].Count)
+		assert.Equal(t, []string{user3}, userIds(details[0].Users))
+		assert.Equal(t, int64(2), details[1].Count)
+		assert.Equal(t, []string{user2}, userIds(details[1].Users))
+
+		details, err = ss.Reaction().GetDetailsForPost(postId, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, details, 2)
+		assert.Equal(t, []string{user1}, userIds(details[0].Users))
+		assert.Empty(t, details[1].Users)
+	})
+
+	t.Run("post without reactions", func(t *testing.T) {
+		details, err := ss.Reaction().GetDetailsForPost(model.NewId(), 0, 100)
+		require.NoError(t, err)
+		assert.Empty(t, details)
+	})
+}
+
 func testReactionGetForPost(t *testing.T, ss store.Store) {
 	postId := model.NewId()
 
@@ -590,6 +723,68 @@ func testReactionStorePermanentDeleteBatch(t *testing.T, ss store.Store) {
 	require.Len(t, returned, 1, "reactions for newer post should not have been deleted")
 }
 
+func testReactionGetOrphanedReactions(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	orphanedPost, err := ss.Post().Save(&model.Post{
+		ChannelId: channelID,
+		UserId:    model.NewId(),
+	})
+	require.NoError(t, err)
+	livePost, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+	})
+	require.NoError(t, err)
+
+	reactions := []*model.Reaction{
+		{UserId: model.NewId(), PostId: orphanedPost.Id, EmojiName: "smile"},
+		{UserId: model.NewId(), PostId: orphanedPost.Id, EmojiName: "sad"},
+		{UserId: model.NewId(), PostId: livePost.Id, EmojiName: "smile"},
+	}
+	for _, reaction := range reactions {
+		_, err = ss.Reaction().Save(reaction)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, ss.Post().PermanentDeleteByChannel(channelID))
+
+	// Other tests can leave orphaned reactions behind too, so only look for the ones seeded here.
+	orphanedFor := func(t *testing.T) map[string]int {
+		t.Helper()
+		orphaned, err := ss.Reaction().GetOrphanedReactions(100000)
+		require.NoError(t, err)
+
+		counts := map[string]int{}
+		for _, reaction := range orphaned {
+			counts[reaction.PostId]++
+		}
+		return counts
+	}
+
+	counts := orphanedFor(t)
+	assert.Equal(t, 2, counts[orphanedPost.Id])
+	assert.Zero(t, counts[livePost.Id])
+
+	limited, err := ss.Reaction().GetOrphanedReactions(1)
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+
+	for {
+		deleted, err := ss.Reaction().DeleteOrphanedRows(1000)
+		require.NoError(t, err)
+		if deleted == 0 {
+			break
+		}
+	}
+
+	counts = orphanedFor(t)
+	assert.Zero(t, counts[orphanedPost.Id])
+
+	returned, err := ss.Reaction().GetForPost(livePost.Id, false)
+	require.NoError(t, err)
+	assert.Len(t, returned, 1)
+}
+
 func testReactionBulkGetForPosts(t *testing.T, ss store.Store) {
 	postId := model.NewId()
 	post2Id := model.NewId()
@@ -653,6 +848,65 @@ func testReactionBulkGetForPosts(t *testing.T, ss store.Store) {
 
 }
 
+func testReactionGetForPosts(t *testing.T, ss store.Store) {
+	post1Id := model.NewId()
+	post2Id := model.NewId()
+	post3Id := model.NewId()
+	otherPostId := model.NewId()
+	userId := model.NewId()
+
+	save := func(postId, emojiName string) *model.Reaction {
+		reaction, err := ss.Reaction().Save(&model.Reaction{
+			UserId:    userId,
+			PostId:    postId,
+			EmojiName: emojiName,
+		})
+		require.NoError(t, err)
+		return reaction
+	}
+
+	post1Smile := save(post1Id, "smile")
+	post1Angry := save(post1Id, "angry")
+	post2Smile := save(post2Id, "smile")
+	deleted := save(post2Id, "sad")
+	save(otherPostId, "smile")
+
+	_, err := ss.Reaction().Delete(deleted)
+	require.NoError(t, err)
+
+	t.Run("several posts", func(t *testing.T) {
+		reactions, err := ss.Reaction().GetForPosts([]string{post1Id, post2Id, post3Id})
+		require.NoError(t, err)
+		require.Len(t, reactions, 2)
+
+		emojiNames := func(reactions []*model.Reaction) []string {
+			names := []string{}
+			for _, reaction := range reactions {
+				names = append(names, reaction.EmojiName)
+			}
+			return names
+		}
+
+		assert.ElementsMatch(t, []string{post1Smile.EmojiName, post1Angry.EmojiName}, emojiNames(reactions[post1Id]))
+		assert.Equal(t, []string{post2Smile.EmojiName}, emojiNames(reactions[post2Id]))
+		assert.NotContains(t, reactions, post3Id)
+		assert.NotContains(t, reactions, otherPostId)
+
+		for postId, postReactions := range reactions {
+			for _, reaction := range postReactions {
+				assert.Equal(t, postId, reaction.PostId)
+				assert.Equal(t, userId, reaction.UserId)
+			}
+		}
+	})
+
+	t.Run("no posts", func(t *testing.T) {
+		reactions, err := ss.Reaction().GetForPosts([]string{})
+		require.NoError(t, err)
+		assert.Empty(t, reactions)
+	})
+}
+
 // testReactionDeadlock is a best-case attempt to recreate the deadlock scenario.
 // It at least deadlocks 2 times out of 5.
 func testReactionDeadlock(t *testing.T, ss store.Store) {
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestScheduledChannelDeletionStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testScheduledChannelDeletionStoreSaveGet(t, ss) })
+	t.Run("GetDue", func(t *testing.T) { testScheduledChannelDeletionStoreGetDue(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testScheduledChannelDeletionStoreDelete(t, ss) })
+}
+
+func testScheduledChannelDeletionStoreSaveGet(t *testing.T, ss store.Store) {
+	deletion, err := ss.ScheduledChannelDeletion().Save(&model.ScheduledChannelDeletion{ChannelId: model.NewId(), DeleteAt: model.GetMillis() + 1000})
+	require.NoError(t, err)
+	require.NotZero(t, deletion.CreateAt)
+
+	got, err := ss.ScheduledChannelDeletion().Get(deletion.ChannelId)
+	require.NoError(t, err)
+	require.Equal(t, deletion, got)
+
+	t.Run("saving again replaces the deletion", func(t *testing.T) {
+		replaced, err := ss.ScheduledChannelDeletion().Save(&model.ScheduledChannelDeletion{ChannelId: deletion.ChannelId, DeleteAt: deletion.DeleteAt + 1000})
+		require.NoError(t, err)
+
+		got, err := ss.ScheduledChannelDeletion().Get(deletion.ChannelId)
+		require.NoError(t, err)
+		require.Equal(t, replaced, got)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		_, err := ss.ScheduledChannelDeletion().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("save invalid", func(t *testing.T) {
+		_, err := ss.ScheduledChannelDeletion().Save(&model.ScheduledChannelDeletion{ChannelId: model.NewId()})
+		require.Error(t, err)
+	})
+}
+
+func testScheduledChannelDeletionStoreGetDue(t *testing.T, ss store.Store) {
+	// Use times far in the past so that deletions saved by other tests aren't due.
+	now := int64(1000)
+
+	later, err := ss.ScheduledChannelDeletion().Save(&model.ScheduledChannelDeletion{ChannelId: model.NewId(), DeleteAt: now - 10})
+	require.NoError(t, err)
+
+	sooner, err := ss.ScheduledChannelDeletion().Save(&model.ScheduledChannelDeletion{ChannelId: model.NewId(), DeleteAt: now - 20})
+	require.NoError(t, err)
+
+	_, err = ss.ScheduledChannelDeletion().Save(&model.ScheduledChannelDeletion{ChannelId: model.NewId(), DeleteAt: now + 10})
+	require.NoError(t, err)
+
+	due, err := ss.ScheduledChannelDeletion().GetDue(now, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*model.ScheduledChannelDeletion{sooner, later}, due)
+
+	due, err = ss.ScheduledChannelDeletion().GetDue(now, 1)
+	require.NoError(t, err)
+	require.Equal(t, []*model.ScheduledChannelDeletion{sooner}, due)
+
+	for _, deletion := range []*model.ScheduledChannelDeletion{sooner, later} {
+		require.NoError(t, ss.ScheduledChannelDeletion().Delete(deletion.ChannelId))
+	}
+}
+
+func testScheduledChannelDeletionStoreDelete(t *testing.T, ss store.Store) {
+	deletion, err := ss.ScheduledChannelDeletion().Save(&model.ScheduledChannelDeletion{ChannelId: model.NewId(), DeleteAt: model.GetMillis()})
+	require.NoError(t, err)
+
+	err = ss.ScheduledChannelDeletion().Delete(deletion.ChannelId)
+	require.NoError(t, err)
+
+	var nfErr *store.ErrNotFound
+	_, err = ss.ScheduledChannelDeletion().Get(deletion.ChannelId)
+	require.True(t, errors.As(err, &nfErr))
+
+	err = ss.ScheduledChannelDeletion().Delete(deletion.ChannelId)
+	require.True(t, errors.As(err, &nfErr))
+}
@@ -21,6 +21,7 @@ const (
 func TestSessionStore(t *testing.T, ss store.Store) {
 	// Run serially to prevent interfering with other tests
 	testSessionCleanup(t, ss)
+	testSessionRemoveExpiredSessions(t, ss)
 
 	t.Run("Save", func(t *testing.T) { testSessionStoreSave(t, ss) })
 	t.Run("SessionGet", func(t *testing.T) { testSessionGet(t, ss) })
@@ -422,3 +423,46 @@ func testUpdateExpiredNotify(t *testing.T, ss store.Store) {
 	require.NoError(t, err)
 	require.False(t, session.ExpiredNotify)
 }
+
+func testSessionRemoveExpiredSessions(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	var live []*model.Session
+	for _, expiresAt := range []int64{0, now + 1000000} {
+		session, err := ss.Session().Save(&model.Session{UserId: model.NewId(), ExpiresAt: expiresAt})
+		require.NoError(t, err)
+		live = append(live, session)
+	}
+
+	var expired []*model.Session
+	for _, expiresAt := range []int64{1, 2, now - 1} {
+		session, err := ss.Session().Save(&model.Session{UserId: model.NewId(), ExpiresAt: expiresAt})
+		require.NoError(t, err)
+		expired = append(expired, session)
+	}
+
+	removed, err := ss.Session().RemoveExpiredSessions(now, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), removed)
+
+	total := removed
+	for removed > 0 {
+		removed, err = ss.Session().RemoveExpiredSessions(now, 2)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, removed, int64(2))
+		total += removed
+	}
+	assert.GreaterOrEqual(t, total, int64(len(expired)))
+
+	for _, session := range expired {
+		_, err = ss.Session().Get(context.Background(), session.Id)
+		assert.Error(t, err)
+	}
+
+	for _, session := range live {
+		_, err = ss.Session().Get(context.Background(), session.Id)
+		assert.NoError(t, err)
+
+		require.NoError(t, ss.Session().Remove(session.Id))
+	}
+}
@@ -17,44 +17,49 @@ import (
 
 // Store can be used to provide mock stores for testing.
 type Store struct {
-	TeamStore                 mocks.TeamStore
-	ChannelStore              mocks.ChannelStore
-	PostStore                 mocks.PostStore
-	UserStore                 mocks.UserStore
-	RetentionPolicyStore      mocks.RetentionPolicyStore
-	BotStore                  mocks.BotStore
-	AuditStore                mocks.AuditStore
-	ClusterDiscoveryStore     mocks.ClusterDiscoveryStore
-	RemoteClusterStore        mocks.RemoteClusterStore
-	ComplianceStore           mocks.ComplianceStore
-	SessionStore              mocks.SessionStore
-	OAuthStore                mocks.OAuthStore
-	SystemStore               mocks.SystemStore
-	WebhookStore              mocks.WebhookStore
-	CommandStore              mocks.CommandStore
-	CommandWebhookStore       mocks.CommandWebhookStore
-	PreferenceStore           mocks.PreferenceStore
-	LicenseStore              mocks.LicenseStore
-	TokenStore                mocks.TokenStore
-	EmojiStore                mocks.EmojiStore
-	ThreadStore               mocks.ThreadStore
-	StatusStore               mocks.StatusStore
-	FileInfoStore             mocks.FileInfoStore
-	UploadSessionStore        mocks.UploadSessionStore
-	ReactionStore             mocks.ReactionStore
-	JobStore                  mocks.JobStore
-	UserAccessTokenStore      mocks.UserAccessTokenStore
-	PluginStore               mocks.PluginStore
-	ChannelMemberHistoryStore mocks.ChannelMemberHistoryStore
-	RoleStore                 mocks.RoleStore
-	SchemeStore               mocks.SchemeStore
-	TermsOfServiceStore       mocks.TermsOfServiceStore
-	GroupStore                mocks.GroupStore
-	UserTermsOfServiceStore   mocks.UserTermsOfServiceStore
-	LinkMetadataStore         mocks.LinkMetadataStore
-	SharedChannelStore        mocks.SharedChannelStore
-	ProductNoticesStore       mocks.ProductNoticesStore
-	context                   context.Context
+	TeamStore                     mocks.TeamStore
+	ChannelStore                  mocks.ChannelStore
+	PostStore                     mocks.PostStore
+	UserStore                     mocks.UserStore
+	RetentionPolicyStore          mocks.RetentionPolicyStore
+	BotStore                      mocks.BotStore
+	AuditStore                    mocks.AuditStore
+	ClusterDiscoveryStore         mocks.ClusterDiscoveryStore
+	RemoteClusterStore            mocks.RemoteClusterStore
+	ComplianceStore               mocks.ComplianceStore
+	SessionStore                  mocks.SessionStore
+	OAuthStore                    mocks.OAuthStore
+	SystemStore                   mocks.SystemStore
+	WebhookStore                  mocks.WebhookStore
+	CommandStore                  mocks.CommandStore
+	CommandWebhookStore           mocks.CommandWebhookStore
+	PreferenceStore               mocks.PreferenceStore
+	LicenseStore                  mocks.LicenseStore
+	TokenStore                    mocks.TokenStore
+	EmojiStore                    mocks.EmojiStore
+	ThreadStore                   mocks.ThreadStore
+	StatusStore                   mocks.StatusStore
+	FileInfoStore                 mocks.FileInfoStore
+	UploadSessionStore            mocks.UploadSessionStore
+	ReactionStore                 mocks.ReactionStore
+	JobStore                      mocks.JobStore
+	UserAccessTokenStore          mocks.UserAccessTokenStore
+	PluginStore                   mocks.PluginStore
+	ChannelMemberHistoryStore     mocks.ChannelMemberHistoryStore
+	RoleStore                     mocks.RoleStore
+	SchemeStore                   mocks.SchemeStore
+	TermsOfServiceStore           mocks.TermsOfServiceStore
+	GroupStore                    mocks.GroupStore
+	UserTermsOfServiceStore       mocks.UserTermsOfServiceStore
+	LinkMetadataStore             mocks.LinkMetadataStore
+	SharedChannelStore            mocks.SharedChannelStore
+	ProductNoticesStore           mocks.ProductNoticesStore
+	PostReportStore               mocks.PostReportStore
+	PostReminderStore             mocks.PostReminderStore
+	ChannelTemplateStore          mocks.ChannelTemplateStore
+	GuestChannelInviteStore       mocks.GuestChannelInviteStore
+	ScheduledChannelDeletionStore mocks.ScheduledChannelDeletionStore
+	context                       context.Context
 }
 
 func (s *Store) SetContext(context context.Context)                { s.context = context }
@@ -95,19 +100,26 @@ func (s *Store) UserTermsOfService() store.UserTermsOfServiceStore { return &s.U
 func (s *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return &s.ChannelMemberHistoryStore
 }
-func (s *Store) Group() store.GroupStore                 { return &s.GroupStore }
-func (s *Store) LinkMetadata() store.LinkMetadataStore   { return &s.LinkMetadataStore }
-func (s *Store) SharedChannel() store.SharedChannelStore { return &s.SharedChannelStore }
-func (s *Store) MarkSystemRanUnitTests()                 { /* do nothing */ }
-func (s *Store) Close()                                  { /* do nothing */ }
-func (s *Store) LockToMaster()                           { /* do nothing */ }
-func (s *Store) UnlockFromMaster()                       { /* do nothing */ }
-func (s *Store) DropAllTables()                          { /* do nothing */ }
-func (s *Store) GetDbVersion(bool) (string, error)       { return "", nil }
-func (s *Store) GetInternalMasterDB() *sql.DB            { return nil }
-func (s *Store) GetInternalReplicaDBs() []*sql.DB        { return nil }
-func (s *Store) RecycleDBConnections(time.Duration)      {}
-func (s *Store) GetDBSchemaVersion() (int, error)        { return 1, nil }
+func (s *Store) Group() store.GroupStore                           { return &s.GroupStore }
+func (s *Store) LinkMetadata() store.LinkMetadataStore             { return &s.LinkMetadataStore }
+func (s *Store) SharedChannel() store.SharedChannelStore           { return &s.SharedChannelStore }
+func (s *Store) PostReport() store.PostReportStore                 { return &s.PostReportStore }
+func (s *Store) PostReminder() store.PostReminderStore             { return &s.PostReminderStore }
+func (s *Store) ChannelTemplate() store.ChannelTemplateStore       { return &s.ChannelTemplateStore }
+func (s *Store) GuestChannelInvite() store.GuestChannelInviteStore { return &s.GuestChannelInviteStore }
+func (s *Store) ScheduledChannelDeletion() store.ScheduledChannelDeletionStore {
+	return &s.ScheduledChannelDeletionStore
+}
+func (s *Store) MarkSystemRanUnitTests()            { /* do nothing */ }
+func (s *Store) Close()                             { /* do nothing */ }
+func (s *Store) LockToMaster()                      { /* do nothing */ }
+func (s *Store) UnlockFromMaster()                  { /* do nothing */ }
+func (s *Store) DropAllTables()                     { /* do nothing */ }
+func (s *Store) GetDbVersion(bool) (string, error)  { return "", nil }
+func (s *Store) GetInternalMasterDB() *sql.DB       { return nil }
+func (s *Store) GetInternalReplicaDBs() []*sql.DB   { return nil }
+func (s *Store) RecycleDBConnections(time.Duration) {}
+func (s *Store) GetDBSchemaVersion() (int, error)   { return 1, nil }
 func (s *Store) GetAppliedMigrations() ([]model.AppliedMigration, error) {
 	return []model.AppliedMigration{}, nil
 }
@@ -154,5 +166,10 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.ThreadStore,
 		&s.ProductNoticesStore,
 		&s.SharedChannelStore,
+		&s.PostReportStore,
+		&s.PostReminderStore,
+		&s.ChannelTemplateStore,
+		&s.GuestChannelInviteStore,
+		&s.ScheduledChannelDeletionStore,
 	)
 }
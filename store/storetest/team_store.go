@@ -60,15 +60,19 @@ func TestTeamStore(t *testing.T, ss store.Store) {
 	t.Run("SaveTeamMemberMaxMembers", func(t *testing.T) { testSaveTeamMemberMaxMembers(t, ss) })
 	t.Run("GetTeamMember", func(t *testing.T) { testGetTeamMember(t, ss) })
 	t.Run("GetTeamMembersByIds", func(t *testing.T) { testGetTeamMembersByIds(t, ss) })
+	t.Run("GetTeamMembersByIdsInOrder", func(t *testing.T) { testGetTeamMembersByIdsInOrder(t, ss) })
 	t.Run("MemberCount", func(t *testing.T) { testTeamStoreMemberCount(t, ss) })
+	t.Run("GetTeamsWithMemberCount", func(t *testing.T) { testTeamStoreGetTeamsWithMemberCount(t, ss) })
 	t.Run("GetChannelUnreadsForAllTeams", func(t *testing.T) { testGetChannelUnreadsForAllTeams(t, ss) })
 	t.Run("GetChannelUnreadsForTeam", func(t *testing.T) { testGetChannelUnreadsForTeam(t, ss) })
 	t.Run("UpdateLastTeamIconUpdate", func(t *testing.T) { testUpdateLastTeamIconUpdate(t, ss) })
+	t.Run("IncrementInviteUses", func(t *testing.T) { testTeamStoreIncrementInviteUses(t, ss) })
 	t.Run("GetTeamsByScheme", func(t *testing.T) { testGetTeamsByScheme(t, ss) })
 	t.Run("MigrateTeamMembers", func(t *testing.T) { testTeamStoreMigrateTeamMembers(t, ss) })
 	t.Run("ResetAllTeamSchemes", func(t *testing.T) { testResetAllTeamSchemes(t, ss) })
 	t.Run("ClearAllCustomRoleAssignments", func(t *testing.T) { testTeamStoreClearAllCustomRoleAssignments(t, ss) })
 	t.Run("AnalyticsGetTeamCountForScheme", func(t *testing.T) { testTeamStoreAnalyticsGetTeamCountForScheme(t, ss) })
+	t.Run("GetMemberCountsByScheme", func(t *testing.T) { testTeamStoreGetMemberCountsByScheme(t, ss) })
 	t.Run("GetAllForExportAfter", func(t *testing.T) { testTeamStoreGetAllForExportAfter(t, ss) })
 	t.Run("GetTeamMembersForExport", func(t *testing.T) { testTeamStoreGetTeamMembersForExport(t, ss) })
 	t.Run("GetTeamsForUserWithPagination", func(t *testing.T) { testTeamMembersWithPagination(t, ss) })
@@ -104,9 +108,16 @@ func testTeamStoreUpdate(t *testing.T, ss store.Store) {
 
 	time.Sleep(100 * time.Millisecond)
 
+	o1.DefaultChannels = model.StringArray{"off-topic", "announcements"}
+	o1.DefaultTheme = `{"sidebarBg":"#145dbf"}`
 	_, err = ss.Team().Update(&o1)
 	require.NoError(t, err)
 
+	r1, err := ss.Team().Get(o1.Id)
+	require.NoError(t, err)
+	require.Equal(t, o1.DefaultChannels, r1.DefaultChannels)
+	require.Equal(t, o1.DefaultTheme, r1.DefaultTheme)
+
 	o1.Id = "missing"
 	_, err = ss.Team().Update(&o1)
 	require.Error(t, err, "Update should have failed because of missing key")
@@ -3064,6 +3075,70 @@ func testGetTeamMembersByIds(t *testing.T, ss store.Store) {
 	require.Error(t, err, "empty user ids - should have failed")
 }
 
+func testGetTeamMembersByIdsInOrder(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	var userIds []string
+	for i := 0; i < 4; i++ {
+		member, err := ss.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: model.NewId()}, -1)
+		require.NoError(t, err)
+		userIds = append(userIds, member.UserId)
+	}
+
+	// A member of another team and a member who has left the team are both missing.
+	otherTeamMember, err := ss.Team().SaveMember(&model.TeamMember{TeamId: model.NewId(), UserId: model.NewId()}, -1)
+	require.NoError(t, err)
+	leftMember, err := ss.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: model.NewId(), DeleteAt: model.GetMillis()}, -1)
+	require.NoError(t, err)
+
+	memberUserIds := func(members []*model.TeamMember) []string {
+		ids := make([]string, 0, len(members))
+		for _, member := range members {
+			require.Equal(t, teamId, member.TeamId)
+			ids = append(ids, member.UserId)
+		}
+		return ids
+	}
+
+	t.Run("preserves the order of the ids", func(t *testing.T) {
+		requested := []string{userIds[2], userIds[0], userIds[3], userIds[1]}
+		members, missing, err := ss.Team().GetMembersByIdsInOrder(teamId, requested, nil)
+		require.NoError(t, err)
+		assert.Equal(t, requested, memberUserIds(members))
+		assert.Empty(t, missing)
+
+		requested = []string{userIds[1], userIds[3], userIds[0], userIds[2]}
+		members, _, err = ss.Team().GetMembersByIdsInOrder(teamId, requested, nil)
+		require.NoError(t, err)
+		assert.Equal(t, requested, memberUserIds(members))
+	})
+
+	t.Run("reports missing ids in order", func(t *testing.T) {
+		unknownId := model.NewId()
+		requested := []string{unknownId, userIds[1], leftMember.UserId, userIds[0], otherTeamMember.UserId}
+		members, missing, err := ss.Team().GetMembersByIdsInOrder(teamId, requested, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{userIds[1], userIds[0]}, memberUserIds(members))
+		assert.Equal(t, []string{unknownId, leftMember.UserId, otherTeamMember.UserId}, missing)
+	})
+
+	t.Run("ignores duplicate ids", func(t *testing.T) {
+		unknownId := model.NewId()
+		requested := []string{userIds[3], unknownId, userIds[2], userIds[3], unknownId}
+		members, missing, err := ss.Team().GetMembersByIdsInOrder(teamId, requested, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{userIds[3], userIds[2]}, memberUserIds(members))
+		assert.Equal(t, []string{unknownId}, missing)
+	})
+
+	t.Run("empty list of ids", func(t *testing.T) {
+		members, missing, err := ss.Team().GetMembersByIdsInOrder(teamId, []string{}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, members)
+		assert.Empty(t, missing)
+	})
+}
+
 func testTeamStoreMemberCount(t *testing.T, ss store.Store) {
 	u1 := &model.User{}
 	u1.Email = MakeEmail()
@@ -3108,6 +3183,98 @@ func testTeamStoreMemberCount(t *testing.T, ss store.Store) {
 	require.Equal(t, 1, int(result), "wrong count")
 }
 
+func testTeamStoreGetTeamsWithMemberCount(t *testing.T, ss store.Store) {
+	saveTeam := func(t *testing.T) *model.Team {
+		t.Helper()
+		team, err := ss.Team().Save(&model.Team{
+			DisplayName: "DisplayName",
+			Name:        "zz" + model.NewId() + "b",
+			Email:       MakeEmail(),
+			Type:        model.TeamOpen,
+		})
+		require.NoError(t, err)
+		return team
+	}
+
+	saveUser := func(t *testing.T, deleteAt int64) *model.User {
+		t.Helper()
+		user, err := ss.User().Save(&model.User{
+			Email:    MakeEmail(),
+			Username: model.NewId(),
+			DeleteAt: deleteAt,
+		})
+		require.NoError(t, err)
+		return user
+	}
+
+	team1 := saveTeam(t)
+	team2 := saveTeam(t)
+	emptyTeam := saveTeam(t)
+
+	active1 := saveUser(t, 0)
+	active2 := saveUser(t, 0)
+	deactivated := saveUser(t, model.GetMillis())
+	leaver := saveUser(t, 0)
+
+	for _, member := range []*model.TeamMember{
+		{TeamId: team1.Id, UserId: active1.Id},
+		{TeamId: team1.Id, UserId: active2.Id},
+		{TeamId: team1.Id, UserId: deactivated.Id},
+		{TeamId: team1.Id, UserId: leaver.Id},
+		{TeamId: team2.Id, UserId: active1.Id},
+		{TeamId: team2.Id, UserId: deactivated.Id},
+	} {
+		_, err := ss.Team().SaveMember(member, -1)
+		require.NoError(t, err)
+	}
+
+	leaverMember, err := ss.Team().GetMember(context.Background(), team1.Id, leaver.Id)
+	require.NoError(t, err)
+	leaverMember.DeleteAt = model.GetMillis()
+	_, err = ss.Team().UpdateMember(leaverMember)
+	require.NoError(t, err)
+
+	t.Run("counts only active members", func(t *testing.T) {
+		teams, err := ss.Team().GetTeamsWithMemberCount(0, 10000)
+		require.NoError(t, err)
+
+		counts := map[string]int64{}
+		for _, team := range teams {
+			counts[team.Id] = team.ActiveMemberCount
+		}
+
+		require.Contains(t, counts, team1.Id)
+		require.Equal(t, int64(2), counts[team1.Id])
+		require.Contains(t, counts, team2.Id)
+		require.Equal(t, int64(1), counts[team2.Id])
+		require.Contains(t, counts, emptyTeam.Id)
+		require.Equal(t, int64(0), counts[emptyTeam.Id])
+
+		for _, team := range teams {
+			if team.Id == team1.Id {
+				require.Equal(t, team1.Name, team.Name)
+				require.Equal(t, team1.DisplayName, team.DisplayName)
+			}
+		}
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		all, err := ss.Team().GetTeamsWithMemberCount(0, 10000)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(all), 3)
+
+		first, err := ss.Team().GetTeamsWithMemberCount(0, 2)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+
+		second, err := ss.Team().GetTeamsWithMemberCount(2, 1)
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+
+		require.Equal(t, all[:3], append(first, second...))
+	})
+}
+
 func testGetChannelUnreadsForAllTeams(t *testing.T, ss store.Store) {
 	teamId1 := model.NewId()
 	teamId2 := model.NewId()
@@ -3221,6 +3388,96 @@ func testUpdateLastTeamIconUpdate(t *testing.T, ss store.Store) {
 	require.Greater(t, ro1.LastTeamIconUpdate, lastTeamIconUpdateInitial, "LastTeamIconUpdate not updated")
 }
 
+func testTeamStoreIncrementInviteUses(t *testing.T, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName:   "DisplayName",
+		Name:          NewTestId(),
+		Email:         MakeEmail(),
+		Type:          model.TeamOpen,
+		InviteMaxUses: 2,
+	})
+	require.NoError(t, err)
+
+	t.Run("counts uses up to the limit", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			used, err := ss.Team().IncrementInviteUses(team.Id, team.InviteId)
+			require.NoError(t, err)
+			require.True(t, used)
+		}
+
+		used, err := ss.Team().IncrementInviteUses(team.Id, team.InviteId)
+		require.NoError(t, err)
+		require.False(t, used)
+
+		team, err = ss.Team().Get(team.Id)
+		require.NoError(t, err)
+		require.Equal(t, 2, team.InviteUses)
+	})
+
+	t.Run("updating the team keeps the uses", func(t *testing.T) {
+		team.InviteUses = 0
+		team.InviteMaxUses = 3
+		_, err := ss.Team().Update(team)
+		require.NoError(t, err)
+
+		team, err = ss.Team().Get(team.Id)
+		require.NoError(t, err)
+		require.Equal(t, 2, team.InviteUses)
+		require.Equal(t, 3, team.InviteMaxUses)
+	})
+
+	t.Run("old invite id", func(t *testing.T) {
+		used, err := ss.Team().IncrementInviteUses(team.Id, model.NewId())
+		require.NoError(t, err)
+		require.False(t, used)
+	})
+
+	t.Run("regenerating the invite id resets the uses", func(t *testing.T) {
+		team.InviteId = model.NewId()
+		_, err := ss.Team().Update(team)
+		require.NoError(t, err)
+
+		team, err = ss.Team().Get(team.Id)
+		require.NoError(t, err)
+		require.Equal(t, 0, team.InviteUses)
+
+		used, err := ss.Team().IncrementInviteUses(team.Id, team.InviteId)
+		require.NoError(t, err)
+		require.True(t, used)
+	})
+
+	t.Run("releasing a use", func(t *testing.T) {
+		require.NoError(t, ss.Team().ReleaseInviteUse(team.Id, team.InviteId))
+
+		team, err = ss.Team().Get(team.Id)
+		require.NoError(t, err)
+		require.Equal(t, 0, team.InviteUses)
+
+		// there are no uses left to release, and old invite ids aren't released
+		require.NoError(t, ss.Team().ReleaseInviteUse(team.Id, team.InviteId))
+		used, err := ss.Team().IncrementInviteUses(team.Id, team.InviteId)
+		require.NoError(t, err)
+		require.True(t, used)
+		require.NoError(t, ss.Team().ReleaseInviteUse(team.Id, model.NewId()))
+
+		team, err = ss.Team().Get(team.Id)
+		require.NoError(t, err)
+		require.Equal(t, 1, team.InviteUses)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		team.InviteMaxUses = 0
+		_, err := ss.Team().Update(team)
+		require.NoError(t, err)
+
+		for i := 0; i < 5; i++ {
+			used, err := ss.Team().IncrementInviteUses(team.Id, team.InviteId)
+			require.NoError(t, err)
+			require.True(t, used)
+		}
+	})
+}
+
 func testGetTeamsByScheme(t *testing.T, ss store.Store) {
 	// Create some schemes.
 	s1 := &model.Scheme{
@@ -3451,6 +3708,86 @@ func testTeamStoreClearAllCustomRoleAssignments(t *testing.T, ss store.Store) {
 	assert.Equal(t, "", r4.Roles)
 }
 
+func testTeamStoreGetMemberCountsByScheme(t *testing.T, ss store.Store) {
+	saveScheme := func(t *testing.T) *model.Scheme {
+		t.Helper()
+		scheme, err := ss.Scheme().Save(&model.Scheme{
+			DisplayName: NewTestId(),
+			Name:        NewTestId(),
+			Description: NewTestId(),
+			Scope:       model.SchemeScopeTeam,
+		})
+		require.NoError(t, err)
+		return scheme
+	}
+
+	saveTeam := func(t *testing.T, schemeID *string) *model.Team {
+		t.Helper()
+		team, err := ss.Team().Save(&model.Team{
+			Name:        NewTestId(),
+			DisplayName: NewTestId(),
+			Email:       MakeEmail(),
+			Type:        model.TeamOpen,
+			SchemeId:    schemeID,
+		})
+		require.NoError(t, err)
+		return team
+	}
+
+	saveUser := func(t *testing.T, deleteAt int64) *model.User {
+		t.Helper()
+		user, err := ss.User().Save(&model.User{
+			Email:    MakeEmail(),
+			Username: model.NewId(),
+			DeleteAt: deleteAt,
+		})
+		require.NoError(t, err)
+		return user
+	}
+
+	scheme1 := saveScheme(t)
+	scheme2 := saveScheme(t)
+	unusedScheme := saveScheme(t)
+
+	scheme1Team1 := saveTeam(t, &scheme1.Id)
+	scheme1Team2 := saveTeam(t, &scheme1.Id)
+	scheme2Team := saveTeam(t, &scheme2.Id)
+	deletedTeam := saveTeam(t, &scheme2.Id)
+	defaultTeam := saveTeam(t, nil)
+
+	user1 := saveUser(t, 0)
+	user2 := saveUser(t, 0)
+	user3 := saveUser(t, 0)
+	deactivated := saveUser(t, model.GetMillis())
+
+	for _, member := range []*model.TeamMember{
+		{TeamId: scheme1Team1.Id, UserId: user1.Id},
+		{TeamId: scheme1Team1.Id, UserId: user2.Id},
+		{TeamId: scheme1Team1.Id, UserId: deactivated.Id},
+		{TeamId: scheme1Team2.Id, UserId: user1.Id},
+		{TeamId: scheme1Team2.Id, UserId: user3.Id},
+		{TeamId: scheme2Team.Id, UserId: user2.Id},
+		{TeamId: deletedTeam.Id, UserId: user3.Id},
+		{TeamId: defaultTeam.Id, UserId: user1.Id},
+	} {
+		_, err := ss.Team().SaveMember(member, -1)
+		require.NoError(t, err)
+	}
+
+	deletedTeam.DeleteAt = model.GetMillis()
+	_, err := ss.Team().Update(deletedTeam)
+	require.NoError(t, err)
+
+	counts, err := ss.Team().GetMemberCountsByScheme()
+	require.NoError(t, err)
+
+	// Members of several teams on the same scheme are only counted once.
+	assert.Equal(t, int64(3), counts[scheme1.Id])
+	assert.Equal(t, int64(1), counts[scheme2.Id])
+	assert.NotContains(t, counts, unusedScheme.Id)
+	assert.GreaterOrEqual(t, counts[""], int64(1))
+}
+
 func testTeamStoreAnalyticsGetTeamCountForScheme(t *testing.T, ss store.Store) {
 	s1 := &model.Scheme{
 		DisplayName: NewTestId(),
@@ -27,6 +27,7 @@ func TestThreadStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("GetTeamsUnreadForUser", func(t *testing.T) { testGetTeamsUnreadForUser(t, ss) })
 	t.Run("GetVarious", func(t *testing.T) { testVarious(t, ss) })
 	t.Run("MarkAllAsReadByChannels", func(t *testing.T) { testMarkAllAsReadByChannels(t, ss) })
+	t.Run("RecomputeReplyCounts", func(t *testing.T) { testThreadStoreRecomputeReplyCounts(t, ss, s) })
 }
 
 func testThreadStorePopulation(t *testing.T, ss store.Store) {
@@ -1226,3 +1227,79 @@ func testMarkAllAsReadByChannels(t *testing.T, ss store.Store) {
 		assertThreadReplyCount(t, userBID, 0)
 	})
 }
+
+func testThreadStoreRecomputeReplyCounts(t *testing.T, ss store.Store, s SqlStore) {
+	channelID := model.NewId()
+
+	saveThread := func(replies int) (*model.Post, []*model.Post) {
+		root, err := ss.Post().Save(&model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Message:   NewTestId(),
+		})
+		require.NoError(t, err)
+
+		var posts []*model.Post
+		for i := 0; i < replies; i++ {
+			posts = append(posts, &model.Post{
+				ChannelId: channelID,
+				UserId:    model.NewId(),
+				RootId:    root.Id,
+				Message:   NewTestId(),
+				CreateAt:  root.CreateAt + int64(i+1),
+			})
+		}
+		posts, _, err = ss.Post().SaveMultiple(posts)
+		require.NoError(t, err)
+
+		return root, posts
+	}
+
+	// recompute runs through every thread in small batches and returns the number of repaired threads.
+	recompute := func() int64 {
+		var total int64
+		cursor := ""
+		for {
+			next, repaired, err := ss.Thread().RecomputeReplyCounts(cursor, 2)
+			require.NoError(t, err)
+			total += repaired
+			if next == "" {
+				return total
+			}
+			require.Greater(t, next, cursor)
+			cursor = next
+		}
+	}
+
+	requireThread := func(rootID string, replyCount, lastReplyAt int64) {
+		t.Helper()
+		thread, err := ss.Thread().Get(rootID)
+		require.NoError(t, err)
+		require.Equal(t, replyCount, thread.ReplyCount)
+		require.Equal(t, lastReplyAt, thread.LastReplyAt)
+	}
+
+	corruptedRoot, corruptedReplies := saveThread(3)
+	bulkDeletedRoot, bulkDeletedReplies := saveThread(2)
+	untouchedRoot, untouchedReplies := saveThread(1)
+
+	// Repair whatever other tests left behind so that only the corruption below is counted.
+	recompute()
+
+	_, err := s.GetMasterX().Exec("UPDATE Threads SET ReplyCount = 10, LastReplyAt = 1 WHERE PostId = ?", corruptedRoot.Id)
+	require.NoError(t, err)
+	// Deleting replies directly, as bulk deletes do, doesn't update their thread.
+	_, err = s.GetMasterX().Exec("UPDATE Posts SET DeleteAt = 1 WHERE RootId = ?", bulkDeletedRoot.Id)
+	require.NoError(t, err)
+	requireThread(bulkDeletedRoot.Id, 2, bulkDeletedReplies[1].CreateAt)
+
+	require.Equal(t, int64(2), recompute())
+
+	requireThread(corruptedRoot.Id, 3, corruptedReplies[2].CreateAt)
+	requireThread(bulkDeletedRoot.Id, 0, 0)
+	requireThread(untouchedRoot.Id, 1, untouchedReplies[0].CreateAt)
+
+	t.Run("nothing left to repair", func(t *testing.T) {
+		require.Equal(t, int64(0), recompute())
+	})
+}
@@ -40,6 +40,7 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("Count", func(t *testing.T) { testCount(t, ss) })
 	t.Run("AnalyticsActiveCount", func(t *testing.T) { testUserStoreAnalyticsActiveCount(t, ss, s) })
 	t.Run("AnalyticsActiveCountForPeriod", func(t *testing.T) { testUserStoreAnalyticsActiveCountForPeriod(t, ss, s) })
+	t.Run("AnalyticsActiveCountByDay", func(t *testing.T) { testUserStoreAnalyticsActiveCountByDay(t, ss, s) })
 	t.Run("AnalyticsGetInactiveUsersCount", func(t *testing.T) { testUserStoreAnalyticsGetInactiveUsersCount(t, ss) })
 	t.Run("AnalyticsGetSystemAdminCount", func(t *testing.T) { testUserStoreAnalyticsGetSystemAdminCount(t, ss) })
 	t.Run("AnalyticsGetGuestCount", func(t *testing.T) { testUserStoreAnalyticsGetGuestCount(t, ss) })
@@ -64,6 +65,7 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("GetByEmail", func(t *testing.T) { testUserStoreGetByEmail(t, ss) })
 	t.Run("GetByAuthData", func(t *testing.T) { testUserStoreGetByAuthData(t, ss) })
 	t.Run("GetByUsername", func(t *testing.T) { testUserStoreGetByUsername(t, ss) })
+	t.Run("GetByUsernames", func(t *testing.T) { testUserStoreGetByUsernames(t, ss) })
 	t.Run("GetForLogin", func(t *testing.T) { testUserStoreGetForLogin(t, ss) })
 	t.Run("UpdatePassword", func(t *testing.T) { testUserStoreUpdatePassword(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testUserStoreDelete(t, ss) })
@@ -81,6 +83,7 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("SearchWithoutTeam", func(t *testing.T) { testUserStoreSearchWithoutTeam(t, ss) })
 	t.Run("SearchInGroup", func(t *testing.T) { testUserStoreSearchInGroup(t, ss) })
 	t.Run("SearchNotInGroup", func(t *testing.T) { testUserStoreSearchNotInGroup(t, ss) })
+	t.Run("SearchByAttribute", func(t *testing.T) { testUserStoreSearchByAttribute(t, ss) })
 	t.Run("GetProfilesNotInTeam", func(t *testing.T) { testUserStoreGetProfilesNotInTeam(t, ss) })
 	t.Run("ClearAllCustomRoleAssignments", func(t *testing.T) { testUserStoreClearAllCustomRoleAssignments(t, ss) })
 	t.Run("GetAllAfter", func(t *testing.T) { testUserStoreGetAllAfter(t, ss) })
@@ -893,6 +896,8 @@ func testUserStoreGetProfilesInChannel(t *testing.T, ss store.Store) {
 		ChannelId:   c1.Id,
 		UserId:      u2.Id,
 		NotifyProps: model.GetDefaultChannelNotifyProps(),
+		SchemeUser:  true,
+		SchemeAdmin: true,
 	})
 	require.NoError(t, nErr)
 
@@ -921,6 +926,17 @@ func testUserStoreGetProfilesInChannel(t *testing.T, ss store.Store) {
 	})
 	require.NoError(t, nErr)
 
+	t.Run("get channel admins in channel 1, offset 0, limit 100", func(t *testing.T) {
+		users, err := ss.User().GetProfilesInChannel(&model.UserGetOptions{
+			InChannelId:  c1.Id,
+			ChannelRoles: []string{model.ChannelAdminRoleId},
+			Page:         0,
+			PerPage:      100,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []*model.User{sanitized(u2)}, users)
+	})
+
 	t.Run("get all users in channel 1, offset 0, limit 100", func(t *testing.T) {
 		users, err := ss.User().GetProfilesInChannel(&model.UserGetOptions{
 			InChannelId: c1.Id,
@@ -1974,6 +1990,59 @@ func testUserStoreGetByAuthData(t *testing.T, ss store.Store) {
 	})
 }
 
+func testUserStoreGetByUsernames(t *testing.T, ss store.Store) {
+	u1, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u1" + model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(u1.Id)) }()
+
+	u2, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u2" + model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(u2.Id)) }()
+
+	t.Run("all found", func(t *testing.T) {
+		users, missing, err := ss.User().GetByUsernames([]string{u1.Username, u2.Username})
+		require.NoError(t, err)
+		assert.Equal(t, []*model.User{u1, u2}, users)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("mixed case", func(t *testing.T) {
+		users, missing, err := ss.User().GetByUsernames([]string{strings.ToUpper(u1.Username), u2.Username})
+		require.NoError(t, err)
+		assert.Equal(t, []*model.User{u1, u2}, users)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		users, missing, err := ss.User().GetByUsernames([]string{u1.Username, strings.ToUpper(u1.Username)})
+		require.NoError(t, err)
+		assert.Equal(t, []*model.User{u1}, users)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("missing usernames", func(t *testing.T) {
+		unknown := "Unknown" + model.NewId()
+
+		users, missing, err := ss.User().GetByUsernames([]string{u1.Username, unknown})
+		require.NoError(t, err)
+		assert.Equal(t, []*model.User{u1}, users)
+		assert.Equal(t, []string{unknown}, missing)
+	})
+
+	t.Run("no usernames", func(t *testing.T) {
+		users, missing, err := ss.User().GetByUsernames([]string{})
+		require.NoError(t, err)
+		assert.Empty(t, users)
+		assert.Empty(t, missing)
+	})
+}
+
 func testUserStoreGetByUsername(t *testing.T, ss store.Store) {
 	teamId := model.NewId()
 
@@ -3634,6 +3703,70 @@ func testUserStoreSearchInGroup(t *testing.T, ss store.Store) {
 	}
 }
 
+func testUserStoreSearchByAttribute(t *testing.T, ss store.Store) {
+	department := "engineering-" + model.NewId()
+
+	newUser := func(username string, props model.StringMap) *model.User {
+		user, err := ss.User().Save(&model.User{
+			Username: username + model.NewId(),
+			Email:    MakeEmail(),
+			Props:    props,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, ss.User().PermanentDelete(user.Id)) })
+		return user
+	}
+
+	u1 := newUser("alice", model.StringMap{"department": department, "location": "remote"})
+	u2 := newUser("bob", model.StringMap{"department": department})
+
+	// None of these are returned.
+	newUser("carol", model.StringMap{"department": department + "-ops"})
+	newUser("dave", model.StringMap{"team": department})
+	newUser("erin", nil)
+	deleted, err := ss.User().Save(&model.User{
+		Username: "frank" + model.NewId(),
+		Email:    MakeEmail(),
+		Props:    model.StringMap{"department": department},
+		DeleteAt: model.GetMillis(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(deleted.Id)) }()
+
+	userIDs := func(users []*model.User) []string {
+		ids := make([]string, 0, len(users))
+		for _, user := range users {
+			ids = append(ids, user.Id)
+		}
+		return ids
+	}
+
+	t.Run("exact value of the attribute", func(t *testing.T) {
+		users, err := ss.User().SearchByAttribute("department", department, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{u1.Id, u2.Id}, userIDs(users))
+		assert.Empty(t, users[0].Password)
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		users, err := ss.User().SearchByAttribute("department", department, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{u2.Id}, userIDs(users))
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		users, err := ss.User().SearchByAttribute("department", "sales-"+model.NewId(), 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, users)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := ss.User().SearchByAttribute("", department, 0, 10)
+		var invErr *store.ErrInvalidInput
+		assert.ErrorAs(t, err, &invErr)
+	})
+}
+
 func testUserStoreSearchNotInGroup(t *testing.T, ss store.Store) {
 	u1 := &model.User{
 		Username:  "jimbo1" + model.NewId(),
@@ -4137,6 +4270,77 @@ func testUserStoreAnalyticsActiveCount(t *testing.T, ss store.Store, s SqlStore)
 	assert.Equal(t, int64(4), count)
 }
 
+func testUserStoreAnalyticsActiveCountByDay(t *testing.T, ss store.Store, s SqlStore) {
+	cleanupStatusStore(t, s)
+
+	users := make([]*model.User, 3)
+	for i := range users {
+		user, err := ss.User().Save(&model.User{
+			Email:    MakeEmail(),
+			Username: "u" + model.NewId(),
+		})
+		require.NoError(t, err)
+		defer func() { require.NoError(t, ss.User().PermanentDelete(user.Id)) }()
+		users[i] = user
+	}
+
+	bot := users[2]
+	_, err := ss.Bot().Save(&model.Bot{
+		UserId:   bot.Id,
+		Username: bot.Username,
+		OwnerId:  users[0].Id,
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.Bot().PermanentDelete(bot.Id)) }()
+
+	// Use days long past so that activity from other tests doesn't count towards them. The times are
+	// at noon so that the days don't depend on the time zone of the database.
+	firstDay := time.Date(2001, time.January, 10, 12, 0, 0, 0, time.UTC)
+	secondDay := firstDay.AddDate(0, 0, 1)
+	earlierDay := firstDay.AddDate(0, 0, -10)
+	millis := func(day time.Time) int64 { return day.UnixNano() / int64(time.Millisecond) }
+
+	saveSession := func(userID string, lastActivityAt int64) {
+		session, err := ss.Session().Save(&model.Session{UserId: userID})
+		require.NoError(t, err)
+		require.NoError(t, ss.Session().UpdateLastActivityAt(session.Id, lastActivityAt))
+		t.Cleanup(func() { ss.Session().Remove(session.Id) })
+	}
+
+	// users[0] was active on both days, users[1] only on the first through both a session and their
+	// status, and the bot doesn't count. Activity before the given time is left out.
+	saveSession(users[0].Id, millis(firstDay))
+	saveSession(users[0].Id, millis(secondDay))
+	saveSession(users[1].Id, millis(firstDay))
+	saveSession(bot.Id, millis(firstDay))
+	require.NoError(t, ss.Status().SaveOrUpdate(&model.Status{UserId: users[1].Id, Status: model.StatusOffline, LastActivityAt: millis(firstDay) + 1000}))
+	require.NoError(t, ss.Status().SaveOrUpdate(&model.Status{UserId: users[0].Id, Status: model.StatusOffline, LastActivityAt: millis(earlierDay)}))
+
+	countsByDay := func(since int64) map[string]float64 {
+		rows, err := ss.User().AnalyticsActiveCountByDay(since)
+		require.NoError(t, err)
+
+		counts := map[string]float64{}
+		for _, row := range rows {
+			for _, day := range []time.Time{earlierDay, firstDay, secondDay} {
+				if strings.HasPrefix(row.Name, day.Format("2006-01-02")) {
+					counts[day.Format("2006-01-02")] = row.Value
+				}
+			}
+		}
+		return counts
+	}
+
+	assert.Equal(t, map[string]float64{
+		"2001-01-10": 2,
+		"2001-01-11": 1,
+	}, countsByDay(millis(firstDay)-DayMilliseconds))
+
+	assert.Equal(t, map[string]float64{
+		"2001-01-11": 1,
+	}, countsByDay(millis(secondDay)-1000))
+}
+
 func testUserStoreAnalyticsActiveCountForPeriod(t *testing.T, ss store.Store, s SqlStore) {
 
 	cleanupStatusStore(t, s)
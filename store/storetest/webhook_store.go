@@ -564,9 +564,16 @@ func testWebhookStoreUpdateOutgoing(t *testing.T, ss store.Store) {
 
 	o1.Token = model.NewId()
 	o1.Username = "another-test-user-name"
+	o1.TriggerReactions = []string{"eyes"}
+	o1.TriggerOnReactionRemoved = true
 
 	_, err := ss.Webhook().UpdateOutgoing(o1)
 	require.NoError(t, err)
+
+	webhook, err := ss.Webhook().GetOutgoing(o1.Id)
+	require.NoError(t, err)
+	require.Equal(t, model.StringArray{"eyes"}, webhook.TriggerReactions)
+	require.True(t, webhook.TriggerOnReactionRemoved)
 }
 
 func testWebhookStoreCountIncoming(t *testing.T, ss store.Store) {
@@ -17,44 +17,49 @@ import (
 
 type TimerLayer struct {
 	store.Store
-	Metrics                   einterfaces.MetricsInterface
-	AuditStore                store.AuditStore
-	BotStore                  store.BotStore
-	ChannelStore              store.ChannelStore
-	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
-	ClusterDiscoveryStore     store.ClusterDiscoveryStore
-	CommandStore              store.CommandStore
-	CommandWebhookStore       store.CommandWebhookStore
-	ComplianceStore           store.ComplianceStore
-	EmojiStore                store.EmojiStore
-	FileInfoStore             store.FileInfoStore
-	GroupStore                store.GroupStore
-	JobStore                  store.JobStore
-	LicenseStore              store.LicenseStore
-	LinkMetadataStore         store.LinkMetadataStore
-	OAuthStore                store.OAuthStore
-	PluginStore               store.PluginStore
-	PostStore                 store.PostStore
-	PreferenceStore           store.PreferenceStore
-	ProductNoticesStore       store.ProductNoticesStore
-	ReactionStore             store.ReactionStore
-	RemoteClusterStore        store.RemoteClusterStore
-	RetentionPolicyStore      store.RetentionPolicyStore
-	RoleStore                 store.RoleStore
-	SchemeStore               store.SchemeStore
-	SessionStore              store.SessionStore
-	SharedChannelStore        store.SharedChannelStore
-	StatusStore               store.StatusStore
-	SystemStore               store.SystemStore
-	TeamStore                 store.TeamStore
-	TermsOfServiceStore       store.TermsOfServiceStore
-	ThreadStore               store.ThreadStore
-	TokenStore                store.TokenStore
-	UploadSessionStore        store.UploadSessionStore
-	UserStore                 store.UserStore
-	UserAccessTokenStore      store.UserAccessTokenStore
-	UserTermsOfServiceStore   store.UserTermsOfServiceStore
-	WebhookStore              store.WebhookStore
+	Metrics                       einterfaces.MetricsInterface
+	AuditStore                    store.AuditStore
+	BotStore                      store.BotStore
+	ChannelStore                  store.ChannelStore
+	ChannelMemberHistoryStore     store.ChannelMemberHistoryStore
+	ChannelTemplateStore          store.ChannelTemplateStore
+	ClusterDiscoveryStore         store.ClusterDiscoveryStore
+	CommandStore                  store.CommandStore
+	CommandWebhookStore           store.CommandWebhookStore
+	ComplianceStore               store.ComplianceStore
+	EmojiStore                    store.EmojiStore
+	FileInfoStore                 store.FileInfoStore
+	GroupStore                    store.GroupStore
+	GuestChannelInviteStore       store.GuestChannelInviteStore
+	JobStore                      store.JobStore
+	LicenseStore                  store.LicenseStore
+	LinkMetadataStore             store.LinkMetadataStore
+	OAuthStore                    store.OAuthStore
+	PluginStore                   store.PluginStore
+	PostStore                     store.PostStore
+	PostReminderStore             store.PostReminderStore
+	PostReportStore               store.PostReportStore
+	PreferenceStore               store.PreferenceStore
+	ProductNoticesStore           store.ProductNoticesStore
+	ReactionStore                 store.ReactionStore
+	RemoteClusterStore            store.RemoteClusterStore
+	RetentionPolicyStore          store.RetentionPolicyStore
+	RoleStore                     store.RoleStore
+	ScheduledChannelDeletionStore store.ScheduledChannelDeletionStore
+	SchemeStore                   store.SchemeStore
+	SessionStore                  store.SessionStore
+	SharedChannelStore            store.SharedChannelStore
+	StatusStore                   store.StatusStore
+	SystemStore                   store.SystemStore
+	TeamStore                     store.TeamStore
+	TermsOfServiceStore           store.TermsOfServiceStore
+	ThreadStore                   store.ThreadStore
+	TokenStore                    store.TokenStore
+	UploadSessionStore            store.UploadSessionStore
+	UserStore                     store.UserStore
+	UserAccessTokenStore          store.UserAccessTokenStore
+	UserTermsOfServiceStore       store.UserTermsOfServiceStore
+	WebhookStore                  store.WebhookStore
 }
 
 func (s *TimerLayer) Audit() store.AuditStore {
@@ -73,6 +78,10 @@ func (s *TimerLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return s.ChannelMemberHistoryStore
 }
 
+func (s *TimerLayer) ChannelTemplate() store.ChannelTemplateStore {
+	return s.ChannelTemplateStore
+}
+
 func (s *TimerLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
@@ -101,6 +110,10 @@ func (s *TimerLayer) Group() store.GroupStore {
 	return s.GroupStore
 }
 
+func (s *TimerLayer) GuestChannelInvite() store.GuestChannelInviteStore {
+	return s.GuestChannelInviteStore
+}
+
 func (s *TimerLayer) Job() store.JobStore {
 	return s.JobStore
 }
@@ -125,6 +138,14 @@ func (s *TimerLayer) Post() store.PostStore {
 	return s.PostStore
 }
 
+func (s *TimerLayer) PostReminder() store.PostReminderStore {
+	return s.PostReminderStore
+}
+
+func (s *TimerLayer) PostReport() store.PostReportStore {
+	return s.PostReportStore
+}
+
 func (s *TimerLayer) Preference() store.PreferenceStore {
 	return s.PreferenceStore
 }
@@ -149,6 +170,10 @@ func (s *TimerLayer) Role() store.RoleStore {
 	return s.RoleStore
 }
 
+func (s *TimerLayer) ScheduledChannelDeletion() store.ScheduledChannelDeletionStore {
+	return s.ScheduledChannelDeletionStore
+}
+
 func (s *TimerLayer) Scheme() store.SchemeStore {
 	return s.SchemeStore
 }
@@ -225,6 +250,11 @@ type TimerLayerChannelMemberHistoryStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerChannelTemplateStore struct {
+	store.ChannelTemplateStore
+	Root *TimerLayer
+}
+
 type TimerLayerClusterDiscoveryStore struct {
 	store.ClusterDiscoveryStore
 	Root *TimerLayer
@@ -260,6 +290,11 @@ type TimerLayerGroupStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerGuestChannelInviteStore struct {
+	store.GuestChannelInviteStore
+	Root *TimerLayer
+}
+
 type TimerLayerJobStore struct {
 	store.JobStore
 	Root *TimerLayer
@@ -290,6 +325,16 @@ type TimerLayerPostStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPostReminderStore struct {
+	store.PostReminderStore
+	Root *TimerLayer
+}
+
+type TimerLayerPostReportStore struct {
+	store.PostReportStore
+	Root *TimerLayer
+}
+
 type TimerLayerPreferenceStore struct {
 	store.PreferenceStore
 	Root *TimerLayer
@@ -320,6 +365,11 @@ type TimerLayerRoleStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerScheduledChannelDeletionStore struct {
+	store.ScheduledChannelDeletionStore
+	Root *TimerLayer
+}
+
 type TimerLayerSchemeStore struct {
 	store.SchemeStore
 	Root *TimerLayer
@@ -949,6 +999,22 @@ func (s *TimerLayerChannelStore) GetByNames(team_id string, names []string, allo
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetByNamesIncludeDeleted(team_id string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetByNamesIncludeDeleted(team_id, names, allowFromCache)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByNamesIncludeDeleted", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetChannelCounts(teamID string, userID string) (*model.ChannelCounts, error) {
 	start := time.Now()
 
@@ -997,6 +1063,22 @@ func (s *TimerLayerChannelStore) GetChannelMembersTimezones(channelID string) ([
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetChannelMembersWithTeamData(channelID string, offset int, limit int) ([]*model.ChannelMemberWithTeamMember, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetChannelMembersWithTeamData(channelID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelMembersWithTeamData", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetChannelUnread(channelID string, userID string) (*model.ChannelUnread, error) {
 	start := time.Now()
 
@@ -1013,6 +1095,22 @@ func (s *TimerLayerChannelStore) GetChannelUnread(channelID string, userID strin
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetChannelUnreadCountsForUser(userID string, teamID string) ([]*model.ChannelUnreadCount, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetChannelUnreadCountsForUser(userID, teamID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelUnreadCountsForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetChannels(teamID string, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, error) {
 	start := time.Now()
 
@@ -1093,6 +1191,22 @@ func (s *TimerLayerChannelStore) GetChannelsByUser(userID string, includeDeleted
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetChannelsDeletedBefore(deletedBefore int64, afterChannelID string, limit int) (model.ChannelList, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetChannelsDeletedBefore(deletedBefore, afterChannelID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsDeletedBefore", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetChannelsWithCursor(teamId string, userId string, opts *model.ChannelSearchOpts, afterChannelID string) (model.ChannelList, error) {
 	start := time.Now()
 
@@ -1125,6 +1239,22 @@ func (s *TimerLayerChannelStore) GetChannelsWithTeamDataByIds(channelIds []strin
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetCreationInfo(teamID string, offset int, limit int) ([]*model.ChannelCreationInfo, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetCreationInfo(teamID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetCreationInfo", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetDeleted(team_id string, offset int, limit int, userID string) (model.ChannelList, error) {
 	start := time.Now()
 
@@ -1157,6 +1287,38 @@ func (s *TimerLayerChannelStore) GetDeletedByName(team_id string, name string) (
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetDirectChannelsForUsers(userA string, userB string) ([]*model.Channel, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetDirectChannelsForUsers(userA, userB)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDirectChannelsForUsers", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelStore) GetDuplicateDirectChannels() ([]*model.DuplicateDirectChannels, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetDuplicateDirectChannels()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDuplicateDirectChannels", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetFileCount(channelID string) (int64, error) {
 	start := time.Now()
 
@@ -1285,6 +1447,22 @@ func (s *TimerLayerChannelStore) GetMemberCountsByGroup(ctx context.Context, cha
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetMemberCountsByType(channelID string) (*model.ChannelMemberCountsByType, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetMemberCountsByType(channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCountsByType", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetMemberForPost(postID string, userID string) (*model.ChannelMember, error) {
 	start := time.Now()
 
@@ -1413,6 +1591,38 @@ func (s *TimerLayerChannelStore) GetMembersInfoByChannelIds(channelIDs []string)
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetMembersNotInTeam(afterChannelID string, afterUserID string, limit int) (model.ChannelMembers, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetMembersNotInTeam(afterChannelID, afterUserID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersNotInTeam", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelStore) GetMembersUpdatedSince(channelID string, since int64, offset int, limit int) (model.ChannelMembers, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetMembersUpdatedSince(channelID, since, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersUpdatedSince", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetMoreChannels(teamID string, userID string, offset int, limit int) (model.ChannelList, error) {
 	start := time.Now()
 
@@ -1557,6 +1767,22 @@ func (s *TimerLayerChannelStore) GetSidebarCategoryOrder(userID string, teamID s
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetTeamChannelCountForUser(teamID string, userID string) (int64, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetTeamChannelCountForUser(teamID, userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetTeamChannelCountForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetTeamChannels(teamID string) (model.ChannelList, error) {
 	start := time.Now()
 
@@ -1790,6 +2016,22 @@ func (s *TimerLayerChannelStore) IsUserInChannelUseCache(userID string, channelI
 	return result
 }
 
+func (s *TimerLayerChannelStore) MergeDirectChannels(canonicalID string, duplicateIDs []string, deleteAt int64) ([]string, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.MergeDirectChannels(canonicalID, duplicateIDs, deleteAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.MergeDirectChannels", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) MigrateChannelMembers(fromChannelID string, fromUserID string) (map[string]string, error) {
 	start := time.Now()
 
@@ -2062,6 +2304,22 @@ func (s *TimerLayerChannelStore) SearchArchivedInTeam(teamID string, term string
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) SearchByPurpose(teamID string, term string, page int, perPage int) (model.ChannelList, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.SearchByPurpose(teamID, term, page, perPage)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchByPurpose", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) SearchForUserInTeam(userID string, teamID string, term string, includeDeleted bool) (model.ChannelList, error) {
 	start := time.Now()
 
@@ -2110,6 +2368,22 @@ func (s *TimerLayerChannelStore) SearchInTeam(teamID string, term string, includ
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) SearchMembersForUser(userID string, term string, includePrivate bool, page int, perPage int) (model.ChannelMembersWithTeamData, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.SearchMembersForUser(userID, term, includePrivate, page, perPage)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchMembersForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) SearchMore(userID string, teamID string, term string) (model.ChannelList, error) {
 	start := time.Now()
 
@@ -2286,6 +2560,22 @@ func (s *TimerLayerChannelStore) UpdateSidebarCategories(userID string, teamID s
 	return result, resultVar1, err
 }
 
+func (s *TimerLayerChannelStore) UpdateSidebarCategoriesAndOrder(userID string, teamID string, categories []*model.SidebarCategoryWithChannels, categoryOrder []string) ([]*model.SidebarCategoryWithChannels, []*model.SidebarCategoryWithChannels, error) {
+	start := time.Now()
+
+	result, resultVar1, err := s.ChannelStore.UpdateSidebarCategoriesAndOrder(userID, teamID, categories, categoryOrder)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateSidebarCategoriesAndOrder", success, elapsed)
+	}
+	return result, resultVar1, err
+}
+
 func (s *TimerLayerChannelStore) UpdateSidebarCategoryOrder(userID string, teamID string, categoryOrder []string) error {
 	start := time.Now()
 
@@ -2366,6 +2656,22 @@ func (s *TimerLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int
 	return result, err
 }
 
+func (s *TimerLayerChannelMemberHistoryStore) GetChannelMemberHistory(channelID string, from int64, to int64) ([]*model.ChannelMemberHistory, error) {
+	start := time.Now()
+
+	result, err := s.ChannelMemberHistoryStore.GetChannelMemberHistory(channelID, from, to)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetChannelMemberHistory", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 	start := time.Now()
 
@@ -2398,6 +2704,22 @@ func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime
 	return result, err
 }
 
+func (s *TimerLayerChannelMemberHistoryStore) GetUsersLeftSince(channelID string, since int64, offset int, limit int) ([]*model.ChannelMemberHistory, error) {
+	start := time.Now()
+
+	result, err := s.ChannelMemberHistoryStore.GetUsersLeftSince(channelID, since, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetUsersLeftSince", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
 	start := time.Now()
 
@@ -2462,10 +2784,10 @@ func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPo
 	return result, resultVar1, err
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
+func (s *TimerLayerChannelTemplateStore) Delete(id string) error {
 	start := time.Now()
 
-	err := s.ClusterDiscoveryStore.Cleanup()
+	err := s.ChannelTemplateStore.Delete(id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -2473,15 +2795,15 @@ func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Cleanup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTemplateStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+func (s *TimerLayerChannelTemplateStore) Get(id string) (*model.ChannelTemplate, error) {
 	start := time.Now()
 
-	result, err := s.ClusterDiscoveryStore.Delete(discovery)
+	result, err := s.ChannelTemplateStore.Get(id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -2489,15 +2811,15 @@ func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscove
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTemplateStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+func (s *TimerLayerChannelTemplateStore) GetForTeam(teamID string) ([]*model.ChannelTemplate, error) {
 	start := time.Now()
 
-	result, err := s.ClusterDiscoveryStore.Exists(discovery)
+	result, err := s.ChannelTemplateStore.GetForTeam(teamID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -2505,12 +2827,76 @@ func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscove
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Exists", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTemplateStore.GetForTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+func (s *TimerLayerChannelTemplateStore) Save(template *model.ChannelTemplate) (*model.ChannelTemplate, error) {
+	start := time.Now()
+
+	result, err := s.ChannelTemplateStore.Save(template)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTemplateStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
+	start := time.Now()
+
+	err := s.ClusterDiscoveryStore.Cleanup()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Cleanup", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+	start := time.Now()
+
+	result, err := s.ClusterDiscoveryStore.Delete(discovery)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Delete", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+	start := time.Now()
+
+	result, err := s.ClusterDiscoveryStore.Exists(discovery)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Exists", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
 	start := time.Now()
 
 	result, err := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
@@ -2941,6 +3327,22 @@ func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji
 	return result, err
 }
 
+func (s *TimerLayerEmojiStore) GetUnusedCustomEmoji(messagesSince int64, limit int) (*model.UnusedEmojiList, error) {
+	start := time.Now()
+
+	result, err := s.EmojiStore.GetUnusedCustomEmoji(messagesSince, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetUnusedCustomEmoji", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	start := time.Now()
 
@@ -3116,6 +3518,22 @@ func (s *TimerLayerFileInfoStore) GetForPost(postID string, readFromMaster bool,
 	return result, err
 }
 
+func (s *TimerLayerFileInfoStore) GetForPosts(postIds []string) (map[string][]*model.FileInfo, error) {
+	start := time.Now()
+
+	result, err := s.FileInfoStore.GetForPosts(postIds)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForPosts", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
 	start := time.Now()
 
@@ -3291,6 +3709,22 @@ func (s *TimerLayerFileInfoStore) SetContent(fileID string, content string) erro
 	return err
 }
 
+func (s *TimerLayerFileInfoStore) SetQuarantined(fileID string, quarantined bool) error {
+	start := time.Now()
+
+	err := s.FileInfoStore.SetQuarantined(fileID, quarantined)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetQuarantined", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
 	start := time.Now()
 
@@ -3691,6 +4125,22 @@ func (s *TimerLayerGroupStore) GetByUser(userID string) ([]*model.Group, error)
 	return result, err
 }
 
+func (s *TimerLayerGroupStore) GetChannelMemberCountsByGroup(channelID string) ([]*model.ChannelMemberCountByGroup, error) {
+	start := time.Now()
+
+	result, err := s.GroupStore.GetChannelMemberCountsByGroup(channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetChannelMemberCountsByGroup", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
 	start := time.Now()
 
@@ -4123,6 +4573,70 @@ func (s *TimerLayerGroupStore) UpsertMembers(groupID string, userIDs []string) (
 	return result, err
 }
 
+func (s *TimerLayerGuestChannelInviteStore) Cleanup(expiryTime int64) error {
+	start := time.Now()
+
+	err := s.GuestChannelInviteStore.Cleanup(expiryTime)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GuestChannelInviteStore.Cleanup", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerGuestChannelInviteStore) Delete(userID string, channelID string) error {
+	start := time.Now()
+
+	err := s.GuestChannelInviteStore.Delete(userID, channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GuestChannelInviteStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerGuestChannelInviteStore) Exists(userID string, channelID string) (bool, error) {
+	start := time.Now()
+
+	result, err := s.GuestChannelInviteStore.Exists(userID, channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GuestChannelInviteStore.Exists", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerGuestChannelInviteStore) Save(invite *model.GuestChannelInvite) error {
+	start := time.Now()
+
+	err := s.GuestChannelInviteStore.Save(invite)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GuestChannelInviteStore.Save", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
 	start := time.Now()
 
@@ -4907,6 +5421,22 @@ func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, valu
 	return result, err
 }
 
+func (s *TimerLayerPostStore) AnalyticsBotPostCount(teamID string, since int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.AnalyticsBotPostCount(teamID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsBotPostCount", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
 	start := time.Now()
 
@@ -5034,6 +5564,22 @@ func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afte
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetEarliestPostTimeForChannels(channelIDs []string, excludeSystemPosts bool) (map[string]int64, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetEarliestPostTimeForChannels(channelIDs, excludeSystemPosts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEarliestPostTimeForChannels", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
 	start := time.Now()
 
@@ -5098,6 +5644,38 @@ func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID strin
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetFlaggedPostsForUserPaged(userID string, options model.GetFlaggedPostsOptions) (*model.PostList, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetFlaggedPostsForUserPaged(userID, options)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForUserPaged", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetLastPostCreateAtForUser(channelID string, userID string) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetLastPostCreateAtForUser(channelID, userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetLastPostCreateAtForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) GetLastPostRowCreateAt() (int64, error) {
 	start := time.Now()
 
@@ -5258,6 +5836,22 @@ func (s *TimerLayerPostStore) GetPostsAfter(options model.GetPostsOptions, sanit
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetPostsAround(postID string, before int, after int) (*model.PostList, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetPostsAround(postID, before, after)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsAround", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
 	start := time.Now()
 
@@ -5290,6 +5884,38 @@ func (s *TimerLayerPostStore) GetPostsBefore(options model.GetPostsOptions, sani
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetPostsBetween(channelID string, from int64, to int64, page int, perPage int) ([]*model.Post, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetPostsBetween(channelID, from, to, page, perPage)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBetween", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetPostsByHashtag(teamID string, hashtag string, channelIDs []string, page int, perPage int) (*model.PostList, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetPostsByHashtag(teamID, hashtag, channelIDs, page, perPage)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsByHashtag", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 	start := time.Now()
 
@@ -5354,6 +5980,38 @@ func (s *TimerLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceFo
 	return result, resultVar1, err
 }
 
+func (s *TimerLayerPostStore) GetPostsUpdatedSince(channelID string, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
+	start := time.Now()
+
+	result, resultVar1, err := s.PostStore.GetPostsUpdatedSince(channelID, cursor, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsUpdatedSince", success, elapsed)
+	}
+	return result, resultVar1, err
+}
+
+func (s *TimerLayerPostStore) GetRecentPostsForUser(userID string, cursor model.GetRecentPostsForUserCursor, limit int) ([]*model.Post, model.GetRecentPostsForUserCursor, error) {
+	start := time.Now()
+
+	result, resultVar1, err := s.PostStore.GetRecentPostsForUser(userID, cursor, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRecentPostsForUser", success, elapsed)
+	}
+	return result, resultVar1, err
+}
+
 func (s *TimerLayerPostStore) GetRecentSearchesForUser(userID string) ([]*model.SearchParams, error) {
 	start := time.Now()
 
@@ -5402,6 +6060,22 @@ func (s *TimerLayerPostStore) GetSingle(id string, inclDeleted bool) (*model.Pos
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetThreadParticipants(rootID string, limit int) ([]string, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetThreadParticipants(rootID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetThreadParticipants", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
 	start := time.Now()
 
@@ -5513,6 +6187,22 @@ func (s *TimerLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now int64
 	return result, resultVar1, err
 }
 
+func (s *TimerLayerPostStore) PermanentDeleteBatchReturningIds(endTime int64, limit int) ([]string, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.PermanentDeleteBatchReturningIds(endTime, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteBatchReturningIds", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) PermanentDeleteByChannel(channelID string) error {
 	start := time.Now()
 
@@ -5545,6 +6235,22 @@ func (s *TimerLayerPostStore) PermanentDeleteByUser(userID string) error {
 	return err
 }
 
+func (s *TimerLayerPostStore) PermanentDeleteEditHistoryBatch(endTime int64, limit int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.PermanentDeleteEditHistoryBatch(endTime, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteEditHistoryBatch", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, error) {
 	start := time.Now()
 
@@ -5625,6 +6331,150 @@ func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (
 	return result, err
 }
 
+func (s *TimerLayerPostReminderStore) Delete(userID string, postID string) error {
+	start := time.Now()
+
+	err := s.PostReminderStore.Delete(userID, postID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReminderStore) GetDue(before int64, limit int) ([]*model.PostReminder, error) {
+	start := time.Now()
+
+	result, err := s.PostReminderStore.GetDue(before, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.GetDue", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReminderStore) GetForUser(userID string) ([]*model.PostReminder, error) {
+	start := time.Now()
+
+	result, err := s.PostReminderStore.GetForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReminderStore) Save(reminder *model.PostReminder) (*model.PostReminder, error) {
+	start := time.Now()
+
+	result, err := s.PostReminderStore.Save(reminder)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReminderStore) UpdateFailedAttempts(userID string, postID string, attempts int) error {
+	start := time.Now()
+
+	err := s.PostReminderStore.UpdateFailedAttempts(userID, postID, attempts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReminderStore.UpdateFailedAttempts", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReportStore) Get(id string) (*model.PostReport, error) {
+	start := time.Now()
+
+	result, err := s.PostReportStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReportStore) GetAll(options model.PostReportGetOptions) ([]*model.PostReport, error) {
+	start := time.Now()
+
+	result, err := s.PostReportStore.GetAll(options)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReportStore) Save(report *model.PostReport) (*model.PostReport, error) {
+	start := time.Now()
+
+	result, err := s.PostReportStore.Save(report)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReportStore) UpdateStatus(id string, status string) (*model.PostReport, error) {
+	start := time.Now()
+
+	result, err := s.PostReportStore.UpdateStatus(id, status)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.UpdateStatus", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
 	start := time.Now()
 
@@ -5721,10 +6571,26 @@ func (s *TimerLayerPreferenceStore) Get(userID string, category string, name str
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+func (s *TimerLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+	start := time.Now()
+
+	result, err := s.PreferenceStore.GetAll(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
 	start := time.Now()
 
-	result, err := s.PreferenceStore.GetAll(userID)
+	result, err := s.PreferenceStore.GetCategory(userID, category)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5732,15 +6598,15 @@ func (s *TimerLayerPreferenceStore) GetAll(userID string) (model.Preferences, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+func (s *TimerLayerPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, error) {
 	start := time.Now()
 
-	result, err := s.PreferenceStore.GetCategory(userID, category)
+	result, err := s.PreferenceStore.GetCategoryAndName(category, name)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5748,7 +6614,7 @@ func (s *TimerLayerPreferenceStore) GetCategory(userID string, category string)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategoryAndName", success, elapsed)
 	}
 	return result, err
 }
@@ -5785,6 +6651,22 @@ func (s *TimerLayerPreferenceStore) Save(preferences model.Preferences) error {
 	return err
 }
 
+func (s *TimerLayerPreferenceStore) SaveIfNotExists(preference *model.Preference) (bool, error) {
+	start := time.Now()
+
+	result, err := s.PreferenceStore.SaveIfNotExists(preference)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.SaveIfNotExists", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
 	start := time.Now()
 
@@ -5913,6 +6795,22 @@ func (s *TimerLayerReactionStore) DeleteOrphanedRows(limit int) (int64, error) {
 	return result, err
 }
 
+func (s *TimerLayerReactionStore) GetDetailsForPost(postID string, offset int, limit int) ([]*model.ReactionDetails, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.GetDetailsForPost(postID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetDetailsForPost", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
 	start := time.Now()
 
@@ -5945,6 +6843,38 @@ func (s *TimerLayerReactionStore) GetForPostSince(postId string, since int64, ex
 	return result, err
 }
 
+func (s *TimerLayerReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.GetForPosts(postIds)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPosts", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) GetOrphanedReactions(limit int) ([]*model.Reaction, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.GetOrphanedReactions(limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetOrphanedReactions", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerReactionStore) GetTopForTeamSince(teamID string, userID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
 	start := time.Now()
 
@@ -6009,6 +6939,22 @@ func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reactio
 	return result, err
 }
 
+func (s *TimerLayerReactionStore) SaveMultiple(reactions []*model.Reaction) ([]*model.Reaction, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.SaveMultiple(reactions)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.SaveMultiple", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerRemoteClusterStore) Delete(remoteClusterId string) (bool, error) {
 	start := time.Now()
 
@@ -6585,6 +7531,70 @@ func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 	return result, err
 }
 
+func (s *TimerLayerScheduledChannelDeletionStore) Delete(channelID string) error {
+	start := time.Now()
+
+	err := s.ScheduledChannelDeletionStore.Delete(channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledChannelDeletionStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerScheduledChannelDeletionStore) Get(channelID string) (*model.ScheduledChannelDeletion, error) {
+	start := time.Now()
+
+	result, err := s.ScheduledChannelDeletionStore.Get(channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledChannelDeletionStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledChannelDeletionStore) GetDue(before int64, limit int) ([]*model.ScheduledChannelDeletion, error) {
+	start := time.Now()
+
+	result, err := s.ScheduledChannelDeletionStore.GetDue(before, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledChannelDeletionStore.GetDue", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledChannelDeletionStore) Save(deletion *model.ScheduledChannelDeletion) (*model.ScheduledChannelDeletion, error) {
+	start := time.Now()
+
+	result, err := s.ScheduledChannelDeletionStore.Save(deletion)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledChannelDeletionStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSchemeStore) CountByScope(scope string) (int64, error) {
 	start := time.Now()
 
@@ -6873,6 +7883,22 @@ func (s *TimerLayerSessionStore) RemoveAllSessions() error {
 	return err
 }
 
+func (s *TimerLayerSessionStore) RemoveExpiredSessions(now int64, limit int) (int64, error) {
+	start := time.Now()
+
+	result, err := s.SessionStore.RemoveExpiredSessions(now, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.RemoveExpiredSessions", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSessionStore) Save(session *model.Session) (*model.Session, error) {
 	start := time.Now()
 
@@ -7960,6 +8986,22 @@ func (s *TimerLayerTeamStore) GetMember(ctx context.Context, teamID string, user
 	return result, err
 }
 
+func (s *TimerLayerTeamStore) GetMemberCountsByScheme() (map[string]int64, error) {
+	start := time.Now()
+
+	result, err := s.TeamStore.GetMemberCountsByScheme()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMemberCountsByScheme", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerTeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
 	start := time.Now()
 
@@ -7992,6 +9034,22 @@ func (s *TimerLayerTeamStore) GetMembersByIds(teamID string, userIds []string, r
 	return result, err
 }
 
+func (s *TimerLayerTeamStore) GetMembersByIdsInOrder(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, []string, error) {
+	start := time.Now()
+
+	result, resultVar1, err := s.TeamStore.GetMembersByIdsInOrder(teamID, userIds, restrictions)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembersByIdsInOrder", success, elapsed)
+	}
+	return result, resultVar1, err
+}
+
 func (s *TimerLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
 	start := time.Now()
 
@@ -8072,6 +9130,22 @@ func (s *TimerLayerTeamStore) GetTeamsForUserWithPagination(userID string, page
 	return result, err
 }
 
+func (s *TimerLayerTeamStore) GetTeamsWithMemberCount(offset int, limit int) ([]*model.TeamWithMemberCount, error) {
+	start := time.Now()
+
+	result, err := s.TeamStore.GetTeamsWithMemberCount(offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsWithMemberCount", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
 	start := time.Now()
 
@@ -8120,6 +9194,22 @@ func (s *TimerLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 	return result, err
 }
 
+func (s *TimerLayerTeamStore) IncrementInviteUses(teamID string, inviteID string) (bool, error) {
+	start := time.Now()
+
+	result, err := s.TeamStore.IncrementInviteUses(teamID, inviteID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.IncrementInviteUses", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
 	start := time.Now()
 
@@ -8167,6 +9257,22 @@ func (s *TimerLayerTeamStore) PermanentDelete(teamID string) error {
 	return err
 }
 
+func (s *TimerLayerTeamStore) ReleaseInviteUse(teamID string, inviteID string) error {
+	start := time.Now()
+
+	err := s.TeamStore.ReleaseInviteUse(teamID, inviteID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ReleaseInviteUse", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
 	start := time.Now()
 
@@ -8839,6 +9945,22 @@ func (s *TimerLayerThreadStore) PermanentDeleteBatchThreadMembershipsForRetentio
 	return result, resultVar1, err
 }
 
+func (s *TimerLayerThreadStore) RecomputeReplyCounts(afterPostID string, limit int) (string, int64, error) {
+	start := time.Now()
+
+	result, resultVar1, err := s.ThreadStore.RecomputeReplyCounts(afterPostID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.RecomputeReplyCounts", success, elapsed)
+	}
+	return result, resultVar1, err
+}
+
 func (s *TimerLayerThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
 	start := time.Now()
 
@@ -9046,6 +10168,22 @@ func (s *TimerLayerUserStore) AnalyticsActiveCount(timestamp int64, options mode
 	return result, err
 }
 
+func (s *TimerLayerUserStore) AnalyticsActiveCountByDay(since int64) (model.AnalyticsRows, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.AnalyticsActiveCountByDay(since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsActiveCountByDay", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
 	start := time.Now()
 
@@ -9397,6 +10535,22 @@ func (s *TimerLayerUserStore) GetByUsername(username string) (*model.User, error
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetByUsernames(usernames []string) ([]*model.User, []string, error) {
+	start := time.Now()
+
+	result, resultVar1, err := s.UserStore.GetByUsernames(usernames)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByUsernames", success, elapsed)
+	}
+	return result, resultVar1, err
+}
+
 func (s *TimerLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
 	start := time.Now()
 
@@ -9970,6 +11124,22 @@ func (s *TimerLayerUserStore) Search(teamID string, term string, options *model.
 	return result, err
 }
 
+func (s *TimerLayerUserStore) SearchByAttribute(key string, value string, page int, perPage int) ([]*model.User, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.SearchByAttribute(key, value, page, perPage)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchByAttribute", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) SearchInChannel(channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
 	start := time.Now()
 
@@ -10910,6 +12080,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &TimerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ChannelTemplateStore = &TimerLayerChannelTemplateStore{ChannelTemplateStore: childStore.ChannelTemplate(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &TimerLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CommandStore = &TimerLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
 	newStore.CommandWebhookStore = &TimerLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
@@ -10917,18 +12088,22 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.EmojiStore = &TimerLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
 	newStore.FileInfoStore = &TimerLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &TimerLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.GuestChannelInviteStore = &TimerLayerGuestChannelInviteStore{GuestChannelInviteStore: childStore.GuestChannelInvite(), Root: &newStore}
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &TimerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostReminderStore = &TimerLayerPostReminderStore{PostReminderStore: childStore.PostReminder(), Root: &newStore}
+	newStore.PostReportStore = &TimerLayerPostReportStore{PostReportStore: childStore.PostReport(), Root: &newStore}
 	newStore.PreferenceStore = &TimerLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ProductNoticesStore = &TimerLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
 	newStore.ReactionStore = &TimerLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
 	newStore.RemoteClusterStore = &TimerLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
 	newStore.RetentionPolicyStore = &TimerLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
 	newStore.RoleStore = &TimerLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.ScheduledChannelDeletionStore = &TimerLayerScheduledChannelDeletionStore{ScheduledChannelDeletionStore: childStore.ScheduledChannelDeletion(), Root: &newStore}
 	newStore.SchemeStore = &TimerLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &TimerLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.SharedChannelStore = &TimerLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
@@ -0,0 +1,35 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package wsapi
+
+import (
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func (api *API) InitChannel() {
+	api.Router.Handle("channel_focus", api.APIWebSocketHandler(api.channelFocus))
+	api.Router.Handle("channel_blur", api.APIWebSocketHandler(api.channelBlur))
+}
+
+func (api *API) channelFocus(req *model.WebSocketRequest) (map[string]interface{}, *model.AppError) {
+	var ok bool
+	var channelId string
+	if channelId, ok = req.Data["channel_id"].(string); !ok || !model.IsValidId(channelId) {
+		return nil, NewInvalidWebSocketParamError(req.Action, "channel_id")
+	}
+
+	if !api.App.SessionHasPermissionToChannel(req.Session, channelId, model.PermissionReadChannel) {
+		return nil, NewInvalidWebSocketParamError(req.Action, "channel_id")
+	}
+
+	userIds := api.App.FocusChannel(req.ConnectionID, req.Session.UserId, channelId)
+
+	return map[string]interface{}{"user_ids": userIds}, nil
+}
+
+func (api *API) channelBlur(req *model.WebSocketRequest) (map[string]interface{}, *model.AppError) {
+	api.App.BlurChannel(req.ConnectionID)
+
+	return nil, nil
+}